@@ -296,6 +296,15 @@ func processScheduleOptions(
 				continue
 			}
 			s.incArgs.UpdatesLastBackupMetric = updatesLastBackupMetric
+		case optCompactAfterIncrementals:
+			if s.incArgs == nil {
+				return errors.Newf("%s requires an incremental backup schedule", optCompactAfterIncrementals)
+			}
+			compactAfterIncrementals, err := parseCompactAfterIncrementals(v)
+			if err != nil {
+				return err
+			}
+			s.incArgs.CompactAfterIncrementals = compactAfterIncrementals
 		default:
 			return errors.Newf("unexpected schedule option: %s = %s", k, v)
 		}
@@ -432,6 +441,7 @@ func processFullBackupRecurrence(
 			s.fullArgs.UpdatesLastBackupMetric,
 			s.incStmt,
 			s.fullArgs.ChainProtectedTimestampRecords,
+			0, /* compactAfterIncrementals */
 		)
 
 		if err != nil {
@@ -637,9 +647,10 @@ func makeAlterBackupScheduleSpec(
 var alterBackupScheduleOptions = exprutil.KVOptionValidationMap{
 	// optFirstRun and optIgnoreExistingBackups excluded here, as they don't
 	// make much sense in the context of ALTER.
-	optOnExecFailure:           exprutil.KVStringOptAny,
-	optOnPreviousRunning:       exprutil.KVStringOptAny,
-	optUpdatesLastBackupMetric: exprutil.KVStringOptAny,
+	optOnExecFailure:            exprutil.KVStringOptAny,
+	optOnPreviousRunning:        exprutil.KVStringOptAny,
+	optUpdatesLastBackupMetric:  exprutil.KVStringOptAny,
+	optCompactAfterIncrementals: exprutil.KVStringOptAny,
 }
 
 func alterBackupScheduleTypeCheck(
@@ -0,0 +1,677 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/gogo/protobuf/types"
+)
+
+// Long chains of incremental backups make restores slow, since every layer of
+// the chain has to be read to restore any span. Incremental backup schedules
+// may therefore be configured, via the compact_after_incrementals schedule
+// option, to compact the chain they append to once it has grown to a given
+// number of incremental layers.
+//
+// Compaction is run by a backup job whose details have CompactChain set. It
+// does not read from the cluster's KV: instead, it merges the files of the
+// full backup and incrementals listed in IncrementalFrom, reading them as of
+// the end time of the chain just like RESTORE would, and writes the result as
+// a new full backup into its own subdirectory of the same collection, using
+// the same SST sink as the backup processors. Once the new full backup is
+// written, the LATEST file is pointed at it so that subsequent incremental
+// backups are appended to the compacted chain rather than the old one. The
+// old chain is left in place.
+//
+// At most one compaction job runs for a given chain at a time: incremental
+// backups appended to the chain while it is being compacted don't start
+// another one. A resumed compaction job reuses the compacted backup if a
+// previous attempt finished writing it, and otherwise removes the data files
+// written by previous attempts before starting over.
+//
+// The compacted backup only contains the latest revision of each key as of the
+// end time of the chain. Chains taken with revision_history are therefore
+// never compacted, since their revisions could not be restored anymore.
+
+// maybeStartScheduledCompaction starts a job to compact the backup chain the
+// incremental backup described by details was appended to, if that backup was
+// run by a schedule configured to compact its chains and the chain now has at
+// least the configured number of incremental layers.
+func maybeStartScheduledCompaction(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.BackupDetails,
+	user username.SQLUsername,
+) error {
+	if details.ScheduleID == 0 || details.StartTime.IsEmpty() || details.CollectionURI == "" {
+		return nil
+	}
+
+	env := scheduledjobs.ProdJobSchedulerEnv
+	if knobs, ok := execCfg.DistSQLSrv.TestingKnobs.JobsTestingKnobs.(*jobs.TestingKnobs); ok {
+		if knobs.JobSchedulerEnv != nil {
+			env = knobs.JobSchedulerEnv
+		}
+	}
+
+	var args *backuppb.ScheduledBackupExecutionArgs
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		var err error
+		_, args, err = getScheduledBackupExecutionArgsFromSchedule(ctx, env, txn,
+			execCfg.InternalExecutor, details.ScheduleID)
+		return err
+	}); err != nil {
+		if jobs.HasScheduledJobNotFoundError(err) {
+			// The schedule was dropped while the backup was running.
+			return nil
+		}
+		return err
+	}
+	if args.CompactAfterIncrementals == 0 {
+		return nil
+	}
+	if len(details.URIsByLocalityKV) > 0 {
+		log.Warningf(ctx, "not compacting backup chain %s: locality aware backups cannot be compacted",
+			details.Destination.Subdir)
+		return nil
+	}
+	if details.RevisionHistory {
+		log.Warningf(ctx, "not compacting backup chain %s: backups with revision history cannot be compacted",
+			details.Destination.Subdir)
+		return nil
+	}
+
+	chain, err := resolveBackupChain(ctx, execCfg, details, user)
+	if err != nil {
+		return err
+	}
+	if int64(len(chain)-1) < args.CompactAfterIncrementals {
+		return nil
+	}
+
+	jobID, err := startBackupCompaction(ctx, execCfg, details, chain, user)
+	if err != nil {
+		return err
+	}
+	if jobID == jobspb.InvalidJobID {
+		log.Infof(ctx, "not compacting backup chain %s: it is already being compacted",
+			details.Destination.Subdir)
+		return nil
+	}
+	log.Infof(ctx, "started job %d to compact %d backups in %s", jobID, len(chain),
+		details.Destination.Subdir)
+	return nil
+}
+
+// startBackupCompaction creates a job to compact the backup chain, which ends
+// with the incremental backup described by details, into a new full backup.
+// If a job compacting the same chain is already running, no job is created and
+// InvalidJobID is returned.
+func startBackupCompaction(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.BackupDetails,
+	chain []string,
+	user username.SQLUsername,
+) (jobspb.JobID, error) {
+	compactedURI, _, err := backupdest.GetURIsByLocalityKV([]string{details.CollectionURI},
+		details.EndTime.GoTime().Format(backupbase.DateBasedIntoFolderName))
+	if err != nil {
+		return 0, err
+	}
+	compactedSubdir, err := collectionSubdir(details.CollectionURI, compactedURI)
+	if err != nil {
+		return 0, err
+	}
+	sanitizedCollectionURI, err := cloud.SanitizeExternalStorageURI(details.CollectionURI, nil /* extraParams */)
+	if err != nil {
+		return 0, err
+	}
+
+	record := jobs.Record{
+		Description: fmt.Sprintf("COMPACT BACKUP '%s' IN '%s' INTO '%s'",
+			details.Destination.Subdir, sanitizedCollectionURI, compactedSubdir),
+		Username: user,
+		Details: jobspb.BackupDetails{
+			EndTime:           details.EndTime,
+			URI:               compactedURI,
+			Destination:       jobspb.BackupDetails_Destination{Subdir: compactedSubdir},
+			CollectionURI:     details.CollectionURI,
+			EncryptionOptions: details.EncryptionOptions,
+			IncrementalFrom:   chain,
+			CompactChain:      true,
+		},
+		Progress: jobspb.BackupProgress{},
+	}
+	jobID := execCfg.JobRegistry.MakeJobID()
+	var inFlight bool
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		var err error
+		inFlight, err = jobs.RunningJobExists(ctx, jobspb.InvalidJobID, execCfg.InternalExecutor, txn,
+			func(payload *jobspb.Payload) bool {
+				backupDetails, ok := payload.Details.(*jobspb.Payload_Backup)
+				return ok && backupDetails.Backup.CompactChain &&
+					len(backupDetails.Backup.IncrementalFrom) > 0 &&
+					backupDetails.Backup.IncrementalFrom[0] == chain[0]
+			},
+		)
+		if err != nil || inFlight {
+			return err
+		}
+		_, err = execCfg.JobRegistry.CreateAdoptableJobWithTxn(ctx, record, jobID, txn)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	if inFlight {
+		return jobspb.InvalidJobID, nil
+	}
+	return jobID, nil
+}
+
+// resolveBackupChain returns the URIs of the full backup and all incremental
+// backups, up to and including the one described by details, of the chain
+// that the incremental backup described by details was appended to.
+func resolveBackupChain(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.BackupDetails,
+	user username.SQLUsername,
+) ([]string, error) {
+	fullURI, _, err := backupdest.GetURIsByLocalityKV([]string{details.CollectionURI},
+		details.Destination.Subdir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Incremental backups are written to a subdirectory of the incrementals
+	// location of the chain named after their end time, so the layers of the
+	// chain are the siblings of this backup.
+	incURI, err := url.Parse(details.URI)
+	if err != nil {
+		return nil, err
+	}
+	partName := details.EndTime.GoTime().Format(backupbase.DateBasedIncFolderName)
+	if !strings.HasSuffix(incURI.Path, partName) {
+		return nil, errors.AssertionFailedf("unexpected path %q for incremental backup ending at %s",
+			incURI.Path, details.EndTime)
+	}
+	incURI.Path = strings.TrimSuffix(incURI.Path, partName)
+
+	incStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, incURI.String(), user)
+	if err != nil {
+		return nil, err
+	}
+	defer incStore.Close()
+	priors, err := backupdest.FindPriorBackups(ctx, incStore, backupdest.OmitManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []string{fullURI}
+	for _, prior := range priors {
+		// Layers appended after this backup are not part of the compaction.
+		if prior > partName {
+			break
+		}
+		priorURI := *incURI
+		priorURI.Path = path.Join(incURI.Path, prior)
+		chain = append(chain, priorURI.String())
+	}
+	return chain, nil
+}
+
+// compactChain implements the resumer of a backup job that compacts a backup
+// chain into a new full backup.
+//
+// Progress is not checkpointed: a resumed compaction either reuses the
+// compacted backup written by a previous attempt, if its manifest was written,
+// or removes the files of the previous attempt and starts over.
+func (b *backupResumer) compactChain(
+	ctx context.Context, p sql.JobExecContext, details jobspb.BackupDetails,
+) error {
+	execCfg := p.ExecCfg()
+	user := p.User()
+	kmsEnv := backupencryption.MakeBackupKMSEnv(execCfg.Settings, &execCfg.ExternalIODirConfig,
+		execCfg.DB, user, execCfg.InternalExecutor)
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+
+	foundLockFile, err := backupinfo.CheckForBackupLock(ctx, execCfg, details.URI, b.job.ID(), user)
+	if err != nil {
+		return err
+	}
+	if !foundLockFile {
+		if err := backupinfo.CheckForPreviousBackup(ctx, execCfg, details.URI, b.job.ID(), user); err != nil {
+			return err
+		}
+		if err := backupinfo.WriteBackupLock(ctx, execCfg, details.URI, b.job.ID(), user); err != nil {
+			return err
+		}
+	}
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+
+	dest, err := mkStore(ctx, details.URI, user)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	if foundLockFile {
+		compacted, memSize, err := backupinfo.ReadBackupManifestFromStore(ctx, &mem, dest,
+			details.EncryptionOptions, &kmsEnv)
+		if err == nil {
+			defer mem.Shrink(ctx, memSize)
+			log.Infof(ctx, "reusing compacted backup %s written by a previous attempt",
+				details.Destination.Subdir)
+			return b.finishCompaction(ctx, execCfg, details, compacted.EntryCounts, user)
+		}
+		if !errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return err
+		}
+		if err := removeCompactionDataFiles(ctx, dest); err != nil {
+			return err
+		}
+	}
+
+	from := make([][]string, len(details.IncrementalFrom))
+	for i, uri := range details.IncrementalFrom {
+		from[i] = []string{uri}
+	}
+	_, manifests, localityInfo, memSize, err := backupdest.DeprecatedResolveBackupManifestsExplicitIncrementals(
+		ctx, &mem, mkStore, from, details.EndTime, details.EncryptionOptions, &kmsEnv, user)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+	last := manifests[len(manifests)-1]
+	if !last.EndTime.EqOrdering(details.EndTime) {
+		return errors.AssertionFailedf("backup chain ends at %s, expected %s", last.EndTime, details.EndTime)
+	}
+	for i := range manifests {
+		if manifests[i].MVCCFilter == backuppb.MVCCFilter_All {
+			return errors.Newf("backup chain %s contains revision history, which cannot be compacted",
+				details.IncrementalFrom[0])
+		}
+	}
+
+	var enc *roachpb.FileEncryptionOptions
+	if details.EncryptionOptions != nil {
+		key, err := backupencryption.GetEncryptionKey(ctx, details.EncryptionOptions, &kmsEnv)
+		if err != nil {
+			return err
+		}
+		enc = &roachpb.FileEncryptionOptions{Key: key}
+	}
+
+	if err := checkCoverage(ctx, last.Spans, manifests); err != nil {
+		return err
+	}
+	backupLocalityMap, err := makeBackupLocalityMap(localityInfo, user)
+	if err != nil {
+		return err
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(manifests, details.EndTime)
+	if err != nil {
+		return err
+	}
+	importSpans := makeSimpleImportSpans(last.Spans, manifests, backupLocalityMap,
+		introducedSpanFrontier, nil /* lowWaterMark */, targetRestoreSpanSize.Get(&execCfg.Settings.SV))
+
+	pkIDs := make(map[uint64]bool)
+	for i := range last.Descriptors {
+		if t, _, _, _, _ := descpb.GetDescriptors(&last.Descriptors[i]); t != nil {
+			pkIDs[roachpb.BulkOpSummaryID(uint64(t.ID), uint64(t.PrimaryIndex.ID))] = true
+		}
+	}
+
+	// The compacted backup is a full backup of the spans of the last layer of
+	// the chain as of its end time.
+	compacted := last
+	compacted.StartTime = hlc.Timestamp{}
+	compacted.IntroducedSpans = nil
+	compacted.DescriptorChanges = nil
+	compacted.Files = nil
+	compacted.EntryCounts = roachpb.RowCount{}
+	compacted.Dir = dest.Conf()
+	compacted.ID = uuid.MakeV4()
+
+	progressLogger := jobs.NewChunkProgressLogger(b.job, len(importSpans), 0, jobs.ProgressUpdateOnly)
+	requestFinishedCh := make(chan struct{}, len(importSpans)) // enough buffer to never block
+	progCh := make(chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
+
+	progressLoop := func(ctx context.Context) error {
+		return progressLogger.Loop(ctx, requestFinishedCh)
+	}
+	collectFiles := func(ctx context.Context) error {
+		for progress := range progCh {
+			var progDetails backuppb.BackupManifest_Progress
+			if err := types.UnmarshalAny(&progress.ProgressDetails, &progDetails); err != nil {
+				return err
+			}
+			for _, file := range progDetails.Files {
+				compacted.Files = append(compacted.Files, file)
+				compacted.EntryCounts.Add(file.EntryCounts)
+			}
+		}
+		return nil
+	}
+	runCompaction := func(ctx context.Context) error {
+		defer close(requestFinishedCh)
+		defer close(progCh)
+		sink := makeFileSSTSink(sstSinkConf{
+			progCh:   progCh,
+			enc:      enc,
+			id:       execCfg.NodeInfo.NodeID.SQLInstanceID(),
+			settings: &execCfg.Settings.SV,
+		}, dest)
+		defer func() {
+			if err := sink.Close(); err != nil {
+				log.Warningf(ctx, "failed to close compaction SST sink: %+v", err)
+			}
+		}()
+		for _, entry := range importSpans {
+			if err := compactSpanEntry(ctx, execCfg, entry, enc, details.EndTime, pkIDs, sink); err != nil {
+				return err
+			}
+			requestFinishedCh <- struct{}{}
+		}
+		return sink.flush(ctx)
+	}
+	if len(importSpans) == 0 {
+		progressLoop = nil
+	}
+	if err := ctxgroup.GoAndWait(ctx, progressLoop, collectFiles, runCompaction); err != nil {
+		return errors.Wrapf(err, "compacting %d backups", len(manifests))
+	}
+
+	// The ENCRYPTION-INFO files of the chain live in its full backup, and the
+	// table statistics files in each of its layers. Both are copied as-is since
+	// the compacted backup is encrypted with the same key.
+	if details.EncryptionOptions != nil {
+		fullStore, err := mkStore(ctx, details.IncrementalFrom[0], user)
+		if err != nil {
+			return err
+		}
+		defer fullStore.Close()
+		encryptionInfoFiles, err := backupencryption.GetEncryptionInfoFiles(ctx, fullStore)
+		if err != nil {
+			return err
+		}
+		for _, name := range encryptionInfoFiles {
+			if err := copyBackupFile(ctx, fullStore, dest, name); err != nil {
+				return err
+			}
+		}
+	}
+	lastStore, err := mkStore(ctx, details.IncrementalFrom[len(details.IncrementalFrom)-1], user)
+	if err != nil {
+		return err
+	}
+	defer lastStore.Close()
+	copiedStats := make(map[string]struct{})
+	for _, name := range compacted.StatisticsFilenames {
+		if _, ok := copiedStats[name]; ok {
+			continue
+		}
+		copiedStats[name] = struct{}{}
+		if err := copyBackupFile(ctx, lastStore, dest, name); err != nil {
+			return err
+		}
+	}
+
+	if err := backupinfo.WriteBackupManifest(ctx, dest, backupbase.BackupManifestName,
+		details.EncryptionOptions, &kmsEnv, &compacted); err != nil {
+		return err
+	}
+	return b.finishCompaction(ctx, execCfg, details, compacted.EntryCounts, user)
+}
+
+// finishCompaction points the LATEST file of the collection at the compacted
+// backup, once it has been written.
+func (b *backupResumer) finishCompaction(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.BackupDetails,
+	entryCounts roachpb.RowCount,
+	user username.SQLUsername,
+) error {
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+
+	// Only point LATEST at the compacted backup if it still points at the chain
+	// that was compacted, that is if no newer full backup was taken meanwhile.
+	chainSubdir, err := collectionSubdir(details.CollectionURI, details.IncrementalFrom[0])
+	if err != nil {
+		return err
+	}
+	latest, err := backupdest.ReadLatestFile(ctx, details.CollectionURI, mkStore, user)
+	if err != nil {
+		return err
+	}
+	if path.Clean(latest) == chainSubdir {
+		collection, err := mkStore(ctx, details.CollectionURI, user)
+		if err != nil {
+			return err
+		}
+		defer collection.Close()
+		if err := backupdest.WriteNewLatestFile(ctx, execCfg.Settings, collection, details.Destination.Subdir); err != nil {
+			return err
+		}
+	} else if path.Clean(latest) != path.Clean(details.Destination.Subdir) {
+		log.Infof(ctx, "not updating LATEST to compacted backup %s since it now points to %s",
+			details.Destination.Subdir, latest)
+	}
+
+	b.backupStats = entryCounts
+	return nil
+}
+
+// compactSpanEntry merges the files of the span entry, reading them as of
+// endTime, and writes the result to sink in chunks of roughly the size of the
+// files produced by export requests.
+func compactSpanEntry(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	entry execinfrapb.RestoreSpanEntry,
+	enc *roachpb.FileEncryptionOptions,
+	endTime hlc.Timestamp,
+	pkIDs map[uint64]bool,
+	sink *fileSSTSink,
+) error {
+	var dirs []cloud.ExternalStorage
+	defer func() {
+		for _, dir := range dirs {
+			if err := dir.Close(); err != nil {
+				log.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	storeFiles := make([]storageccl.StoreFile, 0, len(entry.Files))
+	for _, file := range entry.Files {
+		dir, err := execCfg.DistSQLSrv.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, dir)
+		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+	}
+	iterOpts := storage.IterOptions{
+		RangeKeyMaskingBelow: endTime,
+		KeyTypes:             storage.IterKeyTypePointsAndRanges,
+		LowerBound:           keys.LocalMax,
+		UpperBound:           keys.MaxKey,
+	}
+	iter, err := storageccl.ExternalSSTReader(ctx, storeFiles, enc, iterOpts)
+	if err != nil {
+		return err
+	}
+	readAsOfIter := storage.NewReadAsOfIterator(iter, endTime)
+	defer readAsOfIter.Close()
+
+	targetSize := batcheval.ExportRequestTargetFileSize.Get(&execCfg.Settings.SV)
+	var sstFile storage.MemFile
+	sst := storage.MakeBackupSSTWriter(ctx, execCfg.Settings, &sstFile)
+	defer func() { sst.Close() }()
+	var counter storage.RowCounter
+	chunkStart := entry.Span.Key
+
+	flush := func(end roachpb.Key) error {
+		if counter.DataSize == 0 {
+			return nil
+		}
+		if err := sst.Finish(); err != nil {
+			return err
+		}
+		exported := exportedSpan{
+			metadata: backuppb.BackupManifest_File{
+				Span:        roachpb.Span{Key: chunkStart, EndKey: end},
+				EntryCounts: countRows(counter.BulkOpSummary, pkIDs),
+				EndTime:     endTime,
+			},
+			dataSST:       sstFile.Data(),
+			atKeyBoundary: true,
+		}
+		if err := sink.write(ctx, exported); err != nil {
+			return err
+		}
+		sstFile = storage.MemFile{}
+		sst = storage.MakeBackupSSTWriter(ctx, execCfg.Settings, &sstFile)
+		counter = storage.RowCounter{}
+		chunkStart = end
+		return nil
+	}
+
+	var lastRow roachpb.Key
+	startKey, endKey := storage.MVCCKey{Key: entry.Span.Key}, storage.MVCCKey{Key: entry.Span.EndKey}
+	for readAsOfIter.SeekGE(startKey); ; readAsOfIter.NextKey() {
+		ok, err := readAsOfIter.Valid()
+		if err != nil {
+			return err
+		}
+		if !ok || !readAsOfIter.UnsafeKey().Less(endKey) {
+			break
+		}
+		key := readAsOfIter.UnsafeKey()
+		value := readAsOfIter.UnsafeValue()
+
+		// Files are only cut between SQL rows, so that a row is never split
+		// across files.
+		row, err := keys.EnsureSafeSplitKey(key.Key)
+		if err != nil {
+			// Non-SQL keys are never part of a multi-key row.
+			row = key.Key
+		}
+		if counter.DataSize >= targetSize && !row.Equal(lastRow) {
+			if err := flush(key.Key.Clone()); err != nil {
+				return err
+			}
+		}
+		lastRow = append(lastRow[:0], row...)
+
+		if err := counter.Count(key.Key); err != nil {
+			return err
+		}
+		counter.DataSize += int64(len(key.Key) + len(value))
+		if key.Timestamp.IsEmpty() {
+			err = sst.PutUnversioned(key.Key, value)
+		} else {
+			err = sst.PutRawMVCC(key, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return flush(entry.Span.EndKey)
+}
+
+// removeCompactionDataFiles removes the data files written to dest by a
+// previous attempt to compact a backup chain. They are not referenced by any
+// manifest, since the manifest is only written once all of them are.
+func removeCompactionDataFiles(ctx context.Context, dest cloud.ExternalStorage) error {
+	// Data files are written to the data/ directory, see generateUniqueSSTName.
+	const dataDir = "data/"
+	var names []string
+	if err := dest.List(ctx, dataDir, "", func(name string) error {
+		names = append(names, dataDir+strings.TrimPrefix(name, "/"))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := dest.Delete(ctx, name); err != nil {
+			return err
+		}
+	}
+	if len(names) > 0 {
+		log.Infof(ctx, "removed %d files written by a previous compaction attempt", len(names))
+	}
+	return nil
+}
+
+// copyBackupFile copies the file name from src to dest.
+func copyBackupFile(
+	ctx context.Context, src cloud.ExternalStorage, dest cloud.ExternalStorage, name string,
+) error {
+	r, err := src.ReadFile(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close(ctx)
+	content, err := ioctx.ReadAll(ctx, r)
+	if err != nil {
+		return err
+	}
+	return cloud.WriteFile(ctx, dest, name, bytes.NewReader(content))
+}
+
+// collectionSubdir returns the path of uri relative to the collection at
+// collectionURI.
+func collectionSubdir(collectionURI string, uri string) (string, error) {
+	parsedURI, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	parsedCollectionURI, err := url.Parse(collectionURI)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(path.Clean(parsedURI.Path), path.Clean(parsedCollectionURI.Path)), nil
+}
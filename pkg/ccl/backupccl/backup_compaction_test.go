@@ -0,0 +1,178 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackupCompaction tests that an encrypted chain of backups can be
+// compacted into a full backup that restores the same data as the chain.
+func TestBackupCompaction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+	execCfg := tc.Server(0).ExecutorConfig().(sql.ExecutorConfig)
+	ctx := context.Background()
+
+	const passphrase = `encryption_passphrase = 'abc'`
+	sqlDB.Exec(t, fmt.Sprintf(`BACKUP DATABASE data INTO '%s' WITH %s`, localFoo, passphrase))
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 5`)
+	sqlDB.Exec(t, fmt.Sprintf(`BACKUP DATABASE data INTO LATEST IN '%s' WITH %s`, localFoo, passphrase))
+	sqlDB.Exec(t, `DELETE FROM data.bank WHERE id = 7`)
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (100, 100, 'new')`)
+	sqlDB.Exec(t, fmt.Sprintf(`BACKUP DATABASE data INTO LATEST IN '%s' WITH %s`, localFoo, passphrase))
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	var incJobID jobspb.JobID
+	sqlDB.QueryRow(t,
+		`SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'BACKUP' ORDER BY created DESC LIMIT 1`,
+	).Scan(&incJobID)
+	incJob, err := execCfg.JobRegistry.LoadJob(ctx, incJobID)
+	require.NoError(t, err)
+	details := incJob.Details().(jobspb.BackupDetails)
+
+	chain, err := resolveBackupChain(ctx, &execCfg, details, username.RootUserName())
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+
+	jobID, err := startBackupCompaction(ctx, &execCfg, details, chain, username.RootUserName())
+	require.NoError(t, err)
+	jobutils.WaitForJobToSucceed(t, sqlDB, jobID)
+
+	// The compacted backup is a full backup, which LATEST now points to.
+	require.Len(t, sqlDB.QueryStr(t, `SHOW BACKUPS IN $1`, localFoo), 2)
+	sqlDB.CheckQueryResults(t, fmt.Sprintf(
+		`SELECT DISTINCT backup_type FROM [SHOW BACKUP FROM LATEST IN '%s' WITH %s]`, localFoo, passphrase),
+		[][]string{{"full"}})
+
+	sqlDB.Exec(t, fmt.Sprintf(
+		`RESTORE DATABASE data FROM LATEST IN '%s' WITH new_db_name = 'compacted', %s`, localFoo, passphrase))
+	sqlDB.CheckQueryResults(t, `SELECT * FROM compacted.bank ORDER BY id`, expected)
+
+	// Incremental backups are now appended to the compacted backup.
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id = 100`)
+	sqlDB.Exec(t, fmt.Sprintf(`BACKUP DATABASE data INTO LATEST IN '%s' WITH %s`, localFoo, passphrase))
+	expected = sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+	sqlDB.Exec(t, fmt.Sprintf(
+		`RESTORE DATABASE data FROM LATEST IN '%s' WITH new_db_name = 'appended', %s`, localFoo, passphrase))
+	sqlDB.CheckQueryResults(t, `SELECT * FROM appended.bank ORDER BY id`, expected)
+}
+
+// TestScheduledBackupCompaction tests that an incremental backup schedule
+// configured with compact_after_incrementals compacts its chain once it has
+// enough incremental layers, and that the compacted chain can be restored and
+// appended to.
+func TestScheduledBackupCompaction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	th, cleanup := newTestHelper(t)
+	defer cleanup()
+
+	// We'll be manipulating schedule time via th.env, but we can't fool actual backup
+	// when it comes to AsOf time.  So, override AsOf backup clause to be the current time.
+	th.cfg.TestingKnobs.(*jobs.TestingKnobs).OverrideAsOfClause = func(clause *tree.AsOfClause, _ time.Time) {
+		expr, err := tree.MakeDTimestampTZ(th.cfg.DB.Clock().PhysicalTime(), time.Microsecond)
+		require.NoError(t, err)
+		clause.Expr = expr
+	}
+
+	th.sqlDB.Exec(t, `
+CREATE DATABASE db;
+CREATE TABLE db.t (k INT PRIMARY KEY, v INT);
+INSERT INTO db.t VALUES (1, 1), (2, 2), (3, 3);
+`)
+
+	const destination = "nodelocal://0/compaction"
+	schedules, err := th.createBackupSchedule(t,
+		`CREATE SCHEDULE FOR BACKUP DATABASE db INTO $1 RECURRING '@hourly' FULL BACKUP '@weekly'
+WITH SCHEDULE OPTIONS compact_after_incrementals = '2'`, destination)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	full, inc := schedules[0], schedules[1]
+	if full.IsPaused() {
+		full, inc = inc, full
+	}
+
+	// runSchedule executes the schedule and waits for it to have run n jobs
+	// successfully.
+	runSchedule := func(scheduleID int64, n int) {
+		th.env.SetTime(th.loadSchedule(t, scheduleID).NextRun().Add(time.Second))
+		require.NoError(t, th.executeSchedules())
+		testutils.SucceedsSoon(t, func() error {
+			th.server.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+			var count int
+			th.sqlDB.QueryRow(t, "SELECT count(*) FROM "+th.env.SystemJobsTableName()+
+				" WHERE status=$1 AND created_by_type=$2 AND created_by_id=$3",
+				jobs.StatusSucceeded, jobs.CreatedByScheduledJobs, scheduleID).Scan(&count)
+			if count != n {
+				return errors.Newf("expected %d successful jobs, found %d", n, count)
+			}
+			return nil
+		})
+	}
+	waitForCompactions := func(n int) {
+		testutils.SucceedsSoon(t, func() error {
+			th.server.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+			var count int
+			th.sqlDB.QueryRow(t, `SELECT count(*) FROM [SHOW JOBS]
+WHERE job_type = 'BACKUP' AND description LIKE 'COMPACT BACKUP%' AND status = $1`,
+				jobs.StatusSucceeded).Scan(&count)
+			if count != n {
+				return errors.Newf("expected %d successful compactions, found %d", n, count)
+			}
+			return nil
+		})
+	}
+
+	runSchedule(full.ScheduleID(), 1)
+	th.sqlDB.Exec(t, `UPDATE db.t SET v = v + 1 WHERE k = 1`)
+	runSchedule(inc.ScheduleID(), 1)
+	th.sqlDB.Exec(t, `DELETE FROM db.t WHERE k = 2`)
+	th.sqlDB.Exec(t, `INSERT INTO db.t VALUES (4, 4)`)
+	runSchedule(inc.ScheduleID(), 2)
+	expected := th.sqlDB.QueryStr(t, `SELECT * FROM db.t ORDER BY k`)
+
+	// The second incremental backup started a compaction of the chain, after
+	// which LATEST points to a compacted full backup.
+	waitForCompactions(1)
+	require.Len(t, th.sqlDB.QueryStr(t, `SHOW BACKUPS IN $1`, destination), 2)
+	th.sqlDB.CheckQueryResults(t,
+		`SELECT DISTINCT backup_type FROM [SHOW BACKUP FROM LATEST IN $1]`, [][]string{{"full"}})
+	th.sqlDB.Exec(t, `RESTORE DATABASE db FROM LATEST IN $1 WITH new_db_name = 'compacted'`, destination)
+	th.sqlDB.CheckQueryResults(t, `SELECT * FROM compacted.t ORDER BY k`, expected)
+
+	// The schedule appends its next incremental backup to the compacted chain,
+	// which is too short to be compacted again.
+	th.sqlDB.Exec(t, `UPDATE db.t SET v = v + 1 WHERE k = 4`)
+	runSchedule(inc.ScheduleID(), 3)
+	expected = th.sqlDB.QueryStr(t, `SELECT * FROM db.t ORDER BY k`)
+	th.sqlDB.Exec(t, `RESTORE DATABASE db FROM LATEST IN $1 WITH new_db_name = 'appended'`, destination)
+	th.sqlDB.CheckQueryResults(t, `SELECT * FROM appended.t ORDER BY k`, expected)
+	waitForCompactions(1)
+}
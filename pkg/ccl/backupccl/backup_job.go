@@ -407,6 +407,9 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 	// The span is finished by the registry executing the job.
 	details := b.job.Details().(jobspb.BackupDetails)
 	p := execCtx.(sql.JobExecContext)
+	if details.CompactChain {
+		return b.compactChain(ctx, p, details)
+	}
 	kmsEnv := backupencryption.MakeBackupKMSEnv(p.ExecCfg().Settings,
 		&p.ExecCfg().ExternalIODirConfig, p.ExecCfg().DB, p.User(), p.ExecCfg().InternalExecutor)
 
@@ -732,6 +735,13 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}
 
+	// If this incremental backup was run by a schedule that compacts its backup
+	// chains, compact the chain if it has grown long enough. Failing to do so
+	// does not fail the backup, which already completed.
+	if err := maybeStartScheduledCompaction(ctx, p.ExecCfg(), details, p.User()); err != nil {
+		log.Warningf(ctx, "failed to start compaction of backup chain: %+v", err)
+	}
+
 	b.backupStats = res
 
 	// Collect telemetry.
@@ -11,6 +11,7 @@ package backupccl
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,19 +44,21 @@ import (
 )
 
 const (
-	optFirstRun                = "first_run"
-	optOnExecFailure           = "on_execution_failure"
-	optOnPreviousRunning       = "on_previous_running"
-	optIgnoreExistingBackups   = "ignore_existing_backups"
-	optUpdatesLastBackupMetric = "updates_cluster_last_backup_time_metric"
+	optFirstRun                 = "first_run"
+	optOnExecFailure            = "on_execution_failure"
+	optOnPreviousRunning        = "on_previous_running"
+	optIgnoreExistingBackups    = "ignore_existing_backups"
+	optUpdatesLastBackupMetric  = "updates_cluster_last_backup_time_metric"
+	optCompactAfterIncrementals = "compact_after_incrementals"
 )
 
 var scheduledBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
-	optFirstRun:                exprutil.KVStringOptRequireValue,
-	optOnExecFailure:           exprutil.KVStringOptRequireValue,
-	optOnPreviousRunning:       exprutil.KVStringOptRequireValue,
-	optIgnoreExistingBackups:   exprutil.KVStringOptRequireNoValue,
-	optUpdatesLastBackupMetric: exprutil.KVStringOptRequireNoValue,
+	optFirstRun:                 exprutil.KVStringOptRequireValue,
+	optOnExecFailure:            exprutil.KVStringOptRequireValue,
+	optOnPreviousRunning:        exprutil.KVStringOptRequireValue,
+	optIgnoreExistingBackups:    exprutil.KVStringOptRequireNoValue,
+	optUpdatesLastBackupMetric:  exprutil.KVStringOptRequireNoValue,
+	optCompactAfterIncrementals: exprutil.KVStringOptRequireValue,
 }
 
 // scheduledBackupGCProtectionEnabled is used to enable and disable the chaining
@@ -122,6 +125,14 @@ func parseWaitBehavior(wait string, details *jobspb.ScheduleDetails) error {
 	return nil
 }
 
+func parseCompactAfterIncrementals(v string) (int64, error) {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, errors.Newf("%s must be a positive integer, found %q", optCompactAfterIncrementals, v)
+	}
+	return n, nil
+}
+
 func parseOnPreviousRunningOption(
 	onPreviousRunning jobspb.ScheduleDetails_WaitBehavior,
 ) (string, error) {
@@ -361,6 +372,29 @@ func doCreateBackupSchedules(
 		}
 	}
 
+	var compactAfterIncrementals int64
+	if v, ok := scheduleOptions[optCompactAfterIncrementals]; ok {
+		if incRecurrence == nil {
+			return errors.Newf("%s requires an incremental backup schedule", optCompactAfterIncrementals)
+		}
+		if compactAfterIncrementals, err = parseCompactAfterIncrementals(v); err != nil {
+			return err
+		}
+		// Compacted backups only contain the latest revision of each key, so
+		// compacting a chain would discard its revision history.
+		if eval.BackupOptions.CaptureRevisionHistory != nil {
+			revisionHistory, err := p.ExprEvaluator(scheduleBackupOp).Bool(
+				ctx, eval.BackupOptions.CaptureRevisionHistory,
+			)
+			if err != nil {
+				return err
+			}
+			if revisionHistory {
+				return errors.Newf("%s cannot be used with revision_history", optCompactAfterIncrementals)
+			}
+		}
+	}
+
 	evalCtx := &p.ExtendedEvalContext().Context
 	firstRun, err := scheduleFirstRun(evalCtx, scheduleOptions)
 	if err != nil {
@@ -393,7 +427,7 @@ func doCreateBackupSchedules(
 		}
 		inc, incScheduledBackupArgs, err = makeBackupSchedule(
 			env, p.User(), scheduleLabel, incRecurrence, details, unpauseOnSuccessID,
-			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, compactAfterIncrementals)
 		if err != nil {
 			return err
 		}
@@ -417,7 +451,7 @@ func doCreateBackupSchedules(
 	var fullScheduledBackupArgs *backuppb.ScheduledBackupExecutionArgs
 	full, fullScheduledBackupArgs, err := makeBackupSchedule(
 		env, p.User(), scheduleLabel, fullRecurrence, details, unpauseOnSuccessID,
-		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, 0 /* compactAfterIncrementals */)
 	if err != nil {
 		return err
 	}
@@ -523,6 +557,7 @@ func makeBackupSchedule(
 	updateLastMetricOnSuccess bool,
 	backupNode *tree.Backup,
 	chainProtectedTimestampRecords bool,
+	compactAfterIncrementals int64,
 ) (*jobs.ScheduledJob, *backuppb.ScheduledBackupExecutionArgs, error) {
 	sj := jobs.NewScheduledJob(env)
 	sj.SetScheduleLabel(label)
@@ -533,6 +568,7 @@ func makeBackupSchedule(
 		UnpauseOnSuccess:               unpauseOnSuccess,
 		UpdatesLastBackupMetric:        updateLastMetricOnSuccess,
 		ChainProtectedTimestampRecords: chainProtectedTimestampRecords,
+		CompactAfterIncrementals:       compactAfterIncrementals,
 	}
 	if backupNode.AppendToLatest {
 		args.BackupType = backuppb.ScheduledBackupExecutionArgs_INCREMENTAL
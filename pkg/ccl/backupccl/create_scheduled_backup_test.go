@@ -614,6 +614,24 @@ func TestSerializesScheduledBackupExecutionArgs(t *testing.T) {
 			query:  `CREATE SCHEDULE FOR BACKUP INTO 'foo' WITH encryption_passphrase=$1 RECURRING '@hourly'`,
 			errMsg: "failed to evaluate backup encryption_passphrase",
 		},
+		{
+			name:   "compaction-without-incrementals",
+			user:   enterpriseUser,
+			query:  `CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly' FULL BACKUP ALWAYS WITH SCHEDULE OPTIONS compact_after_incrementals = '10'`,
+			errMsg: "compact_after_incrementals requires an incremental backup schedule",
+		},
+		{
+			name:   "compaction-invalid-threshold",
+			user:   enterpriseUser,
+			query:  `CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly' WITH SCHEDULE OPTIONS compact_after_incrementals = '0'`,
+			errMsg: "compact_after_incrementals must be a positive integer",
+		},
+		{
+			name:   "compaction-with-revision-history",
+			user:   enterpriseUser,
+			query:  `CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' WITH revision_history RECURRING '@hourly' WITH SCHEDULE OPTIONS compact_after_incrementals = '10'`,
+			errMsg: "compact_after_incrementals cannot be used with revision_history",
+		},
 	}
 
 	for i, tc := range testCases {
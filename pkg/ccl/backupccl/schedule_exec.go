@@ -10,6 +10,7 @@ package backupccl
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuppb"
@@ -283,6 +284,21 @@ func (e *scheduledBackupExecutor) GetCreateScheduleStatement(
 		},
 	}
 
+	// The compaction threshold is only stored on the incremental schedule.
+	incArgs := args
+	if !backupNode.AppendToLatest && dependentSchedule != nil {
+		incArgs = &backuppb.ScheduledBackupExecutionArgs{}
+		if err := pbtypes.UnmarshalAny(dependentSchedule.ExecutionArgs().Args, incArgs); err != nil {
+			return "", errors.Wrap(err, "un-marshaling args")
+		}
+	}
+	if incArgs.CompactAfterIncrementals > 0 {
+		scheduleOptions = append(scheduleOptions, tree.KVOption{
+			Key:   optCompactAfterIncrementals,
+			Value: tree.NewDString(strconv.FormatInt(incArgs.CompactAfterIncrementals, 10)),
+		})
+	}
+
 	var destinations []string
 	for i := range backupNode.To {
 		dest, ok := backupNode.To[i].(*tree.StrVal)
@@ -169,6 +169,9 @@ type avroMetadata map[string]interface{}
 type avroEnvelopeOpts struct {
 	beforeField, afterField, recordField bool
 	updatedField, resolvedField          bool
+	// debeziumFields adds the op, source and ts_ms fields of the debezium
+	// envelope.
+	debeziumFields bool
 }
 
 // avroEnvelopeRecord is an `avroRecord` that wraps a changed SQL row and some
@@ -178,6 +181,7 @@ type avroEnvelopeRecord struct {
 
 	opts                  avroEnvelopeOpts
 	before, after, record *avroDataRecord
+	source                *avroRecord
 }
 
 // typeToAvroSchema converts a database type to an avro field
@@ -921,6 +925,26 @@ func envelopeToAvroSchema(
 		}
 		schema.Fields = append(schema.Fields, recordField)
 	}
+	if opts.debeziumFields {
+		schema.source = debeziumSourceToAvroSchema(topic, namespace)
+		schema.Fields = append(schema.Fields,
+			&avroSchemaField{
+				SchemaType: []avroSchemaType{avroSchemaNull, avroSchemaString},
+				Name:       `op`,
+				Default:    nil,
+			},
+			&avroSchemaField{
+				SchemaType: []avroSchemaType{avroSchemaNull, schema.source},
+				Name:       `source`,
+				Default:    nil,
+			},
+			&avroSchemaField{
+				SchemaType: []avroSchemaType{avroSchemaNull, avroSchemaLong},
+				Name:       `ts_ms`,
+				Default:    nil,
+			},
+		)
+	}
 
 	schemaJSON, err := json.Marshal(schema)
 	if err != nil {
@@ -999,12 +1023,73 @@ func (r *avroEnvelopeRecord) BinaryFromRow(
 			native[`resolved`] = goavro.Union(avroUnionKey(avroSchemaString), ts.AsOfSystemTime())
 		}
 	}
+	if r.opts.debeziumFields {
+		native[`op`] = nil
+		if op, ok := meta[`op`]; ok {
+			delete(meta, `op`)
+			native[`op`] = goavro.Union(avroUnionKey(avroSchemaString), op)
+		}
+		native[`source`] = nil
+		if s, ok := meta[`source`]; ok {
+			delete(meta, `source`)
+			source, ok := s.(debeziumSource)
+			if !ok {
+				return nil, changefeedbase.WithTerminalError(
+					errors.Errorf(`unknown metadata source type: %T`, s))
+			}
+			native[`source`] = goavro.Union(avroUnionKey(r.source), debeziumSourceToNative(source))
+		}
+		native[`ts_ms`] = nil
+		if ts, ok := meta[`ts_ms`]; ok {
+			delete(meta, `ts_ms`)
+			native[`ts_ms`] = goavro.Union(avroUnionKey(avroSchemaLong), ts)
+		}
+	}
 	for k := range meta {
 		return nil, changefeedbase.WithTerminalError(errors.AssertionFailedf(`unhandled meta key: %s`, k))
 	}
 	return r.codec.BinaryFromNative(buf, native)
 }
 
+// debeziumSourceToAvroSchema creates an avro record schema for the source
+// block of the debezium envelope.
+func debeziumSourceToAvroSchema(topic string, namespace string) *avroRecord {
+	schema := &avroRecord{
+		Name:       SQLNameToAvroName(topic) + `_source`,
+		SchemaType: `record`,
+		Namespace:  namespace,
+	}
+	for _, name := range debeziumSourceFields {
+		var typ avroSchemaType = avroSchemaString
+		if name == debeziumSourceTsMs {
+			typ = avroSchemaLong
+		}
+		schema.Fields = append(schema.Fields, &avroSchemaField{
+			SchemaType: []avroSchemaType{avroSchemaNull, typ},
+			Name:       name,
+			Default:    nil,
+		})
+	}
+	return schema
+}
+
+// debeziumSourceToNative returns the goavro native representation of the
+// source block of the debezium envelope.
+func debeziumSourceToNative(s debeziumSource) map[string]interface{} {
+	str := func(v string) interface{} {
+		return goavro.Union(avroUnionKey(avroSchemaString), v)
+	}
+	return map[string]interface{}{
+		debeziumSourceConnector: str(debeziumConnector),
+		debeziumSourceDB:        str(s.db),
+		debeziumSourceSchema:    str(s.schema),
+		debeziumSourceTable:     str(s.table),
+		debeziumSourceTsMs:      goavro.Union(avroUnionKey(avroSchemaLong), s.tsMs()),
+		debeziumSourceSnapshot:  str(s.snapshotString()),
+		debeziumSourceMVCC:      str(s.mvcc.AsOfSystemTime()),
+	}
+}
+
 // Refresh the metadata for user-defined types on a cached schema
 // The only user-defined type is enum, so this is usually a no-op.
 func (r *avroDataRecord) refreshTypeMetadata(row cdcevent.Row) error {
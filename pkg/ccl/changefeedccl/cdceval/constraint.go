@@ -94,7 +94,16 @@ func constrainSpansBySelectClause(
 		return []roachpb.Span{ed.TableDescriptor().PrimaryIndexSpan(codec)}, nil, nil
 	}
 
-	tableName := tableNameOrAlias(ed.TableName, selectClause.From.Tables[0])
+	target, joins := splitFromClause(selectClause.From.Tables[0])
+	// Filters referencing lookup tables can't be used to constrain spans of the
+	// target table; keep the filter as is.
+	for _, j := range joins {
+		if ref, ok := j.Right.(*tree.TableRef); ok && referencesTable(ref.As.Alias, selectClause.Where.Expr) {
+			return []roachpb.Span{ed.TableDescriptor().PrimaryIndexSpan(codec)}, selectClause.Where.Expr, nil
+		}
+	}
+
+	tableName := tableNameOrAlias(ed.TableName, target)
 	semaCtx := newSemaCtxWithTypeResolver(ed)
 	return sc.ConstrainPrimaryIndexSpanByExpr(
 		ctx, sql.BestEffortConstrain, tableName, ed.TableDescriptor(),
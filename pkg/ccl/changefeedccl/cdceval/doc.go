@@ -54,4 +54,13 @@ return the MVCC timestamp of the event.
 We also provide custom, CDC specific functions, such as cdc_prev() which returns prevoius row as
 a JSONB record.  See functions.go for more details.
 
+The target table may be joined with lookup tables (e.g. "SELECT o.*, c.name FROM orders AS o
+JOIN customers AS c ON c.id = o.customer_id").  The join condition must equate each primary key
+column of the lookup table with an expression, so that at most one row of the lookup table
+matches each event.  The columns of the lookup tables must be qualified with the table name
+(or alias); they are bound to the IndexedVars following the columns of the event.  Before the
+expressions are evaluated, the matching rows of the lookup tables are read as of the MVCC timestamp
+of the event (see lookup.go).  Events without a matching row are filtered out by inner joins, while
+left joins evaluate the columns of the lookup table to NULL.
+
 ***/
@@ -14,10 +14,13 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/normalize"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -35,6 +38,10 @@ type Evaluator struct {
 	selectors []tree.SelectExpr
 	from      tree.TableExpr
 	where     tree.Expr
+	joins     []lookupJoin
+
+	// lookups reads the rows of the tables joined by the expression.
+	lookups LookupReader
 
 	evalCtx *eval.Context
 	// Current evaluator.  Re-initialized whenever event descriptor
@@ -43,11 +50,12 @@ type Evaluator struct {
 }
 
 // NewEvaluator returns evaluator configured to process specified
-// select expression.
+// select expression. The lookup reader may be nil if the select
+// expression doesn't join any lookup tables.
 func NewEvaluator(
-	ctx context.Context, evalCtx *eval.Context, sc *tree.SelectClause,
+	ctx context.Context, evalCtx *eval.Context, sc *tree.SelectClause, lookups LookupReader,
 ) (*Evaluator, error) {
-	e := &Evaluator{evalCtx: evalCtx.Copy()}
+	e := &Evaluator{evalCtx: evalCtx.Copy(), lookups: lookups}
 
 	if len(sc.From.Tables) > 0 { // 0 tables used only in tests.
 		if len(sc.From.Tables) != 1 {
@@ -60,6 +68,9 @@ func NewEvaluator(
 		return nil, err
 	}
 
+	if len(e.joins) > 0 && e.lookups == nil {
+		return nil, errors.AssertionFailedf("expected lookup reader for expression with lookup joins")
+	}
 	return e, nil
 }
 
@@ -78,7 +89,7 @@ func (e *Evaluator) MatchesFilter(
 				errors.Newf("error while evaluating WHERE clause: %s", pan))
 		}
 	}()
-	if e.where == nil {
+	if e.where == nil && len(e.joins) == 0 {
 		return true, nil
 	}
 
@@ -144,15 +155,42 @@ func (e *Evaluator) initSelectClause(ctx context.Context, sc *tree.SelectClause)
 		e.where = expr
 	}
 
+	e.joins = e.joins[:0]
+	if len(sc.From.Tables) == 1 {
+		_, joins := splitFromClause(sc.From.Tables[0])
+		for _, j := range joins {
+			lj, err := makeLookupJoin(j)
+			if err != nil {
+				return err
+			}
+			if lj.cond, err = validateExpressionForCDC(ctx, lj.cond, semaCtx); err != nil {
+				return err
+			}
+			e.joins = append(e.joins, lj)
+		}
+	}
+
 	return nil
 }
 
 // initEval initializes evaluator for the specified event descriptor.
 func (e *Evaluator) initEval(ctx context.Context, d *cdcevent.EventDescriptor) error {
+	// The lookup tables are joined using their schema as of the schema
+	// timestamp of the event.
+	lookupDescs := make([]catalog.TableDescriptor, len(e.joins))
+	for i, j := range e.joins {
+		desc, err := e.lookups.TableDescriptor(ctx, j.tableID, d.SchemaTS)
+		if err != nil {
+			return err
+		}
+		lookupDescs[i] = desc
+	}
+
 	if e.evaluator != nil {
 		sameVersion, sameTypes := d.EqualsWithUDTCheck(e.evaluator.EventDescriptor)
-		if sameVersion && sameTypes {
-			// Event descriptor and UDT types are the same -- re-use the same evaluator.
+		if sameVersion && sameTypes && e.evaluator.sameLookupVersions(lookupDescs) {
+			// Event descriptor, UDT types and lookup tables are the same -- re-use
+			// the same evaluator.
 			return nil
 		}
 
@@ -182,7 +220,15 @@ func (e *Evaluator) initEval(ctx context.Context, d *cdcevent.EventDescriptor) e
 		}
 	}
 
-	evaluator := newExprEval(e.evalCtx, d, tableNameOrAlias(d.TableName, e.from))
+	target, _ := splitFromClause(e.from)
+	lookups, err := makeLookupTables(e.joins, lookupDescs, len(d.ResultColumns()), d.SchemaTS)
+	if err != nil {
+		return err
+	}
+	evaluator := newExprEval(e.evalCtx, d, tableNameOrAlias(d.TableName, target), lookups, e.lookups)
+	if err := evaluator.addLookupKeys(ctx); err != nil {
+		return err
+	}
 	for _, selector := range e.selectors {
 		if err := evaluator.addSelector(ctx, selector, len(e.selectors)); err != nil {
 			return err
@@ -211,6 +257,9 @@ type exprEval struct {
 	projection     cdcevent.Projection // cdcevent.Projects helps construct projection results.
 	filter         tree.TypedExpr      // where clause filter
 
+	lookups      []*lookupTable // tables joined by the expression.
+	lookupReader LookupReader   // reads the rows of lookup tables.
+
 	// keep track of number of times particular column name was used
 	// in selectors.  Since the data produced by CDC gets converted
 	// to the formats (JSON, avro, etc.) that may not like having multiple
@@ -223,17 +272,29 @@ type exprEval struct {
 }
 
 func newExprEval(
-	evalCtx *eval.Context, ed *cdcevent.EventDescriptor, tableName *tree.TableName,
+	evalCtx *eval.Context,
+	ed *cdcevent.EventDescriptor,
+	tableName *tree.TableName,
+	lookups []*lookupTable,
+	lookupReader LookupReader,
 ) *exprEval {
 	cols := ed.ResultColumns()
+	// The columns of lookup tables follow the columns of the event.
+	vars := cols
+	for _, l := range lookups {
+		vars = append(vars[:len(vars):len(vars)], l.ResultColumns()...)
+	}
 	e := &exprEval{
 		EventDescriptor: ed,
 		semaCtx:         newSemaCtxWithTypeResolver(ed),
 		evalCtx:         evalCtx.Copy(),
-		evalHelper:      &rowContainer{cols: cols},
+		evalHelper:      &rowContainer{cols: vars},
 		projection:      cdcevent.MakeProjection(ed),
 		nameUseCount:    make(map[string]int),
+		lookups:         lookups,
+		lookupReader:    lookupReader,
 	}
+	e.rowEvalCtx.lookupRows = make([]cdcevent.Row, len(lookups))
 
 	evalCtx = nil // From this point, only e.evalCtx should be used.
 
@@ -260,13 +321,15 @@ func newExprEval(
 		return rc
 	}
 
-	e.iVarHelper = tree.MakeIndexedVarHelper(e.evalHelper, len(cols))
+	e.iVarHelper = tree.MakeIndexedVarHelper(e.evalHelper, len(vars))
 	e.resolver = cdcNameResolver{
 		EventDescriptor: ed,
 		NameResolutionVisitor: schemaexpr.MakeNameResolutionVisitor(
 			colinfo.NewSourceInfoForSingleTable(*tableName, nakedResultColumns()),
 			e.iVarHelper,
 		),
+		iVarHelper: e.iVarHelper,
+		lookups:    lookups,
 	}
 
 	return e
@@ -277,6 +340,7 @@ type rowEvalContext struct {
 	mvccTS     hlc.Timestamp
 	updatedRow cdcevent.Row
 	prevRow    cdcevent.Row
+	lookupRows []cdcevent.Row // Rows of lookup tables; uninitialized if not found.
 	memo       struct {
 		prevJSON tree.Datum
 	}
@@ -291,11 +355,64 @@ func (e *exprEval) setupContext(
 	e.rowEvalCtx.mvccTS = mvccTS
 	e.evalCtx.TxnTimestamp = mvccTS.GoTime()
 	e.evalCtx.StmtTimestamp = mvccTS.GoTime()
+	for i := range e.rowEvalCtx.lookupRows {
+		e.rowEvalCtx.lookupRows[i] = cdcevent.Row{}
+	}
 
 	// Clear out all memo records
 	e.rowEvalCtx.memo.prevJSON = nil
 }
 
+// datumAt returns the value of the variable at the specified ordinal.
+// Variables refer to the columns of the updated row, followed by the columns
+// of each lookup table.
+func (e *exprEval) datumAt(idx int) (tree.Datum, error) {
+	for i := len(e.lookups) - 1; i >= 0; i-- {
+		if l := e.lookups[i]; idx >= l.offset {
+			row := e.rowEvalCtx.lookupRows[i]
+			if !row.IsInitialized() {
+				return tree.DNull, nil
+			}
+			return row.DatumAt(idx - l.offset)
+		}
+	}
+	return e.rowEvalCtx.updatedRow.DatumAt(idx)
+}
+
+// lookup reads the rows of the lookup tables matching the updated row.
+// Returns false if a lookup table joined with an inner join has no matching
+// row. Must be called after setupContext has been called.
+func (e *exprEval) lookup(ctx context.Context) (bool, error) {
+	for i, l := range e.lookups {
+		for k, expr := range l.keyExprs {
+			d, err := e.evalExpr(ctx, expr, expr.ResolvedType())
+			if err != nil {
+				return false, err
+			}
+			l.keyValues[k] = d
+		}
+		desc := l.TableDescriptor()
+		key, containsNull, err := rowenc.EncodeIndexKey(
+			desc, desc.GetPrimaryIndex(), l.colMap, l.keyValues, l.keyPrefix)
+		if err != nil {
+			return false, err
+		}
+		// NULL never equals anything, so there can't be a matching row.
+		if !containsNull {
+			row, err := e.lookupReader.ReadRow(
+				ctx, desc, keys.MakeFamilyKey(key, 0), e.rowEvalCtx.mvccTS)
+			if err != nil {
+				return false, err
+			}
+			e.rowEvalCtx.lookupRows[i] = row
+		}
+		if !e.rowEvalCtx.lookupRows[i].IsInitialized() && !l.outer {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // evalProjection responsible for evaluating projection expression.
 // Returns new projection Row.
 func (e *exprEval) evalProjection(
@@ -306,6 +423,11 @@ func (e *exprEval) evalProjection(
 	}
 
 	e.setupContext(updatedRow, mvccTS, prevRow)
+	// If a lookup table joined with an inner join has no matching row, the
+	// event should have been filtered out; its columns are NULL.
+	if _, err := e.lookup(ctx); err != nil {
+		return cdcevent.Row{}, err
+	}
 
 	for i, expr := range e.selectors {
 		d, err := e.evalExpr(ctx, expr, types.Any)
@@ -324,11 +446,17 @@ func (e *exprEval) evalProjection(
 func (e *exprEval) matchesFilter(
 	ctx context.Context, updatedRow cdcevent.Row, mvccTS hlc.Timestamp, prevRow cdcevent.Row,
 ) (bool, error) {
-	if e.filter == nil {
+	if e.filter == nil && len(e.lookups) == 0 {
 		return true, nil
 	}
 
 	e.setupContext(updatedRow, mvccTS, prevRow)
+	if matches, err := e.lookup(ctx); err != nil || !matches {
+		return false, err
+	}
+	if e.filter == nil {
+		return true, nil
+	}
 	d, err := e.evalExpr(ctx, e.filter, types.Bool)
 	if err != nil {
 		return false, err
@@ -479,13 +607,13 @@ func (e *exprEval) evalExpr(
 	case tree.Datum:
 		return t, nil
 	case *tree.IndexedVar:
-		d, err := e.rowEvalCtx.updatedRow.DatumAt(t.Idx)
+		d, err := e.datumAt(t.Idx)
 		if err != nil {
 			return nil, err
 		}
 		return d, nil
 	default:
-		v := replaceIndexVarVisitor{row: e.rowEvalCtx.updatedRow}
+		v := replaceIndexVarVisitor{datumAt: e.datumAt}
 		newExpr, _ := tree.WalkExpr(&v, expr)
 		if v.err != nil {
 			return nil, v.err
@@ -557,6 +685,11 @@ type cdcNameResolver struct {
 	schemaexpr.NameResolutionVisitor
 	*cdcevent.EventDescriptor
 	err error
+
+	// Columns of lookup tables must be qualified with the table name or alias,
+	// and are resolved to the variables following the event columns.
+	iVarHelper tree.IndexedVarHelper
+	lookups    []*lookupTable
 }
 
 // tag errors generated by cdcNameResolver.
@@ -584,6 +717,25 @@ func (v *cdcNameResolver) wrapError() func() {
 
 // VisitPre implements tree.Visitor interface.
 func (v *cdcNameResolver) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err == nil {
+		for _, l := range v.lookups {
+			colName, ok := lookupColumnName(l.alias, expr)
+			if !ok {
+				continue
+			}
+			for i, col := range l.ResultColumns() {
+				if col.Name == string(colName) {
+					return false, v.iVarHelper.IndexedVar(l.offset + i)
+				}
+			}
+			v.err = &cdcResolverError{
+				error: pgerror.Newf(pgcode.UndefinedColumn,
+					"column %q does not exist in lookup table %s", colName, l.alias),
+			}
+			return false, expr
+		}
+	}
+
 	defer v.wrapError()()
 	recurse, newExpr = v.NameResolutionVisitor.VisitPre(expr)
 	return v.err == nil, newExpr
@@ -598,6 +750,15 @@ func (v *cdcNameResolver) VisitPost(expr tree.Expr) tree.Expr {
 	case *tree.AllColumnsSelector:
 		// AllColumnsSelector occurs when "x.*" is used.  We have a simple 1 table support,
 		// so make sure table names match.
+		for _, l := range v.lookups {
+			if t.TableName.String() == string(l.alias) {
+				v.err = &cdcResolverError{
+					error: pgerror.Newf(pgcode.FeatureNotSupported,
+						"star expansion of lookup table %s is not supported", l.alias),
+				}
+				return t
+			}
+		}
 		if t.TableName.String() != v.TableName {
 			v.err = &cdcResolverError{
 				error: pgerror.Newf(pgcode.UndefinedTable, "no data source matches pattern: %s", t.String()),
@@ -690,8 +851,8 @@ func (c *rowContainer) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
 }
 
 type replaceIndexVarVisitor struct {
-	row cdcevent.Row
-	err error
+	datumAt func(idx int) (tree.Datum, error)
+	err     error
 }
 
 var _ tree.Visitor = (*replaceIndexVarVisitor)(nil)
@@ -699,7 +860,7 @@ var _ tree.Visitor = (*replaceIndexVarVisitor)(nil)
 // VisitPre implements tree.Visitor interface.
 func (v *replaceIndexVarVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	if iVar, ok := expr.(*tree.IndexedVar); ok {
-		datum, err := v.row.DatumAt(iVar.Idx)
+		datum, err := v.datumAt(iVar.Idx)
 		if err != nil {
 			v.err = pgerror.Wrapf(err, pgcode.NumericValueOutOfRange, "variable @%d out of bounds", iVar.Idx)
 			return false, expr
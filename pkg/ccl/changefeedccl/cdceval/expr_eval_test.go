@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -32,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -417,7 +419,7 @@ CREATE TABLE foo (
 
 			serverCfg := s.DistSQLServer().(*distsql.ServerImpl).ServerConfig
 			ctx := context.Background()
-			decoder, err := cdcevent.NewEventDecoder(ctx, &serverCfg, targets, false, false)
+			decoder, err := cdcevent.NewEventDecoder(ctx, &serverCfg, targets, false, false, false)
 			require.NoError(t, err)
 
 			for _, action := range tc.setupActions {
@@ -606,6 +608,140 @@ func TestEvaluatesProjection(t *testing.T) {
 	}
 }
 
+func TestEvaluatesLookupJoin(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.ExecMultiple(t,
+		`CREATE TABLE orders (id INT PRIMARY KEY, customer_id INT)`,
+		`CREATE TABLE customers (id INT PRIMARY KEY, name STRING)`,
+	)
+	ordersDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "orders")
+	customersDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "customers")
+
+	serverCfg := s.DistSQLServer().(*distsql.ServerImpl).ServerConfig
+	ctx := context.Background()
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:    jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID: ordersDesc.GetID(),
+	})
+	decoder, err := cdcevent.NewEventDecoder(ctx, &serverCfg, targets, false, false, false)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		join   string
+		expect map[string]string // Customer names keyed by order ID; missing if filtered.
+	}{
+		{
+			name:   "inner",
+			join:   "JOIN",
+			expect: map[string]string{"1": "alice", "3": "bob"},
+		},
+		{
+			name:   "left",
+			join:   "LEFT JOIN",
+			expect: map[string]string{"1": "alice", "2": "NULL", "3": "bob"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB.Exec(t, "DELETE FROM orders WHERE true")
+			sqlDB.Exec(t, "UPSERT INTO customers VALUES (1, 'alice')")
+
+			stmt, err := parser.ParseOne(fmt.Sprintf(
+				"SELECT o.id, c.name FROM [%d AS o] %s [%d AS c] ON c.id = o.customer_id",
+				ordersDesc.GetID(), tc.join, customersDesc.GetID()))
+			require.NoError(t, err)
+			slct := stmt.AST.(*tree.Select).Select.(*tree.SelectClause)
+			lookups, err := NewLookupReader(ctx, &serverCfg, slct)
+			require.NoError(t, err)
+			evalCtx := eval.MakeTestingEvalContext(s.ClusterSettings())
+			evaluator, err := NewEvaluator(ctx, &evalCtx, slct, lookups)
+			require.NoError(t, err)
+
+			popRow, cleanup := cdctest.MakeRangeFeedValueReader(t, s.ExecutorConfig(), ordersDesc)
+			defer cleanup()
+
+			// Rows are looked up as of the time of each event.
+			sqlDB.Exec(t, "INSERT INTO orders VALUES (1, 1), (2, 2)")
+			sqlDB.Exec(t, "UPDATE customers SET name = 'bob' WHERE id = 1")
+			sqlDB.Exec(t, "INSERT INTO orders VALUES (3, 1)")
+
+			actual := make(map[string]string)
+			for _, v := range readSortedRangeFeedValues(t, 3, popRow) {
+				updatedRow := decodeRow(t, decoder, &v, false)
+				prevRow := decodeRow(t, decoder, &v, true)
+				matches, err := evaluator.MatchesFilter(ctx, updatedRow, v.Timestamp(), prevRow)
+				require.NoError(t, err)
+				if !matches {
+					continue
+				}
+				projection, err := evaluator.Projection(ctx, updatedRow, v.Timestamp(), prevRow)
+				require.NoError(t, err)
+				values := slurpValues(t, projection)
+				actual[values["id"]] = values["name"]
+			}
+			require.Equal(t, tc.expect, actual)
+		})
+	}
+}
+
+func TestLookupReaderCachesRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.ExecMultiple(t,
+		`CREATE TABLE orders (id INT PRIMARY KEY, customer_id INT)`,
+		`CREATE TABLE customers (id INT PRIMARY KEY, name STRING)`,
+		`INSERT INTO customers VALUES (1, 'alice')`,
+	)
+	ordersDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "orders")
+	customersDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "customers")
+
+	serverCfg := s.DistSQLServer().(*distsql.ServerImpl).ServerConfig
+	ctx := context.Background()
+	stmt, err := parser.ParseOne(fmt.Sprintf(
+		"SELECT o.id, c.name FROM [%d AS o] JOIN [%d AS c] ON c.id = o.customer_id",
+		ordersDesc.GetID(), customersDesc.GetID()))
+	require.NoError(t, err)
+	lookups, err := NewLookupReader(ctx, &serverCfg, stmt.AST.(*tree.Select).Select.(*tree.SelectClause))
+	require.NoError(t, err)
+	reader := lookups.(*kvLookupReader)
+
+	key := keys.MakeFamilyKey(encoding.EncodeVarintAscending(rowenc.MakeIndexKeyPrefix(
+		s.Codec(), customersDesc.GetID(), customersDesc.GetPrimaryIndexID()), 1), 0)
+	expectName := func(ts hlc.Timestamp, expect string, expectReads int) {
+		t.Helper()
+		row, err := reader.ReadRow(ctx, customersDesc, key, ts)
+		require.NoError(t, err)
+		name, err := row.DatumAt(1)
+		require.NoError(t, err)
+		require.Equal(t, tree.NewDString(expect), name)
+		require.Equal(t, expectReads, reader.reads)
+	}
+
+	// Rows read for an event are reused for later events.
+	aliceTS := s.Clock().Now()
+	expectName(aliceTS, "alice", 1)
+	expectName(s.Clock().Now(), "alice", 1)
+
+	// Rows written after the cached row was read are read again.
+	sqlDB.Exec(t, "UPDATE customers SET name = 'bob' WHERE id = 1")
+	expectName(s.Clock().Now(), "bob", 2)
+
+	// Events which precede the cached row read the row as of their timestamp.
+	expectName(aliceTS, "alice", 4)
+}
+
 // makeEvaluator creates Evaluator and configures it with specified
 // select statement predicate.
 func makeEvaluator(t *testing.T, st *cluster.Settings, selectStr string) (*Evaluator, error) {
@@ -614,7 +750,7 @@ func makeEvaluator(t *testing.T, st *cluster.Settings, selectStr string) (*Evalu
 	require.NoError(t, err)
 	slct := s.AST.(*tree.Select).Select.(*tree.SelectClause)
 	evalCtx := eval.MakeTestingEvalContext(st)
-	return NewEvaluator(context.Background(), &evalCtx, slct)
+	return NewEvaluator(context.Background(), &evalCtx, slct, nil)
 }
 
 func makeExprEval(
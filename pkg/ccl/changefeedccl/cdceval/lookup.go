@@ -0,0 +1,448 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package cdceval
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// LookupReader reads the rows of the lookup tables joined by a changefeed
+// expression.
+type LookupReader interface {
+	// TableDescriptor returns the descriptor of the lookup table as of the
+	// specified timestamp.
+	TableDescriptor(ctx context.Context, id descpb.ID, ts hlc.Timestamp) (catalog.TableDescriptor, error)
+
+	// ReadRow returns the row of the lookup table stored at the specified
+	// primary key, as of the specified timestamp. The returned row is not
+	// initialized if there is no such row.
+	ReadRow(
+		ctx context.Context, desc catalog.TableDescriptor, key roachpb.Key, ts hlc.Timestamp,
+	) (cdcevent.Row, error)
+}
+
+// lookupJoin describes a lookup table joined by a changefeed expression.
+type lookupJoin struct {
+	tableID descpb.ID
+	alias   tree.Name
+	outer   bool      // Set for LEFT JOIN.
+	cond    tree.Expr // Join condition.
+}
+
+// makeLookupJoin returns lookupJoin for the normalized join expression.
+func makeLookupJoin(j *tree.JoinTableExpr) (lookupJoin, error) {
+	ref, ok := j.Right.(*tree.TableRef)
+	if !ok {
+		return lookupJoin{}, errors.AssertionFailedf("expected table reference, found %T", j.Right)
+	}
+	on, ok := j.Cond.(*tree.OnJoinCond)
+	if !ok {
+		return lookupJoin{}, errors.AssertionFailedf("expected ON condition, found %T", j.Cond)
+	}
+	return lookupJoin{
+		tableID: descpb.ID(ref.TableID),
+		alias:   ref.As.Alias,
+		outer:   j.JoinType == tree.AstLeft,
+		cond:    on.Expr,
+	}, nil
+}
+
+// lookupTable is a lookup table being joined while evaluating events.
+type lookupTable struct {
+	lookupJoin
+	*cdcevent.EventDescriptor // Describes the columns of the lookup table.
+
+	// offset is the ordinal of the first column of the lookup table among the
+	// variables of the expressions.
+	offset int
+
+	keyExprs  []tree.TypedExpr    // Values of the primary key columns, in index order.
+	keyValues tree.Datums         // Evaluated keyExprs.
+	colMap    catalog.TableColMap // Maps primary key column IDs to keyValues.
+	keyPrefix []byte              // Primary index prefix.
+}
+
+// lookupColumnName returns the name of the column referenced by the
+// expression if the expression references a column of the table with the
+// specified alias.
+func lookupColumnName(alias tree.Name, expr tree.Expr) (tree.Name, bool) {
+	if n, ok := expr.(*tree.UnresolvedName); ok {
+		vn, err := n.NormalizeVarName()
+		if err != nil {
+			return "", false
+		}
+		expr = vn
+	}
+	c, ok := expr.(*tree.ColumnItem)
+	if !ok || c.TableName == nil || c.TableName.NumParts != 1 {
+		return "", false
+	}
+	return c.ColumnName, tree.Name(c.TableName.Parts[0]) == alias
+}
+
+// referencesTable returns true if the expression references columns of the
+// table with the specified alias.
+func referencesTable(alias tree.Name, expr tree.Expr) (found bool) {
+	_, _ = tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if _, ok := lookupColumnName(alias, expr); ok {
+			found = true
+		}
+		sel := expr
+		if n, ok := expr.(*tree.UnresolvedName); ok && n.Star {
+			if vn, err := n.NormalizeVarName(); err == nil {
+				sel = vn
+			}
+		}
+		if s, ok := sel.(*tree.AllColumnsSelector); ok && s.TableName.NumParts == 1 &&
+			tree.Name(s.TableName.Parts[0]) == alias {
+			found = true
+		}
+		return !found, expr, nil
+	})
+	return found
+}
+
+// lookupKeyExprs decomposes the join condition of a lookup join into the
+// expressions for the primary key columns of the lookup table, in index order.
+// The join condition must be a conjunction of equalities between each of the
+// primary key columns and an expression which doesn't reference the lookup
+// table.
+func lookupKeyExprs(
+	alias tree.Name, desc catalog.TableDescriptor, cond tree.Expr,
+) ([]tree.Expr, error) {
+	primaryIdx := desc.GetPrimaryIndex()
+	keyColumnNames := make([]string, primaryIdx.NumKeyColumns())
+	for i := range keyColumnNames {
+		keyColumnNames[i] = primaryIdx.GetKeyColumnName(i)
+	}
+	invalidCondErr := func() error {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"join condition for lookup table %s must equate each of its primary key columns (%s) "+
+				"with an expression", alias, strings.Join(keyColumnNames, ", "))
+	}
+
+	var conjuncts []tree.Expr
+	var split func(e tree.Expr)
+	split = func(e tree.Expr) {
+		switch t := e.(type) {
+		case *tree.AndExpr:
+			split(t.Left)
+			split(t.Right)
+		case *tree.ParenExpr:
+			split(t.Expr)
+		default:
+			conjuncts = append(conjuncts, e)
+		}
+	}
+	split(cond)
+
+	keyExprs := make([]tree.Expr, len(keyColumnNames))
+	for _, c := range conjuncts {
+		cmp, ok := c.(*tree.ComparisonExpr)
+		if !ok || cmp.Operator.Symbol != treecmp.EQ {
+			return nil, invalidCondErr()
+		}
+		colName, ok := lookupColumnName(alias, cmp.Left)
+		expr := cmp.Right
+		if !ok {
+			colName, ok = lookupColumnName(alias, cmp.Right)
+			expr = cmp.Left
+		}
+		if !ok || referencesTable(alias, expr) {
+			return nil, invalidCondErr()
+		}
+		found := false
+		for i, name := range keyColumnNames {
+			if name == string(colName) {
+				if keyExprs[i] != nil {
+					return nil, invalidCondErr()
+				}
+				keyExprs[i] = expr
+				found = true
+			}
+		}
+		if !found {
+			return nil, invalidCondErr()
+		}
+	}
+	for _, expr := range keyExprs {
+		if expr == nil {
+			return nil, invalidCondErr()
+		}
+	}
+	return keyExprs, nil
+}
+
+// makeLookupTables returns the lookup tables for the joins, using the
+// specified descriptors. numEventCols is the number of columns of the event
+// which precede the columns of the lookup tables among the variables.
+func makeLookupTables(
+	joins []lookupJoin, descs []catalog.TableDescriptor, numEventCols int, schemaTS hlc.Timestamp,
+) ([]*lookupTable, error) {
+	lookups := make([]*lookupTable, len(joins))
+	offset := numEventCols
+	for i, j := range joins {
+		desc := descs[i]
+		family, err := desc.FindFamilyByID(0)
+		if err != nil {
+			return nil, err
+		}
+		ed, err := cdcevent.NewEventDescriptor(desc, family, false, false, schemaTS)
+		if err != nil {
+			return nil, err
+		}
+		lookups[i] = &lookupTable{lookupJoin: j, EventDescriptor: ed, offset: offset}
+		offset += len(ed.ResultColumns())
+	}
+	return lookups, nil
+}
+
+// sameLookupVersions returns true if the lookup tables were initialized with
+// the same versions of the specified descriptors.
+func (e *exprEval) sameLookupVersions(descs []catalog.TableDescriptor) bool {
+	for i, desc := range descs {
+		if e.lookups[i].Version != desc.GetVersion() {
+			return false
+		}
+	}
+	return true
+}
+
+// addLookupKeys type checks the expressions for the primary keys of the
+// lookup tables. The expressions may only reference the columns of the event,
+// and of the preceding lookup tables.
+func (e *exprEval) addLookupKeys(ctx context.Context) error {
+	defer func() {
+		e.resolver.lookups = e.lookups
+	}()
+
+	for i, l := range e.lookups {
+		e.resolver.lookups = e.lookups[:i]
+		desc := l.TableDescriptor()
+		keyExprs, err := lookupKeyExprs(l.alias, desc, l.cond)
+		if err != nil {
+			return err
+		}
+
+		primaryIdx := desc.GetPrimaryIndex()
+		l.keyExprs = make([]tree.TypedExpr, len(keyExprs))
+		l.keyValues = make(tree.Datums, len(keyExprs))
+		for k, expr := range keyExprs {
+			col, err := desc.FindColumnWithID(primaryIdx.GetKeyColumnID(k))
+			if err != nil {
+				return err
+			}
+			typedExpr, err := e.typeCheck(ctx, expr, col.GetType())
+			if err != nil {
+				return err
+			}
+			if !typedExpr.ResolvedType().Equivalent(col.GetType()) {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"join condition for lookup table %s equates column %s of type %s with %s of type %s",
+					l.alias, col.GetName(), col.GetType().SQLString(),
+					expr, typedExpr.ResolvedType().SQLString())
+			}
+			l.keyExprs[k] = typedExpr
+			l.colMap.Set(col.GetID(), k)
+		}
+		l.keyPrefix = rowenc.MakeIndexKeyPrefix(e.evalCtx.Codec, desc.GetID(), primaryIdx.GetID())
+	}
+	return nil
+}
+
+// lookupDescriptors is a LookupReader which only provides descriptors
+// resolved when the changefeed is created, for validating expressions.
+type lookupDescriptors map[descpb.ID]catalog.TableDescriptor
+
+var _ LookupReader = lookupDescriptors(nil)
+
+// TableDescriptor implements LookupReader.
+func (d lookupDescriptors) TableDescriptor(
+	_ context.Context, id descpb.ID, _ hlc.Timestamp,
+) (catalog.TableDescriptor, error) {
+	desc, ok := d[id]
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected lookup table %d", id)
+	}
+	return desc, nil
+}
+
+// ReadRow implements LookupReader.
+func (d lookupDescriptors) ReadRow(
+	context.Context, catalog.TableDescriptor, roachpb.Key, hlc.Timestamp,
+) (cdcevent.Row, error) {
+	return cdcevent.Row{}, errors.AssertionFailedf("lookup tables can't be read during validation")
+}
+
+// kvLookupReader is a LookupReader which reads lookup tables from KV.
+//
+// Looked up rows are cached: reading a key at a timestamp, and finding a
+// value written at an earlier timestamp, means that the key has the same
+// value at any timestamp in between, so the cached row is reused for events
+// within that interval. Since events are emitted well after they were
+// written, keys are read at the current time rather than at the time of the
+// event, which makes the interval extend past all the events which are
+// pending; the key is only read again at the time of the event if it is
+// missing, or was written after the event.
+type kvLookupReader struct {
+	db       *kv.DB
+	leaseMgr *lease.Manager
+	decoder  cdcevent.Decoder
+	rows     *cache.UnorderedCache
+
+	// reads is the number of keys read from KV, for testing.
+	reads int
+}
+
+// cachedLookupRow is a looked up row; the row is valid at any timestamp
+// between valueTS and readTS.
+type cachedLookupRow struct {
+	row     cdcevent.Row
+	version descpb.DescriptorVersion
+	valueTS hlc.Timestamp
+	readTS  hlc.Timestamp
+}
+
+var _ LookupReader = (*kvLookupReader)(nil)
+
+// NewLookupReader returns a LookupReader for the lookup tables joined by the
+// select clause, or nil if the select clause doesn't join any tables.
+func NewLookupReader(
+	ctx context.Context, cfg *execinfra.ServerConfig, sc *tree.SelectClause,
+) (LookupReader, error) {
+	if len(sc.From.Tables) != 1 {
+		return nil, nil
+	}
+	_, joins := splitFromClause(sc.From.Tables[0])
+	if len(joins) == 0 {
+		return nil, nil
+	}
+
+	var targets changefeedbase.Targets
+	for _, j := range joins {
+		lj, err := makeLookupJoin(j)
+		if err != nil {
+			return nil, err
+		}
+		targets.Add(changefeedbase.Target{
+			Type:    jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID: lj.tableID,
+		})
+	}
+	decoder, err := cdcevent.NewEventDecoder(ctx, cfg, targets, false, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return &kvLookupReader{
+		db:       cfg.DB,
+		leaseMgr: cfg.LeaseManager.(*lease.Manager),
+		decoder:  decoder,
+		rows:     cache.NewUnorderedCache(cdcevent.DefaultCacheConfig),
+	}, nil
+}
+
+// TableDescriptor implements LookupReader.
+func (r *kvLookupReader) TableDescriptor(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (catalog.TableDescriptor, error) {
+	desc, err := r.leaseMgr.Acquire(ctx, ts, id)
+	if err != nil {
+		if errors.Is(err, catalog.ErrDescriptorDropped) {
+			return nil, changefeedbase.WithTerminalError(
+				errors.Wrapf(err, "lookup table %d", id))
+		}
+		return nil, err
+	}
+	// Immediately release the lease, since we only need it for the exact
+	// timestamp requested.
+	defer desc.Release(ctx)
+	return desc.Underlying().(catalog.TableDescriptor), nil
+}
+
+// ReadRow implements LookupReader.
+func (r *kvLookupReader) ReadRow(
+	ctx context.Context, desc catalog.TableDescriptor, key roachpb.Key, ts hlc.Timestamp,
+) (cdcevent.Row, error) {
+	if v, ok := r.rows.Get(string(key)); ok {
+		cached := v.(*cachedLookupRow)
+		if cached.version == desc.GetVersion() &&
+			cached.valueTS.LessEq(ts) && ts.LessEq(cached.readTS) {
+			return cached.row, nil
+		}
+	}
+
+	// Read the key at the current time; if the value predates the event, it
+	// remains valid until now. Otherwise, including when the key is missing,
+	// which may be the result of a deletion after the event, read the key
+	// again at the time of the event.
+	readTS := r.db.Clock().Now()
+	if readTS.Less(ts) {
+		readTS = ts
+	}
+	value, err := r.get(ctx, key, readTS)
+	if err != nil {
+		return cdcevent.Row{}, err
+	}
+	if value == nil || ts.Less(value.Timestamp) {
+		readTS = ts
+		if value, err = r.get(ctx, key, readTS); err != nil {
+			return cdcevent.Row{}, err
+		}
+	}
+
+	cached := &cachedLookupRow{version: desc.GetVersion(), valueTS: ts, readTS: readTS}
+	if value != nil {
+		row, err := r.decoder.DecodeKV(ctx, roachpb.KeyValue{Key: key, Value: *value}, ts, false)
+		if err != nil {
+			return cdcevent.Row{}, err
+		}
+		cached.row = row
+		cached.valueTS = value.Timestamp
+	}
+	r.rows.Add(string(key), cached)
+	return cached.row, nil
+}
+
+// get reads the value of the key at the specified timestamp.
+func (r *kvLookupReader) get(
+	ctx context.Context, key roachpb.Key, ts hlc.Timestamp,
+) (*roachpb.Value, error) {
+	r.reads++
+	var value *roachpb.Value
+	if err := r.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		res, err := txn.Get(ctx, key)
+		value = res.Value
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
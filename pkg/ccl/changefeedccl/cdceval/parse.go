@@ -45,3 +45,37 @@ func tableNameOrAlias(name string, expr tree.TableExpr) *tree.TableName {
 	}
 	return tree.NewUnqualifiedTableName(tree.Name(name))
 }
+
+// splitFromClause splits the table expression in the FROM clause of a
+// changefeed expression into the expression for the target table and the
+// lookup joins, in the order they were specified.
+func splitFromClause(from tree.TableExpr) (target tree.TableExpr, joins []*tree.JoinTableExpr) {
+	for {
+		j, ok := from.(*tree.JoinTableExpr)
+		if !ok {
+			break
+		}
+		joins = append(joins, j)
+		from = j.Left
+	}
+	// Joins were collected starting with the last one.
+	for i, k := 0, len(joins)-1; i < k; i, k = i+1, k-1 {
+		joins[i], joins[k] = joins[k], joins[i]
+	}
+	return from, joins
+}
+
+// joinConditions returns the ON conditions of the lookup joins in the
+// select clause.
+func joinConditions(sc *tree.SelectClause) (conds []tree.Expr) {
+	if len(sc.From.Tables) != 1 {
+		return nil
+	}
+	_, joins := splitFromClause(sc.From.Tables[0])
+	for _, j := range joins {
+		if on, ok := j.Cond.(*tree.OnJoinCond); ok {
+			conds = append(conds, on.Expr)
+		}
+	}
+	return conds
+}
@@ -18,8 +18,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
@@ -79,8 +81,11 @@ func NormalizeAndValidateSelectForTarget(
 		return n, target, errors.AssertionFailedf("expected non-nil transaction")
 	}
 
-	// Perform normalization.
-	var err error
+	// Resolve lookup tables, and perform normalization.
+	lookups, err := normalizeLookupJoins(ctx, execCtx, sc, desc)
+	if err != nil {
+		return n, target, err
+	}
 	normalized, err := normalizeSelectClause(ctx, *execCtx.SemaCtx(), sc, desc)
 	if err != nil {
 		return n, target, err
@@ -118,7 +123,7 @@ func NormalizeAndValidateSelectForTarget(
 
 	// Construct and initialize evaluator.  This performs some static checks,
 	// and (importantly) type checks expressions.
-	evaluator, err := NewEvaluator(ctx, evalCtx, sc)
+	evaluator, err := NewEvaluator(ctx, evalCtx, sc, lookups)
 	if err != nil {
 		return n, target, err
 	}
@@ -126,6 +131,96 @@ func NormalizeAndValidateSelectForTarget(
 	return normalized, target, evaluator.initEval(ctx, ed)
 }
 
+// normalizeLookupJoins resolves the lookup tables joined by the select
+// clause, and replaces their names with table references. Returns the
+// descriptors of the lookup tables, or nil if there are none.
+func normalizeLookupJoins(
+	ctx context.Context,
+	execCtx sql.PlanHookState,
+	sc *tree.SelectClause,
+	desc catalog.TableDescriptor,
+) (lookupDescriptors, error) {
+	target, joins := splitFromClause(sc.From.Tables[0])
+	if len(joins) == 0 {
+		return nil, nil
+	}
+
+	aliases := map[tree.Name]struct{}{
+		tableNameOrAlias(desc.GetName(), target).ObjectName: {},
+	}
+	descs := make(lookupDescriptors)
+	for _, j := range joins {
+		if _, isOn := j.Cond.(*tree.OnJoinCond); !isOn || j.Hint != "" ||
+			(j.JoinType != "" && j.JoinType != tree.AstInner && j.JoinType != tree.AstLeft) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"invalid CDC expression: only inner and left lookup joins with ON conditions are supported")
+		}
+
+		var tn *tree.TableName
+		var alias tree.AliasClause
+		switch t := j.Right.(type) {
+		case *tree.TableName:
+			tn = t
+		case *tree.AliasedTableExpr:
+			tn, _ = t.Expr.(*tree.TableName)
+			alias = t.As
+		}
+		if tn == nil {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"invalid CDC expression: only tables can be joined")
+		}
+
+		_, lookupDesc, err := resolver.ResolveExistingTableObject(
+			ctx, execCtx, tn, tree.ObjectLookupFlagsWithRequired())
+		if err != nil {
+			return nil, err
+		}
+		if err := validateLookupTable(lookupDesc); err != nil {
+			return nil, err
+		}
+		if err := execCtx.CheckPrivilege(ctx, lookupDesc, privilege.SELECT); err != nil {
+			return nil, err
+		}
+
+		if alias.Alias == "" {
+			alias.Alias = tree.Name(lookupDesc.GetName())
+		}
+		if _, found := aliases[alias.Alias]; found {
+			return nil, pgerror.Newf(pgcode.DuplicateAlias,
+				"source name %q specified more than once", alias.Alias)
+		}
+		aliases[alias.Alias] = struct{}{}
+
+		descs[lookupDesc.GetID()] = lookupDesc
+		j.Right = &tree.TableRef{
+			TableID: int64(lookupDesc.GetID()),
+			As:      alias,
+		}
+		if j.JoinType == tree.AstInner {
+			j.JoinType = ""
+		}
+	}
+	return descs, nil
+}
+
+// validateLookupTable verifies that the rows of the table can be looked up
+// while evaluating changefeed expressions.
+func validateLookupTable(desc catalog.TableDescriptor) error {
+	if !desc.IsTable() || desc.IsVirtualTable() {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"lookup table %s is not a table", desc.GetName())
+	}
+	if desc.NumFamilies() != 1 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"lookup table %s must have a single column family", desc.GetName())
+	}
+	if desc.ContainsUserDefinedTypes() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"lookup table %s must not use user defined types", desc.GetName())
+	}
+	return nil
+}
+
 func setTargetType(
 	desc catalog.TableDescriptor,
 	target jobspb.ChangefeedTargetSpecification,
@@ -258,23 +353,29 @@ func normalizeSelectClause(
 	// won't be able to deserialize string representation (grammar requires
 	// "select ... from [table_id as alias]")
 	var alias tree.AliasClause
-	switch t := sc.From.Tables[0].(type) {
+	target, joins := splitFromClause(sc.From.Tables[0])
+	switch t := target.(type) {
 	case *tree.AliasedTableExpr:
 		alias = t.As
 	case tree.TablePattern:
 	default:
 		// This is verified by sql.y -- but be safe.
 		return normalizedSelectClause, errors.AssertionFailedf("unexpected table expression type %T",
-			sc.From.Tables[0])
+			target)
 	}
 
 	if alias.Alias == "" {
 		alias.Alias = tree.Name(desc.GetName())
 	}
-	sc.From.Tables[0] = &tree.TableRef{
+	targetRef := &tree.TableRef{
 		TableID: int64(desc.GetID()),
 		As:      alias,
 	}
+	if len(joins) == 0 {
+		sc.From.Tables[0] = targetRef
+	} else {
+		joins[0].Left = targetRef
+	}
 
 	// Setup sema ctx to handle cdc expressions. We want to make sure we only
 	// override some properties, while keeping other properties (type resolver)
@@ -297,7 +398,7 @@ func normalizeSelectClause(
 		OIDs: make(map[oid.Oid]struct{}),
 	}
 
-	stmt, err := tree.SimpleStmtVisit(sc, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+	normalizeExpr := func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
 		// Replace type references with resolved type.
 		switch e := expr.(type) {
 		case *tree.AnnotateTypeExpr:
@@ -327,8 +428,20 @@ func normalizeSelectClause(
 		// Collect resolved type OIDs.
 		recurse, newExpr = v.VisitPre(expr)
 		return recurse, newExpr, nil
-	})
+	}
 
+	// Join conditions aren't visited as part of the statement.
+	for _, j := range joins {
+		if on, ok := j.Cond.(*tree.OnJoinCond); ok {
+			expr, err := tree.SimpleVisit(on.Expr, normalizeExpr)
+			if err != nil {
+				return normalizedSelectClause, err
+			}
+			on.Expr = expr
+		}
+	}
+
+	stmt, err := tree.SimpleStmtVisit(sc, normalizeExpr)
 	if err != nil {
 		return normalizedSelectClause, err
 	}
@@ -401,11 +514,19 @@ func SelectClauseRequiresPrev(
 	ctx context.Context, semaCtx tree.SemaContext, sc NormalizedSelectClause,
 ) (bool, error) {
 	c := checkForPrevVisitor{semaCtx: semaCtx, ctx: ctx}
-	_, err := tree.SimpleStmtVisit(sc.Clause(), func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+	visit := func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
 		recurse, newExpr = c.VisitPre(expr)
 		return recurse, newExpr, nil
-	})
-	return c.foundPrev, err
+	}
+	if _, err := tree.SimpleStmtVisit(sc.Clause(), visit); err != nil {
+		return false, err
+	}
+	for _, cond := range joinConditions(sc.Clause()) {
+		if _, err := tree.SimpleVisit(cond, visit); err != nil {
+			return false, err
+		}
+	}
+	return c.foundPrev, nil
 }
 
 type checkColumnsVisitor struct {
@@ -414,6 +535,9 @@ type checkColumnsVisitor struct {
 	columns      []descpb.ColumnID
 	seenStar     bool
 	splitColFams bool
+
+	// Columns of lookup tables are ignored.
+	lookupAliases []tree.Name
 }
 
 func (c *checkColumnsVisitor) VisitCols(expr tree.Expr) (bool, tree.Expr) {
@@ -427,6 +551,11 @@ func (c *checkColumnsVisitor) VisitCols(expr tree.Expr) (bool, tree.Expr) {
 		return c.VisitCols(vn)
 
 	case *tree.ColumnItem:
+		for _, alias := range c.lookupAliases {
+			if _, ok := lookupColumnName(alias, e); ok {
+				return false, expr
+			}
+		}
 		col, err := c.desc.FindColumnWithName(e.ColumnName)
 		if err != nil {
 			c.err = err
@@ -442,9 +571,29 @@ func (c *checkColumnsVisitor) VisitCols(expr tree.Expr) (bool, tree.Expr) {
 }
 
 func (c *checkColumnsVisitor) FindColumnFamilies(sc NormalizedSelectClause) error {
-	_, err := tree.SimpleStmtVisit(sc.Clause(), func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+	clause := sc.Clause()
+	if len(clause.From.Tables) == 1 {
+		_, joins := splitFromClause(clause.From.Tables[0])
+		for _, j := range joins {
+			if ref, ok := j.Right.(*tree.TableRef); ok {
+				c.lookupAliases = append(c.lookupAliases, ref.As.Alias)
+			}
+		}
+	}
+
+	visit := func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
 		recurse, newExpr = c.VisitCols(expr)
 		return recurse, newExpr, nil
-	})
-	return err
+	}
+	if _, err := tree.SimpleStmtVisit(clause, visit); err != nil {
+		return err
+	}
+	// Join conditions reference the columns of the target which are needed to
+	// look up rows.
+	for _, cond := range joinConditions(clause) {
+		if _, err := tree.SimpleVisit(cond, visit); err != nil {
+			return err
+		}
+	}
+	return nil
 }
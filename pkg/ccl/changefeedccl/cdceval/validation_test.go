@@ -48,12 +48,14 @@ func TestNormalizeAndValidate(t *testing.T) {
 		`CREATE TABLE other.foo (a INT)`,
 		`CREATE TABLE baz (a INT PRIMARY KEY, b INT, c STRING, FAMILY most (a, b), FAMILY only_c (c))`,
 		`CREATE TABLE bop (a INT, b INT, c STRING, FAMILY most (a, b), FAMILY only_c (c), primary key (a, b))`,
+		`CREATE TABLE customers (id INT PRIMARY KEY, name STRING)`,
 	)
 
 	fooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
 	otherFooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "other", "foo")
 	bazDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "baz")
 	bopDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "bop")
+	customersDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "customers")
 
 	ctx := context.Background()
 	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
@@ -234,6 +236,52 @@ func TestNormalizeAndValidate(t *testing.T) {
 			expectErr:    `split_column_families is not supported with changefeed expressions yet`,
 			splitColFams: true,
 		},
+		{
+			name: "lookup join",
+			desc: fooDesc,
+			stmt: "SELECT foo.a, c.name FROM foo JOIN customers AS c ON c.id = foo.a",
+			expectStmt: fmt.Sprintf(
+				"SELECT foo.a, c.name FROM [%d AS foo] JOIN [%d AS c] ON c.id = foo.a",
+				fooDesc.GetID(), customersDesc.GetID()),
+		},
+		{
+			name: "left lookup join",
+			desc: fooDesc,
+			stmt: "SELECT * FROM foo LEFT OUTER JOIN customers ON a = customers.id WHERE customers.name != 'x'",
+			expectStmt: fmt.Sprintf(
+				"SELECT * FROM [%d AS foo] LEFT JOIN [%d AS customers] ON a = customers.id WHERE customers.name != 'x'",
+				fooDesc.GetID(), customersDesc.GetID()),
+		},
+		{
+			name:      "lookup join must specify primary key",
+			desc:      fooDesc,
+			stmt:      "SELECT * FROM foo JOIN customers ON customers.name = 'x'",
+			expectErr: `join condition for lookup table customers must equate each of its primary key columns \(id\)`,
+		},
+		{
+			name:      "lookup join with type mismatch",
+			desc:      fooDesc,
+			stmt:      "SELECT * FROM foo JOIN customers ON customers.id = foo.status",
+			expectErr: `equates column id of type INT8 with foo.status`,
+		},
+		{
+			name:      "lookup table columns must exist",
+			desc:      fooDesc,
+			stmt:      "SELECT c.nope FROM foo JOIN customers AS c ON c.id = a",
+			expectErr: `column "nope" does not exist in lookup table c`,
+		},
+		{
+			name:      "lookup table with multiple column families",
+			desc:      fooDesc,
+			stmt:      "SELECT * FROM foo JOIN baz ON baz.a = foo.a",
+			expectErr: `lookup table baz must have a single column family`,
+		},
+		{
+			name:      "lookup table alias must be unique",
+			desc:      fooDesc,
+			stmt:      "SELECT * FROM foo JOIN customers AS foo ON foo.id = 1",
+			expectErr: `source name "foo" specified more than once`,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			sc, err := ParseChangefeedExpression(tc.stmt)
@@ -44,6 +44,12 @@ type Metadata struct {
 	FamilyName       string                   // Column family name.
 	HasOtherFamilies bool                     // True if the table multiple families.
 	SchemaTS         hlc.Timestamp            // Schema timestamp for table descriptor.
+
+	// DatabaseName and SchemaName are the names of the database and schema
+	// containing the table. They are only populated for rows produced by a
+	// Decoder created with withNames set.
+	DatabaseName string
+	SchemaName   string
 }
 
 // Decoder is an interface for decoding KVs into cdc event row.
@@ -358,6 +364,7 @@ func (d *EventDescriptor) TableDescriptor() catalog.TableDescriptor {
 }
 
 type eventDescriptorFactory func(
+	ctx context.Context,
 	desc catalog.TableDescriptor,
 	family *descpb.ColumnFamilyDescriptor,
 	schemaTS hlc.Timestamp,
@@ -384,12 +391,15 @@ type eventDecoder struct {
 }
 
 func getEventDescriptorCached(
+	ctx context.Context,
 	desc catalog.TableDescriptor,
 	family *descpb.ColumnFamilyDescriptor,
 	includeVirtual bool,
 	keyOnly bool,
+	withNames bool,
 	schemaTS hlc.Timestamp,
 	cache *cache.UnorderedCache,
+	rfCache *rowFetcherCache,
 ) (*EventDescriptor, error) {
 	idVer := CacheKey{ID: desc.GetID(), Version: desc.GetVersion(), FamilyID: family.ID}
 
@@ -404,17 +414,29 @@ func getEventDescriptorCached(
 	if err != nil {
 		return nil, err
 	}
+	// NB: The names are resolved only when the descriptor is first cached, so
+	// renaming the database or schema is only reflected once the table
+	// descriptor changes.
+	if withNames {
+		ed.DatabaseName, ed.SchemaName, err = rfCache.namesForTable(ctx, desc, schemaTS)
+		if err != nil {
+			return nil, err
+		}
+	}
 	cache.Add(idVer, ed)
 	return ed, nil
 }
 
-// NewEventDecoder returns key value decoder.
+// NewEventDecoder returns key value decoder. If withNames is set, the
+// EventDescriptors of the decoded rows include the names of the database and
+// schema containing the table.
 func NewEventDecoder(
 	ctx context.Context,
 	cfg *execinfra.ServerConfig,
 	targets changefeedbase.Targets,
 	includeVirtual bool,
 	keyOnly bool,
+	withNames bool,
 ) (Decoder, error) {
 	rfCache, err := newRowFetcherCache(
 		ctx,
@@ -430,11 +452,13 @@ func NewEventDecoder(
 
 	eventDescriptorCache := cache.NewUnorderedCache(DefaultCacheConfig)
 	getEventDescriptor := func(
+		ctx context.Context,
 		desc catalog.TableDescriptor,
 		family *descpb.ColumnFamilyDescriptor,
 		schemaTS hlc.Timestamp,
 	) (*EventDescriptor, error) {
-		return getEventDescriptorCached(desc, family, includeVirtual, keyOnly, schemaTS, eventDescriptorCache)
+		return getEventDescriptorCached(
+			ctx, desc, family, includeVirtual, keyOnly, withNames, schemaTS, eventDescriptorCache, rfCache)
 	}
 
 	return &eventDecoder{
@@ -462,7 +486,7 @@ func (d *eventDecoder) DecodeKV(
 		return Row{}, err
 	}
 
-	ed, err := d.getEventDescriptor(d.desc, d.family, schemaTS)
+	ed, err := d.getEventDescriptor(ctx, d.desc, d.family, schemaTS)
 	if err != nil {
 		return Row{}, err
 	}
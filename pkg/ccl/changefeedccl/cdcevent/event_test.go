@@ -360,7 +360,7 @@ CREATE TABLE foo (
 			})
 			serverCfg := s.DistSQLServer().(*distsql.ServerImpl).ServerConfig
 			ctx := context.Background()
-			decoder, err := NewEventDecoder(ctx, &serverCfg, targets, tc.includeVirtual, tc.keyOnly)
+			decoder, err := NewEventDecoder(ctx, &serverCfg, targets, tc.includeVirtual, tc.keyOnly, false)
 			require.NoError(t, err)
 			expectedEvents := len(tc.expectMainFamily) + len(tc.expectOnlyCFamily)
 			for i := 0; i < expectedEvents; i++ {
@@ -576,7 +576,7 @@ func TestEventColumnOrderingWithSchemaChanges(t *testing.T) {
 			})
 			serverCfg := s.DistSQLServer().(*distsql.ServerImpl).ServerConfig
 			ctx := context.Background()
-			decoder, err := NewEventDecoder(ctx, &serverCfg, targets, tc.includeVirtual, false)
+			decoder, err := NewEventDecoder(ctx, &serverCfg, targets, tc.includeVirtual, false, false)
 			require.NoError(t, err)
 
 			expectedEvents := len(tc.expectMainFamily) + len(tc.expectECFamily)
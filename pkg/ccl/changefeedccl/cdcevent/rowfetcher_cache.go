@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -169,6 +170,38 @@ func (c *rowFetcherCache) tableDescForKey(
 	return tableDesc, family, nil
 }
 
+// namesForTable returns the names of the database and schema containing the
+// table as of the specified timestamp.
+func (c *rowFetcherCache) namesForTable(
+	ctx context.Context, tableDesc catalog.TableDescriptor, ts hlc.Timestamp,
+) (dbName string, schemaName string, _ error) {
+	nameForID := func(id descpb.ID) (string, error) {
+		desc, err := c.leaseMgr.Acquire(ctx, ts, id)
+		if err != nil {
+			return "", err
+		}
+		// Immediately release the lease, since we only need it for the exact
+		// timestamp requested.
+		defer desc.Release(ctx)
+		return desc.Underlying().GetName(), nil
+	}
+
+	dbName, err := nameForID(tableDesc.GetParentID())
+	if err != nil {
+		return "", "", err
+	}
+	// The public schema of databases created before 22.1 is not backed by a
+	// descriptor.
+	if tableDesc.GetParentSchemaID() == keys.PublicSchemaID {
+		return dbName, catconstants.PublicSchemaName, nil
+	}
+	schemaName, err = nameForID(tableDesc.GetParentSchemaID())
+	if err != nil {
+		return "", "", err
+	}
+	return dbName, schemaName, nil
+}
+
 // ErrUnwatchedFamily is a sentinel error that indicates this part of the row
 // is not being watched and does not need to be decoded.
 var ErrUnwatchedFamily = errors.New("watched table but unwatched family")
@@ -63,6 +63,12 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink EventSink
+	// dlq, if non-nil, receives the events that cannot be encoded. It is
+	// flushed along with sink.
+	dlq *deadLetterQueue
+	// txnBoundaries, if non-nil, counts the rows emitted for each transaction
+	// until they are reported to the changeFrontier.
+	txnBoundaries *txnBoundaryTracker
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -256,6 +262,16 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		return
 	}
 
+	if onError, _ := opts.GetOnError(); onError == changefeedbase.OptOnErrorDLQ {
+		ca.dlq, err = makeDeadLetterQueue(ctx, ca.flowCtx.Cfg, ca.spec.Feed, timestampOracle,
+			ca.spec.User(), ca.spec.JobID, ca.sliMetrics)
+		if err != nil {
+			ca.MoveToDraining(err)
+			ca.cancel()
+			return
+		}
+	}
+
 	// This is the correct point to set up certain hooks depending on the sink
 	// type.
 	if b, ok := ca.sink.(*bufferSink); ok {
@@ -281,9 +297,14 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		return
 	}
 
+	if opts.TxnBoundaries() {
+		ca.txnBoundaries = makeTxnBoundaryTracker()
+	}
+
 	ca.eventConsumer, ca.sink, err = newEventConsumer(
 		ctx, ca.flowCtx, feed, ca.frontier.SpanFrontier(), kvFeedHighWater,
-		ca.sink, feed, ca.spec.Select, ca.knobs, ca.metrics, ca.isSinkless())
+		ca.sink, feed, ca.spec.Select, ca.knobs, ca.metrics, ca.isSinkless(), ca.dlq,
+		ca.txnBoundaries)
 
 	if err != nil {
 		// Early abort in the case that there is an error setting up the consumption.
@@ -454,6 +475,9 @@ func (ca *changeAggregator) close() {
 		// Best effort: context is often cancel by now, so we expect to see an error
 		_ = ca.sink.Close()
 	}
+	if ca.dlq != nil {
+		_ = ca.dlq.Close()
+	}
 	ca.memAcc.Close(ca.Ctx())
 	if ca.kvFeedMemMon != nil {
 		ca.kvFeedMemMon.Stop(ca.Ctx())
@@ -525,7 +549,7 @@ func (ca *changeAggregator) tick() error {
 			return ca.noteResolvedSpan(resolved)
 		}
 	case kvevent.TypeFlush:
-		return ca.sink.Flush(ca.Ctx())
+		return ca.flushSinks()
 	}
 
 	return nil
@@ -562,13 +586,25 @@ func (ca *changeAggregator) noteResolvedSpan(resolved jobspb.ResolvedSpan) error
 	return nil
 }
 
+// flushSinks flushes the sink and then the dead letter queue, which the sink's
+// event consumer may have enqueued events to while flushing.
+func (ca *changeAggregator) flushSinks() error {
+	if err := ca.sink.Flush(ca.Ctx()); err != nil {
+		return err
+	}
+	if ca.dlq != nil {
+		return ca.dlq.Flush(ca.Ctx())
+	}
+	return nil
+}
+
 // flushFrontier flushes sink and emits resolved timestamp if needed.
 func (ca *changeAggregator) flushFrontier() error {
 	// Make sure to the sink before forwarding resolved spans,
 	// otherwise, we could lose buffered messages and violate the
 	// at-least-once guarantee. This is also true for checkpointing the
 	// resolved spans in the job progress.
-	if err := ca.sink.Flush(ca.Ctx()); err != nil {
+	if err := ca.flushSinks(); err != nil {
 		return err
 	}
 
@@ -588,6 +624,12 @@ func (ca *changeAggregator) flushFrontier() error {
 		return span.ContinueMatch
 	})
 
+	// The rows of the transactions at or below the local frontier have all
+	// been flushed above.
+	if ca.txnBoundaries != nil {
+		batch.TxnBoundaries = ca.txnBoundaries.resolve(ca.frontier.Frontier())
+	}
+
 	return ca.emitResolved(batch)
 }
 
@@ -597,6 +639,7 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 		Stats: jobspb.ResolvedSpans_Stats{
 			RecentKvCount: ca.recentKVCount,
 		},
+		TxnBoundaries: batch.TxnBoundaries,
 	}
 	updateBytes, err := protoutil.Marshal(&progressUpdate)
 	if err != nil {
@@ -653,6 +696,9 @@ type changeFrontier struct {
 	freqEmitResolved time.Duration
 	// lastEmitResolved is the last time a resolved timestamp was emitted.
 	lastEmitResolved time.Time
+	// txnBoundaries, if non-nil, sums up the rows the aggregators emitted for
+	// each transaction until the frontier passes the transaction.
+	txnBoundaries *txnBoundaryTracker
 
 	// slowLogEveryN rate-limits the logging of slow spans
 	slowLogEveryN log.EveryN
@@ -880,6 +926,14 @@ func newChangeFrontierProcessor(
 		return nil, err
 	}
 
+	if opts.TxnBoundaries() {
+		if _, ok := cf.encoder.(txnBoundaryEncoder); !ok {
+			return nil, errors.Errorf(`%s is not supported with %s=%s`,
+				changefeedbase.OptTxnBoundaries, changefeedbase.OptFormat, encodingOpts.Format)
+		}
+		cf.txnBoundaries = makeTxnBoundaryTracker()
+	}
+
 	return cf, nil
 }
 
@@ -922,6 +976,12 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 		return
 	}
 
+	if _, ok := cf.sink.(txnBoundarySink); cf.txnBoundaries != nil && !ok {
+		cf.MoveToDraining(errors.Errorf(`%s is not supported by this sink`,
+			changefeedbase.OptTxnBoundaries))
+		return
+	}
+
 	if b, ok := cf.sink.(*bufferSink); ok {
 		cf.resolvedBuf = &b.buf
 	}
@@ -1096,6 +1156,14 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 
 	cf.maybeMarkJobIdle(resolvedSpans.Stats.RecentKvCount)
 
+	// The aggregator reports transactions along with the resolved spans which
+	// cover them, so they must be tracked before the frontier is forwarded.
+	if cf.txnBoundaries != nil {
+		for _, b := range resolvedSpans.TxnBoundaries {
+			cf.txnBoundaries.add(b)
+		}
+	}
+
 	for _, resolved := range resolvedSpans.ResolvedSpans {
 		// Inserting a timestamp less than the one the changefeed flow started at
 		// could potentially regress the job progress. This is not expected, but it
@@ -1126,6 +1194,14 @@ func (cf *changeFrontier) forwardFrontier(resolved jobspb.ResolvedSpan) error {
 
 	cf.maybeLogBehindSpan(frontierChanged)
 
+	// Transaction boundaries are emitted before the frontier is checkpointed,
+	// since they won't be emitted again once the changefeed resumes from it.
+	if frontierChanged && cf.txnBoundaries != nil {
+		if err := cf.emitTxnBoundaries(cf.frontier.Frontier()); err != nil {
+			return err
+		}
+	}
+
 	// If frontier changed, we emit resolved timestamp.
 	emitResolved := frontierChanged
 
@@ -1189,9 +1265,14 @@ func (cf *changeFrontier) maybeCheckpointJob(
 	// During backfills or when some problematic spans stop advancing, the
 	// highwater mark remains fixed while other spans may significantly outpace
 	// it, therefore to avoid losing that progress on changefeed resumption we
-	// also store as many of those leading spans as we can in the job progress
+	// also store as many of those leading spans as we can in the job progress.
+	// Lagging spans aren't checkpointed for changefeeds which emit transaction
+	// boundaries, since the rows of the transactions above the highwater mark
+	// in the leading spans wouldn't be counted again on resumption. Backfills
+	// are fine, since the rows they emit aren't counted.
 	updateCheckpoint :=
-		(inBackfill || cf.frontier.hasLaggingSpans(cf.spec.Feed.StatementTime, &cf.js.settings.SV)) &&
+		(inBackfill || (cf.txnBoundaries == nil &&
+			cf.frontier.hasLaggingSpans(cf.spec.Feed.StatementTime, &cf.js.settings.SV))) &&
 			cf.js.canCheckpointSpans()
 
 	// If the highwater has moved an empty checkpoint will be saved
@@ -1385,6 +1466,17 @@ func (cf *changeFrontier) maybeEmitResolved(newResolved hlc.Timestamp) error {
 	return nil
 }
 
+// emitTxnBoundaries emits the boundaries of the transactions that committed at
+// or before the resolved timestamp.
+func (cf *changeFrontier) emitTxnBoundaries(resolved hlc.Timestamp) error {
+	boundaries := cf.txnBoundaries.resolve(resolved)
+	if len(boundaries) == 0 {
+		return nil
+	}
+	return cf.sink.(txnBoundarySink).EmitTxnBoundaries(
+		cf.Ctx(), cf.encoder.(txnBoundaryEncoder), boundaries)
+}
+
 func (cf *changeFrontier) isBehind() bool {
 	frontier := cf.frontier.Frontier()
 	if frontier.IsEmpty() {
@@ -447,6 +447,10 @@ func createChangefeedJobRecord(
 		details.Select = cdceval.AsStringUnredacted(normalized.Clause())
 
 		opts.SetDefaultEnvelope(changefeedbase.OptEnvelopeBare)
+		if opts.DebeziumEnvelope() {
+			return nil, errors.Errorf(`%s=%s is not supported with changefeed expressions`,
+				changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeDebezium)
+		}
 
 		// TODO(#85143): do not enforce schema_change_policy='stop' for changefeed expressions.
 		schemachangeOptions, err := opts.GetSchemaChangeHandlingOptions()
@@ -498,6 +502,12 @@ func createChangefeedJobRecord(
 		)
 	}
 
+	// The debezium envelope tells inserts apart from updates and includes the
+	// previous version of every changed row, both of which require diff.
+	if opts.DebeziumEnvelope() {
+		opts.ForceDiff()
+	}
+
 	if err = validateDetailsAndOptions(details, opts); err != nil {
 		return nil, err
 	}
@@ -794,6 +804,36 @@ func validateSink(
 	if err := canarySink.Close(); err != nil {
 		return err
 	}
+	if _, ok := canarySink.(txnBoundarySink); opts.TxnBoundaries() && !ok {
+		return errors.Errorf(`%s is not supported by this sink`, changefeedbase.OptTxnBoundaries)
+	}
+	switch canarySink.(type) {
+	case *parquetCloudStorageSink:
+		if opts.IsSet(changefeedbase.OptResolvedTimestamps) {
+			return errors.Errorf(`cannot specify both %s and %s`,
+				changefeedbase.OptFormatParquet, changefeedbase.OptResolvedTimestamps)
+		}
+	case *icebergCloudStorageSink:
+		// Iceberg snapshots are committed when resolved timestamps are emitted.
+		if !opts.IsSet(changefeedbase.OptResolvedTimestamps) {
+			return errors.Errorf(`%s=%s requires the %s option`, changefeedbase.SinkParamTableFormat,
+				changefeedbase.SinkTableFormatIceberg, changefeedbase.OptResolvedTimestamps)
+		}
+		if details.Select != `` {
+			return errors.Errorf(`%s=%s does not support changefeed expressions`,
+				changefeedbase.SinkParamTableFormat, changefeedbase.SinkTableFormatIceberg)
+		}
+	}
+	if opts.GetDLQSinkURI() != `` {
+		canaryDLQ, err := makeDeadLetterQueue(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig, details,
+			nilOracle, p.User(), jobID, sli)
+		if err != nil {
+			return err
+		}
+		if err := canaryDLQ.Close(); err != nil {
+			return err
+		}
+	}
 	if sink, ok := canarySink.(SinkWithTopics); ok {
 		if opts.IsSet(changefeedbase.OptResolvedTimestamps) &&
 			opts.IsSet(changefeedbase.OptSplitColumnFamilies) {
@@ -882,6 +922,13 @@ func validateDetailsAndOptions(
 		}
 	}
 
+	if onError, err := opts.GetOnError(); err != nil {
+		return err
+	} else if onError == changefeedbase.OptOnErrorDLQ && details.SinkURI == `` {
+		return errors.Errorf(`%s=%s is not supported for sinkless changefeeds`,
+			changefeedbase.OptOnError, changefeedbase.OptOnErrorDLQ)
+	}
+
 	{
 		if details.Select != "" {
 			if len(details.TargetSpecifications) != 1 {
@@ -966,8 +1013,9 @@ func (b *changefeedResumer) handleChangefeedError(
 		return errors.CombineErrors(changefeedErr, errErr)
 	}
 	switch onError {
-	// default behavior
-	case changefeedbase.OptOnErrorFail:
+	// default behavior; the dead letter queue only receives the errors which
+	// are specific to a single event.
+	case changefeedbase.OptOnErrorFail, changefeedbase.OptOnErrorDLQ:
 		return changefeedErr
 	// pause instead of failing
 	case changefeedbase.OptOnErrorPause:
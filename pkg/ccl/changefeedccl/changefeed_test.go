@@ -617,6 +617,66 @@ func TestChangefeedEnvelope(t *testing.T) {
 	cdcTest(t, testFn, feedTestRestrictSinks("sinkless", "enterprise", "kafka"))
 }
 
+func TestChangefeedDebeziumEnvelope(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH envelope='debezium'`)
+		defer closeFeed(t, foo)
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'b')`)
+		sqlDB.Exec(t, `UPDATE foo SET b = 'c' WHERE a = 1`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 2`)
+
+		// Deletions are only followed by a tombstone for kafka, since the
+		// tombstone only serves kafka log compaction.
+		_, isKafka := f.(*kafkaFeedFactory)
+		expected := []string{
+			`[1]->r map[] map[a:1 b:a] snapshot=true`,
+			`[2]->c map[] map[a:2 b:b] snapshot=false`,
+			`[1]->u map[a:1 b:a] map[a:1 b:c] snapshot=false`,
+			`[2]->d map[a:2 b:b] map[] snapshot=false`,
+		}
+		if isKafka {
+			expected = append(expected, `[2]->tombstone`)
+		}
+
+		// The timestamps in the source block aren't deterministic, so only the
+		// stable parts of every message are compared.
+		msgs, err := readNextMessages(context.Background(), foo, len(expected))
+		require.NoError(t, err)
+		var actual []string
+		for _, m := range msgs {
+			if len(m.Value) == 0 {
+				actual = append(actual, fmt.Sprintf(`%s->tombstone`, m.Key))
+				continue
+			}
+			var value struct {
+				Before map[string]interface{} `json:"before"`
+				After  map[string]interface{} `json:"after"`
+				Op     string                 `json:"op"`
+				Source map[string]interface{} `json:"source"`
+			}
+			require.NoError(t, gojson.Unmarshal(m.Value, &value))
+			require.Equal(t, `cockroachdb`, value.Source[`connector`])
+			require.Equal(t, `d`, value.Source[`db`])
+			require.Equal(t, `public`, value.Source[`schema`])
+			require.Equal(t, `foo`, value.Source[`table`])
+			actual = append(actual, fmt.Sprintf(`%s->%s %v %v snapshot=%s`,
+				m.Key, value.Op, value.Before, value.After, value.Source[`snapshot`]))
+		}
+		require.Equal(t, expected, actual)
+	}
+
+	// The cloudstorage, webhook and pubsub sinks reject the debezium envelope.
+	cdcTest(t, testFn, feedTestRestrictSinks("sinkless", "kafka"))
+}
+
 func TestChangefeedFullTableName(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -4461,7 +4521,7 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo into $1 WITH on_error`,
 		`kafka://nope`)
 	sqlDB.ExpectErr(
-		t, `unknown on_error: not_valid, valid values are 'pause' and 'fail'`,
+		t, `unknown on_error: not_valid, valid values are 'pause', 'fail' and 'dlq'`,
 		`CREATE CHANGEFEED FOR foo into $1 WITH on_error='not_valid'`,
 		`kafka://nope`)
 }
@@ -10,3 +10,8 @@ package changefeedbase
 
 // ConfluentAvroWireFormatMagic is the "magic" header bytes for kafka messages.
 const ConfluentAvroWireFormatMagic = byte(0)
+
+// ConfluentProtobufWireFormatMagic is the "magic" header byte for kafka
+// messages encoded with a protobuf schema. Confluent uses the same magic byte
+// for all schema types; the schema ID that follows it determines the type.
+const ConfluentProtobufWireFormatMagic = ConfluentAvroWireFormatMagic
@@ -86,6 +86,12 @@ func WithTerminalError(cause error) error {
 	return errors.Mark(cause, &terminalError{})
 }
 
+// IsTerminalError returns true if the error has been marked as a terminal
+// changefeed error by WithTerminalError.
+func IsTerminalError(err error) bool {
+	return errors.Is(err, &terminalError{})
+}
+
 // AsTerminalError determines if the cause error is a terminal changefeed
 // error.  Returns non-nil error if changefeed should terminate with the
 // returned error.
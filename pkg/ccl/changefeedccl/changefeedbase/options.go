@@ -93,8 +93,10 @@ const (
 	OptWebhookAuthHeader        = `webhook_auth_header`
 	OptWebhookClientTimeout     = `webhook_client_timeout`
 	OptOnError                  = `on_error`
+	OptDLQSink                  = `dlq_sink`
 	OptMetricsScope             = `metrics_label`
 	OptVirtualColumns           = `virtual_columns`
+	OptTxnBoundaries            = `txn_boundaries`
 
 	OptVirtualColumnsOmitted VirtualColumnVisibility = `omitted`
 	OptVirtualColumnsNull    VirtualColumnVisibility = `null`
@@ -140,14 +142,20 @@ const (
 	OptEnvelopeDeprecatedRow EnvelopeType = `deprecated_row`
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 	OptEnvelopeBare          EnvelopeType = `bare`
+	OptEnvelopeDebezium      EnvelopeType = `debezium`
 
-	OptFormatJSON    FormatType = `json`
-	OptFormatAvro    FormatType = `avro`
-	OptFormatCSV     FormatType = `csv`
-	OptFormatParquet FormatType = `parquet`
+	OptFormatJSON     FormatType = `json`
+	OptFormatAvro     FormatType = `avro`
+	OptFormatCSV      FormatType = `csv`
+	OptFormatParquet  FormatType = `parquet`
+	OptFormatProtobuf FormatType = `protobuf`
 
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
+	// OptOnErrorDLQ diverts the events that cannot be encoded to the dead
+	// letter queue configured with OptDLQSink instead of retrying them.
+	// All other errors make the job fail.
+	OptOnErrorDLQ OnErrorType = `dlq`
 
 	DeprecatedOptFormatAvro                   = `experimental_avro`
 	DeprecatedSinkSchemeCloudStorageAzure     = `experimental-azure`
@@ -170,6 +178,7 @@ const (
 	SinkParamClientKey              = `client_key`
 	SinkParamFileSize               = `file_size`
 	SinkParamPartitionFormat        = `partition_format`
+	SinkParamTableFormat            = `table_format`
 	SinkParamSchemaTopic            = `schema_topic`
 	SinkParamTLSEnabled             = `tls_enabled`
 	SinkParamSkipTLSVerify          = `insecure_tls_skip_verify`
@@ -195,6 +204,10 @@ const (
 	SinkParamSASLPassword           = `sasl_password`
 	SinkParamSASLMechanism          = `sasl_mechanism`
 
+	// SinkTableFormatIceberg is the value of SinkParamTableFormat which makes
+	// cloud storage sinks write Apache Iceberg tables.
+	SinkTableFormatIceberg = `iceberg`
+
 	RegistryParamCACert = `ca_cert`
 
 	// Topics is used to store the topics generated by the sink in the options
@@ -289,8 +302,8 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptConfluentSchemaRegistry:  stringOption,
 	OptCursor:                   timestampOption,
 	OptEndTime:                  timestampOption,
-	OptEnvelope:                 enum("row", "key_only", "wrapped", "deprecated_row", "bare"),
-	OptFormat:                   enum("json", "avro", "csv", "experimental_avro", "parquet"),
+	OptEnvelope:                 enum("row", "key_only", "wrapped", "deprecated_row", "bare", "debezium"),
+	OptFormat:                   enum("json", "avro", "csv", "experimental_avro", "parquet", "protobuf"),
 	OptFullTableName:            flagOption,
 	OptKeyInValue:               flagOption,
 	OptTopicInValue:             flagOption,
@@ -311,9 +324,11 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptWebhookSinkConfig:        jsonOption,
 	OptWebhookAuthHeader:        stringOption,
 	OptWebhookClientTimeout:     durationOption,
-	OptOnError:                  enum("pause", "fail"),
+	OptOnError:                  enum("pause", "fail", "dlq"),
+	OptDLQSink:                  stringOption,
 	OptMetricsScope:             stringOption,
 	OptVirtualColumns:           enum("omitted", "null"),
+	OptTxnBoundaries:            flagOption,
 }
 
 // CommonOptions is options common to all sinks
@@ -323,9 +338,9 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
 	OptMVCCTimestamps, OptDiff, OptSplitColumnFamilies,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
-	OptProtectDataFromGCOnPause, OptOnError,
+	OptProtectDataFromGCOnPause, OptOnError, OptDLQSink,
 	OptInitialScan, OptNoInitialScan, OptInitialScanOnly,
-	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics)
+	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, OptTxnBoundaries, Topics)
 
 // SQLValidOptions is options exclusive to SQL sink
 var SQLValidOptions map[string]struct{} = nil
@@ -384,6 +399,7 @@ var RedactedOptions = map[string]redactionFunc{
 	OptWebhookAuthHeader:       redactSimple,
 	SinkParamClientKey:         redactSimple,
 	OptConfluentSchemaRegistry: RedactUserFromURI,
+	OptDLQSink:                 redactSimple,
 }
 
 // NoLongerExperimental aliases options prefixed with experimental that no longer need to be
@@ -400,7 +416,7 @@ var NoLongerExperimental = map[string]string{
 // InitialScanOnlyUnsupportedOptions is options that are not supported with the
 // initial scan only option
 var InitialScanOnlyUnsupportedOptions = makeStringSet(OptEndTime, OptResolvedTimestamps, OptDiff,
-	OptMVCCTimestamps, OptUpdatedTimestamps)
+	OptMVCCTimestamps, OptUpdatedTimestamps, OptTxnBoundaries)
 
 // AlterChangefeedUnsupportedOptions are changefeed options that we do not allow
 // users to alter.
@@ -706,11 +722,36 @@ func (s StatementOptions) GetEncodingOptions() (EncodingOptions, error) {
 
 // Validate checks for incompatible encoding options.
 func (e EncodingOptions) Validate() error {
-	if e.Envelope == OptEnvelopeRow && e.Format == OptFormatAvro {
+	if e.Envelope == OptEnvelopeRow && (e.Format == OptFormatAvro || e.Format == OptFormatProtobuf) {
 		return errors.Errorf(`%s=%s is not supported with %s=%s`,
-			OptEnvelope, OptEnvelopeRow, OptFormat, OptFormatAvro,
+			OptEnvelope, OptEnvelopeRow, OptFormat, e.Format,
 		)
 	}
+	if e.Envelope == OptEnvelopeDebezium {
+		if e.Format != OptFormatJSON && e.Format != OptFormatAvro {
+			return errors.Errorf(`%s=%s is not supported with %s=%s`,
+				OptEnvelope, OptEnvelopeDebezium, OptFormat, e.Format,
+			)
+		}
+		// The debezium envelope always includes the previous version of the
+		// row, and its source block already carries the event's timestamps.
+		unsupported := []struct {
+			k string
+			b bool
+		}{
+			{OptKeyInValue, e.KeyInValue},
+			{OptTopicInValue, e.TopicInValue},
+			{OptUpdatedTimestamps, e.UpdatedTimestamps},
+			{OptMVCCTimestamps, e.MVCCTimestamps},
+		}
+		for _, v := range unsupported {
+			if v.b {
+				return errors.Errorf(`%s is not supported with %s=%s`,
+					v.k, OptEnvelope, OptEnvelopeDebezium)
+			}
+		}
+		return nil
+	}
 	if e.Envelope != OptEnvelopeWrapped && e.Format != OptFormatJSON && e.Format != OptFormatParquet {
 		requiresWrap := []struct {
 			k string
@@ -837,6 +878,21 @@ func (s StatementOptions) KeyOnly() bool {
 	return s.m[OptEnvelope] == string(OptEnvelopeKeyOnly)
 }
 
+// DebeziumEnvelope returns true if we are using the 'debezium' envelope.
+func (s StatementOptions) DebeziumEnvelope() bool {
+	return s.m[OptEnvelope] == string(OptEnvelopeDebezium)
+}
+
+// TxnBoundaries returns true if a marker should be emitted once every row
+// changed by a transaction has been emitted. Such changefeeds don't checkpoint
+// the spans that lead the high-water mark, see
+// FrontierHighwaterLagCheckpointThreshold, so when they resume they re-emit
+// everything above the high-water mark, not just the lagging spans.
+func (s StatementOptions) TxnBoundaries() bool {
+	_, ok := s.m[OptTxnBoundaries]
+	return ok
+}
+
 // GetMinCheckpointFrequency returns the minimum frequency with which checkpoints should be
 // recorded. Returns nil if not set, and an error if invalid.
 func (s StatementOptions) GetMinCheckpointFrequency() (*time.Duration, error) {
@@ -884,6 +940,12 @@ func (s StatementOptions) GetOnError() (OnErrorType, error) {
 	return OnErrorType(v), nil
 }
 
+// GetDLQSinkURI returns the URI of the sink that the dead letter queue emits
+// to, or the empty string if the changefeed doesn't have one.
+func (s StatementOptions) GetDLQSinkURI() string {
+	return s.m[OptDLQSink]
+}
+
 func describeEnum(strs ...string) string {
 	switch len(strs) {
 	case 1:
@@ -893,11 +955,10 @@ func describeEnum(strs ...string) string {
 	default:
 		s := "valid values are "
 		for i, v := range strs {
-			if i > 0 {
-				s = s + ", "
-			}
 			if i == len(strs)-1 {
 				s = s + " and "
+			} else if i > 0 {
+				s = s + ", "
 			}
 			s = s + fmt.Sprintf("'%s'", v)
 		}
@@ -934,10 +995,28 @@ func (s StatementOptions) ValidateForCreateChangefeed() error {
 			return errors.Newf(`%s=%s is only usable with %s`, OptFormat, OptFormatCSV, OptInitialScanOnly)
 		}
 	}
-	// Right now parquet does not support any of these options
+	onError, err := s.GetOnError()
+	if err != nil {
+		return err
+	}
+	_, hasDLQSink := s.m[OptDLQSink]
+	if onError == OptOnErrorDLQ && !hasDLQSink {
+		return errors.Newf(`%s=%s requires the %s option`, OptOnError, OptOnErrorDLQ, OptDLQSink)
+	}
+	if onError != OptOnErrorDLQ && hasDLQSink {
+		return errors.Newf(`%s is only usable with %s=%s`, OptDLQSink, OptOnError, OptOnErrorDLQ)
+	}
+	if format, ok := s.m[OptFormat]; ok && s.TxnBoundaries() && format != string(OptFormatJSON) {
+		return errors.Newf(`%s is only usable with %s=%s`, OptTxnBoundaries, OptFormat, OptFormatJSON)
+	}
+	// Right now parquet does not support any of these options. Resolved
+	// timestamps are validated by the sink, since they are required to write
+	// Iceberg tables.
 	if s.m[OptFormat] == string(OptFormatParquet) {
-		if err := validateInitialScanUnsupportedOptions(string(OptFormatParquet)); err != nil {
-			return err
+		for o := range InitialScanOnlyUnsupportedOptions {
+			if _, ok := s.m[o]; ok && o != OptResolvedTimestamps {
+				return errors.Newf(`cannot specify both %s and %s`, OptFormatParquet, o)
+			}
 		}
 	}
 	return nil
@@ -23,6 +23,10 @@ func TestOptionsValidations(t *testing.T) {
 
 	require.NoError(t, MakeDefaultOptions().ValidateForCreateChangefeed(),
 		"Default options should be valid")
+	require.NoError(t, MakeStatementOptions(map[string]string{"on_error": "dlq", "dlq_sink": "null://"}).
+		ValidateForCreateChangefeed())
+	require.NoError(t, MakeStatementOptions(map[string]string{"txn_boundaries": "", "format": "json"}).
+		ValidateForCreateChangefeed())
 
 	tests := []struct {
 		input map[string]string
@@ -30,6 +34,11 @@ func TestOptionsValidations(t *testing.T) {
 	}{
 		{map[string]string{"format": "txt"}, "unknown format"},
 		{map[string]string{"initial_scan": "", "no_initial_scan": ""}, "cannot specify both"},
+		{map[string]string{"on_error": "dlq"}, "on_error=dlq requires the dlq_sink option"},
+		{map[string]string{"dlq_sink": "null://"}, "dlq_sink is only usable with on_error=dlq"},
+		{map[string]string{"on_error": "pause", "dlq_sink": "null://"}, "dlq_sink is only usable with on_error=dlq"},
+		{map[string]string{"txn_boundaries": "", "format": "avro"}, "txn_boundaries is only usable with format=json"},
+		{map[string]string{"txn_boundaries": "", "initial_scan": "only"}, "cannot specify both"},
 	}
 
 	for _, test := range tests {
@@ -40,3 +49,45 @@ func TestOptionsValidations(t *testing.T) {
 	}
 
 }
+
+func TestEncodingOptionsValidations(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, input := range []map[string]string{
+		{"envelope": "debezium"},
+		{"envelope": "debezium", "format": "avro"},
+		{"envelope": "debezium", "diff": ""},
+	} {
+		_, err := MakeStatementOptions(input).GetEncodingOptions()
+		require.NoError(t, err, fmt.Sprintf("%v should be valid", input))
+	}
+
+	tests := []struct {
+		input map[string]string
+		err   string
+	}{
+		{map[string]string{"envelope": "debezium", "format": "csv"},
+			"envelope=debezium is not supported with format=csv"},
+		{map[string]string{"envelope": "debezium", "updated": ""},
+			"updated is not supported with envelope=debezium"},
+		{map[string]string{"envelope": "debezium", "key_in_value": ""},
+			"key_in_value is not supported with envelope=debezium"},
+	}
+
+	for _, test := range tests {
+		_, err := MakeStatementOptions(test.input).GetEncodingOptions()
+		require.Error(t, err, fmt.Sprintf("%v should not be valid", test.input))
+		require.Contains(t, err.Error(), test.err)
+	}
+}
+
+func TestDescribeEnum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	require.Equal(t, "the only valid value is 'a'", describeEnum("a"))
+	require.Equal(t, "valid values are 'a' and 'b'", describeEnum("a", "b"))
+	require.Equal(t, "valid values are 'a', 'b' and 'c'", describeEnum("a", "b", "c"))
+	require.Equal(t, "valid values are 'a', 'b', 'c' and 'd'", describeEnum("a", "b", "c", "d"))
+}
@@ -85,7 +85,7 @@ var FrontierCheckpointFrequency = settings.RegisterDurationSetting(
 var FrontierHighwaterLagCheckpointThreshold = settings.RegisterDurationSetting(
 	settings.TenantWritable,
 	"changefeed.frontier_highwater_lag_checkpoint_threshold",
-	"controls the maximum the high-water mark is allowed to lag behind the leading spans of the frontier before per-span checkpointing is enabled; if 0, checkpointing due to high-water lag is disabled",
+	"controls the maximum the high-water mark is allowed to lag behind the leading spans of the frontier before per-span checkpointing is enabled; if 0, checkpointing due to high-water lag is disabled; changefeeds with the txn_boundaries option never checkpoint spans due to high-water lag",
 	10*time.Minute,
 	settings.NonNegativeDuration,
 )
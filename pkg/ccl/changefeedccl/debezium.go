@@ -0,0 +1,121 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// The debezium envelope mirrors the layout of the change events produced by
+// Debezium connectors, so that consumers built for Debezium can read
+// changefeeds without modification:
+//
+//	{
+//	  "before": {...},
+//	  "after": {...},
+//	  "op": "u",
+//	  "source": {"connector": "cockroachdb", "db": ..., "table": ..., ...},
+//	  "ts_ms": 1665000000000
+//	}
+//
+// Kafka sinks additionally receive a tombstone (an empty value) after every
+// deletion so that log compaction can remove the deleted key.
+
+// Debezium operation codes.
+const (
+	debeziumOpCreate = `c`
+	debeziumOpUpdate = `u`
+	debeziumOpDelete = `d`
+	// debeziumOpRead is used for the rows emitted by a scan of the table, which
+	// Debezium calls a snapshot.
+	debeziumOpRead = `r`
+)
+
+// debeziumConnector is the name of the connector reported in the source block.
+const debeziumConnector = `cockroachdb`
+
+// Field names of the source block.
+const (
+	debeziumSourceConnector = `connector`
+	debeziumSourceDB        = `db`
+	debeziumSourceSchema    = `schema`
+	debeziumSourceTable     = `table`
+	debeziumSourceTsMs      = `ts_ms`
+	debeziumSourceSnapshot  = `snapshot`
+	debeziumSourceMVCC      = `mvcc_timestamp`
+)
+
+// debeziumSourceFields lists the fields of the source block in the order in
+// which they appear in schemas.
+var debeziumSourceFields = []string{
+	debeziumSourceConnector,
+	debeziumSourceDB,
+	debeziumSourceSchema,
+	debeziumSourceTable,
+	debeziumSourceTsMs,
+	debeziumSourceSnapshot,
+	debeziumSourceMVCC,
+}
+
+// debeziumProcessingTime returns the time reported in the top-level ts_ms
+// field, which Debezium defines as the time at which the event was processed.
+// It's a variable so that tests can make it deterministic.
+var debeziumProcessingTime = timeutil.Now
+
+// debeziumOp returns the operation code for the change from prev to updated.
+// It relies on the diff option, which the debezium envelope implies, to tell
+// inserts apart from updates.
+func debeziumOp(evCtx eventContext, updated, prev cdcevent.Row) string {
+	switch {
+	case updated.IsDeleted():
+		return debeziumOpDelete
+	case evCtx.backfill:
+		return debeziumOpRead
+	case prev.HasValues() && !prev.IsDeleted():
+		return debeziumOpUpdate
+	default:
+		return debeziumOpCreate
+	}
+}
+
+// debeziumSource is the content of the source block of an event.
+type debeziumSource struct {
+	db, schema, table string
+	mvcc              hlc.Timestamp
+	snapshot          bool
+}
+
+func makeDebeziumSource(evCtx eventContext, row cdcevent.Row) debeziumSource {
+	return debeziumSource{
+		db:       row.DatabaseName,
+		schema:   row.SchemaName,
+		table:    row.TableName,
+		mvcc:     evCtx.mvcc,
+		snapshot: evCtx.backfill,
+	}
+}
+
+// tsMs returns the time at which the change was committed, in milliseconds
+// since the epoch.
+func (s debeziumSource) tsMs() int64 {
+	return s.mvcc.WallTime / int64(time.Millisecond)
+}
+
+// snapshotString returns the value of the snapshot field, which Debezium
+// encodes as a string.
+func (s debeziumSource) snapshotString() string {
+	if s.snapshot {
+		return `true`
+	}
+	return `false`
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebeziumEnvelope(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	defer func(old func() time.Time) { debeziumProcessingTime = old }(debeziumProcessingTime)
+	debeziumProcessingTime = func() time.Time { return timeutil.Unix(2, 0) }
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}
+	updatedRow := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`baz`)},
+	}
+	ts := hlc.Timestamp{WallTime: 1500 * int64(time.Millisecond), Logical: 2}
+
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+
+	const jsonSource = `"source": {"connector": "cockroachdb", "db": "d", ` +
+		`"mvcc_timestamp": "1500000000.0000000002", "schema": "public", ` +
+		`"snapshot": "%s", "table": "foo", "ts_ms": 1500}, "ts_ms": 2000}`
+	const avroSource = `"source":{"foo_source":{"connector":{"string":"cockroachdb"},` +
+		`"db":{"string":"d"},"schema":{"string":"public"},"table":{"string":"foo"},` +
+		`"ts_ms":{"long":1500},"snapshot":{"string":"%s"},` +
+		`"mvcc_timestamp":{"string":"1500000000.0000000002"}}},"ts_ms":{"long":2000}}`
+
+	expecteds := map[changefeedbase.FormatType]struct {
+		scan, insert, update, delete, resolved string
+	}{
+		changefeedbase.OptFormatJSON: {
+			scan: `[1]->{"after": {"a": 1, "b": "bar"}, "before": null, "op": "r", ` +
+				fmt.Sprintf(jsonSource, "true"),
+			insert: `[1]->{"after": {"a": 1, "b": "bar"}, "before": null, "op": "c", ` +
+				fmt.Sprintf(jsonSource, "false"),
+			update: `[1]->{"after": {"a": 1, "b": "baz"}, "before": {"a": 1, "b": "bar"}, "op": "u", ` +
+				fmt.Sprintf(jsonSource, "false"),
+			delete: `[1]->{"after": null, "before": {"a": 1, "b": "baz"}, "op": "d", ` +
+				fmt.Sprintf(jsonSource, "false"),
+			resolved: `{"resolved":"1500000000.0000000002"}`,
+		},
+		changefeedbase.OptFormatAvro: {
+			scan: `{"a":{"long":1}}->{"before":null,` +
+				`"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},"op":{"string":"r"},` +
+				fmt.Sprintf(avroSource, "true"),
+			insert: `{"a":{"long":1}}->{"before":null,` +
+				`"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},"op":{"string":"c"},` +
+				fmt.Sprintf(avroSource, "false"),
+			update: `{"a":{"long":1}}->` +
+				`{"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}},` +
+				`"after":{"foo":{"a":{"long":1},"b":{"string":"baz"}}},"op":{"string":"u"},` +
+				fmt.Sprintf(avroSource, "false"),
+			delete: `{"a":{"long":1}}->` +
+				`{"before":{"foo_before":{"a":{"long":1},"b":{"string":"baz"}}},` +
+				`"after":null,"op":{"string":"d"},` +
+				fmt.Sprintf(avroSource, "false"),
+			resolved: `{"resolved":{"string":"1500000000.0000000002"}}`,
+		},
+	}
+
+	for format, expected := range expecteds {
+		t.Run(string(format), func(t *testing.T) {
+			o := changefeedbase.EncodingOptions{
+				Format:   format,
+				Envelope: changefeedbase.OptEnvelopeDebezium,
+				Diff:     true,
+			}
+
+			var rowStringFn func([]byte, []byte) string
+			var resolvedStringFn func([]byte) string
+			switch format {
+			case changefeedbase.OptFormatJSON:
+				rowStringFn = func(k, v []byte) string { return fmt.Sprintf(`%s->%s`, k, v) }
+				resolvedStringFn = func(r []byte) string { return string(r) }
+			case changefeedbase.OptFormatAvro:
+				reg := cdctest.StartTestSchemaRegistry()
+				defer reg.Close()
+				o.SchemaRegistryURI = reg.URL()
+				rowStringFn = func(k, v []byte) string {
+					key, value := avroToJSON(t, reg, k), avroToJSON(t, reg, v)
+					return fmt.Sprintf(`%s->%s`, key, value)
+				}
+				resolvedStringFn = func(r []byte) string {
+					return string(avroToJSON(t, reg, r))
+				}
+			}
+
+			require.NoError(t, o.Validate())
+			e, err := getEncoder(o, targets)
+			require.NoError(t, err)
+
+			makeRow := func(datums rowenc.EncDatumRow, deleted bool) cdcevent.Row {
+				r := cdcevent.TestingMakeEventRow(tableDesc, 0, datums, deleted)
+				r.DatabaseName, r.SchemaName = `d`, `public`
+				return r
+			}
+			encode := func(evCtx eventContext, updated, prev cdcevent.Row) string {
+				key, err := e.EncodeKey(context.Background(), updated)
+				require.NoError(t, err)
+				key = append([]byte(nil), key...)
+				value, err := e.EncodeValue(context.Background(), evCtx, updated, prev)
+				require.NoError(t, err)
+				return rowStringFn(key, value)
+			}
+
+			evCtx := eventContext{updated: ts, mvcc: ts}
+			scanCtx := evCtx
+			scanCtx.backfill = true
+			noPrev := makeRow(nil, false)
+
+			require.Equal(t, expected.scan, encode(scanCtx, makeRow(row, false), noPrev))
+			require.Equal(t, expected.insert, encode(evCtx, makeRow(row, false), makeRow(row, true)))
+			require.Equal(t, expected.update, encode(evCtx, makeRow(updatedRow, false), makeRow(row, false)))
+			require.Equal(t, expected.delete, encode(evCtx, makeRow(updatedRow, true), makeRow(updatedRow, false)))
+
+			resolved, err := e.EncodeResolvedTimestamp(context.Background(), tableDesc.GetName(), ts)
+			require.NoError(t, err)
+			require.Equal(t, expected.resolved, resolvedStringFn(resolved))
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// deadLetterQueue receives the events of a changefeed running with
+// on_error='dlq' that cannot be encoded, so that a single bad event doesn't
+// stall the changefeed in an endless retry loop or fail it. Each event is
+// emitted to the sink configured with dlq_sink as a JSON object which describes
+// the event and the error:
+//
+//	{
+//	  "error": "...",
+//	  "job_id": 123,
+//	  "table": "d.public.foo",
+//	  "key": [1],
+//	  "row": {"a": 1, "b": "..."},
+//	  "deleted": false,
+//	  "mvcc_timestamp": "1665000000000000000.0000000001",
+//	  "updated": "1665000000000000000.0000000001",
+//	  "replay": {"cursor": "...", "end_time": "..."}
+//	}
+//
+// The message key is the JSON encoded primary key of the row. The message is
+// emitted to a topic named after the one the event was meant for, with the
+// _dlq suffix (see dlqTopic).
+//
+// Once the cause of the error has been addressed, an entry can be replayed by
+// running a changefeed on the entry's table with the cursor and end_time
+// options set to the values in the entry's replay object. That changefeed
+// emits every change made to the table at the entry's MVCC timestamp.
+//
+// A deadLetterQueue is safe for concurrent use, since the parallel event
+// consumer enqueues events from several workers.
+type deadLetterQueue struct {
+	jobID   jobspb.JobID
+	metrics *sliMetrics
+
+	mu struct {
+		syncutil.Mutex
+		sink Sink
+	}
+}
+
+// makeDeadLetterQueue dials the sink of the changefeed's dead letter queue.
+func makeDeadLetterQueue(
+	ctx context.Context,
+	serverCfg *execinfra.ServerConfig,
+	feedCfg jobspb.ChangefeedDetails,
+	timestampOracle timestampLowerBoundOracle,
+	user username.SQLUsername,
+	jobID jobspb.JobID,
+	metrics *sliMetrics,
+) (*deadLetterQueue, error) {
+	opts := changefeedbase.MakeStatementOptions(feedCfg.Opts)
+	dlqCfg := feedCfg
+	dlqCfg.SinkURI = opts.GetDLQSinkURI()
+	// The sink names its topics after the targets, so the targets are renamed
+	// to match the topics of the entries.
+	dlqCfg.Tables = make(jobspb.ChangefeedTargets, len(feedCfg.Tables))
+	for id, t := range feedCfg.Tables {
+		t.StatementTimeName += dlqTopicSuffix
+		dlqCfg.Tables[id] = t
+	}
+	dlqCfg.TargetSpecifications = make(
+		[]jobspb.ChangefeedTargetSpecification, len(feedCfg.TargetSpecifications))
+	for i, ts := range feedCfg.TargetSpecifications {
+		if ts.StatementTimeName != "" {
+			ts.StatementTimeName += dlqTopicSuffix
+		}
+		dlqCfg.TargetSpecifications[i] = ts
+	}
+	// The entries are JSON objects regardless of the changefeed's encoding
+	// options, which the dead letter queue's sink may not support.
+	dlqCfg.Opts = map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatJSON),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeBare),
+	}
+
+	// NB: The sink is dialed directly rather than through getSink so that
+	// testing knobs which wrap the changefeed's sink don't apply to it. The
+	// emitted messages aren't recorded in the sink metrics either; they're
+	// accounted for by the dead letter queue metrics instead.
+	sink, err := makeSink(ctx, serverCfg, dlqCfg, timestampOracle, user, jobID, (*sliMetrics)(nil))
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating %s", changefeedbase.OptDLQSink)
+	}
+	if err := sink.Dial(); err != nil {
+		return nil, errors.Wrapf(err, "dialing %s", changefeedbase.OptDLQSink)
+	}
+
+	dlq := &deadLetterQueue{jobID: jobID, metrics: metrics}
+	dlq.mu.sink = sink
+	return dlq, nil
+}
+
+// undeliverableEventError marks the errors which are specific to the event
+// being encoded, and would recur if the event were retried.
+type undeliverableEventError struct{}
+
+func (*undeliverableEventError) Error() string {
+	return "undeliverable event"
+}
+
+// markUndeliverableEvent marks the error as specific to the event being
+// encoded, so that the event is sent to the dead letter queue of a changefeed
+// running with on_error='dlq'.
+func markUndeliverableEvent(err error) error {
+	return errors.Mark(err, &undeliverableEventError{})
+}
+
+// isUndeliverableEventError returns true if the error, which was returned
+// while encoding an event, was marked by markUndeliverableEvent. Other errors,
+// such as the schema registry being unreachable or rejecting the changefeed's
+// credentials, are not specific to the event and follow the fail behavior.
+func isUndeliverableEventError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return errors.Is(err, &undeliverableEventError{})
+}
+
+// Enqueue emits an entry for an event that couldn't be encoded because of the
+// specified error.
+func (q *deadLetterQueue) Enqueue(
+	ctx context.Context,
+	topic TopicDescriptor,
+	row cdcevent.Row,
+	evCtx eventContext,
+	cause error,
+) error {
+	key, value, err := q.makeEntry(row, evCtx, cause)
+	if err != nil {
+		return errors.CombineErrors(cause, err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.mu.sink.EmitRow(
+		ctx, dlqTopic{topic}, key, value, evCtx.updated, evCtx.mvcc, kvevent.Alloc{},
+	); err != nil {
+		return err
+	}
+	q.metrics.recordDLQMessage(len(key) + len(value))
+	return nil
+}
+
+// makeEntry returns the key and value of the entry for the event.
+func (q *deadLetterQueue) makeEntry(
+	row cdcevent.Row, evCtx eventContext, cause error,
+) (key, value []byte, _ error) {
+	// A new versionEncoder is used for each entry since the rows may belong to
+	// different tables or versions of the same table.
+	var enc versionEncoder
+	keyJSON, err := enc.encodeKeyRaw(row)
+	if err != nil {
+		return nil, nil, err
+	}
+	rowJSON, err := enc.rowAsGoNative(row, nil /* meta */)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replay := json.NewObjectBuilder(2)
+	replay.Add(`cursor`, json.FromString(evCtx.mvcc.Prev().AsOfSystemTime()))
+	replay.Add(`end_time`, json.FromString(evCtx.mvcc.Next().AsOfSystemTime()))
+
+	b := json.NewObjectBuilder(9)
+	b.Add(`error`, json.FromString(cause.Error()))
+	b.Add(`job_id`, json.FromInt64(int64(q.jobID)))
+	b.Add(`table`, json.FromString(dlqTableName(row)))
+	b.Add(`key`, keyJSON)
+	b.Add(`row`, rowJSON)
+	b.Add(`deleted`, json.FromBool(row.IsDeleted()))
+	b.Add(`mvcc_timestamp`, json.FromString(evCtx.mvcc.AsOfSystemTime()))
+	b.Add(`updated`, json.FromString(evCtx.updated.AsOfSystemTime()))
+	b.Add(`replay`, replay.Build())
+
+	var keyBuf, valueBuf bytes.Buffer
+	keyJSON.Format(&keyBuf)
+	b.Build().Format(&valueBuf)
+	return keyBuf.Bytes(), valueBuf.Bytes(), nil
+}
+
+// dlqTableName returns the fully qualified name of the row's table.
+func dlqTableName(row cdcevent.Row) string {
+	tn := tree.MakeTableNameWithSchema(
+		tree.Name(row.DatabaseName), tree.Name(row.SchemaName), tree.Name(row.TableName))
+	return tn.FQString()
+}
+
+// dlqTopicSuffix is appended to the names of the dead letter queue's topics.
+const dlqTopicSuffix = "_dlq"
+
+// dlqTopic is the topic which receives the dead letter queue entries for the
+// events of the wrapped topic. Its name has the _dlq suffix, so that the
+// entries are kept apart from the changefeed's messages even if the dead
+// letter queue uses the same sink.
+type dlqTopic struct {
+	TopicDescriptor
+}
+
+// GetNameComponents implements the TopicDescriptor interface.
+func (t dlqTopic) GetNameComponents() (changefeedbase.StatementTimeName, []string) {
+	name, components := t.TopicDescriptor.GetNameComponents()
+	return name + dlqTopicSuffix, components
+}
+
+// GetTargetSpecification implements the TopicDescriptor interface.
+func (t dlqTopic) GetTargetSpecification() changefeedbase.Target {
+	spec := t.TopicDescriptor.GetTargetSpecification()
+	spec.StatementTimeName += dlqTopicSuffix
+	return spec
+}
+
+// Flush blocks until every entry has been acknowledged by the sink.
+func (q *deadLetterQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.mu.sink.Flush(ctx)
+}
+
+// Close closes the sink.
+func (q *deadLetterQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.mu.sink.Close()
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	datums := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}
+	makeRow := func(deleted bool) cdcevent.Row {
+		r := cdcevent.TestingMakeEventRow(tableDesc, 0, datums, deleted)
+		r.DatabaseName, r.SchemaName = `d`, `public`
+		return r
+	}
+	ts := hlc.Timestamp{WallTime: 10, Logical: 2}
+	evCtx := eventContext{updated: ts, mvcc: ts}
+
+	sli, err := newAggregateMetrics(time.Minute).getOrCreateScope(defaultSLIScope)
+	require.NoError(t, err)
+	sink := &bufferSink{metrics: (*sliMetrics)(nil)}
+	dlq := &deadLetterQueue{jobID: 42, metrics: sli}
+	dlq.mu.sink = sink
+
+	const expectedReplay = `"replay": {"cursor": "10.0000000001", "end_time": "10.0000000003"}`
+	for _, tc := range []struct {
+		row           cdcevent.Row
+		expectedValue string
+	}{
+		{
+			row: makeRow(false /* deleted */),
+			expectedValue: `{"deleted": false, "error": "boom", "job_id": 42, "key": [1], ` +
+				`"mvcc_timestamp": "10.0000000002", ` + expectedReplay + `, ` +
+				`"row": {"a": 1, "b": "bar"}, "table": "d.public.foo", "updated": "10.0000000002"}`,
+		},
+		{
+			row: makeRow(true /* deleted */),
+			expectedValue: `{"deleted": true, "error": "boom", "job_id": 42, "key": [1], ` +
+				`"mvcc_timestamp": "10.0000000002", ` + expectedReplay + `, ` +
+				`"row": null, "table": "d.public.foo", "updated": "10.0000000002"}`,
+		},
+	} {
+		require.NoError(t, dlq.Enqueue(ctx, topic(`foo`), tc.row, evCtx, errors.New(`boom`)))
+		require.False(t, sink.buf.IsEmpty())
+		emitted := sink.buf.Pop()
+		require.Equal(t, `foo_dlq`, string(*emitted[1].Datum.(*tree.DString)))
+		require.Equal(t, `[1]`, string(*emitted[2].Datum.(*tree.DBytes)))
+		require.Equal(t, tc.expectedValue, string(*emitted[3].Datum.(*tree.DBytes)))
+	}
+	require.EqualValues(t, 2, sli.DLQMessages.Value())
+	require.Less(t, int64(0), sli.DLQBytes.Value())
+}
+
+func TestIsUndeliverableEventError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	// Only the errors which are explicitly marked as specific to the event are
+	// sent to the dead letter queue.
+	require.True(t, isUndeliverableEventError(ctx, errors.Wrap(
+		markAvroRowError(changefeedbase.WithTerminalError(
+			errors.New(`type OID not yet supported with avro`))), `encoding`)))
+	require.True(t, isUndeliverableEventError(ctx, errors.Wrap(
+		markUndeliverableEvent(errors.New(`registering schema: 409 Conflict`)), `encoding`)))
+	require.False(t, isUndeliverableEventError(ctx, errors.New(`connection refused`)))
+	require.False(t, isUndeliverableEventError(ctx, errors.New(`registering schema: 401 Unauthorized`)))
+	require.False(t, isUndeliverableEventError(ctx, markAvroRowError(errors.New(`boom`))))
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, isUndeliverableEventError(canceledCtx, markUndeliverableEvent(errors.New(`boom`))))
+}
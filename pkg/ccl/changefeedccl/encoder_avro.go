@@ -84,7 +84,8 @@ func newConfluentAvroEncoder(
 	}
 
 	e.updatedField = opts.UpdatedTimestamps
-	e.beforeField = opts.Diff
+	// The debezium envelope always has a before field.
+	e.beforeField = opts.Diff || opts.Envelope == changefeedbase.OptEnvelopeDebezium
 
 	// TODO: Implement this.
 	if opts.KeyInValue {
@@ -117,17 +118,26 @@ func newConfluentAvroEncoder(
 // Get the raw SQL-formatted string for a table name
 // and apply full_table_name and avro_schema_prefix options
 func (e *confluentAvroEncoder) rawTableName(eventMeta cdcevent.Metadata) (string, error) {
-	target, found := e.targets.FindByTableIDAndFamilyName(eventMeta.TableID, eventMeta.FamilyName)
+	return confluentRawTableName(e.targets, e.schemaPrefix, eventMeta)
+}
+
+// confluentRawTableName returns the raw SQL-formatted string for a table name
+// with the given schema prefix applied. It is used to name the schemas that
+// are registered with a confluent schema registry.
+func confluentRawTableName(
+	targets changefeedbase.Targets, schemaPrefix string, eventMeta cdcevent.Metadata,
+) (string, error) {
+	target, found := targets.FindByTableIDAndFamilyName(eventMeta.TableID, eventMeta.FamilyName)
 	if !found {
 		return eventMeta.TableName, errors.Newf("Could not find Target for %s", eventMeta)
 	}
 	switch target.Type {
 	case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY:
-		return e.schemaPrefix + string(target.StatementTimeName), nil
+		return schemaPrefix + string(target.StatementTimeName), nil
 	case jobspb.ChangefeedTargetSpecification_EACH_FAMILY:
-		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
+		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
 	case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
-		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, target.FamilyName), nil
+		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, target.FamilyName), nil
 	default:
 		return "", errors.AssertionFailedf("Found a matching target with unimplemented type %s", target.Type)
 	}
@@ -135,6 +145,29 @@ func (e *confluentAvroEncoder) rawTableName(eventMeta cdcevent.Metadata) (string
 
 // EncodeKey implements the Encoder interface.
 func (e *confluentAvroEncoder) EncodeKey(ctx context.Context, row cdcevent.Row) ([]byte, error) {
+	key, err := e.encodeKey(ctx, row)
+	return key, markAvroRowError(err)
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentAvroEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	value, err := e.encodeValue(ctx, evCtx, updatedRow, prevRow)
+	return value, markAvroRowError(err)
+}
+
+// markAvroRowError marks the terminal errors returned while converting a row
+// and its schema to Avro as specific to the row. They are caused by the row's
+// types or values, which retrying won't change.
+func markAvroRowError(err error) error {
+	if err != nil && changefeedbase.IsTerminalError(err) {
+		return markUndeliverableEvent(err)
+	}
+	return err
+}
+
+func (e *confluentAvroEncoder) encodeKey(ctx context.Context, row cdcevent.Row) ([]byte, error) {
 	// No familyID in the cache key for keys because it's the same schema for all families
 	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
 
@@ -175,8 +208,7 @@ func (e *confluentAvroEncoder) EncodeKey(ctx context.Context, row cdcevent.Row)
 	return registered.schema.BinaryFromRow(header, row.ForEachKeyColumn())
 }
 
-// EncodeValue implements the Encoder interface.
-func (e *confluentAvroEncoder) EncodeValue(
+func (e *confluentAvroEncoder) encodeValue(
 	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
 ) ([]byte, error) {
 	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
@@ -226,10 +258,14 @@ func (e *confluentAvroEncoder) EncodeValue(
 		// it goes in the "record" field. In the "key_only" envelope it's omitted.
 		// This means metadata can safely go at the top level as there are never arbitrary column names
 		// for it to conflict with.
-		if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+		switch e.envelopeType {
+		case changefeedbase.OptEnvelopeWrapped:
 			opts = avroEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField}
 			afterDataSchema = currentSchema
-		} else {
+		case changefeedbase.OptEnvelopeDebezium:
+			opts = avroEnvelopeOpts{afterField: true, beforeField: beforeDataSchema != nil, debeziumFields: true}
+			afterDataSchema = currentSchema
+		default:
 			opts = avroEnvelopeOpts{recordField: true, updatedField: e.updatedField}
 			recordDataSchema = currentSchema
 		}
@@ -260,6 +296,13 @@ func (e *confluentAvroEncoder) EncodeValue(
 			`updated`: evCtx.updated,
 		}
 	}
+	if registered.schema.opts.debeziumFields {
+		meta = map[string]interface{}{
+			`op`:     debeziumOp(evCtx, updatedRow, prevRow),
+			`source`: makeDebeziumSource(evCtx, updatedRow),
+			`ts_ms`:  debeziumProcessingTime().UnixMilli(),
+		}
+	}
 
 	// https://docs.confluent.io/current/schema-registry/docs/serializer-formatter.html#wire-format
 	header := []byte{
@@ -312,5 +355,6 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 func (e *confluentAvroEncoder) register(
 	ctx context.Context, schema *avroRecord, subject string,
 ) (int32, error) {
-	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.codec.Schema())
+	return e.schemaRegistry.RegisterSchemaForSubject(
+		ctx, subject, confluentSchemaTypeAvro, schema.codec.Schema())
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -28,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -87,11 +89,16 @@ func makeJSONEncoder(opts changefeedbase.EncodingOptions) (*jsonEncoder, error)
 		}
 	}
 
-	if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+	switch e.envelopeType {
+	case changefeedbase.OptEnvelopeWrapped:
 		if err := e.initWrappedEnvelope(); err != nil {
 			return nil, err
 		}
-	} else {
+	case changefeedbase.OptEnvelopeDebezium:
+		if err := e.initDebeziumEnvelope(); err != nil {
+			return nil, err
+		}
+	default:
 		if err := e.initRawEnvelope(); err != nil {
 			return nil, err
 		}
@@ -340,6 +347,75 @@ func (e *jsonEncoder) initWrappedEnvelope() error {
 	return nil
 }
 
+func (e *jsonEncoder) initDebeziumEnvelope() error {
+	b, err := json.NewFixedKeysObjectBuilder([]string{"before", "after", "op", "source", "ts_ms"})
+	if err != nil {
+		return err
+	}
+	// NB: The builder sorts the keys it's given in place.
+	sourceBuilder, err := json.NewFixedKeysObjectBuilder(
+		append([]string(nil), debeziumSourceFields...))
+	if err != nil {
+		return err
+	}
+
+	e.envelopeEncoder = func(evCtx eventContext, updated, prev cdcevent.Row) (json.JSON, error) {
+		after, err := e.versionEncoder(updated.EventDescriptor).rowAsGoNative(updated, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("after", after); err != nil {
+			return nil, err
+		}
+
+		var before json.JSON = json.NullJSONValue
+		if prev.IsInitialized() && !prev.IsDeleted() {
+			before, err = e.versionEncoder(prev.EventDescriptor).rowAsGoNative(prev, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := b.Set("before", before); err != nil {
+			return nil, err
+		}
+
+		if err := b.Set("op", json.FromString(debeziumOp(evCtx, updated, prev))); err != nil {
+			return nil, err
+		}
+
+		source := makeDebeziumSource(evCtx, updated)
+		for _, kv := range []struct {
+			key string
+			val json.JSON
+		}{
+			{debeziumSourceConnector, json.FromString(debeziumConnector)},
+			{debeziumSourceDB, json.FromString(source.db)},
+			{debeziumSourceSchema, json.FromString(source.schema)},
+			{debeziumSourceTable, json.FromString(source.table)},
+			{debeziumSourceTsMs, json.FromInt64(source.tsMs())},
+			{debeziumSourceSnapshot, json.FromString(source.snapshotString())},
+			{debeziumSourceMVCC, json.FromString(source.mvcc.AsOfSystemTime())},
+		} {
+			if err := sourceBuilder.Set(kv.key, kv.val); err != nil {
+				return nil, err
+			}
+		}
+		sourceJSON, err := sourceBuilder.Build()
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("source", sourceJSON); err != nil {
+			return nil, err
+		}
+
+		if err := b.Set("ts_ms", json.FromInt64(debeziumProcessingTime().UnixMilli())); err != nil {
+			return nil, err
+		}
+		return b.Build()
+	}
+	return nil
+}
+
 // EncodeValue implements the Encoder interface.
 func (e *jsonEncoder) EncodeValue(
 	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
@@ -348,7 +424,10 @@ func (e *jsonEncoder) EncodeValue(
 		return nil, nil
 	}
 
-	if updatedRow.IsDeleted() && !canJSONEncodeMetadata(e.envelopeType) {
+	// The debezium envelope describes deletions in the value, like the wrapped
+	// envelope does, but doesn't support encoding metadata.
+	if updatedRow.IsDeleted() && !canJSONEncodeMetadata(e.envelopeType) &&
+		e.envelopeType != changefeedbase.OptEnvelopeDebezium {
 		return nil, nil
 	}
 
@@ -369,7 +448,34 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(
 		`resolved`: eval.TimestampToDecimalDatum(resolved).Decimal.String(),
 	}
 	var jsonEntries interface{}
-	if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+	if e.envelopeType == changefeedbase.OptEnvelopeWrapped ||
+		e.envelopeType == changefeedbase.OptEnvelopeDebezium {
+		jsonEntries = meta
+	} else {
+		jsonEntries = map[string]interface{}{
+			jsonMetaSentinel: meta,
+		}
+	}
+	return gojson.Marshal(jsonEntries)
+}
+
+// EncodeTxnBoundary implements the txnBoundaryEncoder interface.
+func (e *jsonEncoder) EncodeTxnBoundary(
+	_ context.Context, _ string, b jobspb.TxnBoundary,
+) ([]byte, error) {
+	txn := map[string]interface{}{
+		`mvcc_timestamp`: eval.TimestampToDecimalDatum(b.Timestamp).Decimal.String(),
+		`row_count`:      b.RowCount,
+	}
+	if !b.TxnID.Equal(uuid.Nil) {
+		txn[`id`] = b.TxnID.String()
+	}
+	meta := map[string]interface{}{
+		`txn`: txn,
+	}
+	var jsonEntries interface{}
+	if e.envelopeType == changefeedbase.OptEnvelopeWrapped ||
+		e.envelopeType == changefeedbase.OptEnvelopeDebezium {
 		jsonEntries = meta
 	} else {
 		jsonEntries = map[string]interface{}{
@@ -0,0 +1,249 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// confluentProtobufEncoder encodes changefeed entries as protobuf messages in
+// the confluent wire format. Keys are the primary key columns in a message.
+// Values are all columns in a message, wrapped in an envelope message. The
+// .proto schema of every message is derived from the table descriptor and
+// registered with a confluent schema registry.
+type confluentProtobufEncoder struct {
+	schemaRegistry            schemaRegistry
+	schemaPrefix              string
+	updatedField, beforeField bool
+	targets                   changefeedbase.Targets
+	envelopeType              changefeedbase.EnvelopeType
+
+	keyCache   *cache.UnorderedCache // [tableIDAndVersion]confluentRegisteredProtobufKeySchema
+	valueCache *cache.UnorderedCache // [tableIDAndVersionPair]confluentRegisteredProtobufEnvelopeSchema
+
+	// resolvedCache doesn't need to be bounded like the other caches because the number of topics
+	// is fixed per changefeed.
+	resolvedCache map[string]confluentRegisteredProtobufEnvelopeSchema
+
+	buf []byte
+}
+
+type confluentRegisteredProtobufKeySchema struct {
+	schema     *protobufMessage
+	registryID int32
+}
+
+type confluentRegisteredProtobufEnvelopeSchema struct {
+	schema     *protobufEnvelopeMessage
+	registryID int32
+}
+
+var _ Encoder = &confluentProtobufEncoder{}
+
+func newConfluentProtobufEncoder(
+	opts changefeedbase.EncodingOptions, targets changefeedbase.Targets,
+) (*confluentProtobufEncoder, error) {
+	e := &confluentProtobufEncoder{
+		schemaPrefix: opts.AvroSchemaPrefix,
+		updatedField: opts.UpdatedTimestamps,
+		beforeField:  opts.Diff,
+		targets:      targets,
+		envelopeType: opts.Envelope,
+	}
+
+	if opts.KeyInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if opts.TopicInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptTopicInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if len(opts.SchemaRegistryURI) == 0 {
+		return nil, errors.Errorf(`WITH option %s is required for %s=%s`,
+			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+
+	reg, err := newConfluentSchemaRegistry(opts.SchemaRegistryURI)
+	if err != nil {
+		return nil, err
+	}
+
+	e.schemaRegistry = reg
+	e.keyCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.valueCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.resolvedCache = make(map[string]confluentRegisteredProtobufEnvelopeSchema)
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeKey(
+	ctx context.Context, row cdcevent.Row,
+) ([]byte, error) {
+	// No familyID in the cache key for keys because it's the same schema for all families
+	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
+
+	var registered confluentRegisteredProtobufKeySchema
+	if v, ok := e.keyCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufKeySchema)
+	} else {
+		tableName, err := confluentRawTableName(e.targets, e.schemaPrefix, row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.schema, err = primaryIndexToProtobufMessage(row, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(tableName) + confluentSubjectSuffixKey
+		registered.registryID, err = e.register(ctx, subject, registered.schema)
+		if err != nil {
+			return nil, err
+		}
+		e.keyCache.Add(cacheKey, registered)
+	}
+
+	var err error
+	e.buf = appendConfluentProtobufHeader(e.buf[:0], registered.registryID)
+	e.buf, err = registered.schema.appendRow(e.buf, row.ForEachKeyColumn())
+	return e.buf, err
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
+		return nil, nil
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && prevRow.IsInitialized() {
+		cacheKey[0] = tableIDAndVersion{
+			tableID: prevRow.TableID, version: prevRow.Version, familyID: prevRow.FamilyID,
+		}
+	}
+	cacheKey[1] = tableIDAndVersion{
+		tableID: updatedRow.TableID, version: updatedRow.Version, familyID: updatedRow.FamilyID,
+	}
+
+	var registered confluentRegisteredProtobufEnvelopeSchema
+	if v, ok := e.valueCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufEnvelopeSchema)
+	} else {
+		var beforeMessage, afterMessage, recordMessage *protobufMessage
+		if e.beforeField && prevRow.IsInitialized() {
+			var err error
+			beforeMessage, err = tableToProtobufMessage(prevRow, `before`)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		currentMessage, err := tableToProtobufMessage(updatedRow, avroSchemaNoSuffix)
+		if err != nil {
+			return nil, err
+		}
+
+		// As with avro, row data goes in the "after" field in the wrapped
+		// envelope and in the "record" field otherwise.
+		var opts protobufEnvelopeOpts
+		if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+			opts = protobufEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField}
+			afterMessage = currentMessage
+		} else {
+			opts = protobufEnvelopeOpts{recordField: true, updatedField: e.updatedField}
+			recordMessage = currentMessage
+		}
+
+		name, err := confluentRawTableName(e.targets, e.schemaPrefix, updatedRow.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.schema = envelopeToProtobufMessage(name, opts, beforeMessage, afterMessage, recordMessage)
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(name) + confluentSubjectSuffixValue
+		registered.registryID, err = e.register(
+			ctx, subject, &registered.schema.protobufMessage, registered.schema.nestedMessages()...)
+		if err != nil {
+			return nil, err
+		}
+		e.valueCache.Add(cacheKey, registered)
+	}
+
+	var err error
+	e.buf = appendConfluentProtobufHeader(e.buf[:0], registered.registryID)
+	e.buf, err = registered.schema.appendEnvelope(
+		e.buf, evCtx.updated, hlc.Timestamp{} /* resolved */, prevRow, updatedRow, updatedRow)
+	return e.buf, err
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	registered, ok := e.resolvedCache[topic]
+	if !ok {
+		opts := protobufEnvelopeOpts{resolvedField: true}
+		registered.schema = envelopeToProtobufMessage(topic, opts, nil /* before */, nil /* after */, nil /* record */)
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		var err error
+		registered.registryID, err = e.register(ctx, subject, &registered.schema.protobufMessage)
+		if err != nil {
+			return nil, err
+		}
+
+		e.resolvedCache[topic] = registered
+	}
+	var nilRow cdcevent.Row
+	var err error
+	e.buf = appendConfluentProtobufHeader(e.buf[:0], registered.registryID)
+	e.buf, err = registered.schema.appendEnvelope(
+		e.buf, hlc.Timestamp{} /* updated */, resolved, nilRow, nilRow, nilRow)
+	return e.buf, err
+}
+
+func (e *confluentProtobufEncoder) register(
+	ctx context.Context, subject string, message *protobufMessage, nested ...*protobufMessage,
+) (int32, error) {
+	schema := protobufSchema(append([]*protobufMessage{message}, nested...)...)
+	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, confluentSchemaTypeProtobuf, schema)
+}
+
+// appendConfluentProtobufHeader appends the confluent wire format header for a
+// protobuf message to buf.
+//
+// https://docs.confluent.io/platform/current/schema-registry/serdes-develop/index.html#wire-format
+func appendConfluentProtobufHeader(buf []byte, registryID int32) []byte {
+	buf = append(buf,
+		changefeedbase.ConfluentProtobufWireFormatMagic,
+		0, 0, 0, 0, // Placeholder for the ID.
+	)
+	binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(registryID))
+	// The header ends with the indexes of the encoded message within the
+	// registered schema. The encoded message is always the first message
+	// declared in the schema, and the message index list [0] is encoded as a
+	// single 0 byte.
+	return append(buf, 0)
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// parseTestProtobufSchema parses the subset of the .proto syntax produced by
+// protobufSchema into a file descriptor.
+func parseTestProtobufSchema(t *testing.T, schema string) protoreflect.FileDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:   proto.String(`test.proto`),
+		Syntax: proto.String(`proto3`),
+	}
+	var msg *descriptorpb.DescriptorProto
+	for _, line := range strings.Split(schema, "\n") {
+		tokens := strings.Fields(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+		switch {
+		case len(tokens) == 0 || tokens[0] == `syntax`:
+		case tokens[0] == `message`:
+			msg = &descriptorpb.DescriptorProto{Name: proto.String(tokens[1])}
+			fdp.MessageType = append(fdp.MessageType, msg)
+		case tokens[0] == `}`:
+			msg = nil
+		default:
+			require.NotNil(t, msg, "field outside of message: %s", line)
+			optional := tokens[0] == `optional`
+			if optional {
+				tokens = tokens[1:]
+			}
+			// <type> <name> = <number>
+			require.Len(t, tokens, 4, "unexpected field: %s", line)
+			num, err := strconv.Atoi(tokens[3])
+			require.NoError(t, err)
+			field := &descriptorpb.FieldDescriptorProto{
+				Name:   proto.String(tokens[1]),
+				Number: proto.Int32(int32(num)),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}
+			if typ, ok := descriptorpb.FieldDescriptorProto_Type_value[`TYPE_`+strings.ToUpper(tokens[0])]; ok {
+				field.Type = descriptorpb.FieldDescriptorProto_Type(typ).Enum()
+			} else {
+				field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+				field.TypeName = proto.String(`.` + tokens[0])
+			}
+			if optional {
+				// proto3 optional fields are members of a synthetic oneof.
+				field.Proto3Optional = proto.Bool(true)
+				field.OneofIndex = proto.Int32(int32(len(msg.OneofDecl)))
+				msg.OneofDecl = append(msg.OneofDecl,
+					&descriptorpb.OneofDescriptorProto{Name: proto.String(`_` + tokens[1])})
+			}
+			msg.Field = append(msg.Field, field)
+		}
+	}
+	fd, err := protodesc.NewFile(fdp, nil /* resolver */)
+	require.NoError(t, err)
+	return fd
+}
+
+// protobufToJSON decodes bytes that were encoded by the confluent protobuf
+// encoder using the schema most recently registered for the given subject and
+// returns their JSON representation with sorted keys.
+func protobufToJSON(
+	t *testing.T, reg *cdctest.SchemaRegistry, subject string, encoded []byte,
+) string {
+	t.Helper()
+	if len(encoded) == 0 {
+		return ``
+	}
+	require.Greater(t, len(encoded), 5)
+	require.Equal(t, changefeedbase.ConfluentProtobufWireFormatMagic, encoded[0])
+	// The message index list [0] is encoded as a single 0 byte.
+	require.Equal(t, byte(0), encoded[5])
+
+	fd := parseTestProtobufSchema(t, reg.SchemaForSubject(subject))
+	msg := dynamicpb.NewMessage(fd.Messages().Get(0))
+	require.NoError(t, proto.Unmarshal(encoded[6:], msg))
+	protoJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	require.NoError(t, err)
+
+	// protojson output is deliberately unstable, so round trip it through
+	// encoding/json, which sorts object keys.
+	var native interface{}
+	require.NoError(t, json.Unmarshal(protoJSON, &native))
+	sorted, err := json.Marshal(native)
+	require.NoError(t, err)
+	return string(sorted)
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(
+		`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c BOOL, d FLOAT, e BYTES, f DECIMAL)`)
+	require.NoError(t, err)
+	dec, err := tree.ParseDDecimal(`1.50`)
+	require.NoError(t, err)
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+		rowenc.EncDatum{Datum: tree.DBoolTrue},
+		rowenc.EncDatum{Datum: tree.NewDFloat(1.5)},
+		rowenc.EncDatum{Datum: tree.NewDBytes("\x01")},
+		rowenc.EncDatum{Datum: dec},
+	}
+	nullRow := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(0)},
+		rowenc.EncDatum{Datum: tree.DNull},
+		rowenc.EncDatum{Datum: tree.DBoolFalse},
+		rowenc.EncDatum{Datum: tree.DNull},
+		rowenc.EncDatum{Datum: tree.DNull},
+		rowenc.EncDatum{Datum: tree.DNull},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+
+	const (
+		rowJSON     = `{"a":"1","b":"bar","c":true,"d":1.5,"e":"AQ==","f":"1.50"}`
+		nullRowJSON = `{"a":"0","c":false}`
+		rowMessage  = `{
+  optional int64 a = 1;
+  optional string b = 2;
+  optional bool c = 3;
+  optional double d = 4;
+  optional bytes e = 5;
+  optional string f = 6;
+}
+`
+	)
+
+	for _, tc := range []struct {
+		envelope      changefeedbase.EnvelopeType
+		updated, diff bool
+
+		valueSchema                string
+		insert, insertNull, delete string
+	}{
+		{
+			envelope: changefeedbase.OptEnvelopeKeyOnly,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			valueSchema: "syntax = \"proto3\";\n\n" +
+				"message foo_envelope {\n  foo after = 1;\n}\n\n" +
+				"message foo " + rowMessage,
+			insert:     `{"after":` + rowJSON + `}`,
+			insertNull: `{"after":` + nullRowJSON + `}`,
+			delete:     `{}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			updated:  true,
+			diff:     true,
+			valueSchema: "syntax = \"proto3\";\n\n" +
+				"message foo_envelope {\n" +
+				"  foo after = 1;\n" +
+				"  foo_before before = 2;\n" +
+				"  optional string updated = 4;\n" +
+				"}\n\n" +
+				"message foo " + rowMessage + "\n" +
+				"message foo_before " + rowMessage,
+			insert:     `{"after":` + rowJSON + `,"updated":"1.0000000002"}`,
+			insertNull: `{"after":` + nullRowJSON + `,"updated":"1.0000000002"}`,
+			delete:     `{"before":` + rowJSON + `,"updated":"1.0000000002"}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeBare,
+			valueSchema: "syntax = \"proto3\";\n\n" +
+				"message foo_envelope {\n  foo record = 3;\n}\n\n" +
+				"message foo " + rowMessage,
+			insert:     `{"record":` + rowJSON + `}`,
+			insertNull: `{"record":` + nullRowJSON + `}`,
+			delete:     `{"record":` + rowJSON + `}`,
+		},
+	} {
+		name := fmt.Sprintf("envelope=%s,updated=%t,diff=%t", tc.envelope, tc.updated, tc.diff)
+		t.Run(name, func(t *testing.T) {
+			reg := cdctest.StartTestSchemaRegistry()
+			defer reg.Close()
+
+			opts := changefeedbase.EncodingOptions{
+				Format:            changefeedbase.OptFormatProtobuf,
+				Envelope:          tc.envelope,
+				UpdatedTimestamps: tc.updated,
+				Diff:              tc.diff,
+				SchemaRegistryURI: reg.URL(),
+			}
+			require.NoError(t, opts.Validate())
+			targets := changefeedbase.Targets{}
+			targets.Add(changefeedbase.Target{
+				Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+				TableID:           tableDesc.GetID(),
+				StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+			})
+			e, err := getEncoder(opts, targets)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			evCtx := eventContext{updated: ts}
+			encode := func(updated, prev cdcevent.Row) (key, value string) {
+				k, err := e.EncodeKey(ctx, updated)
+				require.NoError(t, err)
+				key = protobufToJSON(t, reg, `foo-key`, k)
+				v, err := e.EncodeValue(ctx, evCtx, updated, prev)
+				require.NoError(t, err)
+				value = protobufToJSON(t, reg, `foo-value`, v)
+				return key, value
+			}
+
+			key, value := encode(
+				cdcevent.TestingMakeEventRow(tableDesc, 0, row, false),
+				cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false),
+			)
+			require.Equal(t, `{"a":"1"}`, key)
+			require.Equal(t, tc.insert, value)
+
+			key, value = encode(
+				cdcevent.TestingMakeEventRow(tableDesc, 0, nullRow, false),
+				cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false),
+			)
+			require.Equal(t, `{"a":"0"}`, key)
+			require.Equal(t, tc.insertNull, value)
+
+			key, value = encode(
+				cdcevent.TestingMakeEventRow(tableDesc, 0, row, true),
+				cdcevent.TestingMakeEventRow(tableDesc, 0, row, false),
+			)
+			require.Equal(t, `{"a":"1"}`, key)
+			require.Equal(t, tc.delete, value)
+
+			require.Equal(t, string(confluentSchemaTypeProtobuf), reg.SchemaTypeForSubject(`foo-key`))
+			require.Equal(t,
+				"syntax = \"proto3\";\n\nmessage foo {\n  optional int64 a = 1;\n}\n",
+				reg.SchemaForSubject(`foo-key`))
+			if tc.envelope != changefeedbase.OptEnvelopeKeyOnly {
+				require.Equal(t, string(confluentSchemaTypeProtobuf), reg.SchemaTypeForSubject(`foo-value`))
+				require.Equal(t, tc.valueSchema, reg.SchemaForSubject(`foo-value`))
+			}
+
+			resolved, err := e.EncodeResolvedTimestamp(ctx, `foo`, ts)
+			require.NoError(t, err)
+			require.Equal(t, `{"resolved":"1.0000000002"}`, protobufToJSON(t, reg, `foo-value`, resolved))
+		})
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		opts := changefeedbase.EncodingOptions{
+			Format:   changefeedbase.OptFormatProtobuf,
+			Envelope: changefeedbase.OptEnvelopeRow,
+		}
+		require.EqualError(t, opts.Validate(), `envelope=row is not supported with format=protobuf`)
+
+		opts.Envelope = changefeedbase.OptEnvelopeWrapped
+		_, err := getEncoder(opts, changefeedbase.Targets{})
+		require.EqualError(t, err, `WITH option confluent_schema_registry is required for format=protobuf`)
+
+		opts.KeyInValue = true
+		_, err = getEncoder(opts, changefeedbase.Targets{})
+		require.EqualError(t, err, `key_in_value is not supported with format=protobuf`)
+	})
+}
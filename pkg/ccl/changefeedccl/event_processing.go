@@ -12,6 +12,7 @@ import (
 	"context"
 	"hash"
 	"hash/crc32"
+	"net/url"
 	"runtime"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdceval"
@@ -37,6 +38,9 @@ type eventContext struct {
 	updated, mvcc hlc.Timestamp
 	// topic is set to the string to be included if TopicInValue is true
 	topic string
+	// backfill is true if the event was produced by a scan of the table, such
+	// as the initial scan, rather than by a write to it.
+	backfill bool
 }
 
 type eventConsumer interface {
@@ -59,6 +63,14 @@ type kvEventToRowConsumer struct {
 	evaluator      *cdceval.Evaluator
 	safeExpr       string
 	encodingFormat changefeedbase.FormatType
+	// emitTombstones is set if every deletion should be followed by a message
+	// with an empty value, which allows kafka log compaction to remove the key.
+	// It is only set for kafka sinks.
+	emitTombstones bool
+	// dlq, if set, receives the events that cannot be encoded.
+	dlq *deadLetterQueue
+	// txnBoundaries, if set, counts the rows emitted for each transaction.
+	txnBoundaries *txnBoundaryTracker
 
 	topicDescriptorCache map[TopicIdentifier]TopicDescriptor
 	topicNamer           *TopicNamer
@@ -76,6 +88,8 @@ func newEventConsumer(
 	knobs TestingKnobs,
 	metrics *Metrics,
 	isSinkless bool,
+	dlq *deadLetterQueue,
+	txnBoundaries *txnBoundaryTracker,
 ) (eventConsumer, EventSink, error) {
 	cfg := flowCtx.Cfg
 	evalCtx := flowCtx.EvalCtx
@@ -101,7 +115,7 @@ func newEventConsumer(
 		}
 
 		return newKVEventToRowConsumer(ctx, cfg, evalCtx, frontier, cursor, s,
-			encoder, details, expr, knobs, topicNamer)
+			encoder, details, expr, knobs, topicNamer, dlq, txnBoundaries)
 	}
 
 	// TODO (jayshrivastava) enable parallel consumers for sinkless changefeeds
@@ -174,15 +188,36 @@ func newKVEventToRowConsumer(
 	expr execinfrapb.Expression,
 	knobs TestingKnobs,
 	topicNamer *TopicNamer,
+	dlq *deadLetterQueue,
+	txnBoundaries *txnBoundaryTracker,
 ) (*kvEventToRowConsumer, error) {
+	encodingOpts, err := details.Opts.GetEncodingOptions()
+	if err != nil {
+		return nil, err
+	}
+	isDebezium := encodingOpts.Envelope == changefeedbase.OptEnvelopeDebezium
+
+	// The database and schema names are only needed by the debezium envelope
+	// and by the entries of the dead letter queue.
+	withNames := isDebezium || dlq != nil
 	includeVirtual := details.Opts.IncludeVirtual()
 	keyOnly := details.Opts.KeyOnly()
-	decoder, err := cdcevent.NewEventDecoder(ctx, cfg, details.Targets, includeVirtual, keyOnly)
-
+	decoder, err := cdcevent.NewEventDecoder(
+		ctx, cfg, details.Targets, includeVirtual, keyOnly, withNames)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tombstones only serve Kafka log compaction.
+	var emitTombstones bool
+	if isDebezium {
+		u, err := url.Parse(details.SinkURI)
+		if err != nil {
+			return nil, err
+		}
+		emitTombstones = isKafkaSink(u)
+	}
+
 	var evaluator *cdceval.Evaluator
 	var safeExpr string
 	if expr.Expr != "" {
@@ -191,15 +226,14 @@ func newKVEventToRowConsumer(
 			return nil, err
 		}
 		safeExpr = tree.AsString(expr)
-		evaluator, err = cdceval.NewEvaluator(ctx, evalCtx, expr)
+		lookups, err := cdceval.NewLookupReader(ctx, cfg, expr)
+		if err != nil {
+			return nil, err
+		}
+		evaluator, err = cdceval.NewEvaluator(ctx, evalCtx, expr, lookups)
 		if err != nil {
 			return nil, err
 		}
-	}
-
-	encodingOpts, err := details.Opts.GetEncodingOptions()
-	if err != nil {
-		return nil, err
 	}
 
 	return &kvEventToRowConsumer{
@@ -215,6 +249,9 @@ func newKVEventToRowConsumer(
 		evaluator:            evaluator,
 		safeExpr:             safeExpr,
 		encodingFormat:       encodingOpts.Format,
+		emitTombstones:       emitTombstones,
+		dlq:                  dlq,
+		txnBoundaries:        txnBoundaries,
 	}, nil
 }
 
@@ -320,8 +357,9 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	}
 
 	evCtx := eventContext{
-		updated: schemaTimestamp,
-		mvcc:    mvccTimestamp,
+		updated:  schemaTimestamp,
+		mvcc:     mvccTimestamp,
+		backfill: !ev.BackfillTimestamp().IsEmpty(),
 	}
 
 	if c.topicNamer != nil {
@@ -352,14 +390,14 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, updatedRow)
 	if err != nil {
-		return err
+		return c.maybeEnqueueToDLQ(ctx, ev, topic, updatedRow, evCtx, err)
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
 	// TODO(yevgeniy): Some refactoring is needed in the encoder: namely, prevRow
 	// might not be available at all when working with changefeed expressions.
 	encodedValue, err := c.encoder.EncodeValue(ctx, evCtx, updatedRow, prevRow)
 	if err != nil {
-		return err
+		return c.maybeEnqueueToDLQ(ctx, ev, topic, updatedRow, evCtx, err)
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
 
@@ -373,12 +411,50 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	); err != nil {
 		return err
 	}
+	if c.emitTombstones && updatedRow.IsDeleted() {
+		// The tombstone reuses the deletion's key and has no value, so it is
+		// not separately accounted for in the memory budget.
+		if err := c.sink.EmitRow(
+			ctx, topic, keyCopy, nil /* value */, schemaTimestamp, mvccTimestamp, kvevent.Alloc{},
+		); err != nil {
+			return err
+		}
+	}
+	if c.txnBoundaries != nil && !evCtx.backfill {
+		c.txnBoundaries.recordRow(ev.TxnID(), mvccTimestamp)
+	}
 	if log.V(3) {
 		log.Infof(ctx, `r %s: %s -> %s`, updatedRow.TableName, keyCopy, valueCopy)
 	}
 	return nil
 }
 
+// maybeEnqueueToDLQ diverts an event which could not be encoded because of the
+// specified error to the dead letter queue. The error is
+// returned if the changefeed doesn't have a dead letter queue, or if the error
+// isn't specific to the event.
+func (c *kvEventToRowConsumer) maybeEnqueueToDLQ(
+	ctx context.Context,
+	ev kvevent.Event,
+	topic TopicDescriptor,
+	row cdcevent.Row,
+	evCtx eventContext,
+	cause error,
+) error {
+	if c.dlq == nil || !isUndeliverableEventError(ctx, cause) {
+		return cause
+	}
+	if err := c.dlq.Enqueue(ctx, topic, row, evCtx, cause); err != nil {
+		return err
+	}
+	a := ev.DetachAlloc()
+	a.Release(ctx)
+	if log.V(1) {
+		log.Infof(ctx, `emitted event of %s to the dead letter queue: %v`, row.TableName, cause)
+	}
+	return nil
+}
+
 // Close is a noop for the kvEventToRowConsumer because it
 // has no goroutines in flight.
 func (c *kvEventToRowConsumer) Close() error {
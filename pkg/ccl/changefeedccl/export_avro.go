@@ -0,0 +1,285 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/importer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+	"github.com/linkedin/goavro/v2"
+)
+
+const (
+	avroExportFilePatternPart    = "%part%"
+	avroExportFilePatternDefault = avroExportFilePatternPart + ".avro"
+
+	// avroExportRecordName is the name of the record schema of exported files.
+	avroExportRecordName = "export"
+
+	// avroExportBlockRows is the number of rows written to each block of an
+	// exported object container file. Compression is applied per block, so
+	// writing a block per row would make it next to useless.
+	avroExportBlockRows = 1000
+)
+
+// avroExportSchema is the schema of the records of exported avro files, which
+// uses the same schema that changefeeds use for the column types.
+type avroExportSchema struct {
+	codec       *goavro.Codec
+	compression string
+	fields      []*avroSchemaField
+}
+
+func newAvroExportSchema(sp execinfrapb.ExportSpec, typs []*types.T) (*avroExportSchema, error) {
+	if len(sp.ColNames) != len(typs) {
+		return nil, errors.AssertionFailedf(
+			"expected %d column names for avro export, found %d", len(typs), len(sp.ColNames))
+	}
+	record := &avroRecord{
+		Name:       avroExportRecordName,
+		SchemaType: `record`,
+	}
+	for i, typ := range typs {
+		field, err := typeToAvroSchema(typ)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", sp.ColNames[i])
+		}
+		field.Name = SQLNameToAvroName(sp.ColNames[i])
+		field.Metadata = typ.SQLString()
+		field.Default = nil
+		record.Fields = append(record.Fields, field)
+	}
+	schemaJSON, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := goavro.NewCodec(string(schemaJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	compression := goavro.CompressionNullLabel
+	switch sp.Format.Compression {
+	case roachpb.IOFileFormat_Gzip:
+		compression = goavro.CompressionDeflateLabel
+	case roachpb.IOFileFormat_Snappy:
+		compression = goavro.CompressionSnappyLabel
+	}
+
+	return &avroExportSchema{
+		codec:       codec,
+		compression: compression,
+		fields:      record.Fields,
+	}, nil
+}
+
+// avroExporter writes rows to an avro object container file (OCF).
+// Compression is done by the OCF block codec rather than by compressing the
+// whole file, so the files can be read back by IMPORT AVRO as is.
+type avroExporter struct {
+	*avroExportSchema
+	buf *bytes.Buffer
+	ocf *goavro.OCFWriter
+	// pending holds the records that have not yet been appended to the OCF
+	// writer as a block, and pendingBytes is the size of their binary encoding
+	// before compression.
+	pending      []interface{}
+	pendingBytes int
+	scratch      []byte
+}
+
+var _ importer.ExportEncoder = &avroExporter{}
+
+// newExporter starts a new object container file with the schema.
+func (s *avroExportSchema) newExporter() (*avroExporter, error) {
+	buf := bytes.NewBuffer([]byte{})
+	ocf, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               buf,
+		Codec:           s.codec,
+		CompressionName: s.compression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &avroExporter{
+		avroExportSchema: s,
+		buf:              buf,
+		ocf:              ocf,
+	}, nil
+}
+
+// Write appends a row to the avro file. Rows are buffered until a full block
+// is available or the exporter is closed.
+func (c *avroExporter) Write(row tree.Datums) error {
+	native := make(map[string]interface{}, len(c.fields))
+	for i, d := range row {
+		v, err := encodeAvroExportDatum(c.fields[i], d)
+		if err != nil {
+			return err
+		}
+		native[c.fields[i].Name] = v
+	}
+	// The records are encoded again when the block is appended, but the size
+	// of the pending records has to be known for the file to be cut at the
+	// requested size.
+	encoded, err := c.codec.BinaryFromNative(c.scratch[:0], native)
+	if err != nil {
+		return err
+	}
+	c.scratch = encoded
+	c.pending = append(c.pending, native)
+	c.pendingBytes += len(encoded)
+	if len(c.pending) >= avroExportBlockRows {
+		return c.flush()
+	}
+	return nil
+}
+
+// encodeAvroExportDatum encodes d as the native value for the nullable field.
+// Unlike field.encodeFn, the result is freshly allocated, so that a whole block
+// of rows can be buffered before it is encoded.
+func encodeAvroExportDatum(field *avroSchemaField, d tree.Datum) (interface{}, error) {
+	if d == tree.DNull {
+		return nil, nil
+	}
+	encoded, err := field.encodeDatum(tree.UnwrapDOidWrapper(d), nil /* memo */)
+	if err != nil {
+		return nil, err
+	}
+	union := field.SchemaType.([]avroSchemaType)
+	unionKey := avroUnionKey(union[1])
+	if _, isString := encoded.(string); isString && len(union) > 2 {
+		// Types such as decimal fall back to a string for values that the main
+		// type cannot represent.
+		unionKey = avroUnionKey(avroSchemaString)
+	}
+	return map[string]interface{}{unionKey: encoded}, nil
+}
+
+// flush appends any buffered rows to the OCF writer as a block.
+func (c *avroExporter) flush() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+	if err := c.ocf.Append(c.pending); err != nil {
+		return err
+	}
+	c.pending = c.pending[:0]
+	c.pendingBytes = 0
+	return nil
+}
+
+// Close appends any buffered rows to the file. The OCF writer doesn't buffer
+// anything itself, so the file is then complete.
+func (c *avroExporter) Close() error {
+	return c.flush()
+}
+
+// Bytes results in the slice of bytes.
+func (c *avroExporter) Bytes() []byte {
+	return c.buf.Bytes()
+}
+
+// Len returns the length of the file, including the rows which are still
+// buffered, before compression, until they are appended to the file.
+func (c *avroExporter) Len() int {
+	return c.buf.Len() + c.pendingBytes
+}
+
+// FileName returns the name of the file for the given part. No suffix is added
+// for compression since the codec is recorded in the file header.
+func (c *avroExporter) FileName(spec execinfrapb.ExportSpec, part string) string {
+	pattern := avroExportFilePatternDefault
+	if spec.NamePattern != "" {
+		pattern = spec.NamePattern
+	}
+	return strings.Replace(pattern, avroExportFilePatternPart, part, -1)
+}
+
+func newAvroWriterProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportSpec,
+	input execinfra.RowSource,
+	output execinfra.RowReceiver,
+) (execinfra.Processor, error) {
+	c := &avroWriterProcessor{
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		input:       input,
+		output:      output,
+	}
+	semaCtx := tree.MakeSemaContext()
+	if err := c.out.Init(ctx, &execinfrapb.PostProcessSpec{}, c.OutputTypes(), &semaCtx, flowCtx.NewEvalCtx()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type avroWriterProcessor struct {
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ExportSpec
+	input       execinfra.RowSource
+	out         execinfra.ProcOutputHelper
+	output      execinfra.RowReceiver
+}
+
+var _ execinfra.Processor = &avroWriterProcessor{}
+
+func (sp *avroWriterProcessor) OutputTypes() []*types.T {
+	res := make([]*types.T, len(colinfo.ExportColumns))
+	for i := range res {
+		res[i] = colinfo.ExportColumns[i].Typ
+	}
+	return res
+}
+
+func (sp *avroWriterProcessor) MustBeStreaming() bool {
+	return false
+}
+
+func (sp *avroWriterProcessor) Run(ctx context.Context) {
+	ctx, span := tracing.ChildSpan(ctx, "avroWriter")
+	defer span.Finish()
+
+	// The schema is shared by the files of all chunks.
+	var schema *avroExportSchema
+	err := importer.WriteExportFiles(ctx, sp.flowCtx, sp.spec, sp.input, &sp.out, sp.output,
+		func() (importer.ExportEncoder, error) {
+			if schema == nil {
+				var err error
+				if schema, err = newAvroExportSchema(sp.spec, sp.input.OutputTypes()); err != nil {
+					return nil, err
+				}
+			}
+			return schema.newExporter()
+		})
+
+	execinfra.DrainAndClose(
+		ctx, sp.output, err, func(context.Context) {} /* pushTrailingMeta */, sp.input)
+}
+
+func init() {
+	rowexec.NewAvroWriterProcessor = newAvroWriterProcessor
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAvro(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	const create = `CREATE TABLE %s (
+		id INT PRIMARY KEY, s STRING, d DECIMAL(10, 2), ts TIMESTAMPTZ, day DATE, a INT[], b BOOL
+	)`
+	sqlDB.Exec(t, fmt.Sprintf(create, "foo"))
+	sqlDB.Exec(t, `INSERT INTO foo VALUES
+		(1, 'a', 1.5, '2022-01-02 03:04:05.123456+00', '2022-01-02', ARRAY[1, NULL], true),
+		(2, NULL, NULL, NULL, NULL, NULL, NULL),
+		(3, 'c', 'NaN', '2022-01-03 00:00:00+00', '2022-01-03', ARRAY[], false)`)
+	const query = `SELECT * FROM %s ORDER BY id`
+	expected := sqlDB.QueryStr(t, fmt.Sprintf(query, "foo"))
+
+	// exportAndImport exports foo with the given options and imports the
+	// resulting files into a new table, returning the exported file paths.
+	exportAndImport := func(t *testing.T, name string, opts string) []string {
+		sqlDB.Exec(t, fmt.Sprintf(`EXPORT INTO AVRO 'nodelocal://0/%s' %s FROM TABLE foo`, name, opts))
+		files, err := filepath.Glob(filepath.Join(dir, name, "export*-n*.avro"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+
+		uris := make([]string, len(files))
+		for i, f := range files {
+			uris[i] = fmt.Sprintf(`'nodelocal://0/%s/%s'`, name, filepath.Base(f))
+		}
+		sqlDB.Exec(t, fmt.Sprintf(create, name))
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO %s AVRO DATA (%s)`, name, strings.Join(uris, ", ")))
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(query, name), expected)
+		return files
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		files := exportAndImport(t, "roundtrip", "")
+		require.Len(t, files, 1)
+
+		f, err := os.Open(files[0])
+		require.NoError(t, err)
+		defer f.Close()
+		ocf, err := goavro.NewOCFReader(f)
+		require.NoError(t, err)
+		require.Equal(t, goavro.CompressionNullLabel, ocf.CompressionName())
+		require.Contains(t, ocf.Codec().Schema(), `"__crdb__":"DECIMAL(10,2)"`)
+	})
+
+	t.Run("chunked", func(t *testing.T) {
+		files := exportAndImport(t, "chunked", "WITH chunk_rows = '2'")
+		require.Len(t, files, 2)
+	})
+
+	t.Run("chunk-size", func(t *testing.T) {
+		files := exportAndImport(t, "sized", "WITH chunk_size = '1B'")
+		require.Len(t, files, 3)
+	})
+
+	for _, codec := range []string{"gzip", "snappy"} {
+		t.Run(codec, func(t *testing.T) {
+			exportAndImport(t, codec, fmt.Sprintf("WITH compression = '%s'", codec))
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "column d: decimal with no precision not yet supported with avro",
+			`EXPORT INTO AVRO 'nodelocal://0/unsupported' FROM SELECT 1.5::DECIMAL AS d`)
+	})
+}
+
+func TestAvroExporterLen(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	schema, err := newAvroExportSchema(
+		execinfrapb.ExportSpec{ColNames: []string{"s"}}, []*types.T{types.String})
+	require.NoError(t, err)
+	exporter, err := schema.newExporter()
+	require.NoError(t, err)
+
+	// Rows which are still buffered count towards the length of the file, so
+	// that files are cut at the requested size.
+	headerLen := exporter.Len()
+	require.NoError(t, exporter.Write(tree.Datums{tree.NewDString("abc")}))
+	require.Greater(t, exporter.Len(), headerLen)
+	require.NoError(t, exporter.Close())
+	require.Equal(t, len(exporter.Bytes()), exporter.Len())
+}
@@ -0,0 +1,1019 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	pqexporter "github.com/cockroachdb/cockroach/pkg/sql/importer"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/lib/pq/oid"
+	"github.com/linkedin/goavro/v2"
+)
+
+// icebergCloudStorageSink writes the output of a parquet changefeed as Apache
+// Iceberg tables (https://iceberg.apache.org/spec/), one for each topic. It is
+// used when the cloud storage sink URI specifies table_format=iceberg.
+//
+// The table of each topic is stored in the <topic> directory of the sink:
+//   - <topic>/data contains parquet data files, and equality delete files.
+//   - <topic>/metadata contains manifests, manifest lists and the versions of
+//     the table metadata; version-hint.text holds the current version, as is
+//     the case for tables managed by Iceberg's hadoop catalog.
+//
+// Change aggregators write data files as described in the comment on
+// cloudStorageSink, except that only the latest version of each row is written
+// to the data file, and that each data file is accompanied by an equality
+// delete file with the primary keys of all the rows which were emitted to it,
+// including deleted rows. Aggregators also write a manifest for each of these
+// files; manifests are named after their data file, and are thus ordered just
+// like data files.
+//
+// The change frontier commits a snapshot of each table whenever it emits a
+// resolved timestamp. Once a timestamp is resolved, no data file lexically
+// preceding it can be written, so the snapshot adds the manifests named with
+// timestamps up to the resolved timestamp which weren't added by a prior
+// snapshot. Equality deletes only apply to data files with lower sequence
+// numbers, so the manifests of each data file are assigned their own sequence
+// number, in lexical order: the deletes accompanying a data file remove the
+// prior versions of its rows from all the data files preceding it.
+//
+// Iceberg field IDs are the IDs of the columns, which never change. Changefeed
+// expressions aren't supported, since their columns have no ID.
+type icebergCloudStorageSink struct {
+	*parquetCloudStorageSink
+
+	// location is the URI of the sink, excluding its parameters; the paths in
+	// Iceberg metadata are URIs.
+	location string
+}
+
+const (
+	icebergFormatVersion = 2
+
+	// Content of data files.
+	icebergContentData            = 0
+	icebergContentEqualityDeletes = 2
+
+	// Content of manifests.
+	icebergManifestContentData    = 0
+	icebergManifestContentDeletes = 1
+
+	icebergManifestEntryStatusAdded = 1
+
+	icebergDataDir     = `data`
+	icebergMetadataDir = `metadata`
+	icebergVersionHint = `version-hint.text`
+
+	// Manifest names are suffixed with the kind of the file they describe.
+	icebergDataManifestSuffix   = `-m0.avro`
+	icebergDeleteManifestSuffix = `-m1.avro`
+
+	// icebergResolvedProperty is the snapshot summary property holding the
+	// resolved timestamp (formatted as with cloudStorageFormatTime) up to which
+	// data files have been committed.
+	icebergResolvedProperty = `crdb.changefeed.resolved`
+)
+
+// icebergManifestEntrySchema is the Avro schema of the entries of manifests,
+// limited to the fields needed to describe unpartitioned files.
+const icebergManifestEntrySchema = `{
+  "type": "record",
+  "name": "manifest_entry",
+  "fields": [
+    {"name": "status", "type": "int", "field-id": 0},
+    {"name": "snapshot_id", "type": ["null", "long"], "default": null, "field-id": 1},
+    {"name": "sequence_number", "type": ["null", "long"], "default": null, "field-id": 3},
+    {"name": "file_sequence_number", "type": ["null", "long"], "default": null, "field-id": 4},
+    {"name": "data_file", "field-id": 2, "type": {
+      "type": "record",
+      "name": "r2",
+      "fields": [
+        {"name": "content", "type": "int", "field-id": 134},
+        {"name": "file_path", "type": "string", "field-id": 100},
+        {"name": "file_format", "type": "string", "field-id": 101},
+        {"name": "partition", "field-id": 102, "type": {"type": "record", "name": "r102", "fields": []}},
+        {"name": "record_count", "type": "long", "field-id": 103},
+        {"name": "file_size_in_bytes", "type": "long", "field-id": 104},
+        {"name": "equality_ids", "type": ["null", {"type": "array", "items": "int", "element-id": 136}],
+         "default": null, "field-id": 135}
+      ]
+    }}
+  ]
+}`
+
+// icebergManifestFileSchema is the Avro schema of the entries of manifest
+// lists.
+const icebergManifestFileSchema = `{
+  "type": "record",
+  "name": "manifest_file",
+  "fields": [
+    {"name": "manifest_path", "type": "string", "field-id": 500},
+    {"name": "manifest_length", "type": "long", "field-id": 501},
+    {"name": "partition_spec_id", "type": "int", "field-id": 502},
+    {"name": "content", "type": "int", "field-id": 517},
+    {"name": "sequence_number", "type": "long", "field-id": 515},
+    {"name": "min_sequence_number", "type": "long", "field-id": 516},
+    {"name": "added_snapshot_id", "type": "long", "field-id": 503},
+    {"name": "added_files_count", "type": "int", "field-id": 504},
+    {"name": "existing_files_count", "type": "int", "field-id": 505},
+    {"name": "deleted_files_count", "type": "int", "field-id": 506},
+    {"name": "added_rows_count", "type": "long", "field-id": 512},
+    {"name": "existing_rows_count", "type": "long", "field-id": 513},
+    {"name": "deleted_rows_count", "type": "long", "field-id": 514}
+  ]
+}`
+
+func makeIcebergCloudStorageSink(
+	baseCloudStorageSink *cloudStorageSink, u sinkURL,
+) (*icebergCloudStorageSink, error) {
+	parquetSink, err := makeParquetCloudStorageSink(baseCloudStorageSink)
+	if err != nil {
+		return nil, err
+	}
+	location := *u.URL
+	location.User = nil
+	location.RawQuery = ``
+	return &icebergCloudStorageSink{
+		parquetCloudStorageSink: parquetSink,
+		location:                strings.TrimSuffix(location.String(), `/`),
+	}, nil
+}
+
+// EncodeAndEmitRow implements the SinkWithEncoder interface.
+func (s *icebergCloudStorageSink) EncodeAndEmitRow(
+	ctx context.Context,
+	updatedRow cdcevent.Row,
+	prevRow cdcevent.Row,
+	topic TopicDescriptor,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	cs := s.wrapped
+	file, err := cs.getOrCreateFile(topic, mvcc)
+	if err != nil {
+		return err
+	}
+	file.alloc.Merge(&alloc)
+
+	if file.iceberg == nil {
+		file.iceberg, err = makeIcebergFileWriter(
+			updatedRow, s.location, file.topic, file.schemaID, s.compression)
+		if err != nil {
+			return err
+		}
+	}
+	if err := file.iceberg.addRow(updatedRow); err != nil {
+		return err
+	}
+	file.numMessages++
+
+	// Rows are buffered until the file is flushed; use the memory allocated for
+	// them as an estimate of the size of the file.
+	if file.alloc.Bytes() > cs.targetMaxFileSize {
+		cs.metrics.recordSizeBasedFlush()
+		if err := cs.flushTopicVersions(ctx, file.topic, file.schemaID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmitResolvedTimestamp implements the Sink interface. It commits the data
+// files written up to the resolved timestamp to each table.
+func (s *icebergCloudStorageSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	cs := s.wrapped
+	if cs.files == nil {
+		return errors.New(`cannot EmitRow on a closed sink`)
+	}
+
+	defer cs.metrics.recordResolvedCallback()()
+
+	if err := cs.waitAsyncFlush(ctx); err != nil {
+		return errors.Wrapf(err, "while emitting resolved timestamp")
+	}
+
+	tables, err := s.listTables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := s.commitTable(ctx, table, resolved); err != nil {
+			return errors.Wrapf(err, "committing iceberg table %s", table)
+		}
+	}
+	return nil
+}
+
+// listTables returns the names of the directories at the root of the sink,
+// which include the tables.
+func (s *icebergCloudStorageSink) listTables(ctx context.Context) ([]string, error) {
+	var tables []string
+	if err := s.wrapped.es.List(ctx, `/`, `/`, func(name string) error {
+		if name = strings.Trim(name, `/`); name != `` {
+			tables = append(tables, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// commitTable commits a snapshot of the table adding the manifests of the data
+// files written since the last snapshot, up to the resolved timestamp.
+func (s *icebergCloudStorageSink) commitTable(
+	ctx context.Context, table string, resolved hlc.Timestamp,
+) error {
+	es := s.wrapped.es
+	md, version, err := readIcebergTableMetadata(ctx, es, table)
+	if err != nil {
+		return err
+	}
+	parent := md.currentSnapshot()
+	var committed string
+	if parent != nil {
+		committed = parent.Summary[icebergResolvedProperty]
+	}
+
+	resolvedName := cloudStorageFormatTime(resolved)
+	metadataDir := path.Join(table, icebergMetadataDir)
+	var manifests []string
+	if err := es.List(ctx, metadataDir+`/`, ``, func(name string) error {
+		name = strings.TrimPrefix(name, `/`)
+		if !strings.HasSuffix(name, icebergDataManifestSuffix) &&
+			!strings.HasSuffix(name, icebergDeleteManifestSuffix) {
+			return nil
+		}
+		if len(name) < len(resolvedName) {
+			return nil
+		}
+		if ts := name[:len(resolvedName)]; ts > committed && ts <= resolvedName {
+			manifests = append(manifests, name)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return nil
+	}
+	sort.Strings(manifests)
+
+	var prevMetadataFile string
+	if md == nil {
+		md = newIcebergTableMetadata(s.location + `/` + table)
+	} else {
+		prevMetadataFile = s.location + `/` + icebergMetadataFile(table, version)
+	}
+
+	// The manifest list of the snapshot includes the manifests of its parent.
+	var manifestFiles []interface{}
+	if parent != nil {
+		manifestFiles, err = readIcebergManifestList(
+			ctx, es, strings.TrimPrefix(parent.ManifestList, s.location+`/`))
+		if err != nil {
+			return err
+		}
+	}
+
+	snapshotID := makeIcebergSnapshotID()
+	seq := md.LastSequenceNumber
+	var prevDataFile string
+	var addedFiles, addedRows int64
+	for _, name := range manifests {
+		// The manifests of a data file and of its delete file share a sequence
+		// number.
+		dataFile := name[:len(name)-len(icebergDataManifestSuffix)]
+		if dataFile != prevDataFile {
+			seq++
+			prevDataFile = dataFile
+		}
+		manifestPath := path.Join(metadataDir, name)
+		m, err := readIcebergManifest(ctx, es, manifestPath)
+		if err != nil {
+			return err
+		}
+		md.addSchema(m.schema)
+		addedFiles += m.fileCount
+		addedRows += m.recordCount
+		manifestFiles = append(manifestFiles, map[string]interface{}{
+			"manifest_path":        s.location + `/` + manifestPath,
+			"manifest_length":      m.length,
+			"partition_spec_id":    int32(0),
+			"content":              m.content,
+			"sequence_number":      seq,
+			"min_sequence_number":  seq,
+			"added_snapshot_id":    snapshotID,
+			"added_files_count":    int32(m.fileCount),
+			"existing_files_count": int32(0),
+			"deleted_files_count":  int32(0),
+			"added_rows_count":     m.recordCount,
+			"existing_rows_count":  int64(0),
+			"deleted_rows_count":   int64(0),
+		})
+	}
+
+	snapshot := icebergSnapshot{
+		SnapshotID:     snapshotID,
+		SequenceNumber: seq,
+		TimestampMs:    timeutil.Now().UnixMilli(),
+		ManifestList: s.location + `/` + path.Join(
+			metadataDir, fmt.Sprintf(`snap-%d.avro`, snapshotID)),
+		Summary: map[string]string{
+			`operation`:             `overwrite`,
+			`added-files`:           strconv.FormatInt(addedFiles, 10),
+			`added-records`:         strconv.FormatInt(addedRows, 10),
+			icebergResolvedProperty: resolvedName,
+		},
+		SchemaID: md.CurrentSchemaID,
+	}
+	if parent != nil {
+		snapshot.ParentSnapshotID = &parent.SnapshotID
+	}
+	manifestList, err := encodeIcebergManifestList(snapshot, manifestFiles)
+	if err != nil {
+		return err
+	}
+	if err := cloud.WriteFile(ctx, es,
+		strings.TrimPrefix(snapshot.ManifestList, s.location+`/`), bytes.NewReader(manifestList),
+	); err != nil {
+		return err
+	}
+
+	md.addSnapshot(snapshot, prevMetadataFile)
+	metadata, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+	if err := cloud.WriteFile(ctx, es,
+		icebergMetadataFile(table, version+1), bytes.NewReader(metadata),
+	); err != nil {
+		return err
+	}
+	// The new version becomes current once the version hint is updated.
+	return cloud.WriteFile(ctx, es, path.Join(metadataDir, icebergVersionHint),
+		strings.NewReader(strconv.Itoa(version+1)))
+}
+
+// icebergFileWriter buffers the rows emitted to a cloudStorageSinkFile until
+// the file is flushed, keeping only the latest version of each row.
+type icebergFileWriter struct {
+	location    string // URI of the sink.
+	table       string
+	schema      icebergSchema
+	compression parquet.CompressionCodec
+
+	columns    []pqexporter.ParquetColumn
+	fieldIDs   []int32
+	keyNames   []string
+	keyColumns []pqexporter.ParquetColumn
+	keyIDs     []int32
+
+	rows map[string]icebergRow // Keyed by encoded primary key.
+	keys []string              // Keys of rows, in the order they were emitted.
+
+	// objects are the files to write to the sink once the file is flushed.
+	objects []icebergObject
+}
+
+type icebergRow struct {
+	values  map[string]interface{}
+	deleted bool
+}
+
+type icebergObject struct {
+	path string // Relative to the sink.
+	data []byte
+}
+
+func makeIcebergFileWriter(
+	row cdcevent.Row,
+	location string,
+	table string,
+	schemaID int64,
+	compression parquet.CompressionCodec,
+) (*icebergFileWriter, error) {
+	w := &icebergFileWriter{
+		location:    location,
+		table:       table,
+		schema:      icebergSchema{Type: `struct`, SchemaID: schemaID},
+		compression: compression,
+		rows:        make(map[string]icebergRow),
+	}
+
+	isKey := make(map[string]struct{})
+	if err := row.ForEachKeyColumn().Col(func(col cdcevent.ResultColumn) error {
+		isKey[col.Name] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := row.ForAllColumns().Col(func(col cdcevent.ResultColumn) error {
+		if col.PGAttributeNum == 0 {
+			return changefeedbase.WithTerminalError(pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %s is not a table column: %s=%s does not support changefeed expressions",
+				col.Name, changefeedbase.SinkParamTableFormat, changefeedbase.SinkTableFormatIceberg))
+		}
+		typ, err := icebergType(col.Typ)
+		if err != nil {
+			return changefeedbase.WithTerminalError(
+				errors.Wrapf(err, "column %s", col.Name))
+		}
+		// As with parquet files, all fields are optional so that all schema
+		// changes are compatible.
+		const nullable = true
+		pqCol, err := pqexporter.NewParquetColumn(col.Typ, col.Name, nullable)
+		if err != nil {
+			return err
+		}
+		id := int32(col.PGAttributeNum)
+		w.schema.Fields = append(w.schema.Fields, icebergField{ID: id, Name: col.Name, Type: typ})
+		w.columns = append(w.columns, pqCol)
+		w.fieldIDs = append(w.fieldIDs, id)
+		if _, ok := isKey[col.Name]; ok {
+			w.keyNames = append(w.keyNames, col.Name)
+			w.keyColumns = append(w.keyColumns, pqCol)
+			w.keyIDs = append(w.keyIDs, id)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// icebergType returns the Iceberg type of the values of the parquet column for
+// the specified type.
+func icebergType(typ *types.T) (string, error) {
+	switch typ.Family() {
+	case types.BoolFamily:
+		return `boolean`, nil
+	case types.IntFamily:
+		if typ.Oid() == oid.T_int8 {
+			return `long`, nil
+		}
+		return `int`, nil
+	case types.FloatFamily:
+		if typ.Oid() == oid.T_float4 {
+			return `float`, nil
+		}
+		return `double`, nil
+	case types.StringFamily, types.CollatedStringFamily, types.INetFamily, types.JsonFamily,
+		types.EnumFamily, types.DateFamily, types.TimestampFamily, types.TimestampTZFamily,
+		types.TimeTZFamily, types.IntervalFamily, types.Box2DFamily:
+		// Encoded as strings.
+		return `string`, nil
+	case types.UuidFamily:
+		return `uuid`, nil
+	case types.BytesFamily, types.GeographyFamily, types.GeometryFamily:
+		return `binary`, nil
+	default:
+		return ``, pgerror.Newf(pgcode.FeatureNotSupported,
+			"type %s is not supported by %s=%s", typ.SQLString(),
+			changefeedbase.SinkParamTableFormat, changefeedbase.SinkTableFormatIceberg)
+	}
+}
+
+// addRow buffers the row, replacing any prior version of the row.
+func (w *icebergFileWriter) addRow(row cdcevent.Row) error {
+	var key []byte
+	if err := row.ForEachKeyColumn().Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		var err error
+		key, err = keyside.Encode(key, d, encoding.Ascending)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{}, len(w.columns))
+	colOrd := -1
+	if err := row.ForAllColumns().Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		colOrd++
+		if d == tree.DNull {
+			values[col.Name] = nil
+			return nil
+		}
+		encodeFn, err := w.columns[colOrd].GetEncoder()
+		if err != nil {
+			return err
+		}
+		values[col.Name], err = encodeFn(d)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if _, ok := w.rows[string(key)]; !ok {
+		w.keys = append(w.keys, string(key))
+	}
+	w.rows[string(key)] = icebergRow{values: values, deleted: row.IsDeleted()}
+	return nil
+}
+
+// finish encodes the data file, the equality delete file and their manifests.
+// The files are named after the specified data file name.
+func (w *icebergFileWriter) finish(name string) error {
+	var dataRows, deleteRows []map[string]interface{}
+	for _, k := range w.keys {
+		r := w.rows[k]
+		if !r.deleted {
+			dataRows = append(dataRows, r.values)
+		}
+		keyValues := make(map[string]interface{}, len(w.keyNames))
+		for _, n := range w.keyNames {
+			keyValues[n] = r.values[n]
+		}
+		deleteRows = append(deleteRows, keyValues)
+	}
+	w.rows, w.keys = nil, nil
+
+	var manifests []icebergObject
+	addFile := func(
+		fileName, manifestName string,
+		f icebergDataFile,
+		columns []pqexporter.ParquetColumn,
+		fieldIDs []int32,
+		rows []map[string]interface{},
+	) error {
+		data, err := w.encodeParquet(columns, fieldIDs, rows)
+		if err != nil {
+			return err
+		}
+		filePath := path.Join(w.table, icebergDataDir, fileName)
+		f.path = w.location + `/` + filePath
+		f.recordCount = int64(len(rows))
+		f.size = int64(len(data))
+		manifest, err := encodeIcebergManifest(w.schema, f)
+		if err != nil {
+			return err
+		}
+		w.objects = append(w.objects, icebergObject{path: filePath, data: data})
+		manifests = append(manifests, icebergObject{
+			path: path.Join(w.table, icebergMetadataDir, manifestName),
+			data: manifest,
+		})
+		return nil
+	}
+
+	if len(dataRows) > 0 {
+		if err := addFile(name+`.parquet`, name+icebergDataManifestSuffix,
+			icebergDataFile{content: icebergContentData},
+			w.columns, w.fieldIDs, dataRows,
+		); err != nil {
+			return err
+		}
+	}
+	if len(deleteRows) > 0 {
+		if err := addFile(name+`-deletes.parquet`, name+icebergDeleteManifestSuffix,
+			icebergDataFile{content: icebergContentEqualityDeletes, equalityIDs: w.keyIDs},
+			w.keyColumns, w.keyIDs, deleteRows,
+		); err != nil {
+			return err
+		}
+	}
+	// Manifests are written after the files they reference.
+	w.objects = append(w.objects, manifests...)
+	return nil
+}
+
+// encodeParquet returns the parquet file with the specified rows.
+func (w *icebergFileWriter) encodeParquet(
+	columns []pqexporter.ParquetColumn, fieldIDs []int32, rows []map[string]interface{},
+) ([]byte, error) {
+	schema := pqexporter.NewParquetSchema(columns)
+	for i, col := range schema.RootColumn.Children {
+		id := fieldIDs[i]
+		col.SchemaElement.FieldID = &id
+	}
+
+	var buf bytes.Buffer
+	pw := goparquet.NewFileWriter(&buf,
+		goparquet.WithSchemaDefinition(schema),
+		goparquet.WithCompressionCodec(w.compression),
+	)
+	for _, r := range rows {
+		if err := pw.AddData(r); err != nil {
+			return nil, err
+		}
+	}
+	if err := pw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// size returns the total size of the files encoded by finish.
+func (w *icebergFileWriter) size() (size int) {
+	for _, o := range w.objects {
+		size += len(o.data)
+	}
+	return size
+}
+
+// flushToStorage writes the files encoded by finish to the sink.
+func (w *icebergFileWriter) flushToStorage(ctx context.Context, es cloud.ExternalStorage) error {
+	for _, o := range w.objects {
+		if err := cloud.WriteFile(ctx, es, o.path, bytes.NewReader(o.data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icebergDataFile describes a data file, or a delete file.
+type icebergDataFile struct {
+	content     int32
+	path        string // URI of the file.
+	recordCount int64
+	size        int64
+	equalityIDs []int32
+}
+
+// encodeIcebergManifest returns the manifest adding the specified file.
+func encodeIcebergManifest(schema icebergSchema, f icebergDataFile) ([]byte, error) {
+	codec, err := goavro.NewCodec(icebergManifestEntrySchema)
+	if err != nil {
+		return nil, err
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	content := `data`
+	var equalityIDs interface{}
+	if f.content == icebergContentEqualityDeletes {
+		content = `deletes`
+		ids := make([]interface{}, len(f.equalityIDs))
+		for i, id := range f.equalityIDs {
+			ids[i] = id
+		}
+		equalityIDs = goavro.Union(`array`, ids)
+	}
+
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:     &buf,
+		Codec: codec,
+		MetaData: map[string][]byte{
+			`schema`:            schemaJSON,
+			`schema-id`:         []byte(strconv.FormatInt(schema.SchemaID, 10)),
+			`partition-spec`:    []byte(`[]`),
+			`partition-spec-id`: []byte(`0`),
+			`format-version`:    []byte(strconv.Itoa(icebergFormatVersion)),
+			`content`:           []byte(content),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Append([]interface{}{map[string]interface{}{
+		`status`: int32(icebergManifestEntryStatusAdded),
+		// The snapshot ID and sequence numbers are inherited from the manifest
+		// list once the manifest is committed.
+		`snapshot_id`:          nil,
+		`sequence_number`:      nil,
+		`file_sequence_number`: nil,
+		`data_file`: map[string]interface{}{
+			`content`:            f.content,
+			`file_path`:          f.path,
+			`file_format`:        `PARQUET`,
+			`partition`:          map[string]interface{}{},
+			`record_count`:       f.recordCount,
+			`file_size_in_bytes`: f.size,
+			`equality_ids`:       equalityIDs,
+		},
+	}}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// icebergManifest describes a manifest written by a change aggregator.
+type icebergManifest struct {
+	length      int64
+	content     int32
+	schema      icebergSchema
+	fileCount   int64
+	recordCount int64
+}
+
+func readIcebergManifest(
+	ctx context.Context, es cloud.ExternalStorage, manifestPath string,
+) (icebergManifest, error) {
+	data, err := readIcebergFile(ctx, es, manifestPath)
+	if err != nil {
+		return icebergManifest{}, err
+	}
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return icebergManifest{}, err
+	}
+
+	m := icebergManifest{length: int64(len(data)), content: icebergManifestContentData}
+	if string(r.MetaData()[`content`]) == `deletes` {
+		m.content = icebergManifestContentDeletes
+	}
+	if err := json.Unmarshal(r.MetaData()[`schema`], &m.schema); err != nil {
+		return icebergManifest{}, errors.Wrapf(err, "decoding schema of manifest %s", manifestPath)
+	}
+	for r.Scan() {
+		entry, err := r.Read()
+		if err != nil {
+			return icebergManifest{}, err
+		}
+		dataFile, _ := entry.(map[string]interface{})[`data_file`].(map[string]interface{})
+		recordCount, ok := dataFile[`record_count`].(int64)
+		if !ok {
+			return icebergManifest{}, errors.AssertionFailedf(
+				"unexpected entry in manifest %s: %v", manifestPath, entry)
+		}
+		m.fileCount++
+		m.recordCount += recordCount
+	}
+	return m, r.Err()
+}
+
+// encodeIcebergManifestList returns the manifest list of the snapshot.
+func encodeIcebergManifestList(
+	snapshot icebergSnapshot, manifestFiles []interface{},
+) ([]byte, error) {
+	codec, err := goavro.NewCodec(icebergManifestFileSchema)
+	if err != nil {
+		return nil, err
+	}
+	parentID := `null`
+	if snapshot.ParentSnapshotID != nil {
+		parentID = strconv.FormatInt(*snapshot.ParentSnapshotID, 10)
+	}
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:     &buf,
+		Codec: codec,
+		MetaData: map[string][]byte{
+			`snapshot-id`:        []byte(strconv.FormatInt(snapshot.SnapshotID, 10)),
+			`parent-snapshot-id`: []byte(parentID),
+			`sequence-number`:    []byte(strconv.FormatInt(snapshot.SequenceNumber, 10)),
+			`format-version`:     []byte(strconv.Itoa(icebergFormatVersion)),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Append(manifestFiles); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func readIcebergManifestList(
+	ctx context.Context, es cloud.ExternalStorage, manifestListPath string,
+) ([]interface{}, error) {
+	data, err := readIcebergFile(ctx, es, manifestListPath)
+	if err != nil {
+		return nil, err
+	}
+	r, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var manifestFiles []interface{}
+	for r.Scan() {
+		manifestFile, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+		manifestFiles = append(manifestFiles, manifestFile)
+	}
+	return manifestFiles, r.Err()
+}
+
+// icebergSchema is the JSON representation of an Iceberg schema.
+type icebergSchema struct {
+	Type     string         `json:"type"`
+	SchemaID int64          `json:"schema-id"`
+	Fields   []icebergField `json:"fields"`
+}
+
+type icebergField struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+}
+
+// icebergTableMetadata is the JSON representation of Iceberg table metadata.
+type icebergTableMetadata struct {
+	FormatVersion      int                           `json:"format-version"`
+	TableUUID          string                        `json:"table-uuid"`
+	Location           string                        `json:"location"`
+	LastSequenceNumber int64                         `json:"last-sequence-number"`
+	LastUpdatedMs      int64                         `json:"last-updated-ms"`
+	LastColumnID       int32                         `json:"last-column-id"`
+	CurrentSchemaID    int64                         `json:"current-schema-id"`
+	Schemas            []icebergSchema               `json:"schemas"`
+	DefaultSpecID      int                           `json:"default-spec-id"`
+	PartitionSpecs     []icebergPartitionSpec        `json:"partition-specs"`
+	LastPartitionID    int                           `json:"last-partition-id"`
+	DefaultSortOrderID int                           `json:"default-sort-order-id"`
+	SortOrders         []icebergSortOrder            `json:"sort-orders"`
+	Properties         map[string]string             `json:"properties"`
+	CurrentSnapshotID  int64                         `json:"current-snapshot-id"`
+	Refs               map[string]icebergSnapshotRef `json:"refs"`
+	Snapshots          []icebergSnapshot             `json:"snapshots"`
+	SnapshotLog        []icebergSnapshotLogEntry     `json:"snapshot-log"`
+	MetadataLog        []icebergMetadataLogEntry     `json:"metadata-log"`
+}
+
+type icebergPartitionSpec struct {
+	SpecID int               `json:"spec-id"`
+	Fields []json.RawMessage `json:"fields"`
+}
+
+type icebergSortOrder struct {
+	OrderID int               `json:"order-id"`
+	Fields  []json.RawMessage `json:"fields"`
+}
+
+type icebergSnapshotRef struct {
+	SnapshotID int64  `json:"snapshot-id"`
+	Type       string `json:"type"`
+}
+
+type icebergSnapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id,omitempty"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	TimestampMs      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaID         int64             `json:"schema-id"`
+}
+
+type icebergSnapshotLogEntry struct {
+	TimestampMs int64 `json:"timestamp-ms"`
+	SnapshotID  int64 `json:"snapshot-id"`
+}
+
+type icebergMetadataLogEntry struct {
+	TimestampMs  int64  `json:"timestamp-ms"`
+	MetadataFile string `json:"metadata-file"`
+}
+
+// icebergUnpartitionedLastPartitionID is the last partition field ID of
+// unpartitioned tables; partition field IDs start at 1000.
+const icebergUnpartitionedLastPartitionID = 999
+
+func newIcebergTableMetadata(location string) *icebergTableMetadata {
+	return &icebergTableMetadata{
+		FormatVersion:     icebergFormatVersion,
+		TableUUID:         uuid.MakeV4().String(),
+		Location:          location,
+		PartitionSpecs:    []icebergPartitionSpec{{Fields: []json.RawMessage{}}},
+		LastPartitionID:   icebergUnpartitionedLastPartitionID,
+		SortOrders:        []icebergSortOrder{{Fields: []json.RawMessage{}}},
+		Properties:        map[string]string{`write.format.default`: `parquet`},
+		CurrentSnapshotID: -1,
+		Refs:              map[string]icebergSnapshotRef{},
+		Snapshots:         []icebergSnapshot{},
+		SnapshotLog:       []icebergSnapshotLogEntry{},
+		MetadataLog:       []icebergMetadataLogEntry{},
+	}
+}
+
+// currentSnapshot returns the current snapshot of the table, if any.
+func (md *icebergTableMetadata) currentSnapshot() *icebergSnapshot {
+	if md == nil {
+		return nil
+	}
+	for i := range md.Snapshots {
+		if md.Snapshots[i].SnapshotID == md.CurrentSnapshotID {
+			return &md.Snapshots[i]
+		}
+	}
+	return nil
+}
+
+// addSchema adds the schema to the table, unless it was already added. Schema
+// IDs are descriptor versions, so the latest schema has the highest ID.
+func (md *icebergTableMetadata) addSchema(schema icebergSchema) {
+	for _, f := range schema.Fields {
+		if f.ID > md.LastColumnID {
+			md.LastColumnID = f.ID
+		}
+	}
+	if len(md.Schemas) == 0 || schema.SchemaID > md.CurrentSchemaID {
+		md.CurrentSchemaID = schema.SchemaID
+	}
+	for _, s := range md.Schemas {
+		if s.SchemaID == schema.SchemaID {
+			return
+		}
+	}
+	md.Schemas = append(md.Schemas, schema)
+}
+
+// addSnapshot makes the snapshot the current snapshot of the table.
+// prevMetadataFile is the URI of the metadata file being replaced, if any.
+func (md *icebergTableMetadata) addSnapshot(snapshot icebergSnapshot, prevMetadataFile string) {
+	if prevMetadataFile != `` {
+		md.MetadataLog = append(md.MetadataLog, icebergMetadataLogEntry{
+			TimestampMs:  md.LastUpdatedMs,
+			MetadataFile: prevMetadataFile,
+		})
+	}
+	md.LastSequenceNumber = snapshot.SequenceNumber
+	md.LastUpdatedMs = snapshot.TimestampMs
+	md.CurrentSnapshotID = snapshot.SnapshotID
+	md.Refs[`main`] = icebergSnapshotRef{SnapshotID: snapshot.SnapshotID, Type: `branch`}
+	md.Snapshots = append(md.Snapshots, snapshot)
+	md.SnapshotLog = append(md.SnapshotLog, icebergSnapshotLogEntry{
+		TimestampMs: snapshot.TimestampMs,
+		SnapshotID:  snapshot.SnapshotID,
+	})
+}
+
+// readIcebergTableMetadata returns the current metadata of the table, and its
+// version. Returns nil metadata if the table doesn't exist yet.
+func readIcebergTableMetadata(
+	ctx context.Context, es cloud.ExternalStorage, table string,
+) (*icebergTableMetadata, int, error) {
+	hint, err := readIcebergFile(ctx, es, path.Join(table, icebergMetadataDir, icebergVersionHint))
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(string(hint)))
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid version hint for table %s", table)
+	}
+	data, err := readIcebergFile(ctx, es, icebergMetadataFile(table, version))
+	if err != nil {
+		return nil, 0, err
+	}
+	var md icebergTableMetadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, 0, errors.Wrapf(err, "decoding metadata of table %s", table)
+	}
+	if md.Refs == nil {
+		md.Refs = map[string]icebergSnapshotRef{}
+	}
+	return &md, version, nil
+}
+
+// icebergMetadataFile returns the path of the specified version of the table
+// metadata.
+func icebergMetadataFile(table string, version int) string {
+	return path.Join(table, icebergMetadataDir, fmt.Sprintf(`v%d.metadata.json`, version))
+}
+
+func readIcebergFile(ctx context.Context, es cloud.ExternalStorage, name string) ([]byte, error) {
+	r, err := es.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close(ctx)
+	return ioctx.ReadAll(ctx, r)
+}
+
+// makeIcebergSnapshotID returns a random, positive snapshot ID.
+func makeIcebergSnapshotID() int64 {
+	id := uuid.MakeV4()
+	return int64(binary.BigEndian.Uint64(id.GetBytes()) >> 1)
+}
@@ -0,0 +1,217 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/blobs"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIcebergCloudStorageSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	externalIODir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+
+	settings := cluster.MakeTestingClusterSettings()
+	settings.ExternalIODir = externalIODir
+	clientFactory := blobs.TestBlobServiceClient(settings.ExternalIODir)
+	externalStorageFromURI := func(ctx context.Context, uri string, user username.SQLUsername, opts ...cloud.ExternalStorageOption) (cloud.ExternalStorage,
+		error) {
+		return cloud.ExternalStorageFromURI(ctx, uri, base.ExternalIODirConfig{}, settings,
+			clientFactory,
+			user,
+			nil, /* ie */
+			nil, /* ief */
+			nil, /* kvDB */
+			nil, /* limiters */
+			cloud.NilMetrics,
+			opts...)
+	}
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	topic := &tableDescriptorTopic{
+		Metadata: makeMetadata(tableDesc),
+		spec: changefeedbase.Target{
+			Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID:           tableDesc.GetID(),
+			StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+		},
+	}
+	makeRow := func(a int, b string, deleted bool) cdcevent.Row {
+		return cdcevent.TestingMakeEventRow(tableDesc, 0, rowenc.EncDatumRow{
+			rowenc.EncDatum{Datum: tree.NewDInt(tree.DInt(a))},
+			rowenc.EncDatum{Datum: tree.NewDString(b)},
+		}, deleted)
+	}
+	ts := func(i int64) hlc.Timestamp { return hlc.Timestamp{WallTime: i} }
+
+	testSpan := roachpb.Span{Key: []byte("a"), EndKey: []byte("b")}
+	sf, err := span.MakeFrontier(testSpan)
+	require.NoError(t, err)
+	timestampOracle := &changeAggregatorLowerBoundOracle{sf: sf}
+
+	u, err := url.Parse(fmt.Sprintf("nodelocal://0/%s", testDir(t)))
+	require.NoError(t, err)
+	sinkURI := sinkURL{URL: u}
+	sinkURI.addParam(changefeedbase.SinkParamTableFormat, changefeedbase.SinkTableFormatIceberg)
+	opts := changefeedbase.EncodingOptions{
+		Format:   changefeedbase.OptFormatParquet,
+		Envelope: changefeedbase.OptEnvelopeBare,
+	}
+
+	sink, err := makeCloudStorageSink(ctx, sinkURI, 1, settings, opts,
+		timestampOracle, externalStorageFromURI, username.RootUserName(), nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+	s, ok := sink.(*icebergCloudStorageSink)
+	require.True(t, ok)
+	es := s.wrapped.es
+
+	emit := func(row cdcevent.Row, mvcc hlc.Timestamp) {
+		require.NoError(t, s.EncodeAndEmitRow(ctx, row, cdcevent.Row{}, topic, mvcc, mvcc, zeroAlloc))
+	}
+	manifestLists := func(md *icebergTableMetadata) (res [][]interface{}) {
+		for _, snapshot := range md.Snapshots {
+			list, err := readIcebergManifestList(
+				ctx, es, path.Join(`foo`, icebergMetadataDir, path.Base(snapshot.ManifestList)))
+			require.NoError(t, err)
+			res = append(res, list)
+		}
+		return res
+	}
+
+	emit(makeRow(1, `a`, false), ts(1))
+	emit(makeRow(2, `b`, false), ts(2))
+	emit(makeRow(1, `c`, false), ts(3))
+	emit(makeRow(2, `b`, true), ts(4))
+	_, err = sf.Forward(testSpan, ts(5))
+	require.NoError(t, err)
+	require.NoError(t, s.Flush(ctx))
+
+	md, version, err := readIcebergTableMetadata(ctx, es, `foo`)
+	require.NoError(t, err)
+	require.Nil(t, md, "table is committed with resolved timestamps")
+
+	require.NoError(t, s.EmitResolvedTimestamp(ctx, nil, ts(5)))
+	md, version, err = readIcebergTableMetadata(ctx, es, `foo`)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+	require.Equal(t, []icebergSchema{{
+		Type:     `struct`,
+		SchemaID: int64(tableDesc.GetVersion()),
+		Fields: []icebergField{
+			{ID: 1, Name: `a`, Type: `long`},
+			{ID: 2, Name: `b`, Type: `string`},
+		},
+	}}, md.Schemas)
+	require.Len(t, md.Snapshots, 1)
+	require.Equal(t, cloudStorageFormatTime(ts(5)), md.Snapshots[0].Summary[icebergResolvedProperty])
+	require.Equal(t, int64(1), md.LastSequenceNumber)
+
+	lists := manifestLists(md)
+	require.Len(t, lists[0], 2)
+	for i, expected := range []struct {
+		content     int32
+		recordCount int64
+	}{
+		// Only the latest version of row 1 is in the data file, while the keys of
+		// both rows are in the delete file.
+		{content: icebergManifestContentData, recordCount: 1},
+		{content: icebergManifestContentDeletes, recordCount: 2},
+	} {
+		entry := lists[0][i].(map[string]interface{})
+		require.Equal(t, expected.content, entry[`content`])
+		require.Equal(t, expected.recordCount, entry[`added_rows_count`])
+		require.Equal(t, int64(1), entry[`sequence_number`])
+	}
+
+	// Nothing was written since the last snapshot.
+	require.NoError(t, s.EmitResolvedTimestamp(ctx, nil, ts(6)))
+	md, version, err = readIcebergTableMetadata(ctx, es, `foo`)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	emit(makeRow(1, `d`, false), ts(7))
+	_, err = sf.Forward(testSpan, ts(10))
+	require.NoError(t, err)
+	require.NoError(t, s.Flush(ctx))
+	require.NoError(t, s.EmitResolvedTimestamp(ctx, nil, ts(10)))
+
+	md, version, err = readIcebergTableMetadata(ctx, es, `foo`)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+	require.Len(t, md.Snapshots, 2)
+	require.Equal(t, md.Snapshots[0].SnapshotID, *md.Snapshots[1].ParentSnapshotID)
+	require.Equal(t, md.Snapshots[1].SnapshotID, md.CurrentSnapshotID)
+	require.Len(t, md.MetadataLog, 1)
+
+	// The manifests of the parent snapshot are kept, and the new ones are
+	// assigned the next sequence number.
+	lists = manifestLists(md)
+	require.Len(t, lists[1], 4)
+	require.Equal(t, lists[0], lists[1][:2])
+	for _, entry := range lists[1][2:] {
+		require.Equal(t, int64(2), entry.(map[string]interface{})[`sequence_number`])
+	}
+}
+
+func TestIcebergType(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		typ      *types.T
+		expected string
+	}{
+		{types.Bool, `boolean`},
+		{types.Int, `long`},
+		{types.Int4, `int`},
+		{types.Float4, `float`},
+		{types.Float, `double`},
+		{types.String, `string`},
+		{types.Jsonb, `string`},
+		{types.TimestampTZ, `string`},
+		{types.Uuid, `uuid`},
+		{types.Bytes, `binary`},
+	} {
+		typ, err := icebergType(tc.typ)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, typ, tc.typ.SQLString())
+	}
+
+	for _, typ := range []*types.T{types.Decimal, types.Time, types.IntArray} {
+		_, err := icebergType(typ)
+		require.Error(t, err, typ.SQLString())
+	}
+}
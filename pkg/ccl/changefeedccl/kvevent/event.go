@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -135,6 +136,13 @@ func (e *Event) PrevKeyValue() roachpb.KeyValue {
 	return roachpb.KeyValue{Key: v.Key, Value: v.PrevValue}
 }
 
+// TxnID returns the ID of the transaction that wrote the KV if this is a KV
+// event. It is empty if the ID of the transaction is unknown, which is always
+// the case for KVs produced by a scan.
+func (e *Event) TxnID() uuid.UUID {
+	return e.ev.Val.TxnID
+}
+
 func (e *Event) boundaryType() jobspb.ResolvedSpan_BoundaryType {
 	switch e.et {
 	case resolvedNone:
@@ -61,6 +61,8 @@ type AggMetrics struct {
 	RunningCount              *aggmetric.AggGauge
 	BatchReductionCount       *aggmetric.AggGauge
 	InternalRetryMessageCount *aggmetric.AggGauge
+	DLQMessages               *aggmetric.AggCounter
+	DLQBytes                  *aggmetric.AggCounter
 
 	// There is always at least 1 sliMetrics created for defaultSLI scope.
 	mu struct {
@@ -114,6 +116,8 @@ type sliMetrics struct {
 	RunningCount              *aggmetric.Gauge
 	BatchReductionCount       *aggmetric.Gauge
 	InternalRetryMessageCount *aggmetric.Gauge
+	DLQMessages               *aggmetric.Counter
+	DLQBytes                  *aggmetric.Counter
 }
 
 // sinkDoesNotCompress is a sentinel value indicating the sink
@@ -237,6 +241,15 @@ func (m *sliMetrics) recordSizeBasedFlush() {
 	m.SizeBasedFlushes.Inc(1)
 }
 
+// recordDLQMessage records a message emitted to the dead letter queue.
+func (m *sliMetrics) recordDLQMessage(bytes int) {
+	if m == nil {
+		return
+	}
+	m.DLQMessages.Inc(1)
+	m.DLQBytes.Inc(int64(bytes))
+}
+
 type wrappingCostController struct {
 	ctx      context.Context
 	inner    metricsRecorder
@@ -478,6 +491,18 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		Measurement: "Messages",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaDLQMessages := metric.Metadata{
+		Name:        "changefeed.dlq_messages",
+		Help:        "Events that could not be encoded and were emitted to the dead letter queue",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaDLQBytes := metric.Metadata{
+		Name:        "changefeed.dlq_bytes",
+		Help:        "Bytes emitted to the dead letter queue",
+		Measurement: "Bytes",
+		Unit:        metric.Unit_BYTES,
+	}
 	// NB: When adding new histograms, use sigFigs = 1.  Older histograms
 	// retain significant figures of 2.
 	b := aggmetric.MakeBuilder("scope")
@@ -499,6 +524,8 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		RunningCount:              b.Gauge(metaChangefeedRunning),
 		BatchReductionCount:       b.Gauge(metaBatchReductionCount),
 		InternalRetryMessageCount: b.Gauge(metaInternalRetryMessageCount),
+		DLQMessages:               b.Counter(metaDLQMessages),
+		DLQBytes:                  b.Counter(metaDLQBytes),
 	}
 	a.mu.sliMetrics = make(map[string]*sliMetrics)
 	_, err := a.getOrCreateScope(defaultSLIScope)
@@ -560,6 +587,8 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		RunningCount:              a.RunningCount.AddChild(scope),
 		BatchReductionCount:       a.BatchReductionCount.AddChild(scope),
 		InternalRetryMessageCount: a.InternalRetryMessageCount.AddChild(scope),
+		DLQMessages:               a.DLQMessages.AddChild(scope),
+		DLQBytes:                  a.DLQBytes.AddChild(scope),
 	}
 
 	a.mu.sliMetrics[scope] = sm
@@ -0,0 +1,361 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protobufScalarType is the name of a protobuf scalar type as it is spelled in
+// a .proto file.
+type protobufScalarType string
+
+const (
+	protobufBool   protobufScalarType = `bool`
+	protobufInt64  protobufScalarType = `int64`
+	protobufDouble protobufScalarType = `double`
+	protobufString protobufScalarType = `string`
+	protobufBytes  protobufScalarType = `bytes`
+)
+
+// protobufEncodeFn appends the tag and the encoded value of a non-NULL datum
+// for the field with the given number to buf.
+type protobufEncodeFn func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error)
+
+// protobufField is a single field of a protobufMessage. Exactly one of
+// scalarType and message is set.
+type protobufField struct {
+	name       string
+	number     protowire.Number
+	scalarType protobufScalarType
+	message    *protobufMessage
+
+	// encodeFn is only set for fields that correspond to a column.
+	encodeFn protobufEncodeFn
+}
+
+// protobufMessage is a proto3 message derived from the columns of a table (or
+// of one of its column families).
+//
+// All scalar fields are declared `optional` so that a NULL value (which is
+// encoded by omitting the field) can be told apart from a zero value. As with
+// avro, this means the schema doesn't mirror the column's nullability, but it
+// makes every schema evolution of a table backward compatible.
+type protobufMessage struct {
+	name   string
+	fields []protobufField
+	// fieldIdxByColName maps the name of a column to the index of the field
+	// that it is encoded into.
+	fieldIdxByColName map[string]int
+}
+
+// protobufEnvelopeOpts controls which fields are present in a
+// protobufEnvelopeMessage.
+type protobufEnvelopeOpts struct {
+	beforeField, afterField, recordField bool
+	updatedField, resolvedField          bool
+}
+
+// The field numbers of the envelope fields are fixed, independent of which
+// fields are present, so that consumers of different changefeeds over the same
+// table see the same numbering.
+const (
+	protobufEnvelopeAfterNumber    protowire.Number = 1
+	protobufEnvelopeBeforeNumber   protowire.Number = 2
+	protobufEnvelopeRecordNumber   protowire.Number = 3
+	protobufEnvelopeUpdatedNumber  protowire.Number = 4
+	protobufEnvelopeResolvedNumber protowire.Number = 5
+)
+
+// protobufEnvelopeMessage is a proto3 message containing the before and after
+// versions of a row change and metadata about that row change.
+type protobufEnvelopeMessage struct {
+	protobufMessage
+	opts                  protobufEnvelopeOpts
+	before, after, record *protobufMessage
+
+	// scratch is used to encode the nested row messages before they are
+	// appended to the envelope with their length prefix.
+	scratch []byte
+}
+
+// typeToProtobufType returns the protobuf scalar type that values of the given
+// SQL type are encoded as, along with the function that encodes them.
+// Booleans, integers, floats, strings and bytes map to their natural protobuf
+// counterparts. Every other type is encoded as a string containing its text
+// representation, which is the same representation used by the CSV format.
+func typeToProtobufType(typ *types.T) (protobufScalarType, protobufEncodeFn) {
+	switch typ.Family() {
+	case types.BoolFamily:
+		return protobufBool, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			b, ok := tree.UnwrapDOidWrapper(d).(*tree.DBool)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.VarintType)
+			return protowire.AppendVarint(buf, protowire.EncodeBool(bool(*b))), nil
+		}
+	case types.IntFamily:
+		return protobufInt64, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			i, ok := tree.UnwrapDOidWrapper(d).(*tree.DInt)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.VarintType)
+			return protowire.AppendVarint(buf, uint64(*i)), nil
+		}
+	case types.FloatFamily:
+		return protobufDouble, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			f, ok := tree.UnwrapDOidWrapper(d).(*tree.DFloat)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.Fixed64Type)
+			return protowire.AppendFixed64(buf, math.Float64bits(float64(*f))), nil
+		}
+	case types.StringFamily:
+		return protobufString, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			s, ok := tree.UnwrapDOidWrapper(d).(*tree.DString)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.BytesType)
+			return protowire.AppendString(buf, string(*s)), nil
+		}
+	case types.CollatedStringFamily:
+		return protobufString, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			s, ok := tree.UnwrapDOidWrapper(d).(*tree.DCollatedString)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.BytesType)
+			return protowire.AppendString(buf, s.Contents), nil
+		}
+	case types.BytesFamily:
+		return protobufBytes, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			b, ok := tree.UnwrapDOidWrapper(d).(*tree.DBytes)
+			if !ok {
+				return nil, unexpectedProtobufDatumError(typ, d)
+			}
+			buf = protowire.AppendTag(buf, num, protowire.BytesType)
+			return protowire.AppendString(buf, string(*b)), nil
+		}
+	default:
+		fmtCtx := tree.NewFmtCtx(tree.FmtExport)
+		return protobufString, func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			fmtCtx.Reset()
+			fmtCtx.FormatNode(d)
+			buf = protowire.AppendTag(buf, num, protowire.BytesType)
+			return protowire.AppendBytes(buf, fmtCtx.Bytes()), nil
+		}
+	}
+}
+
+func unexpectedProtobufDatumError(typ *types.T, d tree.Datum) error {
+	return changefeedbase.WithTerminalError(errors.AssertionFailedf(
+		`unexpected datum of type %T for column of type %s`, d, typ.SQLString()))
+}
+
+// newProtobufMessageForRow constructs a protobuf message for the Row. Only the
+// columns returned by the Iterator are used to populate message fields, which
+// are numbered in column order. name must be a valid protobuf identifier.
+func newProtobufMessageForRow(it cdcevent.Iterator, name string) (*protobufMessage, error) {
+	m := &protobufMessage{
+		name:              name,
+		fieldIdxByColName: make(map[string]int),
+	}
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		scalarType, encodeFn := typeToProtobufType(col.Typ)
+		m.fieldIdxByColName[col.Name] = len(m.fields)
+		m.fields = append(m.fields, protobufField{
+			// Avro names are also valid protobuf identifiers.
+			name:       SQLNameToAvroName(col.Name),
+			number:     protowire.Number(len(m.fields) + 1),
+			scalarType: scalarType,
+			encodeFn:   encodeFn,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// primaryIndexToProtobufMessage constructs the protobuf message for the
+// primary key of the row.
+func primaryIndexToProtobufMessage(row cdcevent.Row, sqlName string) (*protobufMessage, error) {
+	return newProtobufMessageForRow(row.ForEachKeyColumn(), SQLNameToAvroName(sqlName))
+}
+
+// tableToProtobufMessage constructs the protobuf message for the event values.
+// If a name suffix is provided, it is appended to the end of the message name.
+// Messages are named the same way as the corresponding avro records.
+func tableToProtobufMessage(row cdcevent.Row, nameSuffix string) (*protobufMessage, error) {
+	var name string
+	if row.HasOtherFamilies {
+		name = SQLNameToAvroName(row.TableName + "." + row.FamilyName)
+	} else {
+		name = SQLNameToAvroName(row.TableName)
+	}
+	if nameSuffix != avroSchemaNoSuffix {
+		name = name + `_` + nameSuffix
+	}
+	return newProtobufMessageForRow(row.ForEachColumn(), name)
+}
+
+// appendRow encodes the datums returned by the Iterator into the protobuf
+// binary format and appends them to buf. NULL datums are omitted.
+func (m *protobufMessage) appendRow(buf []byte, it cdcevent.Iterator) ([]byte, error) {
+	if err := it.Datum(func(d tree.Datum, col cdcevent.ResultColumn) (err error) {
+		fieldIdx, ok := m.fieldIdxByColName[col.Name]
+		if !ok {
+			return changefeedbase.WithTerminalError(
+				errors.AssertionFailedf("could not find protobuf field for column %s", col.Name))
+		}
+		if d == tree.DNull {
+			return nil
+		}
+		f := &m.fields[fieldIdx]
+		buf, err = f.encodeFn(buf, f.number, d)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// envelopeToProtobufMessage creates a protobuf message for an envelope
+// containing before and after versions of a row change and metadata about that
+// row change. before is optional, and after can instead be record.
+func envelopeToProtobufMessage(
+	topic string, opts protobufEnvelopeOpts, before, after, record *protobufMessage,
+) *protobufEnvelopeMessage {
+	m := &protobufEnvelopeMessage{
+		protobufMessage: protobufMessage{
+			name: SQLNameToAvroName(topic) + `_envelope`,
+		},
+		opts: opts,
+	}
+	if opts.afterField {
+		m.after = after
+		m.fields = append(m.fields, protobufField{
+			name: `after`, number: protobufEnvelopeAfterNumber, message: after,
+		})
+	}
+	if opts.beforeField && before != nil {
+		m.before = before
+		m.fields = append(m.fields, protobufField{
+			name: `before`, number: protobufEnvelopeBeforeNumber, message: before,
+		})
+	}
+	if opts.recordField {
+		m.record = record
+		m.fields = append(m.fields, protobufField{
+			name: `record`, number: protobufEnvelopeRecordNumber, message: record,
+		})
+	}
+	if opts.updatedField {
+		m.fields = append(m.fields, protobufField{
+			name: `updated`, number: protobufEnvelopeUpdatedNumber, scalarType: protobufString,
+		})
+	}
+	if opts.resolvedField {
+		m.fields = append(m.fields, protobufField{
+			name: `resolved`, number: protobufEnvelopeResolvedNumber, scalarType: protobufString,
+		})
+	}
+	return m
+}
+
+// appendEnvelope encodes the given metadata and row data into the protobuf
+// binary format and appends it to buf. Rows that are absent (for example the
+// after row of a deletion) and empty timestamps are omitted.
+func (m *protobufEnvelopeMessage) appendEnvelope(
+	buf []byte, updated, resolved hlc.Timestamp, beforeRow, afterRow, recordRow cdcevent.Row,
+) ([]byte, error) {
+	appendNested := func(
+		buf []byte, num protowire.Number, nested *protobufMessage, row cdcevent.Row,
+	) ([]byte, error) {
+		var err error
+		m.scratch, err = nested.appendRow(m.scratch[:0], row.ForEachColumn())
+		if err != nil {
+			return nil, err
+		}
+		buf = protowire.AppendTag(buf, num, protowire.BytesType)
+		return protowire.AppendBytes(buf, m.scratch), nil
+	}
+
+	var err error
+	if m.after != nil && afterRow.HasValues() && !afterRow.IsDeleted() {
+		if buf, err = appendNested(buf, protobufEnvelopeAfterNumber, m.after, afterRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.before != nil && beforeRow.HasValues() && !beforeRow.IsDeleted() {
+		if buf, err = appendNested(buf, protobufEnvelopeBeforeNumber, m.before, beforeRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.record != nil && recordRow.HasValues() {
+		if buf, err = appendNested(buf, protobufEnvelopeRecordNumber, m.record, recordRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.opts.updatedField && !updated.IsEmpty() {
+		buf = protowire.AppendTag(buf, protobufEnvelopeUpdatedNumber, protowire.BytesType)
+		buf = protowire.AppendString(buf, updated.AsOfSystemTime())
+	}
+	if m.opts.resolvedField && !resolved.IsEmpty() {
+		buf = protowire.AppendTag(buf, protobufEnvelopeResolvedNumber, protowire.BytesType)
+		buf = protowire.AppendString(buf, resolved.AsOfSystemTime())
+	}
+	return buf, nil
+}
+
+// nestedMessages returns the row messages referenced by the envelope.
+func (m *protobufEnvelopeMessage) nestedMessages() []*protobufMessage {
+	var nested []*protobufMessage
+	for _, f := range m.fields {
+		if f.message != nil {
+			nested = append(nested, f.message)
+		}
+	}
+	return nested
+}
+
+// protobufSchema renders the given messages as the text of a proto3 .proto
+// file, which is the form in which protobuf schemas are registered with a
+// confluent schema registry. The first message is the one that is actually
+// encoded; the rest are the messages it references.
+func protobufSchema(messages ...*protobufMessage) string {
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\n")
+	for _, m := range messages {
+		fmt.Fprintf(&b, "\nmessage %s {\n", m.name)
+		for _, f := range m.fields {
+			if f.message != nil {
+				fmt.Fprintf(&b, "  %s %s = %d;\n", f.message.name, f.name, f.number)
+			} else {
+				fmt.Fprintf(&b, "  optional %s %s = %d;\n", f.scalarType, f.name, f.number)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
@@ -14,8 +14,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
@@ -35,13 +37,28 @@ type schemaRegistry interface {
 
 	// RegisterSchemaForSubject registers the given schema for the
 	// given subject. The returned int32 is a schema ID that can
-	// be used in Avro wire messages or in other calls to the
-	// schema registry.
-	RegisterSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
+	// be used in Avro or protobuf wire messages or in other calls
+	// to the schema registry.
+	RegisterSchemaForSubject(
+		ctx context.Context, subject string, schemaType confluentSchemaType, schema string,
+	) (int32, error)
 }
 
+// confluentSchemaType is the type of a schema registered with a confluent
+// schema registry.
+type confluentSchemaType string
+
+const (
+	// confluentSchemaTypeAvro is the default schema type. It is never sent
+	// explicitly so that we remain compatible with registries that predate
+	// support for other schema types.
+	confluentSchemaTypeAvro     confluentSchemaType = ``
+	confluentSchemaTypeProtobuf confluentSchemaType = `PROTOBUF`
+)
+
 type confluentSchemaVersionRequest struct {
-	Schema string `json:"schema"`
+	Schema     string              `json:"schema"`
+	SchemaType confluentSchemaType `json:"schemaType,omitempty"`
 }
 
 type confluentSchemaVersionResponse struct {
@@ -138,18 +155,18 @@ func (r *confluentSchemaRegistry) Ping(ctx context.Context) error {
 }
 
 // RegisterSchemaForSubject registers the given schema for the given
-// subject. The schema type is assumed to be AVRO.
+// subject. An empty schema type indicates an AVRO schema.
 //
 //	https://docs.confluent.io/platform/current/schema-registry/develop/api.html#post--subjects-(string-%20subject)-versions
 func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
-	ctx context.Context, subject string, schema string,
+	ctx context.Context, subject string, schemaType confluentSchemaType, schema string,
 ) (int32, error) {
 	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
 	if log.V(1) {
-		log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		log.Infof(ctx, "registering %s schema %s %s", schemaType.String(), u, schema)
 	}
 
-	req := confluentSchemaVersionRequest{Schema: schema}
+	req := confluentSchemaVersionRequest{Schema: schema, SchemaType: schemaType}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(req); err != nil {
 		return 0, err
@@ -164,7 +181,15 @@ func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
 		defer gracefulClose(ctx, resp.Body)
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			body, _ := io.ReadAll(resp.Body)
-			return errors.Errorf("registering schema to %s %s: %s", u, resp.Status, body)
+			err := errors.Errorf("registering schema to %s %s: %s", u, resp.Status, body)
+			// The registry rejects schemas that are incompatible with the
+			// subject's previous versions (409) or invalid (422). Retrying won't
+			// change that, and the schema is derived from the event's table.
+			if resp.StatusCode == http.StatusConflict ||
+				resp.StatusCode == http.StatusUnprocessableEntity {
+				err = markUndeliverableEvent(err)
+			}
+			return err
 		}
 		var res confluentSchemaVersionResponse
 		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
@@ -179,6 +204,14 @@ func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
 	return id, nil
 }
 
+// String implements the fmt.Stringer interface.
+func (t confluentSchemaType) String() string {
+	if t == confluentSchemaTypeAvro {
+		return `avro`
+	}
+	return strings.ToLower(string(t))
+}
+
 func (r *confluentSchemaRegistry) doWithRetry(ctx context.Context, fn func() error) error {
 	// Since network services are often a source of flakes, add a few retries here
 	// before we give up and return an error that will bubble up and tear down the
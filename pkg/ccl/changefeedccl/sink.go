@@ -121,6 +121,33 @@ func getSink(
 	user username.SQLUsername,
 	jobID jobspb.JobID,
 	m metricsRecorder,
+) (Sink, error) {
+	sink, err := makeSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if knobs, ok := serverCfg.TestingKnobs.Changefeed.(*TestingKnobs); ok && knobs.WrapSink != nil {
+		sink = knobs.WrapSink(sink, jobID)
+	}
+
+	if err := sink.Dial(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// makeSink returns the sink described by the feed's sink URI and options
+// without dialing it.
+func makeSink(
+	ctx context.Context,
+	serverCfg *execinfra.ServerConfig,
+	feedCfg jobspb.ChangefeedDetails,
+	timestampOracle timestampLowerBoundOracle,
+	user username.SQLUsername,
+	jobID jobspb.JobID,
+	m metricsRecorder,
 ) (Sink, error) {
 	u, err := url.Parse(feedCfg.SinkURI)
 	if err != nil {
@@ -204,20 +231,7 @@ func getSink(
 		}
 	}
 
-	sink, err := newSink()
-	if err != nil {
-		return nil, err
-	}
-
-	if knobs, ok := serverCfg.TestingKnobs.Changefeed.(*TestingKnobs); ok && knobs.WrapSink != nil {
-		sink = knobs.WrapSink(sink, jobID)
-	}
-
-	if err := sink.Dial(); err != nil {
-		return nil, err
-	}
-
-	return sink, nil
+	return newSink()
 }
 
 func validateSinkOptions(opts map[string]string, sinkSpecificOpts map[string]struct{}) error {
@@ -373,6 +387,31 @@ func (s *bufferSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitTxnBoundaries implements the txnBoundarySink interface.
+func (s *bufferSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	if s.closed {
+		return errors.New(`cannot EmitTxnBoundaries on a closed sink`)
+	}
+
+	var noTopic string
+	for _, b := range boundaries {
+		payload, err := encoder.EncodeTxnBoundary(ctx, noTopic, b)
+		if err != nil {
+			return err
+		}
+		s.scratch, payload = s.scratch.Copy(payload, 0 /* extraCap */)
+		s.buf.Push(rowenc.EncDatumRow{
+			{Datum: tree.DNull}, // resolved span
+			{Datum: tree.DNull}, // topic
+			{Datum: tree.DNull}, // key
+			{Datum: s.alloc.NewDBytes(tree.DBytes(payload))}, // value
+		})
+	}
+	return nil
+}
+
 // Flush implements the Sink interface.
 func (s *bufferSink) Flush(_ context.Context) error {
 	defer s.metrics.recordFlushRequestCallback()()
@@ -466,6 +505,19 @@ func (n *nullSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitTxnBoundaries implements the txnBoundarySink interface.
+func (n *nullSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	if err := n.pace(ctx); err != nil {
+		return err
+	}
+	if log.V(2) {
+		log.Infof(ctx, "emitting %d transaction boundaries", len(boundaries))
+	}
+	return nil
+}
+
 // Flush implements Sink interface.
 func (n *nullSink) Flush(ctx context.Context) error {
 	defer n.metrics.recordFlushRequestCallback()()
@@ -70,6 +70,7 @@ type cloudStorageSinkFile struct {
 	alloc        kvevent.Alloc
 	oldestMVCC   hlc.Timestamp
 	parquetCodec *parquetFileWriter
+	iceberg      *icebergFileWriter
 }
 
 var _ io.Writer = &cloudStorageSinkFile{}
@@ -405,6 +406,19 @@ func makeCloudStorageSink(
 		s.partitionFormat = dateFormat
 	}
 
+	var iceberg bool
+	if tableFormat := u.consumeParam(changefeedbase.SinkParamTableFormat); tableFormat != "" {
+		if tableFormat != changefeedbase.SinkTableFormatIceberg {
+			return nil, errors.Errorf("invalid %s of %s", changefeedbase.SinkParamTableFormat, tableFormat)
+		}
+		if encodingOpts.Format != changefeedbase.OptFormatParquet {
+			return nil, errors.Errorf(`%s=%s is only usable with %s=%s`,
+				changefeedbase.SinkParamTableFormat, tableFormat,
+				changefeedbase.OptFormat, changefeedbase.OptFormatParquet)
+		}
+		iceberg = true
+	}
+
 	if s.timestampOracle != nil {
 		s.setDataFileTimestamp()
 	}
@@ -462,7 +476,12 @@ func makeCloudStorageSink(
 	}
 
 	if encodingOpts.Format == changefeedbase.OptFormatParquet {
-		parquetSinkWithEncoder, err := makeParquetCloudStorageSink(s)
+		var parquetSinkWithEncoder Sink
+		if iceberg {
+			parquetSinkWithEncoder, err = makeIcebergCloudStorageSink(s, u)
+		} else {
+			parquetSinkWithEncoder, err = makeParquetCloudStorageSink(s)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -705,6 +724,15 @@ func (s *cloudStorageSink) flushFile(ctx context.Context, file *cloudStorageSink
 	s.prevFilename = filename
 	dest := filepath.Join(s.dataFilePartition, filename)
 
+	if file.iceberg != nil {
+		// Iceberg files are written to the directory of their table; see
+		// icebergCloudStorageSink.
+		if err := file.iceberg.finish(strings.TrimSuffix(filename, s.ext)); err != nil {
+			return err
+		}
+		file.rawSize = file.iceberg.size()
+	}
+
 	if !asyncFlushEnabled {
 		return file.flushToStorage(ctx, s.es, dest, s.metrics)
 	}
@@ -780,6 +808,14 @@ func (f *cloudStorageSinkFile) flushToStorage(
 		return nil
 	}
 
+	if f.iceberg != nil {
+		if err := f.iceberg.flushToStorage(ctx, es); err != nil {
+			return err
+		}
+		m.recordEmittedBatch(f.created, f.numMessages, f.oldestMVCC, f.rawSize, f.rawSize)
+		return nil
+	}
+
 	if f.codec != nil {
 		if err := f.codec.Close(); err != nil {
 			return err
@@ -15,6 +15,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/url"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -23,6 +24,7 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -36,6 +38,14 @@ import (
 )
 
 // maybeLocker is a wrapper around a Locker that allows for successive Unlocks
+func isKafkaSink(u *url.URL) bool {
+	scheme := u.Scheme
+	if s, ok := changefeedbase.NoLongerExperimental[scheme]; ok {
+		scheme = s
+	}
+	return scheme == changefeedbase.SinkSchemeKafka
+}
+
 type maybeLocker struct {
 	wrapped sync.Locker
 	locked  bool
@@ -398,6 +408,42 @@ func (s *kafkaSink) EmitResolvedTimestamp(
 	})
 }
 
+// EmitTxnBoundaries implements the txnBoundarySink interface.
+func (s *kafkaSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	// Like resolved timestamps, the markers are emitted to every partition,
+	// since the rows of a transaction may have been emitted to any of them.
+	if err := s.topics.Each(func(topic string) error {
+		partitions, err := s.client.Partitions(topic)
+		if err != nil {
+			return err
+		}
+		for _, b := range boundaries {
+			payload, err := encoder.EncodeTxnBoundary(ctx, topic, b)
+			if err != nil {
+				return err
+			}
+			s.scratch, payload = s.scratch.Copy(payload, 0 /* extraCap */)
+			for _, partition := range partitions {
+				msg := &sarama.ProducerMessage{
+					Topic:     topic,
+					Partition: partition,
+					Key:       nil,
+					Value:     sarama.ByteEncoder(payload),
+				}
+				if err := s.emitMessage(ctx, msg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return s.Flush(ctx)
+}
+
 // Flush implements the Sink interface.
 func (s *kafkaSink) Flush(ctx context.Context) error {
 	defer s.metrics.recordFlushRequestCallback()()
@@ -27,6 +27,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/httputil"
@@ -743,6 +744,36 @@ func (s *webhookSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitTxnBoundaries implements the txnBoundarySink interface.
+func (s *webhookSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	for _, b := range boundaries {
+		payload, err := encoder.EncodeTxnBoundary(ctx, "", b)
+		if err != nil {
+			return err
+		}
+
+		select {
+		// check the webhook sink context in case workers have been terminated
+		case <-s.workerCtx.Done():
+			return s.workerCtx.Err()
+		// non-blocking check for error, restart changefeed if encountered
+		case err := <-s.errChan:
+			return err
+		default:
+		}
+
+		// Like resolved timestamps, the markers are sent directly rather than by
+		// the workers.
+		if err := s.sendMessageWithRetries(ctx, payload); err != nil {
+			s.exitWorkersWithError(err)
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *webhookSink) Flush(ctx context.Context) error {
 	s.metrics.recordFlushRequestCallback()()
 
@@ -545,6 +545,15 @@ func (s *notifyFlushSink) EncodeAndEmitRow(
 	return errors.AssertionFailedf("Expected a sink with encoder for, found %T", s.Sink)
 }
 
+func (s *notifyFlushSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	if sink, ok := s.Sink.(txnBoundarySink); ok {
+		return sink.EmitTxnBoundaries(ctx, encoder, boundaries)
+	}
+	return errors.AssertionFailedf("Expected a sink with transaction boundaries, found %T", s.Sink)
+}
+
 var _ Sink = (*notifyFlushSink)(nil)
 
 // feedInjectable is the subset of the
@@ -1627,6 +1636,12 @@ func (s *fakeKafkaSink) Topics() []string {
 	return nil
 }
 
+func (s *fakeKafkaSink) EmitTxnBoundaries(
+	ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+) error {
+	return s.Sink.(*kafkaSink).EmitTxnBoundaries(ctx, encoder, boundaries)
+}
+
 type kafkaFeedFactory struct {
 	enterpriseFeedFactory
 	knobs *sinkKnobs
@@ -0,0 +1,138 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// Changefeeds with the txn_boundaries option emit a marker once every row
+// changed by a transaction has been emitted, so that consumers can tell when
+// they have observed the whole transaction. The rows of a transaction may be
+// emitted by several aggregators, so the markers are emitted by the
+// changeFrontier:
+//
+//   - Each changeAggregator counts the rows it emits for every transaction in
+//     a txnBoundaryTracker. Once its local frontier passes the commit
+//     timestamp of a transaction, it has emitted all of the transaction's rows
+//     in its spans, and it reports the count to the changeFrontier along with
+//     its resolved spans, after flushing the sink.
+//   - The changeFrontier sums up the counts reported by the aggregators in its
+//     own txnBoundaryTracker. Once the changefeed's frontier passes the commit
+//     timestamp of a transaction, every aggregator has reported it, so the
+//     changeFrontier emits the transaction's marker before checkpointing the
+//     frontier.
+//
+// The rows of a transaction all share its commit timestamp, which is how
+// consumers associate rows with markers. Rows emitted by the initial scan or
+// by schema change backfills are not counted, since they don't correspond to
+// the transaction that wrote them. The ID of the transaction is unknown for
+// the rows read by a rangefeed catch-up scan, in which case the rows of all
+// the transactions that committed at a timestamp are counted together.
+
+// txnBoundaryKey identifies the rows written by a transaction.
+type txnBoundaryKey struct {
+	txnID uuid.UUID
+	ts    hlc.Timestamp
+}
+
+// txnBoundaryTracker counts the rows emitted for each transaction until the
+// transaction's boundary is resolved. It is safe for concurrent use, since
+// the parallel event consumer emits rows from several workers.
+type txnBoundaryTracker struct {
+	mu struct {
+		syncutil.Mutex
+		rowCounts map[txnBoundaryKey]int64
+	}
+}
+
+func makeTxnBoundaryTracker() *txnBoundaryTracker {
+	t := &txnBoundaryTracker{}
+	t.mu.rowCounts = make(map[txnBoundaryKey]int64)
+	return t
+}
+
+// recordRow notes that a row written by the transaction, which committed at
+// the specified timestamp, was emitted.
+func (t *txnBoundaryTracker) recordRow(txnID uuid.UUID, ts hlc.Timestamp) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.rowCounts[txnBoundaryKey{txnID: txnID, ts: ts}]++
+}
+
+// add adds the rows of a boundary reported by an aggregator to the rows
+// tracked for the transaction.
+func (t *txnBoundaryTracker) add(b jobspb.TxnBoundary) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.rowCounts[txnBoundaryKey{txnID: b.TxnID, ts: b.Timestamp}] += b.RowCount
+}
+
+// resolve stops tracking, and returns the boundaries of, the transactions
+// that committed at or before the resolved timestamp. The boundaries are
+// ordered by commit timestamp, then by transaction ID.
+func (t *txnBoundaryTracker) resolve(resolved hlc.Timestamp) []jobspb.TxnBoundary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var boundaries []jobspb.TxnBoundary
+	for k, rowCount := range t.mu.rowCounts {
+		if resolved.Less(k.ts) {
+			continue
+		}
+		boundaries = append(boundaries, jobspb.TxnBoundary{
+			TxnID:     k.txnID,
+			Timestamp: k.ts,
+			RowCount:  rowCount,
+		})
+		delete(t.mu.rowCounts, k)
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		if !boundaries[i].Timestamp.Equal(boundaries[j].Timestamp) {
+			return boundaries[i].Timestamp.Less(boundaries[j].Timestamp)
+		}
+		return bytes.Compare(boundaries[i].TxnID.GetBytes(), boundaries[j].TxnID.GetBytes()) < 0
+	})
+	return boundaries
+}
+
+// txnBoundaryEncoder is implemented by the Encoders which support the
+// txn_boundaries option.
+type txnBoundaryEncoder interface {
+	// EncodeTxnBoundary encodes a transaction boundary payload for the given
+	// topic name. The returned bytes are only valid until the next call to
+	// Encode*.
+	EncodeTxnBoundary(context.Context, string, jobspb.TxnBoundary) ([]byte, error)
+}
+
+// txnBoundarySink is implemented by the Sinks which support the
+// txn_boundaries option.
+type txnBoundarySink interface {
+	// EmitTxnBoundaries emits a marker for each of the transaction boundaries
+	// on every topic. Unlike resolved timestamps, a marker that is lost isn't
+	// superseded by later ones, so the markers are emitted synchronously.
+	EmitTxnBoundaries(
+		ctx context.Context, encoder txnBoundaryEncoder, boundaries []jobspb.TxnBoundary,
+	) error
+}
+
+var _ txnBoundaryEncoder = (*jsonEncoder)(nil)
+
+var (
+	_ txnBoundarySink = (*kafkaSink)(nil)
+	_ txnBoundarySink = (*webhookSink)(nil)
+	_ txnBoundarySink = (*bufferSink)(nil)
+	_ txnBoundarySink = (*nullSink)(nil)
+)
@@ -0,0 +1,103 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnBoundaryTracker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := func(i int64) hlc.Timestamp { return hlc.Timestamp{WallTime: i} }
+	txn1 := uuid.UUID{15: 1}
+	txn2 := uuid.UUID{15: 2}
+
+	aggregator := makeTxnBoundaryTracker()
+	aggregator.recordRow(txn2, ts(1))
+	aggregator.recordRow(txn1, ts(1))
+	aggregator.recordRow(txn1, ts(1))
+	aggregator.recordRow(uuid.Nil, ts(2))
+	aggregator.recordRow(txn1, ts(3))
+
+	require.Empty(t, aggregator.resolve(ts(0)))
+	reported := aggregator.resolve(ts(2))
+	require.Equal(t, []jobspb.TxnBoundary{
+		{TxnID: txn1, Timestamp: ts(1), RowCount: 2},
+		{TxnID: txn2, Timestamp: ts(1), RowCount: 1},
+		{TxnID: uuid.Nil, Timestamp: ts(2), RowCount: 1},
+	}, reported)
+	// Resolved boundaries are no longer tracked.
+	require.Empty(t, aggregator.resolve(ts(2)))
+
+	frontier := makeTxnBoundaryTracker()
+	for _, b := range reported {
+		frontier.add(b)
+	}
+	// Another aggregator emitted rows for the same transaction.
+	frontier.add(jobspb.TxnBoundary{TxnID: txn1, Timestamp: ts(1), RowCount: 3})
+	require.Equal(t, []jobspb.TxnBoundary{
+		{TxnID: txn1, Timestamp: ts(1), RowCount: 5},
+		{TxnID: txn2, Timestamp: ts(1), RowCount: 1},
+	}, frontier.resolve(ts(1)))
+	require.Equal(t, []jobspb.TxnBoundary{
+		{TxnID: uuid.Nil, Timestamp: ts(2), RowCount: 1},
+	}, frontier.resolve(ts(5)))
+	require.Equal(t, []jobspb.TxnBoundary{
+		{TxnID: txn1, Timestamp: ts(3), RowCount: 1},
+	}, aggregator.resolve(ts(5)))
+}
+
+func TestJSONEncodeTxnBoundary(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	txnID := uuid.UUID{15: 1}
+	ts := hlc.Timestamp{WallTime: 10, Logical: 2}
+	for _, tc := range []struct {
+		envelope changefeedbase.EnvelopeType
+		boundary jobspb.TxnBoundary
+		expected string
+	}{
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			boundary: jobspb.TxnBoundary{TxnID: txnID, Timestamp: ts, RowCount: 3},
+			expected: `{"txn":{"id":"` + txnID.String() + `","mvcc_timestamp":"10.0000000002","row_count":3}}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			boundary: jobspb.TxnBoundary{Timestamp: ts, RowCount: 1},
+			expected: `{"txn":{"mvcc_timestamp":"10.0000000002","row_count":1}}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeBare,
+			boundary: jobspb.TxnBoundary{TxnID: txnID, Timestamp: ts, RowCount: 3},
+			expected: `{"__crdb__":{"txn":{"id":"` + txnID.String() + `","mvcc_timestamp":"10.0000000002","row_count":3}}}`,
+		},
+	} {
+		e, err := makeJSONEncoder(changefeedbase.EncodingOptions{
+			Format:   changefeedbase.OptFormatJSON,
+			Envelope: tc.envelope,
+		})
+		require.NoError(t, err)
+		encoded, err := e.EncodeTxnBoundary(context.Background(), `foo`, tc.boundary)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, string(encoded))
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlshell
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/errors"
+)
+
+// condFrame is the state of one \if ... \endif block.
+type condFrame struct {
+	// active is true if the input in the current branch is processed.
+	active bool
+	// done is true if a branch of the block was already selected, or if
+	// the entire block is inside an inactive branch. No further branch
+	// can become active in that case.
+	done bool
+	// inElse is true after \else.
+	inElse bool
+}
+
+// errUnterminatedIf is reported when the input ends inside an \if block.
+var errUnterminatedIf = errors.New(`reached end of input without finding closing \endif`)
+
+// condActive returns true if input should be processed, i.e. if the
+// shell is not inside a branch skipped by \if, \elif or \else.
+func (c *cliState) condActive() bool {
+	return len(c.conds) == 0 || c.conds[len(c.conds)-1].active
+}
+
+// isCondCmd returns true if the input line is one of the conditional
+// commands, which are processed even inside inactive branches.
+func isCondCmd(line string) bool {
+	cmd := strings.Fields(strings.TrimRight(line, "; "))
+	if len(cmd) == 0 {
+		return false
+	}
+	switch cmd[0] {
+	case `\if`, `\elif`, `\else`, `\endif`:
+		return true
+	}
+	return false
+}
+
+// evalCondExpr evaluates the argument of \if and \elif. Like in psql,
+// the expression must be a boolean value, typically obtained by
+// interpolating a variable. In addition to the values accepted by
+// clisqlclient.ParseBool, prefixes of true, false, yes and no are
+// accepted, so that the t and f values returned by the server for
+// booleans can be used directly.
+func evalCondExpr(args []string) (bool, error) {
+	expr := strings.Join(args, " ")
+	if b, err := clisqlclient.ParseBool(expr); err == nil {
+		return b, nil
+	}
+	switch v := strings.ToLower(expr); {
+	case v == "":
+	case strings.HasPrefix("true", v), strings.HasPrefix("yes", v):
+		return true, nil
+	case strings.HasPrefix("false", v), strings.HasPrefix("no", v):
+		return false, nil
+	}
+	return false, errors.Newf("unrecognized value %q for expression: boolean expected", expr)
+}
+
+// handleIf supports the \if client-side command.
+func (c *cliState) handleIf(args []string, nextState, errState cliStateEnum) cliStateEnum {
+	if !c.condActive() {
+		// The whole block is skipped; the expression is not evaluated.
+		c.conds = append(c.conds, condFrame{done: true})
+		return nextState
+	}
+	b, err := evalCondExpr(args)
+	if err != nil {
+		// An invalid expression is considered false.
+		c.conds = append(c.conds, condFrame{})
+		return c.invalidSyntaxf(errState, `\if: %v`, err)
+	}
+	c.conds = append(c.conds, condFrame{active: b, done: b})
+	return nextState
+}
+
+// handleElif supports the \elif client-side command.
+func (c *cliState) handleElif(args []string, nextState, errState cliStateEnum) cliStateEnum {
+	if len(c.conds) == 0 {
+		return c.invalidSyntaxf(errState, `\elif: no matching \if`)
+	}
+	f := &c.conds[len(c.conds)-1]
+	if f.inElse {
+		return c.invalidSyntaxf(errState, `\elif: cannot occur after \else`)
+	}
+	if f.done {
+		f.active = false
+		return nextState
+	}
+	b, err := evalCondExpr(args)
+	if err != nil {
+		f.active = false
+		return c.invalidSyntaxf(errState, `\elif: %v`, err)
+	}
+	f.active, f.done = b, b
+	return nextState
+}
+
+// handleElse supports the \else client-side command.
+func (c *cliState) handleElse(args []string, nextState, errState cliStateEnum) cliStateEnum {
+	if len(args) > 0 {
+		return c.invalidSyntax(errState)
+	}
+	if len(c.conds) == 0 {
+		return c.invalidSyntaxf(errState, `\else: no matching \if`)
+	}
+	f := &c.conds[len(c.conds)-1]
+	if f.inElse {
+		return c.invalidSyntaxf(errState, `\else: cannot occur after \else`)
+	}
+	f.inElse = true
+	f.active, f.done = !f.done, true
+	return nextState
+}
+
+// handleEndif supports the \endif client-side command.
+func (c *cliState) handleEndif(args []string, nextState, errState cliStateEnum) cliStateEnum {
+	if len(args) > 0 {
+		return c.invalidSyntax(errState)
+	}
+	if len(c.conds) == 0 {
+		return c.invalidSyntaxf(errState, `\endif: no matching \if`)
+	}
+	c.conds = c.conds[:len(c.conds)-1]
+	return nextState
+}
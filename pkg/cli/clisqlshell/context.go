@@ -96,6 +96,15 @@ type internalContext struct {
 
 	statementWrappers []statementWrapper
 
+	// vars contains the client-side variables defined with \set or
+	// \gset. They are interpolated into the input as :name, :'name'
+	// or :"name".
+	vars map[string]string
+
+	// lastQuery is the last statement entered, after interpolation of
+	// variables. \watch runs it again if the query buffer is empty.
+	lastQuery string
+
 	// state about the current query.
 	mu struct {
 		syncutil.Mutex
@@ -62,6 +62,11 @@ Query Buffer
   \p                during a multi-line statement, show the SQL entered so far.
   \r                during a multi-line statement, erase all the SQL entered so far.
   \| CMD            run an external command and run its output as SQL statements.
+  \gset [PREFIX]    run the query buffer and store its single result row in variables.
+  \gexec            run the query buffer, then run each value in its result as SQL.
+  \watch [SEC] [c=N]
+                    run the query buffer, or the previous query, every SEC seconds (default 2),
+                    N times or until interrupted with Ctrl+C.
 
 Connection
   \c, \connect {[DB] [USER] [HOST] [PORT] | [URL]}
@@ -93,8 +98,21 @@ Operating System
   \! CMD            run an external command and print its results on standard output.
 
 Configuration
-  \set [NAME]       set a client-side flag or (without argument) print the current settings.
-  \unset NAME       unset a flag.
+  \set [NAME [VALUE]]
+                    set a client-side flag or variable, or (without argument) print the
+                    current settings and variables.
+  \unset NAME       unset a flag or variable.
+
+Variables
+  :NAME             insert the value of a variable as-is.
+  :'NAME'           insert the value of a variable as a string literal.
+  :"NAME"           insert the value of a variable as an identifier.
+
+Conditionals
+  \if EXPR          begin a conditional block.
+  \elif EXPR        alternative within the current conditional block.
+  \else             final alternative within the current conditional block.
+  \endif            end the current conditional block.
 
 Statement diagnostics
   \statement-diag list                               list available bundles.
@@ -207,6 +225,14 @@ type cliState struct {
 	// by Ctrl+D, causes the shell to terminate with an error --
 	// reporting the status of the last valid SQL statement executed.
 	exitErr error
+
+	// queryBufferCmd is the \gset, \gexec or \watch command, with its
+	// arguments, that terminated the statement in concatLines, if any.
+	queryBufferCmd []string
+
+	// conds is the stack of \if blocks entered so far. Blocks
+	// do not extend across file inclusion.
+	conds []condFrame
 }
 
 // cliStateEnum drives the CLI state machine in runInteractive().
@@ -505,6 +531,9 @@ func (c *cliState) handleSet(args []string, nextState, errState cliStateEnum) cl
 		if err != nil {
 			panic(err)
 		}
+		if err := c.printVariables(); err != nil {
+			panic(err)
+		}
 
 		return nextState
 	}
@@ -516,13 +545,10 @@ func (c *cliState) handleSet(args []string, nextState, errState cliStateEnum) cl
 	sargs := strings.Join(args, " ")
 
 	ok, optName, hasValue, val := getSetArgs(sargs)
-	if !ok {
-		return c.invalidSyntax(errState)
-	}
-
-	opt, ok := options[optName]
-	if !ok {
-		return c.invalidSyntax(errState)
+	opt, isOption := options[optName]
+	if !ok || !isOption {
+		// Not a client-side option: define a variable instead, like psql.
+		return c.handleSetVariable(nextState, errState)
 	}
 	if len(c.partialLines) > 0 && !opt.validDuringMultilineEntry {
 		return c.invalidOptionChange(errState, optName)
@@ -567,7 +593,11 @@ func (c *cliState) handleUnset(args []string, nextState, errState cliStateEnum)
 	}
 	opt, ok := options[args[0]]
 	if !ok {
-		return c.invalidSyntax(errState)
+		if !varNameRe.MatchString(args[0]) {
+			return c.invalidSyntax(errState)
+		}
+		delete(c.iCtx.vars, args[0])
+		return nextState
 	}
 	if len(c.partialLines) > 0 && !opt.validDuringMultilineEntry {
 		return c.invalidOptionChange(errState, args[0])
@@ -1143,7 +1173,13 @@ func (c *cliState) setupChangefeedOutput() (undo func(), err error) {
 }
 
 func (c *cliState) doHandleCliCmd(loopState, nextState cliStateEnum) cliStateEnum {
-	if len(c.lastInputLine) == 0 || c.lastInputLine[0] != '\\' {
+	if !c.condActive() {
+		// Inside a branch skipped by \if, all the input is ignored
+		// except for the conditional commands.
+		if !isCondCmd(c.lastInputLine) {
+			return loopState
+		}
+	} else if len(c.lastInputLine) == 0 || c.lastInputLine[0] != '\\' {
 		return nextState
 	}
 
@@ -1158,6 +1194,10 @@ func (c *cliState) doHandleCliCmd(loopState, nextState cliStateEnum) cliStateEnu
 	// to handle it as a statement, so save the history.
 	c.addHistory(c.lastInputLine)
 
+	// Variables are interpolated in the arguments of client-side
+	// commands too.
+	c.lastInputLine = c.interpolateVariables(c.lastInputLine)
+
 	// As a convenience to the user, we strip the final semicolon, if
 	// any, in all cases.
 	line := strings.TrimRight(c.lastInputLine, "; ")
@@ -1183,6 +1223,23 @@ func (c *cliState) doHandleCliCmd(loopState, nextState cliStateEnum) cliStateEnu
 	case `\unset`:
 		return c.handleUnset(cmd[1:], loopState, errState)
 
+	case `\if`:
+		return c.handleIf(cmd[1:], loopState, errState)
+
+	case `\elif`:
+		return c.handleElif(cmd[1:], loopState, errState)
+
+	case `\else`:
+		return c.handleElse(cmd[1:], loopState, errState)
+
+	case `\endif`:
+		return c.handleEndif(cmd[1:], loopState, errState)
+
+	case `\gset`, `\gexec`, `\watch`:
+		// These terminate the statement in the query buffer, which is
+		// handled like SQL input.
+		return nextState
+
 	case `\!`:
 		return c.runSyscmd(c.lastInputLine, loopState, errState)
 
@@ -1674,6 +1731,12 @@ func (c *cliState) doPrepareStatementLine(
 		return startState
 	}
 
+	if !c.inCopy() {
+		if sql, cmd := splitQueryBufferCmd(c.concatLines); cmd != nil {
+			return c.prepareQueryBufferCmd(sql, cmd, startState, checkState, execState)
+		}
+	}
+
 	lastTok, ok := scanner.LastLexicalToken(c.concatLines)
 	if c.partialStmtsLen == 0 && !ok {
 		// More whitespace, or comments. Still nothing to do. However
@@ -1711,6 +1774,8 @@ func (c *cliState) doPrepareStatementLine(
 	// Complete input. Remember it in the history.
 	if !c.inCopy() {
 		c.addHistory(c.concatLines)
+		c.concatLines = c.interpolateVariables(c.concatLines)
+		c.iCtx.lastQuery = c.concatLines
 	}
 
 	if !c.iCtx.checkSyntax {
@@ -1738,6 +1803,9 @@ func (c *cliState) doCheckStatement(startState, contState, execState cliStateEnu
 			clierror.NewFormattedError(err, false /*showSeverity*/, false /*verbose*/),
 		)
 
+		// The statement will not run; forget any \gset or \gexec.
+		c.queryBufferCmd = nil
+
 		// Stop here if exiterr is set.
 		if c.iCtx.errExit {
 			return cliStop
@@ -1766,6 +1834,11 @@ func (c *cliState) doCheckStatement(startState, contState, execState cliStateEnu
 // doRunStatements runs all the statements that have been accumulated by
 // concatLines.
 func (c *cliState) doRunStatements(nextState cliStateEnum) cliStateEnum {
+	if cmd := c.queryBufferCmd; cmd != nil {
+		c.queryBufferCmd = nil
+		return c.runQueryBufferCmd(cmd, nextState)
+	}
+
 	if err := c.iCtx.maybeWrapStatement(context.Background(), c.concatLines, c); err != nil {
 		c.exitErr = err
 		if !c.singleStatement {
@@ -1899,7 +1972,11 @@ func (c *cliState) beginCopyFrom(ctx context.Context, sql string) error {
 }
 
 func (c *cliState) doDecidePath() cliStateEnum {
-	if len(c.partialLines) == 0 {
+	if !c.condActive() && !c.atEOF {
+		// Only conditional commands are considered inside a branch
+		// skipped by \if.
+		return cliHandleCliCmd
+	} else if len(c.partialLines) == 0 {
 		return cliProcessFirstLine
 	} else if c.cliCtx.IsInteractive {
 		// In interactive mode, we allow client-side commands to be
@@ -2010,6 +2087,13 @@ func (c *cliState) doRunShell(state cliStateEnum, cmdIn, cmdOut, cmdErr *os.File
 		}
 	}
 
+	if c.atEOF && len(c.conds) > 0 {
+		c.exitErr = errUnterminatedIf
+		if !c.singleStatement {
+			fmt.Fprintln(c.iCtx.stderr, c.exitErr)
+		}
+	}
+
 	return c.exitErr
 }
 
@@ -2288,6 +2372,14 @@ func (c *cliState) maybeHandleInterrupt() func() {
 }
 
 func (c *cliState) runWithInterruptableCtx(fn func(ctx context.Context) error) error {
+	return c.runWithCancelFn(c.conn.Cancel, fn)
+}
+
+// runWithCancelFn is like runWithInterruptableCtx, but cancelFn is
+// called upon Ctrl+C instead of canceling the current query.
+func (c *cliState) runWithCancelFn(
+	cancelFn func(ctx context.Context) error, fn func(ctx context.Context) error,
+) error {
 	if !c.cliCtx.IsInteractive {
 		return fn(context.Background())
 	}
@@ -2301,7 +2393,7 @@ func (c *cliState) runWithInterruptableCtx(fn func(ctx context.Context) error) e
 
 	// Inform the Ctrl+C handler that this query is executing.
 	c.iCtx.mu.Lock()
-	c.iCtx.mu.cancelFn = c.conn.Cancel
+	c.iCtx.mu.cancelFn = cancelFn
 	c.iCtx.mu.doneCh = doneCh
 	c.iCtx.mu.Unlock()
 	defer func() {
@@ -13,7 +13,9 @@ package clisqlshell
 import (
 	"bufio"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clicfg"
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
@@ -221,3 +223,184 @@ func TestGetSetArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestInterpolateVariables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	vars := map[string]string{"a": "x y", "q": "it's", "tbl": `My "Tbl"`, "n": "2"}
+	td := []struct {
+		input    string
+		expected string
+	}{
+		{`SELECT 1`, `SELECT 1`},
+		{`SELECT :a`, `SELECT x y`},
+		{`SELECT :'q'`, `SELECT 'it''s'`},
+		{`SELECT * FROM :"tbl"`, `SELECT * FROM "My ""Tbl"""`},
+		{`SELECT :n:n`, `SELECT 22`},
+		{`SELECT :'q' :"tbl"`, `SELECT 'it''s' "My ""Tbl"""`},
+		// Undefined variables are left alone.
+		{`SELECT :b, :'b', :"b"`, `SELECT :b, :'b', :"b"`},
+		// Literals, quoted identifiers, comments and casts are left alone.
+		{`SELECT ':a', ":a", x::INT -- :a`, `SELECT ':a', ":a", x::INT -- :a`},
+		// The variable name must follow the colon immediately.
+		{`SELECT : a`, `SELECT : a`},
+		// Client-side commands.
+		{`\echo :a :'q'`, `\echo x y 'it''s'`},
+	}
+
+	for _, tc := range td {
+		if res := interpolateVariables(tc.input, vars); res != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.input, tc.expected, res)
+		}
+	}
+}
+
+func TestParseVariableValue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	td := []struct {
+		input    string
+		ok       bool
+		expected string
+	}{
+		{``, true, ``},
+		{`abc`, true, `abc`},
+		// Arguments are concatenated.
+		{`a b  c`, true, `abc`},
+		// Single quotes preserve whitespace.
+		{`'a  b'`, true, `a  b`},
+		{`'it''s' x`, true, `it'sx`},
+		{`''`, true, ``},
+		// Double quotes are kept.
+		{`"My Tbl"`, true, `"My Tbl"`},
+		{`'a`, false, ``},
+		{`"a`, false, ``},
+	}
+
+	for _, tc := range td {
+		res, err := parseVariableValue(tc.input)
+		if (err == nil) != tc.ok || res != tc.expected {
+			t.Errorf("%s: expected (%v, %q), got (%v, %q)", tc.input, tc.ok, tc.expected, err, res)
+		}
+	}
+}
+
+func TestSplitQueryBufferCmd(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	td := []struct {
+		input string
+		sql   string
+		cmd   []string
+	}{
+		{`SELECT 1`, `SELECT 1`, nil},
+		{`SELECT 1 \gset`, `SELECT 1 `, []string{`\gset`}},
+		{`SELECT 1 AS x \gset pre_;`, `SELECT 1 AS x `, []string{`\gset`, `pre_`}},
+		{"SELECT 1\n\\gexec", "SELECT 1\n", []string{`\gexec`}},
+		{`\gset`, ``, []string{`\gset`}},
+		{`SELECT 1 \watch c=2 0.5`, `SELECT 1 `, []string{`\watch`, `c=2`, `0.5`}},
+		// Commands in literals and comments are ignored.
+		{`SELECT '\gset'`, `SELECT '\gset'`, nil},
+		{`SELECT 1 -- \gset`, `SELECT 1 -- \gset`, nil},
+		// Other commands are not recognized.
+		{`SELECT 1 \gsetx`, `SELECT 1 \gsetx`, nil},
+		{`SELECT 1 \ gset`, `SELECT 1 \ gset`, nil},
+	}
+
+	for _, tc := range td {
+		sql, cmd := splitQueryBufferCmd(tc.input)
+		assert.Equal(t, tc.sql, sql, tc.input)
+		assert.Equal(t, tc.cmd, cmd, tc.input)
+	}
+}
+
+func TestHandleCliCmdConditionals(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	c := setupTestCliState()
+	c.iCtx.vars = map[string]string{"yes": "t", "no": "f"}
+
+	td := []struct {
+		line   string
+		active bool
+	}{
+		{`\if :no`, false},
+		{`\set a 1`, false},
+		{`\if true`, false},
+		{`\else`, false},
+		{`\endif`, false},
+		{`\elif :yes`, true},
+		{`\set b 2`, true},
+		{`\elif true`, false},
+		{`\else`, false},
+		{`\endif`, true},
+		{`\if 1`, true},
+		{`\if off`, false},
+		{`\else`, true},
+		{`\endif`, true},
+		{`\endif`, true},
+	}
+
+	for _, tc := range td {
+		c.lastInputLine = tc.line
+		gotState := c.doHandleCliCmd(cliStateEnum(0), cliStateEnum(1))
+		assert.Equal(t, cliStateEnum(0), gotState, tc.line)
+		assert.Equal(t, tc.active, c.condActive(), tc.line)
+		assert.NoError(t, c.exitErr, tc.line)
+	}
+	assert.Empty(t, c.conds)
+	assert.Equal(t, map[string]string{"yes": "t", "no": "f", "b": "2"}, c.iCtx.vars)
+
+	// Invalid uses.
+	for _, line := range []string{`\if maybe`, `\else`, `\else`} {
+		c.exitErr = nil
+		c.lastInputLine = line
+		c.doHandleCliCmd(cliStateEnum(0), cliStateEnum(1))
+		if line == `\if maybe` {
+			// The invalid expression is considered false.
+			assert.False(t, c.condActive())
+		}
+	}
+	assert.Equal(t, errInvalidSyntax, c.exitErr)
+	for _, line := range []string{`\endif`, `\elif true`, `\endif`} {
+		c.exitErr = nil
+		c.lastInputLine = line
+		c.doHandleCliCmd(cliStateEnum(0), cliStateEnum(1))
+	}
+	assert.Equal(t, errInvalidSyntax, c.exitErr)
+}
+
+func TestParseWatchArgs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	td := []struct {
+		args     string
+		ok       bool
+		interval time.Duration
+		count    int
+	}{
+		{``, true, defaultWatchInterval, 0},
+		{`5`, true, 5 * time.Second, 0},
+		{`0.5`, true, 500 * time.Millisecond, 0},
+		{`100ms`, true, 100 * time.Millisecond, 0},
+		{`i=3`, true, 3 * time.Second, 0},
+		{`c=4`, true, defaultWatchInterval, 4},
+		{`interval=1 count=2`, true, time.Second, 2},
+		{`count=2 1m`, true, time.Minute, 2},
+		{`0`, false, 0, 0},
+		{`-1`, false, 0, 0},
+		{`abc`, false, 0, 0},
+		{`c=0`, false, 0, 0},
+		{`c=x`, false, 0, 0},
+		{`x=1`, false, 0, 0},
+	}
+
+	for _, tc := range td {
+		interval, count, err := parseWatchArgs(strings.Fields(tc.args))
+		if (err == nil) != tc.ok || interval != tc.interval || count != tc.count {
+			t.Errorf("%s: expected (%v, %v, %v), got (%v, %v, %v)", tc.args,
+				tc.ok, tc.interval, tc.count, err, interval, count)
+		}
+	}
+}
@@ -146,6 +146,7 @@ func Example_sql_config() {
 	c.RunWithArgs([]string{`sql`, `-e`, `\set display_format=raw`, `-e`, `select 123 as "123"`})
 	// A failure in a client-side command prevents subsequent statements from executing.
 	c.RunWithArgs([]string{`sql`, `--set`, `unknownoption`, `-e`, `select 123 as "123"`})
+	c.RunWithArgs([]string{`sql`, `--set`, `unknownoption=value`, `-e`, `select 123 as "123"`})
 	c.RunWithArgs([]string{`sql`, `--set`, `display_format=invalidvalue`, `-e`, `select 123 as "123"`})
 	c.RunWithArgs([]string{`sql`, `-e`, `\set display_format=invalidvalue`, `-e`, `select 123 as "123"`})
 
@@ -174,6 +175,9 @@ func Example_sql_config() {
 	// sql --set unknownoption -e select 123 as "123"
 	// invalid syntax: \set unknownoption. Try \? for help.
 	// ERROR: -e: invalid syntax
+	// sql --set unknownoption=value -e select 123 as "123"
+	// invalid syntax: \set unknownoption=value. Try \? for help.
+	// ERROR: -e: invalid syntax
 	// sql --set display_format=invalidvalue -e select 123 as "123"
 	// \set display_format=invalidvalue: invalid table display format: invalidvalue (possible values: tsv, csv, table, records, sql, html, raw)
 	// ERROR: -e: invalid table display format: invalidvalue (possible values: tsv, csv, table, records, sql, html, raw)
@@ -365,6 +369,87 @@ func Example_includes() {
 	// ERROR: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: \i: too many recursion levels (max 10)
 }
 
+// Example_variables tests client-side variables, \gset, \gexec and
+// conditional blocks.
+func Example_variables() {
+	c := cli.NewCLITest(cli.TestCLIParams{})
+	defer c.Cleanup()
+
+	c.RunWithArgs([]string{"sql", "-f", "testdata/variables.sql"})
+	c.RunWithArgs([]string{"sql", "-e", `\set x 'hello world'`, "-e", `\echo :x`, "-e", `select :'x' as x`})
+	c.RunWithArgs([]string{"sql", "-e", `\if yes`, "-e", `select 1`})
+	c.RunWithArgs([]string{"sql", "-e", `\set x ''`, "-e", `select length(:'x') as l`})
+	c.RunWithArgs([]string{"sql", "-e", `\set x`, "-e", `select 1`})
+
+	// Output:
+	// sql -f testdata/variables.sql
+	// SET
+	// vars_test it's a test
+	// CREATE TABLE
+	// INSERT 0 2
+	// 2 2
+	// many rows
+	// nested else
+	// k
+	// 1
+	// k
+	// 2
+	// s
+	// it's a test
+	// :greeting
+	// ERROR: \gset: more than one row returned
+	// ERROR: \gset: more than one row returned
+	// sql -e \set x 'hello world' -e \echo :x -e select :'x' as x
+	// hello world
+	// x
+	// hello world
+	// sql -e \if yes -e select 1
+	// ERROR: -e: reached end of input without finding closing \endif
+	// sql -e \set x '' -e select length(:'x') as l
+	// l
+	// 0
+	// sql -e \set x -e select 1
+	// invalid syntax: \set x. Try \? for help.
+	// ERROR: -e: invalid syntax
+}
+
+// Example_watch tests the \watch command.
+func Example_watch() {
+	c := cli.NewCLITest(cli.TestCLIParams{})
+	defer c.Cleanup()
+
+	c.RunWithArgs([]string{"sql", "-e", `select 1 as x \watch c=2 0.01`})
+	// Without a query buffer, the previous query runs again.
+	c.RunWithArgs([]string{"sql", "-e", `select 2 as y`, "-e", `\watch interval=10ms count=2`})
+	// An error stops the loop.
+	c.RunWithArgs([]string{"sql", "-e", `select 1/0 as z \watch c=3 0.01`})
+	c.RunWithArgs([]string{"sql", "-e", `\watch c=2`})
+	c.RunWithArgs([]string{"sql", "-e", `select 1 \watch c=0`})
+
+	// Output:
+	// sql -e select 1 as x \watch c=2 0.01
+	// x
+	// 1
+	// x
+	// 1
+	// sql -e select 2 as y -e \watch interval=10ms count=2
+	// y
+	// 2
+	// y
+	// 2
+	// y
+	// 2
+	// sql -e select 1/0 as z \watch c=3 0.01
+	// ERROR: division by zero
+	// SQLSTATE: 22012
+	// sql -e \watch c=2
+	// invalid syntax: \watch: query buffer is empty
+	// ERROR: -e: invalid syntax
+	// sql -e select 1 \watch c=0
+	// invalid syntax: \watch: invalid count: "0"
+	// ERROR: -e: invalid syntax
+}
+
 // Example_sql_lex tests the usage of the lexer in the sql subcommand.
 func Example_sql_lex() {
 	c := cli.NewCLITest(cli.TestCLIParams{Insecure: true})
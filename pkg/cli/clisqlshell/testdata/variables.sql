@@ -0,0 +1,43 @@
+--- input file for Example_variables.
+
+--- don't report timestamps: it makes the output non-deterministic.
+\unset show_times
+
+USE defaultdb;
+
+\set tbl vars_test
+\set greeting 'it''s a test'
+\echo :tbl :greeting
+CREATE TABLE :tbl (k INT PRIMARY KEY, s STRING);
+INSERT INTO :"tbl" VALUES (1, :'greeting'), (2, ':greeting');
+
+-- capture query results into variables.
+SELECT count(*) AS n, max(k) AS last FROM :tbl \gset res_
+\echo :res_n :res_last
+
+-- conditional blocks.
+SELECT :res_n > 1 AS has_many \gset
+\if :has_many
+\echo many rows
+\if false
+SELECT 'unseen nested';
+\else
+\echo nested else
+\endif
+\elif true
+SELECT 'unseen elif';
+\else
+SELECT 'unseen else';
+\endif
+
+-- run each result as SQL.
+SELECT 'SELECT ' || k || ' AS k' FROM :tbl ORDER BY k \gexec
+
+SELECT s FROM :tbl ORDER BY k;
+
+-- \gset requires exactly one row; this stops processing.
+SELECT k FROM :tbl
+\gset
+
+-- this is not executed
+SELECT 'unseen';
@@ -0,0 +1,411 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlshell
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clierror"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/scanner"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements client-side variables, with the same syntax as
+// psql. Variables are defined with \set, or from query results with
+// \gset, and are interpolated into statements and client-side commands
+// as :name (verbatim), :'name' (as a string literal) or :"name" (as an
+// identifier).
+
+// varNameRe matches valid variable names.
+var varNameRe = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// handleSetVariable handles \set for names that are not client-side
+// options. The value is taken from the raw input line, so that
+// whitespace in quoted strings is preserved.
+//
+// Unlike psql, a variable is only defined when the name is followed by
+// a value separated by whitespace. A name on its own, or followed by
+// an equal sign, is the syntax used to set client-side options, so an
+// unknown name used that way is reported as an error rather than
+// silently defining a variable. A variable can still be set to the
+// empty string by passing a quoted empty string as value.
+func (c *cliState) handleSetVariable(nextState, errState cliStateEnum) cliStateEnum {
+	sargs := strings.TrimSpace(strings.TrimRight(strings.TrimPrefix(c.lastInputLine, `\set`), "; "))
+	name, rawValue := sargs, ""
+	if i := strings.IndexAny(sargs, " \t"); i >= 0 {
+		name, rawValue = sargs[:i], strings.TrimSpace(sargs[i+1:])
+	}
+	if _, isOption := options[name]; isOption || !varNameRe.MatchString(name) {
+		// Options with an invalid value are also reported here.
+		return c.invalidSyntax(errState)
+	}
+	if rawValue == "" || rawValue[0] == '=' {
+		// This looks like an attempt to set an unknown option.
+		return c.invalidSyntax(errState)
+	}
+	val, err := parseVariableValue(rawValue)
+	if err != nil {
+		return c.invalidSyntax(errState)
+	}
+	c.setVariable(name, val)
+	return nextState
+}
+
+func (c *cliState) setVariable(name, val string) {
+	if c.iCtx.vars == nil {
+		c.iCtx.vars = make(map[string]string)
+	}
+	c.iCtx.vars[name] = val
+}
+
+// printVariables prints the client-side variables, if any, for \set
+// without arguments.
+func (c *cliState) printVariables() error {
+	if len(c.iCtx.vars) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(c.iCtx.vars))
+	for n := range c.iCtx.vars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	varData := make([][]string, len(names))
+	for i, n := range names {
+		varData[i] = []string{n, c.iCtx.vars[n]}
+	}
+	return c.sqlExecCtx.PrintQueryOutput(c.iCtx.stdout, c.iCtx.stderr,
+		[]string{"Variable", "Value"},
+		clisqlexec.NewRowSliceIter(varData, "ll" /*align*/))
+}
+
+// parseVariableValue parses the value arguments of \set like psql
+// does: the arguments are concatenated without separator. Within
+// single quotes, whitespace is preserved and two consecutive quotes
+// stand for one quote. Double-quoted text is kept as-is, including
+// the quotes, so that it can be used as an identifier.
+func parseVariableValue(s string) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case ' ', '\t':
+			// Whitespace separates the arguments.
+		case '\'', '"':
+			j := i + 1
+			for {
+				k := strings.IndexByte(s[j:], ch)
+				if k < 0 {
+					return "", errors.New("unterminated quoted string")
+				}
+				j += k + 1
+				if j < len(s) && s[j] == ch {
+					// Doubled quote.
+					j++
+					continue
+				}
+				break
+			}
+			if ch == '"' {
+				buf.WriteString(s[i:j])
+			} else {
+				buf.WriteString(strings.ReplaceAll(s[i+1:j-1], `''`, `'`))
+			}
+			i = j - 1
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return buf.String(), nil
+}
+
+// interpolateVariables substitutes the references to client-side
+// variables in the given input. References inside string literals,
+// quoted identifiers and comments are left alone, as are references
+// to undefined variables.
+func (c *cliState) interpolateVariables(input string) string {
+	if len(c.iCtx.vars) == 0 || !strings.Contains(input, ":") {
+		return input
+	}
+	return interpolateVariables(input, c.iCtx.vars)
+}
+
+func interpolateVariables(input string, vars map[string]string) string {
+	var sc scanner.Scanner
+	sc.Init(input)
+	var buf strings.Builder
+	// copied is the position up to which the input was copied to buf.
+	copied := 0
+	// colonPos is the position of a colon immediately preceding the
+	// current token, or -1 if there is none.
+	colonPos, prevEnd := -1, -1
+	for {
+		var lval fakeSym
+		sc.Scan(&lval)
+		if lval.id == 0 || lval.id == lexbase.ERROR {
+			break
+		}
+		start, end := int(lval.pos), sc.Pos()
+		// The scanner consumes the whitespace following a string
+		// literal, looking for a continuation.
+		end = start + len(strings.TrimRight(input[start:end], " \t\r\n\f"))
+		if colonPos >= 0 && start == prevEnd {
+			if repl, ok := variableReference(input[start:end], lval.s, vars); ok {
+				buf.WriteString(input[copied:colonPos])
+				buf.WriteString(repl)
+				copied = end
+				colonPos, prevEnd = -1, end
+				continue
+			}
+		}
+		colonPos = -1
+		if lval.id == ':' {
+			colonPos = start
+		}
+		prevEnd = end
+	}
+	if copied == 0 {
+		return input
+	}
+	buf.WriteString(input[copied:])
+	return buf.String()
+}
+
+// variableReference returns the replacement for the token following a
+// colon, if the token refers to a defined variable. raw is the text of
+// the token and str its value after unquoting.
+func variableReference(raw, str string, vars map[string]string) (string, bool) {
+	switch {
+	case raw[0] == '\'':
+		if v, ok := vars[str]; ok {
+			return "'" + strings.ReplaceAll(v, "'", "''") + "'", true
+		}
+	case raw[0] == '"':
+		if v, ok := vars[str]; ok {
+			return lexbase.EscapeSQLIdent(v), true
+		}
+	case varNameRe.MatchString(raw):
+		if v, ok := vars[raw]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// splitQueryBufferCmd separates a trailing \gset, \gexec or \watch
+// command from the SQL input that precedes it. cmd is nil if there is no such
+// command.
+func splitQueryBufferCmd(input string) (sql string, cmd []string) {
+	var sc scanner.Scanner
+	sc.Init(input)
+	backslashPos, prevEnd := -1, -1
+	for {
+		var lval fakeSym
+		sc.Scan(&lval)
+		if lval.id == 0 || lval.id == lexbase.ERROR {
+			return input, nil
+		}
+		start, end := int(lval.pos), sc.Pos()
+		if backslashPos >= 0 && start == prevEnd {
+			switch input[start:end] {
+			case "gset", "gexec", "watch":
+				return input[:backslashPos], strings.Fields(strings.TrimRight(input[backslashPos:], "; "))
+			}
+		}
+		backslashPos = -1
+		if lval.id == '\\' {
+			backslashPos = start
+		}
+		prevEnd = end
+	}
+}
+
+// prepareQueryBufferCmd prepares the execution of a statement
+// terminated by \gset, \gexec or \watch.
+func (c *cliState) prepareQueryBufferCmd(
+	sql string, cmd []string, startState, checkState, execState cliStateEnum,
+) cliStateEnum {
+	c.addHistory(c.concatLines)
+
+	errState := startState
+	if c.iCtx.errExit {
+		errState = cliStop
+	}
+	if (cmd[0] == `\gset` && len(cmd) > 2) || (cmd[0] == `\gexec` && len(cmd) > 1) {
+		return c.invalidSyntaxf(errState, `%s. Try \? for help.`, strings.Join(cmd, " "))
+	}
+	if cmd[0] == `\watch` {
+		if _, _, err := parseWatchArgs(cmd[1:]); err != nil {
+			return c.invalidSyntaxf(errState, `\watch: %v`, err)
+		}
+	}
+
+	sql = strings.TrimSpace(sql)
+	if _, ok := scanner.LastLexicalToken(sql); ok {
+		sql = c.interpolateVariables(sql)
+	} else if cmd[0] == `\watch` && c.iCtx.lastQuery != "" {
+		// Like psql, \watch runs the previous query if the query buffer
+		// is empty.
+		sql = c.iCtx.lastQuery
+	} else {
+		return c.invalidSyntaxf(errState, `%s: query buffer is empty`, cmd[0])
+	}
+
+	c.concatLines = sql
+	c.iCtx.lastQuery = sql
+	c.queryBufferCmd = cmd
+
+	if !c.iCtx.checkSyntax {
+		return execState
+	}
+	return checkState
+}
+
+// runQueryBufferCmd runs the statement in concatLines and processes
+// its results as specified by \gset, \gexec or \watch.
+func (c *cliState) runQueryBufferCmd(cmd []string, nextState cliStateEnum) cliStateEnum {
+	// Once we send something to the server, the txn status may change arbitrarily.
+	c.lastKnownTxnStatus = unknownTxnStatus
+
+	if cmd[0] == `\watch` {
+		c.exitErr = c.runWatch(cmd[1:])
+	} else {
+		var cols []string
+		var rows [][]driver.Value
+		c.exitErr = c.runWithInterruptableCtx(func(ctx context.Context) (err error) {
+			cols, rows, err = c.runQueryForResults(ctx, c.concatLines)
+			return err
+		})
+		if c.exitErr == nil {
+			switch cmd[0] {
+			case `\gset`:
+				prefix := ""
+				if len(cmd) > 1 {
+					prefix = cmd[1]
+				}
+				c.exitErr = c.setVariablesFromResults(prefix, cols, rows)
+
+			case `\gexec`:
+				return c.execResults(rows, nextState)
+			}
+		}
+	}
+	if c.exitErr != nil {
+		if !c.singleStatement {
+			clierror.OutputError(c.iCtx.stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
+		}
+		if c.iCtx.errExit {
+			return cliStop
+		}
+	}
+	return nextState
+}
+
+// runQueryForResults runs the given SQL and returns the columns and
+// rows of its last result set. The values are strings in the text
+// format sent by the server, or nil for NULL.
+func (c *cliState) runQueryForResults(
+	ctx context.Context, sql string,
+) (cols []string, rows [][]driver.Value, retErr error) {
+	res, _, err := clisqlclient.MakeQuery(sql)(ctx, c.conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { retErr = errors.CombineErrors(retErr, res.Close()) }()
+	for {
+		resCols := res.Columns()
+		var resRows [][]driver.Value
+		for {
+			vals := make([]driver.Value, len(resCols))
+			if err := res.Next(vals); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, nil, err
+			}
+			resRows = append(resRows, vals)
+		}
+		if len(resCols) > 0 {
+			cols, rows = resCols, resRows
+		}
+		if more, err := res.NextResultSet(); err != nil {
+			return nil, nil, err
+		} else if !more {
+			return cols, rows, nil
+		}
+	}
+}
+
+// setVariablesFromResults implements \gset: the query must return
+// exactly one row, and each column value is stored in the variable
+// named after the column, with the given prefix. NULL values unset
+// the variable.
+func (c *cliState) setVariablesFromResults(
+	prefix string, cols []string, rows [][]driver.Value,
+) error {
+	if len(rows) == 0 {
+		return errors.New(`\gset: no rows returned`)
+	}
+	if len(rows) > 1 {
+		return errors.New(`\gset: more than one row returned`)
+	}
+	for i, col := range cols {
+		name := prefix + col
+		if !varNameRe.MatchString(name) {
+			return errors.Newf(`\gset: invalid variable name: %q`, name)
+		}
+		if val, ok := resultValue(rows[0][i]); ok {
+			c.setVariable(name, val)
+		} else {
+			delete(c.iCtx.vars, name)
+		}
+	}
+	return nil
+}
+
+// execResults implements \gexec: each non-NULL value in the results
+// is executed as a SQL statement, in row order and then column order.
+func (c *cliState) execResults(rows [][]driver.Value, nextState cliStateEnum) cliStateEnum {
+	for _, row := range rows {
+		for _, v := range row {
+			sql, ok := resultValue(v)
+			if !ok {
+				continue
+			}
+			c.concatLines = sql
+			if c.doRunStatements(nextState) == cliStop {
+				return cliStop
+			}
+		}
+	}
+	return nextState
+}
+
+// resultValue returns the string form of a value returned by
+// runQueryForResults. The boolean is false for NULL.
+func resultValue(v driver.Value) (string, bool) {
+	switch t := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return t, true
+	case []byte:
+		return string(t), true
+	default:
+		return fmt.Sprint(t), true
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlshell
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// defaultWatchInterval is the pause between runs of \watch when no
+// interval is specified. This is the same default as psql.
+const defaultWatchInterval = 2 * time.Second
+
+// parseWatchArgs parses the arguments of \watch. Like in psql, the
+// interval can be given as a number of seconds, either alone or as
+// i=SEC or interval=SEC, and the number of runs as c=N or count=N. The
+// interval can also be a duration such as 500ms. A count of zero means
+// that the statement runs until interrupted.
+func parseWatchArgs(args []string) (interval time.Duration, count int, err error) {
+	interval = defaultWatchInterval
+	for _, arg := range args {
+		name, val, hasName := strings.Cut(arg, "=")
+		if !hasName {
+			name, val = "interval", arg
+		}
+		switch name {
+		case "i", "interval":
+			if interval, err = parseWatchInterval(val); err != nil {
+				return 0, 0, err
+			}
+		case "c", "count":
+			if count, err = strconv.Atoi(val); err != nil || count <= 0 {
+				return 0, 0, errors.Newf("invalid count: %q", val)
+			}
+		default:
+			return 0, 0, errors.Newf("unknown argument: %q", arg)
+		}
+	}
+	return interval, count, nil
+}
+
+func parseWatchInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		d = time.Duration(secs * float64(time.Second))
+	} else if d, err = time.ParseDuration(s); err != nil {
+		return 0, errors.Newf("invalid interval: %q", s)
+	}
+	if d <= 0 {
+		return 0, errors.Newf("invalid interval: %q", s)
+	}
+	return d, nil
+}
+
+// runWatch implements \watch: the statement in concatLines runs
+// repeatedly, pausing for the interval between runs, until the count
+// is reached, an error occurs or the user presses Ctrl+C. Ctrl+C only
+// stops the loop; the session remains usable afterwards.
+//
+// When the results are displayed on a terminal, the screen is cleared
+// before each run so that the results are redrawn in place.
+func (c *cliState) runWatch(args []string) error {
+	interval, count, err := parseWatchArgs(args)
+	if err != nil {
+		return err
+	}
+	redraw := c.sqlExecCtx.TerminalOutput && c.iCtx.queryOutputBuf == nil
+
+	// stopCh is closed upon Ctrl+C. A query in progress at that point
+	// is also canceled.
+	stopCh := make(chan struct{})
+	var stopOnce sync.Once
+	var running syncutil.AtomicBool
+	interrupt := func(ctx context.Context) error {
+		stopOnce.Do(func() { close(stopCh) })
+		if running.Get() {
+			return c.conn.Cancel(ctx)
+		}
+		return nil
+	}
+
+	return c.runWithCancelFn(interrupt, func(ctx context.Context) error {
+		for i := 0; count == 0 || i < count; i++ {
+			if i > 0 {
+				select {
+				case <-stopCh:
+					return nil
+				case <-time.After(interval):
+				}
+			}
+			if redraw {
+				// Clear the screen and move the cursor to the top left.
+				fmt.Fprint(c.iCtx.queryOutput, "\033[H\033[2J")
+				fmt.Fprintf(c.iCtx.queryOutput, "%s (every %s)\n\n",
+					timeutil.Now().Format(time.RFC1123), interval)
+			}
+			running.Set(true)
+			err := c.sqlExecCtx.RunQueryAndFormatResults(
+				ctx,
+				c.conn,
+				c.iCtx.queryOutput, // query output.
+				c.iCtx.stdout,      // timings.
+				c.iCtx.stderr,
+				clisqlclient.MakeQuery(c.concatLines),
+			)
+			running.Set(false)
+			c.maybeFlushOutput()
+			select {
+			case <-stopCh:
+				// The error, if any, is the result of the cancellation.
+				return nil
+			default:
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
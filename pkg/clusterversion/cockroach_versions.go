@@ -337,6 +337,52 @@ const (
 	// for a partial statistics collection.
 	V23_1AddPartialStatisticsPredicateCol
 
+	// V23_1ReadCommittedIsolation is the version where transactions can use
+	// the READ COMMITTED isolation level. Nodes running older versions
+	// ignore the isolation level of a transaction, and would evaluate its
+	// requests as if it were SERIALIZABLE.
+	V23_1ReadCommittedIsolation
+
+	// V23_1Procedures is the version where procedures can be created with
+	// CREATE PROCEDURE. Older nodes do not know the is_procedure field of
+	// function descriptors and would allow procedures to be used in
+	// expressions.
+	V23_1Procedures
+
+	// V23_1Triggers is the version where row-level triggers can be created
+	// with CREATE TRIGGER. Older nodes do not know the triggers stored in
+	// table descriptors and would not fire them.
+	V23_1Triggers
+
+	// V23_1UserDefinedAggregates is the version where aggregates can be
+	// created with CREATE AGGREGATE. Older nodes do not know the aggregate
+	// section of function descriptors and would resolve aggregates as regular
+	// functions.
+	V23_1UserDefinedAggregates
+
+	// V23_1FullTextSearch is the version where columns of the TSVECTOR and
+	// TSQUERY types can be created.
+	V23_1FullTextSearch
+
+	// V23_1ExclusionConstraints is the version where exclusion constraints can
+	// be created. Older nodes do not know the exclusion constraints stored in
+	// table descriptors and would not enforce them.
+	V23_1ExclusionConstraints
+
+	// V23_1RangeTypes is the version where columns of the range and multirange
+	// types can be created.
+	V23_1RangeTypes
+
+	// V23_1Jsonpath is the version where columns of the JSONPATH type can be
+	// created.
+	V23_1Jsonpath
+
+	// V23_1SharedLocks is the version where SQL acquires Shared locks for FOR
+	// SHARE and FOR KEY SHARE, and where Shared locks can be replicated. Older
+	// nodes cannot acquire Shared locks in their lock table, and panic when
+	// they encounter a replicated lock that is not an intent.
+	V23_1SharedLocks
+
 	// *************************************************
 	// Step (1): Add new versions here.
 	// Do not add new versions to a patch release.
@@ -587,6 +633,42 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_1AddPartialStatisticsPredicateCol,
 		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 8},
 	},
+	{
+		Key:     V23_1ReadCommittedIsolation,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 10},
+	},
+	{
+		Key:     V23_1Procedures,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 12},
+	},
+	{
+		Key:     V23_1Triggers,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 14},
+	},
+	{
+		Key:     V23_1UserDefinedAggregates,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 16},
+	},
+	{
+		Key:     V23_1FullTextSearch,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 18},
+	},
+	{
+		Key:     V23_1ExclusionConstraints,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 20},
+	},
+	{
+		Key:     V23_1RangeTypes,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 22},
+	},
+	{
+		Key:     V23_1Jsonpath,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 24},
+	},
+	{
+		Key:     V23_1SharedLocks,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 26},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -16,6 +16,7 @@ import (
 	"runtime/debug"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -811,6 +812,13 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	errTxnID := pErr.GetTxn().ID
 	newTxn := roachpb.PrepareTransactionForRetry(ctx, pErr, tc.mu.userPriority, tc.clock)
 
+	// Transactions that take a new read snapshot for each statement don't need
+	// to restart from the beginning when the current statement's snapshot is no
+	// longer valid. Instead, they retry just that statement at a new snapshot.
+	if errTxnID == newTxn.ID && tc.canPartialRetryLocked(pErr) {
+		return tc.handlePartialRetryableErrLocked(ctx, pErr, &newTxn)
+	}
+
 	// We'll pass a TransactionRetryWithProtoRefreshError up to the next layer.
 	retErr := roachpb.NewTransactionRetryWithProtoRefreshError(
 		pErr.String(),
@@ -848,6 +856,51 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	return retErr
 }
 
+// canPartialRetryLocked returns whether the provided retryable error can be
+// handled by retrying only the current statement, instead of restarting the
+// transaction at a new epoch. This is the case for errors which indicate that
+// the statement's read snapshot is stale, in transactions whose isolation
+// level permits each statement to read from its own snapshot.
+func (tc *TxnCoordSender) canPartialRetryLocked(pErr *roachpb.Error) bool {
+	if !tc.mu.txn.IsoLevel.PerStatementReadSnapshot() || tc.mu.txn.CommitTimestampFixed {
+		return false
+	}
+	switch tErr := pErr.GetDetail().(type) {
+	case *roachpb.WriteTooOldError, *roachpb.ReadWithinUncertaintyIntervalError:
+		return true
+	case *roachpb.TransactionRetryError:
+		return tErr.Reason == roachpb.RETRY_WRITE_TOO_OLD
+	default:
+		return false
+	}
+}
+
+// handlePartialRetryableErrLocked is like handleRetryableErrLocked, but it
+// prepares the transaction for a retry of the current statement instead of a
+// restart at a new epoch. The transaction's read and write timestamps are
+// forwarded to the timestamp that the next epoch would have used, and all
+// epoch-scoped state, such as the transaction's writes and lock spans, is
+// retained. The client is expected to roll back to a savepoint established at
+// the start of the statement and then call PrepareForPartialRetry.
+func (tc *TxnCoordSender) handlePartialRetryableErrLocked(
+	ctx context.Context, pErr *roachpb.Error, nextEpochTxn *roachpb.Transaction,
+) *roachpb.TransactionRetryWithProtoRefreshError {
+	txn := tc.mu.txn.Clone()
+	txn.Refresh(nextEpochTxn.WriteTimestamp)
+	txn.UpgradePriority(nextEpochTxn.Priority)
+	tc.mu.txn.Update(txn)
+
+	retErr := roachpb.NewTransactionRetryWithProtoRefreshError(pErr.String(), txn.ID, *txn)
+	retErr.PartialRetry = true
+
+	// Move to a retryable error state, where all Send() calls fail until
+	// PrepareForPartialRetry is called.
+	tc.mu.txnState = txnRetryableError
+	tc.mu.storedRetryableErr = retErr
+	log.VEventf(ctx, 2, "preparing for partial retry at %s", txn.ReadTimestamp)
+	return retErr
+}
+
 // updateStateLocked updates the transaction state in both the success and error
 // cases. It also updates retryable errors with the updated transaction for use
 // by client restarts.
@@ -997,6 +1050,24 @@ func (tc *TxnCoordSender) SetUserPriority(pri roachpb.UserPriority) error {
 	return nil
 }
 
+// SetIsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) SetIsoLevel(isoLevel isolation.Level) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.active && isoLevel != tc.mu.txn.IsoLevel {
+		return errors.New("cannot change the isolation level of a running transaction")
+	}
+	tc.mu.txn.IsoLevel = isoLevel
+	return nil
+}
+
+// IsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) IsoLevel() isolation.Level {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mu.txn.IsoLevel
+}
+
 // SetDebugName is part of the client.TxnSender interface.
 func (tc *TxnCoordSender) SetDebugName(name string) {
 	tc.mu.Lock()
@@ -1111,6 +1182,11 @@ func (tc *TxnCoordSender) IsSerializablePushAndRefreshNotPossible() bool {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
 
+	if tc.mu.txn.IsoLevel.ToleratesWriteSkew() {
+		// Transactions that tolerate write skew can commit at a pushed timestamp
+		// without refreshing their reads.
+		return false
+	}
 	isTxnPushed := tc.mu.txn.WriteTimestamp != tc.mu.txn.ReadTimestamp
 	refreshAttemptNotPossible := tc.interceptorAlloc.txnSpanRefresher.refreshInvalid ||
 		tc.mu.txn.CommitTimestampFixed
@@ -1290,6 +1366,41 @@ func (tc *TxnCoordSender) Step(ctx context.Context) error {
 	return tc.interceptorAlloc.txnSeqNumAllocator.stepLocked(ctx)
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (tc *TxnCoordSender) StepReadTimestamp(ctx context.Context) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if !tc.mu.txn.IsoLevel.PerStatementReadSnapshot() || tc.mu.txn.CommitTimestampFixed {
+		return nil
+	}
+	if err := tc.maybeRejectClientLocked(ctx, nil /* ba */); err != nil {
+		return err.GoError()
+	}
+	// Move the read timestamp up to the present time. Values written before
+	// this point are visible to the new snapshot, so the uncertainty interval
+	// is reset to start at the new read timestamp.
+	now := tc.clock.Now()
+	tc.mu.txn.Refresh(now)
+	tc.mu.txn.GlobalUncertaintyLimit.Forward(now.Add(tc.clock.MaxOffset().Nanoseconds(), 0))
+	tc.mu.txn.ResetObservedTimestamps()
+	log.VEventf(ctx, 2, "stepped read timestamp to %s", tc.mu.txn.ReadTimestamp)
+	return nil
+}
+
+// PrepareForPartialRetry is part of the TxnSender interface.
+func (tc *TxnCoordSender) PrepareForPartialRetry(ctx context.Context) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.txnState != txnRetryableError || !tc.mu.storedRetryableErr.PartialRetry {
+		return errors.AssertionFailedf(
+			"cannot prepare for partial retry in state %s", tc.mu.txnState)
+	}
+	log.VEventf(ctx, 2, "clearing partial retryable error: %s", tc.mu.storedRetryableErr)
+	tc.mu.txnState = txnPending
+	tc.mu.storedRetryableErr = nil
+	return nil
+}
+
 // SetReadSeqNum is part of the TxnSender interface.
 func (tc *TxnCoordSender) SetReadSeqNum(seq enginepb.TxnSeq) error {
 	tc.mu.Lock()
@@ -166,7 +166,9 @@ func (sr *txnSpanRefresher) SendLocked(
 	if err := sr.assertRefreshSpansAtInvalidTimestamp(br.Txn.ReadTimestamp); err != nil {
 		return nil, roachpb.NewError(err)
 	}
-	if !sr.refreshInvalid {
+	// Transactions that tolerate write skew never refresh their reads, so
+	// there's no need to track refresh spans for them.
+	if !sr.refreshInvalid && !br.Txn.IsoLevel.ToleratesWriteSkew() {
 		if err := sr.appendRefreshSpans(ctx, ba, br); err != nil {
 			return nil, roachpb.NewError(err)
 		}
@@ -431,6 +433,12 @@ func (sr *txnSpanRefresher) maybeRefreshPreemptivelyLocked(
 		return ba, nil
 	}
 
+	// If the transaction tolerates write skew, it can commit with a write
+	// timestamp above its read timestamp, so no refresh is necessary.
+	if ba.Txn.IsoLevel.ToleratesWriteSkew() {
+		return ba, nil
+	}
+
 	// If true, tryRefreshTxnSpans will trivially succeed.
 	refreshFree := ba.CanForwardReadTimestamp
 
@@ -582,8 +590,13 @@ func (sr *txnSpanRefresher) appendRefreshSpans(
 // to this point. This requires that the transaction's timestamp has not leaked.
 // It also requires that the txnSpanRefresher has been configured to allow
 // auto-retries.
+//
+// Transactions that take a new read snapshot for each statement never forward
+// their read timestamp in the middle of a statement, because all of the
+// statement's reads must observe the same snapshot. Instead, the statement is
+// retried at a new read snapshot by the client.
 func (sr *txnSpanRefresher) canForwardReadTimestamp(txn *roachpb.Transaction) bool {
-	return sr.canAutoRetry && !txn.CommitTimestampFixed
+	return sr.canAutoRetry && !txn.CommitTimestampFixed && !txn.IsoLevel.PerStatementReadSnapshot()
 }
 
 // canForwardReadTimestampWithoutRefresh returns whether the transaction can
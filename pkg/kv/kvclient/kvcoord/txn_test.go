@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tscache"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -979,3 +980,100 @@ func TestRetrySerializableBumpsToNow(t *testing.T) {
 	}))
 	require.Greater(t, attempt, 1, "Transaction is expected to retry once")
 }
+
+// TestTxnReadCommittedCommitsAfterPush verifies that a Read Committed
+// transaction whose write timestamp is pushed can commit without refreshing its
+// reads, even when those reads are no longer valid at the pushed timestamp.
+func TestTxnReadCommittedCommitsAfterPush(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	txn := s.DB.NewTxn(ctx, "test txn")
+	require.NoError(t, txn.SetIsoLevel(isolation.ReadCommitted))
+
+	// Read a key and then have another transaction overwrite it. A Serializable
+	// transaction would be unable to refresh this read.
+	_, err := txn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, s.DB.Put(ctx, "a", "newer value"))
+
+	// Bump the timestamp cache of the key that the transaction writes to, so
+	// that its write timestamp is pushed.
+	_, err = s.DB.Get(ctx, "b")
+	require.NoError(t, err)
+	require.NoError(t, txn.Put(ctx, "b", "value"))
+	require.NotEqual(t, txn.ReadTimestamp(), txn.ProvisionalCommitTimestamp())
+	require.False(t, txn.IsSerializablePushAndRefreshNotPossible())
+
+	require.NoError(t, txn.Commit(ctx))
+	require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+}
+
+// TestTxnReadCommittedPartialRetry verifies that a write-write conflict in a
+// Read Committed transaction results in a retryable error that allows only
+// the statement that encountered it to be retried, and that the transaction
+// can be restarted from the beginning instead if the client chooses to.
+func TestTxnReadCommittedPartialRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	testutils.RunTrueAndFalse(t, "partial-retry", func(t *testing.T, partialRetry bool) {
+		s := createTestDB(t)
+		defer s.Stop()
+
+		txn := s.DB.NewTxn(ctx, "test txn")
+		require.NoError(t, txn.SetIsoLevel(isolation.ReadCommitted))
+		require.NoError(t, txn.StepReadTimestamp(ctx))
+
+		// The first statement writes a key.
+		require.NoError(t, txn.Put(ctx, "a", "value"))
+
+		// The second statement runs into a write by a concurrent transaction
+		// that committed after the statement's read snapshot.
+		require.NoError(t, txn.StepReadTimestamp(ctx))
+		sp, err := txn.CreateSavepoint(ctx)
+		require.NoError(t, err)
+		require.NoError(t, s.DB.Put(ctx, "b", "newer value"))
+		err = txn.Put(ctx, "b", "value")
+		var retryErr *roachpb.TransactionRetryWithProtoRefreshError
+		require.True(t, errors.As(err, &retryErr))
+		require.True(t, retryErr.PartialRetry)
+		require.Regexp(t, "WriteTooOld", err)
+		require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+
+		if partialRetry {
+			// Retry the second statement at a new read snapshot.
+			require.NoError(t, txn.RollbackToSavepoint(ctx, sp))
+			require.NoError(t, txn.PrepareForPartialRetry(ctx))
+			require.NoError(t, txn.StepReadTimestamp(ctx))
+			require.NoError(t, txn.Put(ctx, "b", "value"))
+			require.NoError(t, txn.Commit(ctx))
+			require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+
+			// Both statements' writes are committed.
+			for _, key := range []string{"a", "b"} {
+				gr, err := s.DB.Get(ctx, key)
+				require.NoError(t, err)
+				require.Equal(t, []byte("value"), gr.ValueBytes())
+			}
+		} else {
+			// Restarting the whole transaction bumps its epoch, discarding the
+			// first statement's write.
+			txn.PrepareForRetry(ctx)
+			require.Equal(t, enginepb.TxnEpoch(1), txn.Epoch())
+			require.NoError(t, txn.Put(ctx, "b", "value"))
+			require.NoError(t, txn.Commit(ctx))
+
+			gr, err := s.DB.Get(ctx, "a")
+			require.NoError(t, err)
+			require.False(t, gr.Exists())
+			gr, err = s.DB.Get(ctx, "b")
+			require.NoError(t, err)
+			require.Equal(t, []byte("value"), gr.ValueBytes())
+		}
+	})
+}
@@ -221,6 +221,9 @@ type Streamer struct {
 	maxKeysPerRow int32
 	budget        *budget
 	keyLocking    lock.Strength
+	// keyLockingReplicated indicates whether the locks acquired by the
+	// requests are replicated.
+	keyLockingReplicated bool
 
 	streamerStatistics
 
@@ -361,15 +364,17 @@ func NewStreamer(
 	acc *mon.BoundAccount,
 	batchRequestsIssued *int64,
 	keyLocking lock.Strength,
+	keyLockingReplicated bool,
 ) *Streamer {
 	if txn.Type() != kv.LeafTxn {
 		panic(errors.AssertionFailedf("RootTxn is given to the Streamer"))
 	}
 	s := &Streamer{
-		distSender: distSender,
-		stopper:    stopper,
-		budget:     newBudget(acc, limitBytes),
-		keyLocking: keyLocking,
+		distSender:           distSender,
+		stopper:              stopper,
+		budget:               newBudget(acc, limitBytes),
+		keyLocking:           keyLocking,
+		keyLockingReplicated: keyLockingReplicated,
 	}
 	if batchRequestsIssued == nil {
 		batchRequestsIssued = new(int64)
@@ -1594,6 +1599,7 @@ func buildResumeSingleRangeBatch(
 			gets = gets[1:]
 			newGet.req.SetSpan(*get.ResumeSpan)
 			newGet.req.KeyLocking = s.keyLocking
+			newGet.req.KeyLockingReplicated = s.keyLockingReplicated
 			newGet.union.Get = &newGet.req
 			resumeReq.reqs[resumeReqIdx].Value = &newGet.union
 			resumeReq.positions = append(resumeReq.positions, position)
@@ -1617,6 +1623,7 @@ func buildResumeSingleRangeBatch(
 			newScan.req.SetSpan(*scan.ResumeSpan)
 			newScan.req.ScanFormat = roachpb.BATCH_RESPONSE
 			newScan.req.KeyLocking = s.keyLocking
+			newScan.req.KeyLockingReplicated = s.keyLockingReplicated
 			newScan.union.Scan = &newScan.req
 			resumeReq.reqs[resumeReqIdx].Value = &newScan.union
 			resumeReq.positions = append(resumeReq.positions, position)
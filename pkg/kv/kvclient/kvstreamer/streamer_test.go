@@ -48,6 +48,7 @@ func getStreamer(
 		acc,
 		nil, /* batchRequestsIssued */
 		lock.None,
+		false, /* keyLockingReplicated */
 	)
 }
 
@@ -100,6 +101,7 @@ func TestStreamerLimitations(t *testing.T) {
 				nil,           /* acc */
 				nil,           /* batchRequestsIssued */
 				lock.None,
+				false, /* keyLockingReplicated */
 			)
 		})
 	})
@@ -483,8 +483,10 @@ func IsEndTxnTriggeringRetryError(
 		isTxnPushed := txn.WriteTimestamp != readTimestamp
 
 		// Return a transaction retry error if the commit timestamp isn't equal to
-		// the txn timestamp.
-		if isTxnPushed {
+		// the txn timestamp. Transactions whose isolation level tolerates write
+		// skew are allowed to commit above their read timestamp without
+		// refreshing their reads.
+		if isTxnPushed && !txn.IsoLevel.ToleratesWriteSkew() {
 			retry, reason = true, roachpb.RETRY_SERIALIZABLE
 		}
 	}
@@ -558,6 +560,12 @@ func resolveLocalLocks(
 				if err != nil {
 					return err
 				}
+				// The transaction may also hold a replicated Shared lock on the key.
+				if released, err := resolveSharedLock(ctx, evalCtx, readWriter, update); err != nil {
+					return err
+				} else if released {
+					ok = true
+				}
 				if ok {
 					resolveAllowance--
 				}
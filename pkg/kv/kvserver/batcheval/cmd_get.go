@@ -21,12 +21,14 @@ import (
 )
 
 func init() {
-	RegisterReadOnlyCommand(roachpb.Get, DefaultDeclareIsolatedKeys, Get)
+	RegisterReadWriteCommand(roachpb.Get, DefaultDeclareIsolatedKeys, Get)
 }
 
-// Get returns the value for a specified key.
+// Get returns the value for a specified key. If the request specifies a key
+// locking strength, a lock is acquired on the key, if it exists. Replicated
+// locks are written to the provided storage.ReadWriter.
 func Get(
-	ctx context.Context, reader storage.Reader, cArgs CommandArgs, resp roachpb.Response,
+	ctx context.Context, readWriter storage.ReadWriter, cArgs CommandArgs, resp roachpb.Response,
 ) (result.Result, error) {
 	args := cArgs.Args.(*roachpb.GetRequest)
 	h := cArgs.Header
@@ -52,7 +54,7 @@ func Get(
 	var val *roachpb.Value
 	var intent *roachpb.Intent
 	var err error
-	val, intent, err = storage.MVCCGet(ctx, reader, args.Key, h.Timestamp, storage.MVCCGetOptions{
+	val, intent, err = storage.MVCCGet(ctx, readWriter, args.Key, h.Timestamp, storage.MVCCGetOptions{
 		Inconsistent:          h.ReadConsistency != roachpb.CONSISTENT,
 		SkipLocked:            h.WaitPolicy == lock.WaitPolicy_SkipLocked,
 		Txn:                   h.Txn,
@@ -90,7 +92,7 @@ func Get(
 		// CollectIntentRows as well so that we're guaranteed to use the same
 		// cached iterator and observe a consistent snapshot of the engine.
 		const usePrefixIter = true
-		intentVals, err = CollectIntentRows(ctx, reader, usePrefixIter, intents)
+		intentVals, err = CollectIntentRows(ctx, readWriter, usePrefixIter, intents)
 		if err == nil {
 			switch len(intentVals) {
 			case 0:
@@ -104,7 +106,14 @@ func Get(
 
 	var res result.Result
 	if args.KeyLocking != lock.None && h.Txn != nil && val != nil {
-		acq := roachpb.MakeLockAcquisition(h.Txn, args.Key, lock.Unreplicated)
+		dur := roachpb.LockingDurability(args)
+		if err := checkLockDurabilitySupported(ctx, cArgs.EvalCtx, dur); err != nil {
+			return result.Result{}, err
+		}
+		acq, err := acquireLockOnKey(ctx, readWriter, h.Txn, args.KeyLocking, dur, args.Key)
+		if err != nil {
+			return result.Result{}, err
+		}
 		res.Local.AcquiredLocks = []roachpb.LockAcquisition{acq}
 	}
 	res.Local.EncounteredIntents = intents
@@ -120,6 +120,11 @@ func computeMinIntentTimestamp(
 		if err != nil {
 			return hlc.Timestamp{}, nil, err
 		}
+		if !engineKey.IsExclusiveLockTableKey() {
+			// Replicated locks of non-Exclusive strength are not intents and do
+			// not hold back the resolved timestamp.
+			continue
+		}
 		lockedKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
 		if err != nil {
 			return hlc.Timestamp{}, nil, errors.Wrapf(err, "decoding LockTable key: %v", lockedKey)
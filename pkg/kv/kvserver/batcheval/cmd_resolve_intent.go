@@ -97,6 +97,12 @@ func ResolveIntent(
 	if err != nil {
 		return result.Result{}, err
 	}
+	// The transaction may also hold a replicated Shared lock on the key.
+	if released, err := resolveSharedLock(ctx, cArgs.EvalCtx, readWriter, update); err != nil {
+		return result.Result{}, err
+	} else if released {
+		ok = true
+	}
 
 	var res result.Result
 	res.Local.ResolvedLocks = []roachpb.LockUpdate{update}
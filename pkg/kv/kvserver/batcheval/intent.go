@@ -13,6 +13,7 @@ package batcheval
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -103,11 +104,17 @@ func readProvisionalVal(
 
 }
 
-// acquireUnreplicatedLocksOnKeys adds an unreplicated lock acquisition by the
-// transaction to the provided result.Result for each key in the scan result.
-func acquireUnreplicatedLocksOnKeys(
+// acquireLocksOnKeys acquires locks of the specified strength and durability
+// by the transaction on each key in the scan result, adding a lock acquisition
+// to the provided result.Result for each of them. Replicated locks are also
+// written to the replicated lock table keyspace.
+func acquireLocksOnKeys(
+	ctx context.Context,
+	readWriter storage.ReadWriter,
 	res *result.Result,
 	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
 	scanFmt roachpb.ScanFormat,
 	scanRes *storage.MVCCScanResult,
 ) error {
@@ -116,13 +123,21 @@ func acquireUnreplicatedLocksOnKeys(
 	case roachpb.BATCH_RESPONSE:
 		var i int
 		return storage.MVCCScanDecodeKeyValues(scanRes.KVData, func(key storage.MVCCKey, _ []byte) error {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(key.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, readWriter, txn, str, dur, copyKey(key.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 			i++
 			return nil
 		})
 	case roachpb.KEY_VALUES:
 		for i, row := range scanRes.KVs {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(row.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, readWriter, txn, str, dur, copyKey(row.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 		}
 		return nil
 	default:
@@ -130,8 +145,58 @@ func acquireUnreplicatedLocksOnKeys(
 	}
 }
 
+// acquireLockOnKey acquires a lock of the specified strength and durability by
+// the transaction on the key and returns the corresponding lock acquisition.
+// Unreplicated locks are only tracked by the in-memory lock table, so nothing
+// is written for them here.
+func acquireLockOnKey(
+	ctx context.Context,
+	readWriter storage.ReadWriter,
+	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
+	key roachpb.Key,
+) (roachpb.LockAcquisition, error) {
+	if dur == lock.Replicated {
+		if err := storage.MVCCAcquireLock(ctx, readWriter, txn, str, key); err != nil {
+			return roachpb.LockAcquisition{}, err
+		}
+	}
+	return roachpb.MakeLockAcquisition(txn, key, dur, str), nil
+}
+
+// checkLockDurabilitySupported returns an error if locks with the specified
+// durability cannot be acquired yet. Replicated locks that are not intents
+// are only acquired once the V23_1SharedLocks version is active, since older
+// nodes cannot decode their lock table keys.
+func checkLockDurabilitySupported(
+	ctx context.Context, evalCtx EvalContext, dur lock.Durability,
+) error {
+	if dur == lock.Replicated &&
+		!evalCtx.ClusterSettings().Version.IsActive(ctx, clusterversion.V23_1SharedLocks) {
+		return errors.AssertionFailedf(
+			"replicated locks cannot be acquired before version %s is active",
+			clusterversion.ByKey(clusterversion.V23_1SharedLocks))
+	}
+	return nil
+}
+
+// resolveSharedLock releases the replicated Shared lock held by the update's
+// transaction on the update's key, if one exists and the update indicates
+// that it is no longer held. Returns whether a lock was released. Replicated
+// Shared locks cannot exist before the V23_1SharedLocks version is active, so
+// resolving intents does not pay for the lookup until then.
+func resolveSharedLock(
+	ctx context.Context, evalCtx EvalContext, readWriter storage.ReadWriter, update roachpb.LockUpdate,
+) (bool, error) {
+	if !evalCtx.ClusterSettings().Version.IsActive(ctx, clusterversion.V23_1SharedLocks) {
+		return false, nil
+	}
+	return storage.MVCCResolveSharedLock(ctx, readWriter, update)
+}
+
 // copyKey copies the provided roachpb.Key into a new byte slice, returning the
-// copy. It is used in acquireUnreplicatedLocksOnKeys for two reasons:
+// copy. It is used in acquireLocksOnKeys for two reasons:
 //  1. the keys in an MVCCScanResult, regardless of the scan format used, point
 //     to a small number of large, contiguous byte slices. These "MVCCScan
 //     batches" contain keys and their associated values in the same backing
@@ -512,7 +512,7 @@ func (m *managerImpl) HandleTransactionPushError(
 
 // OnLockAcquired implements the LockManager interface.
 func (m *managerImpl) OnLockAcquired(ctx context.Context, acq *roachpb.LockAcquisition) {
-	if err := m.lt.AcquireLock(&acq.Txn, acq.Key, lock.Exclusive, acq.Durability); err != nil {
+	if err := m.lt.AcquireLock(&acq.Txn, acq.Key, acq.Strength, acq.Durability); err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
 }
@@ -630,6 +630,29 @@ func (r *Request) txnMeta() *enginepb.TxnMeta {
 	return &r.Txn.TxnMeta
 }
 
+// lockStrength returns the strength of the locks that the request's locking
+// requests acquire. Requests that only acquire Shared locks are compatible
+// with each other, so the request is considered to acquire Shared locks only
+// if all of its locking requests do. Otherwise, it is conservatively
+// considered to acquire Exclusive locks.
+func (r *Request) lockStrength() lock.Strength {
+	str := lock.None
+	for _, ru := range r.Requests {
+		args := ru.GetInner()
+		if !roachpb.IsLocking(args) {
+			continue
+		}
+		if s := roachpb.LockingStrength(args); s != lock.Shared {
+			return lock.Exclusive
+		}
+		str = lock.Shared
+	}
+	if str == lock.None {
+		return lock.Exclusive
+	}
+	return str
+}
+
 func (r *Request) isSingle(m roachpb.Method) bool {
 	if len(r.Requests) != 1 {
 		return false
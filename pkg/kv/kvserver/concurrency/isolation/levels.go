@@ -0,0 +1,40 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package isolation provides type definitions for isolation level concepts
+// used by concurrency control in the key-value layer.
+package isolation
+
+// WeakerThan returns true if the receiver's strength is weaker than the
+// parameter's strength. It returns false if the two isolation levels are
+// equivalent or if the parameter's strength is weaker than the receiver's.
+func (l Level) WeakerThan(l2 Level) bool {
+	// Internally, we exploit the fact that the enum's value increases as the
+	// isolation level gets weaker.
+	return l > l2
+}
+
+// ToleratesWriteSkew returns whether the isolation level permits write skew.
+// Transactions that tolerate write skew may commit at a timestamp above their
+// read timestamp without refreshing their reads.
+func (l Level) ToleratesWriteSkew() bool {
+	return l == ReadCommitted
+}
+
+// PerStatementReadSnapshot returns whether the isolation level establishes a
+// new read snapshot for each SQL statement. Transactions at such levels can
+// retry individual statements on write-write conflicts instead of restarting
+// from the beginning.
+func (l Level) PerStatementReadSnapshot() bool {
+	return l == ReadCommitted
+}
+
+// SafeValue implements redact.SafeValue.
+func (Level) SafeValue() {}
@@ -364,6 +364,7 @@ type lockTableGuardImpl struct {
 	// Information about this request.
 	txn                *enginepb.TxnMeta
 	ts                 hlc.Timestamp
+	str                lock.Strength
 	spans              *spanset.SpanSet
 	waitPolicy         lock.WaitPolicy
 	maxWaitQueueLength int
@@ -610,6 +611,18 @@ func (g *lockTableGuardImpl) IsKeyLockedByConflictingTxn(
 		return true, nil
 	}
 	// Key locked.
+	if l.holder.strength == lock.Shared {
+		// Shared locks only conflict with Exclusive (or stronger) locking.
+		if strength <= lock.Shared {
+			return false, nil
+		}
+		h := l.conflictingSharedHolder(g)
+		if h == nil {
+			// Only locked by this txn.
+			return false, nil
+		}
+		return true, h.txn()
+	}
 	txn, ts := l.getLockHolder()
 	if txn == nil {
 		panic("non-empty lockState with nil lock holder and nil reservation")
@@ -799,6 +812,108 @@ func (lh *lockHolderInfo) isEmpty() bool {
 	return lh.txn == nil && lh.seqs == nil && lh.ts.IsEmpty()
 }
 
+// Acquires the lock for txn at timestamp ts, which is a noop if the lock is
+// already held at txn's sequence number.
+func (lh *lockHolderInfo) acquire(txn *enginepb.TxnMeta, ts hlc.Timestamp) {
+	seqs := lh.seqs
+	if lh.txn != nil && lh.txn.Epoch < txn.Epoch {
+		// Clear the sequences for the older epoch.
+		seqs = seqs[:0]
+	}
+	if len(seqs) > 0 && seqs[len(seqs)-1] >= txn.Sequence {
+		// Idempotent lock acquisition. In this case, we simply ignore the lock
+		// acquisition as long as it corresponds to an existing sequence number.
+		// If the sequence number is not being tracked yet, insert it into the
+		// sequence history. The validity of such a lock re-acquisition should
+		// have already been determined at the MVCC level.
+		if i := sort.Search(len(seqs), func(i int) bool {
+			return seqs[i] >= txn.Sequence
+		}); i == len(seqs) {
+			panic("lockTable bug - search value <= last element")
+		} else if seqs[i] != txn.Sequence {
+			seqs = append(seqs, 0)
+			copy(seqs[i+1:], seqs[i:])
+			seqs[i] = txn.Sequence
+			lh.seqs = seqs
+		}
+		return
+	}
+	lh.txn = txn
+	// Forward the lock's timestamp instead of assigning to it blindly.
+	// While lock acquisition uses monotonically increasing timestamps
+	// from the perspective of the transaction's coordinator, this does
+	// not guarantee that a lock will never be acquired at a higher
+	// epoch and/or sequence number but with a lower timestamp when in
+	// the presence of transaction pushes. Consider the following
+	// sequence of events:
+	//
+	//  - txn A acquires lock at sequence 1, ts 10
+	//  - txn B pushes txn A to ts 20
+	//  - txn B updates lock to ts 20
+	//  - txn A's coordinator does not immediately learn of the push
+	//  - txn A re-acquires lock at sequence 2, ts 15
+	//
+	// A lock's timestamp at a given durability level is not allowed to
+	// regress, so by forwarding its timestamp during the second acquisition
+	// instead if assigning to it blindly, it remains at 20.
+	//
+	// However, a lock's timestamp as reported by getLockHolder can regress
+	// if it is acquired at a lower timestamp and a different durability
+	// than it was previously held with. This is necessary to support
+	// because the hard constraint which we must uphold here that the
+	// lockHolderInfo for a replicated lock cannot diverge from the
+	// replicated state machine in such a way that its timestamp in the
+	// lockTable exceeds that in the replicated keyspace. If this invariant
+	// were to be violated, we'd risk infinite lock-discovery loops for
+	// requests that conflict with the lock as is written in the replicated
+	// state machine but not as is reflected in the lockTable.
+	//
+	// Lock timestamp regressions are safe from the perspective of other
+	// transactions because the request which re-acquired the lock at the
+	// lower timestamp must have been holding a write latch at or below the
+	// new lock's timestamp. This means that no conflicting requests could
+	// be evaluating concurrently. Instead, all will need to re-scan the
+	// lockTable once they acquire latches and will notice the reduced
+	// timestamp at that point, which may cause them to conflict with the
+	// lock even if they had not conflicted before. In a sense, it is no
+	// different than the first time a lock is added to the lockTable.
+	lh.ts.Forward(ts)
+	lh.seqs = append(seqs, txn.Sequence)
+}
+
+// Information about a single transaction holding a lock, for each durability
+// level.
+type lockHolders [lock.MaxDurability + 1]lockHolderInfo
+
+// Returns the TxnMeta of the transaction holding the lock and the timestamp
+// at which it is held.
+func (lh *lockHolders) get() (*enginepb.TxnMeta, hlc.Timestamp) {
+	// If the lock is held as both replicated and unreplicated we want to
+	// provide the lower of the two timestamps, since the lower timestamp
+	// contends with more transactions. Else we provide whichever one it is held
+	// at.
+
+	// Start with the assumption that it is held as replicated.
+	index := lock.Replicated
+	// Condition under which we prefer the unreplicated holder.
+	if lh[index].txn == nil || (lh[lock.Unreplicated].txn != nil &&
+		// If we are evaluating the following clause we are sure that it is held
+		// as both replicated and unreplicated.
+		lh[lock.Unreplicated].ts.Less(lh[lock.Replicated].ts)) {
+		index = lock.Unreplicated
+	}
+	return lh[index].txn, lh[index].ts
+}
+
+// Returns the TxnMeta of the transaction holding the lock, preferring the
+// unreplicated holder. Returns nil if the lock is not held.
+func (lh *lockHolders) txn() *enginepb.TxnMeta {
+	if lh[lock.Unreplicated].txn != nil {
+		return lh[lock.Unreplicated].txn
+	}
+	return lh[lock.Replicated].txn
+}
+
 // Per lock state in lockTableImpl.
 //
 // NOTE: we can't easily pool lockState objects without some form of reference
@@ -821,6 +936,9 @@ type lockState struct {
 	// - both holder.locked and waitQ.reservation != nil cannot be true.
 	// - if holder.locked and multiple holderInfos have txn != nil: all the
 	//   txns must have the same txn.ID.
+	// - holder.sharedHolders is empty unless holder.locked and
+	//   holder.strength == lock.Shared. No two holders of a Shared lock
+	//   belong to the same txn.
 	// - !holder.locked => waitingReaders.Len() == 0. That is, readers wait
 	//   only if the lock is held. They do not wait for a reservation.
 	// - If reservation != nil, that request is not in queuedWriters.
@@ -831,8 +949,14 @@ type lockState struct {
 	// replicated and unreplicated mode at different stages.
 	holder struct {
 		locked bool
-		// LockStrength is always Exclusive
-		holder [lock.MaxDurability + 1]lockHolderInfo
+		// The strength with which the lock is held, either Exclusive or Shared.
+		// Set iff locked.
+		strength lock.Strength
+		// The holder of the lock. When the lock is held with Shared strength,
+		// this is the first of its holders and the remaining holders, if any,
+		// are in sharedHolders.
+		holder        lockHolders
+		sharedHolders []lockHolders
 
 		// The start time of the lockholder being marked as held in the lock table.
 		// NB: In the case of a replicated lock that is held by a transaction, if
@@ -1063,11 +1187,17 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		sb.Printf("txn: %v, ts: %v, seq: %v\n",
 			redact.Safe(txn.ID), redact.Safe(ts), redact.Safe(txn.Sequence))
 	}
-	writeHolderInfo := func(sb *redact.StringBuilder, txn *enginepb.TxnMeta, ts hlc.Timestamp) {
-		sb.Printf("  holder: txn: %v, ts: %v, info: ", redact.Safe(txn.ID), redact.Safe(ts))
+	writeHolderInfo := func(sb *redact.StringBuilder, holders *lockHolders) {
+		txn, ts := holders.get()
+		if l.holder.strength == lock.Shared {
+			sb.SafeString("  shared ")
+		} else {
+			sb.SafeString("  ")
+		}
+		sb.Printf("holder: txn: %v, ts: %v, info: ", redact.Safe(txn.ID), redact.Safe(ts))
 		first := true
-		for i := range l.holder.holder {
-			h := &l.holder.holder[i]
+		for i := range holders {
+			h := &holders[i]
 			if h.txn == nil {
 				continue
 			}
@@ -1101,12 +1231,14 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		}
 		sb.SafeString("\n")
 	}
-	txn, ts := l.getLockHolder()
-	if txn == nil {
+	if !l.holder.locked {
 		sb.Printf("  res: req: %d, ", l.reservation.seqNum)
 		writeResInfo(sb, l.reservation.txn, l.reservation.ts)
 	} else {
-		writeHolderInfo(sb, txn, ts)
+		writeHolderInfo(sb, &l.holder.holder)
+		for i := range l.holder.sharedHolders {
+			writeHolderInfo(sb, &l.holder.sharedHolders[i])
+		}
 	}
 	// TODO(sumeer): Add an optional `description string` field to Request and
 	// lockTableGuardImpl that tests can set to avoid relying on the seqNum to
@@ -1193,7 +1325,7 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		lockWaiters = append(lockWaiters, lock.Waiter{
 			WaitingTxn:   l.reservation.txn,
 			ActiveWaiter: true,
-			Strength:     lock.Exclusive,
+			Strength:     l.reservation.str,
 			WaitDuration: now.Sub(l.reservation.mu.curLockWaitStart),
 		})
 		l.reservation.mu.Unlock()
@@ -1220,7 +1352,7 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		lockWaiters = append(lockWaiters, lock.Waiter{
 			WaitingTxn:   writerGuard.txn,
 			ActiveWaiter: qg.active,
-			Strength:     lock.Exclusive,
+			Strength:     writerGuard.str,
 			WaitDuration: now.Sub(writerGuard.mu.curLockWaitStart),
 		})
 		writerGuard.mu.Unlock()
@@ -1321,6 +1453,11 @@ func (l *lockState) informActiveWaiters() {
 		}
 		g := qg.guard
 		state := waitForState
+		if l.holder.locked && l.holder.strength == lock.Shared {
+			if h := l.conflictingSharedHolder(g); h != nil {
+				state.txn = h.txn()
+			}
+		}
 		if g.isSameTxnAsReservation(state) {
 			state.kind = waitSelf
 		} else {
@@ -1344,12 +1481,27 @@ func (l *lockState) informActiveWaiters() {
 // part of the specified transaction.
 // REQUIRES: l.mu is locked.
 func (l *lockState) releaseWritersFromTxn(txn *enginepb.TxnMeta) {
+	l.releaseWriters(func(g *lockTableGuardImpl) bool { return g.isSameTxn(txn) })
+}
+
+// releaseSharedWaiters removes all waiting writers for the lockState that
+// acquire Shared locks, since they are compatible with the Shared lock that
+// is now held.
+// REQUIRES: l.mu is locked.
+func (l *lockState) releaseSharedWaiters() {
+	l.releaseWriters(func(g *lockTableGuardImpl) bool { return g.str == lock.Shared })
+}
+
+// releaseWriters removes all waiting writers for the lockState that satisfy
+// the given predicate.
+// REQUIRES: l.mu is locked.
+func (l *lockState) releaseWriters(release func(g *lockTableGuardImpl) bool) {
 	for e := l.queuedWriters.Front(); e != nil; {
 		qg := e.Value.(*queuedGuard)
 		curr := e
 		e = e.Next()
 		g := qg.guard
-		if g.isSameTxn(txn) {
+		if release(g) {
 			if qg.active {
 				if g == l.distinguishedWaiter {
 					l.distinguishedWaiter = nil
@@ -1402,6 +1554,9 @@ func (l *lockState) isEmptyLock() bool {
 				panic("lockState with !locked but non-zero lockHolderInfo")
 			}
 		}
+		if len(l.holder.sharedHolders) > 0 {
+			panic("lockState with !locked but non-empty sharedHolders")
+		}
 		if l.waitingReaders.Len() > 0 || l.queuedWriters.Len() > 0 {
 			panic("lockState with waiters but no holder or reservation")
 		}
@@ -1455,17 +1610,108 @@ func (l *lockState) totalAndMaxWaitDuration(now time.Time) (time.Duration, time.
 // REQUIRES: l.mu is locked.
 func (l *lockState) isLockedBy(id uuid.UUID) bool {
 	if l.holder.locked {
-		var holderID uuid.UUID
-		if l.holder.holder[lock.Unreplicated].txn != nil {
-			holderID = l.holder.holder[lock.Unreplicated].txn.ID
-		} else {
-			holderID = l.holder.holder[lock.Replicated].txn.ID
+		if l.holder.strength == lock.Shared {
+			return l.sharedHolder(id) != nil
 		}
-		return id == holderID
+		return id == l.holder.holder.txn().ID
 	}
 	return false
 }
 
+// Returns the holder of the Shared lock with the given transaction id, or nil
+// if the transaction does not hold the lock.
+// REQUIRES: l.mu is locked and the lock is held with Shared strength.
+func (l *lockState) sharedHolder(id uuid.UUID) *lockHolders {
+	if l.holder.holder.txn().ID == id {
+		return &l.holder.holder
+	}
+	for i := range l.holder.sharedHolders {
+		if l.holder.sharedHolders[i].txn().ID == id {
+			return &l.holder.sharedHolders[i]
+		}
+	}
+	return nil
+}
+
+// Removes the given holder of the Shared lock. The lock is no longer held if
+// that was its last holder.
+// REQUIRES: l.mu is locked and the lock is held with Shared strength.
+func (l *lockState) removeSharedHolder(h *lockHolders) {
+	if h == &l.holder.holder {
+		if len(l.holder.sharedHolders) == 0 {
+			l.clearLockHolder()
+			return
+		}
+		l.holder.holder = l.holder.sharedHolders[0]
+		l.holder.sharedHolders = l.holder.sharedHolders[1:]
+		return
+	}
+	for i := range l.holder.sharedHolders {
+		if h == &l.holder.sharedHolders[i] {
+			l.holder.sharedHolders = append(
+				l.holder.sharedHolders[:i], l.holder.sharedHolders[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns the first holder of the Shared lock that conflicts with a request
+// from g's transaction that acquires an Exclusive lock, or nil if g's
+// transaction is the only holder.
+// REQUIRES: l.mu is locked and the lock is held with Shared strength.
+func (l *lockState) conflictingSharedHolder(g *lockTableGuardImpl) *lockHolders {
+	if !g.isSameTxn(l.holder.holder.txn()) {
+		return &l.holder.holder
+	}
+	if len(l.holder.sharedHolders) > 0 {
+		return &l.holder.sharedHolders[0]
+	}
+	return nil
+}
+
+// Like conflictingSharedHolder, but uses the finalizedTxnCache to skip the
+// holders of the Shared lock whose transactions are already finalized.
+// Holders that only hold the lock unreplicated are removed immediately, which
+// may cause the lock to no longer be held. Replicated locks of finalized
+// transactions are added to g.toResolve if g does not need to wait for
+// another holder.
+// REQUIRES: l.mu is locked and the lock is held with Shared strength.
+func (l *lockState) conflictingSharedHolderTxn(g *lockTableGuardImpl) *enginepb.TxnMeta {
+	var conflictingTxn *enginepb.TxnMeta
+	var finalizedTxns []*roachpb.Transaction
+	holderTxns := make([]*enginepb.TxnMeta, 0, 1+len(l.holder.sharedHolders))
+	holderTxns = append(holderTxns, l.holder.holder.txn())
+	for i := range l.holder.sharedHolders {
+		holderTxns = append(holderTxns, l.holder.sharedHolders[i].txn())
+	}
+	for _, holderTxn := range holderTxns {
+		if g.isSameTxn(holderTxn) {
+			continue
+		}
+		finalizedTxn, ok := g.lt.finalizedTxnCache.get(holderTxn.ID)
+		if !ok {
+			if conflictingTxn == nil {
+				conflictingTxn = holderTxn
+			}
+			continue
+		}
+		h := l.sharedHolder(holderTxn.ID)
+		if h[lock.Replicated].txn == nil {
+			// Only held unreplicated. Release immediately.
+			l.removeSharedHolder(h)
+		} else {
+			finalizedTxns = append(finalizedTxns, finalizedTxn)
+		}
+	}
+	if conflictingTxn == nil {
+		for _, txn := range finalizedTxns {
+			g.toResolve = append(
+				g.toResolve, roachpb.MakeLockUpdate(txn, roachpb.Span{Key: l.key}))
+		}
+	}
+	return conflictingTxn
+}
+
 // Returns information about the current lock holder if the lock is held, else
 // returns nil.
 // REQUIRES: l.mu is locked.
@@ -1473,32 +1719,19 @@ func (l *lockState) getLockHolder() (*enginepb.TxnMeta, hlc.Timestamp) {
 	if !l.holder.locked {
 		return nil, hlc.Timestamp{}
 	}
-
-	// If the lock is held as both replicated and unreplicated we want to
-	// provide the lower of the two timestamps, since the lower timestamp
-	// contends with more transactions. Else we provide whichever one it is held
-	// at.
-
-	// Start with the assumption that it is held as replicated.
-	index := lock.Replicated
-	// Condition under which we prefer the unreplicated holder.
-	if l.holder.holder[index].txn == nil || (l.holder.holder[lock.Unreplicated].txn != nil &&
-		// If we are evaluating the following clause we are sure that it is held
-		// as both replicated and unreplicated.
-		l.holder.holder[lock.Unreplicated].ts.Less(l.holder.holder[lock.Replicated].ts)) {
-		index = lock.Unreplicated
-	}
-	return l.holder.holder[index].txn, l.holder.holder[index].ts
+	return l.holder.holder.get()
 }
 
 // Removes the current lock holder from the lock.
 // REQUIRES: l.mu is locked.
 func (l *lockState) clearLockHolder() {
 	l.holder.locked = false
+	l.holder.strength = lock.None
 	l.holder.startTime = time.Time{}
 	for i := range l.holder.holder {
 		l.holder.holder[i] = lockHolderInfo{}
 	}
+	l.holder.sharedHolders = nil
 }
 
 // Decides whether the request g with access sa should actively wait at this
@@ -1573,13 +1806,33 @@ func (l *lockState) tryActiveWait(
 
 	// Lock is not empty.
 	lockHolderTxn, lockHolderTS := l.getLockHolder()
-	if lockHolderTxn != nil && g.isSameTxn(lockHolderTxn) {
+	if l.holder.locked && l.holder.strength == lock.Shared {
+		// Shared locks only conflict with requests that acquire Exclusive locks.
+		//
+		// NB: requests that acquire Shared locks do not queue behind waiting
+		// writers, so a steady stream of them can delay writers.
+		if sa == spanset.SpanReadOnly || g.str == lock.Shared {
+			return false, false
+		}
+		lockHolderTxn = l.conflictingSharedHolderTxn(g)
+		if !l.holder.locked {
+			if l.lockIsFree() {
+				// Empty lock.
+				return false, true
+			}
+			// There is a reservation holder, which may be the caller itself,
+			// so fall through to the processing below.
+		} else if lockHolderTxn == nil {
+			// Only held by this txn or by finalized txns.
+			return false, false
+		}
+	} else if lockHolderTxn != nil && g.isSameTxn(lockHolderTxn) {
 		// Already locked by this txn.
 		return false, false
 	}
 
 	var replicatedLockFinalizedTxn *roachpb.Transaction
-	if lockHolderTxn != nil {
+	if lockHolderTxn != nil && l.holder.strength == lock.Exclusive {
 		finalizedTxn, ok := g.lt.finalizedTxnCache.get(lockHolderTxn.ID)
 		if ok {
 			if l.holder.holder[lock.Replicated].txn == nil {
@@ -1801,6 +2054,10 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, sa spanset.SpanA
 		// will retry as pessimistic.
 		return true
 	}
+	if l.holder.strength == lock.Shared {
+		// Shared locks only conflict with requests that acquire Exclusive locks.
+		return sa == spanset.SpanReadOnly || g.str == lock.Shared || l.conflictingSharedHolder(g) == nil
+	}
 	if g.isSameTxn(lockHolderTxn) {
 		// Already locked by this txn.
 		return true
@@ -1821,7 +2078,7 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, sa spanset.SpanA
 // that is acquiring the lock.
 // Acquires l.mu.
 func (l *lockState) acquireLock(
-	_ lock.Strength,
+	strength lock.Strength,
 	durability lock.Durability,
 	txn *enginepb.TxnMeta,
 	ts hlc.Timestamp,
@@ -1829,77 +2086,29 @@ func (l *lockState) acquireLock(
 ) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if l.holder.locked && l.holder.strength == lock.Shared {
+		if strength == lock.Shared {
+			return l.acquireSharedLock(durability, txn, ts)
+		}
+		// Upgrade from a Shared to an Exclusive lock. The request acquiring the
+		// Exclusive lock waited for all other holders to release the lock.
+		if len(l.holder.sharedHolders) > 0 || l.holder.holder.txn().ID != txn.ID {
+			return errors.AssertionFailedf(
+				"shared lock cannot be upgraded while held by other transactions")
+		}
+		l.holder.strength = lock.Exclusive
+	}
 	if l.holder.locked {
 		// Already held.
 		beforeTxn, beforeTs := l.getLockHolder()
 		if txn.ID != beforeTxn.ID {
 			return errors.AssertionFailedf("existing lock cannot be acquired by different transaction")
 		}
-		seqs := l.holder.holder[durability].seqs
-		if l.holder.holder[durability].txn != nil && l.holder.holder[durability].txn.Epoch < txn.Epoch {
-			// Clear the sequences for the older epoch.
-			seqs = seqs[:0]
-		}
-		if len(seqs) > 0 && seqs[len(seqs)-1] >= txn.Sequence {
-			// Idempotent lock acquisition. In this case, we simply ignore the lock
-			// acquisition as long as it corresponds to an existing sequence number.
-			// If the sequence number is not being tracked yet, insert it into the
-			// sequence history. The validity of such a lock re-acquisition should
-			// have already been determined at the MVCC level.
-			if i := sort.Search(len(seqs), func(i int) bool {
-				return seqs[i] >= txn.Sequence
-			}); i == len(seqs) {
-				panic("lockTable bug - search value <= last element")
-			} else if seqs[i] != txn.Sequence {
-				seqs = append(seqs, 0)
-				copy(seqs[i+1:], seqs[i:])
-				seqs[i] = txn.Sequence
-				l.holder.holder[durability].seqs = seqs
-			}
+		if strength == lock.Shared {
+			// The Exclusive lock held by the transaction is stronger.
 			return nil
 		}
-		l.holder.holder[durability].txn = txn
-		// Forward the lock's timestamp instead of assigning to it blindly.
-		// While lock acquisition uses monotonically increasing timestamps
-		// from the perspective of the transaction's coordinator, this does
-		// not guarantee that a lock will never be acquired at a higher
-		// epoch and/or sequence number but with a lower timestamp when in
-		// the presence of transaction pushes. Consider the following
-		// sequence of events:
-		//
-		//  - txn A acquires lock at sequence 1, ts 10
-		//  - txn B pushes txn A to ts 20
-		//  - txn B updates lock to ts 20
-		//  - txn A's coordinator does not immediately learn of the push
-		//  - txn A re-acquires lock at sequence 2, ts 15
-		//
-		// A lock's timestamp at a given durability level is not allowed to
-		// regress, so by forwarding its timestamp during the second acquisition
-		// instead if assigning to it blindly, it remains at 20.
-		//
-		// However, a lock's timestamp as reported by getLockHolder can regress
-		// if it is acquired at a lower timestamp and a different durability
-		// than it was previously held with. This is necessary to support
-		// because the hard constraint which we must uphold here that the
-		// lockHolderInfo for a replicated lock cannot diverge from the
-		// replicated state machine in such a way that its timestamp in the
-		// lockTable exceeds that in the replicated keyspace. If this invariant
-		// were to be violated, we'd risk infinite lock-discovery loops for
-		// requests that conflict with the lock as is written in the replicated
-		// state machine but not as is reflected in the lockTable.
-		//
-		// Lock timestamp regressions are safe from the perspective of other
-		// transactions because the request which re-acquired the lock at the
-		// lower timestamp must have been holding a write latch at or below the
-		// new lock's timestamp. This means that no conflicting requests could
-		// be evaluating concurrently. Instead, all will need to re-scan the
-		// lockTable once they acquire latches and will notice the reduced
-		// timestamp at that point, which may cause them to conflict with the
-		// lock even if they had not conflicted before. In a sense, it is no
-		// different than the first time a lock is added to the lockTable.
-		l.holder.holder[durability].ts.Forward(ts)
-		l.holder.holder[durability].seqs = append(seqs, txn.Sequence)
-
+		l.holder.holder[durability].acquire(txn, ts)
 		_, afterTs := l.getLockHolder()
 		if beforeTs.Less(afterTs) {
 			l.increasedLockTs(afterTs)
@@ -1939,6 +2148,7 @@ func (l *lockState) acquireLock(
 	}
 	l.reservation = nil
 	l.holder.locked = true
+	l.holder.strength = strength
 	l.holder.holder[durability].txn = txn
 	l.holder.holder[durability].ts = ts
 	l.holder.holder[durability].seqs = append([]enginepb.TxnSeq(nil), txn.Sequence)
@@ -1946,17 +2156,44 @@ func (l *lockState) acquireLock(
 
 	// If there are waiting requests from the same txn, they no longer need to wait.
 	l.releaseWritersFromTxn(txn)
+	if strength == lock.Shared {
+		// Neither do requests that want to acquire Shared locks.
+		l.releaseSharedWaiters()
+	}
 
 	// Inform active waiters since lock has transitioned to held.
 	l.informActiveWaiters()
 	return nil
 }
 
-// A replicated lock held by txn with timestamp ts was discovered by guard g
-// where g is trying to access this key with access sa.
+// Acquires a Shared lock on a lock that is already held with Shared strength,
+// either by adding txn as a new holder or by updating its existing holder.
+// REQUIRES: l.mu is locked.
+func (l *lockState) acquireSharedLock(
+	durability lock.Durability, txn *enginepb.TxnMeta, ts hlc.Timestamp,
+) error {
+	if h := l.sharedHolder(txn.ID); h != nil {
+		h[durability].acquire(txn, ts)
+		return nil
+	}
+	var h lockHolders
+	h[durability].txn = txn
+	h[durability].ts = ts
+	h[durability].seqs = append([]enginepb.TxnSeq(nil), txn.Sequence)
+	l.holder.sharedHolders = append(l.holder.sharedHolders, h)
+	// If there are waiting requests from the same txn, they no longer need to
+	// wait. They will wait for the other holders of the lock when scanning
+	// again, if necessary.
+	l.releaseWritersFromTxn(txn)
+	return nil
+}
+
+// A replicated lock with strength str held by txn with timestamp ts was
+// discovered by guard g where g is trying to access this key with access sa.
 // Acquires l.mu.
 func (l *lockState) discoveredLock(
 	txn *enginepb.TxnMeta,
+	str lock.Strength,
 	ts hlc.Timestamp,
 	g *lockTableGuardImpl,
 	sa spanset.SpanAccess,
@@ -1969,17 +2206,36 @@ func (l *lockState) discoveredLock(
 	if notRemovable {
 		l.notRemovable++
 	}
+	holders := &l.holder.holder
 	if l.holder.locked {
-		if !l.isLockedBy(txn.ID) {
+		sharedCompatible := l.holder.strength == lock.Shared && str == lock.Shared
+		if !sharedCompatible && !l.isLockedBy(txn.ID) {
 			return errors.AssertionFailedf(
 				"discovered lock by different transaction (%s) than existing lock (see issue #63592): %s",
 				txn, l)
 		}
+		if sharedCompatible {
+			// Shared locks are compatible with each other.
+			if holders = l.sharedHolder(txn.ID); holders == nil {
+				l.holder.sharedHolders = append(l.holder.sharedHolders, lockHolders{})
+				holders = &l.holder.sharedHolders[len(l.holder.sharedHolders)-1]
+			}
+		} else if l.holder.strength == lock.Shared {
+			// The Shared lock was upgraded to an Exclusive lock.
+			if len(l.holder.sharedHolders) > 0 {
+				return errors.AssertionFailedf(
+					"discovered lock by transaction (%s) that shares the existing lock: %s", txn, l)
+			}
+			l.holder.strength = lock.Exclusive
+		}
+		// Else the lock is held with Exclusive strength by txn, which is at
+		// least as strong as the discovered lock.
 	} else {
 		l.holder.locked = true
+		l.holder.strength = str
 		l.holder.startTime = clock.PhysicalTime()
 	}
-	holder := &l.holder.holder[lock.Replicated]
+	holder := &holders[lock.Replicated]
 	if holder.txn == nil {
 		holder.txn = txn
 		holder.ts = ts
@@ -2070,7 +2326,11 @@ func (l *lockState) tryClearLock(force bool) bool {
 	if l.notRemovable > 0 && !force {
 		return false
 	}
-	replicatedHeld := l.holder.locked && l.holder.holder[lock.Replicated].txn != nil
+	// Replicated Shared locks are forgotten along with unreplicated locks. They
+	// are not intents, so they are rediscovered by the requests that conflict
+	// with them during evaluation.
+	replicatedHeld := l.holder.locked && l.holder.strength == lock.Exclusive &&
+		l.holder.holder[lock.Replicated].txn != nil
 
 	// Remove unreplicated holder.
 	l.holder.holder[lock.Unreplicated] = lockHolderInfo{}
@@ -2167,6 +2427,9 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 	if !l.isLockedBy(up.Txn.ID) {
 		return false, false
 	}
+	if l.holder.strength == lock.Shared {
+		return true, l.tryUpdateSharedLock(up)
+	}
 	if up.Status.IsFinalized() {
 		l.clearLockHolder()
 		gc = l.lockIsFree()
@@ -2240,6 +2503,56 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 	return true, false
 }
 
+// Updates the holder of the Shared lock that belongs to the transaction in up,
+// removing it if it no longer holds the lock. Returns whether the lockState
+// can be garbage collected.
+// REQUIRES: l.mu is locked and the lock is held with Shared strength by the
+// transaction.
+func (l *lockState) tryUpdateSharedLock(up *roachpb.LockUpdate) (gc bool) {
+	h := l.sharedHolder(up.Txn.ID)
+	isLocked := false
+	if !up.Status.IsFinalized() {
+		txn := &up.Txn
+		for i := range h {
+			holder := &h[i]
+			if holder.txn == nil {
+				continue
+			}
+			// See the comment in tryUpdateLock about forgetting replicated locks.
+			if lock.Durability(i) == lock.Replicated || txn.Epoch > holder.txn.Epoch {
+				*holder = lockHolderInfo{}
+				continue
+			}
+			if txn.Epoch == holder.txn.Epoch {
+				holder.seqs = removeIgnored(holder.seqs, up.IgnoredSeqNums)
+				if len(holder.seqs) == 0 {
+					*holder = lockHolderInfo{}
+					continue
+				}
+			}
+			// Shared locks do not conflict with readers, so there are no
+			// waiters to inform about the timestamp change.
+			holder.ts.Forward(txn.WriteTimestamp)
+			isLocked = true
+		}
+	}
+	if isLocked {
+		return false
+	}
+	l.removeSharedHolder(h)
+	if !l.holder.locked {
+		return l.lockIsFree()
+	}
+	if len(l.holder.sharedHolders) == 0 {
+		// If there are waiting requests from the remaining holder's txn, they no
+		// longer need to wait.
+		l.releaseWritersFromTxn(l.holder.holder.txn())
+	}
+	// Active waiters need to be told about who they are waiting for.
+	l.informActiveWaiters()
+	return false
+}
+
 // The lock holder timestamp has increased. Some of the waiters may no longer
 // need to wait.
 // REQUIRES: l.mu is locked.
@@ -2351,6 +2664,12 @@ func (l *lockState) tryFreeLockOnReplicatedAcquire() bool {
 		return false
 	}
 
+	// Bail if not locked with Exclusive strength. Replicated Shared locks are
+	// not stored as MVCC intents.
+	if l.holder.strength != lock.Exclusive {
+		return false
+	}
+
 	// Bail if the lock has waiting writers. It is not uncontended.
 	if l.queuedWriters.Len() != 0 {
 		return false
@@ -2498,6 +2817,7 @@ func (t *lockTableImpl) newGuardForReq(req Request) *lockTableGuardImpl {
 	g.lt = t
 	g.txn = req.txnMeta()
 	g.ts = req.Timestamp
+	g.str = req.lockStrength()
 	g.spans = req.LockSpans
 	g.waitPolicy = req.WaitPolicy
 	g.maxWaitQueueLength = req.MaxLockWaitQueueLength
@@ -2641,7 +2961,12 @@ func (t *lockTableImpl) AddDiscoveredLock(
 		g.notRemovableLock = l
 		notRemovableLock = true
 	}
-	err = l.discoveredLock(&intent.Txn, intent.Txn.WriteTimestamp, g, sa, notRemovableLock, g.lt.clock)
+	str := intent.Strength
+	if str == lock.None {
+		// Write intents are held with Exclusive strength.
+		str = lock.Exclusive
+	}
+	err = l.discoveredLock(&intent.Txn, str, intent.Txn.WriteTimestamp, g, sa, notRemovableLock, g.lt.clock)
 	// Can't release tree.mu until call l.discoveredLock() since someone may
 	// find an empty lock and remove it from the tree.
 	tree.mu.Unlock()
@@ -2661,8 +2986,8 @@ func (t *lockTableImpl) AcquireLock(
 		// If not enabled, don't track any locks.
 		return nil
 	}
-	if strength != lock.Exclusive {
-		return errors.AssertionFailedf("lock strength not Exclusive")
+	if strength != lock.Exclusive && strength != lock.Shared {
+		return errors.AssertionFailedf("lock strength not Exclusive or Shared")
 	}
 	ss := spanset.SpanGlobal
 	if keys.IsLocal(key) {
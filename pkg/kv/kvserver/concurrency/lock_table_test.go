@@ -63,10 +63,11 @@ new-txn txn=<name> ts=<int>[,<int>] epoch=<int> [seq=<int>]
 
  Creates a TxnMeta.
 
-new-request r=<name> txn=<name>|none ts=<int>[,<int>] spans=r|w@<start>[,<end>]+... [skip-locked] [max-lock-wait-queue-length=<int>]
+new-request r=<name> txn=<name>|none ts=<int>[,<int>] spans=r|w@<start>[,<end>]+... [skip-locked] [max-lock-wait-queue-length=<int>] [strength=<strength>]
 ----
 
- Creates a Request.
+ Creates a Request. If a strength is provided, the request contains a locking
+ GetRequest with that strength.
 
 scan r=<name>
 ----
@@ -82,11 +83,12 @@ start-waiting: <bool>
  Calls lockTable.ScanOptimistic. The request must not have an existing guard.
  If a guard is returned, stores it for later use.
 
-acquire r=<name> k=<key> durability=r|u
+acquire r=<name> k=<key> durability=r|u [strength=<strength>]
 ----
 <error string>
 
  Acquires lock for the request, using the existing guard for that request.
+ The lock is acquired with Exclusive strength unless specified otherwise.
 
 release txn=<name> span=<start>[,<end>]
 ----
@@ -105,7 +107,7 @@ txn-finalized txn=<name> status=committed|aborted
 
  Informs the lock table that the named transaction is finalized.
 
-add-discovered r=<name> k=<key> txn=<name> [lease-seq=<seq>] [consult-finalized-txn-cache=<bool>]
+add-discovered r=<name> k=<key> txn=<name> [lease-seq=<seq>] [consult-finalized-txn-cache=<bool>] [strength=<strength>]
 ----
 <error string>
 
@@ -313,6 +315,11 @@ func TestLockTableBasic(t *testing.T) {
 					LatchSpans:             spans,
 					LockSpans:              spans,
 				}
+				if d.HasArg("strength") {
+					var ru roachpb.RequestUnion
+					ru.MustSetInner(&roachpb.GetRequest{KeyLocking: scanLockStrength(t, d)})
+					req.Requests = []roachpb.RequestUnion{ru}
+				}
 				if txnMeta != nil {
 					// Update the transaction's timestamp, if necessary. The transaction
 					// may have needed to move its timestamp for any number of reasons.
@@ -370,7 +377,11 @@ func TestLockTableBasic(t *testing.T) {
 				if s[0] == 'r' {
 					durability = lock.Replicated
 				}
-				if err := lt.AcquireLock(&req.Txn.TxnMeta, roachpb.Key(key), lock.Exclusive, durability); err != nil {
+				strength := lock.Exclusive
+				if d.HasArg("strength") {
+					strength = scanLockStrength(t, d)
+				}
+				if err := lt.AcquireLock(&req.Txn.TxnMeta, roachpb.Key(key), strength, durability); err != nil {
 					return err.Error()
 				}
 				return lt.String()
@@ -459,6 +470,9 @@ func TestLockTableBasic(t *testing.T) {
 					d.Fatalf(t, "unknown txn %s", txnName)
 				}
 				intent := roachpb.MakeIntent(txnMeta, roachpb.Key(key))
+				if d.HasArg("strength") {
+					intent.Strength = scanLockStrength(t, d)
+				}
 				seq := int(1)
 				if d.HasArg("lease-seq") {
 					d.ScanArgs(t, "lease-seq", &seq)
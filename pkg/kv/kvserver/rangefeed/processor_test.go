@@ -307,13 +307,14 @@ func TestProcessorBasic(t *testing.T) {
 	p.syncEventAndRegistrations()
 	require.Equal(t,
 		[]*roachpb.RangeFeedEvent{
-			rangeFeedValue(
-				roachpb.Key("e"),
-				roachpb.Value{
+			makeRangeFeedEvent(&roachpb.RangeFeedValue{
+				Key: roachpb.Key("e"),
+				Value: roachpb.Value{
 					RawBytes:  []byte("ival"),
 					Timestamp: hlc.Timestamp{WallTime: 13},
 				},
-			),
+				TxnID: txn2,
+			}),
 			rangeFeedCheckpoint(
 				roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("m")},
 				hlc.Timestamp{WallTime: 15},
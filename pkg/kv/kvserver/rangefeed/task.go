@@ -120,6 +120,11 @@ func (s *SeparatedIntentScanner) ConsumeIntents(
 		if err != nil {
 			return err
 		}
+		if !engineKey.IsExclusiveLockTableKey() {
+			// Replicated locks of non-Exclusive strength are not intents and do
+			// not hold back the resolved timestamp.
+			continue
+		}
 		lockedKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
 		if err != nil {
 			return errors.Wrapf(err, "decoding LockTable key: %s", lockedKey)
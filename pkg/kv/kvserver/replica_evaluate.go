@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
@@ -269,6 +270,12 @@ func evaluateBatch(
 
 		reply := br.Responses[index].GetInner()
 
+		if err := checkForConflictingSharedLocks(ctx, readWriter, rec, baHeader.Txn, args); err != nil {
+			pErr := roachpb.NewErrorWithTxn(err, baHeader.Txn)
+			pErr.SetErrorIndex(int32(index))
+			return nil, mergedResult, pErr
+		}
+
 		// Note that `reply` is populated even when an error is returned: it
 		// may carry a response transaction and in the case of WriteTooOldError
 		// (which is sometimes deferred) it is fully populated.
@@ -366,6 +373,13 @@ func evaluateBatch(
 						args.Header().Key, baHeader.Txn.WriteTimestamp, wtoErr.ActualTimestamp)
 					baHeader.Txn.WriteTimestamp.Forward(wtoErr.ActualTimestamp)
 					baHeader.Txn.WriteTooOld = true
+					// Transactions that take a new read snapshot for each statement
+					// retry the statement on write-write conflicts. Deferring the
+					// error would only surface it at commit time, where the whole
+					// transaction would need to be restarted.
+					if baHeader.Txn.IsoLevel.PerStatementReadSnapshot() {
+						writeTooOldState.cantDeferWTOE = true
+					}
 				} else {
 					// For non-transactional requests, there's nowhere to defer the error
 					// to. And the request has to fail because non-transactional batches
@@ -469,6 +483,41 @@ func evaluateBatch(
 	return br, mergedResult, nil
 }
 
+// checkForConflictingSharedLocks returns a WriteIntentError if the request
+// acquires locks that are stronger than Shared locks (including intents) on
+// keys on which other transactions hold replicated Shared locks. Replicated
+// Shared locks are not intents, so they are not observed during MVCC
+// evaluation. The error is handled by the concurrency manager like any other
+// conflicting lock: the request waits for the Shared lock holders to finish
+// and is then re-evaluated.
+//
+// Replicated Shared locks cannot exist before the V23_1SharedLocks version is
+// active, so writes do not pay for the scan until then.
+func checkForConflictingSharedLocks(
+	ctx context.Context,
+	reader storage.Reader,
+	rec batcheval.EvalContext,
+	txn *roachpb.Transaction,
+	args roachpb.Request,
+) error {
+	if !roachpb.IsLocking(args) || roachpb.LockingStrength(args) <= lock.Shared {
+		return nil
+	}
+	if !rec.ClusterSettings().Version.IsActive(ctx, clusterversion.V23_1SharedLocks) {
+		return nil
+	}
+	span := args.Header().Span()
+	maxLocks := storage.MaxIntentsPerWriteIntentError.Get(&rec.ClusterSettings().SV)
+	locks, err := storage.ScanConflictingSharedLocks(ctx, reader, txn, span.Key, span.EndKey, maxLocks)
+	if err != nil {
+		return err
+	}
+	if len(locks) > 0 {
+		return &roachpb.WriteIntentError{Intents: locks}
+	}
+	return nil
+}
+
 // evaluateCommand delegates to the eval method for the given
 // roachpb.Request. The returned Result may be partially valid
 // even if an error is returned. maxKeys is the number of scan results
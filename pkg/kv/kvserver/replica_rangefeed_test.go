@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
@@ -293,7 +294,9 @@ func TestReplicaRangefeed(t *testing.T) {
 	}
 	// Insert a second key transactionally.
 	ts3 := initTime.Add(0, 3)
+	var txn3ID uuid.UUID
 	if err := store1.DB().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txn3ID = txn.ID()
 		if err := txn.SetFixedTimestamp(ctx, ts3); err != nil {
 			return err
 		}
@@ -315,7 +318,9 @@ func TestReplicaRangefeed(t *testing.T) {
 
 	// Update the originally incremented key transactionally.
 	ts5 := initTime.Add(0, 5)
+	var txn5ID uuid.UUID
 	if err := store1.DB().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txn5ID = txn.ID()
 		if err := txn.SetFixedTimestamp(ctx, ts5); err != nil {
 			return err
 		}
@@ -406,13 +411,13 @@ func TestReplicaRangefeed(t *testing.T) {
 			Key: roachpb.Key("c"), Value: expVal2,
 		}},
 		{Val: &roachpb.RangeFeedValue{
-			Key: roachpb.Key("m"), Value: expVal3,
+			Key: roachpb.Key("m"), Value: expVal3, TxnID: txn3ID,
 		}},
 		{Val: &roachpb.RangeFeedValue{
 			Key: roachpb.Key("b"), Value: expVal4, PrevValue: expVal1NoTS,
 		}},
 		{Val: &roachpb.RangeFeedValue{
-			Key: roachpb.Key("b"), Value: expVal5, PrevValue: expVal4NoTS,
+			Key: roachpb.Key("b"), Value: expVal5, PrevValue: expVal4NoTS, TxnID: txn5ID,
 		}},
 		{SST: &roachpb.RangeFeedSSTable{
 			// Binary representation of Data may be modified by SST rewrite, see checkForExpEvents.
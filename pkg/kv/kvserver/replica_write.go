@@ -570,6 +570,15 @@ func (r *Replica) evaluate1PC(
 				pErr:    roachpb.NewError(err),
 			}
 		}
+		// The stripped batch was evaluated non-transactionally, so attribute its
+		// writes to the transaction for the benefit of rangefeeds.
+		if res.LogicalOpLog != nil {
+			for _, op := range res.LogicalOpLog.Ops {
+				if wv, ok := op.GetValue().(*enginepb.MVCCWriteValueOp); ok {
+					wv.TxnID = clonedTxn.ID
+				}
+			}
+		}
 	}
 
 	// Even though the transaction is 1PC and hasn't written any intents, it may
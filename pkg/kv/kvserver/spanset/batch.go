@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 )
 
@@ -350,8 +351,20 @@ func (i *EngineIterator) checkKeyAllowed() (valid bool, err error) {
 			return false, nil // nolint:returnerrcheck
 		}
 	} else if err = i.spans.CheckAllowed(SpanReadOnly, roachpb.Span{Key: key.Key}); err != nil {
-		// Invalid, but no error.
-		return false, nil // nolint:returnerrcheck
+		if !key.IsLockTableKey() {
+			// Invalid, but no error.
+			return false, nil // nolint:returnerrcheck
+		}
+		// Lock table keys are also allowed if the key that they lock is in the
+		// spans, since the lock table keyspace is not declared in the spans.
+		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
+		if err != nil {
+			return false, err
+		}
+		if err := i.spans.CheckAllowed(SpanReadOnly, roachpb.Span{Key: lockedKey}); err != nil {
+			// Invalid, but no error.
+			return false, nil // nolint:returnerrcheck
+		}
 	}
 	return true, nil
 }
@@ -537,11 +550,28 @@ func (s spanSetWriter) ClearIntent(
 
 func (s spanSetWriter) ClearEngineKey(key storage.EngineKey) error {
 	if err := s.spans.CheckAllowed(SpanReadWrite, roachpb.Span{Key: key.Key}); err != nil {
-		return err
+		if lockErr := s.checkAllowedLockTableKey(key); lockErr != nil {
+			return err
+		}
 	}
 	return s.w.ClearEngineKey(key)
 }
 
+// checkAllowedLockTableKey checks whether the replicated lock identified by
+// the lock table key may be written. Like intents in PutIntent and
+// ClearIntent, replicated locks are checked against the key that they lock,
+// since the lock table keyspace is not declared in the spans.
+func (s spanSetWriter) checkAllowedLockTableKey(key storage.EngineKey) error {
+	if !key.IsLockTableKey() {
+		return errors.Errorf("%s is not a lock table key", key)
+	}
+	lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
+	if err != nil {
+		return err
+	}
+	return s.checkAllowed(lockedKey)
+}
+
 func (s spanSetWriter) SingleClearEngineKey(key storage.EngineKey) error {
 	// Pass-through, since single clear is only used for the lock table, which
 	// is not in the spans.
@@ -678,6 +708,12 @@ func (s spanSetWriter) PutIntent(
 }
 
 func (s spanSetWriter) PutEngineKey(key storage.EngineKey, value []byte) error {
+	if key.IsLockTableKey() {
+		if err := s.checkAllowedLockTableKey(key); err != nil {
+			return err
+		}
+		return s.w.PutEngineKey(key, value)
+	}
 	if !s.spansOnly {
 		panic("cannot do timestamp checking for putting EngineKey")
 	}
@@ -13,6 +13,7 @@ package kv
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -76,6 +77,17 @@ func (m *MockTransactionalSender) SetUserPriority(pri roachpb.UserPriority) erro
 	return nil
 }
 
+// SetIsoLevel is part of the TxnSender interface.
+func (m *MockTransactionalSender) SetIsoLevel(isoLevel isolation.Level) error {
+	m.txn.IsoLevel = isoLevel
+	return nil
+}
+
+// IsoLevel is part of the TxnSender interface.
+func (m *MockTransactionalSender) IsoLevel() isolation.Level {
+	return m.txn.IsoLevel
+}
+
 // SetDebugName is part of the TxnSender interface.
 func (m *MockTransactionalSender) SetDebugName(name string) {
 	m.txn.Name = name
@@ -190,6 +202,17 @@ func (m *MockTransactionalSender) Step(_ context.Context) error {
 	return nil
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (m *MockTransactionalSender) StepReadTimestamp(_ context.Context) error {
+	// See Step() above.
+	return nil
+}
+
+// PrepareForPartialRetry is part of the TxnSender interface.
+func (m *MockTransactionalSender) PrepareForPartialRetry(_ context.Context) error {
+	panic("unimplemented")
+}
+
 // SetReadSeqNum is part of the TxnSender interface.
 func (m *MockTransactionalSender) SetReadSeqNum(_ enginepb.TxnSeq) error { return nil }
 
@@ -13,6 +13,7 @@ package kv
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -115,6 +116,13 @@ type TxnSender interface {
 	// SetUserPriority sets the txn's priority.
 	SetUserPriority(roachpb.UserPriority) error
 
+	// SetIsoLevel sets the txn's isolation level. The isolation level cannot
+	// be changed after the txn has become active.
+	SetIsoLevel(isolation.Level) error
+
+	// IsoLevel returns the txn's isolation level.
+	IsoLevel() isolation.Level
+
 	// SetDebugName sets the txn's debug name.
 	SetDebugName(name string)
 
@@ -274,6 +282,22 @@ type TxnSender interface {
 	// The method is idempotent.
 	Step(context.Context) error
 
+	// StepReadTimestamp establishes a new read snapshot for the current
+	// transaction by moving its read timestamp up to the present time. It is
+	// called at the start of each statement by transactions whose isolation
+	// level takes a new read snapshot for each statement, and is a no-op for
+	// all other transactions and for transactions whose commit timestamp has
+	// been fixed.
+	StepReadTimestamp(context.Context) error
+
+	// PrepareForPartialRetry prepares the transaction for a retry of the
+	// current statement after a TransactionRetryWithProtoRefreshError with
+	// PartialRetry set was returned. The caller is expected to roll back to a
+	// savepoint established at the start of the statement, before or after
+	// calling this method, and to step the read timestamp before retrying the
+	// statement.
+	PrepareForPartialRetry(context.Context) error
+
 	// SetReadSeqNum sets the read sequence point for the current transaction.
 	SetReadSeqNum(seq enginepb.TxnSeq) error
 
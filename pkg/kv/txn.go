@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -299,6 +300,26 @@ func (txn *Txn) SetUserPriority(userPriority roachpb.UserPriority) error {
 	return txn.mu.sender.SetUserPriority(userPriority)
 }
 
+// SetIsoLevel sets the transaction's isolation level. Transactions default to
+// Serializable isolation. The isolation level must be set before any
+// operations are performed on the transaction.
+func (txn *Txn) SetIsoLevel(isoLevel isolation.Level) error {
+	if txn.typ != RootTxn {
+		return errors.AssertionFailedf("SetIsoLevel() called on leaf txn")
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.SetIsoLevel(isoLevel)
+}
+
+// IsoLevel returns the transaction's isolation level.
+func (txn *Txn) IsoLevel() isolation.Level {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.IsoLevel()
+}
+
 // TestingSetPriority sets the transaction priority. It is intended for
 // internal (testing) use only.
 func (txn *Txn) TestingSetPriority(priority enginepb.TxnPriority) {
@@ -1075,6 +1096,14 @@ func (txn *Txn) handleRetryableErrLocked(
 	ctx context.Context, retryErr *roachpb.TransactionRetryWithProtoRefreshError,
 ) {
 	txn.resetDeadlineLocked()
+	if retryErr.PartialRetry && txn.mu.ID == retryErr.TxnID {
+		// The error only prepared the transaction for a retry of the statement
+		// that encountered it. Since the whole transaction is being retried
+		// instead, its epoch needs to be bumped so that the writes performed by
+		// the current attempt are discarded.
+		txn.mu.sender.ClearTxnRetryableErr(ctx)
+		txn.mu.sender.ManualRestart(ctx, txn.mu.userPriority, retryErr.Transaction.WriteTimestamp)
+	}
 	txn.replaceRootSenderIfTxnAbortedLocked(ctx, retryErr, retryErr.TxnID)
 }
 
@@ -1488,6 +1517,33 @@ func (txn *Txn) Step(ctx context.Context) error {
 	return txn.mu.sender.Step(ctx)
 }
 
+// StepReadTimestamp establishes a new read snapshot for the transaction, if
+// its isolation level takes a new read snapshot for each statement. Reads
+// performed after the call observe all values committed before it. For all
+// other transactions, the method is a no-op.
+func (txn *Txn) StepReadTimestamp(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		return errors.AssertionFailedf("StepReadTimestamp() called on leaf txn")
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.StepReadTimestamp(ctx)
+}
+
+// PrepareForPartialRetry clears a retryable error that allows the current
+// statement to be retried without restarting the whole transaction, i.e. a
+// TransactionRetryWithProtoRefreshError with PartialRetry set. The caller must
+// roll back to a savepoint established at the start of the statement before
+// retrying the statement.
+func (txn *Txn) PrepareForPartialRetry(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		return errors.AssertionFailedf("PrepareForPartialRetry() called on leaf txn")
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.PrepareForPartialRetry(ctx)
+}
+
 // SetReadSeqNum sets the read sequence number for this transaction.
 func (txn *Txn) SetReadSeqNum(seq enginepb.TxnSeq) error {
 	txn.mu.Lock()
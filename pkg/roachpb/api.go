@@ -161,6 +161,21 @@ func LockingDurability(args Request) lock.Durability {
 	return lock.Replicated
 }
 
+// LockingStrength returns the strength of the locks acquired by the request.
+// The function assumes that IsLocking(args).
+func LockingStrength(args Request) lock.Strength {
+	switch t := args.(type) {
+	case *GetRequest:
+		return t.KeyLocking
+	case *ScanRequest:
+		return t.KeyLocking
+	case *ReverseScanRequest:
+		return t.KeyLocking
+	default:
+		return lock.Exclusive
+	}
+}
+
 // IsIntentWrite returns true if the request produces write intents at
 // the request's sequence number when used within a transaction.
 func IsIntentWrite(args Request) bool {
@@ -1242,9 +1257,19 @@ func flagForLockStrength(l lock.Strength) flag {
 	return 0
 }
 
+// flagForLockDurability returns isWrite for locking reads that acquire
+// Replicated locks, since doing so requires the request to go through raft.
+func flagForLockDurability(l lock.Strength, replicated bool) flag {
+	if l != lock.None && replicated {
+		return isWrite
+	}
+	return 0
+}
+
 func (gr *GetRequest) flags() flag {
 	maybeLocking := flagForLockStrength(gr.KeyLocking)
-	return isRead | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(gr.KeyLocking, gr.KeyLockingReplicated)
+	return isRead | maybeWrite | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 func (*PutRequest) flags() flag {
@@ -1334,12 +1359,14 @@ func (*RevertRangeRequest) flags() flag {
 
 func (sr *ScanRequest) flags() flag {
 	maybeLocking := flagForLockStrength(sr.KeyLocking)
-	return isRead | isRange | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(sr.KeyLocking, sr.KeyLockingReplicated)
+	return isRead | isRange | maybeWrite | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 func (rsr *ReverseScanRequest) flags() flag {
 	maybeLocking := flagForLockStrength(rsr.KeyLocking)
-	return isRead | isRange | isReverse | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(rsr.KeyLocking, rsr.KeyLockingReplicated)
+	return isRead | isRange | isReverse | maybeWrite | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 // EndTxn updates the timestamp cache to prevent replays.
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -1168,6 +1169,9 @@ func (t *Transaction) Update(o *Transaction) {
 	if len(t.Key) == 0 {
 		t.Key = o.Key
 	}
+	// The isolation level is fixed for the lifetime of the transaction, but t
+	// may not have been populated with it yet.
+	t.IsoLevel = o.IsoLevel
 
 	// Update epoch-scoped state, depending on the two transactions' epochs.
 	if t.Epoch < o.Epoch {
@@ -1310,6 +1314,9 @@ func (t Transaction) SafeFormat(w redact.SafePrinter, _ rune) {
 	if ni := len(t.IgnoredSeqNums); ni > 0 {
 		w.Printf(" isn=%d", ni)
 	}
+	if t.IsoLevel != isolation.Serializable {
+		w.Printf(" iso=%s", t.IsoLevel)
+	}
 }
 
 // ResetObservedTimestamps clears out all timestamps recorded from individual
@@ -1474,6 +1481,8 @@ func PrepareTransactionForRetry(
 		)
 		// Use the priority communicated back by the server.
 		txn.Priority = errTxnPri
+		// The new transaction runs at the same isolation level.
+		txn.IsoLevel = pErr.GetTxn().IsoLevel
 	case *ReadWithinUncertaintyIntervalError:
 		txn.WriteTimestamp.Forward(tErr.RetryTimestamp())
 	case *TransactionPushError:
@@ -2050,9 +2059,11 @@ func AsIntents(txn *enginepb.TxnMeta, keys []Key) []Intent {
 }
 
 // MakeLockAcquisition makes a lock acquisition message from the given
-// txn, key, and durability level.
-func MakeLockAcquisition(txn *Transaction, key Key, dur lock.Durability) LockAcquisition {
-	return LockAcquisition{Span: Span{Key: key}, Txn: txn.TxnMeta, Durability: dur}
+// txn, key, durability level, and lock strength.
+func MakeLockAcquisition(
+	txn *Transaction, key Key, dur lock.Durability, str lock.Strength,
+) LockAcquisition {
+	return LockAcquisition{Span: Span{Key: key}, Txn: txn.TxnMeta, Durability: dur, Strength: str}
 }
 
 // MakeLockUpdate makes a lock update from the given txn and span.
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils/zerofields"
@@ -531,6 +532,7 @@ var nonZeroTxn = Transaction{
 	InFlightWrites:       []SequencedWrite{{Key: []byte("c"), Sequence: 1}},
 	CommitTimestampFixed: true,
 	IgnoredSeqNums:       []enginepb.IgnoredSeqNumRange{{Start: 888, End: 999}},
+	IsoLevel:             isolation.ReadCommitted,
 }
 
 func TestTransactionUpdate(t *testing.T) {
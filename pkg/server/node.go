@@ -76,6 +76,9 @@ const (
 
 	graphiteIntervalKey = "external.graphite.interval"
 	maxGraphiteInterval = 15 * time.Minute
+
+	otlpIntervalKey = "external.otlp.interval"
+	maxOTLPInterval = 15 * time.Minute
 )
 
 // Metric names.
@@ -156,6 +159,44 @@ var (
 		10*time.Second,
 		settings.NonNegativeDurationWithMaximum(maxGraphiteInterval),
 	).WithPublic()
+	// otlpEndpoint is the address or URL, if any, of an OpenTelemetry
+	// collector receiving metrics.
+	otlpEndpoint = settings.RegisterValidatedStringSetting(
+		settings.TenantWritable,
+		"external.otlp.endpoint",
+		"if nonempty, push server metrics to the OpenTelemetry collector at the specified endpoint, "+
+			"using OTLP/gRPC for a host:port address (4317 if no port is specified) or "+
+			"OTLP/HTTP for an http:// or https:// URL (/v1/metrics if no path is specified)",
+		"",
+		func(_ *settings.Values, s string) error {
+			if s == "" {
+				return nil
+			}
+			return metric.ValidateOTLPEndpoint(s)
+		},
+	).WithPublic()
+	// otlpInterval is how often metrics are pushed to the OpenTelemetry
+	// collector, if enabled.
+	otlpInterval = settings.RegisterDurationSetting(
+		settings.TenantWritable,
+		otlpIntervalKey,
+		"the interval at which metrics are pushed to the OpenTelemetry collector (if enabled)",
+		10*time.Second,
+		settings.NonNegativeDurationWithMaximum(maxOTLPInterval),
+	).WithPublic()
+	// otlpHeaders are the headers sent with the metrics pushed to the
+	// OpenTelemetry collector, typically for authentication.
+	otlpHeaders = settings.RegisterValidatedStringSetting(
+		settings.TenantWritable,
+		"external.otlp.headers",
+		"comma-separated list of key=value headers to send with the metrics pushed to the "+
+			"OpenTelemetry collector (if enabled), in which keys and values may be URL-encoded",
+		"",
+		func(_ *settings.Values, s string) error {
+			_, err := metric.ParseOTLPHeaders(s)
+			return err
+		},
+	).WithPublic()
 	redactServerTracesForSecondaryTenants = settings.RegisterBoolSetting(
 		settings.SystemOnly,
 		"server.secondary_tenants.redact_trace.enabled",
@@ -944,6 +985,38 @@ func startGraphiteStatsExporter(
 	})
 }
 
+func startOTLPStatsExporter(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	recorder *status.MetricsRecorder,
+	st *cluster.Settings,
+) {
+	ctx = logtags.AddTag(ctx, "otlp stats exporter", nil)
+	pm := metric.MakePrometheusExporter()
+
+	_ = stopper.RunAsyncTask(ctx, "otlp-exporter", func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(otlpInterval.Get(&st.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+				endpoint := otlpEndpoint.Get(&st.SV)
+				if endpoint != "" {
+					// The headers were validated when the setting was changed.
+					headers, _ := metric.ParseOTLPHeaders(otlpHeaders.Get(&st.SV))
+					if err := recorder.ExportToOTLP(ctx, endpoint, headers, &pm); err != nil {
+						log.Infof(ctx, "error pushing metrics to OpenTelemetry collector: %s\n", err)
+					}
+				}
+			}
+		}
+	})
+}
+
 // startWriteNodeStatus begins periodically persisting status summaries for the
 // node and its stores.
 func (n *Node) startWriteNodeStatus(frequency time.Duration) error {
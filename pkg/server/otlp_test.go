@@ -0,0 +1,90 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestOTLPMetrics tests that a server pushes metrics data to an
+// OpenTelemetry collector using OTLP/HTTP, if configured.
+func TestOTLPMetrics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, rawDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	// received is sent the names of the metrics in each request that
+	// carries the expected header.
+	received := make(chan map[string]bool, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		req := &colmetricspb.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Api-Key") != "secret" {
+			http.Error(w, "missing api key", http.StatusUnauthorized)
+			return
+		}
+		names := make(map[string]bool)
+		for _, rm := range req.ResourceMetrics {
+			for _, ilm := range rm.InstrumentationLibraryMetrics {
+				for _, m := range ilm.Metrics {
+					names[m.Name] = true
+				}
+			}
+		}
+		select {
+		case received <- names:
+		default:
+		}
+	}))
+	defer collector.Close()
+
+	const setQ = `SET CLUSTER SETTING "%s" = "%s"`
+	const interval = 3 * time.Millisecond
+	db := sqlutils.MakeSQLRunner(rawDB)
+	db.Exec(t, fmt.Sprintf(setQ, otlpIntervalKey, interval))
+	db.Exec(t, fmt.Sprintf(setQ, "external.otlp.headers", "api-key=secret"))
+	db.Exec(t, fmt.Sprintf(setQ, "external.otlp.endpoint", collector.URL))
+
+	names := <-received
+	// Counters, gauges and histograms are all exported.
+	require.True(t, names["sql_bytesin"])
+	require.True(t, names["sql_conns"])
+	require.True(t, names["sql_service_latency"])
+
+	db.ExpectErr(t, "invalid header",
+		fmt.Sprintf(setQ, "external.otlp.headers", "api-key"))
+	db.ExpectErr(t, "invalid address format",
+		fmt.Sprintf(setQ, "external.otlp.endpoint", "::1"))
+}
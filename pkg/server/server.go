@@ -1580,6 +1580,17 @@ func (s *Server) PreStart(ctx context.Context) error {
 		}
 	})
 
+	// Export statistics to an OpenTelemetry collector, if enabled by
+	// configuration.
+	var otlpOnce sync.Once
+	otlpEndpoint.SetOnChange(&s.st.SV, func(context.Context) {
+		if otlpEndpoint.Get(&s.st.SV) != "" {
+			otlpOnce.Do(func() {
+				startOTLPStatsExporter(workersCtx, s.stopper, s.recorder, s.st)
+			})
+		}
+	})
+
 	// Start the protected timestamp subsystem. Note that this needs to happen
 	// before the modeOperational switch below, as the protected timestamps
 	// subsystem will crash if accessed before being Started (and serving general
@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/system"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/dustin/go-humanize"
@@ -276,6 +277,20 @@ func (mr *MetricsRecorder) ExportToGraphite(
 	return graphiteExporter.Push(ctx, endpoint)
 }
 
+// ExportToOTLP sends the current metric values to an OpenTelemetry
+// collector, along with the given headers. Like ExportToGraphite, it uses
+// its own PrometheusExporter to avoid races with mr.prometheusExporter.
+func (mr *MetricsRecorder) ExportToOTLP(
+	ctx context.Context, endpoint string, headers map[string]string, pm *metric.PrometheusExporter,
+) error {
+	mr.mu.RLock()
+	startedAt := mr.mu.startedAt
+	mr.mu.RUnlock()
+	mr.ScrapeIntoPrometheus(pm)
+	otlpExporter := metric.MakeOTLPExporter(pm, timeutil.Unix(0, startedAt))
+	return otlpExporter.Push(ctx, endpoint, headers)
+}
+
 // GetTimeSeriesData serializes registered metrics for consumption by
 // CockroachDB's time series system.
 func (mr *MetricsRecorder) GetTimeSeriesData() []tspb.TimeSeriesData {
@@ -525,6 +525,17 @@ func (s *SQLServerWrapper) PreStart(ctx context.Context) error {
 		}
 	})
 
+	// Export statistics to an OpenTelemetry collector, if enabled by
+	// configuration.
+	var otlpOnce sync.Once
+	otlpEndpoint.SetOnChange(&s.ClusterSettings().SV, func(context.Context) {
+		if otlpEndpoint.Get(&s.ClusterSettings().SV) != "" {
+			otlpOnce.Do(func() {
+				startOTLPStatsExporter(workersCtx, s.stopper, s.recorder, s.ClusterSettings())
+			})
+		}
+	})
+
 	// After setting modeOperational, we can block until all stores are fully
 	// initialized.
 	s.grpc.setMode(modeOperational)
@@ -108,6 +108,10 @@ func AlterColumnType(
 	if err != nil {
 		return err
 	}
+	err = colinfo.CheckColumnTypeIsSupported(ctx, params.ExecCfg().Settings.Version, typ)
+	if err != nil {
+		return err
+	}
 
 	var kind schemachange.ColumnConversionKind
 	if t.Using != nil {
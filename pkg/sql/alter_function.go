@@ -63,7 +63,9 @@ func (p *planner) AlterFunctionOptions(
 }
 
 func (n *alterFunctionOptionsNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(
+		params.ctx, &n.n.Function, false /* isProcedure */, false, /* isAggregate */
+	)
 	if err != nil {
 		return err
 	}
@@ -112,7 +114,7 @@ func (p *planner) AlterFunctionRename(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -124,7 +126,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(
+		params.ctx, &n.n.Function, n.n.IsProcedure, n.n.IsAggregate,
+	)
 	if err != nil {
 		return err
 	}
@@ -187,7 +191,7 @@ func (p *planner) AlterFunctionSetOwner(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -196,7 +200,9 @@ func (p *planner) AlterFunctionSetOwner(
 }
 
 func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(
+		params.ctx, &n.n.Function, n.n.IsProcedure, n.n.IsAggregate,
+	)
 	if err != nil {
 		return err
 	}
@@ -247,7 +253,7 @@ func (p *planner) AlterFunctionSetSchema(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -259,7 +265,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(
+		params.ctx, &n.n.Function, n.n.IsProcedure, n.n.IsAggregate,
+	)
 	if err != nil {
 		return err
 	}
@@ -378,12 +386,15 @@ func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { r
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
 func (p *planner) mustGetMutableFunctionForAlter(
-	ctx context.Context, funcObj *tree.FuncObj,
+	ctx context.Context, funcObj *tree.FuncObj, isProcedure, isAggregate bool,
 ) (*funcdesc.Mutable, error) {
 	ol, err := p.matchUDF(ctx, funcObj, true /*required*/)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkRoutineKind(funcObj, ol, isProcedure, isAggregate); err != nil {
+		return nil, err
+	}
 	fnID, err := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	if err != nil {
 		return nil, err
@@ -397,10 +408,12 @@ func (p *planner) mustGetMutableFunctionForAlter(
 
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionOverload {
 	ret := descpb.SchemaDescriptor_FunctionOverload{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetArgs())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
+		ID:          fnDesc.GetID(),
+		ArgTypes:    make([]*types.T, len(fnDesc.GetArgs())),
+		ReturnType:  fnDesc.ReturnType.Type,
+		ReturnSet:   fnDesc.ReturnType.ReturnSet,
+		IsProcedure: fnDesc.IsProcedure,
+		IsAggregate: fnDesc.Aggregate != nil,
 	}
 	for i := range fnDesc.Args {
 		ret.ArgTypes[i] = fnDesc.Args[i].Type
@@ -214,6 +214,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			switch d := t.ConstraintDef.(type) {
 			case *tree.UniqueConstraintTableDef:
+				if err := checkUniqueConstraintDeferrability(d); err != nil {
+					return err
+				}
 				if d.WithoutIndex {
 					if err := addUniqueWithoutIndexTableDef(
 						params.ctx,
@@ -450,6 +453,34 @@ func (n *alterTableNode) startExec(params runParams) error {
 				// 	return err
 				// }
 
+			case *tree.ExcludeConstraintTableDef:
+				if t.ValidationBehavior == tree.ValidationSkip {
+					return pgerror.New(pgcode.FeatureNotSupported,
+						"exclusion constraints cannot be marked NOT VALID")
+				}
+				if err := addExclusionConstraint(
+					params.ctx, params.EvalContext(), params.p.SemaCtx(), n.tableDesc, tn, d, NonEmptyTable,
+				); err != nil {
+					return err
+				}
+				version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
+					return err
+				}
+				// The rows of the table are validated in this transaction, since
+				// the constraint is enforced on writes as soon as it is added.
+				name := n.tableDesc.ExclusionConstraints[len(n.tableDesc.ExclusionConstraints)-1].Name
+				if err := params.p.WithInternalExecutor(params.ctx, func(
+					ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor,
+				) error {
+					return validateExclusionConstraintInTxn(
+						ctx, n.tableDesc, txn, ie, params.p.User(), name, "", /* rowFilter */
+					)
+				}); err != nil {
+					return err
+				}
+				n.tableDesc.FindExclusionConstraintByName(name).Validity = descpb.ConstraintValidity_Validated
+
 			default:
 				return errors.AssertionFailedf(
 					"unsupported constraint: %T", t.ConstraintDef)
@@ -504,6 +535,22 @@ func (n *alterTableNode) startExec(params runParams) error {
 			droppedViews = append(droppedViews, colDroppedViews...)
 		case *tree.AlterTableDropConstraint:
 			name := string(t.Constraint)
+			if ec := n.tableDesc.FindExclusionConstraintByName(name); ec != nil {
+				// The backing index of the constraint is dropped with it.
+				n.tableDesc.DropExclusionConstraint(name)
+				jobDesc := fmt.Sprintf(
+					"removing index %q of exclusion constraint which is being dropped; full details: %s",
+					name, tree.AsStringWithFQNames(n.n, params.Ann()),
+				)
+				if err := params.p.dropIndexByName(
+					params.ctx, tn, tree.UnrestrictedName(name), n.tableDesc, false, /* ifExists */
+					t.DropBehavior, ignoreIdxConstraint, jobDesc,
+				); err != nil {
+					return err
+				}
+				descriptorChanged = true
+				continue
+			}
 			c, _ := n.tableDesc.FindConstraintWithName(name)
 			if c == nil {
 				if t.IfExists {
@@ -522,8 +569,42 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return err
 			}
 
+		case *tree.AlterTableAlterConstraint:
+			if ec := n.tableDesc.FindExclusionConstraintByName(string(t.Constraint)); ec != nil {
+				ec.Deferrability = descpb.ConstraintDeferrabilityValue[t.Deferrability]
+				descriptorChanged = true
+				continue
+			}
+			c, _ := n.tableDesc.FindConstraintWithName(string(t.Constraint))
+			if c == nil {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"constraint %q of relation %q does not exist", t.Constraint, n.tableDesc.Name)
+			}
+			deferrability := descpb.ConstraintDeferrabilityValue[t.Deferrability]
+			if ck := c.AsCheck(); ck != nil {
+				ck.CheckDesc().Deferrability = deferrability
+			} else if fk := c.AsForeignKey(); fk != nil {
+				fk.ForeignKeyDesc().Deferrability = deferrability
+				if err := params.p.updateFKBackReferenceDeferrability(
+					params.ctx, n.tableDesc, fk.ForeignKeyDesc(),
+				); err != nil {
+					return err
+				}
+			} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				uwoi.UniqueWithoutIndexDesc().Deferrability = deferrability
+			} else {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q of relation %q is not a foreign key, check, or unique without index constraint",
+					t.Constraint, n.tableDesc.Name)
+			}
+			descriptorChanged = true
+
 		case *tree.AlterTableValidateConstraint:
 			name := string(t.Constraint)
+			if ec := n.tableDesc.FindExclusionConstraintByName(name); ec != nil {
+				// Exclusion constraints are validated when they are added.
+				continue
+			}
 			c, _ := n.tableDesc.FindConstraintWithName(name)
 			if c == nil {
 				return pgerror.Newf(pgcode.UndefinedObject,
@@ -542,14 +623,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			if ck := c.AsCheck(); ck != nil {
 				if err := params.p.WithInternalExecutor(params.ctx, func(ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor) error {
-					return validateCheckInTxn(ctx, &params.p.semaCtx, params.p.SessionData(), n.tableDesc, txn, ie, ck.GetExpr())
+					return validateCheckInTxn(
+						ctx, &params.p.semaCtx, params.p.SessionData(), n.tableDesc, txn, ie, ck.GetExpr(), "", /* rowFilter */
+					)
 				}); err != nil {
 					return err
 				}
 				ck.CheckDesc().Validity = descpb.ConstraintValidity_Validated
 			} else if fk := c.AsForeignKey(); fk != nil {
 				if err := params.p.WithInternalExecutor(params.ctx, func(ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor) error {
-					return validateFkInTxn(ctx, n.tableDesc, txn, ie, params.p.descCollection, name)
+					return validateFkInTxn(ctx, n.tableDesc, txn, ie, params.p.descCollection, name, "" /* rowFilter */)
 				}); err != nil {
 					return err
 				}
@@ -563,6 +646,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 						ie,
 						params.p.User(),
 						name,
+						"", /* rowFilter */
 					)
 				}); err != nil {
 					return err
@@ -749,6 +833,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 			descriptorChanged = descriptorChanged || descChanged
 
 		case *tree.AlterTableRenameConstraint:
+			if ec := n.tableDesc.FindExclusionConstraintByName(string(t.Constraint)); ec != nil {
+				if t.Constraint == t.NewName {
+					// Nothing to do.
+					break
+				}
+				if err := params.p.CheckPrivilege(params.ctx, n.tableDesc, privilege.CREATE); err != nil {
+					return err
+				}
+				if err := renameExclusionConstraint(n.tableDesc, ec, string(t.NewName)); err != nil {
+					return err
+				}
+				descriptorChanged = true
+				break
+			}
 			constraint, _ := n.tableDesc.FindConstraintWithName(string(t.Constraint))
 			if constraint == nil {
 				return pgerror.Newf(pgcode.UndefinedObject,
@@ -1448,6 +1546,21 @@ func validateConstraintNameIsNotUsed(
 		}
 		return false, pgerror.Newf(pgcode.DuplicateObject, "constraint with name %q already exists", name)
 
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
+		// The backing index of the constraint has the same name as the
+		// constraint.
+		if name == "" {
+			return false, nil
+		}
+		if idx, _ := tableDesc.FindIndexWithName(string(name)); idx != nil {
+			if d.IfNotExists {
+				return true, nil
+			}
+			return false, pgerror.Newf(pgcode.DuplicateRelation, "index with name %q already exists", name)
+		}
+
 	default:
 		return false, errors.AssertionFailedf(
 			"unsupported constraint: %T", cmd.ConstraintDef)
@@ -1456,6 +1569,13 @@ func validateConstraintNameIsNotUsed(
 	if name == "" {
 		return false, nil
 	}
+	if tableDesc.FindExclusionConstraintByName(string(name)) != nil {
+		if hasIfNotExists {
+			return true, nil
+		}
+		return false, pgerror.Newf(pgcode.DuplicateObject,
+			"duplicate constraint name: %q", name)
+	}
 	constraint, _ := tableDesc.FindConstraintWithName(string(name))
 	if constraint == nil {
 		return false, nil
@@ -1519,6 +1639,44 @@ func (p *planner) updateFKBackReferenceName(
 	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
 }
 
+// updateFKBackReferenceDeferrability copies the deferrability of the given
+// foreign key to its backreference on the referenced table.
+func (p *planner) updateFKBackReferenceDeferrability(
+	ctx context.Context, tableDesc *tabledesc.Mutable, ref *descpb.ForeignKeyConstraint,
+) error {
+	var referencedTableDesc *tabledesc.Mutable
+	// We don't want to lookup/edit a second copy of the same table.
+	if tableDesc.ID == ref.ReferencedTableID {
+		referencedTableDesc = tableDesc
+	} else {
+		lookup, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.ReferencedTableID, p.txn)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving referenced table ID %d", ref.ReferencedTableID)
+		}
+		referencedTableDesc = lookup
+	}
+	if referencedTableDesc.Dropped() {
+		// The referenced table is being dropped. No need to modify it further.
+		return nil
+	}
+	for i := range referencedTableDesc.InboundFKs {
+		backref := &referencedTableDesc.InboundFKs[i]
+		if backref.Name == ref.Name && backref.OriginTableID == tableDesc.ID {
+			backref.Deferrability = ref.Deferrability
+			if referencedTableDesc == tableDesc {
+				// The caller writes the table descriptor.
+				return nil
+			}
+			return p.writeSchemaChange(
+				ctx, referencedTableDesc, descpb.InvalidMutationID,
+				fmt.Sprintf("updating referenced FK table %s(%d) for table %s(%d)",
+					referencedTableDesc.Name, referencedTableDesc.ID, tableDesc.Name, tableDesc.ID),
+			)
+		}
+	}
+	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
+}
+
 func dropColumnImpl(
 	params runParams,
 	tn *tree.TableName,
@@ -1661,6 +1819,18 @@ func dropColumnImpl(
 				containsThisColumn = true
 			}
 		}
+		// Exclusion constraints that reference the column are dropped along
+		// with their backing index.
+		if c := tableDesc.FindExclusionConstraintByIndexID(idx.GetID()); c != nil {
+			for _, colID := range c.ColumnIDs {
+				if colID == colToDrop.GetID() {
+					containsThisColumn = true
+				}
+			}
+			if containsThisColumn {
+				tableDesc.DropExclusionConstraint(c.Name)
+			}
+		}
 		// Perform the DROP.
 		if containsThisColumn {
 			idxNamesToDelete = append(idxNamesToDelete, idx.GetName())
@@ -334,6 +334,18 @@ func runPlanInsidePlan(
 	params.p.extendedEvalCtx.ExecCfg.DistSQLPlanner.PlanAndRun(
 		ctx, evalCtx, planCtx, params.p.Txn(), plan.main, recv,
 	)
+	if recv.getError() != nil {
+		return resultWriter.Err()
+	}
+
+	// Run the cascades and checks of any mutations in the plan, which can be
+	// present in the statements of procedures.
+	evalCtxFactory := func() *extendedEvalContext {
+		return params.p.ExtendedEvalContextCopy()
+	}
+	params.p.extendedEvalCtx.ExecCfg.DistSQLPlanner.PlanAndRunCascadesAndChecks(
+		ctx, &plannerCopy, evalCtxFactory, plan, recv,
+	)
 	return resultWriter.Err()
 }
 
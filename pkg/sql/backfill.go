@@ -784,7 +784,7 @@ func (sc *SchemaChanger) validateConstraints(
 				defer func() { collection.ReleaseAll(ctx) }()
 				if ck := c.AsCheck(); ck != nil {
 					if err := validateCheckInTxn(
-						ctx, &semaCtx, evalCtx.SessionData(), desc, txn, ie, ck.GetExpr(),
+						ctx, &semaCtx, evalCtx.SessionData(), desc, txn, ie, ck.GetExpr(), "", /* rowFilter */
 					); err != nil {
 						if ck.IsNotNullColumnConstraint() {
 							// TODO (lucy): This should distinguish between constraint
@@ -795,11 +795,13 @@ func (sc *SchemaChanger) validateConstraints(
 						return err
 					}
 				} else if c.AsForeignKey() != nil {
-					if err := validateFkInTxn(ctx, desc, txn, ie, collection, c.GetName()); err != nil {
+					if err := validateFkInTxn(ctx, desc, txn, ie, collection, c.GetName(), "" /* rowFilter */); err != nil {
 						return err
 					}
 				} else if c.AsUniqueWithoutIndex() != nil {
-					if err := validateUniqueWithoutIndexConstraintInTxn(ctx, desc, txn, ie, evalCtx.SessionData().User(), c.GetName()); err != nil {
+					if err := validateUniqueWithoutIndexConstraintInTxn(
+						ctx, desc, txn, ie, evalCtx.SessionData().User(), c.GetName(), "", /* rowFilter */
+					); err != nil {
 						return err
 					}
 				} else {
@@ -1545,7 +1547,7 @@ func ValidateCheckConstraint(
 
 		return ie.WithSyntheticDescriptors([]catalog.Descriptor{tableDesc}, func() error {
 			return validateCheckExpr(ctx, &semaCtx, txn, sessionData, checkConstraint.GetExpr(),
-				tableDesc.(*tabledesc.Mutable), ie)
+				tableDesc.(*tabledesc.Mutable), ie, "" /* rowFilter */)
 		})
 	})
 }
@@ -2489,7 +2491,9 @@ func runSchemaChangesInTxn(
 		if check := c.AsCheck(); check != nil {
 			if check.GetConstraintValidity() == descpb.ConstraintValidity_Validating {
 				if err := planner.WithInternalExecutor(ctx, func(ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor) error {
-					return validateCheckInTxn(ctx, &planner.semaCtx, planner.SessionData(), tableDesc, txn, ie, check.GetExpr())
+					return validateCheckInTxn(
+						ctx, &planner.semaCtx, planner.SessionData(), tableDesc, txn, ie, check.GetExpr(), "", /* rowFilter */
+					)
 				}); err != nil {
 					return err
 				}
@@ -2519,6 +2523,7 @@ func runSchemaChangesInTxn(
 						ie,
 						planner.User(),
 						c.GetName(),
+						"", /* rowFilter */
 					)
 				}); err != nil {
 					return err
@@ -2577,7 +2582,8 @@ func runSchemaChangesInTxn(
 // validateCheckInTxn validates check constraints within the provided
 // transaction. If the provided table descriptor version is newer than the
 // cluster version, it will be used in the InternalExecutor that performs the
-// validation query.
+// validation query. If rowFilter is not empty, only the rows that satisfy it
+// are validated.
 //
 // TODO (lucy): The special case where the table descriptor version is the same
 // as the cluster version only happens because the query in VALIDATE CONSTRAINT
@@ -2594,6 +2600,7 @@ func validateCheckInTxn(
 	txn *kv.Txn,
 	ie sqlutil.InternalExecutor,
 	checkExpr string,
+	rowFilter string,
 ) error {
 	var syntheticDescs []catalog.Descriptor
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
@@ -2603,7 +2610,7 @@ func validateCheckInTxn(
 	return ie.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateCheckExpr(ctx, semaCtx, txn, sessionData, checkExpr, tableDesc, ie)
+			return validateCheckExpr(ctx, semaCtx, txn, sessionData, checkExpr, tableDesc, ie, rowFilter)
 		})
 }
 
@@ -2649,7 +2656,8 @@ func getTargetTablesAndFk(
 // validateFkInTxn validates foreign key constraints within the provided
 // transaction. If the provided table descriptor version is newer than the
 // cluster version, it will be used in the InternalExecutor that performs the
-// validation query.
+// validation query. If rowFilter is not empty, only the rows that satisfy it
+// are validated.
 //
 // TODO (lucy): The special case where the table descriptor version is the same
 // as the cluster version only happens because the query in VALIDATE CONSTRAINT
@@ -2665,6 +2673,7 @@ func validateFkInTxn(
 	ie sqlutil.InternalExecutor,
 	descsCol *descs.Collection,
 	fkName string,
+	rowFilter string,
 ) error {
 	syntheticDescs, fk, targetTable, err := getTargetTablesAndFk(ctx, srcTable, txn, descsCol, fkName)
 	if err != nil {
@@ -2674,14 +2683,15 @@ func validateFkInTxn(
 	return ie.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateForeignKey(ctx, srcTable, targetTable, fk, ie, txn)
+			return validateForeignKey(ctx, srcTable, targetTable, fk, ie, txn, rowFilter)
 		})
 }
 
 // validateUniqueWithoutIndexConstraintInTxn validates a unique constraint
 // within the provided transaction. If the provided table descriptor version
 // is newer than the cluster version, it will be used in the InternalExecutor
-// that performs the validation query.
+// that performs the validation query. If rowFilter is not empty, only the
+// rows that satisfy it are validated.
 //
 // TODO (lucy): The special case where the table descriptor version is the same
 // as the cluster version only happens because the query in VALIDATE CONSTRAINT
@@ -2697,6 +2707,7 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	ie sqlutil.InternalExecutor,
 	user username.SQLUsername,
 	constraintName string,
+	rowFilter string,
 ) error {
 	var syntheticDescs []catalog.Descriptor
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
@@ -2713,6 +2724,16 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	if uc == nil {
 		return errors.AssertionFailedf("unique constraint %s does not exist", constraintName)
 	}
+	// Only the rows that satisfy both the predicate of the constraint and the
+	// filter are checked.
+	pred := uc.Predicate
+	if rowFilter != "" {
+		if pred != "" {
+			pred = fmt.Sprintf("(%s) AND (%s)", pred, rowFilter)
+		} else {
+			pred = rowFilter
+		}
+	}
 
 	return ie.WithSyntheticDescriptors(
 		syntheticDescs,
@@ -2722,7 +2743,7 @@ func validateUniqueWithoutIndexConstraintInTxn(
 				tableDesc,
 				uc.Name,
 				uc.ColumnIDs,
-				uc.Predicate,
+				pred,
 				ie,
 				txn,
 				user,
@@ -0,0 +1,52 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// procedureTxnController commits and rolls back the transaction in which a
+// procedure is executing. Once the transaction is committed or rolled back,
+// the procedure continues to execute in a new transaction.
+type procedureTxnController interface {
+	commitProcedureTxn(ctx context.Context) error
+	rollbackProcedureTxn(ctx context.Context) error
+}
+
+// callNode implements the CALL statement, which invokes a procedure.
+type callNode struct {
+	proc *tree.RoutineExpr
+}
+
+func (n *callNode) startExec(params runParams) error {
+	input := make(tree.Datums, len(n.proc.Input))
+	for i := range n.proc.Input {
+		var err error
+		input[i], err = eval.Expr(params.ctx, params.EvalContext(), n.proc.Input[i])
+		if err != nil {
+			return err
+		}
+	}
+	// The procedure can only control the transaction if the CALL statement is
+	// the only statement in an implicit transaction. Otherwise,
+	// procTxnController is nil and COMMIT and ROLLBACK statements within the
+	// procedure result in an error.
+	_, err := params.p.evalRoutine(params.ctx, n.proc, input, params.p.procTxnController)
+	return err
+}
+
+func (*callNode) Next(runParams) (bool, error) { return false, nil }
+func (*callNode) Values() tree.Datums          { return nil }
+func (*callNode) Close(context.Context)        {}
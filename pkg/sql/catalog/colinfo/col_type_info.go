@@ -11,8 +11,10 @@
 package colinfo
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -105,7 +107,9 @@ func ValidateColumnDefType(t *types.T) error {
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
 		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
-		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily:
+		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily,
+		types.TSQueryFamily, types.TSVectorFamily, types.RangeFamily, types.MultirangeFamily,
+		types.JsonpathFamily:
 		// These types are OK.
 
 	default:
@@ -130,7 +134,7 @@ func ColumnTypeIsIndexable(t *types.T) bool {
 // using an inverted index.
 func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
-	case types.ArrayFamily, types.StringFamily:
+	case types.ArrayFamily, types.StringFamily, types.RangeFamily, types.MultirangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -149,6 +153,7 @@ func ColumnTypeIsOnlyInvertedIndexable(t *types.T) bool {
 	case types.JsonFamily:
 	case types.GeographyFamily:
 	case types.GeometryFamily:
+	case types.TSVectorFamily:
 	default:
 		return false
 	}
@@ -166,8 +171,44 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		default:
 			return MustBeValueEncoded(semanticType.ArrayContents())
 		}
-	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
+	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily,
+		types.TSQueryFamily, types.TSVectorFamily, types.JsonpathFamily:
 		return true
 	}
 	return false
 }
+
+// CheckColumnTypeIsSupported returns an error if the type of a column
+// definition cannot be used before the cluster is upgraded to the version
+// where the type was introduced. Nodes running an older binary cannot decode
+// values of such types.
+func CheckColumnTypeIsSupported(
+	ctx context.Context, version clusterversion.Handle, t *types.T,
+) error {
+	switch t.Family() {
+	case types.ArrayFamily:
+		return CheckColumnTypeIsSupported(ctx, version, t.ArrayContents())
+
+	case types.TSQueryFamily, types.TSVectorFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1FullTextSearch) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use %s columns",
+				clusterversion.ByKey(clusterversion.V23_1FullTextSearch), t.SQLString())
+		}
+
+	case types.RangeFamily, types.MultirangeFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1RangeTypes) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use %s columns",
+				clusterversion.ByKey(clusterversion.V23_1RangeTypes), t.SQLString())
+		}
+
+	case types.JsonpathFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1Jsonpath) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use %s columns",
+				clusterversion.ByKey(clusterversion.V23_1Jsonpath), t.SQLString())
+		}
+	}
+	return nil
+}
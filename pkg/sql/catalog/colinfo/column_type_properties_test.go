@@ -49,11 +49,16 @@ func TestCanHaveCompositeKeyEncoding(t *testing.T) {
 		{types.Int2, false},
 		{types.Int2Vector, false},
 		{types.Int4, false},
+		{types.Int8Multirange, false},
+		{types.Int8Range, false},
 		{types.IntArray, false},
 		{types.Interval, false},
 		{types.IntervalArray, false},
 		{types.Jsonb, false},
+		{types.Jsonpath, false},
 		{types.Name, false},
+		{types.NumMultirange, true},
+		{types.NumRange, true},
 		{types.Oid, false},
 		{types.String, false},
 		{types.StringArray, false},
@@ -65,6 +70,8 @@ func TestCanHaveCompositeKeyEncoding(t *testing.T) {
 		{types.TimestampArray, false},
 		{types.TimestampTZ, false},
 		{types.TimestampTZArray, false},
+		{types.TSQuery, false},
+		{types.TSVector, false},
 		{types.UUIDArray, false},
 		{types.Unknown, true},
 		{types.Uuid, false},
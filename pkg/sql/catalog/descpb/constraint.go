@@ -34,6 +34,23 @@ var ForeignKeyReferenceMatchValue = [...]tree.CompositeKeyMatchMethod{
 	ForeignKeyReference_PARTIAL: tree.MatchPartial,
 }
 
+// ConstraintDeferrabilityValue allows the conversion from a
+// tree.ConstraintDeferrability to a ConstraintDeferrability.
+var ConstraintDeferrabilityValue = [...]ConstraintDeferrability{
+	tree.ConstraintNotDeferrable:      ConstraintDeferrability_NotDeferrable,
+	tree.ConstraintInitiallyImmediate: ConstraintDeferrability_InitiallyImmediate,
+	tree.ConstraintInitiallyDeferred:  ConstraintDeferrability_InitiallyDeferred,
+}
+
+// TreeConstraintDeferrabilityValue allows the conversion from a
+// ConstraintDeferrability to a tree.ConstraintDeferrability.
+// This should match ConstraintDeferrabilityValue.
+var TreeConstraintDeferrabilityValue = [...]tree.ConstraintDeferrability{
+	ConstraintDeferrability_NotDeferrable:      tree.ConstraintNotDeferrable,
+	ConstraintDeferrability_InitiallyImmediate: tree.ConstraintInitiallyImmediate,
+	ConstraintDeferrability_InitiallyDeferred:  tree.ConstraintInitiallyDeferred,
+}
+
 // String implements the fmt.Stringer interface.
 func (x ForeignKeyReference_Match) String() string {
 	switch x {
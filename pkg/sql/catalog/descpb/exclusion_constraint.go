@@ -0,0 +1,68 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package descpb
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ExclusionEqualsOperator is the equality operator of exclusion
+	// constraints.
+	ExclusionEqualsOperator = treecmp.EQ
+	// ExclusionOverlapsOperator is the overlaps operator of exclusion
+	// constraints.
+	ExclusionOverlapsOperator = treecmp.Overlaps
+)
+
+// exclusionOperators are the comparison operators that can be used in
+// exclusion constraints. Only commutative operators are allowed, so that
+// whether two rows conflict does not depend on which of them is compared to
+// the other.
+var exclusionOperators = []treecmp.ComparisonOperatorSymbol{
+	treecmp.EQ,
+	treecmp.NE,
+	treecmp.Overlaps,
+}
+
+// IsExclusionOperator returns true if the given comparison operator can be
+// used in an exclusion constraint.
+func IsExclusionOperator(op treecmp.ComparisonOperator) bool {
+	for _, sym := range exclusionOperators {
+		if op.Symbol == sym {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPartial returns true if the constraint only applies to the rows that
+// satisfy its predicate.
+func (m *ExclusionConstraint) IsPartial() bool {
+	return m.Predicate != ""
+}
+
+// ComparisonOperator returns the comparison operator that the constraint uses
+// for the column at the given position of ColumnIDs.
+func (m *ExclusionConstraint) ComparisonOperator(i int) (treecmp.ComparisonOperator, error) {
+	if i >= len(m.Operators) {
+		return treecmp.ComparisonOperator{}, errors.AssertionFailedf(
+			"exclusion constraint %q has no operator for column %d", m.Name, i)
+	}
+	for _, sym := range exclusionOperators {
+		if sym.String() == m.Operators[i] {
+			return treecmp.MakeComparisonOperator(sym), nil
+		}
+	}
+	return treecmp.ComparisonOperator{}, errors.AssertionFailedf(
+		"exclusion constraint %q has invalid operator %q", m.Name, m.Operators[i])
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package descpb
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// ToTriggerActionTime converts a tree.TriggerActionTime to its corresponding
+// TriggerDescriptor_ActionTime.
+func ToTriggerActionTime(t tree.TriggerActionTime) TriggerDescriptor_ActionTime {
+	switch t {
+	case tree.TriggerActionTimeBefore:
+		return TriggerDescriptor_BEFORE
+	case tree.TriggerActionTimeAfter:
+		return TriggerDescriptor_AFTER
+	default:
+		panic(errors.AssertionFailedf("unknown trigger action time %s", t))
+	}
+}
+
+// ToTree converts the action time to its corresponding tree.TriggerActionTime.
+func (t TriggerDescriptor_ActionTime) ToTree() tree.TriggerActionTime {
+	switch t {
+	case TriggerDescriptor_BEFORE:
+		return tree.TriggerActionTimeBefore
+	case TriggerDescriptor_AFTER:
+		return tree.TriggerActionTimeAfter
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger action time %d", t))
+	}
+}
+
+// ToTriggerEvent converts a tree.TriggerEventType to its corresponding
+// TriggerDescriptor_Event.
+func ToTriggerEvent(e tree.TriggerEventType) TriggerDescriptor_Event {
+	switch e {
+	case tree.TriggerEventInsert:
+		return TriggerDescriptor_INSERT
+	case tree.TriggerEventUpdate:
+		return TriggerDescriptor_UPDATE
+	case tree.TriggerEventDelete:
+		return TriggerDescriptor_DELETE
+	default:
+		panic(errors.AssertionFailedf("unknown trigger event %s", e))
+	}
+}
+
+// ToTree converts the event to its corresponding tree.TriggerEventType.
+func (e TriggerDescriptor_Event) ToTree() tree.TriggerEventType {
+	switch e {
+	case TriggerDescriptor_INSERT:
+		return tree.TriggerEventInsert
+	case TriggerDescriptor_UPDATE:
+		return tree.TriggerEventUpdate
+	case TriggerDescriptor_DELETE:
+		return tree.TriggerEventDelete
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger event %d", e))
+	}
+}
+
+// TreeEvents returns the events of the trigger as tree.TriggerEvents.
+func (m *TriggerDescriptor) TreeEvents() tree.TriggerEvents {
+	events := make(tree.TriggerEvents, len(m.Events))
+	for i, e := range m.Events {
+		events[i] = e.ToTree()
+	}
+	return events
+}
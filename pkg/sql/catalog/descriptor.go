@@ -565,6 +565,20 @@ type TableDescriptor interface {
 	// GetNextConstraintID returns the next unused constraint ID for this table.
 	// Constraint IDs are unique per table, but not unique globally.
 	GetNextConstraintID() descpb.ConstraintID
+	// GetTriggers returns the row-level triggers defined on the table.
+	GetTriggers() []descpb.TriggerDescriptor
+	// FindTriggerByName returns the trigger on the table with the given name, or
+	// nil if there is no such trigger.
+	FindTriggerByName(name string) *descpb.TriggerDescriptor
+	// GetExclusionConstraints returns the exclusion constraints defined on the
+	// table.
+	GetExclusionConstraints() []descpb.ExclusionConstraint
+	// FindExclusionConstraintByName returns the exclusion constraint on the
+	// table with the given name, or nil if there is no such constraint.
+	FindExclusionConstraintByName(name string) *descpb.ExclusionConstraint
+	// FindExclusionConstraintByIndexID returns the exclusion constraint backed
+	// by the index with the given ID, or nil if there is no such constraint.
+	FindExclusionConstraintByIndexID(id descpb.IndexID) *descpb.ExclusionConstraint
 	// CheckConstraintUsesColumn returns whether the check constraint uses the
 	// specified column.
 	CheckConstraintUsesColumn(cc *descpb.TableDescriptor_CheckConstraint, colID descpb.ColumnID) (bool, error)
@@ -920,6 +934,21 @@ type FunctionDescriptor interface {
 	// GetLanguage returns the language of this function.
 	GetLanguage() catpb.Function_Language
 
+	// GetIsProcedure returns true if the descriptor represents a procedure.
+	GetIsProcedure() bool
+
+	// GetAggregate returns the description of the user-defined aggregate, or nil
+	// if the descriptor does not represent an aggregate.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
+	// GetDependedOnByAggregates returns the IDs of the aggregates which use this
+	// function as a support function.
+	GetDependedOnByAggregates() []descpb.ID
+
+	// GetAggregateSupportFuncIDs returns the IDs of the support functions of
+	// the aggregate, or nil if the descriptor does not represent an aggregate.
+	GetAggregateSupportFuncIDs() []descpb.ID
+
 	// ToCreateExpr converts a function descriptor back to a CREATE FUNCTION
 	// statement. This is mainly used for formatting, e.g. SHOW CREATE FUNCTION.
 	ToCreateExpr() (*tree.CreateFunction, error)
@@ -174,10 +174,30 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.GetAggregateSupportFuncIDs() {
+		ret.Add(id)
+	}
+	for _, id := range desc.DependedOnByAggregates {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
 
+// GetAggregateSupportFuncIDs implements the catalog.FunctionDescriptor
+// interface.
+func (desc *immutable) GetAggregateSupportFuncIDs() []descpb.ID {
+	agg := desc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	ret := []descpb.ID{agg.TransitionFuncID}
+	if agg.FinalFuncID != descpb.InvalidID {
+		ret = append(ret, agg.FinalFuncID)
+	}
+	return ret
+}
+
 // ValidateSelf implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vea.Report(catalog.ValidateName(desc))
@@ -226,6 +246,24 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if agg.TransitionFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if desc.FunctionBody != "" {
+			vea.Report(errors.AssertionFailedf("aggregate has a function body"))
+		}
+	}
+
+	for i, aggID := range desc.DependedOnByAggregates {
+		if aggID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("invalid aggregate id %d in depended-on-by-aggregates references #%d", aggID, i))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -255,6 +293,16 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	for _, fnID := range desc.GetAggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -276,11 +324,55 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	// Aggregates are the only functions which reference other functions, so
+	// all other inbound references are from tables.
 	for _, by := range desc.DependedOnBy {
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
+
+	for _, fnID := range desc.GetAggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			continue
+		}
+		vea.Report(desc.validateOutboundAggregateSupportFuncBackReference(fn))
+	}
+
+	for _, aggID := range desc.DependedOnByAggregates {
+		vea.Report(desc.validateInboundAggregateRef(aggID, vdg))
+	}
+}
+
+func (desc *immutable) validateOutboundAggregateSupportFuncBackReference(
+	fn catalog.FunctionDescriptor,
+) error {
+	for _, id := range fn.GetDependedOnByAggregates() {
+		if id == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("aggregate support function %q (%d) has no corresponding depended-on-by-aggregates back reference",
+		fn.GetName(), fn.GetID())
+}
+
+func (desc *immutable) validateInboundAggregateRef(
+	aggID descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	agg, err := vdg.GetFunctionDescriptor(aggID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depended-on-by aggregate back reference")
+	}
+	if agg.Dropped() {
+		return errors.AssertionFailedf("depended-on-by aggregate %q (%d) is dropped",
+			agg.GetName(), agg.GetID())
+	}
+	if a := agg.GetAggregate(); a != nil {
+		if a.TransitionFuncID == desc.GetID() || a.FinalFuncID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by aggregate %q (%d) has no corresponding support function reference",
+		agg.GetName(), agg.GetID())
 }
 
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
@@ -338,6 +430,27 @@ func (desc *immutable) validateInboundTableRef(
 		}
 	}
 
+	for _, triggerID := range by.TriggerIDs {
+		var found bool
+		for _, trigger := range backRefTbl.GetTriggers() {
+			if trigger.ID == triggerID && trigger.FuncID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger with ID %d",
+				backRefTbl.GetName(), by.ID, triggerID)
+		}
+	}
+
+	// Triggers reference their function directly rather than through the
+	// depends-on references of the table.
+	if len(by.TriggerIDs) > 0 && len(by.ColumnIDs) == 0 && len(by.IndexIDs) == 0 &&
+		len(by.ConstraintIDs) == 0 {
+		return nil
+	}
+
 	for _, id := range backRefTbl.GetDependsOn() {
 		if id == desc.GetID() {
 			return nil
@@ -497,6 +610,68 @@ func (desc *Mutable) SetParentSchemaID(id descpb.ID) {
 	desc.ParentSchemaID = id
 }
 
+// AddTriggerReference adds a back-reference from the trigger with the given ID
+// on the given table.
+func (desc *Mutable) AddTriggerReference(tableID descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == tableID {
+			desc.DependedOnBy[i].TriggerIDs = append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			return
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{
+		ID:         tableID,
+		TriggerIDs: []descpb.TriggerID{triggerID},
+	})
+}
+
+// RemoveTriggerReference removes the back-reference from the trigger with the
+// given ID on the given table. The reference to the table is removed entirely
+// if nothing else in the table references the function.
+func (desc *Mutable) RemoveTriggerReference(tableID descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		ref := &desc.DependedOnBy[i]
+		if ref.ID != tableID {
+			continue
+		}
+		for j := range ref.TriggerIDs {
+			if ref.TriggerIDs[j] == triggerID {
+				ref.TriggerIDs = append(ref.TriggerIDs[:j], ref.TriggerIDs[j+1:]...)
+				break
+			}
+		}
+		if len(ref.TriggerIDs) == 0 && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 {
+			desc.DependedOnBy = append(desc.DependedOnBy[:i], desc.DependedOnBy[i+1:]...)
+		}
+		return
+	}
+}
+
+// AddAggregateReference adds a back-reference from the aggregate with the
+// given ID, which uses the function as a support function.
+func (desc *Mutable) AddAggregateReference(aggID descpb.ID) {
+	for _, id := range desc.DependedOnByAggregates {
+		if id == aggID {
+			return
+		}
+	}
+	desc.DependedOnByAggregates = append(desc.DependedOnByAggregates, aggID)
+}
+
+// RemoveAggregateReference removes the back-reference from the aggregate with
+// the given ID.
+func (desc *Mutable) RemoveAggregateReference(aggID descpb.ID) {
+	for i, id := range desc.DependedOnByAggregates {
+		if id == aggID {
+			desc.DependedOnByAggregates = append(
+				desc.DependedOnByAggregates[:i], desc.DependedOnByAggregates[i+1:]...,
+			)
+			return
+		}
+	}
+}
+
 // ToFuncObj converts the descriptor to a tree.FuncObj.
 func (desc *immutable) ToFuncObj() tree.FuncObj {
 	ret := tree.FuncObj{
@@ -545,11 +720,13 @@ func (desc *immutable) ContainsUserDefinedTypes() bool {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnType:  tree.FixedReturnType(desc.ReturnType.Type),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		Body:        desc.FunctionBody,
+		IsUDF:       true,
+		Language:    desc.getCreateExprLang(),
+		IsProcedure: desc.IsProcedure,
 	}
 
 	argTypes := make(tree.ArgTypes, 0, len(desc.Args))
@@ -568,6 +745,17 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.Aggregate = &tree.RoutineAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFuncID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.Aggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+	}
 
 	return ret, nil
 }
@@ -610,7 +798,8 @@ func (desc *immutable) calledOnNullInput() (bool, error) {
 // ToCreateExpr implements the FunctionDescriptor interface.
 func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	ret = &tree.CreateFunction{
-		FuncName: tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
+		IsProcedure: desc.IsProcedure,
+		FuncName:    tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
 		ReturnType: tree.FuncReturnType{
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
@@ -633,9 +822,13 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	// We only store 5 function attributes at the moment. We may extend the
 	// pre-allocated capacity in the future.
 	ret.Options = make(tree.FunctionOptions, 0, 5)
-	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
-	ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
-	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	// Procedures cannot have volatility, leakproof, or null input behavior
+	// attributes.
+	if !desc.IsProcedure {
+		ret.Options = append(ret.Options, desc.getCreateExprVolatility())
+		ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
+		ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	}
 	ret.Options = append(ret.Options, tree.FunctionBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -645,6 +838,8 @@ func (desc *immutable) getCreateExprLang() tree.FunctionLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
 		return tree.FunctionLangSQL
+	case catpb.Function_PLPGSQL:
+		return tree.FunctionLangPLpgSQL
 	}
 	return 0
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/screl"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -162,6 +163,16 @@ func TableDescs(
 				table.DependedOnBy = append(table.DependedOnBy, ref)
 			}
 		}
+		for i := range table.Triggers {
+			trigger := &table.Triggers[i]
+			if fnRewrite, ok := descriptorRewrites[trigger.FuncID]; ok {
+				trigger.FuncID = fnRewrite.ID
+			} else {
+				return errors.Errorf(
+					"cannot restore %q because function %d of trigger %q was not found",
+					table.Name, trigger.FuncID, trigger.Name)
+			}
+		}
 
 		origUniqueWithoutIndexConstraints := table.UniqueWithoutIndexConstraints
 		table.UniqueWithoutIndexConstraints = nil
@@ -298,6 +309,21 @@ func rewriteFunctionBodyDBNames(fnBody string, newDB string) (string, error) {
 	return fmtCtx.CloseAndGetString(), nil
 }
 
+// rewritePLpgSQLFunctionBodyDBNames is like rewriteFunctionBodyDBNames, but
+// for the body of a PL/pgSQL function.
+func rewritePLpgSQLFunctionBodyDBNames(fnBody string, newDB string) (string, error) {
+	block, err := plpgsqlparser.Parse(fnBody)
+	if err != nil {
+		return "", err
+	}
+	f := tree.NewFmtCtx(
+		tree.FmtParsable,
+		tree.FmtReformatTableNames(makeDBNameReplaceFunc(newDB)),
+	)
+	f.FormatNode(block)
+	return f.CloseAndGetString(), nil
+}
+
 // rewriteTypesInExpr rewrites all explicit ID type references in the input
 // expression string according to rewrites.
 func rewriteTypesInExpr(expr string, rewrites jobspb.DescRewriteMap) (string, error) {
@@ -688,18 +714,30 @@ func FunctionDescs(
 
 		// Rewrite function body.
 		fnBody := fnDesc.FunctionBody
-		if overrideDB != "" {
-			dbNameReplaced, err := rewriteFunctionBodyDBNames(fnDesc.FunctionBody, overrideDB)
+		if fnDesc.Lang == catpb.Function_PLPGSQL {
+			// Sequences in PL/pgSQL function bodies are referenced by name, so
+			// only the database names need to be rewritten.
+			if overrideDB != "" {
+				dbNameReplaced, err := rewritePLpgSQLFunctionBodyDBNames(fnBody, overrideDB)
+				if err != nil {
+					return err
+				}
+				fnDesc.FunctionBody = dbNameReplaced
+			}
+		} else {
+			if overrideDB != "" {
+				dbNameReplaced, err := rewriteFunctionBodyDBNames(fnDesc.FunctionBody, overrideDB)
+				if err != nil {
+					return err
+				}
+				fnBody = dbNameReplaced
+			}
+			seqReplaced, err := rewriteSequencesInFunction(fnBody, descriptorRewrites)
 			if err != nil {
 				return err
 			}
-			fnBody = dbNameReplaced
-		}
-		fnBody, err := rewriteSequencesInFunction(fnBody, descriptorRewrites)
-		if err != nil {
-			return err
+			fnDesc.FunctionBody = seqReplaced
 		}
-		fnDesc.FunctionBody = fnBody
 
 		// Rewrite type IDs.
 		for _, arg := range fnDesc.Args {
@@ -731,6 +769,16 @@ func FunctionDescs(
 					fnDesc.Name, typID)
 			}
 		}
+
+		// Rewrite back-references from tables, such as those of triggers.
+		origRefs := fnDesc.DependedOnBy
+		fnDesc.DependedOnBy = nil
+		for _, ref := range origRefs {
+			if refRewrite, ok := descriptorRewrites[ref.ID]; ok {
+				ref.ID = refRewrite.ID
+				fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, ref)
+			}
+		}
 	}
 	return nil
 }
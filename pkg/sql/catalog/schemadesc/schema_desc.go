@@ -508,6 +508,10 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			},
 			IsUDF:                    true,
 			UDFContainsOnlySignature: true,
+			IsProcedure:              funcDescPb.Overloads[i].IsProcedure,
+		}
+		if funcDescPb.Overloads[i].IsAggregate {
+			overload.Class = tree.AggregateClass
 		}
 		argTypes := make(tree.ArgTypes, 0, len(funcDescPb.Overloads[i].ArgTypes))
 		for _, argType := range funcDescPb.Overloads[i].ArgTypes {
@@ -111,6 +111,7 @@ func (b *CheckConstraintBuilder) Build(
 		ColumnIDs:             colIDs.Ordered(),
 		FromHashShardedColumn: c.FromHashShardedColumn,
 		ConstraintID:          constraintID,
+		Deferrability:         descpb.ConstraintDeferrabilityValue[c.Deferrability],
 	}, nil
 }
 
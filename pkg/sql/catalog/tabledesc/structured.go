@@ -714,6 +714,24 @@ func (desc *Mutable) allocateIndexIDs(columnNames map[string]descpb.ColumnID) er
 			}
 		}
 	}
+
+	// Point new exclusion constraints to their backing index, which has the
+	// same name as the constraint.
+	for i := range desc.ExclusionConstraints {
+		c := &desc.ExclusionConstraints[i]
+		if c.IndexID == 0 {
+			idx, err := desc.FindIndexWithName(c.Name)
+			if err != nil {
+				return errors.NewAssertionErrorWithWrappedErrf(err,
+					"backing index of exclusion constraint %q not found", c.Name)
+			}
+			c.IndexID = idx.GetID()
+		}
+		if c.ConstraintID == 0 {
+			c.ConstraintID = desc.NextConstraintID
+			desc.NextConstraintID++
+		}
+	}
 	return nil
 }
 
@@ -1376,6 +1394,75 @@ func (desc *wrapper) FindFKByName(name string) (*descpb.ForeignKeyConstraint, er
 	return nil, fmt.Errorf("fk %q does not exist", name)
 }
 
+// FindTriggerByName returns the trigger on the table with the given name, or
+// nil if there is no such trigger.
+func (desc *wrapper) FindTriggerByName(name string) *descpb.TriggerDescriptor {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].Name == name {
+			return &desc.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// FindExclusionConstraintByName returns the exclusion constraint on the table
+// with the given name, or nil if there is no such constraint.
+func (desc *wrapper) FindExclusionConstraintByName(name string) *descpb.ExclusionConstraint {
+	for i := range desc.ExclusionConstraints {
+		if desc.ExclusionConstraints[i].Name == name {
+			return &desc.ExclusionConstraints[i]
+		}
+	}
+	return nil
+}
+
+// FindExclusionConstraintByIndexID returns the exclusion constraint backed by
+// the index with the given ID, or nil if there is no such constraint.
+func (desc *wrapper) FindExclusionConstraintByIndexID(
+	id descpb.IndexID,
+) *descpb.ExclusionConstraint {
+	for i := range desc.ExclusionConstraints {
+		if desc.ExclusionConstraints[i].IndexID == id {
+			return &desc.ExclusionConstraints[i]
+		}
+	}
+	return nil
+}
+
+// DropExclusionConstraint removes the exclusion constraint with the given name
+// from the table.
+func (desc *Mutable) DropExclusionConstraint(name string) {
+	for i := range desc.ExclusionConstraints {
+		if desc.ExclusionConstraints[i].Name == name {
+			desc.ExclusionConstraints = append(
+				desc.ExclusionConstraints[:i], desc.ExclusionConstraints[i+1:]...,
+			)
+			return
+		}
+	}
+}
+
+// AddTrigger assigns an ID to the given trigger and adds it to the table.
+func (desc *Mutable) AddTrigger(trigger descpb.TriggerDescriptor) *descpb.TriggerDescriptor {
+	if desc.NextTriggerID == 0 {
+		desc.NextTriggerID = 1
+	}
+	trigger.ID = desc.NextTriggerID
+	desc.NextTriggerID++
+	desc.Triggers = append(desc.Triggers, trigger)
+	return &desc.Triggers[len(desc.Triggers)-1]
+}
+
+// DropTrigger removes the trigger with the given ID from the table.
+func (desc *Mutable) DropTrigger(id descpb.TriggerID) {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].ID == id {
+			desc.Triggers = append(desc.Triggers[:i], desc.Triggers[i+1:]...)
+			return
+		}
+	}
+}
+
 // IsPrimaryIndexDefaultRowID returns whether or not the table's primary
 // index is the default primary key on the hidden rowid column.
 func (desc *wrapper) IsPrimaryIndexDefaultRowID() bool {
@@ -2224,6 +2311,10 @@ func (desc *wrapper) FindAllReferences() (map[descpb.ID]struct{}, error) {
 	for _, c := range desc.DependedOnBy {
 		refs[c.ID] = struct{}{}
 	}
+
+	for i := range desc.Triggers {
+		refs[desc.Triggers[i].FuncID] = struct{}{}
+	}
 	return refs, nil
 }
 
@@ -154,6 +154,11 @@ func MakeColumnDefDescs(
 	if err = colinfo.ValidateColumnDefType(resType); err != nil {
 		return nil, err
 	}
+	if evalCtx != nil && evalCtx.Settings != nil {
+		if err = colinfo.CheckColumnTypeIsSupported(ctx, evalCtx.Settings.Version, resType); err != nil {
+			return nil, err
+		}
+	}
 	col.Type = resType
 
 	if d.HasDefaultExpr() {
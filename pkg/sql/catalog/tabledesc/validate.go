@@ -117,6 +117,10 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add trigger function dependencies.
+	for i := range desc.Triggers {
+		ids.Add(desc.Triggers[i].FuncID)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -194,6 +198,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check that trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundTriggerFuncRef(&desc.Triggers[i], vdg))
+	}
+
 	// Row-level TTL is not compatible with foreign keys.
 	// This check should be in ValidateSelf but interferes with AllocateIDs.
 	if desc.HasRowLevelTTL() {
@@ -272,6 +281,15 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check that trigger functions have matching back-references.
+	for i := range desc.Triggers {
+		fn, _ := vdg.GetFunctionDescriptor(desc.Triggers[i].FuncID)
+		if fn == nil {
+			continue
+		}
+		vea.Report(desc.validateOutboundTriggerFuncRefBackReference(&desc.Triggers[i], fn))
+	}
+
 	// Check relation back-references to relations and functions.
 	for _, by := range desc.DependedOnBy {
 		depDesc, err := vdg.GetDescriptor(by.ID)
@@ -329,6 +347,38 @@ func (desc *wrapper) validateOutboundFuncRefBackReference(ref catalog.FunctionDe
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundTriggerFuncRef(
+	trigger *descpb.TriggerDescriptor, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(trigger.FuncID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err,
+			"invalid function reference in trigger %q", trigger.Name)
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("function %q (%d) of trigger %q is dropped",
+			fn.GetName(), fn.GetID(), trigger.Name)
+	}
+	return nil
+}
+
+func (desc *wrapper) validateOutboundTriggerFuncRefBackReference(
+	trigger *descpb.TriggerDescriptor, fn catalog.FunctionDescriptor,
+) error {
+	for _, dep := range fn.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == trigger.ID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("function %q (%d) of trigger %q has no corresponding depended-on-by back reference",
+		fn.GetName(), fn.GetID(), trigger.Name)
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -707,6 +757,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
+			desc.validateTriggers(),
+			desc.validateExclusionConstraints(columnsByID),
 			desc.validateTableIndexes(columnsByID),
 			desc.validatePartitioning(),
 		}
@@ -932,7 +984,24 @@ func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAc
 		}
 		idToName[c.GetConstraintID()] = c.GetName()
 	}
-
+	for i := range desc.ExclusionConstraints {
+		c := &desc.ExclusionConstraints[i]
+		if c.ConstraintID == 0 || c.ConstraintID >= desc.NextConstraintID {
+			vea.Report(errors.AssertionFailedf(
+				"exclusion constraint %q has invalid ID %d", c.Name, c.ConstraintID))
+		}
+		if _, found := names[c.Name]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate constraint name: %q", c.Name))
+		}
+		names[c.Name] = c.ConstraintID
+		if other, found := idToName[c.ConstraintID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"constraint ID %d in constraint %q already in use by %q",
+				c.ConstraintID, c.Name, other))
+		}
+		idToName[c.ConstraintID] = c.Name
+	}
 }
 
 func (desc *wrapper) validateColumns() error {
@@ -1141,6 +1210,96 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validateTriggers validates that triggers are well formed. Checks include
+// validating the trigger IDs, names, events and function references.
+func (desc *wrapper) validateTriggers() error {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.Name == "" {
+			return errors.AssertionFailedf("empty trigger name")
+		}
+		if _, ok := names[trigger.Name]; ok {
+			return errors.AssertionFailedf("duplicate trigger name: %q", trigger.Name)
+		}
+		names[trigger.Name] = struct{}{}
+		if trigger.ID == 0 || trigger.ID >= desc.NextTriggerID {
+			return errors.AssertionFailedf("trigger %q has invalid ID %d", trigger.Name, trigger.ID)
+		}
+		if _, ok := ids[trigger.ID]; ok {
+			return errors.AssertionFailedf("trigger %q has duplicate ID %d", trigger.Name, trigger.ID)
+		}
+		ids[trigger.ID] = struct{}{}
+		if trigger.FuncID == descpb.InvalidID {
+			return errors.AssertionFailedf("trigger %q has invalid function ID %d", trigger.Name, trigger.FuncID)
+		}
+		if len(trigger.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", trigger.Name)
+		}
+	}
+	return nil
+}
+
+// validateExclusionConstraints validates that exclusion constraints are well
+// formed. Checks include validating the column IDs, the operators, the backing
+// index and the column references of the predicate.
+func (desc *wrapper) validateExclusionConstraints(
+	columnsByID map[descpb.ColumnID]catalog.Column,
+) error {
+	for i := range desc.ExclusionConstraints {
+		c := &desc.ExclusionConstraints[i]
+		if len(c.Name) == 0 {
+			return pgerror.Newf(pgcode.Syntax, "empty exclusion constraint name")
+		}
+		if len(c.ColumnIDs) == 0 {
+			return errors.AssertionFailedf("exclusion constraint %q has no columns", c.Name)
+		}
+		if len(c.ColumnIDs) != len(c.Operators) {
+			return errors.AssertionFailedf(
+				"exclusion constraint %q has %d columns but %d operators",
+				c.Name, len(c.ColumnIDs), len(c.Operators),
+			)
+		}
+		for j, colID := range c.ColumnIDs {
+			if _, ok := columnsByID[colID]; !ok {
+				return errors.Newf(
+					"exclusion constraint %q contains unknown column \"%d\"", c.Name, colID,
+				)
+			}
+			if _, err := c.ComparisonOperator(j); err != nil {
+				return err
+			}
+		}
+		idx, err := desc.FindIndexWithID(c.IndexID)
+		if err != nil {
+			return errors.Wrapf(err, "backing index of exclusion constraint %q", c.Name)
+		}
+		if idx.GetName() != c.Name {
+			return errors.AssertionFailedf(
+				"exclusion constraint %q is backed by index %q", c.Name, idx.GetName(),
+			)
+		}
+		if c.IsPartial() {
+			expr, err := parser.ParseExpr(c.Predicate)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf(
+					"partial exclusion constraint %q refers to unknown columns in predicate: %s",
+					c.Name, c.Predicate,
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -39,7 +39,8 @@ import (
 )
 
 // validateCheckExpr verifies that the given CHECK expression returns true
-// for all the rows in the table.
+// for all the rows in the table. If rowFilter is not empty, only the rows that
+// satisfy it are checked.
 //
 // It operates entirely on the current goroutine and is thus able to
 // reuse an existing client.Txn safely.
@@ -51,6 +52,7 @@ func validateCheckExpr(
 	exprStr string,
 	tableDesc *tabledesc.Mutable,
 	ie sqlutil.InternalExecutor,
+	rowFilter string,
 ) error {
 	expr, err := schemaexpr.FormatExprForDisplay(ctx, tableDesc, exprStr, semaCtx, sessionData, tree.FmtParsable)
 	if err != nil {
@@ -58,7 +60,11 @@ func validateCheckExpr(
 	}
 	colSelectors := tabledesc.ColumnsSelectors(tableDesc.AccessibleColumns())
 	columns := tree.AsStringWithFlags(&colSelectors, tree.FmtSerializable)
-	queryStr := fmt.Sprintf(`SELECT %s FROM [%d AS t] WHERE NOT (%s) LIMIT 1`, columns, tableDesc.GetID(), exprStr)
+	where := fmt.Sprintf("NOT (%s)", exprStr)
+	if rowFilter != "" {
+		where += fmt.Sprintf(" AND (%s)", rowFilter)
+	}
+	queryStr := fmt.Sprintf(`SELECT %s FROM [%d AS t] WHERE %s LIMIT 1`, columns, tableDesc.GetID(), where)
 	log.Infof(ctx, "validating check constraint %q with query %q", expr, queryStr)
 	rows, err := ie.QueryRowEx(
 		ctx,
@@ -82,7 +88,8 @@ func validateCheckExpr(
 // matchFullUnacceptableKeyQuery generates and returns a query for rows that are
 // disallowed given the specified MATCH FULL composite FK reference, i.e., rows
 // in the referencing table where the key contains both null and non-null
-// values.
+// values. If rowFilter is not empty, only the rows that satisfy it are
+// returned.
 //
 // For example, a FK constraint on columns (a_id, b_id) with an index c_id on
 // the table "child" would require the following query:
@@ -94,7 +101,10 @@ func validateCheckExpr(
 //
 // LIMIT 1;
 func matchFullUnacceptableKeyQuery(
-	srcTbl catalog.TableDescriptor, fk *descpb.ForeignKeyConstraint, limitResults bool,
+	srcTbl catalog.TableDescriptor,
+	fk *descpb.ForeignKeyConstraint,
+	rowFilter string,
+	limitResults bool,
 ) (sql string, colNames []string, _ error) {
 	nCols := len(fk.OriginColumnIDs)
 	srcCols := make([]string, nCols)
@@ -130,17 +140,22 @@ func matchFullUnacceptableKeyQuery(
 		}
 	}
 
+	filter := ""
+	if rowFilter != "" {
+		filter = fmt.Sprintf(" AND (%s)", rowFilter)
+	}
 	limit := ""
 	if limitResults {
 		limit = " LIMIT 1"
 	}
 	return fmt.Sprintf(
-		`SELECT %[1]s FROM [%[2]d AS tbl] WHERE (%[3]s) AND (%[4]s) %[5]s`,
+		`SELECT %[1]s FROM [%[2]d AS tbl] WHERE (%[3]s) AND (%[4]s)%[5]s %[6]s`,
 		strings.Join(returnedCols, ","),              // 1
 		srcTbl.GetID(),                               // 2
 		strings.Join(srcNullExistsClause, " OR "),    // 3
 		strings.Join(srcNotNullExistsClause, " OR "), // 4
-		limit, // 5
+		filter, // 5
+		limit,  // 6
 	), returnedCols, nil
 }
 
@@ -148,7 +163,8 @@ func matchFullUnacceptableKeyQuery(
 // specified FK constraint, i.e., rows in the referencing table with no matching
 // key in the referenced table. Rows in the referencing table with any null
 // values in the key are excluded from matching (for both MATCH FULL and MATCH
-// SIMPLE).
+// SIMPLE). If rowFilter is not empty, only the rows in the referencing table
+// that satisfy it are checked.
 //
 // For example, a FK constraint on columns (a_id, b_id) on the table "child",
 // referencing columns (a, b) on the table "parent", would require the following
@@ -172,6 +188,7 @@ func nonMatchingRowQuery(
 	srcTbl catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	targetTbl catalog.TableDescriptor,
+	rowFilter string,
 	limitResults bool,
 ) (sql string, originColNames []string, _ error) {
 	originColNames, err := srcTbl.NamesForColumnIDs(fk.OriginColumnIDs)
@@ -219,6 +236,9 @@ func nonMatchingRowQuery(
 		targetCols[i] = fmt.Sprintf("t.%s", tree.NameString(referencedColNames[i]))
 		on[i] = fmt.Sprintf("%s = %s", qualifiedSrcCols[i], targetCols[i])
 	}
+	if rowFilter != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", rowFilter))
+	}
 
 	limit := ""
 	if limitResults {
@@ -244,7 +264,8 @@ func nonMatchingRowQuery(
 }
 
 // validateForeignKey verifies that all the rows in the srcTable
-// have a matching row in their referenced table.
+// have a matching row in their referenced table. If rowFilter is not empty,
+// only the rows in the srcTable that satisfy it are checked.
 //
 // It operates entirely on the current goroutine and is thus able to
 // reuse an existing kv.Txn safely.
@@ -255,6 +276,7 @@ func validateForeignKey(
 	fk *descpb.ForeignKeyConstraint,
 	ie sqlutil.InternalExecutor,
 	txn *kv.Txn,
+	rowFilter string,
 ) error {
 	nCols := len(fk.OriginColumnIDs)
 
@@ -268,7 +290,7 @@ func validateForeignKey(
 	// (The matching options only matter for FKs with more than one column.)
 	if nCols > 1 && fk.Match == descpb.ForeignKeyReference_FULL {
 		query, colNames, err := matchFullUnacceptableKeyQuery(
-			srcTable, fk, true, /* limitResults */
+			srcTable, fk, rowFilter, true, /* limitResults */
 		)
 		if err != nil {
 			return err
@@ -295,7 +317,7 @@ func validateForeignKey(
 		}
 	}
 	query, colNames, err := nonMatchingRowQuery(
-		srcTable, fk, targetTable,
+		srcTable, fk, targetTable, rowFilter,
 		true, /* limitResults */
 	)
 	if err != nil {
@@ -199,6 +199,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 	case core.Ordinality != nil:
 		return nil
 
+	case core.Expand != nil:
+		return nil
+
 	case core.HashJoiner != nil:
 		if !core.HashJoiner.OnExpr.Empty() && core.HashJoiner.Type != descpb.InnerJoin {
 			return errNonInnerHashJoinWithOnExpr
@@ -296,6 +299,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 		// (#55408), so we fallback to the row-by-row engine.
 		return errChangeFrontierWrap
 	case core.Ordinality != nil:
+	case core.Expand != nil:
 	case core.BulkRowWriter != nil:
 	case core.InvertedFilterer != nil:
 	case core.InvertedJoiner != nil:
@@ -1031,6 +1035,23 @@ func NewColOperator(
 			)
 			result.ColumnTypes = appendOneType(spec.Input[0].ColumnTypes, types.Int)
 
+		case core.Expand != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			expandSpec := core.Expand
+			groupingSets := make([]util.FastIntSet, len(expandSpec.GroupingSets))
+			for i := range expandSpec.GroupingSets {
+				for _, col := range expandSpec.GroupingSets[i].Cols {
+					groupingSets[i].Add(int(col))
+				}
+			}
+			result.Root = colexec.NewExpandOp(
+				getStreamingAllocator(ctx, args), inputs[0].Root, spec.Input[0].ColumnTypes,
+				expandSpec.GroupCols, groupingSets,
+			)
+			result.ColumnTypes = colexec.ExpandOutputTypes(spec.Input[0].ColumnTypes, expandSpec.GroupCols)
+
 		case core.HashJoiner != nil:
 			if err := checkNumIn(inputs, 2); err != nil {
 				return r, err
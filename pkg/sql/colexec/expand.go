@@ -0,0 +1,146 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+)
+
+// expandOp is an operator that implements the row multiplication required by
+// GROUPING SETS, ROLLUP and CUBE. For each input batch, it emits one batch per
+// grouping set. Each output batch contains all of the input columns, followed
+// by a copy of each of the grouping columns, followed by an INT column with the
+// ordinal of the grouping set. A copy is NULL if the corresponding input
+// column is not part of the grouping set.
+//
+// No tuples are copied: the output batches reference the vectors of the input
+// batch, and the NULL copies and the grouping set ordinals are served from
+// vectors that are allocated once.
+type expandOp struct {
+	colexecop.OneInputHelper
+
+	allocator  *colmem.Allocator
+	inputTypes []*types.T
+	groupCols  []uint32
+	// groupingSets contains, for each grouping set, the set of input columns
+	// that are part of the set.
+	groupingSets []util.FastIntSet
+
+	// nullVecs contains an all-NULL vector for each of the groupCols.
+	nullVecs []coldata.Vec
+	// setIDVecs contains, for each grouping set, a vector filled with the
+	// ordinal of that set.
+	setIDVecs []coldata.Vec
+
+	// windowedBatch is the output batch of the operator.
+	windowedBatch coldata.Batch
+	// inputBatch is the input batch that is currently being expanded, and
+	// nextSet is the ordinal of the next grouping set to emit for it.
+	inputBatch coldata.Batch
+	nextSet    int
+}
+
+var _ colexecop.Operator = &expandOp{}
+
+// NewExpandOp returns a new expand operator. groupingSets must contain, for
+// each grouping set, the subset of groupCols that are part of the set.
+func NewExpandOp(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	groupCols []uint32,
+	groupingSets []util.FastIntSet,
+) colexecop.Operator {
+	return &expandOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		inputTypes:     inputTypes,
+		groupCols:      groupCols,
+		groupingSets:   groupingSets,
+	}
+}
+
+// ExpandOutputTypes returns the types of the columns produced by an expand
+// operator with the given input types and grouping columns.
+func ExpandOutputTypes(inputTypes []*types.T, groupCols []uint32) []*types.T {
+	outputTypes := make([]*types.T, 0, len(inputTypes)+len(groupCols)+1)
+	outputTypes = append(outputTypes, inputTypes...)
+	for _, col := range groupCols {
+		outputTypes = append(outputTypes, inputTypes[col])
+	}
+	return append(outputTypes, types.Int)
+}
+
+func (e *expandOp) Init(ctx context.Context) {
+	if !e.InitHelper.Init(ctx) {
+		return
+	}
+	e.Input.Init(e.Ctx)
+
+	capacity := coldata.BatchSize()
+	outputTypes := ExpandOutputTypes(e.inputTypes, e.groupCols)
+	e.windowedBatch = e.allocator.NewMemBatchNoCols(outputTypes, capacity)
+	e.nullVecs = make([]coldata.Vec, len(e.groupCols))
+	for i, col := range e.groupCols {
+		e.nullVecs[i] = e.allocator.NewMemColumn(e.inputTypes[col], capacity)
+		e.nullVecs[i].Nulls().SetNulls()
+	}
+	e.setIDVecs = make([]coldata.Vec, len(e.groupingSets))
+	for i := range e.groupingSets {
+		e.setIDVecs[i] = e.allocator.NewMemColumn(types.Int, capacity)
+		col := e.setIDVecs[i].Int64()
+		for j := range col {
+			col[j] = int64(i)
+		}
+	}
+}
+
+func (e *expandOp) Next() coldata.Batch {
+	for e.inputBatch == nil || e.nextSet == len(e.groupingSets) {
+		e.inputBatch = e.Input.Next()
+		e.nextSet = 0
+		if e.inputBatch.Length() == 0 {
+			e.inputBatch = nil
+			return coldata.ZeroBatch
+		}
+	}
+
+	numInputCols := len(e.inputTypes)
+	set := e.groupingSets[e.nextSet]
+	for i := 0; i < numInputCols; i++ {
+		e.windowedBatch.ReplaceCol(e.inputBatch.ColVec(i), i)
+	}
+	for i, col := range e.groupCols {
+		if set.Contains(int(col)) {
+			e.windowedBatch.ReplaceCol(e.inputBatch.ColVec(int(col)), numInputCols+i)
+		} else {
+			e.windowedBatch.ReplaceCol(e.nullVecs[i], numInputCols+i)
+		}
+	}
+	e.windowedBatch.ReplaceCol(e.setIDVecs[e.nextSet], numInputCols+len(e.groupCols))
+
+	n := e.inputBatch.Length()
+	if sel := e.inputBatch.Selection(); sel != nil {
+		e.windowedBatch.SetSelection(true)
+		copy(e.windowedBatch.Selection()[:n], sel[:n])
+	} else {
+		e.windowedBatch.SetSelection(false)
+	}
+	e.windowedBatch.SetLength(n)
+	e.nextSet++
+	return e.windowedBatch
+}
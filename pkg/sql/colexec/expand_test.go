@@ -0,0 +1,80 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestExpand(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	typs := []*types.T{types.Int, types.Int, types.Int}
+	tcs := []struct {
+		groupCols    []uint32
+		groupingSets []util.FastIntSet
+		tuples       colexectestutils.Tuples
+		expected     colexectestutils.Tuples
+	}{
+		{
+			// ROLLUP (a, b)
+			groupCols: []uint32{0, 1},
+			groupingSets: []util.FastIntSet{
+				util.MakeFastIntSet(0, 1), util.MakeFastIntSet(0), {},
+			},
+			tuples: colexectestutils.Tuples{{1, 2, 3}, {4, 5, 6}},
+			expected: colexectestutils.Tuples{
+				{1, 2, 3, 1, 2, 0},
+				{4, 5, 6, 4, 5, 0},
+				{1, 2, 3, 1, nil, 1},
+				{4, 5, 6, 4, nil, 1},
+				{1, 2, 3, nil, nil, 2},
+				{4, 5, 6, nil, nil, 2},
+			},
+		},
+		{
+			// GROUPING SETS ((b), (c))
+			groupCols: []uint32{1, 2},
+			groupingSets: []util.FastIntSet{
+				util.MakeFastIntSet(1), util.MakeFastIntSet(2),
+			},
+			tuples: colexectestutils.Tuples{{1, nil, 3}},
+			expected: colexectestutils.Tuples{
+				{1, nil, 3, nil, nil, 0},
+				{1, nil, 3, nil, 3, 1},
+			},
+		},
+		{
+			// No grouping sets produce no rows.
+			groupCols:    []uint32{0},
+			groupingSets: nil,
+			tuples:       colexectestutils.Tuples{{1, 2, 3}},
+			expected:     colexectestutils.Tuples{},
+		},
+	}
+
+	for _, tc := range tcs {
+		colexectestutils.RunTestsWithoutAllNullsInjection(
+			t, testAllocator, []colexectestutils.Tuples{tc.tuples}, [][]*types.T{typs}, tc.expected,
+			colexectestutils.UnorderedVerifier,
+			func(input []colexecop.Operator) (colexecop.Operator, error) {
+				return NewExpandOp(testAllocator, input[0], typs, tc.groupCols, tc.groupingSets), nil
+			},
+		)
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -1287,6 +1289,29 @@ type connExecutor struct {
 		// transaction has been executed.
 		firstStmtExecuted bool
 
+		// procedureCommitted indicates that a procedure invoked by the current
+		// statement has committed a transaction. The statement cannot be retried
+		// automatically once its effects are committed.
+		procedureCommitted bool
+
+		// stmtPartialRetry indicates that the statement that was just executed
+		// encountered a retryable error which only requires that statement, and
+		// not the whole transaction, to be retried. The transaction has already
+		// been rolled back to the start of the statement, and the statement will
+		// be executed again. See prepareStmtForPartialRetry.
+		stmtPartialRetry bool
+
+		// stmtPartialRetryCount is the number of times that the current statement
+		// has been retried after a retryable error which only required the
+		// statement to be retried.
+		stmtPartialRetryCount int
+
+		// deferredConstraints tracks the deferrable constraints whose checks
+		// are deferred until the current transaction commits, along with the
+		// modes set by SET CONSTRAINTS. It is only used in explicit
+		// transactions.
+		deferredConstraints eval.DeferredConstraints
+
 		// numDDL keeps track of how many DDL statements have been
 		// executed so far.
 		numDDL int
@@ -1695,6 +1720,8 @@ func (ns *prepStmtNamespace) resetTo(
 // (e.g. onTxnFinish() and onTxnRestart()).
 func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 	ex.extraTxnState.firstStmtExecuted = false
+	ex.extraTxnState.procedureCommitted = false
+	ex.extraTxnState.deferredConstraints.Reset()
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 
 	if ex.extraTxnState.fromOuterTxn {
@@ -2157,10 +2184,18 @@ func (ex *connExecutor) execCmd() error {
 		// transaction. Similarly, if a txn just ended, we don't want to run in its
 		// context any more.
 		ctx = ex.Ctx()
+	} else if ex.extraTxnState.stmtPartialRetry {
+		// The statement will be executed again, without any changes to the
+		// transaction's state.
+		advInfo = advanceInfo{code: stayInPlace}
 	} else {
 		// If no event was generated synthesize an advance code.
 		advInfo = advanceInfo{code: advanceOne}
 	}
+	ex.extraTxnState.stmtPartialRetry = false
+	if advInfo.code != stayInPlace {
+		ex.extraTxnState.stmtPartialRetryCount = 0
+	}
 
 	// Decide if we need to close the result or not. We don't need to do it if
 	// we're staying in place or rewinding - the statement will be executed
@@ -2254,6 +2289,11 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 	if _, ok := ex.machine.CurState().(stateOpen); !ok {
 		return nil
 	}
+	// A statement that is being retried without restarting the transaction
+	// doesn't influence the rewind point.
+	if advInfo.code == stayInPlace && advInfo.txnEvent.eventType == noEvent {
+		return nil
+	}
 	if advInfo.txnEvent.eventType == txnStart ||
 		advInfo.txnEvent.eventType == txnRestart {
 		var nextPos CmdPos
@@ -2588,8 +2628,9 @@ func (ex *connExecutor) getRewindTxnCapability() (rewindCapability, bool) {
 	cl := ex.clientComm.LockCommunication()
 
 	// If we already delivered results at or past the start position, we can't
-	// rewind.
-	if cl.ClientPos() >= ex.extraTxnState.txnRewindPos {
+	// rewind. We also can't rewind if a procedure has already committed some of
+	// the effects of the current statement.
+	if cl.ClientPos() >= ex.extraTxnState.txnRewindPos || ex.extraTxnState.procedureCommitted {
 		cl.Close()
 		return rewindCapability{}, false
 	}
@@ -2689,9 +2730,14 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
-		return errors.AssertionFailedf(
-			"unknown isolation level: %s", errors.Safe(modes.Isolation))
+	if modes.Isolation != tree.UnspecifiedIsolation {
+		level, err := ex.txnIsolationLevelToKV(ctx, modes.Isolation)
+		if err != nil {
+			return err
+		}
+		if err := ex.state.setIsoLevel(level); err != nil {
+			return pgerror.WithCandidateCode(err, pgcode.ActiveSQLTransaction)
+		}
 	}
 	rwMode := modes.ReadWriteMode
 	if modes.AsOf.Expr != nil && asOfTs.IsEmpty() {
@@ -2735,6 +2781,37 @@ func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) ro
 	return txnPriorityToProto(mode)
 }
 
+// txnIsolationLevelToKV maps the isolation level of a SQL transaction to the
+// isolation level of the underlying KV transaction. Isolation levels that are
+// not supported, or not enabled, are upgraded to the next stronger one.
+//
+// READ COMMITTED is only used once the cluster version is at least
+// V23_1ReadCommittedIsolation, because older nodes ignore the isolation level
+// of a transaction and would evaluate it as SERIALIZABLE. It also requires
+// V23_1SharedLocks, because READ COMMITTED transactions rely on replicated
+// Shared locks to protect the rows read by foreign key checks.
+func (ex *connExecutor) txnIsolationLevelToKV(
+	ctx context.Context, level tree.IsolationLevel,
+) (isolation.Level, error) {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	switch level {
+	case tree.UnspecifiedIsolation, tree.SerializableIsolation:
+		return isolation.Serializable, nil
+	case tree.ReadCommittedIsolation:
+		st := ex.server.cfg.Settings
+		if allowReadCommittedIsolation.Get(&st.SV) &&
+			st.Version.IsActive(ctx, clusterversion.V23_1ReadCommittedIsolation) &&
+			st.Version.IsActive(ctx, clusterversion.V23_1SharedLocks) {
+			return isolation.ReadCommitted, nil
+		}
+		return isolation.Serializable, nil
+	default:
+		return 0, errors.AssertionFailedf("unknown isolation level: %s", errors.Safe(level))
+	}
+}
+
 // QualityOfService returns the QoSLevel session setting if the session
 // settings are populated, otherwise the default QoSLevel.
 func (ex *connExecutor) QualityOfService() sessiondatapb.QoSLevel {
@@ -2848,6 +2925,14 @@ func (ex *connExecutor) resetEvalCtx(evalCtx *extendedEvalContext, txn *kv.Txn,
 	evalCtx.SkipNormalize = false
 	evalCtx.SchemaChangerState = ex.extraTxnState.schemaChangerState
 	evalCtx.DescIDGenerator = ex.getDescIDGenerator()
+	// Constraint checks can only be deferred in explicit transactions. In an
+	// implicit transaction, the end of the statement is the end of the
+	// transaction, so all constraints are checked immediately.
+	if ex.implicitTxn() {
+		evalCtx.DeferredConstraints = nil
+	} else {
+		evalCtx.DeferredConstraints = &ex.extraTxnState.deferredConstraints
+	}
 
 	// If we are retrying due to an unsatisfiable timestamp bound which is
 	// retriable, it means we were unable to serve the previous minimum timestamp
@@ -2986,6 +3071,8 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		}
 	case txnStart:
 		ex.extraTxnState.firstStmtExecuted = false
+		ex.extraTxnState.procedureCommitted = false
+		ex.extraTxnState.deferredConstraints.Reset()
 		ex.recordTransactionStart(advInfo.txnEvent.txnID)
 		// Start of the transaction, so no statements were executed earlier.
 		// Bump the txn counter for logging.
@@ -225,8 +225,10 @@ func (ex *connExecutor) execPortal(
 		// the fact whether an error occurred or not - if it did, we
 		// still don't want to re-execute the portal from scratch.
 		// The current statement may have just closed and deleted the portal,
-		// so only exhaust it if it still exists.
-		if _, ok := ex.extraTxnState.prepStmtsNamespace.portals[portalName]; ok {
+		// so only exhaust it if it still exists. The portal is also not
+		// exhausted if the statement is going to be retried.
+		if _, ok := ex.extraTxnState.prepStmtsNamespace.portals[portalName]; ok &&
+			!ex.extraTxnState.stmtPartialRetry {
 			ex.exhaustPortal(portalName)
 		}
 		return ev, payload, err
@@ -704,6 +706,23 @@ func (ex *connExecutor) execStmtInOpenState(
 		return makeErrEvent(err)
 	}
 
+	// Transactions whose isolation level takes a new read snapshot for each
+	// statement establish that snapshot now. We also create a savepoint, so
+	// that the statement can be retried at a newer snapshot if it encounters a
+	// conflict, without restarting the whole transaction. KV performs such
+	// partial retries for every transaction with a per-statement read
+	// snapshot, so the savepoint is taken regardless of whether the
+	// transaction is implicit or run by the internal executor.
+	var stmtSavepoint kv.SavepointToken
+	if ex.state.mu.txn.IsoLevel().PerStatementReadSnapshot() {
+		if err := ex.state.mu.txn.StepReadTimestamp(ctx); err != nil {
+			return makeErrEvent(err)
+		}
+		if stmtSavepoint, err = ex.state.mu.txn.CreateSavepoint(ctx); err != nil {
+			return makeErrEvent(err)
+		}
+	}
+
 	if err := p.semaCtx.Placeholders.Assign(pinfo, stmt.NumPlaceholders); err != nil {
 		return makeErrEvent(err)
 	}
@@ -714,7 +733,13 @@ func (ex *connExecutor) execStmtInOpenState(
 
 	p.autoCommit = canAutoCommit && !ex.server.cfg.TestingKnobs.DisableAutoCommitDuringExec
 	p.extendedEvalCtx.TxnIsSingleStmt = canAutoCommit && !ex.extraTxnState.firstStmtExecuted
+	firstStmtExecuted := ex.extraTxnState.firstStmtExecuted
 	ex.extraTxnState.firstStmtExecuted = true
+	if _, isCall := ast.(*tree.Call); isCall && p.extendedEvalCtx.TxnIsSingleStmt {
+		// A procedure can only commit or roll back the transaction if it is
+		// invoked by the only statement in an implicit transaction.
+		p.procTxnController = ex
+	}
 
 	var stmtThresholdSpan *tracing.Span
 	alreadyRecording := ex.transitionCtx.sessionTracing.Enabled()
@@ -752,6 +777,13 @@ func (ex *connExecutor) execStmtInOpenState(
 	}
 
 	if err := res.Err(); err != nil {
+		if stmtSavepoint != nil && ex.prepareStmtForPartialRetry(ctx, err, stmtSavepoint) {
+			// The statement will be executed again; see execCmd. The retry
+			// must be planned the same way as the first attempt, so it is
+			// still considered the first statement if it was.
+			ex.extraTxnState.firstStmtExecuted = firstStmtExecuted
+			return nil, nil, nil
+		}
 		return makeErrEvent(err)
 	}
 
@@ -777,6 +809,61 @@ func (ex *connExecutor) execStmtInOpenState(
 	return nil, nil, nil
 }
 
+// maxStmtPartialRetries is the maximum number of times that a statement is
+// retried after a retryable error which only requires the statement to be
+// retried, before the error is handled by retrying the whole transaction
+// instead.
+const maxStmtPartialRetries = 10
+
+// prepareStmtForPartialRetry checks whether the error encountered by the
+// current statement only requires the statement, and not the whole
+// transaction, to be retried. If so, and if none of the statement's results
+// have been delivered to the client yet, the transaction is rolled back to the
+// provided savepoint, which was established at the start of the statement, and
+// the statement is marked to be executed again. Returns true if the statement
+// will be retried.
+func (ex *connExecutor) prepareStmtForPartialRetry(
+	ctx context.Context, err error, stmtSavepoint kv.SavepointToken,
+) bool {
+	var retryErr *roachpb.TransactionRetryWithProtoRefreshError
+	if !errors.As(err, &retryErr) || !retryErr.PartialRetry {
+		return false
+	}
+	if ex.extraTxnState.stmtPartialRetryCount >= maxStmtPartialRetries {
+		log.VEventf(ctx, 2, "statement retried %d times, retrying the transaction instead",
+			ex.extraTxnState.stmtPartialRetryCount)
+		return false
+	}
+	_, pos, cmdErr := ex.stmtBuf.CurCmd()
+	if cmdErr != nil {
+		return false
+	}
+	cl := ex.clientComm.LockCommunication()
+	defer cl.Close()
+	if cl.ClientPos() >= pos || ex.extraTxnState.procedureCommitted {
+		return false
+	}
+	// Roll back the statement's writes before clearing the retryable error. If
+	// the rollback fails, the error is left in place and the whole transaction
+	// is retried, which discards the statement's writes along with all others.
+	txn := ex.state.mu.txn
+	if rollbackErr := txn.RollbackToSavepoint(ctx, stmtSavepoint); rollbackErr != nil {
+		log.VEventf(ctx, 2, "unable to roll back statement for partial retry: %v", rollbackErr)
+		return false
+	}
+	if prepErr := txn.PrepareForPartialRetry(ctx); prepErr != nil {
+		log.VEventf(ctx, 2, "unable to prepare statement for partial retry: %v", prepErr)
+		return false
+	}
+	// Discard the results of the statement that have been buffered, but not
+	// yet delivered to the client.
+	cl.RTrim(ctx, pos)
+	log.VEventf(ctx, 2, "retrying statement after retryable error: %v", retryErr)
+	ex.extraTxnState.stmtPartialRetry = true
+	ex.extraTxnState.stmtPartialRetryCount++
+	return true
+}
+
 // handleAOST gets the AsOfSystemTime clause from the statement, and sets
 // the timestamps of the transaction accordingly.
 func (ex *connExecutor) handleAOST(ctx context.Context, stmt tree.Statement) error {
@@ -990,6 +1077,14 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Validate the constraints whose checks were deferred until the end of the
+	// transaction.
+	if pending := ex.extraTxnState.deferredConstraints.TakePending(nil /* names */); len(pending) > 0 {
+		if err := ex.planner.validateDeferredConstraints(ctx, pending); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1033,6 +1128,40 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	return nil
 }
 
+// commitProcedureTxn is part of the procedureTxnController interface. It
+// commits the implicit transaction of a CALL statement and starts a new
+// transaction in which the procedure continues to execute.
+func (ex *connExecutor) commitProcedureTxn(ctx context.Context) error {
+	if err := ex.commitSQLTransactionInternal(ctx); err != nil {
+		return err
+	}
+	ex.extraTxnState.procedureCommitted = true
+	return ex.restartProcedureTxn(ctx)
+}
+
+// rollbackProcedureTxn is part of the procedureTxnController interface. It
+// rolls back the implicit transaction of a CALL statement and starts a new
+// transaction in which the procedure continues to execute.
+func (ex *connExecutor) rollbackProcedureTxn(ctx context.Context) error {
+	if err := ex.state.mu.txn.Rollback(ctx); err != nil {
+		log.Warningf(ctx, "txn rollback failed: %s", err)
+	}
+	return ex.restartProcedureTxn(ctx)
+}
+
+// restartProcedureTxn replaces the finished transaction of a procedure with a
+// new one, and updates the planner of the CALL statement to use it.
+func (ex *connExecutor) restartProcedureTxn(ctx context.Context) error {
+	ex.extraTxnState.descCollection.ReleaseAll(ctx)
+	txnTS := ex.server.cfg.Clock.PhysicalTime()
+	txn, err := ex.state.replaceTxn(txnTS, ex.transitionCtx, ex.QualityOfService())
+	if err != nil {
+		return err
+	}
+	ex.planner.resetTxn(txn, txnTS)
+	return nil
+}
+
 // createJobs creates jobs for the records cached in schemaChangeJobRecords
 // during this transaction.
 func (ex *connExecutor) createJobs(ctx context.Context) error {
@@ -1690,10 +1819,15 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, err := ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		ex.sessionDataStack.PushTopClone()
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1714,9 +1848,14 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		return eventStartImplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1744,9 +1883,14 @@ func (ex *connExecutor) beginImplicitTxn(
 	if err != nil {
 		return ex.makeErrEvent(err, ast)
 	}
+	isoLevel, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+	if err != nil {
+		return ex.makeErrEvent(err, ast)
+	}
 	return eventStartImplicitTxn,
 		makeEventTxnStartPayload(
 			ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+			isoLevel,
 			mode,
 			sqlTs,
 			historicalTs,
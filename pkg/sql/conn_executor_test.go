@@ -26,6 +26,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
@@ -38,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
@@ -824,6 +827,202 @@ func TestRetriableErrorDuringUpgradedTransaction(t *testing.T) {
 	require.Equal(t, 2, x)
 }
 
+// TestReadCommittedImplicitTxnWriteWriteConflict ensures that a write-write
+// conflict encountered by a statement in an implicit READ COMMITTED
+// transaction is handled by retrying only the statement at a new read
+// snapshot, which observes the conflicting write instead of overwriting it.
+func TestReadCommittedImplicitTxnWriteWriteConflict(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	const updateStmt = "UPDATE t SET v = v + 1 WHERE k = 1"
+	var updateExecs, txnRetries int64
+	filter := newDynamicRequestFilter()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{
+				TestingRequestFilter: filter.filter,
+			},
+			SQLExecutor: &sql.ExecutorTestingKnobs{
+				BeforeExecute: func(ctx context.Context, stmt string) {
+					if strings.Contains(stmt, updateStmt) {
+						atomic.AddInt64(&updateExecs, 1)
+					}
+				},
+				OnTxnRetry: func(error, *eval.Context) {
+					atomic.AddInt64(&txnRetries, 1)
+				},
+			},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "SET CLUSTER SETTING sql.txn.read_committed_isolation.enabled = true")
+	db.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v INT)")
+	db.Exec(t, "INSERT INTO t VALUES (1, 0)")
+	var tableID uint32
+	db.QueryRow(t, "SELECT 't'::regclass::oid").Scan(&tableID)
+
+	conn, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	rcDB := sqlutils.MakeSQLRunner(conn)
+	rcDB.Exec(t, "SET default_transaction_isolation = 'read committed'")
+	// Don't lock the row while reading it, so that the conflicting write
+	// below isn't blocked by the READ COMMITTED statement.
+	rcDB.Exec(t, "SET enable_implicit_select_for_update = false")
+
+	// Block the first write of the READ COMMITTED statement until after a
+	// conflicting write has committed at a later timestamp.
+	var blocked int64
+	writeReached, unblock := make(chan struct{}), make(chan struct{})
+	filter.setFilter(func(ctx context.Context, ba *roachpb.BatchRequest) *roachpb.Error {
+		if ba.Txn == nil || ba.Txn.IsoLevel != isolation.ReadCommitted {
+			return nil
+		}
+		for _, ru := range ba.Requests {
+			req := ru.GetInner()
+			if !roachpb.IsIntentWrite(req) {
+				continue
+			}
+			_, id, err := keys.SystemSQLCodec.DecodeTablePrefix(req.Header().Key)
+			if err != nil || id != tableID {
+				continue
+			}
+			if atomic.CompareAndSwapInt64(&blocked, 0, 1) {
+				close(writeReached)
+				<-unblock
+			}
+			return nil
+		}
+		return nil
+	})
+	defer filter.setFilter(nil)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := conn.ExecContext(ctx, updateStmt)
+		errCh <- err
+	}()
+	<-writeReached
+	db.Exec(t, "UPDATE t SET v = 10 WHERE k = 1")
+	close(unblock)
+	require.NoError(t, <-errCh)
+
+	// The statement was executed again, without retrying the transaction, and
+	// the retry observed the conflicting write.
+	require.Equal(t, int64(2), atomic.LoadInt64(&updateExecs))
+	require.Equal(t, int64(0), atomic.LoadInt64(&txnRetries))
+	db.CheckQueryResults(t, "SELECT v FROM t WHERE k = 1", [][]string{{"11"}})
+}
+
+// TestReadCommittedForeignKeyCheckLocksParent ensures that the FK check of an
+// insert in a READ COMMITTED transaction locks the referenced row, so that a
+// concurrent delete of that row cannot commit without noticing the new
+// reference. READ COMMITTED transactions can commit above the timestamp at
+// which the check read the row, so without the lock both transactions would
+// commit and leave a dangling reference.
+func TestReadCommittedForeignKeyCheckLocksParent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	filter := newDynamicRequestFilter()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{
+				TestingRequestFilter: filter.filter,
+			},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "SET CLUSTER SETTING sql.txn.read_committed_isolation.enabled = true")
+	db.Exec(t, "CREATE TABLE parent (p INT PRIMARY KEY)")
+	db.Exec(t, "CREATE TABLE child (c INT PRIMARY KEY, p INT REFERENCES parent (p))")
+	db.Exec(t, "INSERT INTO parent VALUES (1)")
+	var parentID, childID uint32
+	db.QueryRow(t, "SELECT 'parent'::regclass::oid").Scan(&parentID)
+	db.QueryRow(t, "SELECT 'child'::regclass::oid").Scan(&childID)
+
+	conn, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	rcDB := sqlutils.MakeSQLRunner(conn)
+	// Write the child row before the FK check runs.
+	rcDB.Exec(t, "SET enable_insert_fast_path = false")
+
+	tableID := func(req roachpb.Request) uint32 {
+		_, id, err := keys.SystemSQLCodec.DecodeTablePrefix(req.Header().Key)
+		if err != nil {
+			return 0
+		}
+		return id
+	}
+	// Block the FK check of the READ COMMITTED insert until the delete has
+	// removed the parent row and is checking for references to it.
+	var checkBlocked, deleteChecking, sharedLock int64
+	checkReached, deleteReached, unblock := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	filter.setFilter(func(ctx context.Context, ba *roachpb.BatchRequest) *roachpb.Error {
+		if ba.Txn == nil {
+			return nil
+		}
+		for _, ru := range ba.Requests {
+			req := ru.GetInner()
+			if !roachpb.IsReadOnly(req) {
+				continue
+			}
+			switch id := tableID(req); {
+			case id == parentID && ba.Txn.IsoLevel == isolation.ReadCommitted:
+				if atomic.CompareAndSwapInt64(&checkBlocked, 0, 1) {
+					if roachpb.IsLocking(req) && roachpb.LockingStrength(req) == lock.Shared {
+						atomic.StoreInt64(&sharedLock, 1)
+					}
+					close(checkReached)
+					<-unblock
+				}
+			case id == childID && ba.Txn.IsoLevel == isolation.Serializable:
+				if atomic.CompareAndSwapInt64(&deleteChecking, 0, 1) {
+					close(deleteReached)
+				}
+			}
+		}
+		return nil
+	})
+	defer filter.setFilter(nil)
+
+	rcDB.Exec(t, "BEGIN TRANSACTION ISOLATION LEVEL READ COMMITTED")
+	insertErrCh := make(chan error, 1)
+	go func() {
+		_, err := conn.ExecContext(ctx, "INSERT INTO child VALUES (1, 1)")
+		if err == nil {
+			_, err = conn.ExecContext(ctx, "COMMIT")
+		} else {
+			_, _ = conn.ExecContext(ctx, "ROLLBACK")
+		}
+		insertErrCh <- err
+	}()
+	<-checkReached
+	deleteErrCh := make(chan error, 1)
+	go func() {
+		_, err := sqlDB.ExecContext(ctx, "DELETE FROM parent WHERE p = 1")
+		deleteErrCh <- err
+	}()
+	<-deleteReached
+	close(unblock)
+	insertErr, deleteErr := <-insertErrCh, <-deleteErrCh
+
+	require.Equal(t, int64(1), atomic.LoadInt64(&sharedLock), "FK check did not acquire a Shared lock")
+	// Exactly one of the two transactions must fail, and no child row may
+	// reference a missing parent row.
+	require.True(t, (insertErr == nil) != (deleteErr == nil),
+		"insert error: %v, delete error: %v", insertErr, deleteErr)
+	db.CheckQueryResults(t,
+		"SELECT count(*) FROM child WHERE p NOT IN (SELECT p FROM parent)", [][]string{{"0"}},
+	)
+}
+
 // This test ensures that when in an explicit transaction and statement
 // preparation uses the user's transaction, errors during those planning queries
 // are handled correctly.
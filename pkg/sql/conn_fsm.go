@@ -19,6 +19,7 @@ package sql
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -112,7 +113,8 @@ type eventTxnStart struct {
 type eventTxnStartPayload struct {
 	tranCtx transitionCtx
 
-	pri roachpb.UserPriority
+	pri      roachpb.UserPriority
+	isoLevel isolation.Level
 	// txnSQLTimestamp is the timestamp that statements executed in the
 	// transaction that is started by this event will report for now(),
 	// current_timestamp(), transaction_timestamp().
@@ -127,6 +129,7 @@ type eventTxnStartPayload struct {
 // makeEventTxnStartPayload creates an eventTxnStartPayload.
 func makeEventTxnStartPayload(
 	pri roachpb.UserPriority,
+	isoLevel isolation.Level,
 	readOnly tree.ReadWriteMode,
 	txnSQLTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
@@ -135,6 +138,7 @@ func makeEventTxnStartPayload(
 ) eventTxnStartPayload {
 	return eventTxnStartPayload{
 		pri:                 pri,
+		isoLevel:            isoLevel,
 		readOnly:            readOnly,
 		txnSQLTimestamp:     txnSQLTimestamp,
 		historicalTimestamp: historicalTimestamp,
@@ -505,6 +509,7 @@ func noTxnToOpen(args fsm.Args) error {
 		payload.txnSQLTimestamp,
 		payload.historicalTimestamp,
 		payload.pri,
+		payload.isoLevel,
 		payload.readOnly,
 		nil, /* txn */
 		payload.tranCtx,
@@ -2783,6 +2783,10 @@ CREATE TABLE crdb_internal.create_function_statements (
 			if err != nil {
 				return err
 			}
+			// Aggregates are not defined by a CREATE FUNCTION statement.
+			if fnDesc.GetAggregate() != nil {
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.FuncName.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -2793,15 +2797,21 @@ CREATE TABLE crdb_internal.create_function_statements (
 			}
 			for i := range treeNode.Options {
 				if body, ok := treeNode.Options[i].(tree.FunctionBodyStr); ok {
-					typeReplacedBody, err := formatFunctionQueryTypesForDisplay(ctx, &p.semaCtx, p.SessionData(), string(body))
-					if err != nil {
-						return err
-					}
-					seqReplacedBody, err := formatQuerySequencesForDisplay(ctx, &p.semaCtx, typeReplacedBody, true /* multiStmt */)
-					if err != nil {
-						return err
+					var displayBody string
+					if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
+						// The body of a PL/pgSQL function is stored as is.
+						displayBody = strings.TrimSuffix(string(body), "\n")
+					} else {
+						typeReplacedBody, err := formatFunctionQueryTypesForDisplay(ctx, &p.semaCtx, p.SessionData(), string(body))
+						if err != nil {
+							return err
+						}
+						displayBody, err = formatQuerySequencesForDisplay(ctx, &p.semaCtx, typeReplacedBody, true /* multiStmt */)
+						if err != nil {
+							return err
+						}
 					}
-					stmtStrs := strings.Split(seqReplacedBody, "\n")
+					stmtStrs := strings.Split(displayBody, "\n")
 					for i := range stmtStrs {
 						stmtStrs[i] = "\t" + stmtStrs[i]
 					}
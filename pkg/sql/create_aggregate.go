@@ -0,0 +1,368 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n *tree.CreateAggregate
+
+	dbDesc     catalog.DatabaseDescriptor
+	scDesc     catalog.SchemaDescriptor
+	args       []descpb.FunctionDescriptor_Argument
+	stateType  *types.T
+	returnType *types.T
+
+	transition *funcdesc.Mutable
+	final      *funcdesc.Mutable
+}
+
+// CreateAggregate creates a user-defined aggregate function.
+// Privileges: CREATE on the schema and EXECUTE on the support functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		n.StatementTag(),
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_1UserDefinedAggregates) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates",
+			clusterversion.ByKey(clusterversion.V23_1UserDefinedAggregates))
+	}
+	if n.CombineFunc != nil {
+		// The combine function is only useful for multi-stage aggregation, which
+		// requires executing the support functions on remote nodes.
+		// TODO(86310): support COMBINEFUNC once UDFs can be executed with
+		// distsql.
+		return nil, unimplemented.NewWithIssue(86310,
+			"COMBINEFUNC is not supported, since user-defined aggregates cannot be executed with distsql")
+	}
+
+	dbDesc, scDesc, _, err := p.ResolveTargetObject(ctx, n.FuncName.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.canCreateOnSchema(
+		ctx, scDesc.GetID(), dbDesc.GetID(), p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(n.Args) == 0 {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"aggregates without arguments are not supported")
+	}
+	node := &createAggregateNode{n: n, dbDesc: dbDesc, scDesc: scDesc}
+	node.args = make([]descpb.FunctionDescriptor_Argument, len(n.Args))
+	argTypes := make([]*types.T, len(n.Args))
+	for i, arg := range n.Args {
+		pbArg, err := makeFunctionArg(ctx, arg, p)
+		if err != nil {
+			return nil, err
+		}
+		if pbArg.Class != catpb.Function_Arg_IN {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates can only have input arguments")
+		}
+		node.args[i] = pbArg
+		argTypes[i] = pbArg.Type
+	}
+
+	node.stateType, err = tree.ResolveType(ctx, n.StateType, p)
+	if err != nil {
+		return nil, err
+	}
+	node.returnType = node.stateType
+
+	// The transition function takes the current state followed by the
+	// aggregate arguments, and returns the new state.
+	transitionArgs := append([]*types.T{node.stateType}, argTypes...)
+	node.transition, err = p.resolveAggregateSupportFunc(ctx, dbDesc, &n.TransitionFunc, transitionArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAggregateSupportFuncReturnType(
+		&n.TransitionFunc, node.transition, node.stateType,
+	); err != nil {
+		return nil, err
+	}
+
+	if n.FinalFunc != nil {
+		node.final, err = p.resolveAggregateSupportFunc(
+			ctx, dbDesc, n.FinalFunc, []*types.T{node.stateType},
+		)
+		if err != nil {
+			return nil, err
+		}
+		node.returnType = node.final.ReturnType.Type
+	}
+
+	if n.InitCond != nil {
+		if _, _, err := tree.ParseAndRequireString(node.stateType, *n.InitCond, p.EvalContext()); err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidTextRepresentation,
+				"invalid initial value %q for aggregate state type %s", *n.InitCond, node.stateType.SQLString())
+		}
+	} else if node.transition.NullInputBehavior != catpb.Function_CALLED_ON_NULL_INPUT &&
+		!argTypes[0].Equivalent(node.stateType) {
+		// With a strict transition function and no initial value, the first
+		// non-null input becomes the initial state, so it must be of the state
+		// type.
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict "+
+				"and transition type is not compatible with input type")
+	}
+	return node, nil
+}
+
+// resolveAggregateSupportFunc resolves the user-defined function with the given
+// name and exact argument types which is used as a support function of an
+// aggregate, and checks that the current user can execute it.
+func (p *planner) resolveAggregateSupportFunc(
+	ctx context.Context,
+	dbDesc catalog.DatabaseDescriptor,
+	name *tree.FunctionName,
+	argTypes []*types.T,
+) (*funcdesc.Mutable, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), &path)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(argTypes, name.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if !ol.IsUDF {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"aggregate support function %s must be a user-defined function", tree.AsString(name))
+	}
+	if ol.IsProcedure || ol.Class != tree.NormalClass {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"aggregate support function %s must be a function returning a single value", tree.AsString(name))
+	}
+	fnID, err := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
+	if err != nil {
+		return nil, err
+	}
+	fnDesc, err := p.Descriptors().GetMutableFunctionByID(ctx, p.Txn(), fnID, tree.ObjectLookupFlagsWithRequired())
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.GetParentID() != dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the aggregate cannot refer to functions in other databases")
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+// checkAggregateSupportFuncReturnType checks that the given support function
+// returns the aggregate state type.
+func checkAggregateSupportFuncReturnType(
+	name *tree.FunctionName, fnDesc *funcdesc.Mutable, stateType *types.T,
+) error {
+	if !fnDesc.ReturnType.Type.Equivalent(stateType) {
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of function %s is not %s", tree.AsString(name), stateType.SQLString())
+	}
+	return nil
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	mutFlags := tree.SchemaLookupFlags{Required: true, RequireMutable: true}
+	mutScDesc, err := params.p.descCollection.GetMutableSchemaByName(
+		params.ctx, params.p.Txn(), n.dbDesc, n.scDesc.GetName(), mutFlags,
+	)
+	if err != nil {
+		return err
+	}
+
+	existing, err := params.p.matchUDF(
+		params.ctx, &tree.FuncObj{FuncName: n.n.FuncName, Args: n.n.Args}, false, /* required */
+	)
+	if err != nil {
+		return err
+	}
+
+	var aggDesc *funcdesc.Mutable
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(pgcode.DuplicateFunction,
+				"function %q already exists with same argument types", n.n.FuncName.Object())
+		}
+		if existing.Class != tree.AggregateClass {
+			err := pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind")
+			if existing.IsProcedure {
+				return errors.WithDetailf(err, "%q is a procedure.", n.n.FuncName.Object())
+			}
+			return errors.WithDetailf(err, "%q is a function.", n.n.FuncName.Object())
+		}
+		aggDesc, err = n.replaceAggregate(params, existing)
+	} else {
+		aggDesc, err = n.createNewAggregate(params, mutScDesc)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The support functions hold back-references to the aggregate, which
+	// prevent them from being dropped while the aggregate exists. The types used
+	// by the aggregate are protected through the type references of the
+	// transition function.
+	for _, fnDesc := range []*funcdesc.Mutable{n.transition, n.final} {
+		if fnDesc == nil {
+			continue
+		}
+		fnDesc.AddAggregateReference(aggDesc.GetID())
+		if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
+			return err
+		}
+	}
+	if err := params.p.writeFuncSchemaChange(params.ctx, aggDesc); err != nil {
+		return err
+	}
+
+	fnName := tree.MakeQualifiedFunctionName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.FuncName.String())
+	return params.p.logEvent(params.ctx, aggDesc.GetID(), &eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    existing != nil,
+	})
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	params runParams, scDesc *schemadesc.Mutable,
+) (*funcdesc.Mutable, error) {
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, err
+	}
+	privileges := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Functions,
+		n.dbDesc.GetPrivileges(),
+	)
+	desc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.FuncName.ObjectName),
+		n.args,
+		n.returnType,
+		false, /* returnSet */
+		privileges,
+	)
+	desc.Aggregate = n.makeAggregateDescriptor()
+
+	if err := params.p.createDescriptorWithID(
+		params.ctx,
+		roachpb.Key{}, // Aggregates do not have namespace entries.
+		desc.GetID(),
+		&desc,
+		tree.AsStringWithFQNames(&n.n.FuncName, params.Ann()),
+	); err != nil {
+		return nil, err
+	}
+
+	argTypes := make([]*types.T, len(desc.Args))
+	for i, arg := range desc.Args {
+		argTypes[i] = arg.Type
+	}
+	scDesc.AddFunction(
+		desc.GetName(),
+		descpb.SchemaDescriptor_FunctionOverload{
+			ID:          desc.GetID(),
+			ArgTypes:    argTypes,
+			ReturnType:  desc.ReturnType.Type,
+			IsAggregate: true,
+		},
+	)
+	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate"); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+func (n *createAggregateNode) replaceAggregate(
+	params runParams, existing *tree.QualifiedOverload,
+) (*funcdesc.Mutable, error) {
+	fnID, err := funcdesc.UserDefinedFunctionOIDToID(existing.Oid)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := params.p.checkPrivilegesForDropFunction(params.ctx, fnID)
+	if err != nil {
+		return nil, err
+	}
+	if !n.returnType.Equal(desc.ReturnType.Type) {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"cannot change return type of existing function")
+	}
+
+	// Remove the back-references from the old support functions before the new
+	// ones are added.
+	for _, supportID := range desc.GetAggregateSupportFuncIDs() {
+		supportDesc, err := params.p.Descriptors().GetMutableFunctionByID(
+			params.ctx, params.p.Txn(), supportID, tree.ObjectLookupFlagsWithRequired(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		supportDesc.RemoveAggregateReference(desc.GetID())
+		if err := params.p.writeFuncSchemaChange(params.ctx, supportDesc); err != nil {
+			return nil, err
+		}
+	}
+	desc.Aggregate = n.makeAggregateDescriptor()
+	return desc, nil
+}
+
+func (n *createAggregateNode) makeAggregateDescriptor() *descpb.FunctionDescriptor_Aggregate {
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		TransitionFuncID: n.transition.GetID(),
+		StateType:        n.stateType,
+		InitCond:         n.n.InitCond,
+	}
+	if n.final != nil {
+		agg.FinalFuncID = n.final.GetID()
+	}
+	return agg
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type createFunctionNode struct {
@@ -56,6 +57,17 @@ func (n *createFunctionNode) startExec(params runParams) error {
 		)
 	}
 
+	if n.cf.IsProcedure && !params.EvalContext().Settings.Version.IsActive(
+		params.ctx,
+		clusterversion.V23_1Procedures,
+	) {
+		return pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"version %v must be finalized to create procedures",
+			clusterversion.ByKey(clusterversion.V23_1Procedures),
+		)
+	}
+
 	if n.cf.RoutineBody != nil {
 		return unimplemented.NewWithIssue(85144, "CREATE FUNCTION...sql_body unimplemented")
 	}
@@ -115,11 +127,8 @@ func (*createFunctionNode) Close(ctx context.Context)           {}
 func (n *createFunctionNode) createNewFunction(
 	udfDesc *funcdesc.Mutable, scDesc *schemadesc.Mutable, params runParams,
 ) error {
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 	if err := funcdesc.CheckLeakProofVolatility(udfDesc); err != nil {
 		return err
@@ -151,10 +160,11 @@ func (n *createFunctionNode) createNewFunction(
 	scDesc.AddFunction(
 		udfDesc.GetName(),
 		descpb.SchemaDescriptor_FunctionOverload{
-			ID:         udfDesc.GetID(),
-			ArgTypes:   argTypes,
-			ReturnType: returnType,
-			ReturnSet:  udfDesc.ReturnType.ReturnSet,
+			ID:          udfDesc.GetID(),
+			ArgTypes:    argTypes,
+			ReturnType:  returnType,
+			ReturnSet:   udfDesc.ReturnType.ReturnSet,
+			IsProcedure: udfDesc.IsProcedure,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -187,11 +197,8 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	}
 
 	resetFuncOption(udfDesc)
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 
 	if err := funcdesc.CheckLeakProofVolatility(udfDesc); err != nil {
@@ -265,12 +272,31 @@ func (n *createFunctionNode) getMutableFuncDesc(
 	if existing != nil {
 		// Return an error if there is an existing match but not a replacement.
 		if !n.cf.Replace {
+			kind := "function"
+			if n.cf.IsProcedure {
+				kind = "procedure"
+			}
 			return nil, false, pgerror.Newf(
 				pgcode.DuplicateFunction,
-				"function %q already exists with same argument types",
-				n.cf.FuncName.Object(),
+				"%s %q already exists with same argument types",
+				kind, n.cf.FuncName.Object(),
 			)
 		}
+		// A function cannot be replaced by a procedure or an aggregate, nor vice
+		// versa.
+		if existing.Class == tree.AggregateClass {
+			return nil, false, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is an aggregate function.", n.cf.FuncName.Object(),
+			)
+		}
+		if existing.IsProcedure != n.cf.IsProcedure {
+			err := pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind")
+			if existing.IsProcedure {
+				return nil, false, errors.WithDetailf(err, "%q is a procedure.", n.cf.FuncName.Object())
+			}
+			return nil, false, errors.WithDetailf(err, "%q is a function.", n.cf.FuncName.Object())
+		}
 		fnID, err := funcdesc.UserDefinedFunctionOIDToID(existing.Oid)
 		if err != nil {
 			return nil, false, err
@@ -311,6 +337,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.ReturnType.IsSet,
 		privileges,
 	)
+	newUdfDesc.IsProcedure = n.cf.IsProcedure
 
 	return &newUdfDesc, true, nil
 }
@@ -406,6 +433,29 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	return nil
 }
 
+// setFuncOptions sets the given options on the function descriptor. The
+// language is set first because the function body is processed differently
+// depending on the language.
+func setFuncOptions(
+	params runParams, udfDesc *funcdesc.Mutable, options tree.FunctionOptions,
+) error {
+	for _, option := range options {
+		if _, ok := option.(tree.FunctionLanguage); ok {
+			if err := setFuncOption(params, udfDesc, option); err != nil {
+				return err
+			}
+		}
+	}
+	for _, option := range options {
+		if _, ok := option.(tree.FunctionLanguage); !ok {
+			if err := setFuncOption(params, udfDesc, option); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.FunctionOption) error {
 	switch t := option.(type) {
 	case tree.FunctionVolatility:
@@ -429,6 +479,13 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 		}
 		udfDesc.SetLang(v)
 	case tree.FunctionBodyStr:
+		if udfDesc.GetLanguage() == catpb.Function_PLPGSQL {
+			// A PL/pgSQL function body is not a list of SQL statements, so it is
+			// stored as is. Sequences and user-defined types referenced in the
+			// body are resolved by name when the function is invoked.
+			udfDesc.SetFuncBody(string(t))
+			return nil
+		}
 		// Replace any sequence names in the function body with IDs.
 		seqReplacedFuncBody, err := replaceSeqNamesWithIDs(params.ctx, params.p, string(t), true)
 		if err != nil {
@@ -387,6 +387,24 @@ func populateInvertedIndexDescriptor(
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
 		indexDesc.GeoConfig = *geoindex.DefaultGeographyIndexConfig()
+	case types.TSVectorFamily:
+		switch invCol.OpClass {
+		case "tsvector_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
+	case types.RangeFamily:
+		switch invCol.OpClass {
+		case "range_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
+	case types.MultirangeFamily:
+		switch invCol.OpClass {
+		case "multirange_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	case types.StringFamily:
 		// Check the opclass of the last column in the list, which is the column
 		// we're going to inverted index.
@@ -663,6 +663,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintNotDeferrable,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -726,13 +727,24 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// checkUniqueConstraintDeferrability returns an error if the given unique
+// constraint is deferrable but is enforced by an index, since index entries
+// are always checked for uniqueness when they are written.
+func checkUniqueConstraintDeferrability(d *tree.UniqueConstraintTableDef) error {
+	if d.Deferrability != tree.ConstraintNotDeferrable && !d.WithoutIndex {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"DEFERRABLE is only supported for UNIQUE WITHOUT INDEX constraints")
+	}
+	return nil
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -746,6 +758,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -795,12 +808,13 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:          constraintName,
+		TableID:       tbl.ID,
+		ColumnIDs:     columnIDs,
+		Predicate:     predicate,
+		Validity:      validity,
+		ConstraintID:  tbl.NextConstraintID,
+		Deferrability: descpb.ConstraintDeferrabilityValue[deferrability],
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1046,6 +1060,7 @@ func ResolveFK(
 		OnUpdate:            descpb.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               descpb.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrability:       descpb.ConstraintDeferrabilityValue[d.Deferrability],
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1866,6 +1881,9 @@ func NewTableDesc(
 				return nil, err
 			}
 		case *tree.UniqueConstraintTableDef:
+			if err := checkUniqueConstraintDeferrability(d); err != nil {
+				return nil, err
+			}
 			if d.WithoutIndex {
 				// We will add the unique constraint below.
 				break
@@ -1979,7 +1997,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2166,6 +2185,13 @@ func NewTableDesc(
 				return nil, err
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExclusionConstraint(
+				ctx, evalCtx, semaCtx, &desc, &n.Table, d, NewTable,
+			); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
@@ -2555,6 +2581,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				def := tree.CheckConstraintTableDef{
 					Name:                  tree.Name(c.Name),
 					FromHashShardedColumn: c.FromHashShardedColumn,
+					Deferrability:         descpb.TreeConstraintDeferrabilityValue[c.Deferrability],
 				}
 				def.Expr, err = parser.ParseExpr(c.Expr)
 				if err != nil {
@@ -2568,7 +2595,8 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 						Name:    tree.Name(c.Name),
 						Columns: make(tree.IndexElemList, 0, len(c.ColumnIDs)),
 					},
-					WithoutIndex: true,
+					WithoutIndex:  true,
+					Deferrability: descpb.TreeConstraintDeferrabilityValue[c.Deferrability],
 				}
 				colNames, err := td.NamesForColumnIDs(c.ColumnIDs)
 				if err != nil {
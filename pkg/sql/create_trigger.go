@@ -0,0 +1,133 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	funcDesc  *funcdesc.Mutable
+}
+
+// CreateTrigger creates a row-level trigger on a table.
+// Privileges: CREATE on the table and EXECUTE on the trigger function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		n.StatementTag(),
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_1Triggers) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create triggers",
+			clusterversion.ByKey(clusterversion.V23_1Triggers))
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc.IsVirtualTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a table", tableDesc.GetName())
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if tableDesc.FindTriggerByName(string(n.Name)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"trigger %q for relation %q already exists", n.Name, tableDesc.GetName())
+	}
+
+	fnDesc, err := p.resolveTriggerFunction(ctx, &n.FuncName)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return &createTriggerNode{n: n, tableDesc: tableDesc, funcDesc: fnDesc}, nil
+}
+
+// resolveTriggerFunction resolves the zero-argument user-defined function with
+// the given name and checks that it returns type trigger.
+func (p *planner) resolveTriggerFunction(
+	ctx context.Context, name *tree.FunctionName,
+) (*funcdesc.Mutable, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), &path)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload([]*types.T{}, name.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if !ol.IsUDF || ol.FixedReturnType().Family() != types.TriggerFamily {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", tree.AsString(name))
+	}
+	fnID, err := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
+	if err != nil {
+		return nil, err
+	}
+	return p.Descriptors().GetMutableFunctionByID(ctx, p.Txn(), fnID, tree.ObjectLookupFlagsWithRequired())
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	if catalog.HasConcurrentDeclarativeSchemaChange(n.tableDesc) {
+		return scerrors.ConcurrentSchemaChangeError(n.tableDesc)
+	}
+	events := make([]descpb.TriggerDescriptor_Event, len(n.n.Events))
+	for i, event := range n.n.Events {
+		events[i] = descpb.ToTriggerEvent(event)
+	}
+	trigger := n.tableDesc.AddTrigger(descpb.TriggerDescriptor{
+		Name:       string(n.n.Name),
+		ActionTime: descpb.ToTriggerActionTime(n.n.ActionTime),
+		Events:     events,
+		FuncID:     n.funcDesc.GetID(),
+	})
+	n.funcDesc.AddTriggerReference(n.tableDesc.GetID(), trigger.ID)
+
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("creating trigger %s on table %s(%d)",
+			n.n.Name, n.tableDesc.GetName(), n.tableDesc.GetID()),
+	); err != nil {
+		return err
+	}
+	return params.p.writeFuncSchemaChange(params.ctx, n.funcDesc)
+}
+
+func (n *createTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createTriggerNode) Close(ctx context.Context)           {}
@@ -0,0 +1,288 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// deferredConstraintMaxRows is the maximum number of rows that are tracked
+// for each deferred constraint. The checks of constraints with more rows are
+// performed against all the rows of their table.
+var deferredConstraintMaxRows = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.deferred_constraints.max_tracked_rows",
+	"the maximum number of rows written by a transaction that are tracked for a "+
+		"deferred constraint; beyond it, the constraint is checked against all the "+
+		"rows of its table when the transaction commits",
+	1000,
+	settings.NonNegativeInt, /* validateFn */
+)
+
+// deferredConstraintRecorder records the rows written by a tableWriter that
+// must be checked for the constraints whose checks are deferred until the end
+// of the transaction.
+type deferredConstraintRecorder struct {
+	// deferred is nil if no deferred constraint involves the table.
+	deferred *eval.DeferredConstraints
+	maxRows  int
+	// pkColIDs are the primary key columns of the table.
+	pkColIDs []descpb.ColumnID
+	specs    []deferredConstraintSpec
+}
+
+// deferredConstraintSpec describes the rows to record for a deferred
+// constraint that involves the table being written.
+type deferredConstraintSpec struct {
+	tableID descpb.ID
+	name    string
+	// referencedColIDs is set if the constraint is a foreign key that
+	// references the table. The values of these columns in the rows that are
+	// deleted or updated are recorded. Otherwise, the constraint is defined on
+	// the table, and the primary keys of the rows that are inserted or updated
+	// are recorded.
+	referencedColIDs []descpb.ColumnID
+	// colIDs are the columns involved in the constraint. Updates that do not
+	// modify any of them are not recorded. If empty, all updates are recorded.
+	colIDs catalog.TableColSet
+}
+
+// init initializes the recorder for writes to the given table.
+func (r *deferredConstraintRecorder) init(evalCtx *eval.Context, desc catalog.TableDescriptor) {
+	*r = deferredConstraintRecorder{}
+	if evalCtx == nil {
+		return
+	}
+	for _, dc := range evalCtx.DeferredConstraints.Pending() {
+		if descpb.ID(dc.TableID) == desc.GetID() {
+			spec := deferredConstraintSpec{tableID: descpb.ID(dc.TableID), name: dc.Name}
+			if c, _ := desc.FindConstraintWithName(dc.Name); c != nil {
+				if ck := c.AsCheck(); ck != nil {
+					spec.colIDs = catalog.MakeTableColSet(ck.CheckDesc().ColumnIDs...)
+				} else if fk := c.AsForeignKey(); fk != nil {
+					spec.colIDs = catalog.MakeTableColSet(fk.ForeignKeyDesc().OriginColumnIDs...)
+				} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil && !uwi.IsPartial() {
+					spec.colIDs = catalog.MakeTableColSet(uwi.UniqueWithoutIndexDesc().ColumnIDs...)
+				}
+			}
+			r.specs = append(r.specs, spec)
+		}
+		for _, fk := range desc.InboundForeignKeys() {
+			fkDesc := fk.ForeignKeyDesc()
+			if descpb.ID(dc.TableID) == fkDesc.OriginTableID && dc.Name == fkDesc.Name {
+				r.specs = append(r.specs, deferredConstraintSpec{
+					tableID:          descpb.ID(dc.TableID),
+					name:             dc.Name,
+					referencedColIDs: fkDesc.ReferencedColumnIDs,
+					colIDs:           catalog.MakeTableColSet(fkDesc.ReferencedColumnIDs...),
+				})
+			}
+		}
+	}
+	if len(r.specs) == 0 {
+		return
+	}
+	r.deferred = evalCtx.DeferredConstraints
+	r.maxRows = int(deferredConstraintMaxRows.Get(&evalCtx.Settings.SV))
+	r.pkColIDs = desc.GetPrimaryIndex().IndexDesc().KeyColumnIDs
+}
+
+// recordInsert records an inserted row, whose values are laid out according
+// to colIDToRowIndex.
+func (r *deferredConstraintRecorder) recordInsert(
+	values tree.Datums, colIDToRowIndex catalog.TableColMap,
+) {
+	if r.deferred == nil {
+		return
+	}
+	for i := range r.specs {
+		if r.specs[i].referencedColIDs == nil {
+			r.recordRow(&r.specs[i], values, colIDToRowIndex)
+		}
+	}
+}
+
+// recordUpdate records an updated row. The old and new values are laid out
+// according to the fetch columns of the updater.
+func (r *deferredConstraintRecorder) recordUpdate(oldValues, newValues tree.Datums, ru *row.Updater) {
+	if r.deferred == nil {
+		return
+	}
+	for i := range r.specs {
+		spec := &r.specs[i]
+		if !spec.colIDs.Empty() && !updatesAnyColumn(ru, spec.colIDs) {
+			continue
+		}
+		if spec.referencedColIDs == nil {
+			r.recordRow(spec, newValues, ru.FetchColIDtoRowIndex)
+		} else {
+			r.recordReferencedKey(spec, oldValues, ru.FetchColIDtoRowIndex)
+		}
+	}
+}
+
+// recordDelete records a deleted row, whose values are laid out according to
+// colIDToRowIndex.
+func (r *deferredConstraintRecorder) recordDelete(
+	values tree.Datums, colIDToRowIndex catalog.TableColMap,
+) {
+	if r.deferred == nil {
+		return
+	}
+	for i := range r.specs {
+		if r.specs[i].referencedColIDs != nil {
+			r.recordReferencedKey(&r.specs[i], values, colIDToRowIndex)
+		}
+	}
+}
+
+func (r *deferredConstraintRecorder) recordRow(
+	spec *deferredConstraintSpec, values tree.Datums, colIDToRowIndex catalog.TableColMap,
+) {
+	pk, ok := extractDeferredConstraintKey(r.pkColIDs, values, colIDToRowIndex)
+	if !ok {
+		r.deferred.RecordAllRows(spec.tableID, spec.name)
+		return
+	}
+	r.deferred.RecordRow(spec.tableID, spec.name, r.pkColIDs, pk, r.maxRows)
+}
+
+func (r *deferredConstraintRecorder) recordReferencedKey(
+	spec *deferredConstraintSpec, values tree.Datums, colIDToRowIndex catalog.TableColMap,
+) {
+	key, ok := extractDeferredConstraintKey(spec.referencedColIDs, values, colIDToRowIndex)
+	if !ok {
+		r.deferred.RecordAllRows(spec.tableID, spec.name)
+		return
+	}
+	// No row can reference a key with NULL values.
+	for _, d := range key {
+		if d == tree.DNull {
+			return
+		}
+	}
+	r.deferred.RecordReferencedKey(spec.tableID, spec.name, key, r.maxRows)
+}
+
+// extractDeferredConstraintKey returns the values of the given columns in the
+// row, or false if some of the columns are not part of the row.
+func extractDeferredConstraintKey(
+	colIDs []descpb.ColumnID, values tree.Datums, colIDToRowIndex catalog.TableColMap,
+) (tree.Datums, bool) {
+	key := make(tree.Datums, len(colIDs))
+	for i, colID := range colIDs {
+		idx, ok := colIDToRowIndex.Get(colID)
+		if !ok {
+			return nil, false
+		}
+		key[i] = values[idx]
+	}
+	return key, true
+}
+
+// updatesAnyColumn returns true if the updater modifies any of the given
+// columns.
+func updatesAnyColumn(ru *row.Updater, colIDs catalog.TableColSet) bool {
+	for _, col := range ru.UpdateCols {
+		if colIDs.Contains(col.GetID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// deferredConstraintRowFilter returns a predicate over the columns of the
+// table that selects the rows recorded for the deferred constraint. For
+// foreign keys, fk is the constraint, and the predicate also selects the rows
+// that reference the recorded referenced keys. It returns false if the
+// recorded rows cannot be selected, in which case all rows must be checked.
+func deferredConstraintRowFilter(
+	tableDesc catalog.TableDescriptor, dc *eval.DeferredConstraint, fk *descpb.ForeignKeyConstraint,
+) (string, bool) {
+	var disjuncts []string
+	if len(dc.Rows) > 0 {
+		colNames, err := tableDesc.NamesForColumnIDs(dc.RowColumnIDs)
+		if err != nil {
+			return "", false
+		}
+		disjuncts = append(disjuncts, inListPredicate(colNames, dc.Rows))
+	}
+	if len(dc.ReferencedKeys) > 0 {
+		if fk == nil {
+			return "", false
+		}
+		colNames, err := tableDesc.NamesForColumnIDs(fk.OriginColumnIDs)
+		if err != nil {
+			return "", false
+		}
+		disjuncts = append(disjuncts, inListPredicate(colNames, dc.ReferencedKeys))
+	}
+	return strings.Join(disjuncts, " OR "), true
+}
+
+// inListPredicate returns a predicate that is true for the rows whose values
+// of the given columns are one of the given keys, e.g.
+//
+//	(a, b) IN ((1:::INT8, 'x':::STRING), (2:::INT8, 'y':::STRING))
+func inListPredicate(colNames []string, keys []tree.Datums) string {
+	var buf strings.Builder
+	buf.WriteByte('(')
+	for i, name := range colNames {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(tree.NameString(name))
+	}
+	buf.WriteString(") IN (")
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('(')
+		for j, d := range key {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(tree.Serialize(d))
+		}
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// uniqueWithoutIndexRowFilter returns a predicate that selects the rows that
+// have the same values of the columns of the unique constraint as the rows
+// selected by rowFilter.
+func uniqueWithoutIndexRowFilter(
+	tableDesc catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint, rowFilter string,
+) (string, bool) {
+	colNames, err := tableDesc.NamesForColumnIDs(uc.ColumnIDs)
+	if err != nil {
+		return "", false
+	}
+	cols := make([]string, len(colNames))
+	for i, name := range colNames {
+		cols[i] = tree.NameString(name)
+	}
+	return fmt.Sprintf(
+		"(%[1]s) IN (SELECT %[1]s FROM [%[2]d AS t] WHERE %[3]s)",
+		strings.Join(cols, ", "), tableDesc.GetID(), rowFilter,
+	), true
+}
@@ -401,6 +401,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	switch n := node.(type) {
 	// Keep these cases alphabetized, please!
 	case *distinctNode:
+	case *expandNode:
 	case *exportNode:
 	case *filterNode:
 	case *groupNode:
@@ -480,6 +481,14 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// TODO(86310): enable user-defined aggregates in DistSQL.
+				return cannotDistribute, newQueryNotSupportedErrorf(
+					"user-defined aggregate %s cannot be executed with distsql", f.funcName,
+				)
+			}
+		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
 
@@ -574,6 +583,9 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		// always number each row in order.
 		return cannotDistribute, nil
 
+	case *expandNode:
+		return checkSupportForPlanNode(n.source)
+
 	case *projectSetNode:
 		return checkSupportForPlanNode(n.source)
 
@@ -662,6 +674,14 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// TODO(86310): enable user-defined aggregates in DistSQL.
+				return cannotDistribute, newQueryNotSupportedErrorf(
+					"user-defined aggregate %s cannot be executed with distsql", f.userDefined.Name,
+				)
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -1868,11 +1888,20 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			userDefined, err := makeUserDefinedAggregateSpec(ctx, planCtx, fholder.userDefined)
+			if err != nil {
+				return err
+			}
+			aggregations[i].UserDefined = userDefined
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -1904,6 +1933,35 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregateSpec creates the specification of the given
+// user-defined aggregate.
+func makeUserDefinedAggregateSpec(
+	ctx context.Context, planCtx *PlanningCtx, uda *tree.UserDefinedAggregate,
+) (*execinfrapb.UserDefinedAggregate, error) {
+	var res execinfrapb.UserDefinedAggregate
+	var err error
+	if res.Transition, err = physicalplan.MakeExpression(
+		ctx, uda.Transition, planCtx, nil, /* indexVarMap */
+	); err != nil {
+		return nil, err
+	}
+	if uda.Final != nil {
+		if res.Final, err = physicalplan.MakeExpression(
+			ctx, uda.Final, planCtx, nil, /* indexVarMap */
+		); err != nil {
+			return nil, err
+		}
+	}
+	if uda.InitCond != tree.DNull {
+		if res.InitCond, err = physicalplan.MakeExpression(
+			ctx, uda.InitCond, planCtx, nil, /* indexVarMap */
+		); err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2341,8 +2399,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
+		var returnTyp *types.T
 		var err error
-		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+		if agg.UserDefined != nil {
+			_, returnTyp, err = execagg.GetUserDefinedAggregateInfo(agg.UserDefined)
+		} else {
+			_, returnTyp, err = execagg.GetAggregateInfo(agg.Func, argTypes...)
+		}
 		if err != nil {
 			return err
 		}
@@ -3189,6 +3252,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 	case *ordinalityNode:
 		plan, err = dsp.createPlanForOrdinality(ctx, planCtx, n)
 
+	case *expandNode:
+		plan, err = dsp.createPlanForExpand(ctx, planCtx, n)
+
 	case *projectSetNode:
 		plan, err = dsp.createPlanForProjectSet(ctx, planCtx, n)
 
@@ -3575,6 +3641,50 @@ func (dsp *DistSQLPlanner) createPlanForOrdinality(
 	return plan, nil
 }
 
+func (dsp *DistSQLPlanner) createPlanForExpand(
+	ctx context.Context, planCtx *PlanningCtx, n *expandNode,
+) (*PhysicalPlan, error) {
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &execinfrapb.ExpandSpec{
+		GroupCols:    make([]uint32, len(n.groupCols)),
+		GroupingSets: make([]execinfrapb.ExpandSpec_GroupingSet, len(n.groupingSets)),
+	}
+	for i, col := range n.groupCols {
+		spec.GroupCols[i] = uint32(plan.PlanToStreamColMap[col])
+	}
+	for i, set := range n.groupingSets {
+		set.ForEach(func(col int) {
+			spec.GroupingSets[i].Cols = append(spec.GroupingSets[i].Cols, uint32(plan.PlanToStreamColMap[col]))
+		})
+	}
+
+	numResults := len(plan.GetResultTypes())
+	outputTypes := append([]*types.T(nil), plan.GetResultTypes()...)
+	for _, col := range spec.GroupCols {
+		outputTypes = append(outputTypes, outputTypes[col])
+	}
+	outputTypes = append(outputTypes, types.Int)
+	for i := 0; i <= len(n.groupCols); i++ {
+		plan.PlanToStreamColMap = append(plan.PlanToStreamColMap, numResults+i)
+	}
+
+	// The rows for each grouping set can be produced independently for every
+	// input row, so the expansion is performed on every stream. This allows
+	// the aggregation that follows to perform local aggregation before the
+	// rows are shuffled.
+	plan.AddNoGroupingStage(
+		execinfrapb.ProcessorCoreUnion{Expand: spec},
+		execinfrapb.PostProcessSpec{},
+		outputTypes,
+		execinfrapb.Ordering{},
+	)
+	return plan, nil
+}
+
 func createProjectSetSpec(
 	ctx context.Context, planCtx *PlanningCtx, n *projectSetPlanningInfo, indexVarMap []int,
 ) (*execinfrapb.ProjectSetSpec, error) {
@@ -4161,6 +4271,8 @@ func (c *localScanParallelizationChecker) enterNode(
 	switch n := plan.(type) {
 	case *distinctNode:
 		return true, nil
+	case *expandNode:
+		return true, nil
 	case *explainPlanNode:
 		// walkPlan doesn't recurse into explainPlanNode, so we have to manually
 		// walk over the wrapped plan.
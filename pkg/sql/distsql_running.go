@@ -23,11 +23,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/colflow"
 	"github.com/cockroachdb/cockroach/pkg/sql/contention"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
@@ -667,6 +669,14 @@ func (dsp *DistSQLPlanner) Run(
 	// then we are ignorant of the details of the execution plan, so we choose
 	// to be on the safe side and mark 'noMutations' as 'false'.
 	noMutations := planCtx.planner != nil && !planCtx.planner.curPlan.flags.IsSet(planFlagContainsMutation)
+	if noMutations && txn != nil && txn.IsoLevel() == isolation.ReadCommitted &&
+		acquiresSharedLocks(plan.Processors) {
+		// READ COMMITTED transactions replicate their Shared locks. The locks
+		// acquired by LeafTxns are not tracked by the RootTxn, which would not
+		// release them when it finishes, so such plans must use the RootTxn just
+		// like plans with mutations.
+		noMutations = false
+	}
 
 	if txn == nil {
 		// Txn can be nil in some cases, like BulkIO flows. In such a case, we
@@ -1956,11 +1966,48 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
-	// TODO(yuzefovich): at the moment, errOnlyResultWriter is sufficient here,
-	// but it may not be the case when we support cascades through the optimizer.
-	postqueryResultWriter := &errOnlyResultWriter{}
+	// Cascades do not produce any rows, but the postqueries of AFTER triggers
+	// produce a row for each trigger invocation, which is discarded.
+	postqueryResultWriter := &droppingResultWriter{}
 	postqueryRecv.resultWriterMu.row = postqueryResultWriter
 	postqueryRecv.resultWriterMu.batch = postqueryResultWriter
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, nil /* finishedSetupFn */)
 	return postqueryRecv.getError()
 }
+
+// acquiresSharedLocks returns whether any of the processors acquires Shared
+// locks on the rows it reads.
+func acquiresSharedLocks(procs []physicalplan.Processor) bool {
+	isShared := func(str descpb.ScanLockingStrength) bool {
+		return str == descpb.ScanLockingStrength_FOR_SHARE ||
+			str == descpb.ScanLockingStrength_FOR_KEY_SHARE
+	}
+	for i := range procs {
+		core := &procs[i].Spec.Core
+		switch {
+		case core.TableReader != nil:
+			if isShared(core.TableReader.LockingStrength) {
+				return true
+			}
+		case core.IndexSkipTableReader != nil:
+			if isShared(core.IndexSkipTableReader.LockingStrength) {
+				return true
+			}
+		case core.JoinReader != nil:
+			if isShared(core.JoinReader.LockingStrength) {
+				return true
+			}
+		case core.InvertedJoiner != nil:
+			if isShared(core.InvertedJoiner.LockingStrength) {
+				return true
+			}
+		case core.ZigzagJoiner != nil:
+			for _, side := range core.ZigzagJoiner.Sides {
+				if isShared(side.LockingStrength) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -641,6 +641,15 @@ func (e *distSQLSpecExecFactory) ConstructOrdinality(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: ordinality")
 }
 
+func (e *distSQLSpecExecFactory) ConstructExpand(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	groupingSets []exec.NodeColumnOrdinalSet,
+	groupingSetColName string,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: expand")
+}
+
 func (e *distSQLSpecExecFactory) ConstructIndexJoin(
 	input exec.Node,
 	table cat.Table,
@@ -1046,6 +1055,10 @@ func (e *distSQLSpecExecFactory) ConstructCreateFunction(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create function")
 }
 
+func (e *distSQLSpecExecFactory) ConstructCall(proc tree.TypedExpr) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: call")
+}
+
 func (e *distSQLSpecExecFactory) ConstructSequenceSelect(sequence cat.Sequence) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: sequence select")
 }
@@ -18,6 +18,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -38,7 +40,7 @@ func (p *planner) DropFunction(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -60,6 +62,9 @@ func (p *planner) DropFunction(
 		if ol == nil {
 			continue
 		}
+		if err := checkRoutineKind(&fn, ol, n.IsProcedure, n.IsAggregate); err != nil {
+			return nil, err
+		}
 		fnID, err := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 		if err != nil {
 			return nil, err
@@ -72,6 +77,12 @@ func (p *planner) DropFunction(
 		if err != nil {
 			return nil, err
 		}
+		if err := p.checkNoTriggerDependsOnFunction(ctx, mut); err != nil {
+			return nil, err
+		}
+		if err := p.checkNoAggregateDependsOnFunction(ctx, mut); err != nil {
+			return nil, err
+		}
 		dropNode.toDrop = append(dropNode.toDrop, mut)
 	}
 
@@ -134,6 +145,29 @@ func (p *planner) matchUDF(
 	return &ol, nil
 }
 
+// checkRoutineKind returns an error if the given overload is not the kind of
+// routine that is expected: a function, a procedure or an aggregate.
+func checkRoutineKind(
+	fn *tree.FuncObj, ol *tree.QualifiedOverload, isProcedure, isAggregate bool,
+) error {
+	olIsAggregate := ol.Class == tree.AggregateClass
+	if ol.IsProcedure == isProcedure && olIsAggregate == isAggregate {
+		return nil
+	}
+	switch {
+	case isProcedure:
+		return pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure", tree.AsString(fn))
+	case isAggregate:
+		return pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", tree.AsString(fn))
+	case olIsAggregate:
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", tree.AsString(fn)),
+			"Use DROP AGGREGATE or ALTER AGGREGATE for aggregate functions.",
+		)
+	}
+	return pgerror.Newf(pgcode.WrongObjectType, "%s is not a function", tree.AsString(fn))
+}
+
 func (p *planner) checkPrivilegesForDropFunction(
 	ctx context.Context, fnID descpb.ID,
 ) (*funcdesc.Mutable, error) {
@@ -147,6 +181,53 @@ func (p *planner) checkPrivilegesForDropFunction(
 	return mutable, nil
 }
 
+// checkNoTriggerDependsOnFunction returns an error if the function is invoked
+// by a trigger.
+func (p *planner) checkNoTriggerDependsOnFunction(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if len(ref.TriggerIDs) == 0 {
+			continue
+		}
+		tbl, err := p.Descriptors().GetImmutableTableByID(
+			ctx, p.Txn(), ref.ID, tree.ObjectLookupFlagsWithRequired(),
+		)
+		if err != nil {
+			return err
+		}
+		for _, trigger := range tbl.GetTriggers() {
+			if trigger.ID == ref.TriggerIDs[0] {
+				return pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop function %s because trigger %s on table %s depends on it",
+					fnDesc.GetName(), trigger.Name, tbl.GetName(),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// checkNoAggregateDependsOnFunction returns an error if the function is a
+// support function of a user-defined aggregate.
+func (p *planner) checkNoAggregateDependsOnFunction(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	for _, aggID := range fnDesc.GetDependedOnByAggregates() {
+		agg, err := p.Descriptors().GetImmutableFunctionByID(
+			ctx, p.Txn(), aggID, tree.ObjectLookupFlagsWithRequired(),
+		)
+		if err != nil {
+			return err
+		}
+		return pgerror.Newf(pgcode.DependentObjectsStillExist,
+			"cannot drop function %s because aggregate %s depends on it",
+			fnDesc.GetName(), agg.GetName(),
+		)
+	}
+	return nil
+}
+
 func (p *planner) canDropFunction(ctx context.Context, fnDesc catalog.FunctionDescriptor) error {
 	hasOwernship, err := p.HasOwnershipOnSchema(ctx, fnDesc.GetParentSchemaID(), fnDesc.GetParentID())
 	if err != nil {
@@ -202,6 +283,20 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Remove backreferences from the support functions of an aggregate.
+	for _, id := range fnMutable.GetAggregateSupportFuncIDs() {
+		supportFn, err := p.Descriptors().GetMutableFunctionByID(
+			ctx, p.txn, id, tree.ObjectLookupFlagsWithRequired(),
+		)
+		if err != nil {
+			return err
+		}
+		supportFn.RemoveAggregateReference(fnMutable.ID)
+		if err := p.writeFuncSchemaChange(ctx, supportFn); err != nil {
+			return err
+		}
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -348,6 +348,17 @@ func (p *planner) dropIndexByName(
 		)
 	}
 
+	if constraintBehavior == checkIdxConstraint {
+		if c := tableDesc.FindExclusionConstraintByIndexID(idx.GetID()); c != nil {
+			return errors.WithHintf(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"index %q is in use as exclusion constraint", idx.GetName()),
+				"use ALTER TABLE %s DROP CONSTRAINT %s instead.",
+				tree.ErrString(tn), tree.ErrNameString(c.Name),
+			)
+		}
+	}
+
 	// Check if requires CCL binary for eventual zone config removal.
 	_, zone, _, err := GetZoneConfigInTxn(
 		ctx, p.txn, p.ExecCfg().Codec, tableDesc.ID, nil /* index */, "", false,
@@ -258,6 +258,13 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Remove back references from the functions invoked by triggers.
+	for i := range tableDesc.Triggers {
+		if err := p.removeTriggerFuncBackReference(ctx, tableDesc, &tableDesc.Triggers[i]); err != nil {
+			return droppedViews, err
+		}
+	}
+
 	// Drop sequences that the columns of the table own.
 	for _, col := range tableDesc.PublicColumns() {
 		if err := p.dropSequencesOwnedByCol(ctx, col, !droppingParent, behavior); err != nil {
@@ -0,0 +1,100 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropTriggerNode struct {
+	tableDesc *tabledesc.Mutable
+	trigger   descpb.TriggerDescriptor
+}
+
+// DropTrigger drops a trigger from a table.
+// Privileges: CREATE on the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		n.StatementTag(),
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	trigger := tableDesc.FindTriggerByName(string(n.Name))
+	if trigger == nil {
+		if n.IfExists {
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.Name, tableDesc.GetName())
+	}
+	return &dropTriggerNode{tableDesc: tableDesc, trigger: *trigger}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	if catalog.HasConcurrentDeclarativeSchemaChange(n.tableDesc) {
+		return scerrors.ConcurrentSchemaChangeError(n.tableDesc)
+	}
+	if err := params.p.removeTriggerFuncBackReference(
+		params.ctx, n.tableDesc, &n.trigger,
+	); err != nil {
+		return err
+	}
+	n.tableDesc.DropTrigger(n.trigger.ID)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("dropping trigger %s on table %s(%d)",
+			n.trigger.Name, n.tableDesc.GetName(), n.tableDesc.GetID()),
+	)
+}
+
+// removeTriggerFuncBackReference removes the back-reference from the function
+// invoked by the given trigger to the trigger.
+func (p *planner) removeTriggerFuncBackReference(
+	ctx context.Context, tableDesc catalog.TableDescriptor, trigger *descpb.TriggerDescriptor,
+) error {
+	fnDesc, err := p.Descriptors().GetMutableFunctionByID(
+		ctx, p.Txn(), trigger.FuncID, tree.ObjectLookupFlagsWithRequired(),
+	)
+	if err != nil {
+		return err
+	}
+	fnDesc.RemoveTriggerReference(tableDesc.GetID(), trigger.ID)
+	return p.writeFuncSchemaChange(ctx, fnDesc)
+}
+
+func (n *dropTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropTriggerNode) Close(ctx context.Context)           {}
@@ -0,0 +1,405 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// addExclusionConstraint adds the exclusion constraint defined by d to the
+// table, along with the index that backs it. The index has the same name as
+// the constraint.
+//
+// The backing index is used to find the rows that conflict with new rows: it
+// is an inverted index if the constraint compares an array or spatial column
+// with the overlaps operator, prefixed by the columns compared with the
+// equality operator, and a forward index on the indexable columns of the
+// constraint otherwise.
+//
+// The constraint is validated if the table is new. Otherwise it is in the
+// Validating state, and the existing rows must be validated by the caller. The
+// IDs of the constraint and its index are assigned by AllocateIDs.
+func addExclusionConstraint(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	desc *tabledesc.Mutable,
+	tn *tree.TableName,
+	d *tree.ExcludeConstraintTableDef,
+	ts TableState,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1ExclusionConstraints) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create exclusion constraints",
+			clusterversion.ByKey(clusterversion.V23_1ExclusionConstraints))
+	}
+	switch d.Method {
+	case "", "btree", "gist":
+	case "gin", "hash", "brin", "spgist":
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"access method %q does not support exclusion constraints", d.Method)
+	default:
+		return pgerror.Newf(pgcode.UndefinedObject, "access method %q does not exist", d.Method)
+	}
+	if desc.PartitionAllBy {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported on tables with PARTITION ALL BY or "+
+				"LOCALITY REGIONAL BY ROW")
+	}
+
+	cols := make([]catalog.Column, len(d.Elems))
+	c := descpb.ExclusionConstraint{
+		Method:        string(d.Method),
+		ColumnIDs:     make([]descpb.ColumnID, len(d.Elems)),
+		Operators:     make([]string, len(d.Elems)),
+		Deferrability: descpb.ConstraintDeferrabilityValue[d.Deferrability],
+	}
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Expr != nil {
+			return unimplemented.New("exclusion constraint expressions",
+				"exclusion constraints on expressions are not supported")
+		}
+		if elem.OpClass != "" {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"operator classes are not supported in exclusion constraints")
+		}
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return err
+		}
+		if ts != NewTable && !col.Public() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"column %q is being backfilled", col.GetName())
+		}
+		if col.IsInaccessible() || col.IsSystemColumn() {
+			return pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q cannot be used in an exclusion constraint", col.GetName())
+		}
+		if !descpb.IsExclusionOperator(elem.Operator) {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "operator %s is not commutative", elem.Operator),
+				"Only commutative operators can be used in exclusion constraints.",
+			)
+		}
+		// The != operator is evaluated as the negation of the = operator.
+		sym := elem.Operator.Symbol
+		if sym == treecmp.NE {
+			sym = treecmp.EQ
+		}
+		typ := col.GetType()
+		if _, ok := tree.CmpOps[sym].LookupImpl(typ, typ); !ok {
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s", typ.SQLString(), elem.Operator, typ.SQLString())
+		}
+		cols[i] = col
+		c.ColumnIDs[i] = col.GetID()
+		c.Operators[i] = elem.Operator.String()
+	}
+
+	// Build the backing index.
+	idx := descpb.IndexDescriptor{
+		Version: descpb.StrictIndexColumnIDGuaranteesVersion,
+	}
+	var idxCols tree.IndexElemList
+	addIdxCol := func(i int) {
+		for _, idxCol := range idxCols {
+			if idxCol.Column == d.Elems[i].Column {
+				return
+			}
+		}
+		idxCols = append(idxCols, tree.IndexElem{
+			Column:     d.Elems[i].Column,
+			Direction:  d.Elems[i].Direction,
+			NullsOrder: d.Elems[i].NullsOrder,
+		})
+	}
+	invertedOrd := -1
+	for i := range d.Elems {
+		typ := cols[i].GetType()
+		if d.Elems[i].Operator.Symbol == descpb.ExclusionOverlapsOperator &&
+			typ.Family() != types.StringFamily && colinfo.ColumnTypeIsInvertedIndexable(typ) {
+			invertedOrd = i
+			break
+		}
+	}
+	for i := range d.Elems {
+		if i != invertedOrd && d.Elems[i].Operator.Symbol == descpb.ExclusionEqualsOperator &&
+			colinfo.ColumnTypeIsIndexable(cols[i].GetType()) {
+			addIdxCol(i)
+		}
+	}
+	if invertedOrd >= 0 {
+		idx.Type = descpb.IndexDescriptor_INVERTED
+		addIdxCol(invertedOrd)
+	} else {
+		for i := range d.Elems {
+			if colinfo.ColumnTypeIsIndexable(cols[i].GetType()) {
+				addIdxCol(i)
+			}
+		}
+	}
+	if len(idxCols) == 0 {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"none of the columns of the exclusion constraint can be indexed")
+	}
+	if err := idx.FillColumns(idxCols); err != nil {
+		return err
+	}
+	if invertedOrd >= 0 {
+		if err := populateInvertedIndexDescriptor(
+			ctx, evalCtx.Settings, cols[invertedOrd], &idx, idxCols[len(idxCols)-1],
+		); err != nil {
+			return err
+		}
+	}
+	if d.Predicate != nil {
+		pred, err := schemaexpr.ValidatePartialIndexPredicate(ctx, desc, d.Predicate, tn, semaCtx)
+		if err != nil {
+			return err
+		}
+		idx.Predicate = pred
+		c.Predicate = pred
+	}
+
+	// The constraint and its index share a name, which must not be used by any
+	// other constraint or index of the table.
+	nameInUse := func(name string) bool {
+		if c, _ := desc.FindConstraintWithName(name); c != nil {
+			return true
+		}
+		if idx, _ := desc.FindIndexWithName(name); idx != nil {
+			return true
+		}
+		return desc.FindExclusionConstraintByName(name) != nil
+	}
+	c.Name = string(d.Name)
+	if c.Name == "" {
+		parts := []string{desc.Name}
+		for i := range cols {
+			parts = append(parts, cols[i].GetName())
+		}
+		parts = append(parts, "excl")
+		c.Name = tabledesc.GenerateUniqueName(strings.Join(parts, "_"), nameInUse)
+	} else if nameInUse(c.Name) {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", c.Name)
+	}
+	idx.Name = c.Name
+
+	if ts == NewTable {
+		if err := desc.AddSecondaryIndex(idx); err != nil {
+			return err
+		}
+		c.Validity = descpb.ConstraintValidity_Validated
+	} else {
+		idx.CreatedAtNanos = evalCtx.GetTxnTimestamp(time.Microsecond).UnixNano()
+		if err := desc.AddIndexMutationMaybeWithTempIndex(&idx, descpb.DescriptorMutation_ADD); err != nil {
+			return err
+		}
+		c.Validity = descpb.ConstraintValidity_Validating
+	}
+	desc.ExclusionConstraints = append(desc.ExclusionConstraints, c)
+	return nil
+}
+
+// exclusionConflictQuery returns a query that returns the values of the
+// constraint columns of two rows of the table that conflict according to the
+// given exclusion constraint, if there are any. If rowFilter is not empty,
+// only the conflicts of the rows that satisfy it are returned.
+func exclusionConflictQuery(
+	tableDesc catalog.TableDescriptor, c *descpb.ExclusionConstraint, rowFilter string,
+) (sql string, colNames []string, _ error) {
+	colNames, err := tableDesc.NamesForColumnIDs(c.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkNames, err := tableDesc.NamesForColumnIDs(tableDesc.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Each side of the join scans the constraint and primary key columns of
+	// the rows that satisfy the predicate of the constraint.
+	var scanCols []string
+	addScanCol := func(name string) {
+		name = tree.NameString(name)
+		for _, n := range scanCols {
+			if n == name {
+				return
+			}
+		}
+		scanCols = append(scanCols, name)
+	}
+	for _, n := range colNames {
+		addScanCol(n)
+	}
+	for _, n := range pkNames {
+		addScanCol(n)
+	}
+	scan := fmt.Sprintf("SELECT %s FROM [%d AS t]", strings.Join(scanCols, ", "), tableDesc.GetID())
+	if c.IsPartial() {
+		scan += fmt.Sprintf(" WHERE (%s)", c.Predicate)
+	}
+
+	selectCols := make([]string, 0, 2*len(colNames))
+	where := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		op, err := c.ComparisonOperator(i)
+		if err != nil {
+			return "", nil, err
+		}
+		n = tree.NameString(n)
+		selectCols = append(selectCols, "a."+n)
+		where = append(where, fmt.Sprintf("a.%[1]s %[2]s b.%[1]s", n, op))
+	}
+	for _, n := range colNames {
+		selectCols = append(selectCols, "b."+tree.NameString(n))
+	}
+	aPK := make([]string, len(pkNames))
+	bPK := make([]string, len(pkNames))
+	for i, n := range pkNames {
+		aPK[i] = "a." + tree.NameString(n)
+		bPK[i] = "b." + tree.NameString(n)
+	}
+	where = append(where, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(aPK, ", "), strings.Join(bPK, ", "),
+	))
+
+	// Only the rows of the left side of the join are filtered, since they can
+	// conflict with any row of the table.
+	filteredScan := scan
+	if rowFilter != "" {
+		if c.IsPartial() {
+			filteredScan += fmt.Sprintf(" AND (%s)", rowFilter)
+		} else {
+			filteredScan += fmt.Sprintf(" WHERE (%s)", rowFilter)
+		}
+	}
+
+	return fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS a, (%[3]s) AS b WHERE %[4]s LIMIT 1`,
+		strings.Join(selectCols, ", "), // 1
+		filteredScan,                   // 2
+		scan,                           // 3
+		strings.Join(where, " AND "),   // 4
+	), colNames, nil
+}
+
+// validateExclusionConstraintInTxn validates that no two rows of the table
+// conflict according to the exclusion constraint with the given name, within
+// the provided transaction. If the provided table descriptor version is newer
+// than the cluster version, it will be used in the InternalExecutor that
+// performs the validation query. If rowFilter is not empty, only the conflicts
+// of the rows that satisfy it are checked.
+func validateExclusionConstraintInTxn(
+	ctx context.Context,
+	tableDesc *tabledesc.Mutable,
+	txn *kv.Txn,
+	ie sqlutil.InternalExecutor,
+	user username.SQLUsername,
+	constraintName string,
+	rowFilter string,
+) error {
+	var syntheticDescs []catalog.Descriptor
+	if tableDesc.Version > tableDesc.ClusterVersion().Version {
+		syntheticDescs = append(syntheticDescs, tableDesc)
+	}
+	c := tableDesc.FindExclusionConstraintByName(constraintName)
+	if c == nil {
+		return errors.AssertionFailedf("exclusion constraint %s does not exist", constraintName)
+	}
+	query, colNames, err := exclusionConflictQuery(tableDesc, c, rowFilter)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		c.Name, tableDesc.GetName(), colNames, query)
+
+	return ie.WithSyntheticDescriptors(syntheticDescs, func() error {
+		sessionDataOverride := sessiondata.NoSessionDataOverride
+		sessionDataOverride.User = user
+		values, err := ie.QueryRowEx(
+			ctx, "validate exclusion constraint", txn, sessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		if values.Len() == 0 {
+			return nil
+		}
+		n := len(colNames)
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(pgcode.ExclusionViolation,
+					"could not create exclusion constraint %q", c.Name),
+				c.Name,
+			),
+			fmt.Sprintf("Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				strings.Join(colNames, ", "), datumsString(values[:n]),
+				strings.Join(colNames, ", "), datumsString(values[n:]),
+			),
+		)
+	})
+}
+
+// datumsString returns the comma-separated string representations of the
+// given datums.
+func datumsString(datums tree.Datums) string {
+	strs := make([]string, len(datums))
+	for i, d := range datums {
+		strs[i] = d.String()
+	}
+	return strings.Join(strs, ", ")
+}
+
+// renameExclusionConstraint renames the given exclusion constraint of the
+// table, along with its backing index.
+func renameExclusionConstraint(
+	desc *tabledesc.Mutable, c *descpb.ExclusionConstraint, newName string,
+) error {
+	if other, _ := desc.FindConstraintWithName(newName); other != nil ||
+		desc.FindExclusionConstraintByName(newName) != nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", newName)
+	}
+	idx, err := desc.FindIndexWithID(c.IndexID)
+	if err != nil {
+		return err
+	}
+	if other, _ := desc.FindIndexWithName(newName); other != nil && other.GetID() != idx.GetID() {
+		return pgerror.Newf(pgcode.DuplicateRelation, "relation %v already exists", newName)
+	}
+	c.Name = newName
+	idx.IndexDesc().Name = newName
+	return nil
+}
@@ -258,6 +258,19 @@ var traceSessionEventLogEnabled = settings.RegisterBoolSetting(
 	false,
 ).WithPublic()
 
+// allowReadCommittedIsolation controls whether transactions that request the
+// READ COMMITTED isolation level run with it. If false, they are upgraded to
+// SERIALIZABLE isolation. It defaults to false, so that applications which
+// already request READ COMMITTED, and have so far run with SERIALIZABLE
+// isolation, don't switch to weaker semantics on upgrade.
+var allowReadCommittedIsolation = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.enabled",
+	"set to true to allow transactions to use the READ COMMITTED isolation level "+
+		"if specified by BEGIN/SET commands",
+	false,
+)
+
 // ReorderJoinsLimitClusterSettingName is the name of the cluster setting for
 // the maximum number of joins to reorder.
 const ReorderJoinsLimitClusterSettingName = "sql.defaults.reorder_joins_limit"
@@ -1818,6 +1831,11 @@ func checkResultType(typ *types.T) error {
 	case types.TimestampTZFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
+	case types.TSQueryFamily:
+	case types.TSVectorFamily:
+	case types.JsonpathFamily:
+	case types.RangeFamily:
+	case types.MultirangeFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
 	case types.OidFamily:
@@ -2969,6 +2987,10 @@ func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -3334,6 +3356,10 @@ func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
 	m.data.TrigramSimilarityThreshold = val
 }
 
+func (m *sessionDataMutator) SetDefaultTextSearchConfig(val string) {
+	m.data.DefaultTextSearchConfig = val
+}
+
 func (m *sessionDataMutator) SetUnconstrainedNonCoveringIndexScanEnabled(val bool) {
 	m.data.UnconstrainedNonCoveringIndexScanEnabled = val
 }
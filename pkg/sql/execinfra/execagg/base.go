@@ -70,6 +70,38 @@ func GetAggregateInfo(
 	)
 }
 
+// GetUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given user-defined aggregate. The routines of the aggregate
+// cannot be serialized, so the aggregate must be executed locally.
+func GetUserDefinedAggregateInfo(
+	uda *execinfrapb.UserDefinedAggregate,
+) (aggregateConstructor AggregateConstructor, returnType *types.T, err error) {
+	if uda == nil {
+		return nil, nil, errors.AssertionFailedf("missing user-defined aggregate")
+	}
+	transition, ok := uda.Transition.LocalExpr.(*tree.RoutineExpr)
+	if !ok {
+		return nil, nil, errors.AssertionFailedf("user-defined aggregate must be executed locally")
+	}
+	def := &tree.UserDefinedAggregate{
+		Name:       transition.Name,
+		Transition: transition,
+		InitCond:   tree.DNull,
+	}
+	returnType = transition.ResolvedType()
+	if uda.Final.LocalExpr != nil {
+		def.Final = uda.Final.LocalExpr.(*tree.RoutineExpr)
+		returnType = def.Final.ResolvedType()
+	}
+	if uda.InitCond.LocalExpr != nil {
+		def.InitCond = uda.InitCond.LocalExpr.(tree.Datum)
+	}
+	constructAgg := func(evalCtx *eval.Context, arguments tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(def, evalCtx, arguments)
+	}
+	return constructAgg, returnType, nil
+}
+
 // GetAggregateConstructor processes the specification of a single aggregate
 // function.
 //
@@ -81,6 +113,10 @@ func GetAggregateConstructor(
 	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
 	inputTypes []*types.T,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = GetUserDefinedAggregateInfo(aggInfo.UserDefined)
+		return constructor, nil /* arguments */, outputType, err
+	}
 	argTypes := make([]*types.T, len(aggInfo.ColIdx)+len(aggInfo.Arguments))
 	for j, c := range aggInfo.ColIdx {
 		if c >= uint32(len(inputTypes)) {
@@ -110,6 +146,18 @@ func GetAggregateConstructor(
 	return
 }
 
+// GetUserDefinedWindowFunctionInfo returns the windowFunc constructor and the
+// return type for the given user-defined aggregate used as a window function.
+func GetUserDefinedWindowFunctionInfo(
+	uda *execinfrapb.UserDefinedAggregate,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	constructAgg, returnType, err := GetUserDefinedAggregateInfo(uda)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewAggregateWindowFunc(constructAgg), returnType, nil
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
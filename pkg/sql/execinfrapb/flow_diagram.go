@@ -380,6 +380,15 @@ func (o *OrdinalitySpec) summary() (string, []string) {
 	return "Ordinality", []string{}
 }
 
+// summary implements the diagramCellType interface.
+func (e *ExpandSpec) summary() (string, []string) {
+	details := []string{fmt.Sprintf("Columns: %s", colListStr(e.GroupCols))}
+	for i := range e.GroupingSets {
+		details = append(details, fmt.Sprintf("Set %d: (%s)", i, colListStr(e.GroupingSets[i].Cols)))
+	}
+	return "Expand", details
+}
+
 // summary implements the diagramCellType interface.
 func (d *ProjectSetSpec) summary() (string, []string) {
 	var details []string
@@ -0,0 +1,52 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// expandNode represents a node that emits each row of its child node once
+// per grouping set. Used to support GROUPING SETS, ROLLUP and CUBE.
+//
+// Each output row contains the columns of the source, followed by a copy of
+// each of the grouping columns, followed by the ordinal of the grouping set.
+// The copy of a grouping column is NULL if the column is not part of the
+// grouping set.
+type expandNode struct {
+	source  planNode
+	columns colinfo.ResultColumns
+
+	// groupCols are the ordinals of the grouping columns in the source.
+	groupCols []exec.NodeColumnOrdinal
+
+	// groupingSets contains, for each grouping set, the ordinals of the source
+	// columns that are part of the set.
+	groupingSets []exec.NodeColumnOrdinalSet
+}
+
+func (e *expandNode) startExec(runParams) error {
+	panic("expandNode can't be run in local mode")
+}
+
+func (e *expandNode) Next(params runParams) (bool, error) {
+	panic("expandNode can't be run in local mode")
+}
+
+func (e *expandNode) Values() tree.Datums {
+	panic("expandNode can't be run in local mode")
+}
+
+func (e *expandNode) Close(ctx context.Context) { e.source.Close(ctx) }
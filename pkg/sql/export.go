@@ -82,6 +82,8 @@ const (
 	exportSnappyCodec     = "snappy"
 	csvSuffix             = "csv"
 	parquetSuffix         = "parquet"
+	jsonSuffix            = "json"
+	avroSuffix            = "avro"
 )
 
 var exportOptionExpectValues = map[string]exprutil.KVStringOptValidate{
@@ -131,7 +133,9 @@ func (ef *execFactory) ConstructExport(
 		return nil, errors.Errorf("EXPORT cannot be used inside a multi-statement transaction")
 	}
 
-	if fileSuffix != csvSuffix && fileSuffix != parquetSuffix {
+	switch fileSuffix {
+	case csvSuffix, parquetSuffix, jsonSuffix, avroSuffix:
+	default:
 		return nil, errors.Errorf("unsupported export format: %q", fileSuffix)
 	}
 
@@ -208,6 +212,11 @@ func (ef *execFactory) ConstructExport(
 		}
 		format.Format = roachpb.IOFileFormat_Parquet
 		format.Parquet = parquetOpts
+	case jsonSuffix:
+		format.Format = roachpb.IOFileFormat_JSON
+	case avroSuffix:
+		format.Format = roachpb.IOFileFormat_Avro
+		format.Avro = roachpb.AvroOptions{Format: roachpb.AvroOptions_OCF}
 	}
 
 	chunkRows := exportChunkRowsDefault
@@ -239,7 +248,8 @@ func (ef *execFactory) ConstructExport(
 		switch {
 		case strings.EqualFold(name, exportGzipCodec):
 			codec = roachpb.IOFileFormat_Gzip
-		case strings.EqualFold(name, exportSnappyCodec) && fileSuffix == parquetSuffix:
+		case strings.EqualFold(name, exportSnappyCodec) &&
+			(fileSuffix == parquetSuffix || fileSuffix == avroSuffix):
 			codec = roachpb.IOFileFormat_Snappy
 		default:
 			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
@@ -74,6 +74,9 @@ type aggregateFuncHolder struct {
 	arguments tree.Datums
 	// isDistinct indicates whether only distinct values are aggregated.
 	isDistinct bool
+	// userDefined is set if the function is an aggregate created with CREATE
+	// AGGREGATE.
+	userDefined *tree.UserDefinedAggregate
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
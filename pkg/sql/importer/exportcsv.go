@@ -14,17 +14,13 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"fmt"
 	"strings"
 
-	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
-	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
-	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
@@ -44,39 +40,43 @@ type csvExporter struct {
 	compressor *gzip.Writer
 	buf        *bytes.Buffer
 	csvWriter  *csv.Writer
+	nullsAs    *string
+	f          *tree.FmtCtx
+	csvRow     []string
 }
 
+var _ ExportEncoder = &csvExporter{}
+
 // Write append record to csv file.
-func (c *csvExporter) Write(record []string) error {
-	return c.csvWriter.Write(record)
+func (c *csvExporter) Write(row tree.Datums) error {
+	for i, d := range row {
+		if d == tree.DNull {
+			if c.nullsAs == nil {
+				return errors.New("NULL value encountered during EXPORT, " +
+					"use `WITH nullas` to specify the string representation of NULL")
+			}
+			c.csvRow[i] = *c.nullsAs
+			continue
+		}
+		d.Format(c.f)
+		c.csvRow[i] = c.f.String()
+		c.f.Reset()
+	}
+	return c.csvWriter.Write(c.csvRow)
 }
 
-// Close closes the compressor writer which
+// Close flushes the csv writer and closes the compressor writer which
 // appends archive footers.
 func (c *csvExporter) Close() error {
-	if c.compressor != nil {
-		return c.compressor.Close()
-	}
-	return nil
-}
-
-// Flush flushes both csv and compressor writer if
-// initialized.
-func (c *csvExporter) Flush() error {
+	c.f.Close()
 	c.csvWriter.Flush()
-	if c.compressor != nil {
-		return c.compressor.Flush()
+	if err := c.csvWriter.Error(); err != nil {
+		return err
 	}
-	return nil
-}
-
-// ResetBuffer resets the buffer and compressor state.
-func (c *csvExporter) ResetBuffer() {
-	c.buf.Reset()
 	if c.compressor != nil {
-		// Brings compressor to its initial state.
-		c.compressor.Reset(c.buf)
+		return c.compressor.Close()
 	}
+	return nil
 }
 
 // Bytes results in the slice of bytes with compressed content.
@@ -103,7 +103,7 @@ func (c *csvExporter) FileName(spec execinfrapb.ExportSpec, part string) string
 	return fileName
 }
 
-func newCSVExporter(sp execinfrapb.ExportSpec) *csvExporter {
+func newCSVExporter(sp execinfrapb.ExportSpec, numCols int) *csvExporter {
 	buf := bytes.NewBuffer([]byte{})
 	var exporter *csvExporter
 	switch sp.Format.Compression {
@@ -127,6 +127,9 @@ func newCSVExporter(sp execinfrapb.ExportSpec) *csvExporter {
 	if sp.Format.Csv.Comma != 0 {
 		exporter.csvWriter.Comma = sp.Format.Csv.Comma
 	}
+	exporter.nullsAs = sp.Format.Csv.NullEncoding
+	exporter.f = tree.NewFmtCtx(tree.FmtExport)
+	exporter.csvRow = make([]string, numCols)
 	return exporter
 }
 
@@ -179,134 +182,11 @@ func (sp *csvWriter) Run(ctx context.Context) {
 	ctx, span := tracing.ChildSpan(ctx, "csvWriter")
 	defer span.Finish()
 
-	instanceID := sp.flowCtx.EvalCtx.NodeID.SQLInstanceID()
-	uniqueID := builtins.GenerateUniqueInt(builtins.ProcessUniqueID(instanceID))
-
-	err := func() error {
-		typs := sp.input.OutputTypes()
-		sp.input.Start(ctx)
-		input := execinfra.MakeNoMetadataRowSource(sp.input, sp.output)
-
-		alloc := &tree.DatumAlloc{}
-
-		writer := newCSVExporter(sp.spec)
-
-		var nullsAs string
-		if sp.spec.Format.Csv.NullEncoding != nil {
-			nullsAs = *sp.spec.Format.Csv.NullEncoding
-		}
-		f := tree.NewFmtCtx(tree.FmtExport)
-		defer f.Close()
-
-		csvRow := make([]string, len(typs))
-
-		chunk := 0
-		done := false
-		for {
-			var rows int64
-			writer.ResetBuffer()
-			for {
-				// If the bytes.Buffer sink exceeds the target size of a CSV file, we
-				// flush before exporting any additional rows.
-				if int64(writer.buf.Len()) >= sp.spec.ChunkSize {
-					break
-				}
-				if sp.spec.ChunkRows > 0 && rows >= sp.spec.ChunkRows {
-					break
-				}
-				row, err := input.NextRow()
-				if err != nil {
-					return err
-				}
-				if row == nil {
-					done = true
-					break
-				}
-				rows++
-
-				for i, ed := range row {
-					if ed.IsNull() {
-						if sp.spec.Format.Csv.NullEncoding != nil {
-							csvRow[i] = nullsAs
-							continue
-						} else {
-							return errors.New("NULL value encountered during EXPORT, " +
-								"use `WITH nullas` to specify the string representation of NULL")
-						}
-					}
-					if err := ed.EnsureDecoded(typs[i], alloc); err != nil {
-						return err
-					}
-					ed.Datum.Format(f)
-					csvRow[i] = f.String()
-					f.Reset()
-				}
-				if err := writer.Write(csvRow); err != nil {
-					return err
-				}
-			}
-			if rows < 1 {
-				break
-			}
-			if err := writer.Flush(); err != nil {
-				return errors.Wrap(err, "failed to flush csv writer")
-			}
-
-			conf, err := cloud.ExternalStorageConfFromURI(sp.spec.Destination, sp.spec.User())
-			if err != nil {
-				return err
-			}
-			es, err := sp.flowCtx.Cfg.ExternalStorage(ctx, conf)
-			if err != nil {
-				return err
-			}
-			defer es.Close()
-
-			part := fmt.Sprintf("n%d.%d", uniqueID, chunk)
-			chunk++
-			filename := writer.FileName(sp.spec, part)
-			// Close writer to ensure buffer and any compression footer is flushed.
-			err = writer.Close()
-			if err != nil {
-				return errors.Wrapf(err, "failed to close exporting writer")
-			}
-
-			size := writer.Len()
-
-			if err := cloud.WriteFile(ctx, es, filename, bytes.NewReader(writer.Bytes())); err != nil {
-				return err
-			}
-			res := rowenc.EncDatumRow{
-				rowenc.DatumToEncDatum(
-					types.String,
-					tree.NewDString(filename),
-				),
-				rowenc.DatumToEncDatum(
-					types.Int,
-					tree.NewDInt(tree.DInt(rows)),
-				),
-				rowenc.DatumToEncDatum(
-					types.Int,
-					tree.NewDInt(tree.DInt(size)),
-				),
-			}
-
-			cs, err := sp.out.EmitRow(ctx, res, sp.output)
-			if err != nil {
-				return err
-			}
-			if cs != execinfra.NeedMoreRows {
-				// We don't return an error here because we want the error (if any) that
-				// actually caused the consumer to enter a closed/draining state to take precendence.
-				return nil
-			}
-			if done {
-				break
-			}
-		}
-
-		return nil
-	}()
+	numCols := len(sp.input.OutputTypes())
+	err := WriteExportFiles(ctx, sp.flowCtx, sp.spec, sp.input, &sp.out, sp.output,
+		func() (ExportEncoder, error) {
+			return newCSVExporter(sp.spec, numCols), nil
+		})
 
 	// TODO(dt): pick up tracing info in trailing meta
 	execinfra.DrainAndClose(
@@ -0,0 +1,175 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+const exportJSONFilePatternDefault = exportFilePatternPart + ".json"
+
+// jsonExporter writes rows as newline-delimited JSON objects, keyed by column
+// name, optionally compressing the output.
+type jsonExporter struct {
+	compressor *gzip.Writer
+	buf        *bytes.Buffer
+	w          io.Writer
+	colNames   []string
+	scratch    bytes.Buffer
+}
+
+var _ ExportEncoder = &jsonExporter{}
+
+// Write appends a row to the JSON file.
+func (c *jsonExporter) Write(row tree.Datums) error {
+	b := json.NewObjectBuilder(len(row))
+	for i, d := range row {
+		j, err := tree.AsJSON(d, sessiondatapb.DataConversionConfig{}, time.UTC)
+		if err != nil {
+			return err
+		}
+		b.Add(c.colNames[i], j)
+	}
+	c.scratch.Reset()
+	b.Build().Format(&c.scratch)
+	c.scratch.WriteByte('\n')
+	_, err := c.w.Write(c.scratch.Bytes())
+	return err
+}
+
+// Close closes the compressor writer which appends archive footers.
+func (c *jsonExporter) Close() error {
+	if c.compressor != nil {
+		return c.compressor.Close()
+	}
+	return nil
+}
+
+// Bytes results in the slice of bytes with compressed content.
+func (c *jsonExporter) Bytes() []byte {
+	return c.buf.Bytes()
+}
+
+// Len returns length of the buffer with content.
+func (c *jsonExporter) Len() int {
+	return c.buf.Len()
+}
+
+func (c *jsonExporter) FileName(spec execinfrapb.ExportSpec, part string) string {
+	pattern := exportJSONFilePatternDefault
+	if spec.NamePattern != "" {
+		pattern = spec.NamePattern
+	}
+
+	fileName := strings.Replace(pattern, exportFilePatternPart, part, -1)
+	if c.compressor != nil {
+		fileName += ".gz"
+	}
+	return fileName
+}
+
+func newJSONExporter(sp execinfrapb.ExportSpec, numCols int) (*jsonExporter, error) {
+	if len(sp.ColNames) != numCols {
+		return nil, errors.AssertionFailedf(
+			"expected %d column names for JSON export, found %d", numCols, len(sp.ColNames))
+	}
+	buf := bytes.NewBuffer([]byte{})
+	exporter := &jsonExporter{
+		buf:      buf,
+		w:        buf,
+		colNames: sp.ColNames,
+	}
+	if sp.Format.Compression == roachpb.IOFileFormat_Gzip {
+		exporter.compressor = gzip.NewWriter(buf)
+		exporter.w = exporter.compressor
+	}
+	return exporter, nil
+}
+
+func newJSONWriterProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportSpec,
+	input execinfra.RowSource,
+	output execinfra.RowReceiver,
+) (execinfra.Processor, error) {
+	c := &jsonWriter{
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		input:       input,
+		output:      output,
+	}
+	semaCtx := tree.MakeSemaContext()
+	if err := c.out.Init(ctx, &execinfrapb.PostProcessSpec{}, c.OutputTypes(), &semaCtx, flowCtx.NewEvalCtx()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type jsonWriter struct {
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ExportSpec
+	input       execinfra.RowSource
+	out         execinfra.ProcOutputHelper
+	output      execinfra.RowReceiver
+}
+
+var _ execinfra.Processor = &jsonWriter{}
+
+func (sp *jsonWriter) OutputTypes() []*types.T {
+	res := make([]*types.T, len(colinfo.ExportColumns))
+	for i := range res {
+		res[i] = colinfo.ExportColumns[i].Typ
+	}
+	return res
+}
+
+func (sp *jsonWriter) MustBeStreaming() bool {
+	return false
+}
+
+func (sp *jsonWriter) Run(ctx context.Context) {
+	ctx, span := tracing.ChildSpan(ctx, "jsonWriter")
+	defer span.Finish()
+
+	numCols := len(sp.input.OutputTypes())
+	err := WriteExportFiles(ctx, sp.flowCtx, sp.spec, sp.input, &sp.out, sp.output,
+		func() (ExportEncoder, error) {
+			return newJSONExporter(sp.spec, numCols)
+		})
+
+	execinfra.DrainAndClose(
+		ctx, sp.output, err, func(context.Context) {} /* pushTrailingMeta */, sp.input)
+}
+
+func init() {
+	rowexec.NewJSONWriterProcessor = newJSONWriterProcessor
+}
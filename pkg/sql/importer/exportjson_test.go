@@ -0,0 +1,81 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportJSON(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE foo (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], j JSONB)`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES
+		(1, 'a', 1.5, ARRAY[1, 2], '{"k": "v"}'),
+		(2, NULL, NULL, NULL, NULL),
+		(3, 'c"', 3, ARRAY[], '[]')`)
+
+	t.Run("plain", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO JSON 'nodelocal://0/plain' FROM SELECT * FROM foo ORDER BY i`)
+		content := readFileByGlob(t, filepath.Join(dir, "plain", "export*-n*.0.json"))
+		require.Equal(t, `{"a": [1, 2], "d": 1.5, "i": 1, "j": {"k": "v"}, "s": "a"}
+{"a": null, "d": null, "i": 2, "j": null, "s": null}
+{"a": [], "d": 3, "i": 3, "j": [], "s": "c\""}
+`, string(content))
+	})
+
+	t.Run("chunked", func(t *testing.T) {
+		sqlDB.CheckQueryResults(t,
+			`SELECT rows FROM [EXPORT INTO JSON 'nodelocal://0/chunked' WITH chunk_rows = '2' FROM TABLE foo]
+			ORDER BY filename`,
+			[][]string{{"2"}, {"1"}})
+		files, err := filepath.Glob(filepath.Join(dir, "chunked", "export*-n*.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO JSON 'nodelocal://0/compressed' WITH compression = 'gzip'
+			FROM SELECT i FROM foo ORDER BY i`)
+		compressed := readFileByGlob(t, filepath.Join(dir, "compressed", "export*-n*.0.json.gz"))
+		gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		defer gzipReader.Close()
+		content, err := io.ReadAll(gzipReader)
+		require.NoError(t, err)
+		require.Equal(t, "{\"i\": 1}\n{\"i\": 2}\n{\"i\": 3}\n", string(content))
+	})
+
+	t.Run("snappy", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "unsupported compression codec snappy for json file format",
+			`EXPORT INTO JSON 'nodelocal://0/snappy' WITH compression = 'snappy' FROM TABLE foo`)
+	})
+}
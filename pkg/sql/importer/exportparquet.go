@@ -14,7 +14,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"math"
 	"strings"
 	"time"
 
@@ -328,43 +327,16 @@ func NewParquetColumn(typ *types.T, name string, nullable bool) (ParquetColumn,
 			}
 		}
 	case types.DecimalFamily:
-		// TODO (MB): Investigate if the parquet vendor should enforce precision and
-		// scale requirements. In a toy example, the parquet vendor was able to
-		// write/read roundtrip the string "3235.5432" as a Decimal with Scale = 1,
-		// Precision = 1, even though this decimal has a larger scale and precision.
-		// I guess it's the responsibility of CRDB to enforce the Scale and
-		// Precision conditions, and for the parquet vendor to NOT lose data, even if
-		// the data doesn't follow the scale and precision conditions.
-
-		schemaEl.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
-
-		schemaEl.LogicalType = parquet.NewLogicalType()
-		schemaEl.LogicalType.DECIMAL = parquet.NewDecimalType()
-
-		schemaEl.LogicalType.DECIMAL.Scale = typ.Scale()
-		schemaEl.LogicalType.DECIMAL.Precision = typ.Precision()
-		schemaEl.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL)
-
-		// According to PostgresSQL docs, scale or precision of 0 implies max
-		// precision and scale. I assume this is what CRDB does, but this isn't
-		// explicit in the docs https://www.postgresql.org/docs/10/datatype-numeric.html
-		if typ.Scale() == 0 {
-			schemaEl.LogicalType.DECIMAL.Scale = math.MaxInt32
-		}
-		if typ.Precision() == 0 {
-			schemaEl.LogicalType.DECIMAL.Precision = math.MaxInt32
-		}
-
-		schemaEl.Scale = &schemaEl.LogicalType.DECIMAL.Scale
-		schemaEl.Precision = &schemaEl.LogicalType.DECIMAL.Precision
-
+		// Decimals are encoded as strings rather than with the DECIMAL logical
+		// type, which stores an unscaled integer with a fixed scale for the whole
+		// column: CRDB decimals without a declared scale have a scale per value,
+		// and decimals can be NaN or infinite.
+		populateLogicalStringCol(schemaEl)
 		col.encodeFn = func(d tree.Datum) (interface{}, error) {
 			dec := d.(*tree.DDecimal).Decimal
 			return []byte(dec.String()), nil
 		}
 		col.DecodeFn = func(x interface{}) (tree.Datum, error) {
-			// TODO (MB): investigative if crdb should gather decimal metadata from
-			// parquet file during IMPORT PARQUET.
 			return tree.ParseDDecimal(string(x.([]byte)))
 		}
 	case types.UuidFamily:
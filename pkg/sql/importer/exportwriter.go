@@ -0,0 +1,163 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ExportEncoder encodes rows into the contents of a single exported file.
+type ExportEncoder interface {
+	// Write appends a row to the file.
+	Write(row tree.Datums) error
+	// Len returns the number of bytes of the file encoded so far.
+	Len() int
+	// Close flushes any buffered rows and finishes the file, e.g. by writing
+	// compression footers. No rows can be written after Close.
+	Close() error
+	// Bytes returns the contents of the file once it has been closed.
+	Bytes() []byte
+	// FileName returns the name of the file for the given part.
+	FileName(spec execinfrapb.ExportSpec, part string) string
+}
+
+// WriteExportFiles implements the main loop of the processors that export
+// rows. It reads rows from input and encodes them into files of at most
+// spec.ChunkSize bytes, or spec.ChunkRows rows if set, each of which is
+// encoded by a new encoder returned by newEncoder. Once a file is complete,
+// it is written to spec.Destination and a row describing it is emitted to
+// output.
+func WriteExportFiles(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	spec execinfrapb.ExportSpec,
+	input execinfra.RowSource,
+	out *execinfra.ProcOutputHelper,
+	output execinfra.RowReceiver,
+	newEncoder func() (ExportEncoder, error),
+) error {
+	instanceID := flowCtx.EvalCtx.NodeID.SQLInstanceID()
+	uniqueID := builtins.GenerateUniqueInt(builtins.ProcessUniqueID(instanceID))
+
+	typs := input.OutputTypes()
+	input.Start(ctx)
+	rows := execinfra.MakeNoMetadataRowSource(input, output)
+	alloc := &tree.DatumAlloc{}
+	row := make(tree.Datums, len(typs))
+
+	var es cloud.ExternalStorage
+	defer func() {
+		if es != nil {
+			es.Close()
+		}
+	}()
+
+	done := false
+	for chunk := 0; !done; chunk++ {
+		encoder, err := newEncoder()
+		if err != nil {
+			return err
+		}
+		var numRows int64
+		for {
+			// If the encoded file exceeds the target size, we finish it before
+			// exporting any additional rows. Every file holds at least one row,
+			// since some formats write a header before any row.
+			if numRows > 0 && int64(encoder.Len()) >= spec.ChunkSize {
+				break
+			}
+			if spec.ChunkRows > 0 && numRows >= spec.ChunkRows {
+				break
+			}
+			encRow, err := rows.NextRow()
+			if err != nil {
+				return err
+			}
+			if encRow == nil {
+				done = true
+				break
+			}
+			numRows++
+
+			for i, ed := range encRow {
+				if err := ed.EnsureDecoded(typs[i], alloc); err != nil {
+					return err
+				}
+				row[i] = ed.Datum
+			}
+			if err := encoder.Write(row); err != nil {
+				return err
+			}
+		}
+		// Close the encoder to ensure that the file, including any compression
+		// footer, is complete.
+		if err := encoder.Close(); err != nil {
+			return errors.Wrap(err, "failed to close exporting writer")
+		}
+		if numRows < 1 {
+			break
+		}
+
+		if es == nil {
+			conf, err := cloud.ExternalStorageConfFromURI(spec.Destination, spec.User())
+			if err != nil {
+				return err
+			}
+			if es, err = flowCtx.Cfg.ExternalStorage(ctx, conf); err != nil {
+				return err
+			}
+		}
+
+		part := fmt.Sprintf("n%d.%d", uniqueID, chunk)
+		filename := encoder.FileName(spec, part)
+		size := encoder.Len()
+		if err := cloud.WriteFile(ctx, es, filename, bytes.NewReader(encoder.Bytes())); err != nil {
+			return err
+		}
+		res := rowenc.EncDatumRow{
+			rowenc.DatumToEncDatum(
+				types.String,
+				tree.NewDString(filename),
+			),
+			rowenc.DatumToEncDatum(
+				types.Int,
+				tree.NewDInt(tree.DInt(numRows)),
+			),
+			rowenc.DatumToEncDatum(
+				types.Int,
+				tree.NewDInt(tree.DInt(size)),
+			),
+		}
+
+		cs, err := out.EmitRow(ctx, res, output)
+		if err != nil {
+			return err
+		}
+		if cs != execinfra.NeedMoreRows {
+			// We don't return an error here because we want the error (if any) that
+			// actually caused the consumer to enter a closed/draining state to take
+			// precedence.
+			return nil
+		}
+	}
+	return nil
+}
@@ -281,6 +281,23 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}
 
+	// Split large parquet files into inputs which read some of their row groups
+	// the first time the job runs. The inputs are recorded so that their resume
+	// positions keep their meaning when the job is resumed.
+	if format.Format == roachpb.IOFileFormat_Parquet && format.Parquet.RowGroups == nil {
+		var err error
+		files, format.Parquet.RowGroups, err = splitParquetFiles(ctx,
+			p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, p.User(), files, format.Parquet,
+			parquetSplitSize.Get(&p.ExecCfg().Settings.SV))
+		if err != nil {
+			return err
+		}
+		details.URIs, details.Format = files, format
+		if err := r.job.SetDetails(ctx, nil /* txn */, details); err != nil {
+			return err
+		}
+	}
+
 	procsPerNode := int(processorsPerNode.Get(&p.ExecCfg().Settings.SV))
 
 	res, err := ingestWithRetry(ctx, p, r.job, tables, typeDescs, files, format, details.Walltime,
@@ -85,7 +85,7 @@ const (
 
 	optMaxRowSize = "max_row_size"
 
-	// Turn on strict validation when importing avro records.
+	// Turn on strict validation when importing avro or parquet records.
 	avroStrict = "strict_validation"
 	// Default input format is assumed to be OCF (object container file).
 	// This default can be changed by specified either of these options.
@@ -173,6 +173,8 @@ var avroAllowedOptions = makeStringSet(
 	avroRecordsSeparatedBy, avroSchema, avroSchemaURI, optMaxRowSize, csvRowLimit,
 )
 
+var parquetAllowedOptions = makeStringSet(avroStrict, csvRowLimit)
+
 var csvAllowedOptions = makeStringSet(
 	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes, csvRowLimit, csvAllowQuotedNulls,
 )
@@ -199,6 +201,7 @@ var allowedIntoFormats = map[string]struct{}{
 	"AVRO":      {},
 	"DELIMITED": {},
 	"PGCOPY":    {},
+	"PARQUET":   {},
 }
 
 // featureImportEnabled is used to enable and disable the IMPORT feature.
@@ -759,6 +762,22 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+		case "PARQUET":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, parquetAllowedOptions); err != nil {
+				return err
+			}
+			format.Format = roachpb.IOFileFormat_Parquet
+			_, format.Parquet.StrictMode = opts[avroStrict]
+			if override, ok := opts[csvRowLimit]; ok {
+				rowLimit, err := strconv.Atoi(override)
+				if err != nil {
+					return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+				}
+				if rowLimit <= 0 {
+					return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+				}
+				format.Parquet.RowLimit = int64(rowLimit)
+			}
 		default:
 			return unimplemented.Newf("import.format", "unsupported import format: %q", importStmt.FileFormat)
 		}
@@ -115,6 +115,7 @@ func MakeSimpleTableDescriptor(
 	for i := range create.Defs {
 		switch def := create.Defs[i].(type) {
 		case *tree.CheckConstraintTableDef,
+			*tree.ExcludeConstraintTableDef,
 			*tree.FamilyTableDef,
 			*tree.UniqueConstraintTableDef:
 			// ignore
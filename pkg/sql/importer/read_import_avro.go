@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/big"
 	"time"
 	"unicode/utf8"
 
@@ -44,11 +45,25 @@ func nativeTimeToDatum(t time.Time, targetT *types.T) (tree.Datum, error) {
 		return tree.NewDDateFromTime(t)
 	case types.TimestampFamily:
 		return tree.MakeDTimestamp(t, duration)
+	case types.TimestampTZFamily:
+		return tree.MakeDTimestampTZ(t, duration)
 	default:
 		return nil, errors.New("type not supported")
 	}
 }
 
+// avroRatToString formats a decimal returned by goavro without losing any
+// digits. goavro constructs the rational from an unscaled integer and a power
+// of ten, so its denominator always divides some power of ten.
+func avroRatToString(r *big.Rat) string {
+	scale := 0
+	ten := big.NewInt(10)
+	for pow := big.NewInt(1); new(big.Int).Rem(pow, r.Denom()).Sign() != 0; pow.Mul(pow, ten) {
+		scale++
+	}
+	return r.FloatString(scale)
+}
+
 // nativeToDatum converts go native types (interface{} as returned by goavro
 // library) and logical time types to the datum with the appropriate type.
 //
@@ -57,7 +72,7 @@ func nativeTimeToDatum(t time.Time, targetT *types.T) (tree.Datum, error) {
 //   - primitive avro types: null, boolean, int (32), long (64), float (32), double (64),
 //     bytes, string, and arrays of the above.
 //   - logical avro types (as defined by the go avro library): long.time-micros, int.time-millis,
-//     long.timestamp-micros,long.timestamp-millis, and int.date
+//     long.timestamp-micros,long.timestamp-millis, int.date, and bytes.decimal
 //
 // An avro record is, essentially, a key->value mapping from field name to field value.
 // A field->value mapping may be represented directly (i.e. the
@@ -100,6 +115,9 @@ func nativeToDatum(
 		d = tree.NewDFloat(tree.DFloat(v))
 	case time.Time:
 		return nativeTimeToDatum(v, targetT)
+	case *big.Rat:
+		// goavro returns avro cols of logical type decimal as big.Rat.
+		return rowenc.ParseDatumStringAs(ctx, targetT, avroRatToString(v), evalCtx)
 	case time.Duration:
 		// goAvro returns avro cols of logical type time as time.duration
 		dU := v / time.Microsecond
@@ -181,10 +199,13 @@ var familyToAvroT = map[types.Family][]string{
 	types.TimeFamily:      {"string", "long.time-micros", "int.time-millis"},
 	types.TimestampFamily: {"string", "long.timestamp-micros", "long.timestamp-millis"},
 
+	// Avro timestamps are always UTC instants, which is also how changefeeds and
+	// EXPORT encode TIMESTAMPTZ.
+	types.TimestampTZFamily: {"string", "long.timestamp-micros", "long.timestamp-millis"},
+
 	// goavro does not yet support times with local timezones. So, CRDB can only
 	// import these datum types if the goAvro type is string.
-	types.TimeTZFamily:      {"string"},
-	types.TimestampTZFamily: {"string"},
+	types.TimeTZFamily: {"string"},
 
 	// goavro does no support the interval logical type
 	types.IntervalFamily: {"string"},
@@ -195,7 +216,7 @@ var familyToAvroT = map[types.Family][]string{
 	types.INetFamily:           {"string"},
 	types.JsonFamily:           {"string"},
 	types.BitFamily:            {"string"},
-	types.DecimalFamily:        {"string"}, //TODO(Butler): import avro with logical decimal
+	types.DecimalFamily:        {"bytes.decimal", "string"},
 	types.EnumFamily:           {"string"},
 }
 
@@ -0,0 +1,964 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+)
+
+// parquetSplitSize is the size of the row groups of a parquet file above which
+// the file is split into several inputs of an import.
+var parquetSplitSize = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"bulkio.import.parquet_split_size",
+	"target size of the row groups of a parquet file read by a single import processor",
+	128<<20,
+)
+
+// maxParquetRowGroupReaders bounds the number of row groups of a single file
+// that are decoded concurrently. Every reader holds a fully decoded row group
+// in memory while it waits for the rows to be consumed.
+const maxParquetRowGroupReaders = 4
+
+// parquetColumn maps a top-level column of a parquet file to the visible
+// table column it is imported into.
+type parquetColumn struct {
+	name string
+	idx  int
+	def  *parquetschema.ColumnDefinition
+}
+
+// parquetValueToDatum converts the value x of a parquet column described by
+// def to a datum of type targetT. Decoding is driven by the file's schema, so
+// that logical types (decimals, dates, timestamps, lists, ...) are interpreted
+// the same way regardless of the column type they are imported into.
+//
+// Primitive values are first converted to the datum that naturally represents
+// their logical type, which is then cast to the target type if needed. Lists
+// can be imported into array columns. Lists, maps and nested groups can be
+// imported into JSONB columns.
+func parquetValueToDatum(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	x interface{},
+	def *parquetschema.ColumnDefinition,
+	targetT *types.T,
+) (tree.Datum, error) {
+	if x == nil {
+		return tree.DNull, nil
+	}
+
+	elems, elemDef, isList, err := parquetListElements(x, def)
+	if err != nil {
+		return nil, err
+	}
+	if isList && targetT.Family() == types.ArrayFamily {
+		arr := tree.NewDArray(targetT.ArrayContents())
+		for _, elem := range elems {
+			d, err := parquetValueToDatum(ctx, evalCtx, elem, elemDef, targetT.ArrayContents())
+			if err == nil {
+				err = arr.Append(d)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+
+	var d tree.Datum
+	if isList || len(def.Children) > 0 {
+		j, err := parquetValueToJSON(evalCtx, x, def)
+		if err != nil {
+			return nil, err
+		}
+		d = tree.NewDJSON(j)
+	} else {
+		d, err = parquetPrimitiveToDatum(x, def.SchemaElement, targetT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d == tree.DNull || targetT.Equivalent(d.ResolvedType()) {
+		return d, nil
+	}
+	return eval.PerformCast(ctx, evalCtx, d, targetT)
+}
+
+// parquetValueToJSON converts the value x of a parquet column described by def to
+// JSON. Groups become objects keyed by field name, maps become objects keyed by
+// the text of their keys and lists become arrays.
+func parquetValueToJSON(
+	evalCtx *eval.Context, x interface{}, def *parquetschema.ColumnDefinition,
+) (json.JSON, error) {
+	if x == nil {
+		return json.NullJSONValue, nil
+	}
+
+	elems, elemDef, isList, err := parquetListElements(x, def)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case isList:
+		b := json.NewArrayBuilder(len(elems))
+		for _, elem := range elems {
+			j, err := parquetValueToJSON(evalCtx, elem, elemDef)
+			if err != nil {
+				return nil, err
+			}
+			b.Add(j)
+		}
+		return b.Build(), nil
+
+	case isParquetMap(def):
+		keyValue := def.Children[0]
+		if len(keyValue.Children) != 2 {
+			return nil, errors.Newf("unexpected layout of parquet map %q", def.SchemaElement.Name)
+		}
+		group, ok := x.(map[string]interface{})
+		if !ok {
+			return nil, errors.Newf("unexpected value %T for parquet map %q", x, def.SchemaElement.Name)
+		}
+		entries, _ := group[keyValue.SchemaElement.Name].([]map[string]interface{})
+		b := json.NewObjectBuilder(len(entries))
+		for _, entry := range entries {
+			keyDef, valueDef := keyValue.Children[0], keyValue.Children[1]
+			key, ok := entry[keyDef.SchemaElement.Name]
+			if !ok {
+				// An empty map is read back as a single entry without a key.
+				continue
+			}
+			k, err := parquetPrimitiveToDatum(key, keyDef.SchemaElement, types.String)
+			if err != nil {
+				return nil, err
+			}
+			v, err := parquetValueToJSON(evalCtx, entry[valueDef.SchemaElement.Name], valueDef)
+			if err != nil {
+				return nil, err
+			}
+			b.Add(tree.AsStringWithFlags(k, tree.FmtBareStrings), v)
+		}
+		return b.Build(), nil
+
+	case len(def.Children) > 0:
+		group, ok := x.(map[string]interface{})
+		if !ok {
+			return nil, errors.Newf("unexpected value %T for parquet group %q", x, def.SchemaElement.Name)
+		}
+		b := json.NewObjectBuilder(len(def.Children))
+		for _, child := range def.Children {
+			v, err := parquetValueToJSON(evalCtx, group[child.SchemaElement.Name], child)
+			if err != nil {
+				return nil, err
+			}
+			b.Add(child.SchemaElement.Name, v)
+		}
+		return b.Build(), nil
+
+	default:
+		d, err := parquetPrimitiveToDatum(x, def.SchemaElement, types.Jsonb)
+		if err != nil {
+			return nil, err
+		}
+		return tree.AsJSON(d, evalCtx.SessionData().DataConversionConfig, evalCtx.GetLocation())
+	}
+}
+
+// parquetPrimitiveToDatum converts a value of a primitive parquet column to the datum
+// that represents its logical type. The target type is only consulted for
+// binary values that carry no logical type, which are commonly used to store
+// strings as well as raw bytes.
+func parquetPrimitiveToDatum(
+	x interface{}, el *parquet.SchemaElement, targetT *types.T,
+) (tree.Datum, error) {
+	lt := el.GetLogicalType()
+	ct := el.GetConvertedType()
+	isDecimal := (lt != nil && lt.IsSetDECIMAL()) || (el.IsSetConvertedType() && ct == parquet.ConvertedType_DECIMAL)
+
+	switch v := x.(type) {
+	case bool:
+		return tree.MakeDBool(tree.DBool(v)), nil
+
+	case int32:
+		switch {
+		case isDecimal:
+			return parquetDecimal(big.NewInt(int64(v)), parquetDecimalScale(el)), nil
+		case (lt != nil && lt.IsSetDATE()) || (el.IsSetConvertedType() && ct == parquet.ConvertedType_DATE):
+			date, err := pgdate.MakeDateFromUnixEpoch(int64(v))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDDate(date), nil
+		case (lt != nil && lt.IsSetTIME()) || (el.IsSetConvertedType() && ct == parquet.ConvertedType_TIME_MILLIS):
+			return tree.MakeDTime(timeofday.TimeOfDay(int64(v) * 1000)), nil
+		case parquetIsUnsigned(el):
+			return tree.NewDInt(tree.DInt(uint32(v))), nil
+		default:
+			return tree.NewDInt(tree.DInt(v)), nil
+		}
+
+	case int64:
+		switch {
+		case isDecimal:
+			return parquetDecimal(big.NewInt(v), parquetDecimalScale(el)), nil
+		case lt != nil && lt.IsSetTIMESTAMP():
+			t := parquetTime(v, lt.TIMESTAMP.Unit)
+			if lt.TIMESTAMP.IsAdjustedToUTC {
+				return tree.MakeDTimestampTZ(t, time.Microsecond)
+			}
+			return tree.MakeDTimestamp(t, time.Microsecond)
+		case el.IsSetConvertedType() && ct == parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return tree.MakeDTimestampTZ(time.UnixMilli(v).UTC(), time.Microsecond)
+		case el.IsSetConvertedType() && ct == parquet.ConvertedType_TIMESTAMP_MICROS:
+			return tree.MakeDTimestampTZ(time.UnixMicro(v).UTC(), time.Microsecond)
+		case lt != nil && lt.IsSetTIME():
+			t := parquetTime(v, lt.TIME.Unit)
+			return tree.MakeDTime(timeofday.FromTime(t)), nil
+		case el.IsSetConvertedType() && ct == parquet.ConvertedType_TIME_MICROS:
+			return tree.MakeDTime(timeofday.TimeOfDay(v)), nil
+		case parquetIsUnsigned(el):
+			if uint64(v) > math.MaxInt64 {
+				return nil, errors.Newf("unsigned value %d is out of range for INT8", uint64(v))
+			}
+			return tree.NewDInt(tree.DInt(v)), nil
+		default:
+			return tree.NewDInt(tree.DInt(v)), nil
+		}
+
+	case [12]byte:
+		// INT96 is the legacy encoding of nanosecond timestamps.
+		return tree.MakeDTimestampTZ(goparquet.Int96ToTime(v).UTC(), time.Microsecond)
+
+	case float32:
+		return tree.NewDFloat(tree.DFloat(v)), nil
+
+	case float64:
+		return tree.NewDFloat(tree.DFloat(v)), nil
+
+	case []byte:
+		switch {
+		case isDecimal:
+			return parquetDecimalFromBytes(v, parquetDecimalScale(el)), nil
+		case lt != nil && lt.IsSetUUID():
+			return tree.ParseDUuidFromBytes(v)
+		case (lt != nil && lt.IsSetJSON()) || (el.IsSetConvertedType() && ct == parquet.ConvertedType_JSON):
+			return tree.ParseDJSON(string(v))
+		case (lt != nil && (lt.IsSetSTRING() || lt.IsSetENUM())) ||
+			(el.IsSetConvertedType() && (ct == parquet.ConvertedType_UTF8 || ct == parquet.ConvertedType_ENUM)):
+			return tree.NewDString(string(v)), nil
+		}
+		// Binary values without a logical type are interpreted according to the
+		// column they are imported into.
+		switch targetT.Family() {
+		case types.BytesFamily:
+			return tree.NewDBytes(tree.DBytes(v)), nil
+		case types.UuidFamily:
+			if len(v) == 16 {
+				return tree.ParseDUuidFromBytes(v)
+			}
+		case types.GeometryFamily:
+			g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(v))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometry(g), nil
+		case types.GeographyFamily:
+			g, err := geo.ParseGeographyFromEWKB(geopb.EWKB(v))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeography(g), nil
+		}
+		return tree.NewDString(string(v)), nil
+	}
+
+	return nil, errors.Newf("cannot handle parquet value of type %T for column %q", x, el.Name)
+}
+
+// parquetListElements returns the elements of x, along with the definition of
+// those elements, if def describes a list. Both repeated fields and groups
+// annotated with the LIST logical type are lists.
+func parquetListElements(
+	x interface{}, def *parquetschema.ColumnDefinition,
+) (_ []interface{}, elemDef *parquetschema.ColumnDefinition, isList bool, _ error) {
+	el := def.SchemaElement
+	if el.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		// The parquet library returns repeated primitive fields as typed slices
+		// (e.g. []int32) and repeated groups as []map[string]interface{}.
+		elems, err := parquetSliceElements(x, el.Name)
+		return elems, parquetElementDef(def), true, err
+	}
+
+	lt := el.GetLogicalType()
+	if !(lt != nil && lt.IsSetLIST()) && !(el.IsSetConvertedType() && el.GetConvertedType() == parquet.ConvertedType_LIST) {
+		return nil, nil, false, nil
+	}
+	if len(def.Children) != 1 ||
+		def.Children[0].SchemaElement.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED {
+		return nil, nil, false, errors.Newf("unexpected layout of parquet list %q", el.Name)
+	}
+	group, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, nil, false, errors.Newf("unexpected value %T for parquet list %q", x, el.Name)
+	}
+	repeated := def.Children[0]
+	if len(repeated.Children) != 1 {
+		// Two-level lists repeat their elements directly.
+		elems, err := parquetSliceElements(group[repeated.SchemaElement.Name], el.Name)
+		return elems, parquetElementDef(repeated), true, err
+	}
+
+	// Three-level lists wrap every element in a repeated group with a single
+	// field that holds the element.
+	elemDef = repeated.Children[0]
+	wrapped, _ := group[repeated.SchemaElement.Name].([]map[string]interface{})
+	if len(wrapped) == 1 && len(wrapped[0]) == 0 {
+		// The parquet library reads an empty list back as a single element
+		// without a value. See the note in the EXPORT PARQUET array decoder.
+		return nil, elemDef, true, nil
+	}
+	elems := make([]interface{}, len(wrapped))
+	for i, w := range wrapped {
+		elems[i] = w[elemDef.SchemaElement.Name]
+	}
+	return elems, elemDef, true, nil
+}
+
+// parquetSliceElements returns the elements of the slice x.
+func parquetSliceElements(x interface{}, name string) ([]interface{}, error) {
+	if x == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Slice {
+		return nil, errors.Newf("unexpected value %T for repeated parquet field %q", x, name)
+	}
+	elems := make([]interface{}, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+	return elems, nil
+}
+
+// parquetElementDef returns the definition of a single element of the
+// repeated field described by def.
+func parquetElementDef(def *parquetschema.ColumnDefinition) *parquetschema.ColumnDefinition {
+	el := *def.SchemaElement
+	el.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	return &parquetschema.ColumnDefinition{Children: def.Children, SchemaElement: &el}
+}
+
+// isParquetMap returns true if def describes a group annotated with the MAP
+// logical type.
+func isParquetMap(def *parquetschema.ColumnDefinition) bool {
+	el := def.SchemaElement
+	lt := el.GetLogicalType()
+	isMap := (lt != nil && lt.IsSetMAP()) ||
+		(el.IsSetConvertedType() && (el.GetConvertedType() == parquet.ConvertedType_MAP ||
+			el.GetConvertedType() == parquet.ConvertedType_MAP_KEY_VALUE))
+	return isMap && len(def.Children) == 1
+}
+
+func parquetIsUnsigned(el *parquet.SchemaElement) bool {
+	if lt := el.GetLogicalType(); lt != nil && lt.IsSetINTEGER() {
+		return !lt.INTEGER.IsSigned
+	}
+	if !el.IsSetConvertedType() {
+		return false
+	}
+	switch el.GetConvertedType() {
+	case parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16,
+		parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64:
+		return true
+	}
+	return false
+}
+
+func parquetDecimalScale(el *parquet.SchemaElement) int32 {
+	if lt := el.GetLogicalType(); lt != nil && lt.IsSetDECIMAL() {
+		return lt.DECIMAL.Scale
+	}
+	return el.GetScale()
+}
+
+// parquetDecimal returns the decimal unscaled * 10^-scale.
+func parquetDecimal(unscaled *big.Int, scale int32) *tree.DDecimal {
+	d := &tree.DDecimal{}
+	d.Coeff.SetMathBigInt(new(big.Int).Abs(unscaled))
+	d.Negative = unscaled.Sign() < 0
+	d.Exponent = -scale
+	return d
+}
+
+// parquetDecimalFromBytes decodes a decimal stored as a big-endian two's
+// complement unscaled value.
+func parquetDecimalFromBytes(b []byte, scale int32) *tree.DDecimal {
+	unscaled := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return parquetDecimal(unscaled, scale)
+}
+
+// parquetTime interprets v as a number of units since the unix epoch.
+func parquetTime(v int64, unit *parquet.TimeUnit) time.Time {
+	switch {
+	case unit != nil && unit.IsSetMILLIS():
+		return time.UnixMilli(v).UTC()
+	case unit != nil && unit.IsSetNANOS():
+		return time.Unix(0, v).UTC()
+	default:
+		return time.UnixMicro(v).UTC()
+	}
+}
+
+// parquetConsumer implements importRowConsumer interface.
+type parquetConsumer struct {
+	columns []parquetColumn
+	strict  bool
+}
+
+var _ importRowConsumer = &parquetConsumer{}
+
+// FillDatums implements importRowConsumer interface.
+func (p *parquetConsumer) FillDatums(
+	ctx context.Context, native interface{}, rowIndex int64, conv *row.DatumRowConverter,
+) error {
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return errors.Newf("unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+
+	for _, col := range p.columns {
+		datum, err := parquetValueToDatum(
+			ctx, conv.EvalCtx, record[col.name], col.def, conv.VisibleColTypes[col.idx],
+		)
+		if err != nil {
+			return errors.Wrapf(err, "row %d: column %q", rowIndex, col.name)
+		}
+		conv.Datums[col.idx] = datum
+	}
+
+	// Set any nil datums to DNull (in case the file did not have a column for
+	// them at all).
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			if p.strict {
+				return errors.Newf("column %s was not set in the parquet import", conv.VisibleCols[i].GetName())
+			}
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// parquetRowGroup is a decoded row group, or the number of rows of a row group
+// that did not need to be decoded because all of its rows are skipped.
+type parquetRowGroup struct {
+	numRows int64
+	rows    []map[string]interface{}
+	err     error
+}
+
+// parquetRowStream implements importRowProducer interface. Row groups are
+// decoded concurrently by a set of readers, and the stream hands out their rows
+// in file order. Row group i is decoded by reader i % len(readers), which sends
+// it on readers[i % len(readers)].
+type parquetRowStream struct {
+	ctx          context.Context
+	readers      []chan parquetRowGroup
+	numRowGroups int
+	numRows      int64
+
+	nextRowGroup int
+	remaining    int64 // Rows left in the current row group.
+	rows         []map[string]interface{}
+	consumed     int64
+	err          error
+}
+
+var _ importRowProducer = &parquetRowStream{}
+
+// Progress implements importRowProducer interface.
+func (s *parquetRowStream) Progress() float32 {
+	if s.numRows == 0 {
+		return 0
+	}
+	return float32(s.consumed) / float32(s.numRows)
+}
+
+// Scan implements importRowProducer interface.
+func (s *parquetRowStream) Scan() bool {
+	for s.remaining == 0 {
+		if s.err != nil || s.nextRowGroup == s.numRowGroups {
+			return false
+		}
+		select {
+		case <-s.ctx.Done():
+			s.err = s.ctx.Err()
+			return false
+		case rg := <-s.readers[s.nextRowGroup%len(s.readers)]:
+			if rg.err != nil {
+				s.err = rg.err
+				return false
+			}
+			s.nextRowGroup++
+			s.remaining, s.rows = rg.numRows, rg.rows
+		}
+	}
+	s.remaining--
+	s.consumed++
+	return true
+}
+
+// Err implements importRowProducer interface.
+func (s *parquetRowStream) Err() error {
+	return s.err
+}
+
+// Skip implements importRowProducer interface.
+func (s *parquetRowStream) Skip() error {
+	if len(s.rows) > 0 {
+		s.rows = s.rows[1:]
+	}
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (s *parquetRowStream) Row() (interface{}, error) {
+	if len(s.rows) == 0 {
+		return nil, errors.AssertionFailedf("row of a skipped parquet row group requested")
+	}
+	res := s.rows[0]
+	s.rows = s.rows[1:]
+	return res, nil
+}
+
+// parquetFile adapts a file in external storage to the io.ReadSeeker required
+// by the parquet library. The library reads the footer at the end of the file
+// first and then the column chunks of each row group, so the file is reopened
+// at the new offset whenever a read does not continue the previous one.
+type parquetFile struct {
+	ctx  context.Context
+	es   cloud.ExternalStorage
+	size int64
+	pos  int64
+
+	body    ioctx.ReadCloserCtx
+	bodyPos int64
+}
+
+// Read implements io.Reader.
+func (f *parquetFile) Read(p []byte) (int, error) {
+	if f.pos >= f.size {
+		return 0, io.EOF
+	}
+	if f.body == nil || f.bodyPos != f.pos {
+		if err := f.Close(); err != nil {
+			return 0, err
+		}
+		body, _, err := f.es.ReadFileAt(f.ctx, "", f.pos)
+		if err != nil {
+			return 0, err
+		}
+		f.body, f.bodyPos = body, f.pos
+	}
+	n, err := f.body.Read(f.ctx, p)
+	f.pos += int64(n)
+	f.bodyPos += int64(n)
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (f *parquetFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, errors.Newf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Newf("invalid offset %d", offset)
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+// Close implements io.Closer.
+func (f *parquetFile) Close() error {
+	if f.body == nil {
+		return nil
+	}
+	err := f.body.Close(f.ctx)
+	f.body = nil
+	return err
+}
+
+type parquetInputReader struct {
+	importContext *parallelImportContext
+	opts          roachpb.ParquetOptions
+}
+
+var _ inputConverter = &parquetInputReader{}
+
+func newParquetInputReader(
+	semaCtx *tree.SemaContext,
+	kvCh chan row.KVBatch,
+	tableDesc catalog.TableDescriptor,
+	targetCols tree.NameList,
+	parquetOpts roachpb.ParquetOptions,
+	walltime int64,
+	parallelism int,
+	evalCtx *eval.Context,
+	db *kv.DB,
+) (*parquetInputReader, error) {
+	return &parquetInputReader{
+		importContext: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			targetCols: targetCols,
+			kvCh:       kvCh,
+			db:         db,
+		},
+		opts: parquetOpts,
+	}, nil
+}
+
+func (p *parquetInputReader) start(group ctxgroup.Group) {}
+
+// readFiles implements inputConverter interface. Unlike the other formats,
+// parquet files are not read as a stream: they are accessed at the offsets of
+// their row groups, and they are compressed internally.
+func (p *parquetInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user username.SQLUsername,
+) error {
+	for dataFileIndex, dataFile := range dataFiles {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := func() error {
+			conf, err := cloud.ExternalStorageConfFromURI(dataFile, user)
+			if err != nil {
+				return err
+			}
+			es, err := makeExternalStorage(ctx, conf)
+			if err != nil {
+				return err
+			}
+			defer es.Close()
+			return p.readFile(ctx, es, dataFileIndex, resumePos[dataFileIndex])
+		}(); err != nil {
+			return errors.Wrapf(err, "%s", dataFile)
+		}
+	}
+	return nil
+}
+
+// readFile imports the row groups of a parquet file read by a single input of
+// the import. Large files are split into several inputs when the import job
+// starts, see splitParquetFiles. The row groups of an input are decoded
+// concurrently, and resume positions count the rows of the input.
+func (p *parquetInputReader) readFile(
+	ctx context.Context, es cloud.ExternalStorage, inputIdx int32, resumePos int64,
+) error {
+	size, err := es.Size(ctx, "")
+	if err != nil {
+		return err
+	}
+	meta, err := readParquetMetaData(ctx, es, size)
+	if err != nil {
+		return err
+	}
+	span, ok := p.opts.RowGroups[inputIdx]
+	if !ok {
+		span.End = int32(len(meta.RowGroups))
+	} else if int(span.End) > len(meta.RowGroups) {
+		return errors.Newf("file has %d row groups, but row groups [%d, %d) were planned to be read",
+			len(meta.RowGroups), span.Start, span.End)
+	}
+	var numRows int64
+	for _, rg := range meta.RowGroups[span.Start:span.End] {
+		numRows += rg.NumRows
+	}
+
+	f := &parquetFile{ctx: ctx, es: es, size: size}
+	defer f.Close()
+	r, err := goparquet.NewFileReaderWithOptions(f, goparquet.WithFileMetaData(meta))
+	if err != nil {
+		return err
+	}
+
+	columns, err := p.projectColumns(r.GetSchemaDefinition())
+	if err != nil {
+		return err
+	}
+	projection := make([]string, len(columns))
+	for i := range columns {
+		projection[i] = columns[i].name
+	}
+
+	consumer := &parquetConsumer{
+		columns: columns,
+		strict:  p.opts.StrictMode,
+	}
+
+	numReaders := p.importContext.numWorkers
+	if numReaders > maxParquetRowGroupReaders {
+		numReaders = maxParquetRowGroupReaders
+	}
+	if numReaders > int(span.End-span.Start) {
+		numReaders = int(span.End - span.Start)
+	}
+	if numReaders < 1 {
+		numReaders = 1
+	}
+
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	readers := ctxgroup.WithContext(readCtx)
+	producer := &parquetRowStream{
+		ctx:          readCtx,
+		readers:      make([]chan parquetRowGroup, numReaders),
+		numRowGroups: int(span.End - span.Start),
+		numRows:      numRows,
+	}
+	for i := range producer.readers {
+		i := i
+		producer.readers[i] = make(chan parquetRowGroup)
+		readers.GoCtx(func(ctx context.Context) error {
+			readParquetRowGroups(ctx, es, size, meta, span, projection, i, numReaders, resumePos, producer.readers[i])
+			return nil
+		})
+	}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rowLimit: p.opts.RowLimit,
+	}
+	err = runParallelImport(ctx, p.importContext, fileCtx, producer, consumer)
+	cancel()
+	_ = readers.Wait()
+	return err
+}
+
+// readParquetRowGroups decodes every stride'th row group of the span of row
+// groups of the file, starting with the row group first of the span, and sends
+// them to out in order. Row groups that only contain rows before skip, counted
+// from the start of the span, are not decoded.
+func readParquetRowGroups(
+	ctx context.Context,
+	es cloud.ExternalStorage,
+	size int64,
+	meta *parquet.FileMetaData,
+	span roachpb.ParquetRowGroupSpan,
+	projection []string,
+	first, stride int,
+	skip int64,
+	out chan<- parquetRowGroup,
+) {
+	send := func(rg parquetRowGroup) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case out <- rg:
+			return rg.err == nil
+		}
+	}
+
+	f := &parquetFile{ctx: ctx, es: es, size: size}
+	defer f.Close()
+	r, err := goparquet.NewFileReaderWithOptions(f,
+		goparquet.WithFileMetaData(meta),
+		goparquet.WithColumns(projection...),
+		goparquet.WithReaderContext(ctx),
+	)
+	if err != nil {
+		send(parquetRowGroup{err: err})
+		return
+	}
+
+	var startRow int64
+	for i := int(span.Start); i < int(span.End); i++ {
+		numRows := meta.RowGroups[i].NumRows
+		if (i-int(span.Start))%stride == first {
+			rg := parquetRowGroup{numRows: numRows}
+			if startRow+numRows > skip {
+				rg.rows, rg.err = readParquetRowGroup(ctx, r, i, numRows)
+			}
+			if !send(rg) {
+				return
+			}
+		}
+		startRow += numRows
+	}
+}
+
+// readParquetRowGroup decodes all rows of the idx'th row group of r.
+func readParquetRowGroup(
+	ctx context.Context, r *goparquet.FileReader, idx int, numRows int64,
+) ([]map[string]interface{}, error) {
+	// SeekToRowGroup loads the row group preceding the passed position.
+	if err := r.SeekToRowGroupWithContext(ctx, idx+1); err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]interface{}, numRows)
+	for i := range rows {
+		row, err := r.NextRowWithContext(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading row %d of row group %d", i, idx)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// readParquetMetaData reads the metadata in the footer of a parquet file of the
+// specified size.
+func readParquetMetaData(
+	ctx context.Context, es cloud.ExternalStorage, size int64,
+) (*parquet.FileMetaData, error) {
+	f := &parquetFile{ctx: ctx, es: es, size: size}
+	defer f.Close()
+	meta, err := goparquet.ReadFileMetaDataWithContext(ctx, f, true /* extraValidation */)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading parquet file metadata")
+	}
+	return meta, nil
+}
+
+// splitParquetFiles splits the parquet files of an import into inputs which
+// each read a span of consecutive row groups of a file, so that the row groups
+// of large files are read by different import processors. The row groups of an
+// input add up to at most targetSize bytes, unless the input has a single row
+// group. It returns the URIs of the inputs, along with the row groups read by
+// each input. Files are not split if the import has a row limit, since the
+// limit applies to each file.
+func splitParquetFiles(
+	ctx context.Context,
+	makeExternalStorageFromURI cloud.ExternalStorageFromURIFactory,
+	user username.SQLUsername,
+	files []string,
+	opts roachpb.ParquetOptions,
+	targetSize int64,
+) ([]string, map[int32]roachpb.ParquetRowGroupSpan, error) {
+	inputs := make([]string, 0, len(files))
+	rowGroups := make(map[int32]roachpb.ParquetRowGroupSpan, len(files))
+	for _, file := range files {
+		meta, err := func() (*parquet.FileMetaData, error) {
+			es, err := makeExternalStorageFromURI(ctx, file, user)
+			if err != nil {
+				return nil, err
+			}
+			defer es.Close()
+			size, err := es.Size(ctx, "")
+			if err != nil {
+				return nil, err
+			}
+			return readParquetMetaData(ctx, es, size)
+		}()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", file)
+		}
+
+		var span roachpb.ParquetRowGroupSpan
+		var spanSize int64
+		for i, rg := range meta.RowGroups {
+			if opts.RowLimit == 0 && span.End > span.Start && spanSize+rg.TotalByteSize > targetSize {
+				rowGroups[int32(len(inputs))] = span
+				inputs = append(inputs, file)
+				span, spanSize = roachpb.ParquetRowGroupSpan{Start: int32(i)}, 0
+			}
+			span.End = int32(i + 1)
+			spanSize += rg.TotalByteSize
+		}
+		rowGroups[int32(len(inputs))] = span
+		inputs = append(inputs, file)
+	}
+	return inputs, rowGroups, nil
+}
+
+// projectColumns returns the top-level columns of the parquet file that are
+// imported, which are the ones whose name matches a target column of the
+// table. Only these columns are read from the file.
+func (p *parquetInputReader) projectColumns(
+	schema *parquetschema.SchemaDefinition,
+) ([]parquetColumn, error) {
+	fieldIdxByName := make(map[string]int)
+	for idx, col := range p.importContext.tableDesc.VisibleColumns() {
+		fieldIdxByName[col.GetName()] = idx
+	}
+	targets := make(map[string]struct{}, len(p.importContext.targetCols))
+	for _, name := range p.importContext.targetCols {
+		targets[string(name)] = struct{}{}
+	}
+
+	var columns []parquetColumn
+	for _, def := range schema.RootColumn.Children {
+		name := def.SchemaElement.Name
+		field := lexbase.NormalizeName(name)
+		idx, ok := fieldIdxByName[field]
+		if !ok {
+			if p.opts.StrictMode {
+				return nil, errors.Newf("could not find column for parquet column %s", field)
+			}
+			continue
+		}
+		if _, ok := targets[field]; !ok && len(targets) > 0 {
+			continue
+		}
+		columns = append(columns, parquetColumn{name: name, idx: idx, def: def})
+	}
+	return columns, nil
+}
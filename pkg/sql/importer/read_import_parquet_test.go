@@ -0,0 +1,233 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/stretchr/testify/require"
+)
+
+const parquetTestSchema = `message test {
+	required int64 id;
+	optional int64 amount (DECIMAL(10, 2));
+	optional int64 ts (TIMESTAMP(MICROS, true));
+	optional int32 day (DATE);
+	optional group tags (LIST) {
+		repeated group list {
+			required binary element (STRING);
+		}
+	}
+	optional group attrs {
+		required int32 a;
+		optional binary b (STRING);
+	}
+	optional binary ignored (STRING);
+}`
+
+// writeParquetTestFile writes a parquet file with the test schema to path.
+// Every two rows are written to their own row group.
+func writeParquetTestFile(t *testing.T, path string, numRows int) {
+	schema, err := parquetschema.ParseSchemaDefinition(parquetTestSchema)
+	require.NoError(t, err)
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	fw := goparquet.NewFileWriter(f, goparquet.WithSchemaDefinition(schema))
+	start := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 1; i <= numRows; i++ {
+		tags := []map[string]interface{}{}
+		if i%2 == 1 {
+			tags = append(tags,
+				map[string]interface{}{"element": []byte("a")},
+				map[string]interface{}{"element": []byte("b")},
+			)
+		}
+		row := map[string]interface{}{
+			"id":      int64(i),
+			"amount":  int64(i*100 + 23),
+			"ts":      start.Add(time.Duration(i) * time.Hour).UnixMicro(),
+			"day":     int32(18993 + i),
+			"tags":    map[string]interface{}{"list": tags},
+			"ignored": []byte("ignored"),
+		}
+		if i != numRows {
+			row["attrs"] = map[string]interface{}{"a": int32(i), "b": []byte("x")}
+		}
+		require.NoError(t, fw.AddData(row))
+		if i%2 == 0 {
+			require.NoError(t, fw.FlushRowGroup())
+		}
+	}
+	require.NoError(t, fw.Close())
+}
+
+func TestImportParquet(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	const numRows = 6
+	writeParquetTestFile(t, filepath.Join(dir, "simple.parquet"), numRows)
+	const simple = `'nodelocal://0/simple.parquet'`
+
+	const create = `CREATE TABLE %s (
+		id INT PRIMARY KEY, amount DECIMAL(10,2), ts TIMESTAMPTZ, day DATE, tags STRING[], attrs JSONB
+	)`
+	const query = `SELECT id, amount::STRING, extract(epoch FROM ts)::INT, day::STRING, tags::STRING,
+		attrs::STRING FROM %s ORDER BY id`
+	expected := [][]string{
+		{"1", "1.23", "1641096245", "2022-01-02", "{a,b}", `{"a": 1, "b": "x"}`},
+		{"2", "2.23", "1641099845", "2022-01-03", "{}", `{"a": 2, "b": "x"}`},
+		{"3", "3.23", "1641103445", "2022-01-04", "{a,b}", `{"a": 3, "b": "x"}`},
+		{"4", "4.23", "1641107045", "2022-01-05", "{}", `{"a": 4, "b": "x"}`},
+		{"5", "5.23", "1641110645", "2022-01-06", "{a,b}", `{"a": 5, "b": "x"}`},
+		{"6", "6.23", "1641114245", "2022-01-07", "{}", "NULL"},
+	}
+
+	t.Run("logical-types", func(t *testing.T) {
+		sqlDB.Exec(t, fmt.Sprintf(create, "simple"))
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO simple PARQUET DATA (%s)`, simple))
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(query, "simple"), expected)
+	})
+
+	t.Run("projection", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE projected (id INT PRIMARY KEY, tags STRING[], amount DECIMAL)`)
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO projected (id, tags) PARQUET DATA (%s)`, simple))
+		sqlDB.CheckQueryResults(t,
+			`SELECT count(*), count(tags), count(amount) FROM projected`, [][]string{{"6", "6", "0"}})
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		sqlDB.Exec(t, fmt.Sprintf(create, "validated"))
+		sqlDB.ExpectErr(t, "could not find column for parquet column ignored",
+			fmt.Sprintf(`IMPORT INTO validated PARQUET DATA (%s) WITH strict_validation`, simple))
+	})
+
+	t.Run("row-limit", func(t *testing.T) {
+		sqlDB.Exec(t, fmt.Sprintf(create, "limited"))
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO limited PARQUET DATA (%s) WITH row_limit = '3'`, simple))
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(query, "limited"), expected[:3])
+	})
+
+	t.Run("row-group-splits", func(t *testing.T) {
+		execCfg := srv.ExecutorConfig().(sql.ExecutorConfig)
+		uri := "nodelocal://0/simple.parquet"
+		split := func(opts roachpb.ParquetOptions) ([]string, map[int32]roachpb.ParquetRowGroupSpan) {
+			inputs, rowGroups, err := splitParquetFiles(ctx, execCfg.DistSQLSrv.ExternalStorageFromURI,
+				username.RootUserName(), []string{uri}, opts, 1 /* targetSize */)
+			require.NoError(t, err)
+			return inputs, rowGroups
+		}
+
+		inputs, rowGroups := split(roachpb.ParquetOptions{})
+		require.Equal(t, []string{uri, uri, uri}, inputs)
+		require.Equal(t, map[int32]roachpb.ParquetRowGroupSpan{
+			0: {Start: 0, End: 1}, 1: {Start: 1, End: 2}, 2: {Start: 2, End: 3},
+		}, rowGroups)
+
+		// The row limit applies to each file, which is then read as a whole.
+		inputs, rowGroups = split(roachpb.ParquetOptions{RowLimit: 3})
+		require.Equal(t, []string{uri}, inputs)
+		require.Equal(t, map[int32]roachpb.ParquetRowGroupSpan{0: {Start: 0, End: 3}}, rowGroups)
+
+		sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.import.parquet_split_size = '1B'`)
+		defer sqlDB.Exec(t, `RESET CLUSTER SETTING bulkio.import.parquet_split_size`)
+		sqlDB.Exec(t, fmt.Sprintf(create, "split"))
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO split PARQUET DATA (%s)`, simple))
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(query, "split"), expected)
+	})
+
+	t.Run("export-roundtrip", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO PARQUET 'nodelocal://0/roundtrip' FROM SELECT * FROM simple`)
+		files, err := filepath.Glob(filepath.Join(dir, "roundtrip", "*.parquet"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		sqlDB.Exec(t, fmt.Sprintf(create, "roundtrip"))
+		sqlDB.Exec(t, `IMPORT INTO roundtrip PARQUET DATA ($1)`,
+			"nodelocal://0/roundtrip/"+filepath.Base(files[0]))
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(query, "roundtrip"), expected)
+	})
+}
+
+func TestParquetDecimalFromBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		b        []byte
+		scale    int32
+		expected string
+	}{
+		{b: []byte{0x00, 0x7b}, scale: 2, expected: "1.23"},
+		{b: []byte{0xff, 0x85}, scale: 2, expected: "-1.23"},
+		{b: []byte{0x80}, scale: 0, expected: "-128"},
+	} {
+		require.Equal(t, tc.expected, parquetDecimalFromBytes(tc.b, tc.scale).String())
+	}
+}
+
+func TestParquetDecimalEncodings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	schema, err := parquetschema.ParseSchemaDefinition(`message test {
+		required binary binary_decimal (DECIMAL(5, 2));
+		required fixed_len_byte_array(2) fixed_decimal (DECIMAL(5, 2));
+		required binary text_decimal (STRING);
+	}`)
+	require.NoError(t, err)
+	values := map[string]interface{}{
+		"binary_decimal": []byte{0xff, 0x85},
+		"fixed_decimal":  []byte{0x00, 0x7b},
+		"text_decimal":   []byte("NaN"),
+	}
+	expected := map[string]string{
+		"binary_decimal": "-1.23",
+		"fixed_decimal":  "1.23",
+		"text_decimal":   "NaN",
+	}
+
+	// Decimals are decoded according to the type of the parquet column:
+	// DECIMAL columns hold unscaled integers, while strings, as written by
+	// EXPORT PARQUET, are parsed.
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, def := range schema.RootColumn.Children {
+		name := def.SchemaElement.Name
+		d, err := parquetValueToDatum(context.Background(), &evalCtx, values[name], def, types.Decimal)
+		require.NoError(t, err, name)
+		require.Equal(t, expected[name], d.String(), name)
+	}
+}
@@ -1283,6 +1283,7 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = descpb.ConstraintTypePK
 					}
+					deferrability := constraintDeferrability(c)
 					if err := addRow(
 						dbNameStr,                     // constraint_catalog
 						scNameStr,                     // constraint_schema
@@ -1291,8 +1292,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                     // table_schema
 						tbNameStr,                     // table_name
 						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						yesOrNoDatum(deferrability != descpb.ConstraintDeferrability_NotDeferrable),     // is_deferrable
+						yesOrNoDatum(deferrability == descpb.ConstraintDeferrability_InitiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1330,6 +1331,20 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 	},
 }
 
+// constraintDeferrability returns the deferrability of the given constraint.
+// Only foreign key, check and unique without index constraints can be
+// deferrable.
+func constraintDeferrability(c catalog.Constraint) descpb.ConstraintDeferrability {
+	if ck := c.AsCheck(); ck != nil {
+		return ck.CheckDesc().Deferrability
+	} else if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrability
+	} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		return uwoi.UniqueWithoutIndexDesc().Deferrability
+	}
+	return descpb.ConstraintDeferrability_NotDeferrable
+}
+
 // Postgres: not provided
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/user-privileges-table.html
 // TODO(knz): this introspection facility is of dubious utility.
@@ -353,6 +353,7 @@ func (ie *InternalExecutor) newConnExecutorWithTxn(
 		txn.ReadTimestamp().GoTime(),
 		nil, /* historicalTimestamp */
 		roachpb.UnspecifiedUserPriority,
+		txn.IsoLevel(),
 		tree.ReadWrite,
 		txn,
 		ex.transitionCtx,
@@ -135,9 +135,58 @@ func TestLogic_drop_view(
 	runLogicTest(t, "drop_view")
 }
 
+func TestLogic_exclusion_constraints_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints_mixed")
+}
+
 func TestLogic_gc_job_mixed(
 	t *testing.T,
 ) {
 	defer leaktest.AfterTest(t)()
 	runLogicTest(t, "gc_job_mixed")
 }
+
+func TestLogic_jsonpath_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath_mixed")
+}
+
+func TestLogic_procedure_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "procedure_mixed")
+}
+
+func TestLogic_range_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_mixed")
+}
+
+func TestLogic_trigger_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "trigger_mixed")
+}
+
+func TestLogic_tsvector_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "tsvector_mixed")
+}
+
+func TestLogic_udf_aggregate_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate_mixed")
+}
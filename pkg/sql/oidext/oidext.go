@@ -36,6 +36,31 @@ const (
 	T__box2d     = oid.Oid(90005)
 )
 
+// OIDs in this block are the postgres OIDs of the multirange types, which
+// were introduced in postgres 14 and are not yet part of lib/pq.
+const (
+	T_int4multirange  = oid.Oid(4451)
+	T__int4multirange = oid.Oid(6150)
+	T_nummultirange   = oid.Oid(4532)
+	T__nummultirange  = oid.Oid(6151)
+	T_tsmultirange    = oid.Oid(4533)
+	T__tsmultirange   = oid.Oid(6152)
+	T_tstzmultirange  = oid.Oid(4534)
+	T__tstzmultirange = oid.Oid(6153)
+	T_datemultirange  = oid.Oid(4535)
+	T__datemultirange = oid.Oid(6155)
+	T_int8multirange  = oid.Oid(4536)
+	T__int8multirange = oid.Oid(6157)
+	T_anymultirange   = oid.Oid(4537)
+)
+
+// OIDs in this block are the postgres OIDs of the jsonpath type, which is not
+// part of lib/pq.
+const (
+	T_jsonpath  = oid.Oid(4072)
+	T__jsonpath = oid.Oid(4073)
+)
+
 // ExtensionTypeName returns a mapping from extension oids
 // to their type name.
 var ExtensionTypeName = map[oid.Oid]string{
@@ -45,6 +70,23 @@ var ExtensionTypeName = map[oid.Oid]string{
 	T__geography: "_GEOGRAPHY",
 	T_box2d:      "BOX2D",
 	T__box2d:     "_BOX2D",
+
+	T_int4multirange:  "INT4MULTIRANGE",
+	T__int4multirange: "_INT4MULTIRANGE",
+	T_nummultirange:   "NUMMULTIRANGE",
+	T__nummultirange:  "_NUMMULTIRANGE",
+	T_tsmultirange:    "TSMULTIRANGE",
+	T__tsmultirange:   "_TSMULTIRANGE",
+	T_tstzmultirange:  "TSTZMULTIRANGE",
+	T__tstzmultirange: "_TSTZMULTIRANGE",
+	T_datemultirange:  "DATEMULTIRANGE",
+	T__datemultirange: "_DATEMULTIRANGE",
+	T_int8multirange:  "INT8MULTIRANGE",
+	T__int8multirange: "_INT8MULTIRANGE",
+	T_anymultirange:   "ANYMULTIRANGE",
+
+	T_jsonpath:  "JSONPATH",
+	T__jsonpath: "_JSONPATH",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
@@ -15,7 +15,9 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
 )
 
 // Table is an interface to a database table, exposing only the information
@@ -139,6 +141,22 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// TriggerCount returns the number of row-level triggers defined on this
+	// table.
+	TriggerCount() int
+
+	// Trigger returns the ith row-level trigger defined on this table, where
+	// i < TriggerCount.
+	Trigger(i int) Trigger
+
+	// ExclusionConstraintCount returns the number of exclusion constraints
+	// defined on this table.
+	ExclusionConstraintCount() int
+
+	// ExclusionConstraint returns the ith exclusion constraint defined on this
+	// table, where i < ExclusionConstraintCount.
+	ExclusionConstraint(i int) ExclusionConstraint
+
 	// Zone returns a table's zone.
 	Zone() Zone
 
@@ -185,8 +203,56 @@ type Table interface {
 //
 //	CREATE TABLE a (a INT CHECK (a > 0))
 type CheckConstraint struct {
+	Name       string
 	Constraint string
 	Validated  bool
+
+	// Deferrability indicates whether the check of the constraint can be
+	// deferred until the end of the transaction.
+	Deferrability tree.ConstraintDeferrability
+}
+
+// Trigger describes a row-level trigger on a table. The trigger executes the
+// function with the given OID for each row modified by one of the trigger's
+// events, either before or after the row is modified.
+type Trigger struct {
+	Name       tree.Name
+	ActionTime tree.TriggerActionTime
+	Events     tree.TriggerEvents
+	FuncOID    oid.Oid
+}
+
+// ExclusionConstraint describes an exclusion constraint on a table. The
+// constraint guarantees that no two rows of the table satisfy all of the
+// comparisons between their values of the constraint columns. For example, this
+// constraint ensures that no two rows with the same room have overlapping
+// days:
+//
+//	CREATE TABLE a (room INT, days INT[], EXCLUDE (room WITH =, days WITH &&))
+//
+// The optimizer adds a check as a postquery to any query that inserts into or
+// updates the columns of the constraint, like it does for unique constraints.
+type ExclusionConstraint struct {
+	Name string
+
+	// ColumnOrdinals are the table column ordinals of the constraint columns.
+	ColumnOrdinals []int
+
+	// Operators are the comparison operators of the constraint, one for each
+	// column.
+	Operators []treecmp.ComparisonOperator
+
+	// Predicate is the partial predicate expression of the constraint, or the
+	// empty string if the constraint applies to all rows.
+	Predicate string
+
+	// Validated is true if the existing data is known to satisfy the
+	// constraint.
+	Validated bool
+
+	// Deferrability indicates whether the check of the constraint can be
+	// deferred until the end of the transaction.
+	Deferrability tree.ConstraintDeferrability
 }
 
 // TableStatistic is an interface to a table statistic. Each statistic is
@@ -301,6 +367,10 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability indicates whether the check of the foreign key can be
+	// deferred until the end of the transaction.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -348,6 +418,11 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrability indicates whether the uniqueness check can be deferred
+	// until the end of the transaction. Only constraints without an index can
+	// be deferrable.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
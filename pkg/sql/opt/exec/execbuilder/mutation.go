@@ -123,6 +123,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -159,6 +163,12 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	// We cannot use the fast path if there are any cascades, such as AFTER
+	// triggers, since they require the input to be buffered.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
@@ -578,6 +588,15 @@ func (b *Builder) tryBuildDeleteRange(del *memo.DeleteExpr) (_ execPlan, ok bool
 		return execPlan{}, false, nil
 	}
 
+	// Deferred foreign key checks need the values of the deleted rows, so the
+	// fast path cannot be used if the checks of inbound foreign keys can be
+	// deferred.
+	for i, n := 0, tab.InboundForeignKeyCount(); i < n; i++ {
+		if tab.InboundForeignKey(i).Deferrability() != tree.ConstraintNotDeferrable {
+			return execPlan{}, false, nil
+		}
+	}
+
 	ep, err := b.buildDeleteRange(del)
 	if err != nil {
 		return execPlan{}, false, err
@@ -714,6 +733,9 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			for i, col := range c.KeyCols {
 				keyVals[i] = row[query.getNodeColumnOrdinal(col)]
 			}
+			if c.Exclusion {
+				return mkExclusionCheckErr(md, c, keyVals)
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -793,6 +815,45 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing an exclusion
+// constraint violation. The keyVals are the values that correspond to the
+// cat.ExclusionConstraint columns.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	ec := tabMeta.Table.ExclusionConstraint(c.CheckOrdinal)
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k, r)=(2, {1,2}) conflicts with existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, ec.Name)
+
+	details.WriteString("Key (")
+	for i, ord := range ec.ColumnOrdinals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(string(tabMeta.Table.Column(ord).ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+	details.WriteString(") conflicts with existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			ec.Name,
+		),
+		details.String(),
+	)
+}
+
 // mkFKCheckErr generates a user-friendly error describing a foreign key
 // violation. The keyVals are the values that correspond to the
 // cat.ForeignKeyConstraint columns.
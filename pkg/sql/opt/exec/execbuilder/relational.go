@@ -248,6 +248,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.OrdinalityExpr:
 		ep, err = b.buildOrdinality(t)
 
+	case *memo.ExpandExpr:
+		ep, err = b.buildExpand(t)
+
 	case *memo.MergeJoinExpr:
 		ep, err = b.buildMergeJoin(t)
 
@@ -287,6 +290,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateFunctionExpr:
 		ep, err = b.buildCreateFunction(t)
 
+	case *memo.CallExpr:
+		ep, err = b.buildCall(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -1486,7 +1492,17 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
-		name, _ := memo.FindAggregateOverload(agg)
+		var name string
+		var userDefined *tree.UserDefinedAggregate
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			name = uda.Name
+			userDefined, err = b.buildUserDefinedAgg(uda)
+			if err != nil {
+				return execPlan{}, err
+			}
+		} else {
+			name, _ = memo.FindAggregateOverload(agg)
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1508,12 +1524,13 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 		}
 
 		aggInfos[i] = exec.AggInfo{
-			FuncName:   name,
-			Distinct:   distinct,
-			ResultType: item.Agg.DataType(),
-			ArgCols:    argCols,
-			ConstArgs:  constArgs,
-			Filter:     filterOrd,
+			FuncName:    name,
+			Distinct:    distinct,
+			ResultType:  item.Agg.DataType(),
+			ArgCols:     argCols,
+			ConstArgs:   constArgs,
+			Filter:      filterOrd,
+			UserDefined: userDefined,
 		}
 		ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
 	}
@@ -1537,6 +1554,32 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+// buildUserDefinedAgg builds the routines that implement an aggregate created
+// with CREATE AGGREGATE.
+func (b *Builder) buildUserDefinedAgg(
+	uda *memo.UserDefinedAggExpr,
+) (*tree.UserDefinedAggregate, error) {
+	// The support functions are built without any input; their arguments are
+	// provided by the aggregate during execution.
+	transition, err := b.buildUDF(&buildScalarCtx{}, uda.Transition)
+	if err != nil {
+		return nil, err
+	}
+	res := &tree.UserDefinedAggregate{
+		Name:       uda.Name,
+		Transition: transition.(*tree.RoutineExpr),
+		InitCond:   uda.InitCond,
+	}
+	if uda.Final.Op() != opt.NullOp {
+		final, err := b.buildUDF(&buildScalarCtx{}, uda.Final)
+		if err != nil {
+			return nil, err
+		}
+		res.Final = final.(*tree.RoutineExpr)
+	}
+	return res, nil
+}
+
 func (b *Builder) buildDistinct(distinct memo.RelExpr) (execPlan, error) {
 	private := distinct.Private().(*memo.GroupingPrivate)
 
@@ -1976,6 +2019,42 @@ func (b *Builder) buildOrdinality(ord *memo.OrdinalityExpr) (execPlan, error) {
 	return execPlan{root: node, outputCols: outputCols}, nil
 }
 
+func (b *Builder) buildExpand(expand *memo.ExpandExpr) (execPlan, error) {
+	input, err := b.buildRelational(expand.Input)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	groupCols := make([]exec.NodeColumnOrdinal, len(expand.InputCols))
+	for i, col := range expand.InputCols {
+		groupCols[i] = input.getNodeColumnOrdinal(col)
+	}
+	groupingSets := make([]exec.NodeColumnOrdinalSet, len(expand.GroupingSets))
+	for i, set := range expand.GroupingSets {
+		for j, col := range expand.OutputCols {
+			if set.Contains(col) {
+				groupingSets[i].Add(int(groupCols[j]))
+			}
+		}
+	}
+	colName := b.mem.Metadata().ColumnMeta(expand.GroupingSetCol).Alias
+
+	node, err := b.factory.ConstructExpand(input.root, groupCols, groupingSets, colName)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	// The copies of the grouping columns and the grouping set column are
+	// ordered after the input columns.
+	outputCols := input.outputCols.Copy()
+	for _, col := range expand.OutputCols {
+		outputCols.Set(int(col), outputCols.Len())
+	}
+	outputCols.Set(int(expand.GroupingSetCol), outputCols.Len())
+
+	return execPlan{root: node, outputCols: outputCols}, nil
+}
+
 func (b *Builder) buildIndexJoin(join *memo.IndexJoinExpr) (execPlan, error) {
 	input, err := b.buildRelational(join.Input)
 	if err != nil {
@@ -3000,15 +3079,38 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefinedAggs []*tree.UserDefinedAggregate
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var fnRef tree.ResolvableFunctionReference
+		var props *tree.FunctionProperties
+		var overload *tree.Overload
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			if userDefinedAggs == nil {
+				userDefinedAggs = make([]*tree.UserDefinedAggregate, len(w.Windows))
+			}
+			userDefinedAggs[i], err = b.buildUserDefinedAgg(uda)
+			if err != nil {
+				return execPlan{}, err
+			}
+			fnRef = tree.ResolvableFunctionReference{FunctionReference: tree.NewUnresolvedName(uda.Name)}
+			props = &tree.FunctionProperties{}
+			overload = &tree.Overload{
+				Class:      tree.AggregateClass,
+				ReturnType: tree.FixedReturnType(uda.Typ),
+				IsUDF:      true,
+			}
+		} else {
+			var name string
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			fnRef = b.wrapFunction(name)
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
 		args := make([]tree.TypedExpr, fn.ChildCount())
 		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
@@ -3047,7 +3149,7 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 			Frame:      frame,
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
-			b.wrapFunction(name),
+			fnRef,
 			0,
 			args,
 			builtFilter,
@@ -3086,13 +3188,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	}
 
 	node, err := b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   input.sqlOrdering(ord),
+		Cols:            resultCols,
+		Exprs:           exprs,
+		OutputIdxs:      outputIdxs,
+		ArgIdxs:         argIdxs,
+		UserDefinedAggs: userDefinedAggs,
+		FilterIdxs:      filterIdxs,
+		Partition:       partitionIdxs,
+		Ordering:        input.sqlOrdering(ord),
 	})
 	if err != nil {
 		return execPlan{}, err
@@ -747,5 +747,6 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Typ,
 		udf.Volatility,
 		udf.CalledOnNullInput,
+		udf.Program,
 	), nil
 }
@@ -86,6 +86,17 @@ func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, er
 	return execPlan{root: root}, err
 }
 
+func (b *Builder) buildCall(call *memo.CallExpr) (execPlan, error) {
+	scalarCtx := buildScalarCtx{}
+	proc, err := b.buildScalar(&scalarCtx, call.Proc)
+	if err != nil {
+		return execPlan{}, err
+	}
+	root, err := b.factory.ConstructCall(proc)
+	// Call returns no columns.
+	return execPlan{root: root}, err
+}
+
 func (b *Builder) buildExplainOpt(explain *memo.ExplainExpr) (execPlan, error) {
 	fmtFlags := memo.ExprFmtHideAll
 	switch {
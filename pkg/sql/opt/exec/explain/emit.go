@@ -279,6 +279,7 @@ var nodeNames = [...]string{
 	alterTableUnsplitOp:    "unsplit",
 	applyJoinOp:            "", // This node does not have a fixed name.
 	bufferOp:               "buffer",
+	callOp:                 "call",
 	cancelQueriesOp:        "cancel queries",
 	cancelSessionsOp:       "cancel sessions",
 	controlJobsOp:          "control jobs",
@@ -293,6 +294,7 @@ var nodeNames = [...]string{
 	errorIfRowsOp:          "error if rows",
 	explainOp:              "explain",
 	explainOptOp:           "explain",
+	expandOp:               "expand",
 	exportOp:               "export",
 	filterOp:               "filter",
 	groupByOp:              "", // This node does not have a fixed name.
@@ -648,6 +650,13 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			a.Aggregations, nil /* groupCols */, nil /* groupColOrdering */, true, /* isScalar */
 		)
 
+	case expandOp:
+		a := n.args.(*expandArgs)
+		inputCols := a.Input.Columns()
+		for _, set := range a.GroupingSets {
+			ob.Attrf("grouping set", "(%s)", printColumnSet(inputCols, set))
+		}
+
 	case distinctOp:
 		a := n.args.(*distinctArgs)
 		inputCols := a.Input.Columns()
@@ -953,7 +962,8 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		cancelQueriesOp,
 		cancelSessionsOp,
 		createStatisticsOp,
-		exportOp:
+		exportOp,
+		callOp:
 
 	default:
 		return errors.AssertionFailedf("unhandled op %d", n.op)
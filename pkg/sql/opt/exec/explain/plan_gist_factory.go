@@ -569,6 +569,22 @@ func (u *unknownTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) ExclusionConstraintCount() int {
+	return 0
+}
+
+func (u *unknownTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownTable) Zone() cat.Zone {
 	return cat.EmptyZone()
 }
@@ -94,6 +94,17 @@ func getResultColumns(
 			Typ:  types.Int,
 		}), nil
 
+	case expandOp:
+		a := args.(*expandArgs)
+		cols := append(colinfo.ResultColumns(nil), inputs[0]...)
+		for _, col := range a.GroupCols {
+			cols = append(cols, colinfo.ResultColumn{
+				Name: inputs[0][col].Name,
+				Typ:  inputs[0][col].Typ,
+			})
+		}
+		return append(cols, colinfo.ResultColumn{Name: a.GroupingSetColName, Typ: types.Int}), nil
+
 	case groupByOp:
 		a := args.(*groupByArgs)
 		return groupByColumns(inputs[0], a.GroupCols, a.Aggregations), nil
@@ -190,7 +201,7 @@ func getResultColumns(
 
 	case createTableOp, createTableAsOp, createViewOp, controlJobsOp, controlSchedulesOp,
 		cancelQueriesOp, cancelSessionsOp, createStatisticsOp, errorIfRowsOp, deleteRangeOp,
-		createFunctionOp:
+		createFunctionOp, callOp:
 		// These operations produce no columns.
 		return nil, nil
 
@@ -152,6 +152,10 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate was created with CREATE AGGREGATE,
+	// in which case FuncName is the name of the aggregate.
+	UserDefined *tree.UserDefinedAggregate
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -173,6 +177,10 @@ type WindowInfo struct {
 	// in the same order as Exprs.
 	ArgIdxs [][]NodeColumnOrdinal
 
+	// UserDefinedAggs is the list of aggregates created with CREATE AGGREGATE,
+	// in the same order as Exprs. An entry is nil if the function is a builtin.
+	UserDefinedAggs []*tree.UserDefinedAggregate
+
 	// FilterIdxs is the list of column indices to use as filters.
 	FilterIdxs []int
 
@@ -174,6 +174,14 @@ func (ics *indexCandidateSet) categorizeIndexCandidates(expr opt.Expr) {
 	case *memo.BBoxIntersectsExpr:
 		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
 		ics.addVariableExprIndex(expr.Right, ics.overallCandidates)
+	case *memo.TSMatchesExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
+		ics.addVariableExprIndex(expr.Right, ics.overallCandidates)
+	case *memo.AdjacentExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
+		ics.addVariableExprIndex(expr.Right, ics.overallCandidates)
+	case *memo.JsonPathExistsExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
 	}
 	for i, n := 0, expr.ChildCount(); i < n; i++ {
 		ics.categorizeIndexCandidates(expr.Child(i))
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -388,6 +389,8 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		invertedExpr = j.extractJSONExistsCondition(ctx, evalCtx, t.Left, t.Right, false /* all */)
 	case *memo.JsonAllExistsExpr:
 		invertedExpr = j.extractJSONExistsCondition(ctx, evalCtx, t.Left, t.Right, true /* all */)
+	case *memo.JsonPathExistsExpr:
+		invertedExpr = j.extractJSONPathExistsCondition(ctx, evalCtx, t.Left, t.Right)
 	case *memo.EqExpr:
 		if fetch, ok := t.Left.(*memo.FetchValExpr); ok {
 			invertedExpr = j.extractJSONFetchValEqCondition(ctx, evalCtx, fetch, t.Right)
@@ -505,6 +508,143 @@ func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
 	return inverted.NonInvertedColExpression{}
 }
 
+// extractJSONPathExistsCondition extracts an InvertedExpression representing an
+// inverted filter with the JSONPathExists (@?) operator over the planner's
+// inverted index, based on the given left and right expression arguments.
+//
+// Only JSON paths that compare the value at a chain of keys with a constant
+// are supported, for example `$.a.b ? (@ == 1)` or `$ ? (@.a.b == 1)`. These
+// are converted to a union of containment conditions, like j @> '{"a": {"b":
+// 1}}'. If an InvertedExpression cannot be generated from the expression, an
+// inverted.NonInvertedColExpression is returned.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathExistsCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) || !memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	p, ok := tree.AsDJsonpath(memo.ExtractConstDatum(right))
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	keys, val, ok := jsonPathEqualityCondition(p.Path)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	objs := buildJSONPathContainmentObjects(keys, val, !p.Strict)
+	if len(objs) == 0 {
+		return inverted.NonInvertedColExpression{}
+	}
+	var invertedExpr inverted.Expression
+	for i := range objs {
+		expr := getInvertedExprForJSONOrArrayIndexForContaining(ctx, evalCtx, tree.NewDJSON(objs[i]))
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, expr)
+		}
+	}
+	// Containment doesn't match the semantics of the path exactly. For
+	// example, a containment condition matches documents in which the value is
+	// nested in more than one level of arrays, which aren't unwrapped by the
+	// path. The original filter must be applied after the index scan.
+	invertedExpr.SetNotTight()
+	return invertedExpr
+}
+
+// jsonPathEqualityCondition returns the keys and the value of a JSON path that
+// compares the value at a chain of keys with a literal, in one of the forms
+// `$.k1.k2 ? (@ == val)` or `$ ? (@.k1.k2 == val)`. ok is false if the path
+// has a different form.
+func jsonPathEqualityCondition(p *jsonpath.Path) (keys []string, val json.JSON, ok bool) {
+	// appendKeys appends the keys of steps to keys, returning false if any of
+	// the steps is not a member accessor.
+	appendKeys := func(steps []jsonpath.Step) bool {
+		for _, s := range steps {
+			k, ok := s.(*jsonpath.KeyStep)
+			if !ok {
+				return false
+			}
+			keys = append(keys, k.Key)
+		}
+		return true
+	}
+
+	chain, ok := p.Expr.(*jsonpath.Chain)
+	if !ok || len(chain.Steps) == 0 {
+		return nil, nil, false
+	}
+	if _, ok := chain.Head.(jsonpath.Root); !ok {
+		return nil, nil, false
+	}
+	filter, ok := chain.Steps[len(chain.Steps)-1].(*jsonpath.FilterStep)
+	if !ok || !appendKeys(chain.Steps[:len(chain.Steps)-1]) {
+		return nil, nil, false
+	}
+	cmp, ok := filter.Pred.(*jsonpath.Binary)
+	if !ok || cmp.Op != jsonpath.OpEq {
+		return nil, nil, false
+	}
+	operand, lit := cmp.Left, cmp.Right
+	if _, ok := operand.(*jsonpath.Literal); ok {
+		operand, lit = lit, operand
+	}
+	l, ok := lit.(*jsonpath.Literal)
+	if !ok {
+		return nil, nil, false
+	}
+	switch t := operand.(type) {
+	case jsonpath.Current:
+	case *jsonpath.Chain:
+		if _, ok := t.Head.(jsonpath.Current); !ok || !appendKeys(t.Steps) {
+			return nil, nil, false
+		}
+	default:
+		return nil, nil, false
+	}
+	return keys, l.Val, true
+}
+
+// maxLaxJSONPathKeys is the maximum number of keys in a JSON path evaluated in
+// lax mode for which buildJSONPathContainmentObjects builds containment
+// objects. Each key doubles the number of objects.
+const maxLaxJSONPathKeys = 3
+
+// buildJSONPathContainmentObjects constructs JSON objects such that any
+// document in which the value at the given keys equals val contains at least
+// one of them. In lax
+// mode, arrays are unwrapped by the path, so each object level and the value
+// itself may also be wrapped in an array. For example, the keys {"a"} and the
+// value 1 result in {"a": 1} in strict mode, and in {"a": 1}, {"a": [1]},
+// [{"a": 1}] and [{"a": [1]}] in lax mode. Returns nil if there are too many
+// objects.
+func buildJSONPathContainmentObjects(keys []string, val json.JSON, lax bool) []json.JSON {
+	if lax && len(keys) > maxLaxJSONPathKeys {
+		return nil
+	}
+	// maybeWrap returns objs, plus each of objs wrapped in an array in lax
+	// mode.
+	maybeWrap := func(objs []json.JSON) []json.JSON {
+		if !lax {
+			return objs
+		}
+		for _, obj := range objs {
+			b := json.NewArrayBuilder(1)
+			b.Add(obj)
+			objs = append(objs, b.Build())
+		}
+		return objs
+	}
+	objs := maybeWrap([]json.JSON{val})
+	for i := len(keys) - 1; i >= 0; i-- {
+		for k := range objs {
+			objs[k] = buildObject([]string{keys[i]}, objs[k])
+		}
+		objs = maybeWrap(objs)
+	}
+	return objs
+}
+
 // extractJSONFetchValEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // a chain of fetch val expressions and a scalar expression. If an
@@ -750,6 +750,51 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			indexOrd: jsonOrd,
 			ok:       false,
 		},
+		{
+			// JSONPathExists is supported for paths that compare the value at a
+			// chain of keys with a constant. In lax mode, the spans for all of the
+			// ways that arrays can be unwrapped are unioned.
+			filters:          `j @? '$.a ? (@ == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$.a ? (@ == 1)'`,
+		},
+		{
+			filters:          `j @? '$ ? (@.a.b == "foo")'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$ ? (@.a.b == "foo")'`,
+		},
+		{
+			// In strict mode, arrays are not unwrapped, so a single containment
+			// condition is enough.
+			filters:          `j @? 'strict $.a ? (@ == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @? 'strict $.a ? (@ == 1)'`,
+		},
+		{
+			// JSONPathExists is not supported for other paths.
+			filters:  `j @? '$.a'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$.a ? (@ > 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$.a[*] ? (@ == 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 		{
 			// Overlaps is supported for arrays.
 			// Overlaps with a single element array produces
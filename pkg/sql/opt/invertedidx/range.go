@@ -0,0 +1,109 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	_ context.Context, _ *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var constantVal opt.ScalarExpr
+	var overlaps bool
+	switch e := expr.(type) {
+	case *memo.OverlapsExpr:
+		// The && operator is commutative.
+		overlaps = true
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			constantVal = e.Right
+		} else if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) &&
+			memo.CanExtractConstDatum(e.Left) {
+			constantVal = e.Left
+		}
+	case *memo.ContainsExpr:
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			constantVal = e.Right
+		}
+	case *memo.ContainedByExpr:
+		// The query q <@ col is equivalent to col @> q.
+		if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			constantVal = e.Left
+		}
+	}
+	if constantVal == nil {
+		// Can only accelerate with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	var q *tree.DRange
+	switch d := memo.ExtractConstDatum(constantVal).(type) {
+	case *tree.DRange:
+		q = d
+	case *tree.DMultirange:
+		// The spans of the smallest range containing the multirange are a
+		// superset of the spans of the multirange.
+		q = d.Span()
+	default:
+		// A range contains an element if it contains the range consisting of
+		// just the element.
+		q = &tree.DRange{
+			Lower: tree.RangeBound{Val: d, Inclusive: true},
+			Upper: tree.RangeBound{Val: d, Inclusive: true},
+		}
+	}
+	if q.Empty {
+		// Nothing overlaps the empty range, and everything contains it, so the
+		// index can't help.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	var err error
+	if overlaps {
+		invertedExpr, err = rowenc.EncodeOverlappingRangeInvertedIndexSpans(q)
+	} else {
+		invertedExpr, err = rowenc.EncodeContainingRangeInvertedIndexSpans(q)
+	}
+	if err != nil {
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// The spans ignore the inclusivity of range bounds, so the original filter
+	// must always be applied after the inverted index scan.
+	remainingFilters = expr
+
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func TestTryFilterRange(t *testing.T) {
+	semaCtx := tree.MakeSemaContext()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t (r INT8RANGE, m INT8MULTIRANGE, INVERTED INDEX (r), INVERTED INDEX (m))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn := tree.NewUnqualifiedTableName("t")
+	tab := md.AddTable(tc.Table(tn), tn)
+	rangeOrd, multirangeOrd := 1, 2
+
+	// If we can create an inverted filter with the given filter expression and
+	// index, ok=true. The spans ignore the inclusivity of range bounds, so they
+	// are never tight, and the remaining filters are always the original
+	// filters. Every range has exactly one key for each of its bounds, so the
+	// spans are always unique.
+	testCases := []struct {
+		filters  string
+		indexOrd int
+		ok       bool
+	}{
+		{filters: "r && '[1,10)'::INT8RANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "'[1,10)'::INT8RANGE && r", indexOrd: rangeOrd, ok: true},
+		{filters: "r && '(,10)'::INT8RANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "r && '(,)'::INT8RANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "r && '{[1,2), [5,6)}'::INT8MULTIRANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "r @> '[1,10)'::INT8RANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "r @> '[1,)'::INT8RANGE", indexOrd: rangeOrd, ok: true},
+		{filters: "r @> 5", indexOrd: rangeOrd, ok: true},
+		{filters: "'[1,10)'::INT8RANGE <@ r", indexOrd: rangeOrd, ok: true},
+		{filters: "m && '[1,10)'::INT8RANGE", indexOrd: multirangeOrd, ok: true},
+		{filters: "m @> 5", indexOrd: multirangeOrd, ok: true},
+		{filters: "m @> '{[1,2), [5,6)}'::INT8MULTIRANGE", indexOrd: multirangeOrd, ok: true},
+
+		// Nothing overlaps the empty range, and everything contains it.
+		{filters: "r && 'empty'::INT8RANGE", indexOrd: rangeOrd, ok: false},
+		{filters: "r @> 'empty'::INT8RANGE", indexOrd: rangeOrd, ok: false},
+
+		// The column must be the container.
+		{filters: "r <@ '[1,10)'::INT8RANGE", indexOrd: rangeOrd, ok: false},
+		{filters: "'[1,10)'::INT8RANGE @> r", indexOrd: rangeOrd, ok: false},
+
+		// The query must be a constant.
+		{filters: "r && r", indexOrd: rangeOrd, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		spanExpr, _, remainingFilters, _, ok := invertedidx.TryFilterInvertedIndex(
+			context.Background(),
+			evalCtx,
+			&f,
+			filters,
+			nil, /* optionalFilters */
+			tab,
+			md.Table(tab).Index(tc.indexOrd),
+			nil, /* computedColumns */
+		)
+		if tc.ok != ok {
+			t.Fatalf("For (%s), expected %v, got %v", tc.filters, tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if spanExpr.Tight {
+			t.Fatalf("For (%s), expected tight=false", tc.filters)
+		}
+		if !spanExpr.Unique {
+			t.Fatalf("For (%s), expected unique=true", tc.filters)
+		}
+		if remainingFilters.String() != filters.String() {
+			t.Errorf("For (%s), expected remainingFilters=%v, got %v", tc.filters, filters, remainingFilters)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func TestTryFilterTSVector(t *testing.T) {
+	semaCtx := tree.MakeSemaContext()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t (v TSVECTOR, q TSQUERY, INVERTED INDEX (v))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn := tree.NewUnqualifiedTableName("t")
+	tab := md.AddTable(tc.Table(tn), tn)
+	tsvectorOrd := 1
+
+	// If we can create an inverted filter with the given filter expression and
+	// index, ok=true. If the spans in the resulting inverted index constraint
+	// do not have duplicate primary keys, unique=true. If the spans are tight,
+	// tight=true and remainingFilters="". Otherwise, tight is false and
+	// remainingFilters contains some or all of the original filters.
+	testCases := []struct {
+		filters string
+		ok      bool
+		tight   bool
+		unique  bool
+	}{
+		{filters: "v @@ 'cat'", ok: true, tight: true, unique: true},
+		{filters: "'cat' @@ v", ok: true, tight: true, unique: true},
+		{filters: "v @@ 'cat & dog'", ok: true, tight: true, unique: true},
+		{filters: "v @@ 'cat' AND v @@ 'dog'", ok: true, tight: true, unique: true},
+		{filters: "v @@ 'cat | dog'", ok: true, tight: true, unique: false},
+		// Prefix matches can match many lexemes in the same document.
+		{filters: "v @@ 'ca:*'", ok: true, tight: true, unique: false},
+		// The index doesn't store weights or positions, so weight restrictions
+		// and followed by operators must be re-checked.
+		{filters: "v @@ 'cat:A'", ok: true, tight: false, unique: true},
+		{filters: "v @@ 'cat <-> dog'", ok: true, tight: false, unique: true},
+		{filters: "v @@ 'cat & !dog'", ok: true, tight: false, unique: true},
+
+		// Queries that can match documents that contain none of their lexemes
+		// can't be used to constrain the index.
+		{filters: "v @@ '!cat'", ok: false},
+		{filters: "v @@ 'cat | !dog'", ok: false},
+
+		// The query must be a constant.
+		{filters: "v @@ q", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		// We're not testing that the correct SpanExpression is returned here;
+		// that is tested elsewhere. This is just testing that we are constraining
+		// the index when we expect to and we have the correct values for tight,
+		// unique, and remainingFilters.
+		spanExpr, _, remainingFilters, _, ok := invertedidx.TryFilterInvertedIndex(
+			context.Background(),
+			evalCtx,
+			&f,
+			filters,
+			nil, /* optionalFilters */
+			tab,
+			md.Table(tab).Index(tsvectorOrd),
+			nil, /* computedColumns */
+		)
+		if tc.ok != ok {
+			t.Fatalf("For (%s), expected %v, got %v", tc.filters, tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if tc.tight != spanExpr.Tight {
+			t.Fatalf("For (%s), expected tight=%v, but got %v", tc.filters, tc.tight, spanExpr.Tight)
+		}
+		if tc.unique != spanExpr.Unique {
+			t.Fatalf("For (%s), expected unique=%v, but got %v", tc.filters, tc.unique, spanExpr.Unique)
+		}
+
+		if tc.tight {
+			if remainingFilters != nil {
+				t.Fatalf("For (%s), expected remainingFilters=<nil>, got %v", tc.filters, remainingFilters)
+			}
+			continue
+		}
+		if remainingFilters.String() != filters.String() {
+			t.Errorf("For (%s), expected remainingFilters=%v, got %v", tc.filters, filters, remainingFilters)
+		}
+	}
+}
@@ -350,6 +350,12 @@ func (n ZipExpr) OutputCols() opt.ColSet {
 // used by the ColumnAccess scalar expression.
 type TupleOrdinal uint32
 
+// GroupingSets is the list of grouping sets computed by an Expand operator.
+// Each set contains the subset of the Expand output columns that are grouped
+// on in that set; the remaining output columns are NULL for the corresponding
+// rows.
+type GroupingSets []opt.ColSet
+
 // ScanLimit is used for a limited table or index scan and stores the limit as
 // well as the desired scan direction. A value of 0 means that there is no
 // limit.
@@ -1098,10 +1104,18 @@ func ExprIsNeverNull(e opt.ScalarExpr, notNullCols opt.ColSet) bool {
 	case *CastExpr, *AssignmentCastExpr, *NotExpr, *RangeExpr:
 		return ExprIsNeverNull(t.Child(0).(opt.ScalarExpr), notNullCols)
 
+	case *TSMatchesExpr:
+		// The predicate check of a JSON path evaluates to NULL if the path
+		// doesn't return a single boolean.
+		if t.Left.DataType().Family() == types.JsonFamily {
+			return false
+		}
+		return ExprIsNeverNull(t.Left, notNullCols) && ExprIsNeverNull(t.Right, notNullCols)
+
 	case *AndExpr, *OrExpr, *GeExpr, *GtExpr, *NeExpr, *EqExpr, *LeExpr, *LtExpr, *LikeExpr,
 		*NotLikeExpr, *ILikeExpr, *NotILikeExpr, *SimilarToExpr, *NotSimilarToExpr, *RegMatchExpr,
 		*NotRegMatchExpr, *RegIMatchExpr, *NotRegIMatchExpr, *ContainsExpr, *ContainedByExpr, *JsonExistsExpr,
-		*JsonAllExistsExpr, *JsonSomeExistsExpr, *AnyScalarExpr, *BitandExpr, *BitorExpr, *BitxorExpr,
+		*JsonAllExistsExpr, *JsonSomeExistsExpr, *AdjacentExpr, *AnyScalarExpr, *BitandExpr, *BitorExpr, *BitxorExpr,
 		*PlusExpr, *MinusExpr, *MultExpr, *DivExpr, *FloorDivExpr, *ModExpr, *PowExpr, *ConcatExpr,
 		*LShiftExpr, *RShiftExpr, *WhenExpr:
 		return ExprIsNeverNull(t.Child(0).(opt.ScalarExpr), notNullCols) &&
@@ -1183,7 +1197,8 @@ type FKCascades []FKCascade
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
 type FKCascade struct {
-	// FKName is the name of the FK constraint.
+	// FKName is the name of the FK constraint. For an AFTER row trigger, which
+	// is planned like a cascade, it is the name of the trigger.
 	FKName string
 
 	// Builder is an object that can be used as the "optbuilder" for the cascading
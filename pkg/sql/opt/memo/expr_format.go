@@ -1190,6 +1190,18 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 
 	case *UniqueChecksItem:
 		tab := f.Memo.metadata.TableMeta(t.Table)
+		if t.Exclusion {
+			ec := tab.Table.ExclusionConstraint(t.CheckOrdinal)
+			fmt.Fprintf(f.Buffer, ": %s exclude(", tab.Alias.ObjectName)
+			for i, ord := range ec.ColumnOrdinals {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				fmt.Fprintf(f.Buffer, "%s %s", tab.Table.Column(ord).ColName(), ec.Operators[i])
+			}
+			f.Buffer.WriteByte(')')
+			break
+		}
 		constraint := tab.Table.Unique(t.CheckOrdinal)
 		fmt.Fprintf(f.Buffer, ": %s(", tab.Alias.ObjectName)
 		for i := 0; i < constraint.ColumnCount(); i++ {
@@ -1618,6 +1630,15 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 			fmt.Fprintf(f.Buffer, " ordering=%s", t.Ordering)
 		}
 
+	case *ExpandPrivate:
+		fmt.Fprintf(f.Buffer, " sets=")
+		for i, set := range t.GroupingSets {
+			if i > 0 {
+				f.Buffer.WriteByte(',')
+			}
+			f.Buffer.WriteString(set.String())
+		}
+
 	case *GroupingPrivate:
 		fmt.Fprintf(f.Buffer, " cols=%s", t.GroupingCols.String())
 		if !t.Ordering.Any() {
@@ -1656,6 +1677,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
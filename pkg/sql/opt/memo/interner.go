@@ -591,6 +591,14 @@ func (h *hasher) HashUniqueOrdinals(val cat.UniqueOrdinals) {
 	h.hash = hash
 }
 
+func (h *hasher) HashGroupingSets(val GroupingSets) {
+	h.HashInt(len(val))
+	for i := range val {
+		h.HashInt(val[i].Len())
+		h.HashColSet(val[i])
+	}
+}
+
 func (h *hasher) HashSchemaDeps(val opt.SchemaDeps) {
 	// Hash the length and address of the first element.
 	h.HashInt(len(val))
@@ -1027,6 +1035,18 @@ func (h *hasher) IsUniqueOrdinalsEqual(l, r cat.UniqueOrdinals) bool {
 	return true
 }
 
+func (h *hasher) IsGroupingSetsEqual(l, r GroupingSets) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if !l[i].Equals(r[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *hasher) IsSchemaDepsEqual(l, r opt.SchemaDeps) bool {
 	if len(l) != len(r) {
 		return false
@@ -455,6 +455,13 @@ func TestInterner(t *testing.T) {
 			{val1: cat.UniqueOrdinals{1, 2}, val2: cat.UniqueOrdinals{1, 2, 3}, equal: false},
 		}},
 
+		{hashFn: in.hasher.HashGroupingSets, eqFn: in.hasher.IsGroupingSetsEqual, variations: []testVariation{
+			{val1: GroupingSets{}, val2: GroupingSets{}, equal: true},
+			{val1: GroupingSets{opt.MakeColSet(1, 2), opt.ColSet{}}, val2: GroupingSets{opt.MakeColSet(1, 2), opt.ColSet{}}, equal: true},
+			{val1: GroupingSets{opt.MakeColSet(1, 2), opt.ColSet{}}, val2: GroupingSets{opt.ColSet{}, opt.MakeColSet(1, 2)}, equal: false},
+			{val1: GroupingSets{opt.MakeColSet(1)}, val2: GroupingSets{opt.MakeColSet(1), opt.MakeColSet(1)}, equal: false},
+		}},
+
 		{hashFn: in.hasher.HashSchemaDeps, eqFn: in.hasher.IsSchemaDepsEqual, variations: []testVariation{
 			{val1: viewDeps1, val2: viewDeps1, equal: true},
 			{val1: viewDeps1, val2: viewDeps2, equal: false},
@@ -1303,6 +1303,70 @@ func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *pro
 	}
 }
 
+func (b *logicalPropsBuilder) buildExpandProps(expand *ExpandExpr, rel *props.Relational) {
+	BuildSharedProps(expand, &rel.Shared, b.evalCtx)
+
+	inputProps := expand.Input.Relational()
+
+	// Output Columns
+	// --------------
+	// The copies of the grouping columns and the grouping set column are added
+	// to the columns projected by the input operator.
+	rel.OutputCols = inputProps.OutputCols.Union(expand.OutputCols.ToSet())
+	rel.OutputCols.Add(expand.GroupingSetCol)
+
+	// Not Null Columns
+	// ----------------
+	// The grouping set column is not null, and other columns inherit not null
+	// property from input. A copy of a grouping column is not null if the
+	// column is part of every grouping set and is not null in the input.
+	rel.NotNullCols = inputProps.NotNullCols.Copy()
+	rel.NotNullCols.Add(expand.GroupingSetCol)
+	for i, col := range expand.OutputCols {
+		if !inputProps.NotNullCols.Contains(expand.InputCols[i]) {
+			continue
+		}
+		inAllSets := true
+		for _, set := range expand.GroupingSets {
+			if !set.Contains(col) {
+				inAllSets = false
+				break
+			}
+		}
+		if inAllSets {
+			rel.NotNullCols.Add(col)
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// Every input row is duplicated once per grouping set, so keys of the input
+	// are not keys of the output. Start with a copy of the input FD set and
+	// treat the new columns the same way as the columns of a lateral cross
+	// join with an empty FD set.
+	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
+	rel.FuncDeps.MakeApply(&props.FuncDepSet{})
+	addOuterColsToFuncDep(rel.OuterCols, &rel.FuncDeps)
+	rel.FuncDeps.MakeNotNull(rel.NotNullCols)
+	rel.FuncDeps.ProjectCols(rel.OutputCols)
+
+	// Cardinality
+	// -----------
+	// Every input row is emitted once per grouping set.
+	numSets := uint32(len(expand.GroupingSets))
+	rel.Cardinality = inputProps.Cardinality.Product(props.Cardinality{Min: numSets, Max: numSets})
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildExpand(expand, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildWindowProps(window *WindowExpr, rel *props.Relational) {
 	BuildSharedProps(window, &rel.Shared, b.evalCtx)
 
@@ -1519,6 +1583,10 @@ func (b *logicalPropsBuilder) buildCreateFunctionProps(
 	BuildSharedProps(cf, &rel.Shared, b.evalCtx)
 }
 
+func (b *logicalPropsBuilder) buildCallProps(call *CallExpr, rel *props.Relational) {
+	b.buildBasicProps(call, opt.ColList{}, rel)
+}
+
 func (b *logicalPropsBuilder) buildFiltersItemProps(item *FiltersItem, scalar *props.Scalar) {
 	BuildSharedProps(item.Condition, &scalar.Shared, b.evalCtx)
 
@@ -1660,6 +1728,12 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Volatility)
 
+	case *UserDefinedAggExpr:
+		// The support functions of the aggregate are not children of the
+		// expression, so their properties are added here.
+		BuildSharedProps(t.Transition, shared, evalCtx)
+		BuildSharedProps(t.Final, shared, evalCtx)
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -465,6 +465,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.OrdinalityOp:
 		return sb.colStatOrdinality(colSet, e.(*OrdinalityExpr))
 
+	case opt.ExpandOp:
+		return sb.colStatExpand(colSet, e.(*ExpandExpr))
+
 	case opt.WindowOp:
 		return sb.colStatWindow(colSet, e.(*WindowExpr))
 
@@ -2374,6 +2377,74 @@ func (sb *statisticsBuilder) colStatOrdinality(
 	return colStat
 }
 
+// +------------+
+// |   Expand   |
+// +------------+
+
+func (sb *statisticsBuilder) buildExpand(expand *ExpandExpr, relProps *props.Relational) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(expand)
+
+	inputStats := expand.Input.Relational().Statistics()
+
+	// Every input row is emitted once per grouping set.
+	s.RowCount = inputStats.RowCount * float64(len(expand.GroupingSets))
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatExpand(
+	colSet opt.ColSet, expand *ExpandExpr,
+) *props.ColumnStatistic {
+	relProps := expand.Relational()
+	s := relProps.Statistics()
+	numSets := float64(len(expand.GroupingSets))
+
+	colStat, _ := s.ColStats.Add(colSet)
+
+	// The copies of the grouping columns have the same values as the
+	// corresponding input columns, except that they are NULL for the grouping
+	// sets they are not part of.
+	reqCols := colSet.Copy()
+	reqCols.Remove(expand.GroupingSetCol)
+	reqOutputCols := reqCols.Intersection(expand.OutputCols.ToSet())
+	reqCols.DifferenceWith(reqOutputCols)
+	reqCols.UnionWith(opt.TranslateColSet(reqOutputCols, expand.OutputCols, expand.InputCols))
+
+	if reqCols.Empty() {
+		// Only the grouping set column was requested.
+		colStat.DistinctCount = numSets
+		colStat.NullCount = 0
+	} else {
+		inputColStat := sb.colStatFromChild(reqCols, expand, 0 /* childIdx */)
+		colStat.DistinctCount = inputColStat.DistinctCount
+		colStat.NullCount = inputColStat.NullCount * numSets
+		if !reqOutputCols.Empty() || colSet.Contains(expand.GroupingSetCol) {
+			// Each grouping set can produce a different set of values.
+			colStat.DistinctCount *= numSets
+		}
+		if !reqOutputCols.Empty() {
+			// Add the NULLs produced for the grouping sets that the requested
+			// copies are not part of.
+			for _, set := range expand.GroupingSets {
+				if !reqOutputCols.SubsetOf(set) {
+					colStat.NullCount += s.RowCount / numSets
+				}
+			}
+			colStat.NullCount = min(colStat.NullCount, s.RowCount)
+		}
+	}
+
+	if colSet.Intersects(relProps.NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +------------+
 // |   Window   |
 // +------------+
@@ -31,6 +31,11 @@ func (c *CustomFuncs) NeededOrdinalityCols(private *memo.OrdinalityPrivate) opt.
 	return private.Ordering.ColSet()
 }
 
+// NeededExpandCols returns the input grouping columns of an Expand operator.
+func (c *CustomFuncs) NeededExpandCols(private *memo.ExpandPrivate) opt.ColSet {
+	return private.InputCols.ToSet()
+}
+
 // NeededExplainCols returns the columns needed by Explain's required physical
 // properties.
 func (c *CustomFuncs) NeededExplainCols(private *memo.ExplainPrivate) opt.ColSet {
@@ -79,6 +84,14 @@ func (c *CustomFuncs) neededMutationCols(
 		cols.Add(private.CanaryCol)
 	}
 
+	// The values read by cascades from the buffered input are usually a subset
+	// of the columns above, but AFTER triggers may also need old values of
+	// columns that are not otherwise fetched.
+	for i := range private.FKCascades {
+		cols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		cols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+	}
+
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
 			withUses := memo.WithUses(uniqueChecks[i].Check)
@@ -613,6 +626,19 @@ func DerivePruneCols(e memo.RelExpr, disabledRules util.FastIntSet) opt.ColSet {
 		inputPruneCols := DerivePruneCols(ord.Input, disabledRules)
 		relProps.Rule.PruneCols = inputPruneCols.Difference(ord.Ordering.ColSet())
 
+	case opt.ExpandOp:
+		if disabledRules.Contains(int(opt.PruneExpandCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Any pruneable input columns can potentially be pruned, as long as
+		// they're not grouping columns. The copies of the grouping columns and
+		// the grouping set column cannot be pruned without adding an additional
+		// Project operator, so don't add them to the set.
+		expand := e.(*memo.ExpandExpr)
+		inputPruneCols := DerivePruneCols(expand.Input, disabledRules)
+		relProps.Rule.PruneCols = inputPruneCols.Difference(expand.InputCols.ToSet())
+
 	case opt.IndexJoinOp, opt.LookupJoinOp, opt.MergeJoinOp:
 		// There is no need to prune columns projected by Index, Lookup or Merge
 		// joins, since its parent will always be an "alternate" expression in the
@@ -145,6 +145,9 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	OverlapsOp:       treecmp.Overlaps,
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
+	TSMatchesOp:      treecmp.TSMatches,
+	AdjacentOp:       treecmp.Adjacent,
+	JsonPathExistsOp: treecmp.JSONPathExists,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -278,7 +281,8 @@ func ScalarOperatorTransmitsNulls(op Operator) bool {
 	case BitandOp, BitorOp, BitxorOp, PlusOp, MinusOp, MultOp, DivOp, FloorDivOp,
 		ModOp, PowOp, EqOp, NeOp, LtOp, GtOp, LeOp, GeOp, LikeOp, NotLikeOp, ILikeOp,
 		NotILikeOp, SimilarToOp, NotSimilarToOp, RegMatchOp, NotRegMatchOp, RegIMatchOp,
-		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp:
+		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp, TSMatchesOp, AdjacentOp,
+		JsonPathExistsOp:
 		return true
 
 	default:
@@ -294,7 +298,7 @@ func BoolOperatorRequiresNotNullArgs(op Operator) bool {
 		EqOp, LtOp, LeOp, GtOp, GeOp, NeOp,
 		LikeOp, NotLikeOp, ILikeOp, NotILikeOp, SimilarToOp, NotSimilarToOp,
 		RegMatchOp, NotRegMatchOp, RegIMatchOp, NotRegIMatchOp, BBoxCoversOp,
-		BBoxIntersectsOp:
+		BBoxIntersectsOp, TSMatchesOp, AdjacentOp, JsonPathExistsOp:
 		return true
 	}
 	return false
@@ -329,7 +333,7 @@ func AggregateIgnoresNulls(op Operator) bool {
 		return true
 
 	case ArrayAggOp, ConcatAggOp, ConstAggOp, CountRowsOp, FirstAggOp, JsonAggOp,
-		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -357,6 +361,11 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 	case CountOp, CountRowsOp, RegressionCountOp:
 		return false
 
+	case UserDefinedAggOp:
+		// A user-defined aggregate returns its initial state, or the result of
+		// its final function on the initial state, when the input is empty.
+		return false
+
 	default:
 		panic(errors.AssertionFailedf("unhandled op %s", redact.Safe(op)))
 	}
@@ -383,7 +392,7 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
-		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp:
+		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp, UserDefinedAggOp:
 		// These aggregations can return NULL even with non-null input values.
 		return false
 
@@ -434,7 +443,7 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		SqrDiffOp, STCollectOp, StdDevOp, StringAggOp, VarianceOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -456,7 +465,7 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		VarPopOp, JsonObjectAggOp, JsonbObjectAggOp, STCollectOp, CovarPopOp,
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
-		RegressionSYYOp, RegressionCountOp:
+		RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -124,6 +124,10 @@ type Builder struct {
 	// are disabled and only statements whitelisted are allowed.
 	insideFuncDef bool
 
+	// If set, the function definition being processed is a procedure, which
+	// can also contain mutation statements. insideFuncDef is set as well.
+	insideProcDef bool
+
 	// If set, we are collecting view dependencies in schemaDeps. This can only
 	// happen inside view/function definitions.
 	//
@@ -295,6 +299,10 @@ func (b *Builder) buildStmt(
 	if b.insideFuncDef {
 		switch stmt := stmt.(type) {
 		case *tree.Select:
+		case *tree.Insert, *tree.Update, *tree.Delete:
+			if !b.insideProcDef {
+				panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition", stmt.StatementTag()))
+			}
 		default:
 			panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition", stmt.StatementTag()))
 		}
@@ -331,6 +339,9 @@ func (b *Builder) buildStmt(
 	case *tree.CreateFunction:
 		return b.buildCreateFunction(stmt, inScope)
 
+	case *tree.Call:
+		return b.buildCall(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
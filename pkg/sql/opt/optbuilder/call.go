@@ -0,0 +1,51 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// buildCall builds a CALL statement, which invokes a procedure.
+func (b *Builder) buildCall(c *tree.Call, inScope *scope) (outScope *scope) {
+	// Procedures are not cached in the memo, because the statements in their
+	// bodies are not tracked as dependencies of the CALL statement.
+	b.DisableMemoReuse = true
+
+	// Resolve the procedure and type-check its arguments.
+	texpr := inScope.resolveType(c.Proc, types.Any)
+	f, ok := texpr.(*tree.FuncExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected FuncExpr, found %T", texpr))
+	}
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	if !f.ResolvedOverload().IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure", def.Name),
+			"To call a function, use SELECT.",
+		))
+	}
+
+	// The procedure is built as a UDF that is evaluated when the CALL statement
+	// is executed.
+	proc := b.buildUDF(f, def, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	outScope = b.allocScope()
+	outScope.expr = b.factory.ConstructCall(proc)
+	return outScope
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -46,11 +47,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	b.semaCtx.FunctionResolver = nil
 
 	b.insideFuncDef = true
+	b.insideProcDef = cf.IsProcedure
 	b.trackSchemaDeps = true
 	// Make sure datasource names are qualified.
 	b.qualifyDataSourceNamesInAST = true
 	defer func() {
 		b.insideFuncDef = false
+		b.insideProcDef = false
 		b.trackSchemaDeps = false
 		b.schemaDeps = nil
 		b.schemaTypeDeps = util.FastIntSet{}
@@ -67,12 +70,22 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	if err := tree.ValidateFuncOptions(cf.Options); err != nil {
 		panic(err)
 	}
+	if cf.IsProcedure {
+		for _, option := range cf.Options {
+			switch option.(type) {
+			case tree.FunctionVolatility, tree.FunctionLeakproof, tree.FunctionNullInputBehavior:
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"invalid attribute in procedure definition"))
+			}
+		}
+	}
 
 	// Look for function body string from function options.
 	// Note that function body can be an empty string.
 	funcBodyFound := false
 	languageFound := false
 	var funcBodyStr string
+	var lang tree.FunctionLanguage
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.FunctionBodyStr:
@@ -80,6 +93,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			funcBodyStr = string(opt)
 		case tree.FunctionLanguage:
 			languageFound = true
+			lang = opt
 		}
 	}
 
@@ -134,43 +148,53 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		typeDeps.Add(int(typeID))
 	}
 
-	// Parse the function body.
-	stmts, err := parser.Parse(funcBodyStr)
-	if err != nil {
-		panic(err)
-	}
+	var formattedBody string
+	if funcReturnType.Family() == types.TriggerFamily {
+		formattedBody = validateTriggerFunctionBody(funcBodyStr, lang, len(cf.Args))
+	} else if lang == tree.FunctionLangPLpgSQL {
+		formattedBody = b.validatePLpgSQLFunctionBody(
+			funcBodyStr, len(cf.Args), bodyScope, funcReturnType, cf.IsProcedure, &deps, &typeDeps,
+		)
+	} else {
+		// Parse the function body.
+		stmts, err := parser.Parse(funcBodyStr)
+		if err != nil {
+			panic(err)
+		}
 
-	// Validate each statement and collect the dependencies.
-	fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
-	for i, stmt := range stmts {
-		stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
-
-		// Format the statements with qualified datasource names.
-		formatFuncBodyStmt(fmtCtx, stmt.AST, i > 0 /* newLine */)
-
-		// Validate that the result type of the last statement matches the
-		// return type of the function.
-		if i == len(stmts)-1 {
-			// TODO(mgartner): stmtScope.cols does not describe the result
-			// columns of the statement. We should use physical.Presentation
-			// instead.
-			err := validateReturnType(funcReturnType, stmtScope.cols)
-			if err != nil {
-				panic(err)
+		// Validate each statement and collect the dependencies.
+		fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
+		for i, stmt := range stmts {
+			stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
+
+			// Format the statements with qualified datasource names.
+			formatFuncBodyStmt(fmtCtx, stmt.AST, i > 0 /* newLine */)
+
+			// Validate that the result type of the last statement matches the
+			// return type of the function.
+			if i == len(stmts)-1 {
+				// TODO(mgartner): stmtScope.cols does not describe the result
+				// columns of the statement. We should use physical.Presentation
+				// instead.
+				err := validateReturnType(funcReturnType, stmtScope.cols)
+				if err != nil {
+					panic(err)
+				}
 			}
-		}
 
-		deps = append(deps, b.schemaDeps...)
-		typeDeps.UnionWith(b.schemaTypeDeps)
-		// Reset the tracked dependencies for next statement.
-		b.schemaDeps = nil
-		b.schemaTypeDeps = util.FastIntSet{}
+			deps = append(deps, b.schemaDeps...)
+			typeDeps.UnionWith(b.schemaTypeDeps)
+			// Reset the tracked dependencies for next statement.
+			b.schemaDeps = nil
+			b.schemaTypeDeps = util.FastIntSet{}
+		}
+		formattedBody = fmtCtx.String()
 	}
 
 	// Override the function body so that references are fully qualified.
 	for i, option := range cf.Options {
 		if _, ok := option.(tree.FunctionBodyStr); ok {
-			cf.Options[i] = tree.FunctionBodyStr(fmtCtx.String())
+			cf.Options[i] = tree.FunctionBodyStr(formattedBody)
 			break
 		}
 	}
@@ -187,6 +211,64 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	return outScope
 }
 
+// validatePLpgSQLFunctionBody builds each expression and statement in the
+// given PL/pgSQL function or procedure body to validate it, and adds its dependencies to
+// deps and typeDeps. It returns the body formatted with fully qualified
+// references.
+func (b *Builder) validatePLpgSQLFunctionBody(
+	body string,
+	numArgs int,
+	bodyScope *scope,
+	returnType *types.T,
+	isProcedure bool,
+	deps *opt.SchemaDeps,
+	typeDeps *opt.SchemaTypeDeps,
+) string {
+	block, err := plpgsqlparser.Parse(body)
+	if err != nil {
+		panic(err)
+	}
+	_, _, program := b.buildPLpgSQLBody(block, bodyScope, returnType, isProcedure)
+
+	// Collect the user defined type dependencies of the declared variables.
+	for _, typ := range program.VarTypes[numArgs:] {
+		typeIDs, err := typedesc.GetTypeDescriptorClosure(typ)
+		if err != nil {
+			panic(err)
+		}
+		for typeID := range typeIDs {
+			typeDeps.Add(int(typeID))
+		}
+	}
+	*deps = append(*deps, b.schemaDeps...)
+	typeDeps.UnionWith(b.schemaTypeDeps)
+	b.schemaDeps = nil
+	b.schemaTypeDeps = util.FastIntSet{}
+
+	return tree.AsStringWithFlags(block, tree.FmtSimple)
+}
+
+// validateTriggerFunctionBody validates the definition of a function that
+// returns type trigger, and returns its formatted body. The statements in the
+// body cannot be built until the function is invoked by a trigger, because the
+// NEW and OLD records depend on the table of the trigger, so only the syntax of
+// the body is validated.
+func validateTriggerFunctionBody(body string, lang tree.FunctionLanguage, numArgs int) string {
+	if lang != tree.FunctionLangPLpgSQL {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+			"SQL functions cannot return type trigger"))
+	}
+	if numArgs > 0 {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+			"trigger functions cannot have declared arguments"))
+	}
+	block, err := plpgsqlparser.Parse(body)
+	if err != nil {
+		panic(err)
+	}
+	return tree.AsStringWithFlags(block, tree.FmtSimple)
+}
+
 func formatFuncBodyStmt(fmtCtx *tree.FmtCtx, ast tree.Statement, newLine bool) {
 	if newLine {
 		fmtCtx.WriteString("\n")
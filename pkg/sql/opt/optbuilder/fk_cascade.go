@@ -296,7 +296,7 @@ func (cb *onDeleteFastCascadeBuilder) Build(
 				includeInverted:  false,
 			}),
 			nil, /* indexFlags */
-			noRowLocking,
+			b.constraintCheckLocking(tree.ForUpdate),
 			b.allocScope(),
 			true, /* disableNotVisibleIndex */
 		)
@@ -516,7 +516,7 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 			includeInverted:  false,
 		}),
 		nil, /* indexFlags */
-		noRowLocking,
+		b.constraintCheckLocking(tree.ForUpdate),
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	)
@@ -751,7 +751,7 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 			includeInverted:  false,
 		}),
 		nil, /* indexFlags */
-		noRowLocking,
+		b.constraintCheckLocking(tree.ForUpdate),
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	)
@@ -45,7 +45,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -100,6 +102,21 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is non-nil if the GROUP BY clause contains GROUPING SETS,
+	// ROLLUP or CUBE. It contains, for each grouping set, the subset of the
+	// grouping columns in aggInScope that are part of the set.
+	//
+	// In this case the grouping columns in aggOutScope are not the same as the
+	// grouping columns in aggInScope; they are nullable copies produced by an
+	// Expand operator, which emits each input row once per grouping set. The
+	// GroupBy groups on the copies and on groupingSetCol.
+	groupingSets []opt.ColSet
+
+	// groupingSetCol is the column produced by the Expand operator that
+	// contains the ordinal of the grouping set of each row. It is only set if
+	// groupingSets is non-nil.
+	groupingSetCol opt.ColumnID
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -247,7 +264,7 @@ func (a aggregateInfo) isOrderedSetAggregate() bool {
 // ordering sensitive. That is, it can give different results based on the order
 // values are fed to it.
 func (a aggregateInfo) isOrderingSensitive() bool {
-	if a.isOrderedSetAggregate() {
+	if a.isOrderedSetAggregate() || isUserDefinedAggregate(&a.def) {
 		return true
 	}
 	switch a.def.Name {
@@ -321,6 +338,131 @@ func (b *Builder) constructGroupBy(
 	return b.factory.ConstructGroupBy(input, aggs, &private)
 }
 
+// constructGroupingSetsGroupBy constructs the aggregation for a query with
+// grouping sets. The input rows are multiplied by an Expand operator, which
+// produces the nullable copies of the grouping columns that are in the
+// aggOutScope, and the aggregation groups on the copies and on the grouping
+// set column:
+//
+//	SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+//	group-by (copy_a, copy_b, grouping_set)
+//	 └── expand (sets: (copy_a, copy_b), (copy_a), ())
+//	      └── scan t
+//
+// An empty grouping set must produce a row even if the input is empty, like a
+// scalar aggregation does. If there are any empty grouping sets, the
+// aggregation is full-joined with a Values operator that contains the
+// ordinals of these sets, and aggregates that are not NULL on empty input are
+// coalesced with their default value.
+func (b *Builder) constructGroupingSetsGroupBy(
+	g *groupby, aggCols []scopeColumn, ordering opt.Ordering,
+) memo.RelExpr {
+	md := b.factory.Metadata()
+	inCols := g.groupingCols()
+	outCols := g.aggOutScope.cols[len(g.aggOutScope.cols)-len(inCols):]
+
+	private := memo.ExpandPrivate{
+		InputCols:      make(opt.ColList, len(inCols)),
+		OutputCols:     make(opt.ColList, len(outCols)),
+		GroupingSets:   make(memo.GroupingSets, len(g.groupingSets)),
+		GroupingSetCol: g.groupingSetCol,
+	}
+	for i := range inCols {
+		private.InputCols[i] = inCols[i].id
+		private.OutputCols[i] = outCols[i].id
+	}
+	var emptySets []int
+	for i, set := range g.groupingSets {
+		private.GroupingSets[i] = opt.TranslateColSet(set, private.InputCols, private.OutputCols)
+		if set.Empty() {
+			emptySets = append(emptySets, i)
+		}
+	}
+
+	if len(emptySets) == 0 {
+		groupingColSet := private.OutputCols.ToSet()
+		groupingColSet.Add(g.groupingSetCol)
+		input := b.factory.ConstructExpand(g.aggInScope.expr, &private)
+		return b.constructGroupBy(input, groupingColSet, aggCols, ordering)
+	}
+
+	// The grouping set column and the aggregates that are not NULL on empty
+	// input are produced by the aggregation with new column IDs, and are
+	// coalesced with their default values after the full join.
+	private.GroupingSetCol = md.AddColumn("grouping_set", types.Int)
+	groupingColSet := private.OutputCols.ToSet()
+	groupingColSet.Add(private.GroupingSetCol)
+	input := b.factory.ConstructExpand(g.aggInScope.expr, &private)
+
+	var passthrough opt.ColSet
+	passthrough.UnionWith(private.OutputCols.ToSet())
+	projections := make(memo.ProjectionsExpr, 0, len(aggCols)+1)
+	groupByCols := make([]scopeColumn, len(aggCols))
+	copy(groupByCols, aggCols)
+	newIDs := make(map[opt.ColumnID]opt.ColumnID)
+	for i := range groupByCols {
+		col := &groupByCols[i]
+		if newID, ok := newIDs[col.id]; ok {
+			col.id = newID
+			continue
+		}
+		aggOp := memo.ExtractAggFunc(col.scalar).Op()
+		if opt.AggregateIsNullOnEmpty(aggOp) {
+			passthrough.Add(col.id)
+			continue
+		}
+		if !opt.AggregateIsNeverNull(aggOp) {
+			// The value of the aggregate on empty input is not known.
+			panic(unimplemented.Newf("grouping sets",
+				"aggregate %s is not supported with empty grouping sets", col.name.MetadataName()))
+		}
+		newID := md.AddColumn(col.name.MetadataName(), col.typ)
+		projections = append(projections, b.factory.ConstructProjectionsItem(
+			b.factory.ConstructCoalesce(memo.ScalarListExpr{
+				b.factory.ConstructVariable(newID),
+				b.factory.ConstructConstVal(tree.NewDInt(0), types.Int),
+			}),
+			col.id,
+		))
+		newIDs[col.id] = newID
+		col.id = newID
+	}
+	groupBy := b.constructGroupBy(input, groupingColSet, groupByCols, ordering)
+
+	emptySetCol := md.AddColumn("grouping_set", types.Int)
+	rows := make(memo.ScalarListExpr, len(emptySets))
+	tupleTyp := types.MakeTuple([]*types.T{types.Int})
+	for i, set := range emptySets {
+		rows[i] = b.factory.ConstructTuple(
+			memo.ScalarListExpr{b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(set)), types.Int)},
+			tupleTyp,
+		)
+	}
+	values := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{emptySetCol},
+		ID:   md.NextUniqueID(),
+	})
+
+	join := b.factory.ConstructFullJoin(
+		groupBy,
+		values,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(b.factory.ConstructEq(
+			b.factory.ConstructVariable(private.GroupingSetCol),
+			b.factory.ConstructVariable(emptySetCol),
+		))},
+		memo.EmptyJoinPrivate,
+	)
+	projections = append(projections, b.factory.ConstructProjectionsItem(
+		b.factory.ConstructCoalesce(memo.ScalarListExpr{
+			b.factory.ConstructVariable(private.GroupingSetCol),
+			b.factory.ConstructVariable(emptySetCol),
+		}),
+		g.groupingSetCol,
+	))
+	return b.factory.ConstructProject(join, projections, passthrough)
+}
+
 // buildGroupingColumns builds the grouping columns and adds them to the
 // groupby scopes that will be used to build the aggregation expression.
 // Returns the slice of grouping columns.
@@ -335,6 +477,24 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
+
+	if g.groupingSets != nil {
+		// The grouping columns in the aggOutScope are the nullable copies
+		// produced by the Expand operator, so they need new column IDs. Any
+		// references to grouping expressions must resolve to the copies.
+		md := b.factory.Metadata()
+		outCols := g.aggOutScope.cols[len(g.aggOutScope.cols)-len(g.groupStrs):]
+		copies := make(map[opt.ColumnID]*scopeColumn, len(outCols))
+		for i := range outCols {
+			col := &outCols[i]
+			copies[col.id] = col
+			col.id = md.AddColumn(col.name.MetadataName(), col.typ)
+		}
+		for exprStr, col := range g.groupStrs {
+			g.groupStrs[exprStr] = copies[col.id]
+		}
+		g.groupingSetCol = md.AddColumn("grouping_set", types.Int)
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -353,6 +513,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplemented.New("grouping sets",
+				"ordered aggregates are not supported with GROUPING SETS, ROLLUP or CUBE"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -384,7 +548,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -429,12 +593,16 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSetsGroupBy(g, aggCols, g.aggInScope.ordering)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -446,6 +614,98 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	return g.aggOutScope
 }
 
+// maxGroupingFuncArgs is the maximum number of arguments to the GROUPING
+// function, so that the result fits in a 32-bit integer like in Postgres.
+const maxGroupingFuncArgs = 31
+
+// buildGroupingFunc builds a call to the GROUPING function. The result is a
+// bit mask in which bit i (counting from the least significant bit) is set if
+// the i-th argument from the right is not part of the grouping set of the
+// current row. Each argument must be a grouping expression of the aggregation
+// in inScope.
+//
+// The mask only depends on the grouping set of the row, so it is built as a
+// CASE expression on the grouping set column:
+//
+//	SELECT a, b, GROUPING(a, b) FROM t GROUP BY ROLLUP (a, b)
+//
+//	CASE grouping_set WHEN 0 THEN 0 WHEN 1 THEN 1 ELSE 3 END
+func (b *Builder) buildGroupingFunc(
+	f *tree.FuncExpr, inScope, outScope *scope, outCol *scopeColumn, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	if inScope.inAgg {
+		panic(pgerror.New(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations"))
+	}
+	g := inScope.groupby
+	if g == nil {
+		panic(pgerror.New(pgcode.Grouping,
+			"arguments to GROUPING must be grouping expressions of the associated query level"))
+	}
+	if g.buildingGroupingCols {
+		panic(pgerror.New(pgcode.Grouping, "grouping operations are not allowed in GROUP BY"))
+	}
+	if len(f.Exprs) > maxGroupingFuncArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingFuncArgs+1))
+	}
+
+	args := make(opt.ColList, len(f.Exprs))
+	for i, e := range f.Exprs {
+		col, ok := g.groupStrs[symbolicExprStr(e.(tree.TypedExpr))]
+		if !ok {
+			panic(pgerror.New(pgcode.Grouping,
+				"arguments to GROUPING must be grouping expressions of the associated query level"))
+		}
+		args[i] = col.id
+	}
+
+	if g.groupingSets == nil {
+		// Every grouping column is part of the only grouping set.
+		out := b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+		return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	}
+
+	inCols := g.groupingCols()
+	outCols := g.aggOutScope.cols[len(g.aggOutScope.cols)-len(inCols):]
+	inList := make(opt.ColList, len(inCols))
+	outList := make(opt.ColList, len(outCols))
+	for i := range inCols {
+		inList[i] = inCols[i].id
+		outList[i] = outCols[i].id
+	}
+	masks := make([]int64, len(g.groupingSets))
+	for i, set := range g.groupingSets {
+		set = opt.TranslateColSet(set, inList, outList)
+		for j, col := range args {
+			if !set.Contains(col) {
+				masks[i] |= 1 << (len(args) - 1 - j)
+			}
+		}
+	}
+
+	last := len(masks) - 1
+	whens := make(memo.ScalarListExpr, 0, last)
+	for i := 0; i < last; i++ {
+		if masks[i] == masks[last] {
+			continue
+		}
+		whens = append(whens, b.factory.ConstructWhen(
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int),
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(masks[i])), types.Int),
+		))
+	}
+	orElse := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(masks[last])), types.Int)
+	var out opt.ScalarExpr = orElse
+	if len(whens) > 0 {
+		out = b.factory.ConstructCase(b.factory.ConstructVariable(g.groupingSetCol), whens, orElse)
+		if colRefs != nil {
+			colRefs.Add(g.groupingSetCol)
+		}
+	}
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
 // analyzeHaving analyzes the having clause and returns it as a typed
 // expression. fromScope contains the name bindings that are visible for this
 // HAVING clause (e.g., passed in from an enclosing statement).
@@ -508,15 +768,125 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+	hasGroupingSets := false
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			hasGroupingSets = true
+			break
+		}
+	}
+	if !hasGroupingSets {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
+		g.buildingGroupingCols = false
+		return
+	}
+
+	// The grouping sets of the GROUP BY clause are the cross product of the
+	// grouping sets of each of its items. For example:
+	//   GROUP BY a, ROLLUP (b, c)
+	// is equivalent to:
+	//   GROUP BY GROUPING SETS ((a, b, c), (a, b), (a))
+	sets := []opt.ColSet{{}}
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		itemSets := b.buildGroupingSets(e, selects, projectionsScope, fromScope, g.aggInScope)
+		product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+		for _, left := range sets {
+			for _, right := range itemSets {
+				product = append(product, left.Union(right))
+			}
+		}
+		if len(product) > maxGroupingSets {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"too many grouping sets present (maximum %d)", maxGroupingSets))
+		}
+		sets = product
 	}
+	g.groupingSets = sets
 	g.buildingGroupingCols = false
 }
 
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to. It matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. It matches the
+// limit in Postgres.
+const maxCubeElements = 12
+
+// buildGroupingSets builds the grouping columns for an item of a GROUP BY
+// clause that contains GROUPING SETS, ROLLUP or CUBE, and returns the grouping
+// sets of the item. Each grouping set contains the IDs of the grouping columns
+// in aggInScope that are part of the set. A plain expression has a single
+// grouping set.
+//
+// See buildGrouping for a description of the parameters.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
+) []opt.ColSet {
+	gs, ok := groupBy.(*tree.GroupingSet)
+	if !ok {
+		return []opt.ColSet{b.buildGrouping(groupBy, selects, projectionsScope, fromScope, aggInScope)}
+	}
+
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (e1, e2, ..., en) is equivalent to:
+		//   GROUPING SETS ((e1, e2, ..., en), ..., (e1, e2), (e1), ())
+		sets := make([]opt.ColSet, len(gs.Exprs)+1)
+		for i, e := range gs.Exprs {
+			sets[i+1] = sets[i].Union(b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope))
+		}
+		for i, j := 0, len(sets)-1; i < j; i, j = i+1, j-1 {
+			sets[i], sets[j] = sets[j], sets[i]
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (e1, e2, ..., en) is equivalent to the grouping sets formed by
+		// all of the subsets of {e1, e2, ..., en}.
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+		}
+		sets := make([]opt.ColSet, 0, 1<<len(elems))
+		for mask := (1 << len(elems)) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<i) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.ListGroupingSet:
+		// Nested grouping sets are flattened.
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			sets = append(sets, b.buildGroupingSets(e, selects, projectionsScope, fromScope, aggInScope)...)
+			if len(sets) > maxGroupingSets {
+				panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"too many grouping sets present (maximum %d)", maxGroupingSets))
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %d", gs.Type))
+	}
+}
+
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the IDs of the grouping columns in
+// aggInScope that correspond to the expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -536,7 +906,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -634,7 +1004,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -644,7 +1015,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -697,11 +1070,12 @@ func (b *Builder) buildAggregateFunction(
 ) *aggregateInfo {
 	tempScopeColsBefore := len(tempScope.cols)
 
+	args := getTypedAggArgs(f, def)
 	info := aggregateInfo{
 		FuncExpr: f,
 		def:      *def,
 		distinct: (f.Type == tree.DistinctFuncType),
-		args:     make(memo.ScalarListExpr, len(f.Exprs)),
+		args:     make(memo.ScalarListExpr, len(args)),
 	}
 
 	// Temporarily set b.subquery to nil so we don't add outer columns to the
@@ -710,8 +1084,8 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
-		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
+	for i, pexpr := range args {
+		info.args[i] = b.buildAggArg(pexpr, &info, tempScope, fromScope)
 	}
 
 	// If we have a filter, add it to tempScope after all the arguments. We'll
@@ -762,8 +1136,10 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -787,12 +1163,17 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if isUserDefinedAggregate(def) {
+		return b.constructUserDefinedAgg(def, args[0])
+	}
+	switch def.Name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
 	case "avg":
@@ -879,7 +1260,52 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructJsonbObjectAgg(args[0], args[1])
 	}
 
-	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
+	panic(errors.AssertionFailedf("unhandled aggregate: %s", def.Name))
+}
+
+// constructUserDefinedAgg constructs a UserDefinedAgg operator for the
+// user-defined aggregate with the given definition. The arguments of the
+// aggregate are given as a single tuple. The transition and final functions of
+// the aggregate are built as UDFs without input, which are invoked directly
+// with the state and the arguments during execution.
+func (b *Builder) constructUserDefinedAgg(
+	def *memo.FunctionPrivate, input opt.ScalarExpr,
+) opt.ScalarExpr {
+	agg := def.Overload.Aggregate
+	private := memo.UserDefinedAggPrivate{
+		Name:       def.Name,
+		Typ:        def.Overload.FixedReturnType(),
+		Transition: b.buildAggregateSupportFunction(agg.TransitionFunc),
+		Final:      memo.NullSingleton,
+		InitCond:   tree.DNull,
+	}
+	if agg.FinalFunc != 0 {
+		private.Final = b.buildAggregateSupportFunction(agg.FinalFunc)
+	}
+	if agg.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(agg.StateType, *agg.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		private.InitCond = d
+	}
+	return b.factory.ConstructUserDefinedAgg(input, &private)
+}
+
+// buildAggregateSupportFunction builds a UDF expression without input for the
+// support function of a user-defined aggregate with the given OID.
+func (b *Builder) buildAggregateSupportFunction(fnOid oid.Oid) opt.ScalarExpr {
+	name, o, err := b.semaCtx.FunctionResolver.ResolveFunctionByOID(b.ctx, fnOid)
+	if err != nil {
+		panic(err)
+	}
+	return b.buildRoutine(name, o, o.FixedReturnType(), nil /* input */)
+}
+
+// isUserDefinedAggregate returns true if the given function is an aggregate
+// created with CREATE AGGREGATE.
+func isUserDefinedAggregate(def *memo.FunctionPrivate) bool {
+	return def.Overload != nil && def.Overload.Aggregate != nil
 }
 
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
@@ -913,7 +1339,13 @@ func newGroupingError(name tree.Name) error {
 // the groupby metadata indicates that we are grouping on the entire PK of that
 // table. In that case, we can allow col as an "implicit" grouping column, even
 // if it is not specified in the query.
+// Implicit grouping columns are not allowed with grouping sets.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The grouping columns may be NULL in the output of a query with
+		// grouping sets, so the PK does not determine the other columns.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -213,6 +213,11 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		}
 	}
 
+	if ins.OnConflict != nil && tab.TriggerCount() > 0 {
+		panic(unimplemented.NewWithIssue(28296,
+			"UPSERT and INSERT ... ON CONFLICT are not supported on tables with triggers"))
+	}
+
 	// Check if this table has already been mutated in another subquery.
 	b.checkMultipleMutations(tab, ins.OnConflict == nil /* simpleInsert */)
 
@@ -660,6 +665,10 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Apply any BEFORE INSERT triggers to the new rows, before computed columns
+	// are derived from them.
+	mb.buildBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -682,8 +691,12 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForInsert()
 
+	mb.buildExclusionChecksForInsert()
+
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -699,6 +712,7 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 	// Determine the set of arbiter indexes and constraints to use to check for
 	// conflicts.
 	mb.arbiters = mb.findArbiters(onConflict)
+	mb.checkArbiterIsolation()
 	insertColScope := mb.outScope.replace()
 	insertColScope.appendColumnsFromScope(mb.outScope)
 
@@ -745,6 +759,7 @@ func (mb *mutationBuilder) buildInputForUpsert(
 	// Determine the set of arbiter indexes and constraints to use to check for
 	// conflicts.
 	mb.arbiters = mb.findArbiters(onConflict)
+	mb.checkArbiterIsolation()
 	// TODO(mgartner): Add support for multiple arbiter indexes or constraints,
 	//  similar to buildInputForDoNothing.
 	if mb.arbiters.Len() > 1 {
@@ -905,6 +920,8 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpsert()
 
+	mb.buildExclusionChecksForInsert()
+
 	mb.buildFKChecksForUpsert()
 
 	private := mb.makeMutationPrivate(returning != nil)
@@ -11,8 +11,10 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 // lockingSpec maintains a collection of FOR [KEY] UPDATE/SHARE items that apply
@@ -65,6 +67,34 @@ type lockingSpec []*tree.LockingItem
 // noRowLocking indicates that no row-level locking has been specified.
 var noRowLocking lockingSpec
 
+// constraintCheckLocking returns the row-level locking used by the scans of FK
+// checks and FK cascades. Under READ COMMITTED isolation transactions commit
+// above their read timestamp without refreshing their reads, so the rows read
+// by these scans are locked with the given strength to prevent concurrent
+// transactions from invalidating them before the mutation commits.
+// Serializable transactions refresh their reads instead, so no locking is
+// needed.
+func (b *Builder) constraintCheckLocking(strength tree.LockingStrength) lockingSpec {
+	if b.evalCtx.TxnIsoLevel() != isolation.ReadCommitted {
+		return noRowLocking
+	}
+	return lockingSpec{&tree.LockingItem{Strength: strength}}
+}
+
+// checkConstraintCheckIsolation panics if the transaction runs under READ
+// COMMITTED isolation. It is called for constraints that are enforced by
+// checking that no conflicting row exists (UNIQUE WITHOUT INDEX and exclusion
+// constraints). Locking the rows read by such a check cannot prevent a
+// concurrent transaction from inserting a conflicting row, so these
+// constraints are only enforced under SERIALIZABLE isolation.
+func (b *Builder) checkConstraintCheckIsolation(kind, name string) {
+	if b.evalCtx.TxnIsoLevel() == isolation.ReadCommitted {
+		panic(unimplemented.Newf("read committed constraint checks",
+			"%s constraint %q cannot be enforced under READ COMMITTED isolation", kind, name,
+		))
+	}
+}
+
 // isSet returns whether the spec contains any row-level locking modes.
 func (lm lockingSpec) isSet() bool {
 	return len(lm) != 0
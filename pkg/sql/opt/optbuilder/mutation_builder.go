@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -802,7 +803,10 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 			// the check columns if the columns referenced in the check
 			// expression are being mutated.
 			if !isUpdate || referencedCols.Intersects(mutationCols) {
-				mb.checkColIDs[i] = scopeCol.id
+				check := mb.tab.Check(i)
+				if !mb.deferConstraintCheck(mb.tab.ID(), check.Name, check.Deferrability, check.Validated) {
+					mb.checkColIDs[i] = scopeCol.id
+				}
 			}
 		}
 
@@ -811,6 +815,29 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 	}
 }
 
+// deferConstraintCheck returns true if the check of the given constraint should
+// be deferred until the transaction commits, in which case the constraint is
+// recorded in the transaction's deferred constraints and the caller must not
+// plan a check for it. tabID is the table on which the constraint must be
+// validated. Unvalidated constraints are never deferred, since the validation
+// at commit time checks all rows of the table.
+func (mb *mutationBuilder) deferConstraintCheck(
+	tabID cat.StableID, name string, deferrability tree.ConstraintDeferrability, validated bool,
+) bool {
+	if deferrability == tree.ConstraintNotDeferrable || !validated {
+		return false
+	}
+	// Whether the check is deferred depends on the state of the transaction, so
+	// the memo cannot be reused.
+	mb.b.DisableMemoReuse = true
+	deferred := mb.b.evalCtx.DeferredConstraints
+	if !deferred.IsDeferred(name, deferrability) {
+		return false
+	}
+	deferred.Defer(catid.DescID(tabID), name)
+	return true
+}
+
 // mutationColumnIDs returns the set of all column IDs that will be mutated.
 func (mb *mutationBuilder) mutationColumnIDs() opt.ColSet {
 	cols := opt.ColSet{}
@@ -0,0 +1,294 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/errors"
+)
+
+// buildExclusionChecksForInsert builds the check queries that enforce the
+// exclusion constraints of the table for an insert or an upsert.
+func (mb *mutationBuilder) buildExclusionChecksForInsert() {
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		if mb.deferExclusionCheck(i) {
+			continue
+		}
+		var h exclusionCheckHelper
+		if h.init(mb, i) {
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+}
+
+// buildExclusionChecksForUpdate builds the check queries that enforce the
+// exclusion constraints of the table for an update. Only the constraints that
+// reference updated columns are checked.
+func (mb *mutationBuilder) buildExclusionChecksForUpdate() {
+	if mb.tab.ExclusionConstraintCount() == 0 {
+		return
+	}
+
+	mb.ensureWithID()
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		if !mb.exclusionColsUpdated(i) {
+			continue
+		}
+		if mb.deferExclusionCheck(i) {
+			continue
+		}
+		var h exclusionCheckHelper
+		if h.init(mb, i) {
+			// Like for unique constraints, the insertion check works for updates
+			// too since it checks the updated rows against all rows of the table.
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+}
+
+// deferExclusionCheck returns true if the check of the given exclusion
+// constraint should be deferred until the transaction commits. See
+// deferConstraintCheck.
+func (mb *mutationBuilder) deferExclusionCheck(ord int) bool {
+	c := mb.tab.ExclusionConstraint(ord)
+	return mb.deferConstraintCheck(mb.tab.ID(), c.Name, c.Deferrability, c.Validated)
+}
+
+// exclusionColsUpdated returns true if any of the columns of an exclusion
+// constraint are being updated (according to updateColIDs). When the
+// constraint has a partial predicate, it also returns true if the predicate
+// references any of the columns being updated.
+func (mb *mutationBuilder) exclusionColsUpdated(ord int) bool {
+	c := mb.tab.ExclusionConstraint(ord)
+	for _, colOrd := range c.ColumnOrdinals {
+		if mb.updateColIDs[colOrd] != 0 {
+			return true
+		}
+	}
+
+	if c.Predicate != "" {
+		pred := mb.parseExclusionConstraintPredicateExpr(ord)
+		typedPred := mb.fetchScope.resolveAndRequireType(pred, types.Bool)
+
+		var predCols opt.ColSet
+		mb.b.buildScalar(typedPred, mb.fetchScope, nil, nil, &predCols)
+		for colID, ok := predCols.Next(0); ok; colID, ok = predCols.Next(colID + 1) {
+			colOrd := mb.md.ColumnMeta(colID).Table.ColumnOrdinal(colID)
+			if mb.updateColIDs[colOrd] != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseExclusionConstraintPredicateExpr parses the predicate of the given
+// partial exclusion constraint.
+func (mb *mutationBuilder) parseExclusionConstraintPredicateExpr(ord int) tree.Expr {
+	c := mb.tab.ExclusionConstraint(ord)
+	if c.Predicate == "" {
+		panic(errors.AssertionFailedf(
+			"exclusion constraint at ordinal %d is not a partial exclusion constraint", ord))
+	}
+	expr, err := parser.ParseExpr(c.Predicate)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// exclusionCheckHelper is a type associated with a single exclusion constraint
+// and is used to build its check query, which is a semi-join between the new
+// rows and the rows of the table.
+type exclusionCheckHelper struct {
+	mb *mutationBuilder
+
+	constraint cat.ExclusionConstraint
+	ordinal    int
+
+	// primaryKeyOrdinals includes the ordinals of the primary key columns that
+	// are not compared with the equality operator by the constraint.
+	primaryKeyOrdinals util.FastIntSet
+
+	// The scope and column ordinals of the scan that will serve as the right
+	// side of the semi join.
+	scanScope    *scope
+	scanOrdinals []int
+}
+
+// init initializes the helper with an exclusion constraint.
+//
+// Returns false if the constraint should be ignored (e.g. because the new
+// values for the constraint columns are known to be always NULL).
+func (h *exclusionCheckHelper) init(mb *mutationBuilder, ord int) bool {
+	*h = exclusionCheckHelper{
+		mb:         mb,
+		constraint: mb.tab.ExclusionConstraint(ord),
+		ordinal:    ord,
+	}
+
+	// Two different rows always have different primary keys, so they cannot
+	// conflict if the constraint compares all primary key columns with the
+	// equality operator.
+	var eqOrds util.FastIntSet
+	for i, colOrd := range h.constraint.ColumnOrdinals {
+		if h.constraint.Operators[i].Symbol == treecmp.EQ {
+			eqOrds.Add(colOrd)
+		}
+	}
+	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+	primaryOrds.DifferenceWith(eqOrds)
+	if primaryOrds.Empty() {
+		return false
+	}
+	h.primaryKeyOrdinals = primaryOrds
+
+	// A NULL value never conflicts with another value, so a check is not needed
+	// if one of the constraint columns is always set to NULL.
+	for _, colOrd := range h.constraint.ColumnOrdinals {
+		if memo.OutputColumnIsAlwaysNull(mb.outScope.expr, mb.mapToReturnColID(colOrd)) {
+			return false
+		}
+	}
+
+	mb.b.checkConstraintCheckIsolation("exclusion", h.constraint.Name)
+	h.scanScope, h.scanOrdinals = h.buildTableScan()
+	return true
+}
+
+// buildInsertionCheck creates an exclusion check for rows which are added to
+// a table. The input to the insertion check will be produced from the input to
+// the mutation operator.
+func (h *exclusionCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
+	f := h.mb.b.factory
+
+	// Build a self semi-join, with the new values on the left and the
+	// existing values on the right.
+	withScanScope, _ := h.mb.buildCheckInputScan(
+		checkInputScanNewVals, h.scanOrdinals, false, /* isFK */
+	)
+
+	// Build the join filters, comparing the new and existing values of each
+	// column with the operator of the constraint:
+	//   (new_a = existing_a) AND (new_b && existing_b) AND ...
+	numFilters := len(h.constraint.ColumnOrdinals) + 1
+	if h.constraint.Predicate != "" {
+		numFilters += 2
+	}
+	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
+	for i, colOrd := range h.constraint.ColumnOrdinals {
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+			h.constructComparison(
+				h.constraint.Operators[i],
+				f.ConstructVariable(withScanScope.cols[colOrd].id),
+				f.ConstructVariable(h.scanScope.cols[colOrd].id),
+				withScanScope.cols[colOrd].typ,
+			),
+		))
+	}
+
+	// If the constraint is partial, filter out both the new rows and the
+	// existing rows that don't satisfy the predicate.
+	if h.constraint.Predicate != "" {
+		pred := h.mb.parseExclusionConstraintPredicateExpr(h.ordinal)
+
+		typedPred := withScanScope.resolveAndRequireType(pred, types.Bool)
+		withScanPred := h.mb.b.buildScalar(typedPred, withScanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(withScanPred))
+
+		typedPred = h.scanScope.resolveAndRequireType(pred, types.Bool)
+		scanPred := h.mb.b.buildScalar(typedPred, h.scanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(scanPred))
+	}
+
+	// Prevent rows from matching themselves in the semi join:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	for i, ok := h.primaryKeyOrdinals.Next(0); ok; i, ok = h.primaryKeyOrdinals.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(withScanScope.cols[i].id),
+			f.ConstructVariable(h.scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+
+	semiJoin := f.ConstructSemiJoin(withScanScope.expr, h.scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate)
+
+	// Collect the key columns that will be shown in the error message if there
+	// is a violation resulting from this check.
+	keyCols := make(opt.ColList, len(h.constraint.ColumnOrdinals))
+	for i, colOrd := range h.constraint.ColumnOrdinals {
+		keyCols[i] = withScanScope.cols[colOrd].id
+	}
+	project := f.ConstructProject(semiJoin, nil /* projections */, keyCols.ToSet())
+
+	return f.ConstructUniqueChecksItem(project, &memo.UniqueChecksItemPrivate{
+		Table:        h.mb.tabID,
+		CheckOrdinal: h.ordinal,
+		KeyCols:      keyCols,
+		OpName:       h.mb.opName,
+		Exclusion:    true,
+	})
+}
+
+// constructComparison builds the comparison between the new and the existing
+// values of a column of the constraint.
+func (h *exclusionCheckHelper) constructComparison(
+	op treecmp.ComparisonOperator, left, right opt.ScalarExpr, typ *types.T,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	switch op.Symbol {
+	case treecmp.EQ:
+		return f.ConstructEq(left, right)
+	case treecmp.NE:
+		return f.ConstructNe(left, right)
+	case treecmp.Overlaps:
+		if fam := typ.Family(); fam == types.GeometryFamily || fam == types.Box2DFamily {
+			// The && operator means "intersects" when used with geometry or bounding
+			// box operands.
+			return f.ConstructBBoxIntersects(left, right)
+		}
+		return f.ConstructOverlaps(left, right)
+	}
+	panic(errors.AssertionFailedf("unexpected exclusion constraint operator %s", op))
+}
+
+// buildTableScan builds a Scan of the table. The ordinals of the columns
+// scanned are also returned.
+func (h *exclusionCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
+	tabMeta := h.mb.b.addTable(h.mb.tab, tree.NewUnqualifiedTableName(h.mb.tab.Name()))
+	ordinals = tableOrdinals(tabMeta.Table, columnKinds{
+		includeMutations: false,
+		includeSystem:    false,
+		includeInverted:  false,
+	})
+	return h.mb.b.buildScan(
+		tabMeta,
+		ordinals,
+		&tree.IndexFlags{IgnoreUniqueWithoutIndexKeys: true},
+		noRowLocking,
+		h.mb.b.allocScope(),
+		true, /* disableNotVisibleIndex */
+	), ordinals
+}
@@ -73,7 +73,7 @@ func (mb *mutationBuilder) buildFKChecksForInsert() {
 
 	h := &mb.fkCheckHelper
 	for i, n := 0, mb.tab.OutboundForeignKeyCount(); i < n; i++ {
-		if h.initWithOutboundFK(mb, i) {
+		if h.initWithOutboundFK(mb, i) && !mb.deferFKCheck(h.fk) {
 			mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 		}
 	}
@@ -163,6 +163,11 @@ func (mb *mutationBuilder) buildFKChecksAndCascadesForDelete() {
 			})
 			continue
 		}
+		// Only NO ACTION checks can be deferred; RESTRICT is always checked
+		// immediately.
+		if h.fk.DeleteReferenceAction() == tree.NoAction && mb.deferFKCheck(h.fk) {
+			continue
+		}
 
 		withScanScope, _ := mb.buildCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals, true /* isFK */)
 		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(withScanScope.expr, withScanScope.colList()))
@@ -258,7 +263,7 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 	for i, n := 0, mb.tab.OutboundForeignKeyCount(); i < n; i++ {
 		// Verify that at least one FK column is actually updated.
 		if mb.outboundFKColsUpdated(i) {
-			if h.initWithOutboundFK(mb, i) {
+			if h.initWithOutboundFK(mb, i) && !mb.deferFKCheck(h.fk) {
 				mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 			}
 		}
@@ -302,6 +307,9 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && mb.deferFKCheck(h.fk) {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old"
 		// FK values and "new" FK values.
@@ -378,7 +386,7 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 
 	h := &mb.fkCheckHelper
 	for i := 0; i < numOutbound; i++ {
-		if h.initWithOutboundFK(mb, i) {
+		if h.initWithOutboundFK(mb, i) && !mb.deferFKCheck(h.fk) {
 			mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 		}
 	}
@@ -423,6 +431,9 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && mb.deferFKCheck(h.fk) {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old" FK
 		// values and "new" FK values. See buildFKChecksForUpdate for more details.
@@ -454,6 +465,12 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 	telemetry.Inc(sqltelemetry.ForeignKeyChecksUseCounter)
 }
 
+// deferFKCheck returns true if the check of the given foreign key should be
+// deferred until the transaction commits. See deferConstraintCheck.
+func (mb *mutationBuilder) deferFKCheck(fk cat.ForeignKeyConstraint) bool {
+	return mb.deferConstraintCheck(fk.OriginTableID(), fk.Name(), fk.Deferrability(), fk.Validated())
+}
+
 // outboundFKColsUpdated returns true if any of the FK columns for an outbound
 // constraint are being updated (according to updateColIDs).
 func (mb *mutationBuilder) outboundFKColsUpdated(fkOrdinal int) bool {
@@ -618,7 +635,7 @@ func (h *fkCheckHelper) buildOtherTableScan() (outScope *scope, tabMeta *opt.Tab
 		otherTabMeta,
 		h.otherTabOrdinals,
 		&tree.IndexFlags{IgnoreForeignKeys: true},
-		noRowLocking,
+		h.mb.b.constraintCheckLocking(tree.ForShare),
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	), otherTabMeta
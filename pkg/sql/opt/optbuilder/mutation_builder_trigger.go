@@ -0,0 +1,369 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// buildBeforeTriggers wraps the mutation input with the BEFORE row triggers
+// on the target table that are activated by the given event. The trigger
+// functions are invoked for each row, in order of trigger name, with the new
+// and old values of the row. Rows for which a trigger function returns NULL
+// are skipped. For INSERT and UPDATE, the new values of the remaining rows are
+// replaced with the row returned by the function. For example:
+//
+//	CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()
+//	INSERT INTO t VALUES (1, 2)
+//
+// is built as:
+//
+//	project
+//	 ├── columns: a_new:6 b_new:7
+//	 ├── select
+//	 │    ├── project
+//	 │    │    ├── columns: tr:5 column1:3 column2:4
+//	 │    │    ├── values
+//	 │    │    │    └── (1, 2)
+//	 │    │    └── projections
+//	 │    │         └── udf: f [as=tr:5]
+//	 │    └── filters
+//	 │         └── tr:5 IS DISTINCT FROM NULL
+//	 └── projections
+//	      ├── (tr:5).a [as=a_new:6]
+//	      └── (tr:5).b [as=b_new:7]
+//
+// Computed columns are not passed to BEFORE triggers, and cannot be set by
+// them; they are computed from the values returned by the trigger functions.
+func (mb *mutationBuilder) buildBeforeTriggers(event tree.TriggerEventType) {
+	triggers := activeTriggers(mb.tab, tree.TriggerActionTimeBefore, event)
+	if len(triggers) == 0 {
+		return
+	}
+	// The plan depends on the bodies of the trigger functions, which are not
+	// tracked as dependencies of the memo.
+	mb.b.DisableMemoReuse = true
+
+	ords := triggerRecordOrdinals(mb.tab)
+	var newColIDs opt.OptionalColList
+	switch event {
+	case tree.TriggerEventInsert:
+		newColIDs = mb.insertColIDs
+	case tree.TriggerEventUpdate:
+		newColIDs = mb.updateColIDs
+	}
+
+	for i := range triggers {
+		var newVals, oldVals memo.ScalarListExpr
+		if event != tree.TriggerEventDelete {
+			newVals = mb.triggerRecordVals(ords, func(ord int) opt.ColumnID {
+				if mb.tab.Column(ord).IsComputed() {
+					return 0
+				}
+				return mb.mapToReturnColID(ord)
+			})
+		}
+		if event != tree.TriggerEventInsert {
+			oldVals = mb.triggerRecordVals(ords, func(ord int) opt.ColumnID {
+				return mb.fetchColIDs[ord]
+			})
+		}
+		fn := mb.b.buildTriggerFunction(mb.tab, &triggers[i], event, newVals, oldVals)
+
+		// Project the row returned by the trigger function.
+		projectionScope := mb.outScope.replace()
+		projectionScope.appendColumnsFromScope(mb.outScope)
+		resName := scopeColName("").WithMetadataName(string(triggers[i].Name))
+		res := mb.b.synthesizeColumn(projectionScope, resName, fn.DataType(), nil /* expr */, fn)
+		mb.b.constructProjectForScope(mb.outScope, projectionScope)
+		mb.outScope = projectionScope
+
+		// Skip the rows for which the trigger function returned NULL.
+		resVar := mb.b.factory.ConstructVariable(res.id)
+		mb.outScope.expr = mb.b.factory.ConstructSelect(mb.outScope.expr, memo.FiltersExpr{
+			mb.b.factory.ConstructFiltersItem(
+				mb.b.factory.ConstructIsNot(resVar, memo.NullSingleton),
+			),
+		})
+		if event == tree.TriggerEventDelete {
+			continue
+		}
+
+		// Replace the new values of the row with the fields of the returned row.
+		projectionScope = mb.outScope.replace()
+		projectionScope.appendColumnsFromScope(mb.outScope)
+		for j, ord := range ords {
+			col := mb.tab.Column(ord)
+			if col.IsComputed() {
+				continue
+			}
+			field := mb.b.factory.ConstructColumnAccess(resVar, memo.TupleOrdinal(j))
+			newCol := mb.b.synthesizeColumn(
+				projectionScope, scopeColName(col.ColName()), col.DatumType(), nil /* expr */, field,
+			)
+			newColIDs[ord] = newCol.id
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionScope)
+		mb.outScope = projectionScope
+		mb.disambiguateColumns()
+	}
+}
+
+// buildAfterTriggers plans the AFTER row triggers on the target table that are
+// activated by the given event. Like FK cascades, the trigger functions are
+// invoked by queries that run after the mutation, and read the new and old
+// values of the mutated rows from its buffered input.
+func (mb *mutationBuilder) buildAfterTriggers(event tree.TriggerEventType) {
+	triggers := activeTriggers(mb.tab, tree.TriggerActionTimeAfter, event)
+	if len(triggers) == 0 {
+		return
+	}
+	// The plan depends on the bodies of the trigger functions, which are not
+	// tracked as dependencies of the memo.
+	mb.b.DisableMemoReuse = true
+	mb.ensureWithID()
+
+	ords := triggerRecordOrdinals(mb.tab)
+	var newValues, oldValues opt.ColList
+	for _, ord := range ords {
+		if event != tree.TriggerEventDelete {
+			newValues = append(newValues, mb.mapToReturnColID(ord))
+		}
+		if event != tree.TriggerEventInsert {
+			oldValues = append(oldValues, mb.fetchColIDs[ord])
+		}
+	}
+	for i := range triggers {
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName: string(triggers[i].Name),
+			Builder: &afterTriggerBuilder{
+				table:   mb.tab,
+				trigger: triggers[i],
+				event:   event,
+			},
+			WithID:    mb.withID,
+			OldValues: oldValues,
+			NewValues: newValues,
+		})
+	}
+}
+
+// triggerRecordVals returns a scalar for each of the given table ordinals that
+// references the column returned by colID, or NULL if colID returns 0.
+func (mb *mutationBuilder) triggerRecordVals(
+	ords []int, colID func(ord int) opt.ColumnID,
+) memo.ScalarListExpr {
+	vals := make(memo.ScalarListExpr, len(ords))
+	for i, ord := range ords {
+		if id := colID(ord); id != 0 {
+			vals[i] = mb.b.factory.ConstructVariable(id)
+		} else {
+			vals[i] = mb.b.factory.ConstructNull(mb.tab.Column(ord).DatumType())
+		}
+	}
+	return vals
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder that builds the query invoking
+// the function of an AFTER row trigger for each mutated row.
+type afterTriggerBuilder struct {
+	table   cat.Table
+	trigger cat.Trigger
+	event   tree.TriggerEventType
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (memo.RelExpr, error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		md := b.factory.Metadata()
+		inCols := make(opt.ColList, 0, len(newValues)+len(oldValues))
+		inCols = append(append(inCols, newValues...), oldValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range outCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+
+		// Construct a dummy operator as the binding.
+		md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		input := b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		var newVals, oldVals memo.ScalarListExpr
+		for i, col := range outCols {
+			if i < len(newValues) {
+				newVals = append(newVals, b.factory.ConstructVariable(col))
+			} else {
+				oldVals = append(oldVals, b.factory.ConstructVariable(col))
+			}
+		}
+		fn := b.buildTriggerFunction(tb.table, &tb.trigger, tb.event, newVals, oldVals)
+		projectionScope := b.allocScope()
+		resName := scopeColName("").WithMetadataName(string(tb.trigger.Name))
+		b.synthesizeColumn(projectionScope, resName, fn.DataType(), nil /* expr */, fn)
+		return b.constructProject(input, projectionScope.cols)
+	})
+}
+
+// buildTriggerFunction builds an invocation of the function of the given
+// trigger on the given table. newVals and oldVals are the fields of the NEW
+// and OLD records passed to the function; if either is empty, the record is
+// NULL. The function returns a record of the same type.
+//
+// Within the function body, the fields of the records are variables that are
+// referenced as NEW.<column> and OLD.<column>. The function can also reference
+// the special variables TG_NAME, TG_WHEN, TG_OP, TG_TABLE_NAME, and
+// TG_TABLE_SCHEMA, which describe the trigger and the event that fired it.
+func (b *Builder) buildTriggerFunction(
+	tab cat.Table,
+	trigger *cat.Trigger,
+	event tree.TriggerEventType,
+	newVals, oldVals memo.ScalarListExpr,
+) opt.ScalarExpr {
+	fnName, o, err := b.semaCtx.FunctionResolver.ResolveFunctionByOID(b.ctx, trigger.FuncOID)
+	if err != nil {
+		panic(err)
+	}
+	block, err := plpgsqlparser.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	tn, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+
+	ords := triggerRecordOrdinals(tab)
+	bodyScope := b.allocScope()
+	var input memo.ScalarListExpr
+	var argCols opt.ColList
+	addVar := func(record, name tree.Name, typ *types.T, val opt.ScalarExpr) {
+		colName := scopeColName(name)
+		if record != "" {
+			colName = colName.WithMetadataName(string(record) + "_" + string(name))
+		}
+		col := b.synthesizeColumn(bodyScope, colName, typ, nil /* expr */, nil /* scalar */)
+		if record != "" {
+			col.table = tree.MakeUnqualifiedTableName(record)
+		}
+		input = append(input, val)
+		argCols = append(argCols, col.id)
+	}
+	addRecord := func(record tree.Name, vals memo.ScalarListExpr) {
+		for i, ord := range ords {
+			col := tab.Column(ord)
+			var val opt.ScalarExpr
+			if len(vals) > 0 {
+				val = vals[i]
+			} else {
+				val = b.factory.ConstructNull(col.DatumType())
+			}
+			addVar(record, col.ColName(), col.DatumType(), val)
+		}
+	}
+	addString := func(name tree.Name, s string) {
+		addVar("" /* record */, name, types.String, b.factory.ConstructConstVal(tree.NewDString(s), types.String))
+	}
+	addRecord("new", newVals)
+	addRecord("old", oldVals)
+	addString("tg_name", string(trigger.Name))
+	addString("tg_when", trigger.ActionTime.String())
+	addString("tg_op", event.String())
+	addString("tg_table_name", string(tab.Name()))
+	addString("tg_table_schema", string(tn.SchemaName))
+
+	rtyp := triggerRecordType(tab, ords)
+	rels, varCols, program := b.buildPLpgSQLBody(block, bodyScope, rtyp, false /* isProcedure */)
+	argCols = append(argCols, varCols...)
+	return b.factory.ConstructUDF(
+		input,
+		&memo.UDFPrivate{
+			Name:       fnName,
+			ArgCols:    argCols,
+			Body:       rels,
+			Typ:        rtyp,
+			Volatility: o.Volatility,
+			// The function is invoked even if the NEW or OLD record is NULL.
+			CalledOnNullInput: true,
+			Program:           program,
+		},
+	)
+}
+
+// activeTriggers returns the triggers on the given table with the given action
+// time that are activated by the given event, in order of name.
+func activeTriggers(
+	tab cat.Table, actionTime tree.TriggerActionTime, event tree.TriggerEventType,
+) []cat.Trigger {
+	var triggers []cat.Trigger
+	for i, n := 0, tab.TriggerCount(); i < n; i++ {
+		if t := tab.Trigger(i); t.ActionTime == actionTime && t.Events.Contains(event) {
+			triggers = append(triggers, t)
+		}
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name < triggers[j].Name
+	})
+	return triggers
+}
+
+// triggerRecordOrdinals returns the ordinals of the columns of the given table
+// that are the fields of the NEW and OLD records passed to its trigger
+// functions.
+func triggerRecordOrdinals(tab cat.Table) []int {
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// triggerRecordType returns the type of the NEW and OLD records passed to the
+// trigger functions of the given table, given its record ordinals.
+func triggerRecordType(tab cat.Table, ords []int) *types.T {
+	typs := make([]*types.T, len(ords))
+	labels := make([]string, len(ords))
+	for i, ord := range ords {
+		typs[i] = tab.Column(ord).DatumType()
+		labels[i] = string(tab.Column(ord).ColName())
+	}
+	return types.MakeLabeledTuple(typs, labels)
+}
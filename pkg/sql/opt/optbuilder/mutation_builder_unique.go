@@ -56,6 +56,9 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 		if mb.uniqueConstraintIsArbiter(i) {
 			continue
 		}
+		if mb.deferUniqueCheck(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
 		}
@@ -86,6 +89,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 		if !mb.uniqueColsUpdated(i) {
 			continue
 		}
+		if mb.deferUniqueCheck(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for updates too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -125,6 +131,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 		if mb.uniqueConstraintIsArbiter(i) && !mb.uniqueColsUpdated(i) {
 			continue
 		}
+		if mb.deferUniqueCheck(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for upserts too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -136,6 +145,13 @@ func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 	telemetry.Inc(sqltelemetry.UniqueChecksUseCounter)
 }
 
+// deferUniqueCheck returns true if the check of the given unique constraint
+// should be deferred until the transaction commits. See deferConstraintCheck.
+func (mb *mutationBuilder) deferUniqueCheck(uniqueOrdinal cat.UniqueOrdinal) bool {
+	u := mb.tab.Unique(uniqueOrdinal)
+	return mb.deferConstraintCheck(mb.tab.ID(), u.Name(), u.Deferrability(), u.Validated())
+}
+
 // hasUniqueWithoutIndexConstraints returns true if there are any
 // UNIQUE WITHOUT INDEX constraints on the table.
 func (mb *mutationBuilder) hasUniqueWithoutIndexConstraints() bool {
@@ -296,7 +312,11 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueCols.Add(colID)
 	})
 	fds := &h.scanScope.expr.Relational().FuncDeps
-	return !fds.ColsAreLaxKey(uniqueCols)
+	if fds.ColsAreLaxKey(uniqueCols) {
+		return false
+	}
+	mb.b.checkConstraintCheckIsolation("unique", h.unique.Name())
+	return true
 }
 
 // buildInsertionCheck creates a unique check for rows which are added to a
@@ -0,0 +1,504 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+)
+
+// plpgsqlBuilder builds the body of a PL/pgSQL function into a
+// tree.RoutineProgram. Each expression and SQL statement in the body is built
+// into a separate relational expression, which is referenced by the program by
+// its index. The variables of the function, including its arguments, are
+// represented by columns that are replaced by their current values when the
+// statements are planned during execution.
+type plpgsqlBuilder struct {
+	ob *Builder
+
+	// returnType is the return type of the function.
+	returnType *types.T
+
+	// isProcedure is true if the routine is a procedure, which can commit or
+	// roll back its transaction.
+	isProcedure bool
+
+	// vars contains a column for each variable slot. The i-th column
+	// corresponds to the i-th slot of the program.
+	vars []scopeColumn
+
+	// constants contains the slots of variables declared CONSTANT.
+	constants util.FastIntSet
+
+	// visible contains the slots of the variables that are currently in scope,
+	// in order of declaration. If there are multiple variables with the same
+	// name, the last one shadows the others.
+	visible []int
+
+	// labels is a stack of the blocks and loops enclosing the statement that
+	// is currently being built.
+	labels []plpgsqlLabel
+
+	// stmts contains an expression for each statement referenced by the
+	// program.
+	stmts memo.RelListExpr
+}
+
+// plpgsqlLabel describes a block or loop that encloses a statement.
+type plpgsqlLabel struct {
+	name   string
+	isLoop bool
+}
+
+// buildPLpgSQLBody builds the given PL/pgSQL block of a function, or of a
+// procedure if isProcedure is true. bodyScope must contain a column for each
+// argument of the function. It returns the statements
+// referenced by the program, the columns representing the variables declared
+// in the body, and the program.
+func (b *Builder) buildPLpgSQLBody(
+	block *plpgsqltree.Block, bodyScope *scope, returnType *types.T, isProcedure bool,
+) (memo.RelListExpr, opt.ColList, *tree.RoutineProgram) {
+	pb := plpgsqlBuilder{
+		ob:          b,
+		returnType:  returnType,
+		isProcedure: isProcedure,
+		vars:        append([]scopeColumn(nil), bodyScope.cols...),
+	}
+	for i := range pb.vars {
+		pb.visible = append(pb.visible, i)
+	}
+	numArgs := len(pb.vars)
+
+	body := pb.buildBlock(block)
+
+	program := &tree.RoutineProgram{
+		VarNames: make([]tree.Name, len(pb.vars)),
+		VarTypes: make([]*types.T, len(pb.vars)),
+		Body:     body,
+	}
+	var varCols opt.ColList
+	for i := range pb.vars {
+		program.VarNames[i] = pb.vars[i].name.ReferenceName()
+		program.VarTypes[i] = pb.vars[i].typ
+		if i >= numArgs {
+			varCols = append(varCols, pb.vars[i].id)
+		}
+	}
+	return pb.stmts, varCols, program
+}
+
+func (pb *plpgsqlBuilder) buildBlock(block *plpgsqltree.Block) *tree.RoutineBlock {
+	res := &tree.RoutineBlock{Label: block.Label}
+	prevVisible := len(pb.visible)
+	defer func() {
+		pb.visible = pb.visible[:prevVisible]
+	}()
+
+	for i := range block.Decls {
+		decl := &block.Decls[i]
+		for _, slot := range pb.visible[prevVisible:] {
+			if pb.vars[slot].name.ReferenceName() == decl.Var {
+				panic(pgerror.Newf(pgcode.DuplicateObject,
+					"duplicate declaration at or near %q", string(decl.Var)))
+			}
+		}
+		typ, err := tree.ResolveType(pb.ob.ctx, decl.Typ, pb.ob.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		init := tree.RoutineVarInit{Expr: tree.RoutineNoExpr, NotNull: decl.NotNull}
+		if decl.Default != nil {
+			// The variable is not yet in scope when its default is built, so
+			// that the default can refer to a variable it shadows.
+			init.Expr = pb.buildExpr(decl.Default, typ)
+		}
+		col := pb.ob.synthesizeColumn(
+			pb.ob.allocScope(), scopeColName(decl.Var), typ, nil /* expr */, nil, /* scalar */
+		)
+		init.Var = len(pb.vars)
+		pb.vars = append(pb.vars, *col)
+		pb.visible = append(pb.visible, init.Var)
+		if decl.Constant {
+			pb.constants.Add(init.Var)
+		}
+		res.Init = append(res.Init, init)
+	}
+
+	pb.labels = append(pb.labels, plpgsqlLabel{name: block.Label})
+	defer func() {
+		pb.labels = pb.labels[:len(pb.labels)-1]
+	}()
+	res.Body = pb.buildStmts(block.Body)
+	for i := range block.Exceptions {
+		exc := &block.Exceptions[i]
+		var handler tree.RoutineExceptionHandler
+		for _, cond := range exc.Conditions {
+			switch {
+			case cond.SQLErrState != "":
+				handler.Codes = append(handler.Codes, cond.SQLErrState)
+			case cond.SQLErrName == "others":
+				handler.Others = true
+			default:
+				handler.Codes = append(handler.Codes, pgcode.PLpgSQLConditionNameToCode[cond.SQLErrName]...)
+			}
+		}
+		handler.Body = pb.buildStmts(exc.Body)
+		res.Handlers = append(res.Handlers, handler)
+	}
+	return res
+}
+
+func (pb *plpgsqlBuilder) buildStmts(stmts []plpgsqltree.Statement) []tree.RoutineInstr {
+	var res []tree.RoutineInstr
+	for _, stmt := range stmts {
+		if instr := pb.buildStmt(stmt); instr != nil {
+			res = append(res, instr)
+		}
+	}
+	return res
+}
+
+// buildStmt builds a single PL/pgSQL statement. It returns nil if the
+// statement has no effect.
+func (pb *plpgsqlBuilder) buildStmt(stmt plpgsqltree.Statement) tree.RoutineInstr {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		return pb.buildBlock(t)
+
+	case *plpgsqltree.Assignment:
+		slot := pb.resolveTarget(t.Var, t.Field)
+		return &tree.RoutineAssign{Var: slot, Expr: pb.buildExpr(t.Value, pb.vars[slot].typ)}
+
+	case *plpgsqltree.If:
+		res := &tree.RoutineIf{}
+		res.Conds = append(res.Conds, pb.buildExpr(t.Condition, types.Bool))
+		res.Branches = append(res.Branches, pb.buildStmts(t.ThenBody))
+		for i := range t.ElseIfList {
+			res.Conds = append(res.Conds, pb.buildExpr(t.ElseIfList[i].Condition, types.Bool))
+			res.Branches = append(res.Branches, pb.buildStmts(t.ElseIfList[i].Body))
+		}
+		res.Else = pb.buildStmts(t.ElseBody)
+		return res
+
+	case *plpgsqltree.Loop:
+		return pb.buildLoop(t.Label, nil /* cond */, t.Body)
+
+	case *plpgsqltree.While:
+		return pb.buildLoop(t.Label, t.Condition, t.Body)
+
+	case *plpgsqltree.Exit:
+		return pb.buildExit(t.Label, t.Condition, false /* isContinue */)
+
+	case *plpgsqltree.Continue:
+		return pb.buildExit(t.Label, t.Condition, true /* isContinue */)
+
+	case *plpgsqltree.Return:
+		res := &tree.RoutineReturnInstr{Expr: tree.RoutineNoExpr}
+		if pb.returnType.Family() == types.VoidFamily {
+			if t.Expr != nil {
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"RETURN cannot have a parameter in function returning void"))
+			}
+			return res
+		}
+		if t.Expr == nil {
+			panic(pgerror.New(pgcode.Syntax, "missing expression at or near \"RETURN;\""))
+		}
+		res.Expr = pb.buildExpr(pb.expandRecord(t.Expr), pb.returnType)
+		return res
+
+	case *plpgsqltree.Raise:
+		return pb.buildRaise(t)
+
+	case *plpgsqltree.Execute:
+		res := &tree.RoutineExec{Strict: t.Strict}
+		var targetTypes []*types.T
+		for _, name := range t.Target {
+			slot := pb.resolveTarget(name, "" /* field */)
+			res.Into = append(res.Into, slot)
+			targetTypes = append(targetTypes, pb.vars[slot].typ)
+		}
+		res.Stmt = pb.buildSQLStmt(t.SQLStmt, targetTypes, t.Strict)
+		return res
+
+	case *plpgsqltree.Perform:
+		return &tree.RoutineExec{Stmt: pb.buildSQLStmt(t.SQLStmt, nil /* targetTypes */, false /* strict */)}
+
+	case *plpgsqltree.Commit, *plpgsqltree.Rollback:
+		if !pb.isProcedure {
+			panic(pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination"))
+		}
+		_, isRollback := t.(*plpgsqltree.Rollback)
+		return &tree.RoutineTxnControl{Rollback: isRollback}
+
+	case *plpgsqltree.Null:
+		return nil
+
+	default:
+		panic(pgerror.Newf(pgcode.FeatureNotSupported, "unsupported PL/pgSQL statement: %T", stmt))
+	}
+}
+
+func (pb *plpgsqlBuilder) buildLoop(
+	label string, cond tree.Expr, body []plpgsqltree.Statement,
+) tree.RoutineInstr {
+	res := &tree.RoutineLoop{Label: label, Cond: tree.RoutineNoExpr}
+	if cond != nil {
+		res.Cond = pb.buildExpr(cond, types.Bool)
+	}
+	pb.labels = append(pb.labels, plpgsqlLabel{name: label, isLoop: true})
+	res.Body = pb.buildStmts(body)
+	pb.labels = pb.labels[:len(pb.labels)-1]
+	return res
+}
+
+func (pb *plpgsqlBuilder) buildExit(
+	label string, cond tree.Expr, isContinue bool,
+) tree.RoutineInstr {
+	stmtName := "EXIT"
+	if isContinue {
+		stmtName = "CONTINUE"
+	}
+	found := false
+	for i := len(pb.labels) - 1; i >= 0; i-- {
+		l := &pb.labels[i]
+		if label == "" && l.isLoop {
+			found = true
+			break
+		}
+		if label != "" && l.name == label {
+			if isContinue && !l.isLoop {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"block label %q cannot be used in CONTINUE", label))
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		switch {
+		case label != "":
+			panic(pgerror.Newf(pgcode.Syntax,
+				"there is no label %q attached to any block or loop enclosing this statement", label))
+		case isContinue:
+			panic(pgerror.New(pgcode.Syntax, "CONTINUE cannot be used outside a loop"))
+		default:
+			panic(pgerror.Newf(pgcode.Syntax,
+				"%s cannot be used outside a loop, unless it has a label", stmtName))
+		}
+	}
+	res := &tree.RoutineExit{Label: label, Cond: tree.RoutineNoExpr, Continue: isContinue}
+	if cond != nil {
+		res.Cond = pb.buildExpr(cond, types.Bool)
+	}
+	return res
+}
+
+func (pb *plpgsqlBuilder) buildRaise(raise *plpgsqltree.Raise) tree.RoutineInstr {
+	res := &tree.RoutineRaise{
+		Severity: string(raise.Level),
+		Format:   raise.Message,
+		Message:  tree.RoutineNoExpr,
+		Detail:   tree.RoutineNoExpr,
+		Hint:     tree.RoutineNoExpr,
+		ErrCode:  tree.RoutineNoExpr,
+	}
+	for _, param := range raise.Params {
+		res.Params = append(res.Params, pb.buildExpr(param, nil /* typ */))
+	}
+	if cond := raise.Condition; cond != nil {
+		if cond.SQLErrState != "" {
+			res.Code = cond.SQLErrState
+		} else {
+			res.Code = pgcode.PLpgSQLConditionNameToCode[cond.SQLErrName][0]
+			if res.Format == "" {
+				// The message defaults to the name of the condition.
+				res.Format = cond.SQLErrName
+			}
+		}
+	}
+	for _, option := range raise.Options {
+		idx := pb.buildExpr(option.Value, types.String)
+		switch option.Name {
+		case "MESSAGE":
+			res.Message = idx
+		case "DETAIL":
+			res.Detail = idx
+		case "HINT":
+			res.Hint = idx
+		case "ERRCODE":
+			res.ErrCode = idx
+		}
+	}
+	return res
+}
+
+// resolveTarget returns the slot of the variable with the given name that is
+// the target of an assignment. If field is not empty, the target is the field
+// with the given name of the record variable (NEW or OLD in a trigger
+// function) with the given name.
+func (pb *plpgsqlBuilder) resolveTarget(name, field tree.Name) int {
+	for i := len(pb.visible) - 1; i >= 0; i-- {
+		slot := pb.visible[i]
+		col := &pb.vars[slot]
+		if field != "" {
+			if col.table.ObjectName != name || col.name.ReferenceName() != field {
+				continue
+			}
+		} else if col.table.ObjectName != "" || col.name.ReferenceName() != name {
+			continue
+		}
+		if pb.constants.Contains(slot) {
+			panic(pgerror.Newf(pgcode.ErrorInAssignment,
+				"variable %q is declared CONSTANT", string(name)))
+		}
+		return slot
+	}
+	if field != "" {
+		for _, slot := range pb.visible {
+			if pb.vars[slot].table.ObjectName == name {
+				panic(pgerror.Newf(pgcode.UndefinedColumn,
+					"record %q has no field %q", string(name), string(field)))
+			}
+		}
+		panic(pgerror.Newf(pgcode.Syntax, "%q is not a known variable", string(name)+"."+string(field)))
+	}
+	panic(pgerror.Newf(pgcode.Syntax, "%q is not a known variable", string(name)))
+}
+
+// expandRecord returns a tuple of the fields of the record variable (NEW or
+// OLD in a trigger function) if the given expression is a reference to it.
+// Otherwise, the expression is returned unchanged.
+func (pb *plpgsqlBuilder) expandRecord(expr tree.Expr) tree.Expr {
+	name, ok := expr.(*tree.UnresolvedName)
+	if !ok || name.NumParts != 1 || name.Star {
+		return expr
+	}
+	recName := tree.Name(name.Parts[0])
+	tuple := &tree.Tuple{Row: true}
+	for _, slot := range pb.visible {
+		col := &pb.vars[slot]
+		if col.table.ObjectName != recName {
+			continue
+		}
+		field := col.name.ReferenceName()
+		tuple.Exprs = append(tuple.Exprs, tree.NewUnresolvedName(string(recName), string(field)))
+		tuple.Labels = append(tuple.Labels, string(field))
+	}
+	if len(tuple.Exprs) == 0 {
+		return expr
+	}
+	return tuple
+}
+
+// varScope returns a scope containing the variables that are currently
+// visible.
+func (pb *plpgsqlBuilder) varScope() *scope {
+	type varName struct {
+		record, name tree.Name
+	}
+	s := pb.ob.allocScope()
+	var seen map[varName]struct{}
+	for i := len(pb.visible) - 1; i >= 0; i-- {
+		col := &pb.vars[pb.visible[i]]
+		if name := col.name.ReferenceName(); name != "" {
+			key := varName{record: col.table.ObjectName, name: name}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			if seen == nil {
+				seen = make(map[varName]struct{})
+			}
+			seen[key] = struct{}{}
+		}
+		s.cols = append(s.cols, *col)
+	}
+	return s
+}
+
+// buildExpr builds the given expression as a statement of the form
+// "SELECT expr" and returns its index. If typ is not nil, the result is cast
+// to typ with assignment cast semantics.
+func (pb *plpgsqlBuilder) buildExpr(expr tree.Expr, typ *types.T) int {
+	sel := &tree.Select{
+		Select: &tree.SelectClause{Exprs: tree.SelectExprs{{Expr: expr}}},
+	}
+	var targetTypes []*types.T
+	if typ != nil {
+		targetTypes = []*types.T{typ}
+	}
+	return pb.buildSQLStmt(sel, targetTypes, false /* strict */)
+}
+
+// buildSQLStmt builds the given SQL statement and returns its index. If
+// targetTypes is not empty, the result columns of the statement are cast to
+// the given types with assignment cast semantics, and the number of rows
+// returned by the statement is limited to the number required to determine
+// its result.
+func (pb *plpgsqlBuilder) buildSQLStmt(
+	stmt tree.Statement, targetTypes []*types.T, strict bool,
+) int {
+	b := pb.ob
+	stmtScope := b.buildStmt(stmt, targetTypes, pb.varScope())
+	expr := stmtScope.expr
+	physProps := stmtScope.makePhysicalProps()
+
+	if len(targetTypes) > 0 {
+		// Only the first row is assigned to the targets, unless the statement
+		// is STRICT, in which case a second row results in an error.
+		limit := 1
+		if strict {
+			limit = 2
+		}
+		b.buildLimit(&tree.Limit{Count: tree.NewDInt(tree.DInt(limit))}, b.allocScope(), stmtScope)
+		expr = stmtScope.expr
+		physProps.Ordering = props.OrderingChoice{}
+
+		md := b.factory.Metadata()
+		projScope := b.allocScope()
+		for i, col := range physProps.Presentation {
+			if i >= len(targetTypes) {
+				// Extra columns are ignored.
+				break
+			}
+			colMeta := md.ColumnMeta(col.ID)
+			var scalar opt.ScalarExpr = b.factory.ConstructVariable(col.ID)
+			if !colMeta.Type.Identical(targetTypes[i]) {
+				if !cast.ValidCast(colMeta.Type, targetTypes[i], cast.ContextAssignment) {
+					panic(sqlerrors.NewInvalidAssignmentCastError(
+						colMeta.Type, targetTypes[i], colMeta.Alias))
+				}
+				scalar = b.factory.ConstructAssignmentCast(scalar, targetTypes[i])
+			}
+			name := scopeColName("").WithMetadataName(fmt.Sprintf("target%d", i+1))
+			b.synthesizeColumn(projScope, name, targetTypes[i], nil /* expr */, scalar)
+		}
+		expr = b.constructProject(expr, projScope.cols)
+		physProps = projScope.makePhysicalProps()
+	}
+
+	pb.stmts = append(pb.stmts, memo.RelRequiredPropsExpr{
+		RelExpr:   expr,
+		PhysProps: physProps,
+	})
+	return len(pb.stmts) - 1
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -539,10 +540,24 @@ func (b *Builder) buildFunction(
 	}
 
 	overload := f.ResolvedOverload()
+	if overload.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is a procedure", def.Name),
+			"To call a procedure, use CALL.",
+		))
+	}
 	if overload.Body != "" {
+		if overload.FixedReturnType().Family() == types.TriggerFamily {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"trigger functions can only be called as triggers"))
+		}
 		return b.buildUDF(f, def, inScope, outScope, outCol, colRefs)
 	}
 
+	if def.Name == "grouping" {
+		return b.buildGroupingFunc(f, inScope, outScope, outCol, colRefs)
+	}
+
 	if overload.Class == tree.AggregateClass {
 		panic(errors.AssertionFailedf("aggregate function should have been replaced"))
 	}
@@ -626,6 +641,15 @@ func (b *Builder) buildUDF(
 		}
 	}
 
+	out = b.buildRoutine(def.Name, o, f.ResolvedType(), input)
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// buildRoutine builds a UDF expression for the given user-defined overload,
+// with the given input expressions. rtyp is the return type of the routine.
+func (b *Builder) buildRoutine(
+	name string, o *tree.Overload, rtyp *types.T, input memo.ScalarListExpr,
+) opt.ScalarExpr {
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
 	// refer to anything from the outer expression. If there are function
@@ -652,8 +676,42 @@ func (b *Builder) buildUDF(
 		}
 	}
 
+	// Build an expression for each statement in the function body.
+	var rels memo.RelListExpr
+	var program *tree.RoutineProgram
+	if o.Language == tree.FunctionLangPLpgSQL {
+		block, err := plpgsqlparser.Parse(o.Body)
+		if err != nil {
+			panic(err)
+		}
+		var varCols opt.ColList
+		rels, varCols, program = b.buildPLpgSQLBody(block, bodyScope, rtyp, o.IsProcedure)
+		argCols = append(argCols, varCols...)
+	} else {
+		rels = b.buildSQLRoutineBody(o.Body, bodyScope, rtyp)
+	}
+
+	return b.factory.ConstructUDF(
+		input,
+		&memo.UDFPrivate{
+			Name:              name,
+			ArgCols:           argCols,
+			Body:              rels,
+			Typ:               rtyp,
+			Volatility:        o.Volatility,
+			CalledOnNullInput: o.CalledOnNullInput,
+			Program:           program,
+		},
+	)
+}
+
+// buildSQLRoutineBody builds an expression for each statement in the body of a
+// function written in SQL. rtyp is the return type of the function.
+func (b *Builder) buildSQLRoutineBody(
+	body string, bodyScope *scope, rtyp *types.T,
+) memo.RelListExpr {
 	// Parse the function body.
-	stmts, err := parser.Parse(o.Body)
+	stmts, err := parser.Parse(body)
 	if err != nil {
 		panic(err)
 	}
@@ -667,8 +725,10 @@ func (b *Builder) buildUDF(
 
 		// Add a LIMIT 1 to the last statement. This is valid because any other
 		// rows after the first can simply be ignored. The limit could be
-		// beneficial because it could allow additional optimization.
-		if i == len(stmts)-1 {
+		// beneficial because it could allow additional optimization. The result
+		// of a routine returning VOID, like a procedure, is always NULL, so its
+		// last statement is left as is.
+		if i == len(stmts)-1 && rtyp.Family() != types.VoidFamily {
 			b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
 			expr = stmtScope.expr
 			// The limit expression will maintain the desired ordering, if any,
@@ -683,9 +743,9 @@ func (b *Builder) buildUDF(
 				for i := range cols {
 					elems[i] = b.factory.ConstructVariable(cols[i].ID)
 				}
-				tup := b.factory.ConstructTuple(elems, f.ResolvedType())
+				tup := b.factory.ConstructTuple(elems, rtyp)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, tup)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), rtyp, nil /* expr */, tup)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -694,17 +754,17 @@ func (b *Builder) buildUDF(
 			// its type matches the function return type.
 			returnCol := physProps.Presentation[0].ID
 			returnColMeta := b.factory.Metadata().ColumnMeta(returnCol)
-			if returnColMeta.Type != f.ResolvedType() {
-				if !cast.ValidCast(returnColMeta.Type, f.ResolvedType(), cast.ContextAssignment) {
+			if returnColMeta.Type != rtyp {
+				if !cast.ValidCast(returnColMeta.Type, rtyp, cast.ContextAssignment) {
 					panic(sqlerrors.NewInvalidAssignmentCastError(
-						returnColMeta.Type, f.ResolvedType(), returnColMeta.Alias))
+						returnColMeta.Type, rtyp, returnColMeta.Alias))
 				}
 				cast := b.factory.ConstructAssignmentCast(
 					b.factory.ConstructVariable(physProps.Presentation[0].ID),
-					f.ResolvedType(),
+					rtyp,
 				)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, cast)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), rtyp, nil /* expr */, cast)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -715,19 +775,7 @@ func (b *Builder) buildUDF(
 			PhysProps: physProps,
 		}
 	}
-
-	out = b.factory.ConstructUDF(
-		input,
-		&memo.UDFPrivate{
-			Name:              def.Name,
-			ArgCols:           argCols,
-			Body:              rels,
-			Typ:               f.ResolvedType(),
-			Volatility:        o.Volatility,
-			CalledOnNullInput: o.CalledOnNullInput,
-		},
-	)
-	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	return rels
 }
 
 // buildRangeCond builds a RANGE clause as a simpler expression. Examples:
@@ -902,6 +950,12 @@ func (b *Builder) constructComparison(
 			return b.factory.ConstructBBoxIntersects(left, right)
 		}
 		return b.factory.ConstructOverlaps(left, right)
+	case treecmp.TSMatches:
+		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -631,6 +632,13 @@ func (b *Builder) buildScan(
 	}
 	if locking.isSet() {
 		private.Locking = locking.get()
+		if private.Locking.Strength < tree.ForNoKeyUpdate &&
+			!b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.V23_1SharedLocks) {
+			// Nodes running an older binary cannot acquire Shared locks, so FOR
+			// SHARE and FOR KEY SHARE do not lock rows until the cluster is
+			// upgraded.
+			private.Locking.Strength = tree.ForNone
+		}
 		if private.Locking.WaitPolicy == tree.LockWaitSkipLocked && tab.FamilyCount() > 1 {
 			// TODO(rytaft): We may be able to support this if enough columns are
 			// pruned that only a single family is scanned.
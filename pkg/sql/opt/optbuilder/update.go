@@ -283,6 +283,10 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	// Add assignment casts for update columns.
 	mb.addAssignmentCasts(mb.updateColIDs)
 
+	// Apply any BEFORE UPDATE triggers to the updated rows, before computed
+	// columns are derived from them.
+	mb.buildBeforeTriggers(tree.TriggerEventUpdate)
+
 	// Add additional columns for computed expressions that may depend on the
 	// updated columns.
 	mb.addSynthesizedColsForUpdate()
@@ -344,8 +348,12 @@ func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpdate()
 
+	mb.buildExclusionChecksForUpdate()
+
 	mb.buildFKChecksForUpdate()
 
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
@@ -56,6 +56,21 @@ func getTypedExprs(exprs []tree.Expr) []tree.TypedExpr {
 	return argExprs
 }
 
+// getTypedAggArgs returns the arguments of the given aggregate or window
+// function as TypedExprs. The arguments of a user-defined aggregate are wrapped
+// into a single tuple, so that the aggregate always has a single input column.
+func getTypedAggArgs(f *tree.FuncExpr, def *memo.FunctionPrivate) []tree.TypedExpr {
+	argExprs := getTypedExprs(f.Exprs)
+	if !isUserDefinedAggregate(def) {
+		return argExprs
+	}
+	typs := make([]*types.T, len(argExprs))
+	for i := range argExprs {
+		typs[i] = argExprs[i].ResolvedType()
+	}
+	return []tree.TypedExpr{tree.NewTypedTuple(types.MakeTuple(typs), f.Exprs)}
+}
+
 // expandStar expands expr into a list of columns if expr
 // corresponds to a "*", "<table>.*" or "(Expr).*".
 func (b *Builder) expandStar(
@@ -141,7 +141,7 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		fn := b.constructWindowFn(&w.def, argLists[i])
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -259,7 +259,7 @@ func (b *Builder) buildAggregationAsWindow(
 
 	// Build the arguments, partitions and orderings for each aggregate.
 	for i, agg := range g.aggs {
-		argExprs := getTypedExprs(agg.Exprs)
+		argExprs := getTypedAggArgs(agg.FuncExpr, &agg.def)
 
 		// Build the appropriate arguments.
 		argLists[i] = b.buildWindowArgs(argExprs, i, agg.def.Name, fromScope, g.aggInScope)
@@ -286,7 +286,7 @@ func (b *Builder) buildAggregationAsWindow(
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+		fn := b.constructAggregate(&agg.def, argLists[i])
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -335,7 +335,7 @@ func (b *Builder) buildAggregationAsWindow(
 // projecting the default argument to some window functions when we could just
 // not do that projection.
 func (b *Builder) getTypedWindowArgs(w *windowInfo) []tree.TypedExpr {
-	argExprs := getTypedExprs(w.Exprs)
+	argExprs := getTypedAggArgs(w.FuncExpr, &w.def)
 
 	switch w.def.Name {
 	// The second argument of {lead,lag} is 1 by default, and the third argument
@@ -199,6 +199,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"OrderingChoice":      {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":       {fullName: "memo.GroupingOrder", passByVal: true},
 		"TupleOrdinal":        {fullName: "memo.TupleOrdinal", passByVal: true},
+		"GroupingSets":        {fullName: "memo.GroupingSets", passByVal: true},
 		"ScanLimit":           {fullName: "memo.ScanLimit", passByVal: true},
 		"ScanFlags":           {fullName: "memo.ScanFlags", passByVal: true},
 		"JoinFlags":           {fullName: "memo.JoinFlags", passByVal: true},
@@ -224,6 +225,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"Constraint":          {fullName: "constraint.Constraint", isPointer: true, usePointerIntern: true},
 		"FuncProps":           {fullName: "tree.FunctionProperties", isPointer: true, usePointerIntern: true},
 		"FuncOverload":        {fullName: "tree.Overload", isPointer: true, usePointerIntern: true},
+		"RoutineProgram":      {fullName: "tree.RoutineProgram", isPointer: true, usePointerIntern: true},
 		"PhysProps":           {fullName: "physical.Required", isPointer: true},
 		"Presentation":        {fullName: "physical.Presentation", passByVal: true},
 		"RelProps":            {fullName: "props.Relational"},
@@ -211,8 +211,10 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.CheckConstraintTableDef:
 			tab.Checks = append(tab.Checks, cat.CheckConstraint{
-				Constraint: serializeTableDefExpr(def.Expr),
-				Validated:  validatedCheckConstraint(def),
+				Name:          string(def.Name),
+				Constraint:    serializeTableDefExpr(def.Expr),
+				Validated:     validatedCheckConstraint(def),
+				Deferrability: def.Deferrability,
 			})
 		}
 	}
@@ -535,6 +537,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrability,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
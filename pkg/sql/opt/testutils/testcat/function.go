@@ -85,8 +85,8 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		panic(err)
 	}
 
-	// Retrieve the function body, volatility, and calledOnNullInput.
-	body, v, calledOnNullInput := collectFuncOptions(c.Options)
+	// Retrieve the function body, language, volatility, and calledOnNullInput.
+	body, lang, v, calledOnNullInput := collectFuncOptions(c.Options)
 
 	if tc.udfs == nil {
 		tc.udfs = make(map[string]*tree.ResolvedFunctionDefinition)
@@ -96,6 +96,7 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		Types:             argTypes,
 		ReturnType:        tree.FixedReturnType(retType),
 		Body:              body,
+		Language:          lang,
 		Volatility:        v,
 		CalledOnNullInput: calledOnNullInput,
 	}
@@ -111,7 +112,10 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 
 func collectFuncOptions(
 	o tree.FunctionOptions,
-) (body string, v volatility.V, calledOnNullInput bool) {
+) (body string, lang tree.FunctionLanguage, v volatility.V, calledOnNullInput bool) {
+	// The default language is SQL.
+	lang = tree.FunctionLangSQL
+
 	// The default volatility is VOLATILE.
 	v = volatility.Volatile
 
@@ -145,9 +149,10 @@ func collectFuncOptions(
 			}
 
 		case tree.FunctionLanguage:
-			if t != tree.FunctionLangSQL {
-				panic(fmt.Errorf("LANGUAGE must be SQL"))
+			if t != tree.FunctionLangSQL && t != tree.FunctionLangPLpgSQL {
+				panic(fmt.Errorf("LANGUAGE must be SQL or plpgsql"))
 			}
+			lang = t
 
 		default:
 			ctx := tree.NewFmtCtx(tree.FmtSimple)
@@ -162,7 +167,7 @@ func collectFuncOptions(
 		panic(fmt.Errorf("LEAKPROOF functions must be IMMUTABLE"))
 	}
 
-	return body, v, calledOnNullInput
+	return body, lang, v, calledOnNullInput
 }
 
 // formatFunction nicely formats a function definition creating in the opt test
@@ -546,6 +546,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 	case opt.ProjectSetOp:
 		cost = c.computeProjectSetCost(candidate.(*memo.ProjectSetExpr))
 
+	case opt.ExpandOp:
+		cost = c.computeExpandCost(candidate.(*memo.ExpandExpr))
+
 	case opt.ExplainOp:
 		// Technically, the cost of an Explain operation is independent of the cost
 		// of the underlying plan. However, we want to explain the plan we would get
@@ -1343,6 +1346,12 @@ func (c *coster) computeProjectSetCost(projectSet *memo.ProjectSetExpr) memo.Cos
 	return cost
 }
 
+func (c *coster) computeExpandCost(expand *memo.ExpandExpr) memo.Cost {
+	// Add the CPU cost of emitting the rows.
+	cost := memo.Cost(expand.Relational().Statistics().RowCount) * cpuCostFactor
+	return cost
+}
+
 // getOrderingColStats returns the column statistic for the columns in the
 // OrderingChoice oc. The OrderingChoice should be a member of expr. We include
 // the Memo as an argument so that functions that call this function can be used
@@ -726,6 +726,13 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// triggers is the set of row-level triggers defined on this table.
+	triggers []cat.Trigger
+
+	// exclusionConstraints is the set of exclusion constraints defined on this
+	// table.
+	exclusionConstraints []cat.ExclusionConstraint
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -857,12 +864,13 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:          u.GetName(),
+			table:         ot.ID(),
+			columns:       u.CollectKeyColumnIDs().Ordered(),
+			predicate:     u.GetPredicate(),
+			withoutIndex:  true,
+			validity:      u.GetConstraintValidity(),
+			deferrability: u.UniqueWithoutIndexDesc().Deferrability,
 		}
 	}
 
@@ -967,6 +975,7 @@ func newOptTable(
 			match:             fk.Match(),
 			deleteAction:      fk.OnDelete(),
 			updateAction:      fk.OnUpdate(),
+			deferrability:     fk.ForeignKeyDesc().Deferrability,
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -980,6 +989,7 @@ func newOptTable(
 			match:             fk.Match(),
 			deleteAction:      fk.OnDelete(),
 			updateAction:      fk.OnUpdate(),
+			deferrability:     fk.ForeignKeyDesc().Deferrability,
 		})
 	}
 
@@ -1019,12 +1029,51 @@ func newOptTable(
 	ot.checkConstraints = make([]cat.CheckConstraint, 0, len(activeChecks)+len(synthesizedChecks))
 	for i := range activeChecks {
 		ot.checkConstraints = append(ot.checkConstraints, cat.CheckConstraint{
-			Constraint: activeChecks[i].GetExpr(),
-			Validated:  activeChecks[i].GetConstraintValidity() == descpb.ConstraintValidity_Validated,
+			Name:          activeChecks[i].GetName(),
+			Constraint:    activeChecks[i].GetExpr(),
+			Validated:     activeChecks[i].GetConstraintValidity() == descpb.ConstraintValidity_Validated,
+			Deferrability: descpb.TreeConstraintDeferrabilityValue[activeChecks[i].CheckDesc().Deferrability],
 		})
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Add the row-level triggers.
+	triggers := desc.GetTriggers()
+	ot.triggers = make([]cat.Trigger, len(triggers))
+	for i := range triggers {
+		ot.triggers[i] = cat.Trigger{
+			Name:       tree.Name(triggers[i].Name),
+			ActionTime: triggers[i].ActionTime.ToTree(),
+			Events:     triggers[i].TreeEvents(),
+			FuncOID:    catid.FuncIDToOID(triggers[i].FuncID),
+		}
+	}
+
+	// Add the exclusion constraints.
+	exclusionConstraints := desc.GetExclusionConstraints()
+	ot.exclusionConstraints = make([]cat.ExclusionConstraint, len(exclusionConstraints))
+	for i := range exclusionConstraints {
+		c := &exclusionConstraints[i]
+		ec := cat.ExclusionConstraint{
+			Name:           c.Name,
+			ColumnOrdinals: make([]int, len(c.ColumnIDs)),
+			Operators:      make([]treecmp.ComparisonOperator, len(c.ColumnIDs)),
+			Predicate:      c.Predicate,
+			Validated:      c.Validity == descpb.ConstraintValidity_Validated,
+			Deferrability:  descpb.TreeConstraintDeferrabilityValue[c.Deferrability],
+		}
+		for j, colID := range c.ColumnIDs {
+			var err error
+			if ec.ColumnOrdinals[j], err = ot.lookupColumnOrdinal(colID); err != nil {
+				return nil, err
+			}
+			if ec.Operators[j], err = c.ComparisonOperator(j); err != nil {
+				return nil, err
+			}
+		}
+		ot.exclusionConstraints[i] = ec
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1250,6 +1299,26 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return ot.triggers[i]
+}
+
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraintCount() int {
+	return len(ot.exclusionConstraints)
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	return ot.exclusionConstraints[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1819,8 +1888,9 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability descpb.ConstraintDeferrability
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -1878,6 +1948,11 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return descpb.TreeConstraintDeferrabilityValue[u.deferrability]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1891,10 +1966,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        descpb.ForeignKeyReference_Match
-	deleteAction catpb.ForeignKeyAction
-	updateAction catpb.ForeignKeyAction
+	validity      descpb.ConstraintValidity
+	match         descpb.ForeignKeyReference_Match
+	deleteAction  catpb.ForeignKeyAction
+	updateAction  catpb.ForeignKeyAction
+	deferrability descpb.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1966,6 +2042,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return descpb.ForeignKeyReferenceActionType[fk.updateAction]
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return descpb.TreeConstraintDeferrabilityValue[fk.deferrability]
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2218,6 +2299,7 @@ func (ot *optVirtualTable) CheckCount() int {
 func (ot *optVirtualTable) Check(i int) cat.CheckConstraint {
 	check := ot.desc.EnforcedCheckConstraints()[i]
 	return cat.CheckConstraint{
+		Name:       check.GetName(),
 		Constraint: check.GetExpr(),
 		Validated:  check.GetConstraintValidity() == descpb.ConstraintValidity_Validated,
 	}
@@ -2263,6 +2345,26 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraintCount() int {
+	return 0
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("no exclusion constraints"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
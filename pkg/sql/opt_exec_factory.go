@@ -528,6 +528,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -622,6 +623,35 @@ func (ef *execFactory) ConstructOrdinality(input exec.Node, colName string) (exe
 	}, nil
 }
 
+// ConstructExpand is part of the exec.Factory interface.
+func (ef *execFactory) ConstructExpand(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	groupingSets []exec.NodeColumnOrdinalSet,
+	groupingSetColName string,
+) (exec.Node, error) {
+	plan := input.(planNode)
+	inputColumns := planColumns(plan)
+	cols := make(colinfo.ResultColumns, 0, len(inputColumns)+len(groupCols)+1)
+	cols = append(cols, inputColumns...)
+	for _, col := range groupCols {
+		cols = append(cols, colinfo.ResultColumn{
+			Name: inputColumns[col].Name,
+			Typ:  inputColumns[col].Typ,
+		})
+	}
+	cols = append(cols, colinfo.ResultColumn{
+		Name: groupingSetColName,
+		Typ:  types.Int,
+	})
+	return &expandNode{
+		source:       plan,
+		columns:      cols,
+		groupCols:    groupCols,
+		groupingSets: groupingSets,
+	}, nil
+}
+
 // ConstructIndexJoin is part of the exec.Factory interface.
 func (ef *execFactory) ConstructIndexJoin(
 	input exec.Node,
@@ -1120,6 +1150,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefinedAggs != nil {
+			p.funcs[i].userDefined = wi.UserDefinedAggs[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
@@ -1871,6 +1904,15 @@ func (ef *execFactory) ConstructCreateFunction(
 	}, nil
 }
 
+// ConstructCall is part of the exec.Factory interface.
+func (ef *execFactory) ConstructCall(proc tree.TypedExpr) (exec.Node, error) {
+	r, ok := proc.(*tree.RoutineExpr)
+	if !ok {
+		return nil, errors.AssertionFailedf("expected RoutineExpr, found %T", proc)
+	}
+	return &callNode{proc: r}, nil
+}
+
 func toPlanDependencies(
 	deps opt.SchemaDeps, typeDeps opt.SchemaTypeDeps,
 ) (planDependencies, typeDependencies, error) {
@@ -461,6 +461,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -517,6 +519,17 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
 		{`ALTER FUNCTION ??`, `ALTER FUNCTION`},
 		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+
+		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`ALTER PROCEDURE ??`, `ALTER PROCEDURE`},
+		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+		{`CALL ??`, `CALL`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -55,6 +55,8 @@ func TestScanner(t *testing.T) {
 		{`;`, []int{';'}},
 		{`+`, []int{'+'}},
 		{`-`, []int{'-'}},
+		{`-|-`, []int{ADJACENT}},
+		{`-|`, []int{'-', '|'}},
 		{`*`, []int{'*'}},
 		{`/`, []int{'/'}},
 		{`//`, []int{FLOORDIV}},
@@ -63,6 +65,8 @@ func TestScanner(t *testing.T) {
 		{`$`, []int{'$'}},
 		{`&`, []int{'&'}},
 		{`&&`, []int{AND_AND}},
+		{`@@`, []int{AT_AT}},
+		{`@?`, []int{JSON_PATH_EXISTS}},
 		{`|`, []int{'|'}},
 		{`||`, []int{CONCAT}},
 		{`|/`, []int{SQRT}},
@@ -614,12 +614,21 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
 func (u *sqlSymUnion) validationBehavior() tree.ValidationBehavior {
     return u.val.(tree.ValidationBehavior)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) partitionBy() *tree.PartitionBy {
     return u.val.(*tree.PartitionBy)
 }
@@ -819,12 +828,27 @@ func (u *sqlSymUnion) stmts() tree.Statements {
 func (u *sqlSymUnion) routineBody() *tree.RoutineBody {
     return u.val.(*tree.RoutineBody)
 }
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
 func (u *sqlSymUnion) functionObj() tree.FuncObj {
     return u.val.(tree.FuncObj)
 }
 func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
     return u.val.(tree.FuncObjs)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEventType {
+    return u.val.(tree.TriggerEventType)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -844,15 +868,15 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ASENSITIVE ASYMMETRIC AT AT_AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -866,7 +890,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -892,7 +916,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
-%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
+%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS
 
 %token <str> KEY KEYS KMS KV
 
@@ -919,7 +943,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
-%token <str> PROCEDURAL PUBLIC PUBLICATION
+%token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
@@ -937,7 +961,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %token <str> SQLLOGIN
 
 %token <str> STABLE START STATE STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
-%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
+%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENT STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
@@ -1007,6 +1031,8 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.Statement> alter_schema_stmt
 %type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
+%type <tree.Statement> alter_proc_stmt
+%type <tree.Statement> alter_aggregate_stmt
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
@@ -1078,6 +1104,9 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.Statement> alter_func_set_schema_stmt
 %type <tree.Statement> alter_func_owner_stmt
 %type <tree.Statement> alter_func_dep_extension_stmt
+%type <tree.Statement> alter_proc_rename_stmt
+%type <tree.Statement> alter_proc_set_schema_stmt
+%type <tree.Statement> alter_proc_owner_stmt
 
 %type <tree.Statement> backup_stmt
 %type <tree.Statement> begin_stmt
@@ -1117,6 +1146,12 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEventType> trigger_event
+%type <tree.TriggerEvents> trigger_event_list
 
 %type <tree.Statement> create_stats_stmt
 %type <*tree.CreateStatsOptions> opt_create_stats_options
@@ -1139,9 +1174,13 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_trigger_stmt
 %type <tree.Statement> drop_tenant_stmt
 
 %type <tree.Statement> analyze_stmt
+%type <tree.Statement> call_stmt
 %type <tree.Statement> explain_stmt
 %type <tree.Statement> prepare_stmt
 %type <tree.Statement> preparable_stmt
@@ -1176,6 +1215,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1298,6 +1338,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.DropBehavior> opt_drop_behavior
 
 %type <tree.ValidationBehavior> opt_validate_behavior
+%type <tree.ConstraintDeferrability> opt_deferrable constraint_deferrability
 
 %type <str> opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause
 %type <tree.NameList> opt_regions_list
@@ -1318,7 +1359,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.KVOption> role_option password_clause valid_until_clause
 %type <tree.Operator> subquery_op
 %type <*tree.UnresolvedName> func_name func_name_no_crdb_extra
-%type <str> opt_class opt_collate
+%type <str> opt_class opt_collate opt_exclude_access_method
 
 %type <str> cursor_name database_name index_name opt_index_name column_name insert_column_item statistics_name window_name opt_in_database
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
@@ -1449,6 +1490,8 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExcludeElem> exclude_elem
+%type <tree.ExcludeElemList> exclude_elems
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1542,7 +1585,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <*tree.BackupTargetList> opt_backup_targets
 
 %type <tree.GrantTargetList> grant_targets targets_roles target_types
-%type <tree.TableExpr> changefeed_target_expr
+%type <tree.TableExpr> changefeed_target_expr changefeed_from_expr
 %type <*tree.GrantTargetList> opt_on_targets_roles
 %type <tree.RoleSpecList> for_grantee_clause
 %type <privilege.List> privileges
@@ -1582,6 +1625,8 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %type <tree.ResolvableTypeReference> func_return_type func_arg_type
 %type <tree.FunctionOptions> opt_create_func_opt_list create_func_opt_list alter_func_opt_list
 %type <tree.FunctionOption> create_func_opt_item common_func_opt_item
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
 %type <tree.FuncArgClass> func_arg_class
 %type <*tree.UnresolvedObjectName> func_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
@@ -1605,7 +1650,7 @@ func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
 %nonassoc  '<' '>' '=' LESS_EQUALS GREATER_EQUALS NOT_EQUALS
 %nonassoc  '~' BETWEEN IN LIKE ILIKE SIMILAR NOT_REGMATCH REGIMATCH NOT_REGIMATCH NOT_LA
 %nonassoc  ESCAPE              // ESCAPE must be just above LIKE/ILIKE/SIMILAR
-%nonassoc  CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS
+%nonassoc  CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS AT_AT ADJACENT
 %nonassoc  OVERLAPS
 %left      POSTFIXOP           // dummy for postfix OP rules
 // To support target_elem without AS, we must give IDENT an explicit priority
@@ -1681,6 +1726,7 @@ stmt:
 stmt_without_legacy_transaction:
   preparable_stmt            // help texts in sub-rule
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
+| call_stmt                  // EXTEND WITH HELP: CALL
 | copy_from_stmt
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
@@ -1728,6 +1774,8 @@ alter_ddl_stmt:
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1922,6 +1970,57 @@ alter_func_stmt:
 | alter_func_dep_extension_stmt
 | ALTER FUNCTION error // SHOW HELP: ALTER FUNCTION
 
+// %Help: ALTER PROCEDURE - change the definition of a procedure
+// %Category: DDL
+// %Text:
+// ALTER PROCEDURE name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ]
+//    RENAME TO new_name
+// ALTER PROCEDURE name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ]
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER PROCEDURE name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ]
+//    SET SCHEMA new_schema
+// %SeeAlso: WEBDOCS/alter-procedure.html
+alter_proc_stmt:
+  alter_proc_rename_stmt
+| alter_proc_owner_stmt
+| alter_proc_set_schema_stmt
+| ALTER PROCEDURE error // SHOW HELP: ALTER PROCEDURE
+
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( argtype [, ...] ) RENAME TO new_name
+// ALTER AGGREGATE name ( argtype [, ...] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( argtype [, ...] ) SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_argtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE function_with_argtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+    }
+  }
+| ALTER AGGREGATE function_with_argtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -2668,7 +2767,20 @@ alter_table_cmd:
     }
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
-| ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
+| ALTER CONSTRAINT constraint_name constraint_deferrability
+  {
+    $$.val = &tree.AlterTableAlterConstraint{
+      Constraint: tree.Name($3),
+      Deferrability: $4.constraintDeferrability(),
+    }
+  }
+| ALTER CONSTRAINT constraint_name NOT DEFERRABLE
+  {
+    $$.val = &tree.AlterTableAlterConstraint{
+      Constraint: tree.Name($3),
+      Deferrability: tree.ConstraintNotDeferrable,
+    }
+  }
   // ALTER TABLE <name> INHERITS ....
 | INHERITS error
   {
@@ -3703,10 +3815,6 @@ alter_unsupported_stmt:
   {
     return unimplemented(sqllex, "alter domain")
   }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
 
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
@@ -3770,6 +3878,8 @@ import_stmt:
 // Formats:
 //    CSV
 //    Parquet
+//    JSON
+//    Avro
 //
 // Options:
 //    delimiter = '...'   [CSV-specific]
@@ -4221,6 +4331,172 @@ create_func_stmt:
   }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
 
+// %Help: CREATE PROCEDURE - define a new procedure
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] PROCEDURE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//  { LANGUAGE lang_name
+//    | AS 'definition'
+//  } ...
+// %SeeAlso: CALL, WEBDOCS/create-procedure.html
+create_proc_stmt:
+  CREATE opt_or_replace PROCEDURE func_create_name '(' opt_func_arg_with_default_list ')'
+  opt_create_func_opt_list
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: true,
+      Replace: $2.bool(),
+      FuncName: name,
+      Args: $6.functionArgs(),
+      ReturnType: tree.FuncReturnType{
+        Type: types.Void,
+      },
+      Options: $8.functionOptions(),
+    }
+  }
+| CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE name ( argtype [, ...] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE func_create_name func_args '(' aggregate_option_list ')'
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    n, err := tree.NewCreateAggregate($2.bool(), name, $5.functionArgs(), $7.aggregateOptions())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  name '=' typename
+  {
+    $$.val = tree.AggregateOption{Name: $1, Type: $3.typeReference()}
+  }
+| name '=' SCONST
+  {
+    value := $3
+    $$.val = tree.AggregateOption{Name: $1, Value: &value}
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE TRIGGER <name> { BEFORE | AFTER } <event> [ OR ... ]
+//    ON <tablename>
+//    FOR EACH ROW
+//    EXECUTE { FUNCTION | PROCEDURE } <funcname> ( )
+//
+// Events:
+//    INSERT
+//    UPDATE
+//    DELETE
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name
+  trigger_for_each opt_trigger_when EXECUTE function_or_procedure func_create_name '(' ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($3),
+      ActionTime: $4.triggerActionTime(),
+      Events: $5.triggerEvents(),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      FuncName: $12.unresolvedObjectName().ToFunctionName(),
+    }
+  }
+| CREATE TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerEventInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerEventUpdate
+  }
+| UPDATE OF name_list
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "update of")
+  }
+| DELETE
+  {
+    $$.val = tree.TriggerEventDelete
+  }
+| TRUNCATE
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "truncate")
+  }
+
+trigger_for_each:
+  FOR opt_each ROW {}
+| FOR opt_each STATEMENT
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "statement")
+  }
+| /* EMPTY */
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "statement")
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+opt_trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "when")
+  }
+| /* EMPTY */ {}
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
@@ -4477,6 +4753,58 @@ drop_func_stmt:
   }
 | DROP FUNCTION error // SHOW HELP: DROP FUNCTION
 
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-procedure.html
+drop_proc_stmt:
+  DROP PROCEDURE function_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( argtype [, ...] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
 function_with_argtypes_list:
   function_with_argtypes
   {
@@ -4572,6 +4900,36 @@ alter_func_owner_stmt:
     }
   }
 
+alter_proc_rename_stmt:
+  ALTER PROCEDURE function_with_argtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      IsProcedure: true,
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+    }
+  }
+
+alter_proc_set_schema_stmt:
+  ALTER PROCEDURE function_with_argtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      IsProcedure: true,
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+    }
+  }
+
+alter_proc_owner_stmt:
+  ALTER PROCEDURE function_with_argtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      IsProcedure: true,
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+    }
+  }
+
 alter_func_dep_extension_stmt:
   ALTER FUNCTION function_with_argtypes opt_no DEPENDS ON EXTENSION name
   {
@@ -4594,7 +4952,6 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
@@ -4609,7 +4966,6 @@ create_unsupported:
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -4621,7 +4977,6 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
@@ -4637,7 +4992,6 @@ drop_unsupported:
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -4651,6 +5005,9 @@ create_ddl_stmt:
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -4771,7 +5128,7 @@ create_changefeed_stmt:
     }
   }
 | CREATE CHANGEFEED /*$3=*/ opt_changefeed_sink /*$4=*/ opt_with_options
-  AS SELECT /*$7=*/target_list FROM /*$9=*/changefeed_target_expr /*$10=*/opt_where_clause
+  AS SELECT /*$7=*/target_list FROM /*$9=*/changefeed_from_expr /*$10=*/opt_where_clause
   {
     target, err := tree.ChangefeedTargetFromTableExpr($9.tblExpr())
     if err != nil {
@@ -4819,6 +5176,19 @@ changefeed_target:
 
 changefeed_target_expr: insert_target
 
+// The changefeed target may be joined with lookup tables, which are read as of
+// the timestamp of each event.
+changefeed_from_expr:
+  changefeed_target_expr
+| changefeed_from_expr JOIN changefeed_target_expr ON a_expr
+  {
+    $$.val = &tree.JoinTableExpr{Left: $1.tblExpr(), Right: $3.tblExpr(), Cond: &tree.OnJoinCond{Expr: $5.expr()}}
+  }
+| changefeed_from_expr LEFT join_outer JOIN changefeed_target_expr ON a_expr
+  {
+    $$.val = &tree.JoinTableExpr{JoinType: tree.AstLeft, Left: $1.tblExpr(), Right: $5.tblExpr(), Cond: &tree.OnJoinCond{Expr: $7.expr()}}
+  }
+
 opt_table_prefix:
   TABLE
   {}
@@ -4917,6 +5287,30 @@ drop_stmt:
 | drop_unsupported   {}
 | DROP error         // SHOW HELP: DROP
 
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
 drop_ddl_stmt:
   drop_database_stmt // EXTEND WITH HELP: DROP DATABASE
 | drop_index_stmt    // EXTEND WITH HELP: DROP INDEX
@@ -4926,6 +5320,9 @@ drop_ddl_stmt:
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5182,6 +5579,18 @@ analyze_target:
     $$.val = $1.unresolvedObjectName()
   }
 
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text:
+// CALL name ( [ argument ] [, ...] )
+// %SeeAlso: CREATE PROCEDURE, WEBDOCS/call.html
+call_stmt:
+  CALL func_application
+  {
+    $$.val = &tree.Call{Proc: $2.expr().(*tree.FuncExpr)}
+  }
+| CALL error // SHOW HELP: CALL
+
 // %Help: EXPLAIN - show the logical plan of a query
 // %Category: Misc
 // %Text:
@@ -5860,7 +6269,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6106,6 +6515,31 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Deferred: true}
+  }
+| SET CONSTRAINTS ALL IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Deferred: false}
+  }
+| SET CONSTRAINTS name_list DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: true}
+  }
+| SET CONSTRAINTS name_list IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: false}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -6228,11 +6662,11 @@ var_list:
 iso_level:
   READ UNCOMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | READ COMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | SNAPSHOT
   {
@@ -8234,6 +8668,7 @@ alter_schema_stmt:
 //    FOREIGN KEY ( <colnames...> ) REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //    UNIQUE ( <colnames...> ) [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    CHECK ( <expr> )
+//    EXCLUDE [USING <method>] ( <colname> WITH <operator> [, ...] ) [WHERE <expr>]
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | NOT VISIBLE | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr> | ON UPDATE <expr> | GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [( <opt_sequence_option_list> )]}
@@ -8744,7 +9179,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -8752,6 +9187,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -8904,6 +9340,7 @@ constraint_elem:
   {
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
+      Deferrability: $5.constraintDeferrability(),
     }
   }
 | UNIQUE opt_without_index '(' index_params ')'
@@ -8917,6 +9354,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -8940,11 +9378,57 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_exclude_access_method '(' exclude_elems ')' opt_where_clause opt_deferrable
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Method: tree.Name($2),
+      Elems: $4.excludeElems(),
+      Predicate: $6.expr(),
+      Deferrability: $7.constraintDeferrability(),
+    }
+  }
+
+opt_exclude_access_method:
+  USING name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclude_elems:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elems ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  index_elem WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{IndexElem: $1.idxElem(), Operator: op}
+  }
+| index_elem WITH OPERATOR '(' operator_op ')'
+  {
+    op, ok := $5.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $5.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{IndexElem: $1.idxElem(), Operator: op}
   }
 
 
@@ -9053,12 +9537,38 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| constraint_deferrability
+  {
+    $$.val = $1.constraintDeferrability()
+  }
+
+// NOT DEFERRABLE is only accepted by ALTER CONSTRAINT, since it would be
+// ambiguous with NOT NULL and NOT VALID after a constraint definition.
+constraint_deferrability:
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
 
 storing:
   COVERING
@@ -11737,9 +12247,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.ListGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13145,6 +13664,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONAllExists), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr JSON_PATH_EXISTS a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr CONTAINS a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Contains), Left: $1.expr(), Right: $3.expr()}
@@ -13153,6 +13676,14 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.ContainedBy), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_AT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr '=' a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.EQ), Left: $1.expr(), Right: $3.expr()}
@@ -13744,7 +14275,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("grouping"), Exprs: $3.exprs()}
+  }
 
 func_application:
   func_name '(' ')'
@@ -14388,6 +14922,9 @@ all_op:
 | REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.RegIMatch) }
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
+| AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| JSON_PATH_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -15332,6 +15869,7 @@ unreserved_keyword:
 | BUNDLE
 | BY
 | CACHE
+| CALL
 | CALLED
 | CANCEL
 | CANCELQUERY
@@ -15388,6 +15926,7 @@ unreserved_keyword:
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -15574,6 +16113,7 @@ unreserved_keyword:
 | PRIOR
 | PRIORITY
 | PRIVILEGES
+| PROCEDURE
 | PUBLIC
 | PUBLICATION
 | QUERIES
@@ -15659,6 +16199,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -15734,20 +16275,24 @@ unreserved_keyword:
 // Any new keyword should be added to this list.
 bare_label_keywords:
   ATOMIC
+| CALL
 | CALLED
 | COST
 | DEFINER
 | DEPENDS
+| EACH
 | EXTERNAL
 | IMMUTABLE
 | INPUT
 | INVOKER
 | LEAKPROOF
 | PARALLEL
+| PROCEDURE
 | RETURN
 | RETURNS
 | SECURITY
 | STABLE
+| STATEMENT
 | SUPPORT
 | TRANSFORM
 | VOLATILE
@@ -833,7 +833,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -885,6 +884,7 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := descpb.ConstraintDeferrability_NotDeferrable
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -933,6 +933,7 @@ func populateTableConstraints(
 		} else if fk := c.AsForeignKey(); fk != nil {
 			conoid = h.ForeignKeyConstraintOid(db.GetID(), sc.GetID(), table.GetID(), fk)
 			contype = conTypeFK
+			deferrability = fk.ForeignKeyDesc().Deferrability
 			// Foreign keys don't have a single linked index. Pick the first one
 			// that matches on the referenced table.
 			referencedTable, err := tableLookup.getTableByID(fk.GetReferencedTableID())
@@ -985,6 +986,9 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			deferrability = uwoi.UniqueWithoutIndexDesc().Deferrability
+			treeDeferrability := descpb.TreeConstraintDeferrabilityValue[deferrability]
+			f.FormatNode(&treeDeferrability)
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1008,11 +1012,15 @@ func populateTableConstraints(
 			}
 			consrc = tree.NewDString(fmt.Sprintf("(%s)", displayExpr))
 			conbin = consrc
+			deferrability = ck.CheckDesc().Deferrability
+			treeDeferrability := descpb.TreeConstraintDeferrabilityValue[deferrability]
 			validity := ""
 			if !ck.IsConstraintValidated() {
 				validity = " NOT VALID"
 			}
-			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
+			condef = tree.NewDString(fmt.Sprintf(
+				"CHECK ((%s))%s%s", displayExpr, tree.AsString(&treeDeferrability), validity,
+			))
 		}
 
 		if err := addRow(
@@ -1020,9 +1028,9 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
-			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
+			tree.MakeDBool(tree.DBool(deferrability != descpb.ConstraintDeferrability_NotDeferrable)),     // condeferrable
+			tree.MakeDBool(tree.DBool(deferrability == descpb.ConstraintDeferrability_InitiallyDeferred)), // condeferred
+			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())),                                      // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
 			conindid,       // conindid
@@ -1048,6 +1056,73 @@ func populateTableConstraints(
 			return err
 		}
 	}
+	for i := range table.GetExclusionConstraints() {
+		c := &table.GetExclusionConstraints()[i]
+		conkey, err := colIDArrayToDatum(c.ColumnIDs)
+		if err != nil {
+			return err
+		}
+		f := tree.NewFmtCtx(tree.FmtSimple)
+		f.WriteString("EXCLUDE")
+		if c.Method != "" {
+			f.WriteString(" USING ")
+			f.WriteString(c.Method)
+		}
+		f.WriteString(" (")
+		colNames, err := table.NamesForColumnIDs(c.ColumnIDs)
+		if err != nil {
+			return err
+		}
+		for j, name := range colNames {
+			if j > 0 {
+				f.WriteString(", ")
+			}
+			f.FormatName(name)
+			f.WriteString(" WITH ")
+			f.WriteString(c.Operators[j])
+		}
+		f.WriteByte(')')
+		if c.IsPartial() {
+			pred, err := schemaexpr.FormatExprForDisplay(ctx, table, c.Predicate, p.SemaCtx(), p.SessionData(), tree.FmtPGCatalog)
+			if err != nil {
+				return err
+			}
+			f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
+		}
+		treeDeferrability := descpb.TreeConstraintDeferrabilityValue[c.Deferrability]
+		f.FormatNode(&treeDeferrability)
+		if err := addRow(
+			h.ExclusionConstraintOid(db.GetID(), sc.GetID(), table.GetID(), c), // oid
+			tree.NewDString(c.Name), // conname
+			namespaceOid,            // connamespace
+			conTypeExclusion,        // contype
+			tree.MakeDBool(tree.DBool(c.Deferrability != descpb.ConstraintDeferrability_NotDeferrable)),     // condeferrable
+			tree.MakeDBool(tree.DBool(c.Deferrability == descpb.ConstraintDeferrability_InitiallyDeferred)), // condeferred
+			tree.DBoolTrue,                         // convalidated
+			tblOid,                                 // conrelid
+			oidZero,                                // contypid
+			h.IndexOid(table.GetID(), c.IndexID),   // conindid
+			oidZero,                                // confrelid
+			tree.DNull,                             // confupdtype
+			tree.DNull,                             // confdeltype
+			tree.DNull,                             // confmatchtype
+			tree.DBoolTrue,                         // conislocal
+			zeroVal,                                // coninhcount
+			tree.DBoolTrue,                         // connoinherit
+			conkey,                                 // conkey
+			tree.DNull,                             // confkey
+			tree.DNull,                             // conpfeqop
+			tree.DNull,                             // conppeqop
+			tree.DNull,                             // conffeqop
+			tree.DNull,                             // conexclop
+			tree.DNull,                             // conbin
+			tree.DNull,                             // consrc
+			tree.NewDString(f.CloseAndGetString()), // condef
+			tree.DNull,                             // conparentid
+		); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -2489,16 +2564,27 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		for _, typ := range []*types.T{
+			types.Int4Range, types.Int8Range, types.NumRange,
+			types.DateRange, types.TSRange, types.TSTZRange,
+		} {
+			if err := addRow(
+				tree.NewDOid(typ.Oid()),                 // rngtypid
+				tree.NewDOid(typ.RangeContents().Oid()), // rngsubtype
+				oidZero,                                 // rngcollation
+				oidZero,                                 // rngsubopc
+				oidZero,                                 // rngcanonical
+				oidZero,                                 // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -2883,17 +2969,17 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
 	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -2915,7 +3001,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3003,14 +3088,21 @@ func addPGTypeRow(
 			builtinPrefix = "array_"
 			typElem = tree.NewDOid(typ.ArrayContents().Oid())
 		}
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have array types.
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
-	if typ.Family() == types.EnumFamily {
+	switch typ.Family() {
+	case types.EnumFamily:
 		builtinPrefix = "enum_"
 		typType = typTypeEnum
+	case types.RangeFamily:
+		builtinPrefix = "range_"
+		typType = typTypeRange
+	case types.MultirangeFamily:
+		builtinPrefix = "multirange_"
+		typType = typTypeMultirange
 	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
@@ -4204,10 +4296,16 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.ArrayFamily:       typCategoryArray,
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
+	types.TSQueryFamily:     typCategoryUserDefined,
+	types.TSVectorFamily:    typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4426,6 +4524,7 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	exclusionConstraintTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4527,6 +4626,17 @@ func (h oidHasher) UniqueWithoutIndexConstraintOid(
 	return h.getOid()
 }
 
+func (h oidHasher) ExclusionConstraintOid(
+	dbID descpb.ID, scID descpb.ID, tableID descpb.ID, c *descpb.ExclusionConstraint,
+) *tree.DOid {
+	h.writeTypeTag(exclusionConstraintTypeTag)
+	h.writeDB(dbID)
+	h.writeSchema(scID)
+	h.writeTable(tableID)
+	h.writeStr(c.Name)
+	return h.getOid()
+}
+
 func (h oidHasher) UniqueConstraintOid(
 	dbID descpb.ID, scID descpb.ID, tableID descpb.ID, uwi catalog.UniqueWithIndexConstraint,
 ) *tree.DOid {
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
@@ -501,6 +502,41 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(string(b))
+		case oid.T_tsquery:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSQuery(string(b))
+		case oid.T_tsvector:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSVector(string(b))
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
+		}
+		switch typ.Family() {
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.MultirangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultirangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		}
 		if typ.Family() == types.ArrayFamily {
 			// Arrays come in in their string form, so we parse them as such and later
@@ -753,6 +789,31 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(string(b))
+		case oid.T_tsquery:
+			q, err := tsearch.DecodeTSQuery(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		case oid.T_tsvector:
+			v, err := tsearch.DecodeTSVector(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSVector(v), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
 		case oid.T_varbit, oid.T_bit:
 			if len(b) < 4 {
 				return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
@@ -789,8 +850,21 @@ func DecodeDatum(
 			ba, err := bitarray.FromEncodingParts(words, lastBitsUsed)
 			return &tree.DBitArray{BitArray: ba}, err
 		default:
-			if typ.Family() == types.ArrayFamily {
+			switch typ.Family() {
+			case types.ArrayFamily:
 				return decodeBinaryArray(ctx, evalCtx, typ.ArrayContents(), b, code)
+			case types.RangeFamily:
+				r, err := decodeBinaryRange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return r, nil
+			case types.MultirangeFamily:
+				m, err := decodeBinaryMultirange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return m, nil
 			}
 		}
 	default:
@@ -995,6 +1069,96 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// The flags of the binary format of a range, which consists of a flags byte
+// followed by the length-prefixed binary encoding of each finite bound.
+const (
+	RangeEmpty          byte = 0x01
+	RangeLowerInclusive byte = 0x02
+	RangeUpperInclusive byte = 0x04
+	RangeLowerInfinite  byte = 0x08
+	RangeUpperInfinite  byte = 0x10
+)
+
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for range")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&RangeEmpty != 0 {
+		if len(b) != 0 {
+			return nil, NewInvalidBinaryRepresentationErrorf("extra data after empty range")
+		}
+		return tree.NewEmptyDRange(t), nil
+	}
+	readBound := func(infinite bool) (tree.Datum, error) {
+		if infinite {
+			return nil, nil
+		}
+		if len(b) < 4 {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for range bound")
+		}
+		n := int32(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if n < 0 || int(n) > len(b) {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range bound length: %d", n)
+		}
+		d, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, b[:n])
+		b = b[n:]
+		return d, err
+	}
+	lower := tree.RangeBound{Inclusive: flags&RangeLowerInclusive != 0}
+	upper := tree.RangeBound{Inclusive: flags&RangeUpperInclusive != 0}
+	var err error
+	if lower.Val, err = readBound(flags&RangeLowerInfinite != 0); err != nil {
+		return nil, err
+	}
+	if upper.Val, err = readBound(flags&RangeUpperInfinite != 0); err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("extra data after range")
+	}
+	return tree.MakeDRange(t, lower, upper)
+}
+
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DMultirange, error) {
+	if len(b) < 4 {
+		return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for multirange")
+	}
+	count := int32(binary.BigEndian.Uint32(b))
+	b = b[4:]
+	if count < 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("invalid multirange range count: %d", count)
+	}
+	rangeTyp := types.MakeRange(t.RangeContents())
+	var ranges []*tree.DRange
+	for i := int32(0); i < count; i++ {
+		if len(b) < 4 {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for multirange")
+		}
+		n := int32(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if n < 0 || int(n) > len(b) {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range length: %d", n)
+		}
+		r, err := decodeBinaryRange(ctx, evalCtx, rangeTyp, b[:n])
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[n:]
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("extra data after multirange")
+	}
+	return tree.MakeDMultirange(t, ranges), nil
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -239,10 +240,23 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DTSQuery:
+		b.writeLengthPrefixedString(v.TSQuery.String())
+
+	case *tree.DTSVector:
+		b.writeLengthPrefixedString(v.TSVector.String())
+
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Path.String())
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange, *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -535,6 +549,38 @@ func writeBinaryJSON(b *writeBuffer, v json.JSON, t *types.T) {
 	b.writeString(s)
 }
 
+// writeBinaryRange writes the binary encoding of r, without a length prefix,
+// to the buffer. The encoding is a flags byte followed by the length-prefixed
+// encoding of each finite bound.
+func writeBinaryRange(
+	ctx context.Context, b *writeBuffer, r *tree.DRange, sessionLoc *time.Location,
+) {
+	if r.Empty {
+		b.writeByte(pgwirebase.RangeEmpty)
+		return
+	}
+	var flags byte
+	if r.Lower.Inclusive {
+		flags |= pgwirebase.RangeLowerInclusive
+	}
+	if r.Upper.Inclusive {
+		flags |= pgwirebase.RangeUpperInclusive
+	}
+	if r.Lower.IsInfinite() {
+		flags |= pgwirebase.RangeLowerInfinite
+	}
+	if r.Upper.IsInfinite() {
+		flags |= pgwirebase.RangeUpperInfinite
+	}
+	b.writeByte(flags)
+	elemTyp := r.Typ.RangeContents()
+	for _, bound := range [...]tree.RangeBound{r.Lower, r.Upper} {
+		if !bound.IsInfinite() {
+			b.writeBinaryDatum(ctx, bound.Val, sessionLoc, elemTyp)
+		}
+	}
+}
+
 // writeBinaryDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings (floats, ints, chars). It is ignored
 // (and can be nil) for types with a 1:1 datum:type mapping.
@@ -692,6 +738,28 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		writeBinaryRange(ctx, b, v, sessionLoc)
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			rangeLen := b.Len()
+			b.putInt32(int32(0))
+			writeBinaryRange(ctx, b, r, sessionLoc)
+			b.putInt32AtIndex(rangeLen, int32(b.Len()-(rangeLen+4)))
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DVoid:
 		b.putInt32(0)
 
@@ -750,6 +818,31 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON, t)
 
+	case *tree.DTSQuery:
+		data, err := tsearch.EncodeTSQuery(nil, v.TSQuery)
+		if err != nil {
+			b.setError(err)
+			return
+		}
+		b.putInt32(int32(len(data)))
+		b.write(data)
+
+	case *tree.DTSVector:
+		data, err := tsearch.EncodeTSVector(nil, v.TSVector)
+		if err != nil {
+			b.setError(err)
+			return
+		}
+		b.putInt32(int32(len(data)))
+		b.write(data)
+
+	case *tree.DJsonpath:
+		s := v.Path.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.Oid))
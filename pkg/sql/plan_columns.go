@@ -54,6 +54,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *ordinalityNode:
 		return n.columns
+	case *expandNode:
+		return n.columns
 	case *renderNode:
 		return n.columns
 	case *scanNode:
@@ -212,6 +212,11 @@ type planner struct {
 	// auto-commit. This is dependent on information from the optimizer.
 	autoCommit bool
 
+	// procTxnController is set if the current statement is a CALL statement
+	// that is the only statement in an implicit transaction. It allows the
+	// called procedure to commit and roll back the transaction.
+	procTxnController procedureTxnController
+
 	// cancelChecker is used by planNodes to check for cancellation of the associated
 	// query.
 	cancelChecker cancelchecker.CancelChecker
@@ -823,6 +828,7 @@ func (p *planner) resetPlanner(
 	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
 
 	p.autoCommit = false
+	p.procTxnController = nil
 
 	p.schemaResolver.txn = txn
 	p.schemaResolver.sessionDataStack = p.EvalContext().SessionDataStack
@@ -831,6 +837,17 @@ func (p *planner) resetPlanner(
 	p.typeResolutionDbID = descpb.InvalidID
 }
 
+// resetTxn replaces the transaction of the planner while a statement is
+// executing. It is used when a procedure commits or rolls back its
+// transaction and continues in a new one.
+func (p *planner) resetTxn(txn *kv.Txn, txnTS time.Time) {
+	p.txn = txn
+	p.schemaResolver.txn = txn
+	p.evalCatalogBuiltins.Init(p.execCfg.Codec, txn, p.Descriptors())
+	p.extendedEvalCtx.Txn = txn
+	p.extendedEvalCtx.TxnTimestamp = txnTS
+}
+
 // GetReplicationStreamManager returns a ReplicationStreamManager.
 func (p *planner) GetReplicationStreamManager(
 	ctx context.Context,
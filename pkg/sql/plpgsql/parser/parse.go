@@ -0,0 +1,854 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package parser contains the parser for the PL/pgSQL procedural language.
+//
+// The parser is a hand-written recursive descent parser that recognizes the
+// control-flow constructs of PL/pgSQL. The SQL expressions and statements that
+// are embedded in a PL/pgSQL function body are delegated to the SQL parser in
+// pkg/sql/parser.
+package parser
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	sqlparser "github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/scanner"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// Parse parses the body of a PL/pgSQL function. The body must consist of a
+// single block, optionally followed by a semicolon.
+func Parse(body string) (*plpgsqltree.Block, error) {
+	p := parser{src: body}
+	if err := p.scan(); err != nil {
+		return nil, err
+	}
+	var block *plpgsqltree.Block
+	if err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// Panics carrying a parse error are used to unwind the recursive
+				// descent. Any other panic is re-raised.
+				if e, ok := r.(parseError); ok {
+					err = e.error
+					return
+				}
+				panic(r)
+			}
+		}()
+		label := p.parseOptLabel()
+		if !p.peekWord("declare") && !p.peekWord("begin") {
+			p.syntaxError(p.peek(), "expected DECLARE or BEGIN")
+		}
+		block = p.parseBlock(label)
+		p.acceptPunct(";")
+		if t := p.peek(); t.id != 0 {
+			p.syntaxError(t, "unexpected tokens after the end of the function body")
+		}
+		return nil
+	}(); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// token is a lexical token of a PL/pgSQL function body.
+type token struct {
+	id  int32
+	str string
+	// start and end are the byte offsets of the token in the source text.
+	start int
+	end   int
+}
+
+// symType implements the scanner.ScanSymType interface.
+type symType struct {
+	id       int32
+	pos      int32
+	str      string
+	unionVal interface{}
+}
+
+var _ scanner.ScanSymType = &symType{}
+
+// ID implements the scanner.ScanSymType interface.
+func (s *symType) ID() int32 { return s.id }
+
+// SetID implements the scanner.ScanSymType interface.
+func (s *symType) SetID(id int32) { s.id = id }
+
+// Pos implements the scanner.ScanSymType interface.
+func (s *symType) Pos() int32 { return s.pos }
+
+// SetPos implements the scanner.ScanSymType interface.
+func (s *symType) SetPos(pos int32) { s.pos = pos }
+
+// Str implements the scanner.ScanSymType interface.
+func (s *symType) Str() string { return s.str }
+
+// SetStr implements the scanner.ScanSymType interface.
+func (s *symType) SetStr(str string) { s.str = str }
+
+// UnionVal implements the scanner.ScanSymType interface.
+func (s *symType) UnionVal() interface{} { return s.unionVal }
+
+// SetUnionVal implements the scanner.ScanSymType interface.
+func (s *symType) SetUnionVal(val interface{}) { s.unionVal = val }
+
+// parseError wraps errors that are raised as panics during parsing.
+type parseError struct {
+	error
+}
+
+type parser struct {
+	src  string
+	toks []token
+	pos  int
+}
+
+// scan splits the source text into tokens using the SQL scanner.
+func (p *parser) scan() error {
+	var s scanner.Scanner
+	s.Init(p.src)
+	defer s.Cleanup()
+	for {
+		var lval symType
+		s.Scan(&lval)
+		if lval.id == 0 {
+			return nil
+		}
+		if lval.id == lexbase.ERROR {
+			return pgerror.Newf(pgcode.Syntax, "at or near %q: %s", p.src[lval.pos:s.Pos()], lval.str)
+		}
+		p.toks = append(p.toks, token{
+			id:    lval.id,
+			str:   lval.str,
+			start: int(lval.pos),
+			end:   s.Pos(),
+		})
+	}
+}
+
+var eofToken = token{id: 0, str: "EOF"}
+
+func (p *parser) peek() token {
+	return p.peekN(0)
+}
+
+func (p *parser) peekN(n int) token {
+	if p.pos+n >= len(p.toks) {
+		return eofToken
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.id != 0 {
+		p.pos++
+	}
+	return t
+}
+
+// text returns the source text of the given token.
+func (p *parser) text(t token) string {
+	if t.id == 0 {
+		return "EOF"
+	}
+	return p.src[t.start:t.end]
+}
+
+// isWord returns true if the token is an unquoted identifier or keyword.
+func (p *parser) isWord(t token) bool {
+	if t.id == lexbase.IDENT {
+		return p.src[t.start] != '"'
+	}
+	return t.id != 0 && t.id == lexbase.GetKeywordID(t.str)
+}
+
+// isName returns true if the token is an identifier or keyword that can be used
+// as the name of a variable or label.
+func (p *parser) isName(t token) bool {
+	return t.id == lexbase.IDENT || p.isWord(t)
+}
+
+func (p *parser) isKeyword(t token, kw string) bool {
+	return p.isWord(t) && t.str == kw
+}
+
+func (p *parser) isPunct(t token, punct string) bool {
+	return t.id != 0 && !p.isName(t) && p.text(t) == punct
+}
+
+func (p *parser) peekWord(kw string) bool {
+	return p.isKeyword(p.peek(), kw)
+}
+
+func (p *parser) peekPunct(punct string) bool {
+	return p.isPunct(p.peek(), punct)
+}
+
+// acceptWord consumes the next token and returns true if it is the given
+// keyword.
+func (p *parser) acceptWord(kw string) bool {
+	if p.peekWord(kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// acceptPunct consumes the next token and returns true if it is the given
+// punctuation.
+func (p *parser) acceptPunct(punct string) bool {
+	if p.peekPunct(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectWord(kw string) {
+	if !p.acceptWord(kw) {
+		p.syntaxError(p.peek(), "expected %s", strings.ToUpper(kw))
+	}
+}
+
+func (p *parser) expectPunct(punct string) {
+	if !p.acceptPunct(punct) {
+		p.syntaxError(p.peek(), "expected %q", punct)
+	}
+}
+
+// acceptAssign consumes an assignment operator, either := or =, and returns
+// true if one was found.
+func (p *parser) acceptAssign() bool {
+	if p.isPunct(p.peek(), ":") && p.isPunct(p.peekN(1), "=") && p.peek().end == p.peekN(1).start {
+		p.pos += 2
+		return true
+	}
+	return p.acceptPunct("=")
+}
+
+// peekAssign returns true if the token at offset n starts an assignment
+// operator.
+func (p *parser) peekAssign(n int) bool {
+	if p.isPunct(p.peekN(n), ":") && p.isPunct(p.peekN(n+1), "=") {
+		return true
+	}
+	return p.isPunct(p.peekN(n), "=")
+}
+
+func (p *parser) expectName(what string) string {
+	t := p.peek()
+	if !p.isName(t) {
+		p.syntaxError(t, "expected %s", what)
+	}
+	p.next()
+	return t.str
+}
+
+func (p *parser) syntaxError(t token, format string, args ...interface{}) {
+	near := p.text(t)
+	if p.isName(t) {
+		near = t.str
+	}
+	err := pgerror.Newf(pgcode.Syntax, "at or near %q: syntax error", near)
+	err = errors.WithDetailf(err, format, args...)
+	panic(parseError{err})
+}
+
+func (p *parser) raise(err error) {
+	panic(parseError{err})
+}
+
+// parseOptLabel parses an optional <<label>> preceding a block or loop.
+func (p *parser) parseOptLabel() string {
+	if !p.acceptPunct("<<") {
+		return ""
+	}
+	label := p.expectName("label")
+	p.expectPunct(">>")
+	return label
+}
+
+// parseOptEndLabel parses the optional label after the END of a block or loop,
+// and verifies that it matches the label at its beginning.
+func (p *parser) parseOptEndLabel(label string) {
+	t := p.peek()
+	if !p.isName(t) {
+		return
+	}
+	p.next()
+	if label == "" {
+		p.raise(pgerror.Newf(pgcode.Syntax,
+			"end label %q specified for unlabeled block", t.str))
+	}
+	if t.str != label {
+		p.raise(pgerror.Newf(pgcode.Syntax,
+			"end label %q differs from block's label %q", t.str, label))
+	}
+}
+
+// parseBlock parses a block. The label, if any, has already been consumed.
+func (p *parser) parseBlock(label string) *plpgsqltree.Block {
+	block := &plpgsqltree.Block{Label: label}
+	if p.acceptWord("declare") {
+		for !p.peekWord("begin") {
+			if p.peek().id == 0 {
+				p.syntaxError(p.peek(), "expected BEGIN")
+			}
+			block.Decls = append(block.Decls, p.parseDeclaration())
+		}
+	}
+	p.expectWord("begin")
+	block.Body = p.parseStmts("end", "exception")
+	if p.acceptWord("exception") {
+		if !p.peekWord("when") {
+			p.syntaxError(p.peek(), "expected WHEN")
+		}
+		for p.acceptWord("when") {
+			var e plpgsqltree.Exception
+			for {
+				e.Conditions = append(e.Conditions, p.parseCondition(true /* allowOthers */))
+				if !p.acceptWord("or") {
+					break
+				}
+			}
+			p.expectWord("then")
+			e.Body = p.parseStmts("when", "end")
+			block.Exceptions = append(block.Exceptions, e)
+		}
+	}
+	p.expectWord("end")
+	p.parseOptEndLabel(label)
+	return block
+}
+
+// parseDeclaration parses a single variable declaration:
+//
+//	name [ CONSTANT ] type [ NOT NULL ] [ { DEFAULT | := | = } expression ];
+func (p *parser) parseDeclaration() plpgsqltree.Declaration {
+	var decl plpgsqltree.Declaration
+	decl.Var = tree.Name(p.expectName("variable name"))
+	switch {
+	case p.peekWord("alias"):
+		p.raise(unimplemented.New("plpgsql alias", "variable aliases are not yet supported"))
+	case p.peekWord("cursor"), p.peekWord("scroll"):
+		p.raise(unimplemented.New("plpgsql cursor", "cursor declarations are not yet supported"))
+	}
+	decl.Constant = p.acceptWord("constant")
+
+	// The type extends until one of NOT NULL, DEFAULT, an assignment operator
+	// or the end of the declaration.
+	typStart := p.pos
+	p.skipUntil(func(t token) bool {
+		return p.isKeyword(t, "not") || p.isKeyword(t, "default") ||
+			p.isKeyword(t, "collate") || p.isPunct(t, ";") || p.peekAssign(0)
+	})
+	if p.pos == typStart {
+		p.syntaxError(p.peek(), "expected type")
+	}
+	typ, err := sqlparser.GetTypeFromValidSQLSyntax(p.src[p.toks[typStart].start:p.toks[p.pos-1].end])
+	if err != nil {
+		p.raise(err)
+	}
+	decl.Typ = typ
+	if p.peekWord("collate") {
+		p.raise(unimplemented.New("plpgsql collate", "COLLATE is not yet supported in variable declarations"))
+	}
+	if p.acceptWord("not") {
+		p.expectWord("null")
+		decl.NotNull = true
+	}
+	if p.acceptWord("default") || p.acceptAssign() {
+		decl.Default = p.parseExprUntil(func(t token) bool { return p.isPunct(t, ";") })
+	}
+	p.expectPunct(";")
+	if decl.Constant && decl.Default == nil {
+		p.raise(pgerror.Newf(pgcode.Syntax,
+			"variable %q must have a default value, since it's declared CONSTANT", decl.Var))
+	}
+	if decl.NotNull && decl.Default == nil {
+		p.raise(pgerror.Newf(pgcode.Syntax,
+			"variable %q must have a default value, since it's declared NOT NULL", decl.Var))
+	}
+	return decl
+}
+
+// parseCondition parses an error condition in an exception handler or RAISE
+// statement.
+func (p *parser) parseCondition(allowOthers bool) plpgsqltree.Condition {
+	if p.acceptWord("sqlstate") {
+		t := p.next()
+		if t.id != lexbase.SCONST {
+			p.syntaxError(t, "expected SQLSTATE code")
+		}
+		if !isValidSQLState(t.str) {
+			p.raise(pgerror.Newf(pgcode.Syntax, "invalid SQLSTATE code %q", t.str))
+		}
+		return plpgsqltree.Condition{SQLErrState: t.str}
+	}
+	name := p.expectName("condition name")
+	if name == "others" && allowOthers {
+		return plpgsqltree.Condition{SQLErrName: name}
+	}
+	if _, ok := pgcode.PLpgSQLConditionNameToCode[name]; !ok {
+		p.raise(pgerror.Newf(pgcode.UndefinedObject, "unrecognized exception condition %q", name))
+	}
+	return plpgsqltree.Condition{SQLErrName: name}
+}
+
+func isValidSQLState(code string) bool {
+	if len(code) != 5 {
+		return false
+	}
+	for _, c := range code {
+		if !(c >= '0' && c <= '9') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStmts parses a list of statements, which ends at (but does not consume)
+// any of the given keywords.
+func (p *parser) parseStmts(terminators ...string) []plpgsqltree.Statement {
+	var stmts []plpgsqltree.Statement
+	for {
+		t := p.peek()
+		if t.id == 0 {
+			p.syntaxError(t, "expected %s", strings.ToUpper(terminators[0]))
+		}
+		for _, kw := range terminators {
+			if p.isKeyword(t, kw) {
+				return stmts
+			}
+		}
+		stmts = append(stmts, p.parseStmt())
+	}
+}
+
+// parseStmt parses a single statement, including its terminating semicolon.
+func (p *parser) parseStmt() plpgsqltree.Statement {
+	if p.peekPunct("<<") {
+		label := p.parseOptLabel()
+		switch {
+		case p.peekWord("declare"), p.peekWord("begin"):
+			block := p.parseBlock(label)
+			p.expectPunct(";")
+			return block
+		case p.acceptWord("loop"):
+			return p.parseLoop(label)
+		case p.acceptWord("while"):
+			return p.parseWhile(label)
+		case p.peekWord("for"), p.peekWord("foreach"):
+			p.raise(unimplemented.New("plpgsql for", "FOR loops are not yet supported"))
+		}
+		p.syntaxError(p.peek(), "expected block or loop after label")
+	}
+
+	t := p.peek()
+	if p.isWord(t) {
+		switch t.str {
+		case "declare", "begin":
+			// BEGIN is only a nested block if it is not followed by a semicolon,
+			// which would make it a transaction control statement.
+			if t.str == "declare" || !p.isPunct(p.peekN(1), ";") {
+				block := p.parseBlock("")
+				p.expectPunct(";")
+				return block
+			}
+		case "if":
+			p.next()
+			return p.parseIf()
+		case "loop":
+			p.next()
+			return p.parseLoop("")
+		case "while":
+			p.next()
+			return p.parseWhile("")
+		case "exit", "continue":
+			p.next()
+			label, cond := p.parseExitOrContinue()
+			if t.str == "exit" {
+				return &plpgsqltree.Exit{Label: label, Condition: cond}
+			}
+			return &plpgsqltree.Continue{Label: label, Condition: cond}
+		case "return":
+			p.next()
+			return p.parseReturn()
+		case "raise":
+			p.next()
+			return p.parseRaise()
+		case "null":
+			if p.isPunct(p.peekN(1), ";") {
+				p.pos += 2
+				return &plpgsqltree.Null{}
+			}
+		case "commit", "rollback":
+			// COMMIT and ROLLBACK with options, such as AND CHAIN, are parsed
+			// as SQL statements below, and rejected.
+			if p.isPunct(p.peekN(1), ";") {
+				p.pos += 2
+				if t.str == "commit" {
+					return &plpgsqltree.Commit{}
+				}
+				return &plpgsqltree.Rollback{}
+			}
+		case "perform":
+			p.next()
+			start := p.pos
+			p.skipUntil(func(t token) bool { return p.isPunct(t, ";") })
+			if p.pos == start {
+				p.syntaxError(p.peek(), "expected query")
+			}
+			stmt := p.parseSQLStmt("SELECT " + p.src[p.toks[start].start:p.toks[p.pos-1].end])
+			p.expectPunct(";")
+			return &plpgsqltree.Perform{SQLStmt: stmt}
+		case "for", "foreach":
+			p.raise(unimplemented.New("plpgsql for", "FOR loops are not yet supported"))
+		case "case":
+			p.raise(unimplemented.New("plpgsql case", "CASE statements are not yet supported"))
+		case "assert":
+			p.raise(unimplemented.New("plpgsql assert", "ASSERT statements are not yet supported"))
+		case "get":
+			p.raise(unimplemented.New("plpgsql get diagnostics", "GET DIAGNOSTICS is not yet supported"))
+		case "open", "fetch", "move", "close":
+			p.raise(unimplemented.New("plpgsql cursor", "cursor statements are not yet supported"))
+		case "execute":
+			p.raise(unimplemented.New("plpgsql execute", "dynamic SQL with EXECUTE is not yet supported"))
+		}
+		if p.isName(t) && p.peekAssign(1) {
+			p.next()
+			if !p.acceptAssign() {
+				p.syntaxError(p.peek(), "expected assignment")
+			}
+			value := p.parseExprUntil(func(t token) bool { return p.isPunct(t, ";") })
+			p.expectPunct(";")
+			return &plpgsqltree.Assignment{Var: tree.Name(t.str), Value: value}
+		}
+		if p.isName(t) && p.isPunct(p.peekN(1), ".") && p.isName(p.peekN(2)) && p.peekAssign(3) {
+			field := p.peekN(2)
+			p.pos += 3
+			if !p.acceptAssign() {
+				p.syntaxError(p.peek(), "expected assignment")
+			}
+			value := p.parseExprUntil(func(t token) bool { return p.isPunct(t, ";") })
+			p.expectPunct(";")
+			return &plpgsqltree.Assignment{Var: tree.Name(t.str), Field: tree.Name(field.str), Value: value}
+		}
+	}
+	return p.parseExecute()
+}
+
+// parseIf parses an IF statement. The IF keyword has already been consumed.
+func (p *parser) parseIf() *plpgsqltree.If {
+	stmt := &plpgsqltree.If{}
+	stmt.Condition = p.parseExprUntil(p.keyword("then"))
+	p.expectWord("then")
+	stmt.ThenBody = p.parseStmts("elsif", "elseif", "else", "end")
+	for p.acceptWord("elsif") || p.acceptWord("elseif") {
+		var elseIf plpgsqltree.ElseIf
+		elseIf.Condition = p.parseExprUntil(p.keyword("then"))
+		p.expectWord("then")
+		elseIf.Body = p.parseStmts("elsif", "elseif", "else", "end")
+		stmt.ElseIfList = append(stmt.ElseIfList, elseIf)
+	}
+	if p.acceptWord("else") {
+		stmt.ElseBody = p.parseStmts("end")
+	}
+	p.expectWord("end")
+	p.expectWord("if")
+	p.expectPunct(";")
+	return stmt
+}
+
+// parseLoop parses a LOOP statement. The LOOP keyword has already been
+// consumed.
+func (p *parser) parseLoop(label string) *plpgsqltree.Loop {
+	body := p.parseStmts("end")
+	p.parseEndLoop(label)
+	return &plpgsqltree.Loop{Label: label, Body: body}
+}
+
+// parseWhile parses a WHILE statement. The WHILE keyword has already been
+// consumed.
+func (p *parser) parseWhile(label string) *plpgsqltree.While {
+	cond := p.parseExprUntil(p.keyword("loop"))
+	p.expectWord("loop")
+	body := p.parseStmts("end")
+	p.parseEndLoop(label)
+	return &plpgsqltree.While{Label: label, Condition: cond, Body: body}
+}
+
+func (p *parser) parseEndLoop(label string) {
+	p.expectWord("end")
+	p.expectWord("loop")
+	p.parseOptEndLabel(label)
+	p.expectPunct(";")
+}
+
+// parseExitOrContinue parses the remainder of an EXIT or CONTINUE statement:
+//
+//	{ EXIT | CONTINUE } [ label ] [ WHEN condition ];
+func (p *parser) parseExitOrContinue() (label string, cond tree.Expr) {
+	if t := p.peek(); p.isName(t) && !p.isKeyword(t, "when") {
+		label = p.expectName("label")
+	}
+	if p.acceptWord("when") {
+		cond = p.parseExprUntil(func(t token) bool { return p.isPunct(t, ";") })
+	}
+	p.expectPunct(";")
+	return label, cond
+}
+
+// parseReturn parses a RETURN statement. The RETURN keyword has already been
+// consumed.
+func (p *parser) parseReturn() *plpgsqltree.Return {
+	if p.peekWord("next") || p.peekWord("query") {
+		p.raise(unimplemented.New("plpgsql return "+p.peek().str,
+			"RETURN NEXT and RETURN QUERY are not yet supported"))
+	}
+	if p.acceptPunct(";") {
+		return &plpgsqltree.Return{}
+	}
+	expr := p.parseExprUntil(func(t token) bool { return p.isPunct(t, ";") })
+	p.expectPunct(";")
+	return &plpgsqltree.Return{Expr: expr}
+}
+
+var raiseLevels = map[string]plpgsqltree.RaiseLevel{
+	"debug":     plpgsqltree.RaiseDebug,
+	"log":       plpgsqltree.RaiseLog,
+	"info":      plpgsqltree.RaiseInfo,
+	"notice":    plpgsqltree.RaiseNotice,
+	"warning":   plpgsqltree.RaiseWarning,
+	"exception": plpgsqltree.RaiseException,
+}
+
+var raiseOptions = map[string]struct{}{
+	"message": {},
+	"detail":  {},
+	"hint":    {},
+	"errcode": {},
+}
+
+// parseRaise parses a RAISE statement. The RAISE keyword has already been
+// consumed.
+func (p *parser) parseRaise() *plpgsqltree.Raise {
+	stmt := &plpgsqltree.Raise{Level: plpgsqltree.RaiseException}
+	if t := p.peek(); p.isWord(t) {
+		if level, ok := raiseLevels[t.str]; ok {
+			p.next()
+			stmt.Level = level
+		}
+	}
+	endOfParam := func(t token) bool {
+		return p.isPunct(t, ",") || p.isPunct(t, ";") || p.isKeyword(t, "using")
+	}
+	switch t := p.peek(); {
+	case p.isPunct(t, ";"):
+		p.raise(unimplemented.New("plpgsql reraise",
+			"RAISE without arguments is not yet supported"))
+	case t.id == lexbase.SCONST:
+		p.next()
+		stmt.Message = t.str
+		for p.acceptPunct(",") {
+			stmt.Params = append(stmt.Params, p.parseExprUntil(endOfParam))
+		}
+		if n := strings.Count(strings.ReplaceAll(stmt.Message, "%%", ""), "%"); n != len(stmt.Params) {
+			if n > len(stmt.Params) {
+				p.raise(pgerror.New(pgcode.Syntax, "too few parameters specified for RAISE"))
+			}
+			p.raise(pgerror.New(pgcode.Syntax, "too many parameters specified for RAISE"))
+		}
+	case p.isKeyword(t, "using"):
+	default:
+		cond := p.parseCondition(false /* allowOthers */)
+		stmt.Condition = &cond
+	}
+	if p.acceptWord("using") {
+		for {
+			name := p.expectName("RAISE option")
+			if _, ok := raiseOptions[name]; !ok {
+				p.raise(unimplemented.Newf("plpgsql raise option",
+					"RAISE option %s is not yet supported", strings.ToUpper(name)))
+			}
+			for i := range stmt.Options {
+				if stmt.Options[i].Name == strings.ToUpper(name) {
+					p.raise(pgerror.Newf(pgcode.Syntax,
+						"RAISE option already specified: %s", strings.ToUpper(name)))
+				}
+			}
+			if name == "message" && stmt.Message != "" {
+				p.raise(pgerror.New(pgcode.Syntax, "RAISE option already specified: MESSAGE"))
+			}
+			if name == "errcode" && stmt.Condition != nil {
+				p.raise(pgerror.New(pgcode.Syntax, "RAISE option already specified: ERRCODE"))
+			}
+			if !p.acceptAssign() {
+				p.syntaxError(p.peek(), "expected \"=\"")
+			}
+			stmt.Options = append(stmt.Options, plpgsqltree.RaiseOption{
+				Name:  strings.ToUpper(name),
+				Value: p.parseExprUntil(endOfParam),
+			})
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	}
+	if stmt.Message == "" && stmt.Condition == nil && len(stmt.Options) == 0 {
+		p.syntaxError(p.peek(), "expected RAISE message")
+	}
+	p.expectPunct(";")
+	return stmt
+}
+
+// parseExecute parses a SQL statement, which extends until the next
+// semicolon. The statement may contain an INTO clause that names the
+// variables to which the result of the statement is assigned.
+func (p *parser) parseExecute() *plpgsqltree.Execute {
+	first := p.peek()
+	start := p.pos
+	stmt := &plpgsqltree.Execute{}
+	// intoStart and intoEnd are the token indexes of the INTO clause.
+	intoStart, intoEnd := -1, -1
+	skippedInsertInto := !p.isKeyword(first, "insert")
+	depth := 0
+	for {
+		t := p.peek()
+		if t.id == 0 {
+			p.syntaxError(t, "expected \";\"")
+		}
+		if depth == 0 && p.isPunct(t, ";") {
+			break
+		}
+		switch {
+		case p.isPunct(t, "("), p.isPunct(t, "["):
+			depth++
+		case p.isPunct(t, ")"), p.isPunct(t, "]"):
+			depth--
+		case depth == 0 && intoStart == -1 && p.isKeyword(t, "into"):
+			// The first INTO of an INSERT statement is part of the statement.
+			if !skippedInsertInto {
+				skippedInsertInto = true
+				break
+			}
+			intoStart = p.pos
+			p.next()
+			stmt.Strict = p.acceptWord("strict")
+			for {
+				stmt.Target = append(stmt.Target, tree.Name(p.expectName("variable name")))
+				if !p.acceptPunct(",") {
+					break
+				}
+			}
+			intoEnd = p.pos
+			continue
+		}
+		p.next()
+	}
+	if p.pos == start {
+		p.syntaxError(first, "expected statement")
+	}
+	// Reconstruct the statement without its INTO clause.
+	var sql string
+	end := p.toks[p.pos-1].end
+	if intoStart == -1 {
+		sql = p.src[p.toks[start].start:end]
+	} else {
+		sql = p.src[p.toks[start].start:p.toks[intoStart].start]
+		if intoEnd < p.pos {
+			sql += " " + p.src[p.toks[intoEnd].start:end]
+		}
+	}
+	p.expectPunct(";")
+	stmt.SQLStmt = p.parseSQLStmt(sql)
+	return stmt
+}
+
+// parseSQLStmt parses a single SQL statement.
+func (p *parser) parseSQLStmt(sql string) tree.Statement {
+	stmt, err := sqlparser.ParseOne(sql)
+	if err != nil {
+		p.raise(err)
+	}
+	switch stmt.AST.(type) {
+	case *tree.BeginTransaction, *tree.CommitTransaction, *tree.RollbackTransaction,
+		*tree.Savepoint, *tree.ReleaseSavepoint, *tree.RollbackToSavepoint:
+		p.raise(unimplemented.Newf("plpgsql transaction control",
+			"%s is not supported in PL/pgSQL functions", stmt.AST.StatementTag()))
+	}
+	return stmt.AST
+}
+
+// keyword returns a function that returns true if a token is the given
+// keyword.
+func (p *parser) keyword(kw string) func(token) bool {
+	return func(t token) bool { return p.isKeyword(t, kw) }
+}
+
+// skipUntil advances the parser until it reaches a token for which stop returns
+// true, ignoring tokens nested within parentheses, brackets and CASE
+// expressions. The stopping token is not consumed; stop is always called with
+// the token at the current position of the parser.
+func (p *parser) skipUntil(stop func(token) bool) {
+	depth, caseDepth := 0, 0
+	for {
+		t := p.peek()
+		if t.id == 0 {
+			return
+		}
+		if depth == 0 && caseDepth == 0 && stop(t) {
+			return
+		}
+		switch {
+		case p.isPunct(t, "("), p.isPunct(t, "["):
+			depth++
+		case p.isPunct(t, ")"), p.isPunct(t, "]"):
+			depth--
+		case p.isKeyword(t, "case"):
+			caseDepth++
+		case p.isKeyword(t, "end") && caseDepth > 0:
+			caseDepth--
+		case p.isPunct(t, ";"):
+			// Expressions never contain semicolons.
+			return
+		}
+		p.next()
+	}
+}
+
+// parseExprUntil parses a SQL expression that extends until the next token
+// for which stop returns true. The stopping token is not consumed.
+func (p *parser) parseExprUntil(stop func(token) bool) tree.Expr {
+	start := p.pos
+	p.skipUntil(stop)
+	if p.pos == start {
+		p.syntaxError(p.peek(), "expected expression")
+	}
+	if !stop(p.peek()) {
+		p.syntaxError(p.peek(), "unexpected end of expression")
+	}
+	expr, err := sqlparser.ParseExpr(p.src[p.toks[start].start:p.toks[p.pos-1].end])
+	if err != nil {
+		p.raise(err)
+	}
+	return expr
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/datadriven"
+)
+
+// TestParseDatadriven verifies that PL/pgSQL function bodies can be parsed,
+// and that formatting the parsed body produces a body that parses to the same
+// result.
+func TestParseDatadriven(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	datadriven.Walk(t, testutils.TestDataPath(t), func(t *testing.T, path string) {
+		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "parse":
+				block, err := parser.Parse(d.Input)
+				if err != nil {
+					d.Fatalf(t, "unexpected parse error: %v", err)
+				}
+				formatted := tree.AsStringWithFlags(block, tree.FmtSimple)
+				reparsed, err := parser.Parse(formatted)
+				if err != nil {
+					d.Fatalf(t, "unexpected error when reparsing %q: %v", formatted, err)
+				}
+				if s := tree.AsStringWithFlags(reparsed, tree.FmtSimple); s != formatted {
+					d.Fatalf(t, "mismatched AST when reparsing:\nexpected: %s\nactual:   %s", formatted, s)
+				}
+				return formatted
+
+			case "error":
+				_, err := parser.Parse(d.Input)
+				if err == nil {
+					d.Fatalf(t, "expected an error")
+				}
+				pgerr := pgerror.Flatten(err)
+				msg := pgerr.Message
+				if pgerr.Detail != "" {
+					msg += "\nDETAIL: " + pgerr.Detail
+				}
+				return msg
+			}
+			d.Fatalf(t, "unsupported command: %s", d.Cmd)
+			return ""
+		})
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -57,6 +58,35 @@ func NullChance(nullOk bool) (nullChance int) {
 	return nullChance
 }
 
+// randRange generates a random range of the given type. One in ten ranges is
+// empty, and one in five bounds is infinite.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewEmptyDRange(typ)
+	}
+	randBound := func() tree.RangeBound {
+		if rng.Intn(5) == 0 {
+			return tree.RangeBound{}
+		}
+		return tree.RangeBound{
+			Val:       RandDatum(rng, typ.RangeContents(), false /* nullOk */),
+			Inclusive: rng.Intn(2) == 0,
+		}
+	}
+	lower, upper := randBound(), randBound()
+	r, err := tree.MakeDRange(typ, lower, upper)
+	if err != nil {
+		// The bounds may be out of order.
+		lower.Val, upper.Val = upper.Val, lower.Val
+		if r, err = tree.MakeDRange(typ, lower, upper); err != nil {
+			// The bounds could not be canonicalized, e.g. because an inclusive
+			// upper bound is the maximum integer.
+			return tree.NewEmptyDRange(typ)
+		}
+	}
+	return r
+}
+
 // RandDatumWithNullChance generates a random Datum of the given type.
 // nullChance is the chance of returning null, expressed as a fraction
 // denominator. For example, a nullChance of 5 means that there's a 1/5 chance
@@ -185,6 +215,25 @@ func RandDatumWithNullChance(
 			return nil
 		}
 		return &tree.DJSON{JSON: j}
+	case types.TSQueryFamily:
+		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.TSVectorFamily:
+		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
+	case types.JsonpathFamily:
+		p, err := tree.ParseDJsonpath(randJsonpaths[rng.Intn(len(randJsonpaths))])
+		if err != nil {
+			return nil
+		}
+		return p
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultirangeFamily:
+		rangeTyp := types.MakeRange(typ.RangeContents())
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, rangeTyp)
+		}
+		return tree.MakeDMultirange(typ, ranges)
 	case types.TupleFamily:
 		tuple := tree.DTuple{D: make(tree.Datums, len(typ.TupleContents()))}
 		if nullChance == 0 {
@@ -502,6 +551,19 @@ func randJSONSimple(rng *rand.Rand) json.JSON {
 	}
 }
 
+// randJsonpaths is a collection of jsonpath expressions from which random
+// jsonpath datums are chosen.
+var randJsonpaths = []string{
+	`$`,
+	`strict $.a`,
+	`$.a[*]`,
+	`$.a.b[0 to last]`,
+	`$.* ? (@ > 1)`,
+	`$.a ? (@.b == "x" && exists (@.c))`,
+	`$.a.size() + 1`,
+	`$ ? (@ like_regex "^a" flag "i")`,
+}
+
 var (
 	// randInterestingDatums is a collection of interesting datums that can be
 	// used for random testing.
@@ -103,6 +103,14 @@ func (n *renameIndexNode) startExec(params runParams) error {
 		return pgerror.Newf(pgcode.DuplicateRelation, "index name %q already exists", string(n.n.NewName))
 	}
 
+	// The backing index of an exclusion constraint is renamed along with the
+	// constraint.
+	if c := tableDesc.FindExclusionConstraintByIndexID(idx.GetID()); c != nil {
+		if err := renameExclusionConstraint(tableDesc, c, string(n.n.NewName)); err != nil {
+			return err
+		}
+	}
+
 	idx.IndexDesc().Name = string(n.n.NewName)
 
 	if err := validateDescriptor(ctx, p, tableDesc); err != nil {
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -38,16 +39,6 @@ func (p *planner) EvalRoutineExpr(
 		}
 	}
 
-	retTypes := []*types.T{expr.ResolvedType()}
-
-	// The result of the routine is the result of the last statement. The result
-	// of any preceding statements is ignored. We set up a rowResultWriter that
-	// can store the results of the final statement here.
-	var rch rowContainerHelper
-	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
-	defer rch.Close(ctx)
-	rrw := NewRowResultWriter(&rch)
-
 	// Configure stepping for volatile routines so that mutations made by the
 	// invoking statement are visible to the routine.
 	txn := p.Txn()
@@ -65,45 +56,51 @@ func (p *planner) EvalRoutineExpr(
 		}()
 	}
 
-	// Execute each statement in the routine sequentially.
+	// Routines invoked within other statements cannot control the transaction.
+	return p.evalRoutine(ctx, expr, input, nil /* txnCtrl */)
+}
+
+// evalRoutine evaluates the statements of the routine with the given input.
+// If txnCtrl is not nil, the routine is a procedure invoked by a CALL statement
+// that can commit or roll back its transaction.
+func (p *planner) evalRoutine(
+	ctx context.Context,
+	expr *tree.RoutineExpr,
+	input tree.Datums,
+	txnCtrl procedureTxnController,
+) (tree.Datum, error) {
 	ef := newExecFactory(ctx, p)
-	for i := 0; i < expr.NumStmts; i++ {
-		if err := func() error {
-			opName := "udf-stmt-" + expr.Name + "-" + strconv.Itoa(i)
-			ctx, sp := tracing.ChildSpan(ctx, opName)
-			defer sp.Finish()
-
-			// Generate a plan for executing the ith statement.
-			plan, err := expr.PlanFn(ctx, ef, i, input)
-			if err != nil {
-				return err
-			}
 
-			// If this is the last statement, use the rowResultWriter created above.
-			// Otherwise, use a rowResultWriter that drops all rows added to it.
-			var w rowResultWriter
-			if i == expr.NumStmts-1 {
-				w = rrw
-			} else {
-				w = &droppingResultWriter{}
-			}
+	// Routines written in a procedural language execute their statements as
+	// directed by their program.
+	if expr.Program != nil {
+		return p.evalRoutineProgram(ctx, expr, ef, input, txnCtrl)
+	}
 
-			// Place a sequence point before each statement in the routine for
-			// volatile functions.
-			if expr.Volatility == volatility.Volatile {
-				if err := txn.Step(ctx); err != nil {
-					return err
-				}
-			}
+	// The result of a routine that returns VOID is always NULL, so the results
+	// of all of its statements are ignored.
+	isVoid := expr.ResolvedType().Family() == types.VoidFamily
+	retTypes := []*types.T{expr.ResolvedType()}
 
-			// Run the plan.
-			err = runPlanInsidePlan(ctx, p.RunParams(ctx), plan.(*planComponents), w)
-			if err != nil {
-				return err
-			}
+	// The result of the routine is the result of the last statement. The result
+	// of any preceding statements is ignored. We set up a rowResultWriter that
+	// can store the results of the final statement here.
+	var rch rowContainerHelper
+	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
+	defer rch.Close(ctx)
+	rrw := NewRowResultWriter(&rch)
 
-			return nil
-		}(); err != nil {
+	// Execute each statement in the routine sequentially.
+	for i := 0; i < expr.NumStmts; i++ {
+		// If this is the last statement, use the rowResultWriter created above.
+		// Otherwise, use a rowResultWriter that drops all rows added to it.
+		var w rowResultWriter
+		if i == expr.NumStmts-1 && !isVoid {
+			w = rrw
+		} else {
+			w = &droppingResultWriter{}
+		}
+		if err := p.runRoutineStmt(ctx, expr, ef, i, input, w); err != nil {
 			return nil, err
 		}
 	}
@@ -123,17 +120,57 @@ func (p *planner) EvalRoutineExpr(
 	return res[0], nil
 }
 
+// runRoutineStmt plans and runs the statement of the routine with the given
+// index, writing its results to w.
+func (p *planner) runRoutineStmt(
+	ctx context.Context,
+	expr *tree.RoutineExpr,
+	ef tree.RoutineExecFactory,
+	stmtIdx int,
+	input tree.Datums,
+	w rowResultWriter,
+) error {
+	opName := "udf-stmt-" + expr.Name + "-" + strconv.Itoa(stmtIdx)
+	ctx, sp := tracing.ChildSpan(ctx, opName)
+	defer sp.Finish()
+
+	// Generate a plan for executing the statement.
+	plan, err := expr.PlanFn(ctx, ef, stmtIdx, input)
+	if err != nil {
+		return err
+	}
+
+	// Place a sequence point before each statement in the routine for
+	// volatile functions.
+	if expr.Volatility == volatility.Volatile {
+		if err := p.Txn().Step(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Run the plan.
+	return runPlanInsidePlan(ctx, p.RunParams(ctx), plan.(*planComponents), w)
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
 	err error
 }
 
+var _ rowResultWriter = &droppingResultWriter{}
+var _ batchResultWriter = &droppingResultWriter{}
+
 // AddRow is part of the rowResultWriter interface.
 func (d *droppingResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
 	return nil
 }
 
+// AddBatch is part of the batchResultWriter interface.
+func (d *droppingResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // IncrementRowsAffected is part of the rowResultWriter interface.
 func (d *droppingResultWriter) IncrementRowsAffected(ctx context.Context, n int) {}
 
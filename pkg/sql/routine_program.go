@@ -0,0 +1,558 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// evalRoutineProgram evaluates a routine written in a procedural language by
+// executing the instructions of its program.
+func (p *planner) evalRoutineProgram(
+	ctx context.Context,
+	expr *tree.RoutineExpr,
+	ef tree.RoutineExecFactory,
+	input tree.Datums,
+	txnCtrl procedureTxnController,
+) (tree.Datum, error) {
+	e := routineProgramEvaluator{
+		p:       p,
+		expr:    expr,
+		ef:      ef,
+		txnCtrl: txnCtrl,
+		vars:    make(tree.Datums, len(expr.Program.VarTypes)),
+	}
+	copy(e.vars, input)
+	for i := len(input); i < len(e.vars); i++ {
+		e.vars[i] = tree.DNull
+	}
+	sig, err := e.execBlock(ctx, expr.Program.Body)
+	if err != nil {
+		return nil, err
+	}
+	if sig.ctl != routineControlReturn {
+		if expr.ResolvedType().Family() == types.VoidFamily {
+			return tree.DNull, nil
+		}
+		return nil, pgerror.New(pgcode.RoutineExceptionFunctionExecutedNoReturnStatement,
+			"control reached end of function without RETURN")
+	}
+	return e.result, nil
+}
+
+// routineControl describes how the execution of a routine program continues
+// after an instruction.
+type routineControl int
+
+const (
+	// routineControlNone continues with the next instruction.
+	routineControlNone routineControl = iota
+	// routineControlExit exits the innermost loop, or the loop or block with
+	// the given label.
+	routineControlExit
+	// routineControlContinue starts the next iteration of the innermost loop,
+	// or of the loop with the given label.
+	routineControlContinue
+	// routineControlReturn returns from the routine.
+	routineControlReturn
+)
+
+// routineSignal is returned by the execution of an instruction to indicate
+// how execution should continue.
+type routineSignal struct {
+	ctl   routineControl
+	label string
+}
+
+// routineProgramEvaluator holds the state of the evaluation of a routine
+// program.
+type routineProgramEvaluator struct {
+	p    *planner
+	expr *tree.RoutineExpr
+	ef   tree.RoutineExecFactory
+
+	// txnCtrl commits and rolls back the transaction of a procedure. It is nil
+	// if the routine cannot control its transaction.
+	txnCtrl procedureTxnController
+
+	// numSavepoints is the number of blocks with exception handlers that are
+	// currently executing, each of which runs within a savepoint. The
+	// transaction cannot be committed or rolled back within such blocks.
+	numSavepoints int
+
+	// vars contains the current value of each variable slot of the program.
+	// It is used as the input of every statement that is run.
+	vars tree.Datums
+
+	// notNull contains the slots of initialized variables declared NOT NULL.
+	notNull util.FastIntSet
+
+	// result is the value returned by the routine.
+	result tree.Datum
+}
+
+func (e *routineProgramEvaluator) execInstrs(
+	ctx context.Context, instrs []tree.RoutineInstr,
+) (routineSignal, error) {
+	for _, instr := range instrs {
+		sig, err := e.execInstr(ctx, instr)
+		if err != nil || sig.ctl != routineControlNone {
+			return sig, err
+		}
+	}
+	return routineSignal{}, nil
+}
+
+func (e *routineProgramEvaluator) execInstr(
+	ctx context.Context, instr tree.RoutineInstr,
+) (routineSignal, error) {
+	switch t := instr.(type) {
+	case *tree.RoutineBlock:
+		return e.execBlock(ctx, t)
+
+	case *tree.RoutineAssign:
+		d, err := e.evalExpr(ctx, t.Expr)
+		if err != nil {
+			return routineSignal{}, err
+		}
+		return routineSignal{}, e.assign(t.Var, d)
+
+	case *tree.RoutineIf:
+		for i, cond := range t.Conds {
+			ok, err := e.evalCond(ctx, cond)
+			if err != nil {
+				return routineSignal{}, err
+			}
+			if ok {
+				return e.execInstrs(ctx, t.Branches[i])
+			}
+		}
+		return e.execInstrs(ctx, t.Else)
+
+	case *tree.RoutineLoop:
+		return e.execLoop(ctx, t)
+
+	case *tree.RoutineExit:
+		if t.Cond != tree.RoutineNoExpr {
+			ok, err := e.evalCond(ctx, t.Cond)
+			if err != nil || !ok {
+				return routineSignal{}, err
+			}
+		}
+		if t.Continue {
+			return routineSignal{ctl: routineControlContinue, label: t.Label}, nil
+		}
+		return routineSignal{ctl: routineControlExit, label: t.Label}, nil
+
+	case *tree.RoutineReturnInstr:
+		e.result = tree.DNull
+		if t.Expr != tree.RoutineNoExpr {
+			d, err := e.evalExpr(ctx, t.Expr)
+			if err != nil {
+				return routineSignal{}, err
+			}
+			e.result = d
+		}
+		return routineSignal{ctl: routineControlReturn}, nil
+
+	case *tree.RoutineRaise:
+		return routineSignal{}, e.execRaise(ctx, t)
+
+	case *tree.RoutineExec:
+		return routineSignal{}, e.execStmt(ctx, t)
+
+	case *tree.RoutineTxnControl:
+		return routineSignal{}, e.execTxnControl(ctx, t)
+
+	default:
+		return routineSignal{}, errors.AssertionFailedf("unexpected routine instruction %T", instr)
+	}
+}
+
+func (e *routineProgramEvaluator) execBlock(
+	ctx context.Context, block *tree.RoutineBlock,
+) (routineSignal, error) {
+	for _, init := range block.Init {
+		d := tree.Datum(tree.DNull)
+		if init.Expr != tree.RoutineNoExpr {
+			var err error
+			if d, err = e.evalExpr(ctx, init.Expr); err != nil {
+				return routineSignal{}, err
+			}
+		}
+		if init.NotNull {
+			e.notNull.Add(init.Var)
+		} else {
+			e.notNull.Remove(init.Var)
+		}
+		if err := e.assign(init.Var, d); err != nil {
+			return routineSignal{}, err
+		}
+	}
+
+	var sig routineSignal
+	var err error
+	if len(block.Handlers) == 0 {
+		sig, err = e.execInstrs(ctx, block.Body)
+	} else {
+		sig, err = e.execBlockWithHandlers(ctx, block)
+	}
+	if err != nil {
+		return routineSignal{}, err
+	}
+	if sig.ctl == routineControlExit && sig.label != "" && sig.label == block.Label {
+		return routineSignal{}, nil
+	}
+	return sig, nil
+}
+
+// execBlockWithHandlers executes the body of a block that has exception
+// handlers. The body is executed within a savepoint, so that its effects can
+// be rolled back if an error is handled.
+func (e *routineProgramEvaluator) execBlockWithHandlers(
+	ctx context.Context, block *tree.RoutineBlock,
+) (routineSignal, error) {
+	txn := e.p.Txn()
+	if txn.Type() != kv.RootTxn {
+		return routineSignal{}, unimplemented.New("plpgsql exception handling",
+			"exception handlers are not supported in this context")
+	}
+	sp, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		return routineSignal{}, err
+	}
+	e.numSavepoints++
+	sig, err := e.execInstrs(ctx, block.Body)
+	e.numSavepoints--
+	if err == nil {
+		return sig, txn.ReleaseSavepoint(ctx, sp)
+	}
+	handler := findRoutineExceptionHandler(block.Handlers, err)
+	if handler == nil {
+		return routineSignal{}, err
+	}
+	if rollbackErr := txn.RollbackToSavepoint(ctx, sp); rollbackErr != nil {
+		return routineSignal{}, errors.CombineErrors(err, rollbackErr)
+	}
+	return e.execInstrs(ctx, handler.Body)
+}
+
+// findRoutineExceptionHandler returns the first handler that matches the
+// given error, or nil if there is no such handler. Errors that require the
+// transaction to be restarted and internal errors are never handled.
+func findRoutineExceptionHandler(
+	handlers []tree.RoutineExceptionHandler, err error,
+) *tree.RoutineExceptionHandler {
+	code := pgerror.GetPGCode(err).String()
+	class := code[:2]
+	if class == "40" || class == "XX" {
+		return nil
+	}
+	for i := range handlers {
+		h := &handlers[i]
+		if h.Others && code != pgcode.QueryCanceled.String() && code != pgcode.AssertFailure.String() {
+			return h
+		}
+		for _, c := range h.Codes {
+			if c == code || (strings.HasSuffix(c, "000") && c[:2] == class) {
+				return h
+			}
+		}
+	}
+	return nil
+}
+
+func (e *routineProgramEvaluator) execLoop(
+	ctx context.Context, loop *tree.RoutineLoop,
+) (routineSignal, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return routineSignal{}, err
+		}
+		if loop.Cond != tree.RoutineNoExpr {
+			ok, err := e.evalCond(ctx, loop.Cond)
+			if err != nil || !ok {
+				return routineSignal{}, err
+			}
+		}
+		sig, err := e.execInstrs(ctx, loop.Body)
+		if err != nil {
+			return routineSignal{}, err
+		}
+		targetsLoop := sig.label == "" || sig.label == loop.Label
+		switch sig.ctl {
+		case routineControlExit:
+			if targetsLoop {
+				return routineSignal{}, nil
+			}
+			return sig, nil
+		case routineControlContinue:
+			if !targetsLoop {
+				return sig, nil
+			}
+		case routineControlReturn:
+			return sig, nil
+		}
+	}
+}
+
+func (e *routineProgramEvaluator) execStmt(ctx context.Context, stmt *tree.RoutineExec) error {
+	var w routineRowWriter
+	if err := e.runStmt(ctx, stmt.Stmt, &w); err != nil {
+		return err
+	}
+	if len(stmt.Into) == 0 {
+		return nil
+	}
+	if stmt.Strict {
+		if w.numRows == 0 {
+			return pgerror.New(pgcode.NoDataFound, "query returned no rows")
+		}
+		if w.numRows > 1 {
+			return pgerror.New(pgcode.TooManyRows, "query returned more than one row")
+		}
+	}
+	for i, slot := range stmt.Into {
+		d := tree.Datum(tree.DNull)
+		if i < len(w.row) {
+			d = w.row[i]
+		}
+		if err := e.assign(slot, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execTxnControl commits or rolls back the transaction of the procedure, which
+// continues to execute in a new transaction.
+func (e *routineProgramEvaluator) execTxnControl(
+	ctx context.Context, t *tree.RoutineTxnControl,
+) error {
+	if e.txnCtrl == nil {
+		return pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination")
+	}
+	if t.Rollback {
+		if e.numSavepoints > 0 {
+			return pgerror.New(pgcode.InvalidTransactionTermination,
+				"cannot roll back while a subtransaction is active")
+		}
+		return e.txnCtrl.rollbackProcedureTxn(ctx)
+	}
+	if e.numSavepoints > 0 {
+		return pgerror.New(pgcode.InvalidTransactionTermination,
+			"cannot commit while a subtransaction is active")
+	}
+	return e.txnCtrl.commitProcedureTxn(ctx)
+}
+
+// raiseSeverities maps the levels of RAISE statements to the severities of
+// the notices that they send.
+var raiseSeverities = map[string]string{
+	"DEBUG":   "DEBUG1",
+	"LOG":     "LOG",
+	"INFO":    "INFO",
+	"NOTICE":  "NOTICE",
+	"WARNING": "WARNING",
+}
+
+func (e *routineProgramEvaluator) execRaise(ctx context.Context, raise *tree.RoutineRaise) error {
+	params := make([]string, len(raise.Params))
+	for i, param := range raise.Params {
+		d, err := e.evalExpr(ctx, param)
+		if err != nil {
+			return err
+		}
+		params[i] = formatRaiseParam(d)
+	}
+	msg := formatRaiseMessage(raise.Format, params)
+
+	evalOption := func(idx int, dst *string) error {
+		if idx == tree.RoutineNoExpr {
+			return nil
+		}
+		d, err := e.evalExpr(ctx, idx)
+		if err != nil {
+			return err
+		}
+		if d == tree.DNull {
+			return pgerror.New(pgcode.NullValueNotAllowed, "RAISE statement option cannot be null")
+		}
+		*dst = string(tree.MustBeDString(d))
+		return nil
+	}
+	var detail, hint string
+	code := raise.Code
+	if err := evalOption(raise.Message, &msg); err != nil {
+		return err
+	}
+	if err := evalOption(raise.Detail, &detail); err != nil {
+		return err
+	}
+	if err := evalOption(raise.Hint, &hint); err != nil {
+		return err
+	}
+	if err := evalOption(raise.ErrCode, &code); err != nil {
+		return err
+	}
+	if raise.ErrCode != tree.RoutineNoExpr {
+		if codes, ok := pgcode.PLpgSQLConditionNameToCode[code]; ok {
+			code = codes[0]
+		} else if len(code) != 5 {
+			return pgerror.Newf(pgcode.UndefinedObject, "unrecognized exception condition %q", code)
+		}
+	}
+
+	var err error
+	if raise.Severity == "EXCEPTION" {
+		if code == "" {
+			code = pgcode.RaiseException.String()
+		}
+		err = pgerror.New(pgcode.MakeCode(code), msg)
+	} else {
+		err = pgnotice.NewWithSeverityf(raiseSeverities[raise.Severity], "%s", msg)
+	}
+	if detail != "" {
+		err = errors.WithDetail(err, detail)
+	}
+	if hint != "" {
+		err = errors.WithHint(err, hint)
+	}
+	if raise.Severity == "EXCEPTION" {
+		return err
+	}
+	e.p.BufferClientNotice(ctx, pgnotice.Notice(err))
+	return nil
+}
+
+// formatRaiseParam formats a parameter of a RAISE statement in the same way
+// as PL/pgSQL, which formats NULL values as "<NULL>".
+func formatRaiseParam(d tree.Datum) string {
+	if d == tree.DNull {
+		return "<NULL>"
+	}
+	if s, ok := tree.AsDString(d); ok {
+		return string(s)
+	}
+	return tree.AsStringWithFlags(d, tree.FmtPgwireText)
+}
+
+// formatRaiseMessage replaces each "%" in the given format string with the
+// next parameter. "%%" is replaced with a literal "%".
+func formatRaiseMessage(format string, params []string) string {
+	var buf strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			buf.WriteByte(format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			buf.WriteByte('%')
+			i++
+			continue
+		}
+		if len(params) > 0 {
+			buf.WriteString(params[0])
+			params = params[1:]
+		}
+	}
+	return buf.String()
+}
+
+// assign assigns the given value to the variable in the given slot.
+func (e *routineProgramEvaluator) assign(slot int, d tree.Datum) error {
+	if d == tree.DNull && e.notNull.Contains(slot) {
+		return pgerror.Newf(pgcode.NullValueNotAllowed,
+			"null value cannot be assigned to variable %q declared NOT NULL",
+			string(e.expr.Program.VarNames[slot]))
+	}
+	e.vars[slot] = d
+	return nil
+}
+
+// evalExpr returns the value of the first column of the first row returned by
+// the statement with the given index, or NULL if the statement returns no
+// rows.
+func (e *routineProgramEvaluator) evalExpr(ctx context.Context, stmtIdx int) (tree.Datum, error) {
+	var w routineRowWriter
+	if err := e.runStmt(ctx, stmtIdx, &w); err != nil {
+		return nil, err
+	}
+	if len(w.row) == 0 {
+		return tree.DNull, nil
+	}
+	return w.row[0], nil
+}
+
+// evalCond evaluates a boolean condition. NULL is treated as false.
+func (e *routineProgramEvaluator) evalCond(ctx context.Context, stmtIdx int) (bool, error) {
+	d, err := e.evalExpr(ctx, stmtIdx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := d.(*tree.DBool)
+	return ok && bool(*b), nil
+}
+
+func (e *routineProgramEvaluator) runStmt(
+	ctx context.Context, stmtIdx int, w rowResultWriter,
+) error {
+	// The current values of the variables are passed as the input of the
+	// statement. They are copied so that the statement is not affected by
+	// later assignments.
+	input := make(tree.Datums, len(e.vars))
+	copy(input, e.vars)
+	return e.p.runRoutineStmt(ctx, e.expr, e.ef, stmtIdx, input, w)
+}
+
+// routineRowWriter is a rowResultWriter that keeps the first row added to it
+// and counts the number of rows.
+type routineRowWriter struct {
+	row     tree.Datums
+	numRows int
+	err     error
+}
+
+var _ rowResultWriter = &routineRowWriter{}
+
+// AddRow is part of the rowResultWriter interface.
+func (w *routineRowWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if w.numRows == 0 {
+		w.row = make(tree.Datums, len(row))
+		copy(w.row, row)
+	}
+	w.numRows++
+	return nil
+}
+
+// IncrementRowsAffected is part of the rowResultWriter interface.
+func (w *routineRowWriter) IncrementRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (w *routineRowWriter) SetError(err error) {
+	w.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (w *routineRowWriter) Err() error {
+	return w.err
+}
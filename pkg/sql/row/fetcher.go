@@ -387,6 +387,9 @@ func (rf *Fetcher) Init(ctx context.Context, args FetcherInitArgs) error {
 		var batchRequestsIssued int64
 		if args.Txn != nil {
 			fetcherArgs.sendFn = makeKVBatchFetcherDefaultSendFunc(args.Txn, &batchRequestsIssued)
+			fetcherArgs.lockReplicated = getKeyLockingReplicated(
+				args.Txn, getKeyLockingStrength(args.LockStrength),
+			)
 			fetcherArgs.requestAdmissionHeader = args.Txn.AdmissionHeader()
 			fetcherArgs.responseAdmissionQ = args.Txn.DB().SQLKVResponseAdmissionQ
 		}
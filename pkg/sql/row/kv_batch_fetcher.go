@@ -137,6 +137,9 @@ type txnKVFetcher struct {
 	reverse bool
 	// lockStrength represents the locking mode to use when fetching KVs.
 	lockStrength lock.Strength
+	// lockReplicated indicates whether the locks acquired when fetching KVs
+	// are replicated.
+	lockReplicated bool
 	// lockWaitPolicy represents the policy to be used for handling conflicting
 	// locks held by other active transactions.
 	lockWaitPolicy lock.WaitPolicy
@@ -243,6 +246,7 @@ type kvBatchFetcherArgs struct {
 	sendFn                     sendFunc
 	reverse                    bool
 	lockStrength               descpb.ScanLockingStrength
+	lockReplicated             bool
 	lockWaitPolicy             descpb.ScanLockingWaitPolicy
 	lockTimeout                time.Duration
 	acc                        *mon.BoundAccount
@@ -261,6 +265,7 @@ func newKVBatchFetcher(args kvBatchFetcherArgs) *txnKVFetcher {
 		sendFn:                     args.sendFn,
 		reverse:                    args.reverse,
 		lockStrength:               getKeyLockingStrength(args.lockStrength),
+		lockReplicated:             args.lockReplicated,
 		lockWaitPolicy:             getWaitPolicy(args.lockWaitPolicy),
 		lockTimeout:                args.lockTimeout,
 		acc:                        args.acc,
@@ -274,6 +279,7 @@ func newKVBatchFetcher(args kvBatchFetcherArgs) *txnKVFetcher {
 // sendFn are assumed to be non-nil.
 func (f *txnKVFetcher) setTxnAndSendFn(txn *kv.Txn, sendFn sendFunc) {
 	f.sendFn = sendFn
+	f.lockReplicated = getKeyLockingReplicated(txn, f.lockStrength)
 	f.requestAdmissionHeader = txn.AdmissionHeader()
 	f.responseAdmissionQ = txn.DB().SQLKVResponseAdmissionQ
 }
@@ -400,7 +406,9 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 	ba.Header.TargetBytes = int64(f.batchBytesLimit)
 	ba.Header.MaxSpanRequestKeys = int64(f.getBatchKeyLimit())
 	ba.AdmissionHeader = f.requestAdmissionHeader
-	ba.Requests = spansToRequests(f.spans.Spans, f.reverse, f.lockStrength, f.reqsScratch)
+	ba.Requests = spansToRequests(
+		f.spans.Spans, f.reverse, f.lockStrength, f.lockReplicated, f.reqsScratch,
+	)
 
 	if log.ExpensiveLogEnabled(ctx, 2) {
 		log.VEventf(ctx, 2, "Scan %s", f.spans)
@@ -660,12 +668,17 @@ const requestUnionOverhead = int64(unsafe.Sizeof(roachpb.RequestUnion{}))
 // spansToRequests converts the provided spans to the corresponding requests. If
 // a span doesn't have the EndKey set, then a Get request is used for it;
 // otherwise, a Scan (or ReverseScan if reverse is true) request is used with
-// BATCH_RESPONSE format.
+// BATCH_RESPONSE format. The requests acquire locks with the keyLocking
+// strength, which are replicated if keyLockingReplicated is true.
 //
 // The provided reqsScratch is reused if it has enough capacity for all spans,
 // if not, a new slice is allocated.
 func spansToRequests(
-	spans roachpb.Spans, reverse bool, keyLocking lock.Strength, reqsScratch []roachpb.RequestUnion,
+	spans roachpb.Spans,
+	reverse bool,
+	keyLocking lock.Strength,
+	keyLockingReplicated bool,
+	reqsScratch []roachpb.RequestUnion,
 ) []roachpb.RequestUnion {
 	var reqs []roachpb.RequestUnion
 	if cap(reqsScratch) >= len(spans) {
@@ -699,6 +712,7 @@ func spansToRequests(
 				// single key fetch, which can be served using a GetRequest.
 				gets[curGet].req.Key = spans[i].Key
 				gets[curGet].req.KeyLocking = keyLocking
+				gets[curGet].req.KeyLockingReplicated = keyLockingReplicated
 				gets[curGet].union.Get = &gets[curGet].req
 				reqs[i].Value = &gets[curGet].union
 				curGet++
@@ -708,6 +722,7 @@ func spansToRequests(
 			scans[curScan].req.SetSpan(spans[i])
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.KeyLockingReplicated = keyLockingReplicated
 			scans[curScan].union.ReverseScan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
@@ -722,6 +737,7 @@ func spansToRequests(
 				// single key fetch, which can be served using a GetRequest.
 				gets[curGet].req.Key = spans[i].Key
 				gets[curGet].req.KeyLocking = keyLocking
+				gets[curGet].req.KeyLockingReplicated = keyLockingReplicated
 				gets[curGet].union.Get = &gets[curGet].req
 				reqs[i].Value = &gets[curGet].union
 				curGet++
@@ -731,6 +747,7 @@ func spansToRequests(
 			scans[curScan].req.SetSpan(spans[i])
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.KeyLockingReplicated = keyLockingReplicated
 			scans[curScan].union.Scan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
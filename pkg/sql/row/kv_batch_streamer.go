@@ -16,7 +16,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvstreamer"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -25,8 +24,9 @@ import (
 
 // txnKVStreamer handles retrieval of key/values.
 type txnKVStreamer struct {
-	streamer   *kvstreamer.Streamer
-	keyLocking lock.Strength
+	streamer             *kvstreamer.Streamer
+	keyLocking           lock.Strength
+	keyLockingReplicated bool
 
 	spans       roachpb.Spans
 	spanIDs     []int
@@ -49,12 +49,16 @@ var _ KVBatchFetcher = &txnKVStreamer{}
 
 // newTxnKVStreamer creates a new txnKVStreamer.
 func newTxnKVStreamer(
-	streamer *kvstreamer.Streamer, lockStrength descpb.ScanLockingStrength, acc *mon.BoundAccount,
+	streamer *kvstreamer.Streamer,
+	keyLocking lock.Strength,
+	keyLockingReplicated bool,
+	acc *mon.BoundAccount,
 ) KVBatchFetcher {
 	return &txnKVStreamer{
-		streamer:   streamer,
-		keyLocking: getKeyLockingStrength(lockStrength),
-		acc:        acc,
+		streamer:             streamer,
+		keyLocking:           keyLocking,
+		keyLockingReplicated: keyLockingReplicated,
+		acc:                  acc,
 	}
 }
 
@@ -89,7 +93,9 @@ func (f *txnKVStreamer) SetupNextFetch(
 	for i := len(spans); i < len(reqsScratch); i++ {
 		reqsScratch[i] = roachpb.RequestUnion{}
 	}
-	reqs := spansToRequests(spans, false /* reverse */, f.keyLocking, reqsScratch)
+	reqs := spansToRequests(
+		spans, false /* reverse */, f.keyLocking, f.keyLockingReplicated, reqsScratch,
+	)
 	if err := f.streamer.Enqueue(ctx, reqs); err != nil {
 		return err
 	}
@@ -114,6 +114,7 @@ func NewKVFetcher(
 		// this check.
 		fetcherArgs.requestAdmissionHeader = txn.AdmissionHeader()
 		fetcherArgs.responseAdmissionQ = txn.DB().SQLKVResponseAdmissionQ
+		fetcherArgs.lockReplicated = getKeyLockingReplicated(txn, getKeyLockingStrength(lockStrength))
 	}
 	return newKVFetcher(newKVBatchFetcher(fetcherArgs), &batchRequestsIssued)
 }
@@ -138,6 +139,8 @@ func NewStreamingKVFetcher(
 	kvFetcherMemAcc *mon.BoundAccount,
 ) *KVFetcher {
 	var batchRequestsIssued int64
+	keyLocking := getKeyLockingStrength(lockStrength)
+	keyLockingReplicated := getKeyLockingReplicated(txn, keyLocking)
 	streamer := kvstreamer.NewStreamer(
 		distSender,
 		stopper,
@@ -147,7 +150,8 @@ func NewStreamingKVFetcher(
 		streamerBudgetLimit,
 		streamerBudgetAcc,
 		&batchRequestsIssued,
-		getKeyLockingStrength(lockStrength),
+		keyLocking,
+		keyLockingReplicated,
 	)
 	mode := kvstreamer.OutOfOrder
 	if maintainOrdering {
@@ -162,7 +166,10 @@ func NewStreamingKVFetcher(
 		maxKeysPerRow,
 		diskBuffer,
 	)
-	return newKVFetcher(newTxnKVStreamer(streamer, lockStrength, kvFetcherMemAcc), &batchRequestsIssued)
+	return newKVFetcher(
+		newTxnKVStreamer(streamer, keyLocking, keyLockingReplicated, kvFetcherMemAcc),
+		&batchRequestsIssued,
+	)
 }
 
 func newKVFetcher(batchFetcher KVBatchFetcher, batchRequestsIssued *int64) *KVFetcher {
@@ -11,6 +11,8 @@
 package row
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
@@ -27,9 +29,9 @@ func getKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 		// Promote to FOR_SHARE.
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_SHARE:
-		// We currently perform no per-key locking when FOR_SHARE is used
-		// because Shared locks have not yet been implemented.
-		return lock.None
+		// Shared locks are compatible with each other, so FOR_SHARE readers do
+		// not block one another, but they do block writers.
+		return lock.Shared
 
 	case descpb.ScanLockingStrength_FOR_NO_KEY_UPDATE:
 		// Promote to FOR_UPDATE.
@@ -61,3 +63,13 @@ func getWaitPolicy(lockWaitPolicy descpb.ScanLockingWaitPolicy) lock.WaitPolicy
 		panic(errors.AssertionFailedf("unknown wait policy %s", lockWaitPolicy))
 	}
 }
+
+// getKeyLockingReplicated returns whether the per-key locks of the given
+// strength acquired by the transaction must be replicated. READ COMMITTED
+// transactions do not refresh their reads when they commit, so the Shared
+// locks that protect the rows they read must be held until they commit, even
+// across lease transfers and node failures. Unreplicated locks provide no such
+// guarantee.
+func getKeyLockingReplicated(txn *kv.Txn, keyLocking lock.Strength) bool {
+	return keyLocking == lock.Shared && txn != nil && txn.IsoLevel() == isolation.ReadCommitted
+}
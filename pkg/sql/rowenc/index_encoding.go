@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
 	"github.com/cockroachdb/errors"
 )
@@ -604,6 +605,13 @@ func EncodeInvertedIndexTableKeys(
 		// val could be a DOidWrapper, so we need to use the unwrapped datum
 		// here.
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
+	case types.TSVectorFamily:
+		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily:
+		return encodeRangeInvertedIndexTableKeys(tree.MustBeDRange(val), inKey)
+	case types.MultirangeFamily:
+		// Multiranges are indexed by the smallest range that contains them.
+		return encodeRangeInvertedIndexTableKeys(tree.MustBeDMultirange(val).Span(), inKey)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType())
 }
@@ -1032,6 +1040,145 @@ func encodeTrigramInvertedIndexTableKeys(
 	return outKeys, nil
 }
 
+// encodeRangeInvertedIndexTableKeys returns the inverted index keys of a
+// range: a single key for an empty range, and otherwise one key for its lower
+// bound and one for its upper bound. See encoding.RangeInvertedIndexTag.
+func encodeRangeInvertedIndexTableKeys(r *tree.DRange, inKey []byte) ([][]byte, error) {
+	// Make sure to copy inKey into new byte slices to avoid aliasing.
+	prefix := func(tag encoding.RangeInvertedIndexTag) []byte {
+		outKey := make([]byte, len(inKey), len(inKey)+2)
+		copy(outKey, inKey)
+		return encoding.EncodeRangeInvertedIndexKeyPrefix(outKey, tag)
+	}
+	if r.Empty {
+		return [][]byte{prefix(encoding.RangeInvertedIndexEmpty)}, nil
+	}
+	lowerKey, err := keyside.EncodeRangeBound(
+		prefix(encoding.RangeInvertedIndexLower), r.Lower, true /* lower */, encoding.Ascending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	upperKey, err := keyside.EncodeRangeBound(
+		prefix(encoding.RangeInvertedIndexUpper), r.Upper, false /* lower */, encoding.Ascending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{lowerKey, upperKey}, nil
+}
+
+// EncodeOverlappingRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index of a range or multirange column to find the
+// rows that may overlap (&&) the given range. These are the rows with a lower
+// bound not after the upper bound of r, and an upper bound not before the
+// lower bound of r. The spans ignore the inclusivity of the bounds, so the
+// returned expression is not tight.
+//
+// The given range must not be empty, since an empty range overlaps nothing.
+func EncodeOverlappingRangeInvertedIndexSpans(r *tree.DRange) (inverted.Expression, error) {
+	if r.Empty {
+		return nil, errors.AssertionFailedf("cannot encode spans for an empty range")
+	}
+	lowerSpan := rangeInvertedIndexPrefixSpan(encoding.RangeInvertedIndexLower)
+	if !r.Upper.IsInfinite() {
+		end, err := encodeRangeInvertedIndexFiniteBoundPrefix(
+			encoding.RangeInvertedIndexLower, encoding.RangeKeyLowerFinite, r.Upper.Val,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lowerSpan.End = inverted.EncVal(roachpb.Key(end).PrefixEnd())
+	}
+	upperSpan := rangeInvertedIndexPrefixSpan(encoding.RangeInvertedIndexUpper)
+	if !r.Lower.IsInfinite() {
+		start, err := encodeRangeInvertedIndexFiniteBoundPrefix(
+			encoding.RangeInvertedIndexUpper, encoding.RangeKeyUpperFinite, r.Lower.Val,
+		)
+		if err != nil {
+			return nil, err
+		}
+		upperSpan.Start = start
+	}
+	return makeRangeInvertedIndexExpr(lowerSpan, upperSpan), nil
+}
+
+// EncodeContainingRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index of a range or multirange column to find the
+// rows that may contain (@>) the given range. These are the rows with a lower
+// bound not after the lower bound of r, and an upper bound not before the
+// upper bound of r. The spans ignore the inclusivity of the bounds, so the
+// returned expression is not tight.
+//
+// The given range must not be empty, since every range contains the empty
+// range.
+func EncodeContainingRangeInvertedIndexSpans(r *tree.DRange) (inverted.Expression, error) {
+	if r.Empty {
+		return nil, errors.AssertionFailedf("cannot encode spans for an empty range")
+	}
+	lowerSpan := rangeInvertedIndexPrefixSpan(encoding.RangeInvertedIndexLower)
+	if r.Lower.IsInfinite() {
+		infinite := encoding.EncodeRangeKeyTag(
+			lowerSpan.Start, encoding.RangeKeyLowerInfinite, encoding.Ascending,
+		)
+		lowerSpan = inverted.Span{
+			Start: infinite, End: inverted.EncVal(roachpb.Key(infinite).PrefixEnd()),
+		}
+	} else {
+		end, err := encodeRangeInvertedIndexFiniteBoundPrefix(
+			encoding.RangeInvertedIndexLower, encoding.RangeKeyLowerFinite, r.Lower.Val,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lowerSpan.End = inverted.EncVal(roachpb.Key(end).PrefixEnd())
+	}
+	upperSpan := rangeInvertedIndexPrefixSpan(encoding.RangeInvertedIndexUpper)
+	if r.Upper.IsInfinite() {
+		upperSpan.Start = encoding.EncodeRangeKeyTag(
+			upperSpan.Start, encoding.RangeKeyUpperInfinite, encoding.Ascending,
+		)
+	} else {
+		start, err := encodeRangeInvertedIndexFiniteBoundPrefix(
+			encoding.RangeInvertedIndexUpper, encoding.RangeKeyUpperFinite, r.Upper.Val,
+		)
+		if err != nil {
+			return nil, err
+		}
+		upperSpan.Start = start
+	}
+	return makeRangeInvertedIndexExpr(lowerSpan, upperSpan), nil
+}
+
+// rangeInvertedIndexPrefixSpan returns the span of all inverted index keys of
+// ranges with the given tag.
+func rangeInvertedIndexPrefixSpan(tag encoding.RangeInvertedIndexTag) inverted.Span {
+	start := encoding.EncodeRangeInvertedIndexKeyPrefix(nil, tag)
+	return inverted.Span{Start: start, End: inverted.EncVal(roachpb.Key(start).PrefixEnd())}
+}
+
+// encodeRangeInvertedIndexFiniteBoundPrefix returns the prefix of the inverted
+// index keys of the finite bounds with the given value, regardless of their
+// inclusivity.
+func encodeRangeInvertedIndexFiniteBoundPrefix(
+	tag encoding.RangeInvertedIndexTag, finite encoding.RangeKeyTag, val tree.Datum,
+) ([]byte, error) {
+	b := encoding.EncodeRangeInvertedIndexKeyPrefix(nil, tag)
+	b = encoding.EncodeRangeKeyTag(b, finite, encoding.Ascending)
+	return keyside.Encode(b, val, encoding.Ascending)
+}
+
+// makeRangeInvertedIndexExpr returns the intersection of the given spans of
+// lower and upper bounds. Every nonempty range has exactly one lower bound key
+// and one upper bound key, so each of the spans is unique.
+func makeRangeInvertedIndexExpr(lowerSpan, upperSpan inverted.Span) inverted.Expression {
+	lowerExpr := inverted.ExprForSpan(lowerSpan, false /* tight */)
+	lowerExpr.Unique = true
+	upperExpr := inverted.ExprForSpan(upperSpan, false /* tight */)
+	upperExpr.Unique = true
+	return inverted.And(lowerExpr, upperExpr)
+}
+
 // EncodePrimaryIndex constructs a list of k/v pairs for a
 // row encoded as a primary index. This function mirrors the encoding
 // logic in prepareInsertOrUpdateBatch in pkg/sql/row/writer.go.
@@ -150,6 +150,10 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return b, nil
 	case *tree.DArray:
 		return encodeArrayKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
+	case *tree.DMultirange:
+		return encodeMultirangeKey(b, t, dir)
 	case *tree.DCollatedString:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBytesAscending(b, t.Key), nil
@@ -244,6 +244,8 @@ func hasKeyEncoding(typ *types.T) bool {
 		return false
 	case types.ArrayFamily:
 		return hasKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily, types.MultirangeFamily:
+		return hasKeyEncoding(typ.RangeContents())
 	}
 	return true
 }
@@ -0,0 +1,165 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. See
+// encoding.RangeKeyTag for the format.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	b = encoding.EncodeRangeKeyMarker(b, dir)
+	return encodeRangeKeyBody(b, r, dir)
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange: the
+// encodings of its ranges, in order, between a marker and a terminator.
+func encodeMultirangeKey(
+	b []byte, m *tree.DMultirange, dir encoding.Direction,
+) ([]byte, error) {
+	var err error
+	b = encoding.EncodeMultirangeKeyMarker(b, dir)
+	for _, r := range m.Ranges {
+		b, err = encodeRangeKeyBody(b, r, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encoding.EncodeMultirangeKeyTerminator(b, dir), nil
+}
+
+// encodeRangeKeyBody encodes a range without its marker.
+func encodeRangeKeyBody(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	if r.Empty {
+		return encoding.EncodeRangeKeyTag(b, encoding.RangeKeyEmpty, dir), nil
+	}
+	var err error
+	if b, err = EncodeRangeBound(b, r.Lower, true /* lower */, dir); err != nil {
+		return nil, err
+	}
+	return EncodeRangeBound(b, r.Upper, false /* lower */, dir)
+}
+
+// EncodeRangeBound encodes the lower or upper bound of a nonempty range like
+// it is encoded in the key encoding of the range. It is also used for the
+// inverted index keys of ranges.
+func EncodeRangeBound(
+	b []byte, bound tree.RangeBound, lower bool, dir encoding.Direction,
+) ([]byte, error) {
+	if bound.IsInfinite() {
+		tag := encoding.RangeKeyUpperInfinite
+		if lower {
+			tag = encoding.RangeKeyLowerInfinite
+		}
+		return encoding.EncodeRangeKeyTag(b, tag, dir), nil
+	}
+	tag, inclusivity := encoding.RangeKeyUpperFinite, encoding.RangeKeyUpperExclusive
+	if lower {
+		tag, inclusivity = encoding.RangeKeyLowerFinite, encoding.RangeKeyLowerExclusive
+	}
+	if bound.Inclusive {
+		inclusivity = encoding.RangeKeyUpperInclusive
+		if lower {
+			inclusivity = encoding.RangeKeyLowerInclusive
+		}
+	}
+	b = encoding.EncodeRangeKeyTag(b, tag, dir)
+	b, err := Encode(b, bound.Val, dir)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.EncodeRangeKeyTag(b, inclusivity, dir), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	buf, err := encoding.ValidateAndConsumeRangeKeyMarker(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodeRangeKeyBody(a, t, buf, dir)
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	buf, err := encoding.ValidateAndConsumeMultirangeKeyMarker(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeTyp := types.MakeRange(t.RangeContents())
+	var ranges []*tree.DRange
+	for {
+		if len(buf) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		if encoding.IsMultirangeKeyDone(buf, dir) {
+			buf = buf[1:]
+			break
+		}
+		var r *tree.DRange
+		r, buf, err = decodeRangeKeyBody(a, rangeTyp, buf, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	// The ranges of an encoded multirange are already normalized.
+	return &tree.DMultirange{Typ: t, Ranges: ranges}, buf, nil
+}
+
+// decodeRangeKeyBody decodes a range encoded by encodeRangeKeyBody.
+func decodeRangeKeyBody(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (*tree.DRange, []byte, error) {
+	buf, tag, err := encoding.DecodeRangeKeyTag(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tag == encoding.RangeKeyEmpty {
+		return tree.NewEmptyDRange(t), buf, nil
+	}
+	r := &tree.DRange{Typ: t}
+	if tag == encoding.RangeKeyLowerFinite {
+		if r.Lower.Val, buf, err = Decode(a, t.RangeContents(), buf, dir); err != nil {
+			return nil, nil, err
+		}
+		if buf, tag, err = encoding.DecodeRangeKeyTag(buf, dir); err != nil {
+			return nil, nil, err
+		}
+		r.Lower.Inclusive = tag == encoding.RangeKeyLowerInclusive
+	} else if tag != encoding.RangeKeyLowerInfinite {
+		return nil, nil, errors.AssertionFailedf("invalid range lower bound tag %d", tag)
+	}
+	if buf, tag, err = encoding.DecodeRangeKeyTag(buf, dir); err != nil {
+		return nil, nil, err
+	}
+	if tag == encoding.RangeKeyUpperFinite {
+		if r.Upper.Val, buf, err = Decode(a, t.RangeContents(), buf, dir); err != nil {
+			return nil, nil, err
+		}
+		if buf, tag, err = encoding.DecodeRangeKeyTag(buf, dir); err != nil {
+			return nil, nil, err
+		}
+		r.Upper.Inclusive = tag == encoding.RangeKeyUpperInclusive
+	} else if tag != encoding.RangeKeyUpperInfinite {
+		return nil, nil, errors.AssertionFailedf("invalid range upper bound tag %d", tag)
+	}
+	return r, buf, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -232,6 +233,16 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.IPAddr, nil
 	case types.JsonFamily:
 		return encoding.JSON, nil
+	case types.TSQueryFamily:
+		return encoding.TSQuery, nil
+	case types.TSVectorFamily:
+		return encoding.TSVector, nil
+	case types.JsonpathFamily:
+		return encoding.JSONPath, nil
+	case types.RangeFamily:
+		return encoding.Range, nil
+	case types.MultirangeFamily:
+		return encoding.Multirange, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
 	default:
@@ -312,6 +323,32 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 			return nil, err
 		}
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DTSQuery:
+		encoded, err := tsearch.EncodeTSQuery(nil, t.TSQuery)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DTSVector:
+		encoded, err := tsearch.EncodeTSVector(nil, t.TSVector)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeUntaggedBytesValue(b, []byte(t.Path.String())), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DMultirange:
+		encoded, err := encodeMultirange(t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
 	default:
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -188,6 +189,33 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.TSQueryFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		q, err := tsearch.DecodeTSQuery(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTSQuery(tree.DTSQuery{TSQuery: q}), b, nil
+	case types.TSVectorFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		v, err := tsearch.DecodeTSVector(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTSVector(tree.DTSVector{TSVector: v}), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		p, err := tree.ParseDJsonpath(string(data))
+		return p, b, err
 	case types.OidFamily:
 		// TODO: This possibly should decode to uint32 (with corresponding changes
 		// to encoding) to ensure that the value fits in a DOid without any loss of
@@ -203,6 +231,26 @@ func DecodeUntaggedDatum(
 			return nil, nil, err
 		}
 		return decodeArray(a, t, b)
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return r, b, nil
+	case types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		m, _, err := decodeMultirange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return m, b, nil
 	case types.TupleFamily:
 		return decodeTuple(a, t, buf)
 	case types.EnumFamily:
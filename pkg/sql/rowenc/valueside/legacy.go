@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -141,6 +142,29 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.TSQueryFamily:
+		if v, ok := val.(*tree.DTSQuery); ok {
+			data, err := tsearch.EncodeTSQuery(nil, v.TSQuery)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.TSVectorFamily:
+		if v, ok := val.(*tree.DTSVector); ok {
+			data, err := tsearch.EncodeTSVector(nil, v.TSVector)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetBytes([]byte(v.Path.String()))
+			return r, nil
+		}
 	case types.ArrayFamily:
 		if v, ok := val.(*tree.DArray); ok {
 			if err := checkElementType(v.ParamTyp, colType.ArrayContents()); err != nil {
@@ -153,6 +177,24 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(b)
 			return r, nil
 		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			b, err := encodeRange(v, nil)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
+	case types.MultirangeFamily:
+		if v, ok := val.(*tree.DMultirange); ok {
+			b, err := encodeMultirange(v, nil)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
 	case types.CollatedStringFamily:
 		if v, ok := val.(*tree.DCollatedString); ok {
 			if lex.LocaleNamesAreEqual(v.Locale, colType.Locale()) {
@@ -337,6 +379,26 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 		datum, _, err := decodeArray(a, typ, v)
 		// TODO(yuzefovich): do we want to create a new object via tree.DatumAlloc?
 		return datum, err
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeRange(a, typ, v)
+		if err != nil {
+			return nil, err
+		}
+		return datum, nil
+	case types.MultirangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeMultirange(a, typ, v)
+		if err != nil {
+			return nil, err
+		}
+		return datum, nil
 	case types.JsonFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -347,6 +409,32 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.TSQueryFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		q, err := tsearch.DecodeTSQuery(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDTSQuery(q), nil
+	case types.TSVectorFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		vec, err := tsearch.DecodeTSVector(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDTSVector(vec), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJsonpath(string(v))
 	case types.EnumFamily:
 		v, err := value.GetBytes()
 		if err != nil {
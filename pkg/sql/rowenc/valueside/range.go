@@ -0,0 +1,124 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags of the value encoding of a range. They have the same values as
+// the flags of the Postgres binary format of ranges.
+const (
+	rangeEmptyFlag          = 1 << 0
+	rangeLowerInclusiveFlag = 1 << 1
+	rangeUpperInclusiveFlag = 1 << 2
+	rangeLowerInfiniteFlag  = 1 << 3
+	rangeUpperInfiniteFlag  = 1 << 4
+)
+
+// encodeRange produces the value encoding for a range: a flags byte followed
+// by the untagged encodings of its finite bounds.
+func encodeRange(r *tree.DRange, scratch []byte) ([]byte, error) {
+	var flags byte
+	switch {
+	case r.Empty:
+		flags |= rangeEmptyFlag
+	default:
+		if r.Lower.Inclusive {
+			flags |= rangeLowerInclusiveFlag
+		}
+		if r.Upper.Inclusive {
+			flags |= rangeUpperInclusiveFlag
+		}
+		if r.Lower.IsInfinite() {
+			flags |= rangeLowerInfiniteFlag
+		}
+		if r.Upper.IsInfinite() {
+			flags |= rangeUpperInfiniteFlag
+		}
+	}
+	scratch = append(scratch, flags)
+	if r.Empty {
+		return scratch, nil
+	}
+	var err error
+	for _, b := range [...]tree.RangeBound{r.Lower, r.Upper} {
+		if b.IsInfinite() {
+			continue
+		}
+		if scratch, err = encodeArrayElement(scratch, b.Val); err != nil {
+			return nil, err
+		}
+	}
+	return scratch, nil
+}
+
+// encodeMultirange produces the value encoding for a multirange: the number of
+// its ranges followed by their encodings.
+func encodeMultirange(m *tree.DMultirange, scratch []byte) ([]byte, error) {
+	scratch = encoding.EncodeNonsortingUvarint(scratch, uint64(len(m.Ranges)))
+	var err error
+	for _, r := range m.Ranges {
+		if scratch, err = encodeRange(r, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return scratch, nil
+}
+
+// decodeRange decodes the value encoding for a range.
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.Errorf("insufficient bytes to decode range")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmptyFlag != 0 {
+		return tree.NewEmptyDRange(t), b, nil
+	}
+	r := &tree.DRange{Typ: t}
+	var err error
+	if flags&rangeLowerInfiniteFlag == 0 {
+		if r.Lower.Val, b, err = DecodeUntaggedDatum(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		r.Lower.Inclusive = flags&rangeLowerInclusiveFlag != 0
+	}
+	if flags&rangeUpperInfiniteFlag == 0 {
+		if r.Upper.Val, b, err = DecodeUntaggedDatum(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		r.Upper.Inclusive = flags&rangeUpperInclusiveFlag != 0
+	}
+	return r, b, nil
+}
+
+// decodeMultirange decodes the value encoding for a multirange.
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeTyp := types.MakeRange(t.RangeContents())
+	// The ranges of an encoded multirange are already normalized.
+	m := &tree.DMultirange{Typ: t, Ranges: make([]*tree.DRange, n)}
+	for i := range m.Ranges {
+		if m.Ranges[i], b, err = decodeRange(a, rangeTyp, b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m, b, nil
+}
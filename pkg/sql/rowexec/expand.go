@@ -0,0 +1,169 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+)
+
+// expandProcessor is the processor used for GROUPING SETS, ROLLUP and CUBE.
+// For each input row, it emits one row per grouping set containing the input
+// columns, a copy of each of the grouping columns (which is NULL if the
+// column is not part of the grouping set) and the ordinal of the grouping set.
+type expandProcessor struct {
+	execinfra.ProcessorBase
+
+	input     execinfra.RowSource
+	groupCols []uint32
+	// groupingSets contains, for each grouping set, the set of input columns
+	// that are part of the set.
+	groupingSets []util.FastIntSet
+	// groupingSetIDs contains the encoded ordinal of each grouping set.
+	groupingSetIDs rowenc.EncDatumRow
+	// nulls contains a NULL value for each of the groupCols.
+	nulls rowenc.EncDatumRow
+
+	// inputRow is the input row that is currently being expanded, and nextSet
+	// is the ordinal of the next grouping set to emit for it.
+	inputRow rowenc.EncDatumRow
+	nextSet  int
+
+	outputRow rowenc.EncDatumRow
+}
+
+var _ execinfra.Processor = &expandProcessor{}
+var _ execinfra.RowSource = &expandProcessor{}
+
+const expandProcName = "expand"
+
+func newExpandProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.ExpandSpec,
+	input execinfra.RowSource,
+	post *execinfrapb.PostProcessSpec,
+	output execinfra.RowReceiver,
+) (execinfra.RowSourcedProcessor, error) {
+	e := &expandProcessor{
+		input:          input,
+		groupCols:      spec.GroupCols,
+		groupingSets:   make([]util.FastIntSet, len(spec.GroupingSets)),
+		groupingSetIDs: make(rowenc.EncDatumRow, len(spec.GroupingSets)),
+		nulls:          make(rowenc.EncDatumRow, len(spec.GroupCols)),
+	}
+	for i := range spec.GroupingSets {
+		for _, col := range spec.GroupingSets[i].Cols {
+			e.groupingSets[i].Add(int(col))
+		}
+		e.groupingSetIDs[i] = rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(i)))
+	}
+
+	inputTypes := input.OutputTypes()
+	colTypes := make([]*types.T, 0, len(inputTypes)+len(spec.GroupCols)+1)
+	colTypes = append(colTypes, inputTypes...)
+	for i, col := range spec.GroupCols {
+		colTypes = append(colTypes, inputTypes[col])
+		e.nulls[i] = rowenc.DatumToEncDatum(inputTypes[col], tree.DNull)
+	}
+	colTypes = append(colTypes, types.Int)
+	e.outputRow = make(rowenc.EncDatumRow, len(colTypes))
+
+	if err := e.Init(
+		ctx,
+		e,
+		post,
+		colTypes,
+		flowCtx,
+		processorID,
+		output,
+		nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: []execinfra.RowSource{e.input},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if execstats.ShouldCollectStats(ctx, flowCtx.CollectStats) {
+		e.input = newInputStatCollector(e.input)
+		e.ExecStatsForTrace = e.execStatsForTrace
+	}
+
+	return e, nil
+}
+
+// Start is part of the RowSource interface.
+func (e *expandProcessor) Start(ctx context.Context) {
+	ctx = e.StartInternal(ctx, expandProcName)
+	e.input.Start(ctx)
+}
+
+// Next is part of the RowSource interface.
+func (e *expandProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for e.State == execinfra.StateRunning {
+		if e.inputRow == nil || e.nextSet == len(e.groupingSets) {
+			row, meta := e.input.Next()
+
+			if meta != nil {
+				if meta.Err != nil {
+					e.MoveToDraining(nil /* err */)
+				}
+				return nil, meta
+			}
+			if row == nil {
+				e.MoveToDraining(nil /* err */)
+				break
+			}
+			e.inputRow = row
+			e.nextSet = 0
+			if len(e.groupingSets) == 0 {
+				continue
+			}
+		}
+
+		set := e.groupingSets[e.nextSet]
+		n := copy(e.outputRow, e.inputRow)
+		for i, col := range e.groupCols {
+			if set.Contains(int(col)) {
+				e.outputRow[n+i] = e.inputRow[col]
+			} else {
+				e.outputRow[n+i] = e.nulls[i]
+			}
+		}
+		e.outputRow[len(e.outputRow)-1] = e.groupingSetIDs[e.nextSet]
+		e.nextSet++
+		if outRow := e.ProcessRowHelper(e.outputRow); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, e.DrainHelper()
+}
+
+// execStatsForTrace implements ProcessorBase.ExecStatsForTrace.
+func (e *expandProcessor) execStatsForTrace() *execinfrapb.ComponentStats {
+	is, ok := getInputStats(e.input)
+	if !ok {
+		return nil
+	}
+	return &execinfrapb.ComponentStats{
+		Inputs: []execinfrapb.InputStats{is},
+		Output: e.OutputHelper.Stats(),
+	}
+}
@@ -172,6 +172,12 @@ func NewProcessor(
 		}
 		return newOrdinalityProcessor(ctx, flowCtx, processorID, core.Ordinality, inputs[0], post, outputs[0])
 	}
+	if core.Expand != nil {
+		if err := checkNumInOut(inputs, outputs, 1, 1); err != nil {
+			return nil, err
+		}
+		return newExpandProcessor(ctx, flowCtx, processorID, core.Expand, inputs[0], post, outputs[0])
+	}
 	if core.Aggregator != nil {
 		if err := checkNumInOut(inputs, outputs, 1, 1); err != nil {
 			return nil, err
@@ -283,8 +289,19 @@ func NewProcessor(
 			return nil, err
 		}
 
-		if core.Exporter.Format.Format == roachpb.IOFileFormat_Parquet {
+		switch core.Exporter.Format.Format {
+		case roachpb.IOFileFormat_Parquet:
 			return NewParquetWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, inputs[0], outputs[0])
+		case roachpb.IOFileFormat_JSON:
+			if NewJSONWriterProcessor == nil {
+				return nil, errors.New("JSONWriter processor unimplemented")
+			}
+			return NewJSONWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, inputs[0], outputs[0])
+		case roachpb.IOFileFormat_Avro:
+			if NewAvroWriterProcessor == nil {
+				return nil, errors.New("AvroWriter processor unimplemented")
+			}
+			return NewAvroWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, inputs[0], outputs[0])
 		}
 		return NewCSVWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, inputs[0], outputs[0])
 	}
@@ -394,6 +411,12 @@ var NewCSVWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execi
 // NewParquetWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewParquetWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, execinfra.RowSource, execinfra.RowReceiver) (execinfra.Processor, error)
 
+// NewJSONWriterProcessor is implemented in pkg/sql/importer and then injected here via runtime initialization.
+var NewJSONWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, execinfra.RowSource, execinfra.RowReceiver) (execinfra.Processor, error)
+
+// NewAvroWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewAvroWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, execinfra.RowSource, execinfra.RowReceiver) (execinfra.Processor, error)
+
 // NewChangeAggregatorProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewChangeAggregatorProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ChangeAggregatorSpec, *execinfrapb.PostProcessSpec, execinfra.RowReceiver) (execinfra.Processor, error)
 
@@ -369,6 +369,14 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINS)
 			return
+		case '@': // @@
+			s.pos++
+			lval.SetID(lexbase.AT_AT)
+			return
+		case '?': // @?
+			s.pos++
+			lval.SetID(lexbase.JSON_PATH_EXISTS)
+			return
 		}
 		return
 
@@ -393,6 +401,13 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.FETCHVAL)
 			return
+		case '|': // -|
+			if s.peekN(1) == '-' {
+				// -|-
+				s.pos += 2
+				lval.SetID(lexbase.ADJACENT)
+				return
+			}
 		}
 		return
 
@@ -22,6 +22,9 @@ func alterTableAddConstraint(
 	// Extra checks before reaching this point ensured that the command is
 	// an ALTER TABLE ... ADD PRIMARY KEY command.
 	d := t.ConstraintDef.(*tree.UniqueConstraintTableDef)
+	if d.Deferrability != tree.ConstraintNotDeferrable {
+		panic(scerrors.NotImplementedError(t))
+	}
 
 	// Ensure that there is a default rowid column.
 	oldPrimaryIndex := mustRetrievePrimaryIndexElement(b, tbl.TableID)
@@ -258,6 +258,16 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 			}(tbl),
 		})
 	default:
+		// Fall back to legacy schema changer if the table has any triggers, since
+		// the trigger functions hold back-references to the table.
+		if len(tbl.GetTriggers()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, "triggers not supported in declarative schema changer"))
+		}
+		// Exclusion constraints and their backing indexes are only handled by
+		// the legacy schema changer.
+		if len(tbl.GetExclusionConstraints()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, "exclusion constraints not supported in declarative schema changer"))
+		}
 		w.ev(descriptorStatus(tbl), &scpb.Table{
 			TableID:     tbl.GetID(),
 			IsTemporary: tbl.IsTemporary(),
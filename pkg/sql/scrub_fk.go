@@ -69,6 +69,7 @@ func (o *sqlForeignKeyCheckOperation) Start(params runParams) error {
 		o.tableDesc,
 		o.constraint.ForeignKeyDesc(),
 		o.referencedTableDesc,
+		"",    /* rowFilter */
 		false, /* limitResults */
 	)
 	if err != nil {
@@ -89,6 +90,7 @@ func (o *sqlForeignKeyCheckOperation) Start(params runParams) error {
 		checkNullsQuery, _, err := matchFullUnacceptableKeyQuery(
 			o.tableDesc,
 			o.constraint.ForeignKeyDesc(),
+			"",    /* rowFilter */
 			false, /* limitResults */
 		)
 		if err != nil {
@@ -1251,6 +1251,7 @@ const sizeOfDecimalVarPopAggregate = int64(unsafe.Sizeof(decimalVarPopAggregate{
 const sizeOfFloatStdDevAggregate = int64(unsafe.Sizeof(floatStdDevAggregate{}))
 const sizeOfDecimalStdDevAggregate = int64(unsafe.Sizeof(decimalStdDevAggregate{}))
 const sizeOfAnyNotNullAggregate = int64(unsafe.Sizeof(anyNotNullAggregate{}))
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
 const sizeOfConcatAggregate = int64(unsafe.Sizeof(concatAggregate{}))
 const sizeOfBoolAndAggregate = int64(unsafe.Sizeof(boolAndAggregate{}))
 const sizeOfBoolOrAggregate = int64(unsafe.Sizeof(boolOrAggregate{}))
@@ -1424,6 +1425,100 @@ func (a *anyNotNullAggregate) Size() int64 {
 	return sizeOfAnyNotNullAggregate
 }
 
+// userDefinedAggregate evaluates an aggregate created with CREATE AGGREGATE.
+// The state is updated by the transition function for every row, and the
+// result is the final function applied to the state, if there is one.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx *eval.Context
+	def     *tree.UserDefinedAggregate
+	state   tree.Datum
+	// noTransValue is true if the state has not been initialized. As in
+	// Postgres, a strict transition function with no initial condition uses
+	// the first non-NULL argument as the initial state.
+	noTransValue bool
+	// args is scratch space for the input of the transition function.
+	args tree.Datums
+}
+
+// NewUserDefinedAggregate returns an aggregate function that evaluates the
+// given user-defined aggregate. Each call to Add must pass the arguments of
+// the aggregate as a single tuple.
+func NewUserDefinedAggregate(
+	def *tree.UserDefinedAggregate, evalCtx *eval.Context, _ tree.Datums,
+) eval.AggregateFunc {
+	return &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		def:                      def,
+		state:                    def.InitCond,
+		noTransValue:             def.InitCond == tree.DNull,
+	}
+}
+
+// Add updates the state by invoking the transition function with the current
+// state and the arguments in the given tuple.
+func (a *userDefinedAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	args := tree.MustBeDTuple(datum).D
+	if !a.def.Transition.CalledOnNullInput {
+		// A strict transition function is not invoked for rows with NULL
+		// arguments, nor once the state has become NULL.
+		for i := range args {
+			if args[i] == tree.DNull {
+				return nil
+			}
+		}
+		if a.noTransValue {
+			a.noTransValue = false
+			a.state = args[0]
+			return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+		}
+		if a.state == tree.DNull {
+			return nil
+		}
+	}
+	a.args = append(a.args[:0], a.state)
+	a.args = append(a.args, args...)
+	state, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.def.Transition, a.args)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	a.noTransValue = false
+	return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+}
+
+// Result returns the final state, transformed by the final function if the
+// aggregate has one.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.def.Final == nil {
+		return a.state, nil
+	}
+	// Result does not take a context, so the final function is evaluated
+	// without the context of the query.
+	return a.evalCtx.Planner.EvalRoutineExpr(
+		context.TODO(), a.def.Final, tree.Datums{a.state},
+	)
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.def.InitCond
+	a.noTransValue = a.def.InitCond == tree.DNull
+	a.reset(ctx)
+}
+
+// Close implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
 type arrayAggregate struct {
 	arr *tree.DArray
 	// Note that we do not embed singleDatumAggregateBase struct to help with
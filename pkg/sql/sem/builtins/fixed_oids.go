@@ -672,6 +672,7 @@ var builtinOidsBySignature = map[string]oid.Oid{
 	`get_byte(byte_string: bytes, index: int) -> int`:                                                   854,
 	`getdatabaseencoding() -> string`:                                                                   1402,
 	`greatest(anyelement...) -> anyelement`:                                                             981,
+	`grouping(anyelement...) -> int`:                                                                    2047,
 	`has_any_column_privilege(table: string, privilege: string) -> bool`:                                1438,
 	`has_any_column_privilege(table: oid, privilege: string) -> bool`:                                   1439,
 	`has_any_column_privilege(user: string, table: string, privilege: string) -> bool`:                  1440,
@@ -2016,6 +2017,174 @@ var builtinOidsBySignature = map[string]oid.Oid{
 	`with_min_timestamp(min_timestamp: timestamptz, nearest_only: bool) -> timestamptz`:                                                                                             1032,
 	`xor_agg(arg1: bytes) -> bytes`:                                                                                                                                                 248,
 	`xor_agg(arg1: int) -> int`:                                                                                                                                                     249,
+	`to_tsvector(config: string, text: string) -> tsvector`:                                                                                                                         2048,
+	`to_tsvector(text: string) -> tsvector`:                                                                                                                                         2049,
+	`to_tsquery(config: string, text: string) -> tsquery`:                                                                                                                           2050,
+	`to_tsquery(text: string) -> tsquery`:                                                                                                                                           2051,
+	`plainto_tsquery(config: string, text: string) -> tsquery`:                                                                                                                      2052,
+	`plainto_tsquery(text: string) -> tsquery`:                                                                                                                                      2053,
+	`phraseto_tsquery(config: string, text: string) -> tsquery`:                                                                                                                     2054,
+	`phraseto_tsquery(text: string) -> tsquery`:                                                                                                                                     2055,
+	`websearch_to_tsquery(config: string, text: string) -> tsquery`:                                                                                                                 2056,
+	`websearch_to_tsquery(text: string) -> tsquery`:                                                                                                                                 2057,
+	`get_current_ts_config() -> string`:                                                                                                                                             2058,
+	`ts_match_vq(vector: tsvector, query: tsquery) -> bool`:                                                                                                                         2059,
+	`ts_match_qv(query: tsquery, vector: tsvector) -> bool`:                                                                                                                         2060,
+	`ts_rank(weights: float[], vector: tsvector, query: tsquery, normalization: int) -> float4`:                                                                                     2061,
+	`ts_rank(weights: float[], vector: tsvector, query: tsquery) -> float4`:                                                                                                         2062,
+	`ts_rank(vector: tsvector, query: tsquery, normalization: int) -> float4`:                                                                                                       2063,
+	`ts_rank(vector: tsvector, query: tsquery) -> float4`:                                                                                                                           2064,
+	`ts_headline(config: string, document: string, query: tsquery, options: string) -> string`:                                                                                      2065,
+	`ts_headline(config: string, document: string, query: tsquery) -> string`:                                                                                                       2066,
+	`ts_headline(document: string, query: tsquery, options: string) -> string`:                                                                                                      2067,
+	`ts_headline(document: string, query: tsquery) -> string`:                                                                                                                       2068,
+	`array_to_tsvector(lexemes: string[]) -> tsvector`:                                                                                                                              2069,
+	`tsvector_to_array(vector: tsvector) -> string[]`:                                                                                                                               2070,
+	`tsvector_concat(left: tsvector, right: tsvector) -> tsvector`:                                                                                                                  2071,
+	`tsvector_cmp(left: tsvector, right: tsvector) -> int`:                                                                                                                          2072,
+	`strip(vector: tsvector) -> tsvector`:                                                                                                                                           2073,
+	`setweight(vector: tsvector, weight: string) -> tsvector`:                                                                                                                       2074,
+	`setweight(vector: tsvector, weight: string, lexemes: string[]) -> tsvector`:                                                                                                    2075,
+	`ts_delete(vector: tsvector, lexeme: string) -> tsvector`:                                                                                                                       2076,
+	`ts_delete(vector: tsvector, lexemes: string[]) -> tsvector`:                                                                                                                    2077,
+	`ts_filter(vector: tsvector, weights: string[]) -> tsvector`:                                                                                                                    2078,
+	`numnode(query: tsquery) -> int`:                                                                                                                                                2079,
+	`tsquery_phrase(left: tsquery, right: tsquery) -> tsquery`:                                                                                                                      2080,
+	`tsquery_phrase(left: tsquery, right: tsquery, distance: int) -> tsquery`:                                                                                                       2081,
+	`tsqueryin(input: anyelement) -> tsquery`:                                                                                                                                       2082,
+	`tsqueryout(tsquery: tsquery) -> bytes`:                                                                                                                                         2083,
+	`tsqueryrecv(input: anyelement) -> tsquery`:                                                                                                                                     2084,
+	`tsquerysend(tsquery: tsquery) -> bytes`:                                                                                                                                        2085,
+	`tsvectorin(input: anyelement) -> tsvector`:                                                                                                                                     2086,
+	`tsvectorout(tsvector: tsvector) -> bytes`:                                                                                                                                      2087,
+	`tsvectorrecv(input: anyelement) -> tsvector`:                                                                                                                                   2088,
+	`tsvectorsend(tsvector: tsvector) -> bytes`:                                                                                                                                     2089,
+	`lower(range: int8range) -> int`:                                                                                                                                                2090,
+	`upper(range: int8range) -> int`:                                                                                                                                                2091,
+	`isempty(range: int8range) -> bool`:                                                                                                                                             2092,
+	`lower_inc(range: int8range) -> bool`:                                                                                                                                           2093,
+	`upper_inc(range: int8range) -> bool`:                                                                                                                                           2094,
+	`lower_inf(range: int8range) -> bool`:                                                                                                                                           2095,
+	`upper_inf(range: int8range) -> bool`:                                                                                                                                           2096,
+	`lower(multirange: int8multirange) -> int`:                                                                                                                                      2097,
+	`upper(multirange: int8multirange) -> int`:                                                                                                                                      2098,
+	`isempty(multirange: int8multirange) -> bool`:                                                                                                                                   2099,
+	`lower_inc(multirange: int8multirange) -> bool`:                                                                                                                                 2100,
+	`upper_inc(multirange: int8multirange) -> bool`:                                                                                                                                 2101,
+	`lower_inf(multirange: int8multirange) -> bool`:                                                                                                                                 2102,
+	`upper_inf(multirange: int8multirange) -> bool`:                                                                                                                                 2103,
+	`range_merge(a: int8range, b: int8range) -> int8range`:                                                                                                                          2104,
+	`range_merge(multirange: int8multirange) -> int8range`:                                                                                                                          2105,
+	`multirange(range: int8range) -> int8multirange`:                                                                                                                                2106,
+	`lower(range: numrange) -> decimal`:                                                                                                                                             2107,
+	`upper(range: numrange) -> decimal`:                                                                                                                                             2108,
+	`isempty(range: numrange) -> bool`:                                                                                                                                              2109,
+	`lower_inc(range: numrange) -> bool`:                                                                                                                                            2110,
+	`upper_inc(range: numrange) -> bool`:                                                                                                                                            2111,
+	`lower_inf(range: numrange) -> bool`:                                                                                                                                            2112,
+	`upper_inf(range: numrange) -> bool`:                                                                                                                                            2113,
+	`lower(multirange: nummultirange) -> decimal`:                                                                                                                                   2114,
+	`upper(multirange: nummultirange) -> decimal`:                                                                                                                                   2115,
+	`isempty(multirange: nummultirange) -> bool`:                                                                                                                                    2116,
+	`lower_inc(multirange: nummultirange) -> bool`:                                                                                                                                  2117,
+	`upper_inc(multirange: nummultirange) -> bool`:                                                                                                                                  2118,
+	`lower_inf(multirange: nummultirange) -> bool`:                                                                                                                                  2119,
+	`upper_inf(multirange: nummultirange) -> bool`:                                                                                                                                  2120,
+	`range_merge(a: numrange, b: numrange) -> numrange`:                                                                                                                             2121,
+	`range_merge(multirange: nummultirange) -> numrange`:                                                                                                                            2122,
+	`multirange(range: numrange) -> nummultirange`:                                                                                                                                  2123,
+	`lower(range: daterange) -> date`:                                                                                                                                               2124,
+	`upper(range: daterange) -> date`:                                                                                                                                               2125,
+	`isempty(range: daterange) -> bool`:                                                                                                                                             2126,
+	`lower_inc(range: daterange) -> bool`:                                                                                                                                           2127,
+	`upper_inc(range: daterange) -> bool`:                                                                                                                                           2128,
+	`lower_inf(range: daterange) -> bool`:                                                                                                                                           2129,
+	`upper_inf(range: daterange) -> bool`:                                                                                                                                           2130,
+	`lower(multirange: datemultirange) -> date`:                                                                                                                                     2131,
+	`upper(multirange: datemultirange) -> date`:                                                                                                                                     2132,
+	`isempty(multirange: datemultirange) -> bool`:                                                                                                                                   2133,
+	`lower_inc(multirange: datemultirange) -> bool`:                                                                                                                                 2134,
+	`upper_inc(multirange: datemultirange) -> bool`:                                                                                                                                 2135,
+	`lower_inf(multirange: datemultirange) -> bool`:                                                                                                                                 2136,
+	`upper_inf(multirange: datemultirange) -> bool`:                                                                                                                                 2137,
+	`range_merge(a: daterange, b: daterange) -> daterange`:                                                                                                                          2138,
+	`range_merge(multirange: datemultirange) -> daterange`:                                                                                                                          2139,
+	`multirange(range: daterange) -> datemultirange`:                                                                                                                                2140,
+	`lower(range: tsrange) -> timestamp`:                                                                                                                                            2141,
+	`upper(range: tsrange) -> timestamp`:                                                                                                                                            2142,
+	`isempty(range: tsrange) -> bool`:                                                                                                                                               2143,
+	`lower_inc(range: tsrange) -> bool`:                                                                                                                                             2144,
+	`upper_inc(range: tsrange) -> bool`:                                                                                                                                             2145,
+	`lower_inf(range: tsrange) -> bool`:                                                                                                                                             2146,
+	`upper_inf(range: tsrange) -> bool`:                                                                                                                                             2147,
+	`lower(multirange: tsmultirange) -> timestamp`:                                                                                                                                  2148,
+	`upper(multirange: tsmultirange) -> timestamp`:                                                                                                                                  2149,
+	`isempty(multirange: tsmultirange) -> bool`:                                                                                                                                     2150,
+	`lower_inc(multirange: tsmultirange) -> bool`:                                                                                                                                   2151,
+	`upper_inc(multirange: tsmultirange) -> bool`:                                                                                                                                   2152,
+	`lower_inf(multirange: tsmultirange) -> bool`:                                                                                                                                   2153,
+	`upper_inf(multirange: tsmultirange) -> bool`:                                                                                                                                   2154,
+	`range_merge(a: tsrange, b: tsrange) -> tsrange`:                                                                                                                                2155,
+	`range_merge(multirange: tsmultirange) -> tsrange`:                                                                                                                              2156,
+	`multirange(range: tsrange) -> tsmultirange`:                                                                                                                                    2157,
+	`lower(range: tstzrange) -> timestamptz`:                                                                                                                                        2158,
+	`upper(range: tstzrange) -> timestamptz`:                                                                                                                                        2159,
+	`isempty(range: tstzrange) -> bool`:                                                                                                                                             2160,
+	`lower_inc(range: tstzrange) -> bool`:                                                                                                                                           2161,
+	`upper_inc(range: tstzrange) -> bool`:                                                                                                                                           2162,
+	`lower_inf(range: tstzrange) -> bool`:                                                                                                                                           2163,
+	`upper_inf(range: tstzrange) -> bool`:                                                                                                                                           2164,
+	`lower(multirange: tstzmultirange) -> timestamptz`:                                                                                                                              2165,
+	`upper(multirange: tstzmultirange) -> timestamptz`:                                                                                                                              2166,
+	`isempty(multirange: tstzmultirange) -> bool`:                                                                                                                                   2167,
+	`lower_inc(multirange: tstzmultirange) -> bool`:                                                                                                                                 2168,
+	`upper_inc(multirange: tstzmultirange) -> bool`:                                                                                                                                 2169,
+	`lower_inf(multirange: tstzmultirange) -> bool`:                                                                                                                                 2170,
+	`upper_inf(multirange: tstzmultirange) -> bool`:                                                                                                                                 2171,
+	`range_merge(a: tstzrange, b: tstzrange) -> tstzrange`:                                                                                                                          2172,
+	`range_merge(multirange: tstzmultirange) -> tstzrange`:                                                                                                                          2173,
+	`multirange(range: tstzrange) -> tstzmultirange`:                                                                                                                                2174,
+	`int4range(lower: int4, upper: int4) -> int4range`:                                                                                                                              2175,
+	`int4range(lower: int4, upper: int4, bounds: string) -> int4range`:                                                                                                              2176,
+	`int8range(lower: int, upper: int) -> int8range`:                                                                                                                                2177,
+	`int8range(lower: int, upper: int, bounds: string) -> int8range`:                                                                                                                2178,
+	`numrange(lower: decimal, upper: decimal) -> numrange`:                                                                                                                          2179,
+	`numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`:                                                                                                          2180,
+	`daterange(lower: date, upper: date) -> daterange`:                                                                                                                              2181,
+	`daterange(lower: date, upper: date, bounds: string) -> daterange`:                                                                                                              2182,
+	`tsrange(lower: timestamp, upper: timestamp) -> tsrange`:                                                                                                                        2183,
+	`tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`:                                                                                                        2184,
+	`tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`:                                                                                                                2185,
+	`tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`:                                                                                                2186,
+	`range_in(input: anyelement) -> anyrange`:                                                                                                                                       2187,
+	`range_out(anyrange: anyrange) -> bytes`:                                                                                                                                        2188,
+	`range_recv(input: anyelement) -> anyrange`:                                                                                                                                     2189,
+	`range_send(anyrange: anyrange) -> bytes`:                                                                                                                                       2190,
+	`multirange_in(input: anyelement) -> anymultirange`:                                                                                                                             2191,
+	`multirange_out(anymultirange: anymultirange) -> bytes`:                                                                                                                         2192,
+	`multirange_recv(input: anyelement) -> anymultirange`:                                                                                                                           2193,
+	`multirange_send(anymultirange: anymultirange) -> bytes`:                                                                                                                        2194,
+	`jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`:                                                                                                                      2195,
+	`jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`:                                                                                                         2196,
+	`jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`:                                                                                           2197,
+	`jsonb_path_match(target: jsonb, path: jsonpath) -> bool`:                                                                                                                       2198,
+	`jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`:                                                                                                          2199,
+	`jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`:                                                                                            2200,
+	`jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`:                                                                                                                      2201,
+	`jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`:                                                                                                         2202,
+	`jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`:                                                                                           2203,
+	`jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`:                                                                                                                2204,
+	`jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`:                                                                                                   2205,
+	`jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`:                                                                                     2206,
+	`jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`:                                                                                                                2207,
+	`jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`:                                                                                                   2208,
+	`jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`:                                                                                     2209,
+	`jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`:                                                                                                                  2210,
+	`jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`:                                                                                                                   2211,
+	`jsonpath_in(input: anyelement) -> jsonpath`:                                                                                                                                    2212,
+	`jsonpath_out(jsonpath: jsonpath) -> bytes`:                                                                                                                                     2213,
+	`jsonpath_recv(input: anyelement) -> jsonpath`:                                                                                                                                  2214,
+	`jsonpath_send(jsonpath: jsonpath) -> bytes`:                                                                                                                                    2215,
 }
 
 func signatureMustHaveHardcodedOID(sig string) oid.Oid {
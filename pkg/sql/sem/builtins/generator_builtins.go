@@ -370,6 +370,7 @@ var generators = map[string]builtinDefinition{
 	"jsonb_array_elements":      makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsImpl),
 	"json_array_elements_text":  makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsTextImpl),
 	"jsonb_array_elements_text": makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsTextImpl),
+	"jsonb_path_query":          makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonPathQueryImpl, jsonPathQueryWithVarsImpl, jsonPathQueryWithSilentImpl),
 	"json_object_keys":          makeBuiltin(genProps(), jsonObjectKeysImpl),
 	"jsonb_object_keys":         makeBuiltin(genProps(), jsonObjectKeysImpl),
 	"json_each":                 makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachImpl),
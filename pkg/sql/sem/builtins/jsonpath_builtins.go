@@ -0,0 +1,266 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		registerBuiltin(k, v)
+	}
+}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeJSONPathBuiltin(
+		types.Bool,
+		func(p *jsonpath.Path, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			return jsonPathBoolResult(p.Exists(target, vars, silent))
+		},
+		"Checks whether the JSON path returns any item for the specified JSON value.",
+	),
+
+	"jsonb_path_match": makeJSONPathBuiltin(
+		types.Bool,
+		func(p *jsonpath.Path, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			return jsonPathBoolResult(p.Match(target, vars, silent))
+		},
+		"Returns the result of a JSON path predicate check for the specified JSON value.",
+	),
+
+	"jsonb_path_query_array": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(p *jsonpath.Path, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			res, _, err := p.Query(target, vars, silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		},
+		"Returns all JSON items returned by the JSON path for the specified JSON value, as a JSON array.",
+	),
+
+	"jsonb_path_query_first": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(p *jsonpath.Path, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			res, _, err := p.Query(target, vars, silent)
+			if err != nil || len(res) == 0 {
+				return tree.DNull, err
+			}
+			return tree.NewDJSON(res[0]), nil
+		},
+		"Returns the first JSON item returned by the JSON path for the specified JSON value.",
+	),
+
+	// The _opr variants implement the @? and @@ operators.
+	"jsonb_path_exists_opr": makeBuiltin(jsonProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"target", types.Jsonb}, {"path", types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				target, path, _, _ := jsonPathArgs(args)
+				return jsonPathBoolResult(path.Exists(target, nil /* vars */, true /* silent */))
+			},
+			Info:       "Checks whether the JSON path returns any item for the specified JSON value, suppressing errors.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"jsonb_path_match_opr": makeBuiltin(jsonProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"target", types.Jsonb}, {"path", types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				target, path, _, _ := jsonPathArgs(args)
+				return jsonPathBoolResult(path.Match(target, nil /* vars */, true /* silent */))
+			},
+			Info:       "Returns the result of a JSON path predicate check for the specified JSON value, suppressing errors.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
+
+// jsonPathArgs returns the target, path, vars and silent arguments of a
+// JSON path builtin. vars and silent are optional.
+func jsonPathArgs(
+	args tree.Datums,
+) (target json.JSON, path *jsonpath.Path, vars json.JSON, silent bool) {
+	target = tree.MustBeDJSON(args[0]).JSON
+	path = tree.MustBeDJsonpath(args[1]).Path
+	if len(args) > 2 {
+		vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return target, path, vars, silent
+}
+
+// jsonPathBoolResult converts the result of Path.Exists or Path.Match to a
+// datum.
+func jsonPathBoolResult(b, ok bool, err error) (tree.Datum, error) {
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return tree.DNull, nil
+	}
+	return tree.MakeDBool(tree.DBool(b)), nil
+}
+
+// makeJSONPathBuiltin returns the overloads of a builtin that evaluates a
+// JSON path, with and without the optional vars and silent arguments.
+func makeJSONPathBuiltin(
+	ret *types.T,
+	fn func(p *jsonpath.Path, target, vars json.JSON, silent bool) (tree.Datum, error),
+	info string,
+) builtinDefinition {
+	evalFn := func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+		target, path, vars, silent := jsonPathArgs(args)
+		return fn(path, target, vars, silent)
+	}
+	return makeBuiltin(
+		jsonProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"target", types.Jsonb}, {"path", types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(ret),
+			Fn:         evalFn,
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"target", types.Jsonb},
+				{"path", types.Jsonpath},
+				{"vars", types.Jsonb},
+			},
+			ReturnType: tree.FixedReturnType(ret),
+			Fn:         evalFn,
+			Info:       info + " The vars object provides the values of the variables in the path.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"target", types.Jsonb},
+				{"path", types.Jsonpath},
+				{"vars", types.Jsonb},
+				{"silent", types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(ret),
+			Fn:         evalFn,
+			Info: info + " The vars object provides the values of the variables in the path. " +
+				"If silent is true, errors caused by the structure of the JSON value are suppressed.",
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+var jsonPathQueryImpl = makeGeneratorOverload(
+	tree.ArgTypes{{"target", types.Jsonb}, {"path", types.Jsonpath}},
+	jsonArrayGeneratorType,
+	makeJSONPathQueryGenerator,
+	"Returns all JSON items returned by the JSON path for the specified JSON value.",
+	volatility.Immutable,
+)
+
+var jsonPathQueryWithVarsImpl = makeGeneratorOverload(
+	tree.ArgTypes{
+		{"target", types.Jsonb},
+		{"path", types.Jsonpath},
+		{"vars", types.Jsonb},
+	},
+	jsonArrayGeneratorType,
+	makeJSONPathQueryGenerator,
+	"Returns all JSON items returned by the JSON path for the specified JSON value. "+
+		"The vars object provides the values of the variables in the path.",
+	volatility.Immutable,
+)
+
+var jsonPathQueryWithSilentImpl = makeGeneratorOverload(
+	tree.ArgTypes{
+		{"target", types.Jsonb},
+		{"path", types.Jsonpath},
+		{"vars", types.Jsonb},
+		{"silent", types.Bool},
+	},
+	jsonArrayGeneratorType,
+	makeJSONPathQueryGenerator,
+	"Returns all JSON items returned by the JSON path for the specified JSON value. "+
+		"The vars object provides the values of the variables in the path. "+
+		"If silent is true, errors caused by the structure of the JSON value are suppressed.",
+	volatility.Immutable,
+)
+
+// jsonPathQueryGenerator returns the items produced by a JSON path.
+type jsonPathQueryGenerator struct {
+	args      tree.Datums
+	res       []json.JSON
+	nextIndex int
+	buf       [1]tree.Datum
+}
+
+var _ eval.ValueGenerator = &jsonPathQueryGenerator{}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &jsonPathQueryGenerator{args: args}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return jsonArrayGeneratorType
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	target, path, vars, silent := jsonPathArgs(g.args)
+	res, _, err := path.Query(target, vars, silent)
+	if err != nil {
+		return err
+	}
+	g.res = res
+	g.nextIndex = -1
+	return nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	g.nextIndex++
+	if g.nextIndex >= len(g.res) {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.res[g.nextIndex])
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
@@ -84,6 +84,7 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.Timestamp.Oid():   {},
 	types.TimestampTZ.Oid(): {},
 	types.AnyTuple.Oid():    {},
+	types.Jsonpath.Oid():    {},
 }
 
 // PGIOBuiltinPrefix returns the string prefix to a type's IO functions. This
@@ -106,11 +107,13 @@ func init() {
 
 	// Make non-array type i/o builtins.
 	for _, typ := range types.OidToType {
-		// Skip most array types. We're doing them separately below.
+		// Skip most array types, as well as range and multirange types. We're
+		// doing them separately below.
 		switch typ.Oid() {
 		case oid.T_int2vector, oid.T_oidvector:
 		default:
-			if typ.Family() == types.ArrayFamily {
+			switch typ.Family() {
+			case types.ArrayFamily, types.RangeFamily, types.MultirangeFamily:
 				continue
 			}
 		}
@@ -130,6 +133,13 @@ func init() {
 	for name, builtin := range makeTypeIOBuiltins("enum_", types.AnyEnum) {
 		registerBuiltin(name, builtin)
 	}
+	// Make range and multirange type i/o builtins.
+	for name, builtin := range makeTypeIOBuiltins("range_", types.AnyRange) {
+		registerBuiltin(name, builtin)
+	}
+	for name, builtin := range makeTypeIOBuiltins("multirange_", types.AnyMultirange) {
+		registerBuiltin(name, builtin)
+	}
 
 	// Make crdb_internal.create_regfoo and to_regfoo builtins.
 	for _, b := range []struct {
@@ -0,0 +1,216 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	// The range builtins are overloaded for each range and multirange type, so
+	// the overloads are collected by name before being registered.
+	var names []string
+	overloadsByName := make(map[string][]tree.Overload)
+	add := func(name string, o tree.Overload) {
+		if _, ok := overloadsByName[name]; !ok {
+			names = append(names, name)
+		}
+		overloadsByName[name] = append(overloadsByName[name], o)
+	}
+
+	for i, rangeTyp := range types.RangeTypes {
+		multirangeTyp := types.MultirangeTypes[i]
+		for _, t := range []*types.T{rangeTyp, multirangeTyp} {
+			argName := "range"
+			if t.Family() == types.MultirangeFamily {
+				argName = "multirange"
+			}
+			argTypes := tree.ArgTypes{{argName, t}}
+			add("lower", makeRangeBoundBuiltin(argTypes, true /* lower */))
+			add("upper", makeRangeBoundBuiltin(argTypes, false /* lower */))
+			add("isempty", makeRangePropertyBuiltin(argTypes, func(r *tree.DRange) bool {
+				return r.Empty
+			}, "Returns whether the "+argName+" is empty."))
+			add("lower_inc", makeRangePropertyBuiltin(argTypes, func(r *tree.DRange) bool {
+				return !r.Empty && r.Lower.Inclusive
+			}, "Returns whether the lower bound of the "+argName+" is inclusive."))
+			add("upper_inc", makeRangePropertyBuiltin(argTypes, func(r *tree.DRange) bool {
+				return !r.Empty && r.Upper.Inclusive
+			}, "Returns whether the upper bound of the "+argName+" is inclusive."))
+			add("lower_inf", makeRangePropertyBuiltin(argTypes, func(r *tree.DRange) bool {
+				return !r.Empty && r.Lower.IsInfinite()
+			}, "Returns whether the lower bound of the "+argName+" is infinite."))
+			add("upper_inf", makeRangePropertyBuiltin(argTypes, func(r *tree.DRange) bool {
+				return !r.Empty && r.Upper.IsInfinite()
+			}, "Returns whether the upper bound of the "+argName+" is infinite."))
+		}
+
+		add("range_merge", tree.Overload{
+			Types:      tree.ArgTypes{{"a", rangeTyp}, {"b", rangeTyp}},
+			ReturnType: tree.FixedReturnType(rangeTyp),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MergeRanges(tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1])), nil
+			},
+			Info:       "Returns the smallest range which includes both of the given ranges.",
+			Volatility: volatility.Immutable,
+		})
+		add("range_merge", tree.Overload{
+			Types:      tree.ArgTypes{{"multirange", multirangeTyp}},
+			ReturnType: tree.FixedReturnType(rangeTyp),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MustBeDMultirange(args[0]).Span(), nil
+			},
+			Info:       "Returns the smallest range which includes the entire multirange.",
+			Volatility: volatility.Immutable,
+		})
+		add("multirange", tree.Overload{
+			Types:      tree.ArgTypes{{"range", rangeTyp}},
+			ReturnType: tree.FixedReturnType(multirangeTyp),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := tree.MustBeDRange(args[0])
+				return tree.MakeDMultirange(multirangeTyp, []*tree.DRange{r}), nil
+			},
+			Info:       "Returns a multirange containing just the given range.",
+			Volatility: volatility.Immutable,
+		})
+	}
+
+	// Each range type has a constructor function of the same name. INT4RANGE is
+	// not part of types.RangeTypes, since its operators are shared with
+	// INT8RANGE, but it has its own constructor.
+	for _, rangeTyp := range append([]*types.T{types.Int4Range}, types.RangeTypes...) {
+		elemTyp := rangeTyp.RangeContents()
+		name := rangeTyp.PGName()
+		add(name, makeRangeConstructor(rangeTyp, tree.ArgTypes{
+			{"lower", elemTyp}, {"upper", elemTyp},
+		}))
+		add(name, makeRangeConstructor(rangeTyp, tree.ArgTypes{
+			{"lower", elemTyp}, {"upper", elemTyp}, {"bounds", types.String},
+		}))
+	}
+
+	for _, name := range names {
+		registerBuiltin(name, makeBuiltin(
+			tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+			overloadsByName[name]...,
+		))
+	}
+}
+
+// makeRangeBoundBuiltin returns a lower or upper overload for a range or
+// multirange type. The result is NULL if the input is empty or the bound is
+// infinite.
+func makeRangeBoundBuiltin(argTypes tree.ArgTypes, lower bool) tree.Overload {
+	info := "Returns the upper bound of the given " + argTypes[0].Name + "."
+	if lower {
+		info = "Returns the lower bound of the given " + argTypes[0].Name + "."
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(argTypes[0].Typ.RangeContents()),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			r := rangeSpanOf(args[0])
+			if r.Empty {
+				return tree.DNull, nil
+			}
+			b := r.Upper
+			if lower {
+				b = r.Lower
+			}
+			if b.IsInfinite() {
+				return tree.DNull, nil
+			}
+			return b.Val, nil
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeRangePropertyBuiltin returns an overload that computes a boolean
+// property of a range, or of the span of a multirange.
+func makeRangePropertyBuiltin(
+	argTypes tree.ArgTypes, fn func(r *tree.DRange) bool, info string,
+) tree.Overload {
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(fn(rangeSpanOf(args[0])))), nil
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// rangeSpanOf returns the given range, or the span of the given multirange.
+func rangeSpanOf(d tree.Datum) *tree.DRange {
+	switch t := d.(type) {
+	case *tree.DRange:
+		return t
+	case *tree.DMultirange:
+		return t.Span()
+	}
+	panic(errors.AssertionFailedf("expected range or multirange, found %T", d))
+}
+
+// makeRangeConstructor returns an overload of the constructor function of the
+// given range type. A NULL bound is infinite. Without an explicit bounds
+// argument, the range includes its lower bound and excludes its upper bound.
+func makeRangeConstructor(rangeTyp *types.T, argTypes tree.ArgTypes) tree.Overload {
+	info := "Constructs a range with the given bounds, including the lower bound and " +
+		"excluding the upper bound. A NULL bound is infinite."
+	if len(argTypes) == 3 {
+		info = "Constructs a range with the given bounds, whose inclusivity is given by " +
+			"the bounds argument: one of '[]', '[)', '(]' or '()'. A NULL bound is infinite."
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(rangeTyp),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			lower, upper := tree.RangeBound{Inclusive: true}, tree.RangeBound{}
+			if len(args) == 3 {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException, "range constructor flags argument must not be null")
+				}
+				bounds := string(tree.MustBeDString(args[2]))
+				if len(bounds) != 2 ||
+					(bounds[0] != '[' && bounds[0] != '(') || (bounds[1] != ']' && bounds[1] != ')') {
+					return nil, errors.WithHint(
+						pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+						`Valid values are "[]", "[)", "(]", and "()".`,
+					)
+				}
+				lower.Inclusive = bounds[0] == '['
+				upper.Inclusive = bounds[1] == ']'
+			}
+			if args[0] != tree.DNull {
+				lower.Val = args[0]
+			}
+			if args[1] != tree.DNull {
+				upper.Val = args[1]
+			}
+			return tree.MakeDRange(rangeTyp, lower, upper)
+		},
+		Info:              info,
+		Volatility:        volatility.Immutable,
+		CalledOnNullInput: true,
+	}
+}
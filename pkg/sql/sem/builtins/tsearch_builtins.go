@@ -0,0 +1,530 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func init() {
+	for k, v := range tsearchBuiltins {
+		v.props.Category = builtinconstants.CategoryFullTextSearch
+		registerBuiltin(k, v)
+	}
+}
+
+// currentTextSearchConfig returns the text search configuration used by the
+// builtins that aren't given one explicitly.
+func currentTextSearchConfig(evalCtx *eval.Context) string {
+	if config := evalCtx.SessionData().DefaultTextSearchConfig; config != "" {
+		return config
+	}
+	return tsearch.DefaultConfig
+}
+
+// makeTSQueryBuiltin returns the overloads for a builtin that converts text to
+// a TSQuery, with and without an explicit text search configuration.
+func makeTSQueryBuiltin(
+	fn func(config string, input string) (tsearch.TSQuery, error), info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"config", types.String}, {"text", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q, err := fn(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info:       info + " using the given text search configuration.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"text", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q, err := fn(currentTextSearchConfig(evalCtx), string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info:       info + " using the default_text_search_config.",
+			Volatility: volatility.Stable,
+		},
+	)
+}
+
+// lexemesFromArray returns the strings in the input array, which may not
+// contain NULLs.
+func lexemesFromArray(arr *tree.DArray) ([]string, error) {
+	if arr.HasNulls {
+		return nil, pgerror.New(pgcode.NullValueNotAllowed, "lexeme array may not contain nulls")
+	}
+	ret := make([]string, len(arr.Array))
+	for i, d := range arr.Array {
+		ret[i] = string(tree.MustBeDString(d))
+	}
+	return ret, nil
+}
+
+// rankWeightsFromArray returns the ts_rank weights in the input array.
+func rankWeightsFromArray(arr *tree.DArray) ([4]float32, error) {
+	if arr.HasNulls {
+		return [4]float32{}, pgerror.New(pgcode.NullValueNotAllowed, "array of weight must not contain nulls")
+	}
+	weights := make([]float32, len(arr.Array))
+	for i, d := range arr.Array {
+		weights[i] = float32(tree.MustBeDFloat(d))
+	}
+	return tsearch.MakeRankWeights(weights)
+}
+
+// makeTSRankOverload returns a ts_rank overload. The vector and query are
+// preceded by the weights if hasWeights is set, and followed by the
+// normalization if hasNormalization is set.
+func makeTSRankOverload(argTypes tree.ArgTypes, hasWeights, hasNormalization bool) tree.Overload {
+	info := "Ranks the document for the query, based on how frequently its lexemes appear"
+	if hasWeights {
+		info += ", using the given weights for lexemes with weights D, C, B and A"
+	}
+	if hasNormalization {
+		info += ". The normalization controls how the document's length affects its rank"
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(types.Float4),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			weights := tsearch.DefaultRankWeights
+			vectorIdx := 0
+			if hasWeights {
+				var err error
+				weights, err = rankWeightsFromArray(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				vectorIdx = 1
+			}
+			var method int
+			if hasNormalization {
+				method = int(tree.MustBeDInt(args[len(args)-1]))
+			}
+			v := tree.MustBeDTSVector(args[vectorIdx]).TSVector
+			q := tree.MustBeDTSQuery(args[vectorIdx+1]).TSQuery
+			return tree.NewDFloat(tree.DFloat(tsearch.Rank(weights, v, q, method))), nil
+		},
+		Info:       info + ".",
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeTSHeadlineOverload returns a ts_headline overload. The document and
+// query are preceded by the config if hasConfig is set, and followed by the
+// options if hasOptions is set.
+func makeTSHeadlineOverload(hasConfig, hasOptions bool) tree.Overload {
+	var argTypes tree.ArgTypes
+	if hasConfig {
+		argTypes = append(argTypes, tree.ArgTypes{{"config", types.String}}...)
+	}
+	argTypes = append(argTypes, tree.ArgTypes{{"document", types.String}, {"query", types.TSQuery}}...)
+	if hasOptions {
+		argTypes = append(argTypes, tree.ArgTypes{{"options", types.String}}...)
+	}
+	info := "Returns an excerpt of the document with the words that match the query highlighted"
+	vol := volatility.Immutable
+	if hasConfig {
+		info += ", using the given text search configuration"
+	} else {
+		info += ", using the default_text_search_config"
+		vol = volatility.Stable
+	}
+	if hasOptions {
+		info += ". The options control the length and highlighting of the excerpt"
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(types.String),
+		Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			var config string
+			if hasConfig {
+				config = string(tree.MustBeDString(args[0]))
+				args = args[1:]
+			} else {
+				config = currentTextSearchConfig(evalCtx)
+			}
+			var options string
+			if hasOptions {
+				options = string(tree.MustBeDString(args[2]))
+			}
+			ret, err := tsearch.Headline(
+				config, string(tree.MustBeDString(args[0])), tree.MustBeDTSQuery(args[1]).TSQuery, options,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDString(ret), nil
+		},
+		Info:       info + ".",
+		Volatility: vol,
+	}
+}
+
+var tsearchBuiltins = map[string]builtinDefinition{
+	"to_tsvector": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"config", types.String}, {"text", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v, err := tsearch.ToTSVector(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info: "Converts text to a tsvector, normalizing words according to the given text " +
+				"search configuration. Positions are included in the result.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"text", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v, err := tsearch.ToTSVector(currentTextSearchConfig(evalCtx), string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info: "Converts text to a tsvector, normalizing words according to the " +
+				"default_text_search_config. Positions are included in the result.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"to_tsquery": makeTSQueryBuiltin(
+		tsearch.ToTSQuery,
+		"Converts the input text, which must use the tsquery syntax, to a tsquery, "+
+			"normalizing words",
+	),
+	"plainto_tsquery": makeTSQueryBuiltin(
+		tsearch.PlainToTSQuery,
+		"Converts text to a tsquery that matches documents that contain all of its words, "+
+			"normalizing words",
+	),
+	"phraseto_tsquery": makeTSQueryBuiltin(
+		tsearch.PhraseToTSQuery,
+		"Converts text to a tsquery that matches documents that contain its words in "+
+			"the same order, normalizing words",
+	),
+	"websearch_to_tsquery": makeTSQueryBuiltin(
+		tsearch.WebSearchToTSQuery,
+		"Converts text written in a web search engine syntax, which supports quoted phrases, "+
+			"OR and -, to a tsquery, normalizing words",
+	),
+
+	"get_current_ts_config": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDString(strings.TrimPrefix(currentTextSearchConfig(evalCtx), "pg_catalog.")), nil
+			},
+			Info:       "Returns the name of the current default text search configuration.",
+			Volatility: volatility.Stable,
+		},
+	),
+
+	"ts_match_vq": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ret, err := tsearch.EvalTSQuery(tree.MustBeDTSQuery(args[1]).TSQuery, tree.MustBeDTSVector(args[0]).TSVector)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ret)), nil
+			},
+			Info:       "Returns true if the vector matches the query. Equivalent to vector @@ query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_qv": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.TSQuery}, {"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ret, err := tsearch.EvalTSQuery(tree.MustBeDTSQuery(args[0]).TSQuery, tree.MustBeDTSVector(args[1]).TSVector)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ret)), nil
+			},
+			Info:       "Returns true if the vector matches the query. Equivalent to query @@ vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"ts_rank": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		makeTSRankOverload(
+			tree.ArgTypes{{"weights", types.FloatArray}, {"vector", types.TSVector}, {"query", types.TSQuery}, {"normalization", types.Int}},
+			true, /* hasWeights */
+			true, /* hasNormalization */
+		),
+		makeTSRankOverload(
+			tree.ArgTypes{{"weights", types.FloatArray}, {"vector", types.TSVector}, {"query", types.TSQuery}},
+			true,  /* hasWeights */
+			false, /* hasNormalization */
+		),
+		makeTSRankOverload(
+			tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}, {"normalization", types.Int}},
+			false, /* hasWeights */
+			true,  /* hasNormalization */
+		),
+		makeTSRankOverload(
+			tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			false, /* hasWeights */
+			false, /* hasNormalization */
+		),
+	),
+
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		makeTSHeadlineOverload(true /* hasConfig */, true /* hasOptions */),
+		makeTSHeadlineOverload(true /* hasConfig */, false /* hasOptions */),
+		makeTSHeadlineOverload(false /* hasConfig */, true /* hasOptions */),
+		makeTSHeadlineOverload(false /* hasConfig */, false /* hasOptions */),
+	),
+
+	"array_to_tsvector": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"lexemes", types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := lexemesFromArray(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				v, err := tsearch.ArrayToTSVector(lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Converts an array of lexemes to a tsvector without positions.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_to_array": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes := tree.MustBeDTSVector(args[0]).Lexemes()
+				ret := tree.NewDArray(types.String)
+				ret.Array = make(tree.Datums, 0, len(lexemes))
+				for i := range lexemes {
+					if err := ret.Append(tree.NewDString(lexemes[i])); err != nil {
+						return nil, err
+					}
+				}
+				return ret, nil
+			},
+			Info:       "Converts a tsvector to an array of its lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_concat": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"left", types.TSVector}, {"right", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSVector(args[0]).TSVector, tree.MustBeDTSVector(args[1]).TSVector
+				return tree.NewDTSVector(l.Concat(r)), nil
+			},
+			Info: "Concatenates two tsvectors. The positions of the right vector are offset " +
+				"by the largest position of the left vector. Equivalent to left || right.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_cmp": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"left", types.TSVector}, {"right", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSVector(args[0]).TSVector, tree.MustBeDTSVector(args[1]).TSVector
+				return tree.NewDInt(tree.DInt(l.Compare(r))), nil
+			},
+			Info:       "Returns -1, 0 or 1 depending on whether left sorts before, the same as or after right.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strip": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDTSVector(tree.MustBeDTSVector(args[0]).Strip()), nil
+			},
+			Info:       "Removes the positions and weights from a tsvector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"weight", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v, err := tree.MustBeDTSVector(args[0]).SetWeight(string(tree.MustBeDString(args[1])), nil /* lexemes */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Sets the weight of each position in the tsvector to the given weight.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"weight", types.String}, {"lexemes", types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := lexemesFromArray(tree.MustBeDArray(args[2]))
+				if err != nil {
+					return nil, err
+				}
+				v, err := tree.MustBeDTSVector(args[0]).SetWeight(string(tree.MustBeDString(args[1])), lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Sets the weight of the positions of the given lexemes in the tsvector to the given weight.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_delete": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"lexeme", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0]).Delete(string(tree.MustBeDString(args[1])))
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Removes the given lexeme from the tsvector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"lexemes", types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := lexemesFromArray(tree.MustBeDArray(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(tree.MustBeDTSVector(args[0]).Delete(lexemes...)), nil
+			},
+			Info:       "Removes the given lexemes from the tsvector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_filter": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"weights", types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[1])
+				if arr.HasNulls {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "weight array may not contain nulls")
+				}
+				weights := make([]string, len(arr.Array))
+				for i, d := range arr.Array {
+					weights[i] = string(tree.MustBeDString(d))
+				}
+				v, err := tree.MustBeDTSVector(args[0]).Filter(weights)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Returns the tsvector with only the positions that have one of the given weights.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"numnode": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(tree.MustBeDTSQuery(args[0]).NumNodes())), nil
+			},
+			Info:       "Returns the number of lexemes and operators in the tsquery.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsquery_phrase": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"left", types.TSQuery}, {"right", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSQuery(args[0]).TSQuery, tree.MustBeDTSQuery(args[1]).TSQuery
+				q, err := l.FollowedBy(r, 1 /* distance */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info:       "Returns a tsquery that matches a match of left immediately followed by a match of right.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"left", types.TSQuery}, {"right", types.TSQuery}, {"distance", types.Int}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSQuery(args[0]).TSQuery, tree.MustBeDTSQuery(args[1]).TSQuery
+				q, err := l.FollowedBy(r, int(tree.MustBeDInt(args[2])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info:       "Returns a tsquery that matches a match of left followed by a match of right at exactly the given distance.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
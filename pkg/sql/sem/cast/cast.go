@@ -274,6 +274,23 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts between string types and range or multirange types are stable,
+	// since the conversion of timestamp bounds depends on the session. Casts
+	// to strings are allowed in assignment contexts and casts from strings
+	// only in explicit contexts.
+	if isRangeFamily(srcFamily) && tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if srcFamily == types.StringFamily && isRangeFamily(tgtFamily) {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
@@ -304,6 +321,11 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 	return Cast{}, false
 }
 
+// isRangeFamily returns true if f is the range or multirange family.
+func isRangeFamily(f types.Family) bool {
+	return f == types.RangeFamily || f == types.MultirangeFamily
+}
+
 // LookupCastVolatility returns the Volatility of a valid cast.
 func LookupCastVolatility(from, to *types.T) (_ volatility.V, ok bool) {
 	fromFamily := from.Family()
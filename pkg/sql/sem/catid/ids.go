@@ -80,6 +80,12 @@ type ConstraintID uint32
 // SafeValue implements the redact.SafeValue interface.
 func (ConstraintID) SafeValue() {}
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID uint32
+
+// SafeValue implements the redact.SafeValue interface.
+func (TriggerID) SafeValue() {}
+
 // PGAttributeNum is a custom type for Column's logical order.
 type PGAttributeNum uint32
 
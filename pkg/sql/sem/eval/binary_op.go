@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 )
 
@@ -39,6 +40,12 @@ func BinaryOp(
 	return op.Eval(ctx, (*evaluator)(evalCtx), left, right)
 }
 
+func (e *evaluator) EvalAdjacentRangeOp(
+	ctx context.Context, _ *tree.AdjacentRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeAdjacent(a, b))), nil
+}
+
 func (e *evaluator) EvalAppendToMaybeNullArrayOp(
 	ctx context.Context, op *tree.AppendToMaybeNullArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -270,6 +277,22 @@ func (e *evaluator) EvalConcatStringOp(
 
 }
 
+func (e *evaluator) EvalConcatTSQueryOp(
+	ctx context.Context, _ *tree.ConcatTSQueryOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDTSQuery(
+		tree.MustBeDTSQuery(left).TSQuery.Or(tree.MustBeDTSQuery(right).TSQuery),
+	), nil
+}
+
+func (e *evaluator) EvalConcatTSVectorOp(
+	ctx context.Context, _ *tree.ConcatTSVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDTSVector(
+		tree.MustBeDTSVector(left).TSVector.Concat(tree.MustBeDTSVector(right).TSVector),
+	), nil
+}
+
 func (e *evaluator) EvalConcatVarBitOp(
 	ctx context.Context, _ *tree.ConcatVarBitOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -298,6 +321,12 @@ func (e *evaluator) EvalContainedByJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeContains(b, a))), nil
+}
+
 func (e *evaluator) EvalContainsArrayOp(
 	ctx context.Context, _ *tree.ContainsArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -316,6 +345,12 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeContains(a, b))), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -670,6 +705,34 @@ func (e *evaluator) EvalJSONFetchValStringOp(
 	return &tree.DJSON{JSON: j}, nil
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	// Like the jsonb_path_exists_opr builtin, the @? operator suppresses
+	// evaluation errors.
+	exists, ok, err := tree.MustBeDJsonpath(b).Exists(
+		tree.MustBeDJSON(a).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(exists)), nil
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	// Like the jsonb_path_match_opr builtin, the @@ operator suppresses
+	// evaluation errors.
+	match, ok, err := tree.MustBeDJsonpath(b).Match(
+		tree.MustBeDJSON(a).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(match)), nil
+}
+
 func (e *evaluator) EvalJSONSomeExistsOp(
 	ctx context.Context, _ *tree.JSONSomeExistsOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -1191,6 +1254,12 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeOverlaps(left, right))), nil
+}
+
 func (e *evaluator) EvalPlusDateIntOp(
 	ctx context.Context, _ *tree.PlusDateIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1511,3 +1580,27 @@ func (e *evaluator) EvalSimilarToOp(
 	key := similarToKey{s: string(tree.MustBeDString(right)), escape: '\\'}
 	return matchRegexpWithKey(e.ctx(), left, key)
 }
+
+func (e *evaluator) EvalTSMatchesVectorQueryOp(
+	ctx context.Context, _ *tree.TSMatchesVectorQueryOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ret, err := tsearch.EvalTSQuery(
+		tree.MustBeDTSQuery(right).TSQuery, tree.MustBeDTSVector(left).TSVector,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(ret)), nil
+}
+
+func (e *evaluator) EvalTSMatchesQueryVectorOp(
+	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ret, err := tsearch.EvalTSQuery(
+		tree.MustBeDTSQuery(left).TSQuery, tree.MustBeDTSVector(right).TSVector,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(ret)), nil
+}
@@ -416,8 +416,15 @@ func performCastWithoutPrecisionTruncation(
 			}
 		case *tree.DBool, *tree.DDecimal:
 			s = d.String()
-		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D:
+		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D,
+			*tree.DTSQuery, *tree.DTSVector, *tree.DJsonpath:
 			s = tree.AsStringWithFlags(d, tree.FmtBareStrings)
+		case *tree.DRange, *tree.DMultirange:
+			s = tree.AsStringWithFlags(
+				d,
+				tree.FmtPgwireText,
+				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
+			)
 		case *tree.DTimestampTZ:
 			// Convert to context timezone for correct display.
 			ts, err := tree.MakeDTimestampTZ(t.In(evalCtx.GetLocation()), time.Microsecond)
@@ -556,6 +563,60 @@ func performCastWithoutPrecisionTruncation(
 			return tree.NewDBox2D(*bbox), nil
 		}
 
+	case types.TSQueryFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDTSQuery(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDTSQuery(d.Contents)
+		case *tree.DTSQuery:
+			return d, nil
+		}
+
+	case types.TSVectorFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDTSVector(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDTSVector(d.Contents)
+		case *tree.DTSVector:
+			return d, nil
+		}
+
+	case types.JsonpathFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDJsonpath(d.Contents)
+		case *tree.DJsonpath:
+			return d, nil
+		}
+
+	case types.RangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
+
+	case types.MultirangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DMultirange:
+			return d, nil
+		}
+
 	case types.GeographyFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -244,6 +245,12 @@ type Context struct {
 
 	// ChangefeedState stores the state (progress) of core changefeeds.
 	ChangefeedState ChangefeedState
+
+	// DeferredConstraints tracks the deferrable constraints whose checks are
+	// deferred until the current transaction commits. It is nil outside of
+	// explicit transactions, in which case all constraints are checked at the
+	// end of each statement.
+	DeferredConstraints *DeferredConstraints
 }
 
 // DescIDGenerator generates unique descriptor IDs.
@@ -412,6 +419,15 @@ func (ec *Context) QualityOfService() sessiondatapb.QoSLevel {
 	return ec.SessionData().DefaultTxnQualityOfService
 }
 
+// TxnIsoLevel returns the isolation level of the transaction in which the
+// statement is executing, or Serializable if there is no transaction.
+func (ec *Context) TxnIsoLevel() isolation.Level {
+	if ec.Txn == nil {
+		return isolation.Serializable
+	}
+	return ec.Txn.IsoLevel()
+}
+
 // NewTestingEvalContext is a convenience version of MakeTestingEvalContext
 // that returns a pointer.
 func NewTestingEvalContext(st *cluster.Settings) *Context {
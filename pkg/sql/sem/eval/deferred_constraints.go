@@ -0,0 +1,253 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DeferredConstraint identifies a deferrable constraint whose check was
+// deferred until the end of the transaction.
+type DeferredConstraint struct {
+	// TableID is the ID of the table on which the constraint is defined. For
+	// foreign keys, this is the origin (referencing) table.
+	TableID catid.DescID
+	// Name is the name of the constraint.
+	Name string
+	// RowColumnIDs are the IDs of the columns of Rows. They are the primary
+	// key columns of the table when the first row is recorded.
+	RowColumnIDs []catid.ColumnID
+	// Rows contains the primary keys of the rows of the table that were
+	// written while the check was deferred. Only these rows are checked.
+	Rows []tree.Datums
+	// ReferencedKeys contains, for foreign keys, the values of the referenced
+	// columns of the rows of the referenced table that were deleted or updated
+	// while the check was deferred. The rows of the table that reference these
+	// values are checked in addition to Rows.
+	ReferencedKeys []tree.Datums
+	// CheckAllRows is set if the rows to check could not be tracked, in which
+	// case all the rows of the table are checked.
+	CheckAllRows bool
+}
+
+// NeedsCheck returns true if there are rows to check.
+func (c *DeferredConstraint) NeedsCheck() bool {
+	return c.CheckAllRows || len(c.Rows) > 0 || len(c.ReferencedKeys) > 0
+}
+
+// constraintMode is the checking mode of a deferrable constraint, as set by
+// SET CONSTRAINTS.
+type constraintMode int8
+
+const (
+	// constraintModeDefault indicates that the mode is determined by whether
+	// the constraint is INITIALLY DEFERRED or INITIALLY IMMEDIATE.
+	constraintModeDefault constraintMode = iota
+	constraintModeImmediate
+	constraintModeDeferred
+)
+
+// DeferredConstraints tracks the checking mode of deferrable constraints for
+// the current transaction, along with the constraints whose checks have been
+// deferred until the transaction commits. The methods are safe to call on a
+// nil receiver, which behaves as if all constraints are checked immediately.
+type DeferredConstraints struct {
+	// allMode is the mode set by the last SET CONSTRAINTS ALL.
+	allMode constraintMode
+	// modes contains the modes set by SET CONSTRAINTS for specific
+	// constraints since the last SET CONSTRAINTS ALL.
+	modes map[string]constraintMode
+	// pending contains the constraints that must be checked before the
+	// transaction commits, without duplicates.
+	pending []DeferredConstraint
+}
+
+// IsDeferred returns true if the check of the constraint with the given name
+// and deferrability should be deferred until the end of the transaction.
+func (dc *DeferredConstraints) IsDeferred(
+	name string, deferrability tree.ConstraintDeferrability,
+) bool {
+	if dc == nil || deferrability == tree.ConstraintNotDeferrable {
+		return false
+	}
+	mode, ok := dc.modes[name]
+	if !ok {
+		mode = dc.allMode
+	}
+	switch mode {
+	case constraintModeImmediate:
+		return false
+	case constraintModeDeferred:
+		return true
+	default:
+		return deferrability == tree.ConstraintInitiallyDeferred
+	}
+}
+
+// Defer records that the given constraint must be checked before the
+// transaction commits.
+func (dc *DeferredConstraints) Defer(tableID catid.DescID, name string) {
+	if dc.find(tableID, name) != nil {
+		return
+	}
+	dc.pending = append(dc.pending, DeferredConstraint{TableID: tableID, Name: name})
+}
+
+// find returns the pending constraint with the given table and name, or nil if
+// there is none.
+func (dc *DeferredConstraints) find(tableID catid.DescID, name string) *DeferredConstraint {
+	for i := range dc.pending {
+		if c := &dc.pending[i]; c.TableID == tableID && c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Pending returns the constraints that must be checked before the transaction
+// commits. The returned slice must not be modified.
+func (dc *DeferredConstraints) Pending() []DeferredConstraint {
+	if dc == nil {
+		return nil
+	}
+	return dc.pending
+}
+
+// RecordRow records that the row of the pending constraint's table with the
+// given primary key, whose columns are colIDs, must be checked. If more than
+// maxRows rows and keys are recorded for the constraint, or if the primary key
+// columns change, all the rows of the table are checked instead.
+func (dc *DeferredConstraints) RecordRow(
+	tableID catid.DescID, name string, colIDs []catid.ColumnID, pk tree.Datums, maxRows int,
+) {
+	c := dc.find(tableID, name)
+	if c == nil || c.CheckAllRows {
+		return
+	}
+	if c.RowColumnIDs == nil {
+		c.RowColumnIDs = colIDs
+	} else if !sameColumnIDs(c.RowColumnIDs, colIDs) {
+		c.checkAllRows()
+		return
+	}
+	if len(c.Rows)+len(c.ReferencedKeys) >= maxRows {
+		c.checkAllRows()
+		return
+	}
+	c.Rows = append(c.Rows, pk)
+}
+
+// RecordReferencedKey records that the rows of the pending foreign key's
+// table that reference the given key must be checked. See RecordRow.
+func (dc *DeferredConstraints) RecordReferencedKey(
+	tableID catid.DescID, name string, key tree.Datums, maxRows int,
+) {
+	c := dc.find(tableID, name)
+	if c == nil || c.CheckAllRows {
+		return
+	}
+	if len(c.Rows)+len(c.ReferencedKeys) >= maxRows {
+		c.checkAllRows()
+		return
+	}
+	c.ReferencedKeys = append(c.ReferencedKeys, key)
+}
+
+// RecordAllRows records that all the rows of the pending constraint's table
+// must be checked.
+func (dc *DeferredConstraints) RecordAllRows(tableID catid.DescID, name string) {
+	if c := dc.find(tableID, name); c != nil {
+		c.checkAllRows()
+	}
+}
+
+func (c *DeferredConstraint) checkAllRows() {
+	c.CheckAllRows = true
+	c.RowColumnIDs = nil
+	c.Rows = nil
+	c.ReferencedKeys = nil
+}
+
+func sameColumnIDs(a, b []catid.ColumnID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetMode changes the checking mode of the constraints with the given names,
+// or of all constraints if names is nil.
+func (dc *DeferredConstraints) SetMode(names tree.NameList, deferred bool) {
+	mode := constraintModeImmediate
+	if deferred {
+		mode = constraintModeDeferred
+	}
+	if names == nil {
+		dc.allMode = mode
+		dc.modes = nil
+		return
+	}
+	if dc.modes == nil {
+		dc.modes = make(map[string]constraintMode, len(names))
+	}
+	for _, name := range names {
+		dc.modes[string(name)] = mode
+	}
+}
+
+// HasPending returns true if there are deferred constraints that have not
+// been checked yet.
+func (dc *DeferredConstraints) HasPending() bool {
+	return dc != nil && len(dc.pending) > 0
+}
+
+// TakePending removes and returns the deferred constraints with the given
+// names, or all of the deferred constraints if names is nil.
+func (dc *DeferredConstraints) TakePending(names tree.NameList) []DeferredConstraint {
+	if dc == nil {
+		return nil
+	}
+	if names == nil {
+		pending := dc.pending
+		dc.pending = nil
+		return pending
+	}
+	var taken []DeferredConstraint
+	remaining := dc.pending[:0]
+	for _, c := range dc.pending {
+		found := false
+		for _, name := range names {
+			if c.Name == string(name) {
+				found = true
+				break
+			}
+		}
+		if found {
+			taken = append(taken, c)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	dc.pending = remaining
+	return taken
+}
+
+// Reset clears the state of the deferred constraints. It is called at the
+// start and end of each transaction.
+func (dc *DeferredConstraints) Reset() {
+	*dc = DeferredConstraints{}
+}
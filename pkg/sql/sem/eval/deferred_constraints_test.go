@@ -0,0 +1,74 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferredConstraintsRecord(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const maxRows = 2
+	pk := []catid.ColumnID{1}
+	row := func(i int) tree.Datums { return tree.Datums{tree.NewDInt(tree.DInt(i))} }
+
+	var dc DeferredConstraints
+	dc.Defer(10, "c")
+	dc.Defer(10, "c")
+	dc.Defer(11, "fk")
+	require.Len(t, dc.Pending(), 2)
+	require.False(t, dc.Pending()[0].NeedsCheck())
+
+	// Rows of constraints that are not pending are ignored.
+	dc.RecordRow(12, "c", pk, row(1), maxRows)
+	dc.RecordRow(10, "other", pk, row(1), maxRows)
+	require.False(t, dc.Pending()[0].NeedsCheck())
+
+	dc.RecordRow(10, "c", pk, row(1), maxRows)
+	dc.RecordRow(10, "c", pk, row(2), maxRows)
+	c := dc.Pending()[0]
+	require.True(t, c.NeedsCheck())
+	require.False(t, c.CheckAllRows)
+	require.Equal(t, []tree.Datums{row(1), row(2)}, c.Rows)
+
+	// Past the limit, all the rows are checked.
+	dc.RecordRow(10, "c", pk, row(3), maxRows)
+	c = dc.Pending()[0]
+	require.True(t, c.CheckAllRows)
+	require.Nil(t, c.Rows)
+	dc.RecordRow(10, "c", pk, row(4), maxRows)
+	require.Nil(t, dc.Pending()[0].Rows)
+
+	// Referenced keys count towards the limit.
+	dc.RecordReferencedKey(11, "fk", row(1), maxRows)
+	dc.RecordRow(11, "fk", pk, row(1), maxRows)
+	c = dc.Pending()[1]
+	require.False(t, c.CheckAllRows)
+	require.Equal(t, []tree.Datums{row(1)}, c.ReferencedKeys)
+	require.Equal(t, []tree.Datums{row(1)}, c.Rows)
+
+	// A change of the primary key columns requires checking all the rows.
+	dc.Reset()
+	dc.Defer(10, "c")
+	dc.RecordRow(10, "c", pk, row(1), maxRows)
+	dc.RecordRow(10, "c", []catid.ColumnID{2}, row(1), maxRows)
+	require.True(t, dc.Pending()[0].CheckAllRows)
+
+	taken := dc.TakePending(nil /* names */)
+	require.Len(t, taken, 1)
+	require.False(t, dc.HasPending())
+}
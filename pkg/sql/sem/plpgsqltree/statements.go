@@ -0,0 +1,541 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package plpgsqltree
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Statement is a PL/pgSQL statement.
+type Statement interface {
+	tree.NodeFormatter
+	// format formats the statement at the given indentation level. Nested
+	// statements are formatted with one more level of indentation.
+	format(ctx *tree.FmtCtx, indent int)
+	plpgsqlStmt()
+}
+
+func (*Block) plpgsqlStmt()      {}
+func (*Assignment) plpgsqlStmt() {}
+func (*If) plpgsqlStmt()         {}
+func (*Loop) plpgsqlStmt()       {}
+func (*While) plpgsqlStmt()      {}
+func (*Exit) plpgsqlStmt()       {}
+func (*Continue) plpgsqlStmt()   {}
+func (*Return) plpgsqlStmt()     {}
+func (*Raise) plpgsqlStmt()      {}
+func (*Execute) plpgsqlStmt()    {}
+func (*Perform) plpgsqlStmt()    {}
+func (*Commit) plpgsqlStmt()     {}
+func (*Rollback) plpgsqlStmt()   {}
+func (*Null) plpgsqlStmt()       {}
+
+// Block represents a PL/pgSQL block, which is the unit of variable scoping:
+//
+//	[ <<label>> ]
+//	[ DECLARE
+//	    declarations ]
+//	BEGIN
+//	    statements
+//	[ EXCEPTION
+//	    WHEN condition [ OR condition ... ] THEN
+//	        handler_statements
+//	    ... ]
+//	END [ label ];
+//
+// The body of a PL/pgSQL function is always a single block.
+type Block struct {
+	Label      string
+	Decls      []Declaration
+	Body       []Statement
+	Exceptions []Exception
+}
+
+// Declaration is a variable declaration in the DECLARE section of a block.
+type Declaration struct {
+	Var      tree.Name
+	Constant bool
+	Typ      tree.ResolvableTypeReference
+	NotNull  bool
+	// Default is the initial value of the variable. It is nil if the variable
+	// is initialized to NULL.
+	Default tree.Expr
+}
+
+// Exception is a handler in the EXCEPTION section of a block. It is run when
+// an error matching any of its conditions is raised in the block body.
+type Exception struct {
+	Conditions []Condition
+	Body       []Statement
+}
+
+// Condition is an error condition that is matched by an exception handler. It
+// is either a condition name, like division_by_zero or OTHERS, or a SQLSTATE
+// code.
+type Condition struct {
+	SQLErrName  string
+	SQLErrState string
+}
+
+// Assignment represents an assignment to a variable, or to a field of a
+// record variable:
+//
+//	variable [ . field ] := expression;
+type Assignment struct {
+	Var   tree.Name
+	Field tree.Name
+	Value tree.Expr
+}
+
+// If represents a conditional statement:
+//
+//	IF condition THEN
+//	    statements
+//	[ ELSIF condition THEN
+//	    statements ... ]
+//	[ ELSE
+//	    statements ]
+//	END IF;
+type If struct {
+	Condition  tree.Expr
+	ThenBody   []Statement
+	ElseIfList []ElseIf
+	ElseBody   []Statement
+}
+
+// ElseIf is an ELSIF branch of an If statement.
+type ElseIf struct {
+	Condition tree.Expr
+	Body      []Statement
+}
+
+// Loop represents an unconditional loop, which runs until it is terminated by
+// an EXIT or RETURN statement:
+//
+//	[ <<label>> ]
+//	LOOP
+//	    statements
+//	END LOOP [ label ];
+type Loop struct {
+	Label string
+	Body  []Statement
+}
+
+// While represents a loop that runs as long as its condition evaluates to
+// true:
+//
+//	[ <<label>> ]
+//	WHILE condition LOOP
+//	    statements
+//	END LOOP [ label ];
+type While struct {
+	Label     string
+	Condition tree.Expr
+	Body      []Statement
+}
+
+// Exit terminates the innermost enclosing loop, or the loop or block with the
+// given label. If Condition is set, the loop is only exited if it evaluates to
+// true.
+type Exit struct {
+	Label     string
+	Condition tree.Expr
+}
+
+// Continue begins the next iteration of the innermost enclosing loop, or of
+// the loop with the given label. If Condition is set, the next iteration is
+// only started if it evaluates to true.
+type Continue struct {
+	Label     string
+	Condition tree.Expr
+}
+
+// Return terminates the function and returns the value of Expr. Expr is nil
+// for functions that return VOID.
+type Return struct {
+	Expr tree.Expr
+}
+
+// RaiseLevel is the severity level of a RAISE statement.
+type RaiseLevel string
+
+// RaiseLevel values.
+const (
+	RaiseDebug     RaiseLevel = "DEBUG"
+	RaiseLog       RaiseLevel = "LOG"
+	RaiseInfo      RaiseLevel = "INFO"
+	RaiseNotice    RaiseLevel = "NOTICE"
+	RaiseWarning   RaiseLevel = "WARNING"
+	RaiseException RaiseLevel = "EXCEPTION"
+)
+
+// Raise reports a message or raises an error:
+//
+//	RAISE [ level ] 'format' [, expression [, ... ]] [ USING option = expression [, ... ] ];
+//	RAISE [ level ] condition_name [ USING option = expression [, ... ] ];
+//	RAISE [ level ] SQLSTATE 'sqlstate' [ USING option = expression [, ... ] ];
+//
+// Each % in the format string is replaced by the textual representation of the
+// next parameter.
+type Raise struct {
+	Level RaiseLevel
+	// Message is the format string of the message. It is empty if the RAISE
+	// statement specifies a condition instead.
+	Message   string
+	Params    []tree.Expr
+	Condition *Condition
+	Options   []RaiseOption
+}
+
+// RaiseOption is an option given in the USING clause of a RAISE statement. The
+// supported options are MESSAGE, DETAIL, HINT and ERRCODE.
+type RaiseOption struct {
+	Name  string
+	Value tree.Expr
+}
+
+// Execute represents a SQL statement executed by a PL/pgSQL function. If
+// Target is set, the columns of the first row produced by the statement are
+// assigned to the target variables. If Strict is true, it is an error for the
+// statement to produce anything other than exactly one row.
+type Execute struct {
+	SQLStmt tree.Statement
+	Strict  bool
+	Target  []tree.Name
+}
+
+// Perform evaluates a query and discards its result.
+type Perform struct {
+	SQLStmt tree.Statement
+}
+
+// Commit commits the current transaction and starts a new one. It can only
+// be used in procedures.
+type Commit struct{}
+
+// Rollback rolls back the current transaction and starts a new one. It can
+// only be used in procedures.
+type Rollback struct{}
+
+// Null is a statement that does nothing.
+type Null struct{}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Block) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Assignment) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *If) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Loop) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *While) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Exit) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Continue) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Return) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Raise) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Execute) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Perform) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Commit) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Rollback) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Null) Format(ctx *tree.FmtCtx) { s.format(ctx, 0) }
+
+func writeIndent(ctx *tree.FmtCtx, indent int) {
+	for i := 0; i < indent; i++ {
+		ctx.WriteString("  ")
+	}
+}
+
+func formatLabel(ctx *tree.FmtCtx, indent int, label string) {
+	if label != "" {
+		writeIndent(ctx, indent)
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&label)
+		ctx.WriteString(">>\n")
+	}
+}
+
+func formatBody(ctx *tree.FmtCtx, indent int, body []Statement) {
+	for _, stmt := range body {
+		stmt.format(ctx, indent)
+	}
+}
+
+func formatCondition(ctx *tree.FmtCtx, cond tree.Expr) {
+	ctx.FormatNode(cond)
+}
+
+func (s *Block) format(ctx *tree.FmtCtx, indent int) {
+	formatLabel(ctx, indent, s.Label)
+	if len(s.Decls) > 0 {
+		writeIndent(ctx, indent)
+		ctx.WriteString("DECLARE\n")
+		for i := range s.Decls {
+			d := &s.Decls[i]
+			writeIndent(ctx, indent+1)
+			ctx.FormatNode(&d.Var)
+			if d.Constant {
+				ctx.WriteString(" CONSTANT")
+			}
+			ctx.WriteByte(' ')
+			ctx.FormatTypeReference(d.Typ)
+			if d.NotNull {
+				ctx.WriteString(" NOT NULL")
+			}
+			if d.Default != nil {
+				ctx.WriteString(" := ")
+				ctx.FormatNode(d.Default)
+			}
+			ctx.WriteString(";\n")
+		}
+	}
+	writeIndent(ctx, indent)
+	ctx.WriteString("BEGIN\n")
+	formatBody(ctx, indent+1, s.Body)
+	if len(s.Exceptions) > 0 {
+		writeIndent(ctx, indent)
+		ctx.WriteString("EXCEPTION\n")
+		for i := range s.Exceptions {
+			e := &s.Exceptions[i]
+			writeIndent(ctx, indent+1)
+			ctx.WriteString("WHEN ")
+			for j := range e.Conditions {
+				if j > 0 {
+					ctx.WriteString(" OR ")
+				}
+				e.Conditions[j].Format(ctx)
+			}
+			ctx.WriteString(" THEN\n")
+			formatBody(ctx, indent+2, e.Body)
+		}
+	}
+	writeIndent(ctx, indent)
+	ctx.WriteString("END")
+	if s.Label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatNameP(&s.Label)
+	}
+	if indent > 0 {
+		// The outermost block of a function body is not terminated by a
+		// semicolon.
+		ctx.WriteByte(';')
+	}
+	ctx.WriteByte('\n')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (c *Condition) Format(ctx *tree.FmtCtx) {
+	if c.SQLErrState != "" {
+		ctx.WriteString("SQLSTATE ")
+		ctx.WriteString(tree.NewDString(c.SQLErrState).String())
+		return
+	}
+	ctx.WriteString(c.SQLErrName)
+}
+
+func (s *Assignment) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.FormatNode(&s.Var)
+	if s.Field != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&s.Field)
+	}
+	ctx.WriteString(" := ")
+	ctx.FormatNode(s.Value)
+	ctx.WriteString(";\n")
+}
+
+func (s *If) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("IF ")
+	formatCondition(ctx, s.Condition)
+	ctx.WriteString(" THEN\n")
+	formatBody(ctx, indent+1, s.ThenBody)
+	for i := range s.ElseIfList {
+		writeIndent(ctx, indent)
+		ctx.WriteString("ELSIF ")
+		formatCondition(ctx, s.ElseIfList[i].Condition)
+		ctx.WriteString(" THEN\n")
+		formatBody(ctx, indent+1, s.ElseIfList[i].Body)
+	}
+	if len(s.ElseBody) > 0 {
+		writeIndent(ctx, indent)
+		ctx.WriteString("ELSE\n")
+		formatBody(ctx, indent+1, s.ElseBody)
+	}
+	writeIndent(ctx, indent)
+	ctx.WriteString("END IF;\n")
+}
+
+func formatEndLoop(ctx *tree.FmtCtx, indent int, label string) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("END LOOP")
+	if label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatNameP(&label)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Loop) format(ctx *tree.FmtCtx, indent int) {
+	formatLabel(ctx, indent, s.Label)
+	writeIndent(ctx, indent)
+	ctx.WriteString("LOOP\n")
+	formatBody(ctx, indent+1, s.Body)
+	formatEndLoop(ctx, indent, s.Label)
+}
+
+func (s *While) format(ctx *tree.FmtCtx, indent int) {
+	formatLabel(ctx, indent, s.Label)
+	writeIndent(ctx, indent)
+	ctx.WriteString("WHILE ")
+	formatCondition(ctx, s.Condition)
+	ctx.WriteString(" LOOP\n")
+	formatBody(ctx, indent+1, s.Body)
+	formatEndLoop(ctx, indent, s.Label)
+}
+
+func formatExitOrContinue(
+	ctx *tree.FmtCtx, indent int, keyword string, label string, cond tree.Expr,
+) {
+	writeIndent(ctx, indent)
+	ctx.WriteString(keyword)
+	if label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatNameP(&label)
+	}
+	if cond != nil {
+		ctx.WriteString(" WHEN ")
+		formatCondition(ctx, cond)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Exit) format(ctx *tree.FmtCtx, indent int) {
+	formatExitOrContinue(ctx, indent, "EXIT", s.Label, s.Condition)
+}
+
+func (s *Continue) format(ctx *tree.FmtCtx, indent int) {
+	formatExitOrContinue(ctx, indent, "CONTINUE", s.Label, s.Condition)
+}
+
+func (s *Return) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("RETURN")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Raise) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("RAISE")
+	if s.Level != "" {
+		ctx.WriteByte(' ')
+		ctx.WriteString(string(s.Level))
+	}
+	if s.Condition != nil {
+		ctx.WriteByte(' ')
+		s.Condition.Format(ctx)
+	} else if s.Message != "" {
+		ctx.WriteByte(' ')
+		ctx.WriteString(tree.NewDString(s.Message).String())
+		for _, p := range s.Params {
+			ctx.WriteString(", ")
+			ctx.FormatNode(p)
+		}
+	}
+	for i := range s.Options {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString(s.Options[i].Name)
+		ctx.WriteString(" = ")
+		ctx.FormatNode(s.Options[i].Value)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Execute) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.FormatNode(s.SQLStmt)
+	if len(s.Target) > 0 {
+		ctx.WriteString(" INTO ")
+		if s.Strict {
+			ctx.WriteString("STRICT ")
+		}
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Target[i])
+		}
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Perform) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("PERFORM ")
+	// The query is stored as a SELECT statement. Strip the leading SELECT
+	// keyword so that the statement is formatted as it was written.
+	start := ctx.Len()
+	ctx.FormatNode(s.SQLStmt)
+	if formatted := ctx.Bytes()[start:]; bytes.HasPrefix(formatted, []byte("SELECT ")) {
+		query := string(formatted[len("SELECT "):])
+		ctx.Truncate(start)
+		ctx.WriteString(query)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *Commit) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("COMMIT;\n")
+}
+
+func (s *Rollback) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("ROLLBACK;\n")
+}
+
+func (s *Null) format(ctx *tree.FmtCtx, indent int) {
+	writeIndent(ctx, indent)
+	ctx.WriteString("NULL;\n")
+}
@@ -58,6 +58,7 @@ type AlterTableCmd interface {
 
 func (*AlterTableAddColumn) alterTableCmd()          {}
 func (*AlterTableAddConstraint) alterTableCmd()      {}
+func (*AlterTableAlterConstraint) alterTableCmd()    {}
 func (*AlterTableAlterColumnType) alterTableCmd()    {}
 func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
 func (*AlterTableDropColumn) alterTableCmd()         {}
@@ -79,6 +80,7 @@ func (*AlterTableResetStorageParams) alterTableCmd() {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
+var _ AlterTableCmd = &AlterTableAlterConstraint{}
 var _ AlterTableCmd = &AlterTableAlterColumnType{}
 var _ AlterTableCmd = &AlterTableDropColumn{}
 var _ AlterTableCmd = &AlterTableDropConstraint{}
@@ -345,6 +347,29 @@ func (node *AlterTableValidateConstraint) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Constraint)
 }
 
+// AlterTableAlterConstraint represents an ALTER CONSTRAINT command, which
+// changes the deferrability of a constraint.
+type AlterTableAlterConstraint struct {
+	Constraint    Name
+	Deferrability ConstraintDeferrability
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableAlterConstraint) TelemetryName() string {
+	return "alter_constraint"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableAlterConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ALTER CONSTRAINT ")
+	ctx.FormatNode(&node.Constraint)
+	if node.Deferrability == ConstraintNotDeferrable {
+		ctx.WriteString(" NOT DEFERRABLE")
+	} else {
+		ctx.FormatNode(&node.Deferrability)
+	}
+}
+
 // AlterTableRenameColumn represents an ALTER TABLE RENAME [COLUMN] command.
 type AlterTableRenameColumn struct {
 	Column  Name
@@ -98,9 +98,12 @@ func (cts *ChangefeedTargets) Format(ctx *FmtCtx) {
 }
 
 // ChangefeedTargetFromTableExpr returns ChangefeedTarget for the
-// specified table expression.
+// specified table expression. If the expression joins the target with
+// lookup tables, the target is the leftmost table of the join.
 func ChangefeedTargetFromTableExpr(e TableExpr) (ChangefeedTarget, error) {
 	switch t := e.(type) {
+	case *JoinTableExpr:
+		return ChangefeedTargetFromTableExpr(t.Left)
 	case TablePattern:
 		return ChangefeedTarget{TableName: t}, nil
 	case *AliasedTableExpr:
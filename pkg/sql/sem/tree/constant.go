@@ -537,6 +537,19 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.TSQuery,
+		types.TSVector,
+		types.Jsonpath,
+		types.Int8Range,
+		types.NumRange,
+		types.DateRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.Int8Multirange,
+		types.NumMultirange,
+		types.DateMultirange,
+		types.TSMultirange,
+		types.TSTZMultirange,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
@@ -386,6 +387,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -475,6 +477,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -658,6 +661,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrability = t.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -828,6 +832,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -957,10 +962,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table         TableName
+	Col           Name // empty-string means use PK
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1047,14 +1053,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1099,6 +1107,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1152,6 +1161,36 @@ func (node *ReferenceActions) Format(ctx *FmtCtx) {
 	}
 }
 
+// ConstraintDeferrability describes whether the check of a constraint can be
+// deferred until the end of the transaction, and whether it is deferred by
+// default.
+type ConstraintDeferrability int8
+
+// The values for ConstraintDeferrability.
+const (
+	// ConstraintNotDeferrable indicates that the constraint is always checked
+	// at the end of each statement.
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	// ConstraintInitiallyImmediate indicates that the constraint is checked at
+	// the end of each statement, unless it is deferred with SET CONSTRAINTS.
+	ConstraintInitiallyImmediate
+	// ConstraintInitiallyDeferred indicates that the constraint is checked at
+	// the end of the transaction, unless it is made immediate with SET
+	// CONSTRAINTS.
+	ConstraintInitiallyDeferred
+)
+
+// Format implements the NodeFormatter interface. Nothing is written for a
+// constraint that is not deferrable.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	switch *node {
+	case ConstraintInitiallyImmediate:
+		ctx.WriteString(" DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		ctx.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
+
 // CompositeKeyMatchMethod is the algorithm use when matching composite keys.
 // See https://github.com/cockroachdb/cockroach/issues/20305 or
 // https://www.postgresql.org/docs/11/sql-createtable.html for details on the
@@ -1177,13 +1216,14 @@ func (c CompositeKeyMatchMethod) String() string {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1214,6 +1254,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1233,6 +1274,7 @@ type CheckConstraintTableDef struct {
 	Expr                  Expr
 	FromHashShardedColumn bool
 	IfNotExists           bool
+	Deferrability         ConstraintDeferrability
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1258,6 +1300,83 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteString("CHECK (")
 	ctx.FormatNode(node.Expr)
 	ctx.WriteByte(')')
+	ctx.FormatNode(&node.Deferrability)
+}
+
+// ExcludeConstraintTableDef represents an exclusion constraint within a CREATE
+// TABLE statement.
+type ExcludeConstraintTableDef struct {
+	Name Name
+	// Method is the index access method given in the USING clause, or empty
+	// if there is none.
+	Method        Name
+	Elems         ExcludeElemList
+	Predicate     Expr
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Method != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(node.Method.String())
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+	ctx.FormatNode(&node.Deferrability)
+}
+
+// ExcludeElem is a column or expression of an exclusion constraint, along
+// with the operator that is used to compare its values in different rows.
+type ExcludeElem struct {
+	IndexElem
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.IndexElem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
 }
 
 // FamilyTableDef represents a family definition within a CREATE TABLE
@@ -1621,12 +1740,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
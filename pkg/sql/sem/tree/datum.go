@@ -37,11 +37,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -3575,6 +3577,635 @@ func (d *DBox2D) Size() uintptr {
 	return unsafe.Sizeof(*d) + unsafe.Sizeof(d.CartesianBoundingBox)
 }
 
+// DTSQuery is the tsquery Datum.
+type DTSQuery struct {
+	tsearch.TSQuery
+}
+
+// NewDTSQuery returns a new TSQuery Datum.
+func NewDTSQuery(q tsearch.TSQuery) *DTSQuery {
+	return &DTSQuery{TSQuery: q}
+}
+
+// ParseDTSQuery takes a string of TSQuery and returns a DTSQuery value.
+func ParseDTSQuery(str string) (*DTSQuery, error) {
+	q, err := tsearch.ParseTSQuery(str)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.Syntax, "could not parse tsquery")
+	}
+	return NewDTSQuery(q), nil
+}
+
+// AsDTSQuery attempts to retrieve a *DTSQuery from an Expr, returning a
+// *DTSQuery and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSQuery wrapped by a *DOidWrapper is possible.
+func AsDTSQuery(e Expr) (*DTSQuery, bool) {
+	switch t := e.(type) {
+	case *DTSQuery:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSQuery(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSQuery attempts to retrieve a *DTSQuery from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSQuery(e Expr) *DTSQuery {
+	q, ok := AsDTSQuery(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSQuery, found %T", e))
+	}
+	return q
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSQuery) ResolvedType() *types.T {
+	return types.TSQuery
+}
+
+// Compare implements the Datum interface.
+func (d *DTSQuery) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSQuery) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DTSQuery)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.TSQuery.Compare(v.TSQuery), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSQuery) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSQuery) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSQuery) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSQuery) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSQuery) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSQuery) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSQuery) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSQuery) Format(ctx *FmtCtx) {
+	formatTextRepresentation(ctx, d.TSQuery.String())
+}
+
+// Size implements the Datum interface.
+func (d *DTSQuery) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.TSQuery.MemSize()
+}
+
+// DTSVector is the tsvector Datum.
+type DTSVector struct {
+	tsearch.TSVector
+}
+
+// NewDTSVector returns a new TSVector Datum.
+func NewDTSVector(v tsearch.TSVector) *DTSVector {
+	return &DTSVector{TSVector: v}
+}
+
+// ParseDTSVector takes a string of TSVector and returns a DTSVector value.
+func ParseDTSVector(str string) (*DTSVector, error) {
+	v, err := tsearch.ParseTSVector(str)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.Syntax, "could not parse tsvector")
+	}
+	return NewDTSVector(v), nil
+}
+
+// AsDTSVector attempts to retrieve a *DTSVector from an Expr, returning a
+// *DTSVector and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSVector wrapped by a *DOidWrapper is possible.
+func AsDTSVector(e Expr) (*DTSVector, bool) {
+	switch t := e.(type) {
+	case *DTSVector:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSVector(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSVector attempts to retrieve a *DTSVector from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSVector(e Expr) *DTSVector {
+	v, ok := AsDTSVector(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSVector, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSVector) ResolvedType() *types.T {
+	return types.TSVector
+}
+
+// Compare implements the Datum interface.
+func (d *DTSVector) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSVector) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DTSVector)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.TSVector.Compare(v.TSVector), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSVector) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSVector) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSVector) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSVector) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSVector) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSVector) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSVector) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSVector) Format(ctx *FmtCtx) {
+	formatTextRepresentation(ctx, d.TSVector.String())
+}
+
+// Size implements the Datum interface.
+func (d *DTSVector) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.TSVector.MemSize()
+}
+
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	*jsonpath.Path
+}
+
+// NewDJsonpath returns a new jsonpath Datum.
+func NewDJsonpath(p *jsonpath.Path) *DJsonpath {
+	return &DJsonpath{Path: p}
+}
+
+// ParseDJsonpath takes a string of a SQL/JSON path expression and returns a
+// DJsonpath value.
+func ParseDJsonpath(str string) (*DJsonpath, error) {
+	p, err := jsonpath.Parse(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(p), nil
+}
+
+// AsDJsonpath attempts to retrieve a *DJsonpath from an Expr, returning a
+// *DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a *DJsonpath from an Expr, panicking
+// if the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Paths have no meaningful
+// order, so they are compared by their canonical text representation.
+func (d *DJsonpath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return strings.Compare(d.Path.String(), v.Path.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DJsonpath) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	formatTextRepresentation(ctx, d.Path.String())
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.Path.MemSize()
+}
+
+// formatTextRepresentation formats the text representation of a TSQuery,
+// TSVector, range, multirange or jsonpath. Since the representation itself may contain
+// quotes, it is escaped unless bare strings were requested.
+func formatTextRepresentation(ctx *FmtCtx, s string) {
+	f := ctx.flags
+	if f.HasFlags(FmtFlags(lexbase.EncBareStrings)) {
+		ctx.WriteString(s)
+		return
+	}
+	lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, f.EncodeFlags())
+}
+
+// RangeBound is the lower or upper bound of a range.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is included in the range. It is always false for
+	// infinite bounds.
+	Inclusive bool
+}
+
+// IsInfinite returns true if the bound is infinite.
+func (b RangeBound) IsInfinite() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum of the range types. Ranges are constructed with
+// MakeDRange, which validates the bounds and canonicalizes the ranges of
+// discrete element types.
+type DRange struct {
+	Typ *types.T
+	// Empty is true if the range contains no values, in which case the bounds
+	// are unused.
+	Empty bool
+	Lower RangeBound
+	Upper RangeBound
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange
+// and a flag signifying whether the assertion was successful. The function
+// should be used instead of direct type assertions wherever a *DRange wrapped
+// by a *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(d, v), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	if ctx.HasFlags(fmtPgwireFormat) {
+		d.pgwireFormat(ctx)
+		return
+	}
+	formatTextRepresentation(ctx, AsStringWithFlags(
+		d, FmtPgwireText, FmtDataConversionConfig(ctx.dataConversionConfig),
+	))
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower.Val != nil {
+		sz += d.Lower.Val.Size()
+	}
+	if d.Upper.Val != nil {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range [2]RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// DMultirange is the Datum of the multirange types. Its ranges are sorted,
+// nonempty, and neither overlap nor are adjacent to each other; MakeDMultirange
+// establishes these invariants.
+type DMultirange struct {
+	Typ    *types.T
+	Ranges []*DRange
+}
+
+// AsDMultirange attempts to retrieve a *DMultirange from an Expr, returning a
+// *DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a *DMultirange from an Expr,
+// panicking if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	m, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return m
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// Compare implements the Datum interface.
+func (d *DMultirange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMultirange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultirange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if c := compareRanges(d.Ranges[i], v.Ranges[i]); c != 0 {
+			return c, nil
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultirange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	if ctx.HasFlags(fmtPgwireFormat) {
+		d.pgwireFormat(ctx)
+		return
+	}
+	formatTextRepresentation(ctx, AsStringWithFlags(
+		d, FmtPgwireText, FmtDataConversionConfig(ctx.dataConversionConfig),
+	))
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
 // DJSON is the JSON Datum.
 type DJSON struct{ json.JSON }
 
@@ -3696,7 +4327,8 @@ func AsJSON(
 	case *DTimestamp:
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
-	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D:
+	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
+		*DTSQuery, *DTSVector, *DRange, *DMultirange, *DJsonpath:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc))), nil
 	case *DGeometry:
 		return json.FromSpatialObject(t.Geometry.SpatialObject(), geo.DefaultGeoJSONDecimalDigits)
@@ -5357,6 +5989,16 @@ func NewDefaultDatum(collationEnv *CollationEnvironment, t *types.T) (d Datum, e
 		return dNullJSON, nil
 	case types.TimeTZFamily:
 		return dZeroTimeTZ, nil
+	case types.TSQueryFamily:
+		return NewDTSQuery(tsearch.TSQuery{}), nil
+	case types.TSVectorFamily:
+		return NewDTSVector(tsearch.TSVector{}), nil
+	case types.RangeFamily:
+		return NewEmptyDRange(t), nil
+	case types.MultirangeFamily:
+		return &DMultirange{Typ: t}, nil
+	case types.JsonpathFamily:
+		return ParseDJsonpath("$")
 	case types.GeometryFamily, types.GeographyFamily, types.Box2DFamily:
 		// TODO(otan): force Geometry/Geography to not allow `NOT NULL` columns to
 		// make this impossible.
@@ -5462,6 +6104,11 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.EncodedKeyFamily:     {unsafe.Sizeof(DBytes("")), variableSize},
 	types.DateFamily:           {unsafe.Sizeof(DDate{}), fixedSize},
 	types.GeographyFamily:      {unsafe.Sizeof(DGeography{}), variableSize},
+	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
+	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
 	types.GeometryFamily:       {unsafe.Sizeof(DGeometry{}), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
@@ -5475,6 +6122,8 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
 	types.VoidFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
+	// The trigger pseudo-type has no values, so it is treated like void.
+	types.TriggerFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
 
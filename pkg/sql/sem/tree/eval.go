@@ -1184,6 +1184,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &ConcatJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.TSQuery,
+			RightType:  types.TSQuery,
+			ReturnType: types.TSQuery,
+			EvalOp:     &ConcatTSQueryOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.TSVector,
+			RightType:  types.TSVector,
+			ReturnType: types.TSVector,
+			EvalOp:     &ConcatTSVectorOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	// TODO(pmattis): Check that the shift is valid.
@@ -1381,16 +1395,41 @@ func cmpOpFixups(
 		panic(errors.AssertionFailedf("could not find cmp op %s(%s,%s)", op, t, t))
 	}
 
-	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
+		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
+	// Range and multirange comparisons.
+	for i, rangeTyp := range types.RangeTypes {
+		multirangeTyp := types.MultirangeTypes[i]
+		elemTyp := rangeTyp.RangeContents()
+		for _, t := range []*types.T{rangeTyp, multirangeTyp} {
+			appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.In, makeEvalTupleIn(t, volatility.Immutable))
+			appendCmpOp(treecmp.Contains, makeRangeCmpOp(t, elemTyp, &ContainsRangeOp{}))
+			appendCmpOp(treecmp.ContainedBy, makeRangeCmpOp(elemTyp, t, &ContainedByRangeOp{}))
+			for _, other := range []*types.T{rangeTyp, multirangeTyp} {
+				appendCmpOp(treecmp.Overlaps, makeRangeCmpOp(t, other, &OverlapsRangeOp{}))
+				appendCmpOp(treecmp.Contains, makeRangeCmpOp(t, other, &ContainsRangeOp{}))
+				appendCmpOp(treecmp.ContainedBy, makeRangeCmpOp(t, other, &ContainedByRangeOp{}))
+				appendCmpOp(treecmp.Adjacent, makeRangeCmpOp(t, other, &AdjacentRangeOp{}))
 			}
-			s.overloads = append(s.overloads, cmpOp)
 		}
+	}
+
+	// Array equality comparisons.
+	arrayElemTypes := append(append([]*types.T(nil), types.Scalar...), types.AnyEnum)
+	arrayElemTypes = append(arrayElemTypes, types.RangeTypes...)
+	arrayElemTypes = append(arrayElemTypes, types.MultirangeTypes...)
+	for _, t := range arrayElemTypes {
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1502,6 +1541,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeEqFn(types.Float, types.Float, volatility.Leakproof),
 		makeEqFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeEqFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeEqFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeEqFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeEqFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeEqFn(types.INet, types.INet, volatility.Leakproof),
@@ -1559,6 +1600,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeLtFn(types.Float, types.Float, volatility.Leakproof),
 		makeLtFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeLtFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeLtFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeLtFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLtFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLtFn(types.INet, types.INet, volatility.Leakproof),
@@ -1615,6 +1658,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeLeFn(types.Float, types.Float, volatility.Leakproof),
 		makeLeFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeLeFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeLeFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeLeFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLeFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLeFn(types.INet, types.INet, volatility.Leakproof),
@@ -1691,6 +1736,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeIsFn(types.Float, types.Float, volatility.Leakproof),
 		makeIsFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeIsFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeIsFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeIsFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeIsFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeIsFn(types.INet, types.INet, volatility.Leakproof),
@@ -1746,6 +1793,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.AnyTuple, volatility.Leakproof),
 		makeEvalTupleIn(types.Float, volatility.Leakproof),
 		makeEvalTupleIn(types.Box2D, volatility.Leakproof),
+		makeEvalTupleIn(types.TSQuery, volatility.Immutable),
+		makeEvalTupleIn(types.TSVector, volatility.Immutable),
 		makeEvalTupleIn(types.Geography, volatility.Leakproof),
 		makeEvalTupleIn(types.Geometry, volatility.Leakproof),
 		makeEvalTupleIn(types.INet, volatility.Leakproof),
@@ -1840,6 +1889,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
 	treecmp.Contains: {overloads: []*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1888,6 +1946,27 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	)...),
 	},
+
+	treecmp.TSMatches: {overloads: []*CmpOp{
+		{
+			LeftType:   types.TSVector,
+			RightType:  types.TSQuery,
+			EvalOp:     &TSMatchesVectorQueryOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.TSQuery,
+			RightType:  types.TSVector,
+			EvalOp:     &TSMatchesQueryVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
 })
 
 func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bool) []*CmpOp {
@@ -1961,6 +2040,17 @@ func CmpOpInverse(i treecmp.ComparisonOperatorSymbol) (treecmp.ComparisonOperato
 	return inverse, ok
 }
 
+// makeRangeCmpOp returns an overload of one of the range operators, all of
+// which are immutable.
+func makeRangeCmpOp(a, b *types.T, op BinaryEvalOp) *CmpOp {
+	return &CmpOp{
+		LeftType:   a,
+		RightType:  b,
+		EvalOp:     op,
+		Volatility: volatility.Immutable,
+	}
+}
+
 func makeEvalTupleIn(typ *types.T, v volatility.V) *CmpOp {
 	return &CmpOp{
 		LeftType:          typ,
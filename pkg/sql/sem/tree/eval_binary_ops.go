@@ -75,6 +75,18 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// AdjacentRangeOp is a BinaryEvalOp.
+type AdjacentRangeOp struct{}
+
 // AppendToMaybeNullArrayOp is a BinaryEvalOp.
 type AppendToMaybeNullArrayOp struct {
 	Typ *types.T
@@ -299,6 +311,10 @@ type (
 	ConcatJsonbOp struct{}
 	// ConcatStringOp is a BinaryEvalOp.
 	ConcatStringOp struct{}
+	// ConcatTSQueryOp is a BinaryEvalOp.
+	ConcatTSQueryOp struct{}
+	// ConcatTSVectorOp is a BinaryEvalOp.
+	ConcatTSVectorOp struct{}
 	// ConcatVarBitOp is a BinaryEvalOp.
 	ConcatVarBitOp struct{}
 )
@@ -356,6 +372,12 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
@@ -373,3 +395,9 @@ type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// TSMatchesVectorQueryOp is a BinaryEvalOp.
+type TSMatchesVectorQueryOp struct{}
+
+// TSMatchesQueryVectorOp is a BinaryEvalOp.
+type TSMatchesQueryVectorOp struct{}
@@ -55,6 +55,7 @@ type UnaryOpEvaluator interface {
 
 // UnaryOpEvaluator knows how to evaluate BinaryEvalOps.
 type BinaryOpEvaluator interface {
+	EvalAdjacentRangeOp(context.Context, *AdjacentRangeOp, Datum, Datum) (Datum, error)
 	EvalAppendToMaybeNullArrayOp(context.Context, *AppendToMaybeNullArrayOp, Datum, Datum) (Datum, error)
 	EvalBitAndINetOp(context.Context, *BitAndINetOp, Datum, Datum) (Datum, error)
 	EvalBitAndIntOp(context.Context, *BitAndIntOp, Datum, Datum) (Datum, error)
@@ -72,11 +73,15 @@ type BinaryOpEvaluator interface {
 	EvalConcatJsonbOp(context.Context, *ConcatJsonbOp, Datum, Datum) (Datum, error)
 	EvalConcatOp(context.Context, *ConcatOp, Datum, Datum) (Datum, error)
 	EvalConcatStringOp(context.Context, *ConcatStringOp, Datum, Datum) (Datum, error)
+	EvalConcatTSQueryOp(context.Context, *ConcatTSQueryOp, Datum, Datum) (Datum, error)
+	EvalConcatTSVectorOp(context.Context, *ConcatTSVectorOp, Datum, Datum) (Datum, error)
 	EvalConcatVarBitOp(context.Context, *ConcatVarBitOp, Datum, Datum) (Datum, error)
 	EvalContainedByArrayOp(context.Context, *ContainedByArrayOp, Datum, Datum) (Datum, error)
 	EvalContainedByJsonbOp(context.Context, *ContainedByJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainedByRangeOp(context.Context, *ContainedByRangeOp, Datum, Datum) (Datum, error)
 	EvalContainsArrayOp(context.Context, *ContainsArrayOp, Datum, Datum) (Datum, error)
 	EvalContainsJsonbOp(context.Context, *ContainsJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainsRangeOp(context.Context, *ContainsRangeOp, Datum, Datum) (Datum, error)
 	EvalDivDecimalIntOp(context.Context, *DivDecimalIntOp, Datum, Datum) (Datum, error)
 	EvalDivDecimalOp(context.Context, *DivDecimalOp, Datum, Datum) (Datum, error)
 	EvalDivFloatOp(context.Context, *DivFloatOp, Datum, Datum) (Datum, error)
@@ -98,6 +103,8 @@ type BinaryOpEvaluator interface {
 	EvalJSONFetchValIntOp(context.Context, *JSONFetchValIntOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValPathOp(context.Context, *JSONFetchValPathOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValStringOp(context.Context, *JSONFetchValStringOp, Datum, Datum) (Datum, error)
+	EvalJSONPathExistsOp(context.Context, *JSONPathExistsOp, Datum, Datum) (Datum, error)
+	EvalJSONPathMatchOp(context.Context, *JSONPathMatchOp, Datum, Datum) (Datum, error)
 	EvalJSONSomeExistsOp(context.Context, *JSONSomeExistsOp, Datum, Datum) (Datum, error)
 	EvalLShiftINetOp(context.Context, *LShiftINetOp, Datum, Datum) (Datum, error)
 	EvalLShiftIntOp(context.Context, *LShiftIntOp, Datum, Datum) (Datum, error)
@@ -147,6 +154,7 @@ type BinaryOpEvaluator interface {
 	EvalMultIntervalIntOp(context.Context, *MultIntervalIntOp, Datum, Datum) (Datum, error)
 	EvalOverlapsArrayOp(context.Context, *OverlapsArrayOp, Datum, Datum) (Datum, error)
 	EvalOverlapsINetOp(context.Context, *OverlapsINetOp, Datum, Datum) (Datum, error)
+	EvalOverlapsRangeOp(context.Context, *OverlapsRangeOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntOp(context.Context, *PlusDateIntOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntervalOp(context.Context, *PlusDateIntervalOp, Datum, Datum) (Datum, error)
 	EvalPlusDateTimeOp(context.Context, *PlusDateTimeOp, Datum, Datum) (Datum, error)
@@ -181,6 +189,8 @@ type BinaryOpEvaluator interface {
 	EvalRShiftIntOp(context.Context, *RShiftIntOp, Datum, Datum) (Datum, error)
 	EvalRShiftVarBitIntOp(context.Context, *RShiftVarBitIntOp, Datum, Datum) (Datum, error)
 	EvalSimilarToOp(context.Context, *SimilarToOp, Datum, Datum) (Datum, error)
+	EvalTSMatchesQueryVectorOp(context.Context, *TSMatchesQueryVectorOp, Datum, Datum) (Datum, error)
+	EvalTSMatchesVectorQueryOp(context.Context, *TSMatchesVectorQueryOp, Datum, Datum) (Datum, error)
 }
 
 
@@ -239,6 +249,11 @@ func (op *UnaryMinusIntervalOp) Eval(ctx context.Context, e OpEvaluator, v Datum
 	return e.EvalUnaryMinusIntervalOp(ctx, op, v)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *AdjacentRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalAdjacentRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *AppendToMaybeNullArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalAppendToMaybeNullArrayOp(ctx, op, a, b)
@@ -324,6 +339,16 @@ func (op *ConcatStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalConcatStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ConcatTSQueryOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalConcatTSQueryOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *ConcatTSVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalConcatTSVectorOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *ConcatVarBitOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalConcatVarBitOp(ctx, op, a, b)
@@ -339,6 +364,11 @@ func (op *ContainedByJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datu
 	return e.EvalContainedByJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainedByRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainedByRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *ContainsArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalContainsArrayOp(ctx, op, a, b)
@@ -349,6 +379,11 @@ func (op *ContainsJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalContainsJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *DivDecimalIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalDivDecimalIntOp(ctx, op, a, b)
@@ -454,6 +489,16 @@ func (op *JSONFetchValStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Da
 	return e.EvalJSONFetchValStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathExistsOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathMatchOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathMatchOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *JSONSomeExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalJSONSomeExistsOp(ctx, op, a, b)
@@ -699,6 +744,11 @@ func (op *OverlapsINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalOverlapsINetOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *OverlapsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalOverlapsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *PlusDateIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalPlusDateIntOp(ctx, op, a, b)
@@ -869,3 +919,13 @@ func (op *SimilarToOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Dat
 	return e.EvalSimilarToOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *TSMatchesQueryVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalTSMatchesQueryVectorOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *TSMatchesVectorQueryOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalTSMatchesVectorQueryOp(ctx, op, a, b)
+}
+
@@ -1709,6 +1709,11 @@ func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
 func (node *DInterval) String() string        { return AsString(node) }
 func (node *DJSON) String() string            { return AsString(node) }
+func (node *DTSQuery) String() string         { return AsString(node) }
+func (node *DTSVector) String() string        { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
+func (node *DMultirange) String() string      { return AsString(node) }
+func (node *DJsonpath) String() string        { return AsString(node) }
 func (node *DUuid) String() string            { return AsString(node) }
 func (node *DIPAddr) String() string          { return AsString(node) }
 func (node *DString) String() string          { return AsString(node) }
@@ -1748,6 +1753,7 @@ func (node *UnaryExpr) String() string        { return AsString(node) }
 func (node DefaultVal) String() string        { return AsString(node) }
 func (node PartitionMaxVal) String() string   { return AsString(node) }
 func (node PartitionMinVal) String() string   { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *Placeholder) String() string      { return AsString(node) }
 func (node dNull) String() string             { return AsString(node) }
 func (list *NameList) String() string         { return AsString(list) }
@@ -188,9 +188,32 @@ type Overload struct {
 	UDFContainsOnlySignature bool
 	// Body is the SQL string body of a user-defined function.
 	Body string
+	// Language is the language of the body of a user-defined function.
+	Language FunctionLanguage
 	// ReturnSet is set to true when a user-defined function is defined to return
 	// a set of values.
 	ReturnSet bool
+	// IsProcedure is set to true when the user-defined overload is a procedure,
+	// which can only be invoked with a CALL statement.
+	IsProcedure bool
+	// Aggregate is set when the user-defined overload is an aggregate created
+	// with CREATE AGGREGATE. It is nil if UDFContainsOnlySignature is true.
+	Aggregate *RoutineAggregate
+}
+
+// RoutineAggregate describes the support functions of a user-defined
+// aggregate.
+type RoutineAggregate struct {
+	// TransitionFunc is the OID of the state transition function.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the final function, or zero if the final state is
+	// the result of the aggregate.
+	FinalFunc oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// InitCond is the initial value of the state as a string, or nil if the
+	// state is initially NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
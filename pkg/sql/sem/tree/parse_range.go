@@ -0,0 +1,196 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.New(pgcode.InvalidTextRepresentation, "malformed range literal")
+var malformedMultirangeError = pgerror.New(pgcode.InvalidTextRepresentation, "malformed multirange literal")
+
+// ParseDRangeFromString parses the string form of a range, such as
+// '[1,5)', '(,10]' or 'empty'. The input type t is the type of the range to
+// parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx}
+	r, err := p.parseRange(t)
+	if err == nil {
+		p.eatWhitespace()
+		if len(p.s) != 0 {
+			err = errors.WithDetail(malformedRangeError, "Junk after right parenthesis or bracket.")
+		}
+	}
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return r, p.dependsOnContext, nil
+}
+
+// ParseDMultirangeFromString parses the string form of a multirange, such as
+// '{[1,5), [8,10)}' or '{}'. The input type t is the type of the multirange to
+// parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx}
+	m, err := p.parseMultirange(t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return m, p.dependsOnContext, nil
+}
+
+type rangeParseState struct {
+	s                string
+	ctx              ParseTimeContext
+	dependsOnContext bool
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	p.s = strings.TrimLeftFunc(p.s, unicode.IsSpace)
+}
+
+func (p *rangeParseState) parseMultirange(t *types.T) (*DMultirange, error) {
+	p.eatWhitespace()
+	if len(p.s) == 0 || p.s[0] != '{' {
+		return nil, errors.WithDetail(malformedMultirangeError, "Missing left brace.")
+	}
+	p.s = p.s[1:]
+	rangeTyp := types.MakeRange(t.RangeContents())
+	var ranges []*DRange
+	p.eatWhitespace()
+	if len(p.s) > 0 && p.s[0] == '}' {
+		p.s = p.s[1:]
+	} else {
+		for {
+			r, err := p.parseRange(rangeTyp)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+			p.eatWhitespace()
+			if len(p.s) == 0 {
+				return nil, errors.WithDetail(malformedMultirangeError, "Unexpected end of input.")
+			}
+			c := p.s[0]
+			p.s = p.s[1:]
+			if c == '}' {
+				break
+			}
+			if c != ',' {
+				return nil, errors.WithDetail(malformedMultirangeError, "Expected comma or end of multirange.")
+			}
+		}
+	}
+	p.eatWhitespace()
+	if len(p.s) != 0 {
+		return nil, errors.WithDetail(malformedMultirangeError, "Junk after closing right brace.")
+	}
+	return MakeDMultirange(t, ranges), nil
+}
+
+func (p *rangeParseState) parseRange(t *types.T) (*DRange, error) {
+	p.eatWhitespace()
+	if len(p.s) >= len("empty") && strings.EqualFold(p.s[:len("empty")], "empty") {
+		p.s = p.s[len("empty"):]
+		return NewEmptyDRange(t), nil
+	}
+
+	var lower, upper RangeBound
+	switch {
+	case strings.HasPrefix(p.s, "["):
+		lower.Inclusive = true
+	case strings.HasPrefix(p.s, "("):
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Missing left parenthesis or bracket.")
+	}
+	p.s = p.s[1:]
+	var err error
+	if lower.Val, err = p.parseBound(t.RangeContents()); err != nil {
+		return nil, err
+	}
+	if len(p.s) == 0 || p.s[0] != ',' {
+		return nil, errors.WithDetail(malformedRangeError, "Missing comma after lower bound.")
+	}
+	p.s = p.s[1:]
+	if upper.Val, err = p.parseBound(t.RangeContents()); err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.HasPrefix(p.s, "]"):
+		upper.Inclusive = true
+	case strings.HasPrefix(p.s, ")"):
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Too many commas.")
+	}
+	p.s = p.s[1:]
+	return MakeDRange(t, lower, upper)
+}
+
+// parseBound parses a bound value, which may be double-quoted and contain
+// backslash escapes. It returns nil for an infinite bound, which is
+// represented by an unquoted empty string.
+func (p *rangeParseState) parseBound(elemTyp *types.T) (Datum, error) {
+	if len(p.s) > 0 && (p.s[0] == ',' || p.s[0] == ')' || p.s[0] == ']') {
+		return nil, nil
+	}
+	var b strings.Builder
+	inQuote := false
+	for {
+		if len(p.s) == 0 {
+			return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+		}
+		c := p.s[0]
+		if !inQuote && (c == ',' || c == ')' || c == ']') {
+			break
+		}
+		p.s = p.s[1:]
+		switch {
+		case c == '\\':
+			if len(p.s) == 0 {
+				return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+			}
+			b.WriteByte(p.s[0])
+			p.s = p.s[1:]
+		case c == '"' && inQuote && len(p.s) > 0 && p.s[0] == '"':
+			// Two double quotes within a quoted string stand for one double quote.
+			b.WriteByte('"')
+			p.s = p.s[1:]
+		case c == '"':
+			inQuote = !inQuote
+		default:
+			b.WriteByte(c)
+		}
+	}
+	d, dependsOnContext, err := ParseAndRequireString(elemTyp, b.String(), p.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dependsOnContext {
+		p.dependsOnContext = true
+	}
+	return d, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestParseDRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`empty`, types.Int8Range, `empty`},
+		{` EMPTY `, types.Int8Range, `empty`},
+		{`[1,5)`, types.Int8Range, `[1,5)`},
+		{`[1,5]`, types.Int8Range, `[1,6)`},
+		{`(1,5]`, types.Int8Range, `[2,6)`},
+		{`(1,5)`, types.Int8Range, `[2,5)`},
+		{`(1,2)`, types.Int8Range, `empty`},
+		{`[3,3)`, types.Int8Range, `empty`},
+		{`[3,3]`, types.Int8Range, `[3,4)`},
+		{`( 1 , 5 )`, types.Int8Range, `[2,5)`},
+		{`(,5)`, types.Int8Range, `(,5)`},
+		{`[,5]`, types.Int8Range, `(,6)`},
+		{`[1,)`, types.Int8Range, `[1,)`},
+		{`(,)`, types.Int8Range, `(,)`},
+		{`["1","5")`, types.Int4Range, `[1,5)`},
+		{`[1.5,2.50]`, types.NumRange, `[1.5,2.50]`},
+		{`(1.5,2.5)`, types.NumRange, `(1.5,2.5)`},
+		{`[2000-01-01,2000-01-05]`, types.DateRange, `[2000-01-01,2000-01-06)`},
+		{
+			`["2000-01-01 00:00:00","2000-01-02 00:00:00")`,
+			types.TSRange,
+			`["2000-01-01 00:00:00","2000-01-02 00:00:00")`,
+		},
+	}
+	evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			actual, _, err := tree.ParseDRangeFromString(evalContext, td.str, td.typ)
+			if err != nil {
+				t.Fatalf("range %s: got error %s, expected %s", td.str, err.Error(), td.expected)
+			}
+			if s := tree.AsStringWithFlags(actual, tree.FmtPgwireText); s != td.expected {
+				t.Fatalf("range %s: expected %s, got %s", td.str, td.expected, s)
+			}
+		})
+	}
+}
+
+func TestParseDRangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str           string
+		typ           *types.T
+		expectedError string
+	}{
+		{``, types.Int8Range, `could not parse "" as type int8range: malformed range literal`},
+		{`1,5`, types.Int8Range, `could not parse "1,5" as type int8range: malformed range literal`},
+		{`[1,5`, types.Int8Range, `could not parse "[1,5" as type int8range: malformed range literal`},
+		{`[1,2,3)`, types.Int8Range, `could not parse "[1,2,3)" as type int8range: malformed range literal`},
+		{`[1,5) x`, types.Int8Range, `could not parse "[1,5) x" as type int8range: malformed range literal`},
+		{
+			`[5,1)`,
+			types.Int8Range,
+			`could not parse "[5,1)" as type int8range: range lower bound must be less than or equal to range upper bound`,
+		},
+		{
+			`[a,b)`,
+			types.Int8Range,
+			`could not parse "[a,b)" as type int8range: could not parse "a" as type int: strconv.ParseInt: parsing "a": invalid syntax`,
+		},
+		{
+			`[1,3000000000)`,
+			types.Int4Range,
+			`could not parse "[1,3000000000)" as type int4range: integer out of range for type int4`,
+		},
+	}
+	evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			_, _, err := tree.ParseDRangeFromString(evalContext, td.str, td.typ)
+			if err == nil {
+				t.Fatalf("expected %#v to error with message %#v", td.str, td.expectedError)
+			}
+			if err.Error() != td.expectedError {
+				t.Fatalf("range %s: got error %s, expected error %s", td.str, err.Error(), td.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseDMultirange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`{}`, types.Int8Multirange, `{}`},
+		{` { } `, types.Int8Multirange, `{}`},
+		{`{empty}`, types.Int8Multirange, `{}`},
+		{`{[1,5)}`, types.Int8Multirange, `{[1,5)}`},
+		{`{[5,8), [1,3)}`, types.Int8Multirange, `{[1,3),[5,8)}`},
+		{`{[1,3), [3,5)}`, types.Int8Multirange, `{[1,5)}`},
+		{`{[1,4), [2,5), empty}`, types.Int8Multirange, `{[1,5)}`},
+		{`{(,0], [10,)}`, types.Int8Multirange, `{(,1),[10,)}`},
+		{`{[1.5,2], (2,3)}`, types.NumMultirange, `{[1.5,3)}`},
+		{`{[1.5,2), (2,3)}`, types.NumMultirange, `{[1.5,2),(2,3)}`},
+	}
+	evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			actual, _, err := tree.ParseDMultirangeFromString(evalContext, td.str, td.typ)
+			if err != nil {
+				t.Fatalf("multirange %s: got error %s, expected %s", td.str, err.Error(), td.expected)
+			}
+			if s := tree.AsStringWithFlags(actual, tree.FmtPgwireText); s != td.expected {
+				t.Fatalf("multirange %s: expected %s, got %s", td.str, td.expected, s)
+			}
+		})
+	}
+}
+
+func TestParseDMultirangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []string{
+		``,
+		`[1,5)`,
+		`{[1,5)`,
+		`{[1,5) [6,7)}`,
+		`{[1,5),}`,
+		`{[1,5)} x`,
+	}
+	evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, str := range testData {
+		t.Run(str, func(t *testing.T) {
+			if _, _, err := tree.ParseDMultirangeFromString(evalContext, str, types.Int8Multirange); err == nil {
+				t.Fatalf("expected %#v to error", str)
+			}
+		})
+	}
+}
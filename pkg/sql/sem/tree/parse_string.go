@@ -65,6 +65,12 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.TSQueryFamily:
+		d, err = ParseDTSQuery(s)
+	case types.TSVectorFamily:
+		d, err = ParseDTSVector(s)
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == ZeroOidValue {
 			d = WrapAsZeroOid(t)
@@ -93,6 +99,10 @@ func ParseAndRequireString(
 		d, err = MakeDEnumFromLogicalRepresentation(t, s)
 	case types.TupleFamily:
 		d, dependsOnContext, err = ParseDTupleFromString(ctx, s, t)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.VoidFamily:
 		d = DVoidDatum
 	default:
@@ -79,15 +79,63 @@ func (d *DTuple) pgwireFormat(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+func (d *DRange) pgwireFormat(ctx *FmtCtx) {
+	// Ranges are rendered as a pair of bounds enclosed in brackets or
+	// parentheses, depending on whether the bounds are inclusive. The bound
+	// values are printed in "postgres mode" and quoted like tuple elements.
+	// Infinite bounds are printed as the empty string.
+	if d.Empty {
+		ctx.WriteString("empty")
+		return
+	}
+	if d.Lower.Inclusive {
+		ctx.WriteByte('[')
+	} else {
+		ctx.WriteByte('(')
+	}
+	d.pgwireFormatBound(ctx, d.Lower)
+	ctx.WriteByte(',')
+	d.pgwireFormatBound(ctx, d.Upper)
+	if d.Upper.Inclusive {
+		ctx.WriteByte(']')
+	} else {
+		ctx.WriteByte(')')
+	}
+}
+
+func (d *DRange) pgwireFormatBound(ctx *FmtCtx, b RangeBound) {
+	if b.IsInfinite() {
+		return
+	}
+	s := AsStringWithFlags(b.Val, ctx.flags, FmtDataConversionConfig(ctx.dataConversionConfig))
+	pgwireFormatStringWithDoubledEscapes(&ctx.Buffer, s, s == "" || rangeQuoteSet.in(s))
+}
+
+func (d *DMultirange) pgwireFormat(ctx *FmtCtx) {
+	ctx.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			ctx.WriteByte(',')
+		}
+		r.pgwireFormat(ctx)
+	}
+	ctx.WriteByte('}')
+}
+
 func pgwireFormatStringInTuple(buf *bytes.Buffer, in string) {
-	quote := pgwireQuoteStringInTuple(in)
+	pgwireFormatStringWithDoubledEscapes(buf, in, pgwireQuoteStringInTuple(in))
+}
+
+// pgwireFormatStringWithDoubledEscapes writes the given string, optionally
+// enclosed in double quotes, doubling any double quotes and backslashes.
+func pgwireFormatStringWithDoubledEscapes(buf *bytes.Buffer, in string, quote bool) {
 	if quote {
 		buf.WriteByte('"')
 	}
 	// Loop through each unicode code point.
 	for _, r := range in {
 		if r == '"' || r == '\\' {
-			// Strings in tuples double " and \.
+			// Strings in tuples and ranges double " and \.
 			buf.WriteByte(byte(r))
 			buf.WriteByte(byte(r))
 		} else {
@@ -160,7 +208,7 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	}
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -172,6 +220,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 // PgwireFormatFloat returns a []byte representing a float according to
@@ -1792,6 +1792,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1812,6 +1815,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1819,8 +1823,9 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
-	clauses := make([]pretty.Doc, 0, 4)
+	clauses := make([]pretty.Doc, 0, 5)
 	title := pretty.ConcatSpace(
 		pretty.Keyword("FOREIGN KEY"),
 		p.bracket("(", p.Doc(&node.FromCols), ")"))
@@ -1845,6 +1850,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2026,6 +2035,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if d := p.Doc(&node.References.Deferrability); d != pretty.Nil {
+			fkDetails = append(fkDetails, d)
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -2067,6 +2079,9 @@ func (node *CheckConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//
 	d := pretty.ConcatSpace(pretty.Keyword("CHECK"),
 		p.bracket("(", p.Doc(node.Expr), ")"))
+	if deferrability := p.Doc(&node.Deferrability); deferrability != pretty.Nil {
+		d = pretty.ConcatSpace(d, deferrability)
+	}
 
 	if node.Name != "" {
 		d = p.nestUnder(
@@ -2080,6 +2095,16 @@ func (node *CheckConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	return d
 }
 
+func (node *ConstraintDeferrability) doc(p *PrettyCfg) pretty.Doc {
+	switch *node {
+	case ConstraintInitiallyImmediate:
+		return pretty.Keyword("DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	}
+	return pretty.Nil
+}
+
 func (node *ReferenceActions) doc(p *PrettyCfg) pretty.Doc {
 	var docs []pretty.Doc
 	if node.Delete != NoAction {
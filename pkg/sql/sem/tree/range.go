@@ -0,0 +1,393 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var errRangeBoundsOutOfOrder = pgerror.New(
+	pgcode.DataException, "range lower bound must be less than or equal to range upper bound",
+)
+
+// NewEmptyDRange returns the empty range of the given type.
+func NewEmptyDRange(typ *types.T) *DRange {
+	return &DRange{Typ: typ, Empty: true}
+}
+
+// MakeDRange returns the range of the given type with the given bounds. The
+// bound values must have the element type of the range. It returns an error
+// if the lower bound is greater than the upper bound.
+//
+// Like in Postgres, the ranges of discrete element types (integers and dates)
+// are canonicalized to have an inclusive lower bound and an exclusive upper
+// bound, so that equal ranges always have equal bounds.
+func MakeDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if lower.IsInfinite() {
+		lower.Inclusive = false
+	}
+	if upper.IsInfinite() {
+		upper.Inclusive = false
+	}
+	elemTyp := typ.RangeContents()
+	if elemTyp.Family() == types.IntFamily && elemTyp.Width() == 32 {
+		for _, b := range [...]RangeBound{lower, upper} {
+			if i, ok := b.Val.(*DInt); ok && (*i > math.MaxInt32 || *i < math.MinInt32) {
+				return nil, ErrInt4OutOfRange
+			}
+		}
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		c := compareRangeBoundValues(lower.Val, upper.Val)
+		if c > 0 {
+			return nil, errRangeBoundsOutOfOrder
+		}
+		if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+
+	if !lower.IsInfinite() && !lower.Inclusive {
+		next, ok, err := discreteRangeSuccessor(elemTyp, lower.Val)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lower = RangeBound{Val: next, Inclusive: true}
+		}
+	}
+	if !upper.IsInfinite() && upper.Inclusive {
+		next, ok, err := discreteRangeSuccessor(elemTyp, upper.Val)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			upper = RangeBound{Val: next, Inclusive: false}
+		}
+	}
+	// Canonicalization can turn a range like (1,2) into the empty range [2,2).
+	if !lower.IsInfinite() && !upper.IsInfinite() &&
+		compareRangeBoundValues(lower.Val, upper.Val) == 0 && !(lower.Inclusive && upper.Inclusive) {
+		return NewEmptyDRange(typ), nil
+	}
+	return &DRange{Typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// discreteRangeSuccessor returns the value following d if the given element
+// type is discrete, which is used to canonicalize range bounds. It returns
+// false if d cannot be adjusted, either because the type is not discrete or
+// because d is an infinite date.
+func discreteRangeSuccessor(elemTyp *types.T, d Datum) (_ Datum, ok bool, _ error) {
+	switch t := d.(type) {
+	case *DInt:
+		if elemTyp.Width() == 32 && *t >= math.MaxInt32 {
+			return nil, false, ErrInt4OutOfRange
+		}
+		if *t == math.MaxInt64 {
+			return nil, false, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), true, nil
+	case *DDate:
+		if !t.IsFinite() {
+			return nil, false, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return NewDDate(next), true, nil
+	}
+	return nil, false, nil
+}
+
+// MakeDMultirange returns the multirange of the given type that contains the
+// union of the given ranges. Overlapping and adjacent ranges are merged, and
+// empty ranges are dropped.
+func MakeDMultirange(typ *types.T, ranges []*DRange) *DMultirange {
+	sorted := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareRanges(sorted[i], sorted[j]) < 0
+	})
+	merged := sorted[:0]
+	for _, r := range sorted {
+		if n := len(merged); n > 0 {
+			if last := merged[n-1]; last.overlaps(r) || last.isAdjacentTo(r) {
+				merged[n-1] = MergeRanges(last, r)
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return &DMultirange{Typ: typ, Ranges: merged}
+}
+
+// Span returns the smallest range that contains the multirange.
+func (d *DMultirange) Span() *DRange {
+	if len(d.Ranges) == 0 {
+		return NewEmptyDRange(types.MakeRange(d.Typ.RangeContents()))
+	}
+	return MergeRanges(d.Ranges[0], d.Ranges[len(d.Ranges)-1])
+}
+
+// MergeRanges returns the smallest range that contains both a and b.
+func MergeRanges(a, b *DRange) *DRange {
+	if a.Empty {
+		return b
+	}
+	if b.Empty {
+		return a
+	}
+	res := *a
+	if compareRangeBounds(b.Lower, true /* aIsLower */, a.Lower, true /* bIsLower */) < 0 {
+		res.Lower = b.Lower
+	}
+	if compareRangeBounds(b.Upper, false /* aIsLower */, a.Upper, false /* bIsLower */) > 0 {
+		res.Upper = b.Upper
+	}
+	return &res
+}
+
+// RangeOverlaps implements the && operator. It returns true if a and b, each
+// of which is a range or a multirange, have a value in common.
+func RangeOverlaps(a, b Datum) bool {
+	for _, ra := range rangesOf(a) {
+		for _, rb := range rangesOf(b) {
+			if ra.overlaps(rb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RangeContains implements the @> operator. It returns true if a, which is a
+// range or a multirange, contains b, which is a range, a multirange or a value
+// of the element type.
+func RangeContains(a, b Datum) bool {
+	switch t := UnwrapDOidWrapper(b).(type) {
+	case *DRange, *DMultirange:
+		// Every range of b must be contained by a range of a. Since the ranges of
+		// a multirange neither overlap nor are adjacent, no range of b can be
+		// contained by more than one range of a.
+		for _, rb := range rangesOf(t) {
+			contained := false
+			for _, ra := range rangesOf(a) {
+				if ra.containsRange(rb) {
+					contained = true
+					break
+				}
+			}
+			if !contained {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, ra := range rangesOf(a) {
+			if ra.containsElement(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RangeAdjacent implements the -|- operator. It returns true if a and b, each
+// of which is a range or a multirange, do not overlap but have no values
+// between them.
+func RangeAdjacent(a, b Datum) bool {
+	return rangeSpan(a).isAdjacentTo(rangeSpan(b))
+}
+
+// rangesOf returns the nonempty ranges of the given range or multirange.
+func rangesOf(d Datum) []*DRange {
+	switch t := UnwrapDOidWrapper(d).(type) {
+	case *DRange:
+		if t.Empty {
+			return nil
+		}
+		return []*DRange{t}
+	case *DMultirange:
+		return t.Ranges
+	}
+	panic(errors.AssertionFailedf("expected range or multirange, found %T", d))
+}
+
+// rangeSpan returns the smallest range containing the given range or
+// multirange.
+func rangeSpan(d Datum) *DRange {
+	switch t := UnwrapDOidWrapper(d).(type) {
+	case *DRange:
+		return t
+	case *DMultirange:
+		return t.Span()
+	}
+	panic(errors.AssertionFailedf("expected range or multirange, found %T", d))
+}
+
+func (d *DRange) overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	if compareRangeBounds(d.Lower, true /* aIsLower */, other.Lower, true /* bIsLower */) >= 0 &&
+		compareRangeBounds(d.Lower, true /* aIsLower */, other.Upper, false /* bIsLower */) <= 0 {
+		return true
+	}
+	return compareRangeBounds(other.Lower, true /* aIsLower */, d.Lower, true /* bIsLower */) >= 0 &&
+		compareRangeBounds(other.Lower, true /* aIsLower */, d.Upper, false /* bIsLower */) <= 0
+}
+
+func (d *DRange) containsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true /* aIsLower */, other.Lower, true /* bIsLower */) <= 0 &&
+		compareRangeBounds(d.Upper, false /* aIsLower */, other.Upper, false /* bIsLower */) >= 0
+}
+
+func (d *DRange) containsElement(elem Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if !d.Lower.IsInfinite() {
+		c := compareRangeBoundValues(d.Lower.Val, elem)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.IsInfinite() {
+		c := compareRangeBoundValues(d.Upper.Val, elem)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAdjacentTo returns true if one of the ranges ends exactly where the other
+// one begins. Since the ranges of discrete element types are canonicalized,
+// this is the case if and only if the bound values are equal and exactly one
+// of the bounds is inclusive.
+func (d *DRange) isAdjacentTo(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	adjacent := func(upper, lower RangeBound) bool {
+		return !upper.IsInfinite() && !lower.IsInfinite() &&
+			compareRangeBoundValues(upper.Val, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+	}
+	return adjacent(d.Upper, other.Lower) || adjacent(other.Upper, d.Lower)
+}
+
+// compareRanges orders ranges by their lower bounds and then by their upper
+// bounds. The empty range sorts before all other ranges.
+func compareRanges(a, b *DRange) int {
+	switch {
+	case a.Empty && b.Empty:
+		return 0
+	case a.Empty:
+		return -1
+	case b.Empty:
+		return 1
+	}
+	if c := compareRangeBounds(a.Lower, true /* aIsLower */, b.Lower, true /* bIsLower */); c != 0 {
+		return c
+	}
+	return compareRangeBounds(a.Upper, false /* aIsLower */, b.Upper, false /* bIsLower */)
+}
+
+// compareRangeBounds compares two range bounds, each of which is either a
+// lower or an upper bound, by the position in the element domain where they
+// start or stop including values. An infinite lower bound sorts before, and an
+// infinite upper bound after all other bounds. An exclusive lower bound sorts
+// after, and an exclusive upper bound before an inclusive bound with the same
+// value.
+func compareRangeBounds(a RangeBound, aIsLower bool, b RangeBound, bIsLower bool) int {
+	lowerFirst := func(isLower bool) int {
+		if isLower {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case a.IsInfinite() && b.IsInfinite():
+		if aIsLower == bIsLower {
+			return 0
+		}
+		return lowerFirst(aIsLower)
+	case a.IsInfinite():
+		return lowerFirst(aIsLower)
+	case b.IsInfinite():
+		return -lowerFirst(bIsLower)
+	}
+	if c := compareRangeBoundValues(a.Val, b.Val); c != 0 {
+		return c
+	}
+	switch {
+	case !a.Inclusive && !b.Inclusive:
+		if aIsLower == bIsLower {
+			return 0
+		}
+		return -lowerFirst(aIsLower)
+	case !a.Inclusive:
+		return -lowerFirst(aIsLower)
+	case !b.Inclusive:
+		return lowerFirst(bIsLower)
+	}
+	return 0
+}
+
+// compareRangeBoundValues compares two values of the element type of a range.
+func compareRangeBoundValues(a, b Datum) int {
+	return a.Compare(rangeCompareContext{}, b)
+}
+
+// rangeCompareContext is the CompareContext used to compare the values of
+// range bounds. These values always have the same type and are never wrapped
+// or placeholders, so comparing them does not depend on the session.
+type rangeCompareContext struct{}
+
+var _ CompareContext = rangeCompareContext{}
+
+// UnwrapDatum implements the CompareContext interface.
+func (rangeCompareContext) UnwrapDatum(d Datum) Datum {
+	return UnwrapDOidWrapper(d)
+}
+
+// GetLocation implements the CompareContext interface.
+func (rangeCompareContext) GetLocation() *time.Location {
+	return time.UTC
+}
+
+// GetRelativeParseTime implements the CompareContext interface.
+func (rangeCompareContext) GetRelativeParseTime() time.Time {
+	return time.Time{}
+}
+
+// MustGetPlaceholderValue implements the CompareContext interface.
+func (rangeCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range bound", p))
+}
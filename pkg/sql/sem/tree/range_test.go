@@ -0,0 +1,154 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// parseRangeOrMultirange parses s as an INT8MULTIRANGE if it starts with a
+// brace, and as an INT8RANGE otherwise.
+func parseRangeOrMultirange(t *testing.T, evalCtx *eval.Context, s string) tree.Datum {
+	if s[0] == '{' {
+		m, _, err := tree.ParseDMultirangeFromString(evalCtx, s, types.Int8Multirange)
+		require.NoError(t, err)
+		return m
+	}
+	r, _, err := tree.ParseDRangeFromString(evalCtx, s, types.Int8Range)
+	require.NoError(t, err)
+	return r
+}
+
+func TestRangeOperators(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		a, b                         string
+		overlaps, contains, adjacent bool
+	}{
+		{a: `[1,5)`, b: `[3,8)`, overlaps: true},
+		{a: `[1,5)`, b: `[5,8)`, adjacent: true},
+		{a: `[1,5]`, b: `[5,8)`, overlaps: true},
+		{a: `[1,5)`, b: `[2,3)`, overlaps: true, contains: true},
+		{a: `[1,5)`, b: `[1,5)`, overlaps: true, contains: true},
+		{a: `[1,5)`, b: `[6,8)`},
+		{a: `(,)`, b: `[6,8)`, overlaps: true, contains: true},
+		{a: `(,5)`, b: `[5,)`, adjacent: true},
+		{a: `[1,5)`, b: `empty`, contains: true},
+		{a: `empty`, b: `empty`, contains: true},
+		{a: `{[1,3), [5,8)}`, b: `[3,5)`, adjacent: false},
+		{a: `{[1,3), [5,8)}`, b: `[2,6)`, overlaps: true},
+		{a: `{[1,3), [5,8)}`, b: `[5,6)`, overlaps: true, contains: true},
+		{a: `{[1,3), [5,8)}`, b: `{[1,2), [6,7)}`, overlaps: true, contains: true},
+		{a: `{[1,3), [5,8)}`, b: `{[1,2), [6,9)}`, overlaps: true},
+		{a: `{[1,3), [5,8)}`, b: `[8,9)`, adjacent: true},
+		{a: `{}`, b: `{}`, contains: true},
+	}
+	for _, td := range testData {
+		t.Run(td.a+" "+td.b, func(t *testing.T) {
+			a := parseRangeOrMultirange(t, evalCtx, td.a)
+			b := parseRangeOrMultirange(t, evalCtx, td.b)
+			require.Equal(t, td.overlaps, tree.RangeOverlaps(a, b), "overlaps")
+			require.Equal(t, td.overlaps, tree.RangeOverlaps(b, a), "overlaps (commuted)")
+			require.Equal(t, td.contains, tree.RangeContains(a, b), "contains")
+			require.Equal(t, td.adjacent, tree.RangeAdjacent(a, b), "adjacent")
+			require.Equal(t, td.adjacent, tree.RangeAdjacent(b, a), "adjacent (commuted)")
+		})
+	}
+}
+
+func TestRangeContainsElement(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		r        string
+		elem     int
+		expected bool
+	}{
+		{`[1,5)`, 1, true},
+		{`[1,5)`, 4, true},
+		{`[1,5)`, 5, false},
+		{`[1,5)`, 0, false},
+		{`(,5)`, -100, true},
+		{`[1,)`, 100, true},
+		{`empty`, 1, false},
+		{`{[1,3), [5,8)}`, 4, false},
+		{`{[1,3), [5,8)}`, 5, true},
+		{`{}`, 5, false},
+	}
+	for _, td := range testData {
+		r := parseRangeOrMultirange(t, evalCtx, td.r)
+		require.Equal(t, td.expected, tree.RangeContains(r, tree.NewDInt(tree.DInt(td.elem))), "%s @> %d", td.r, td.elem)
+	}
+}
+
+func TestRangeCompare(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	// The ranges and multiranges are in ascending order.
+	for _, sorted := range [][]string{
+		{`empty`, `(,0)`, `(,5)`, `(,)`, `[1,2)`, `[1,3)`, `[1,)`, `[2,3)`},
+		{`{}`, `{(,0)}`, `{[1,2)}`, `{[1,2), [3,4)}`, `{[1,2), [3,5)}`, `{[1,3)}`},
+	} {
+		for i := range sorted {
+			for j := range sorted {
+				a := parseRangeOrMultirange(t, evalCtx, sorted[i])
+				b := parseRangeOrMultirange(t, evalCtx, sorted[j])
+				c := a.Compare(evalCtx, b)
+				switch {
+				case i < j:
+					require.Equal(t, -1, c, "%s < %s", sorted[i], sorted[j])
+				case i == j:
+					require.Equal(t, 0, c, "%s = %s", sorted[i], sorted[j])
+				default:
+					require.Equal(t, 1, c, "%s > %s", sorted[i], sorted[j])
+				}
+			}
+		}
+	}
+}
+
+func TestMergeRanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		a, b, expected string
+	}{
+		{`[1,5)`, `[3,8)`, `[1,8)`},
+		{`[1,2)`, `[5,8)`, `[1,8)`},
+		{`[1,5)`, `empty`, `[1,5)`},
+		{`empty`, `empty`, `empty`},
+		{`(,5)`, `[3,8)`, `(,8)`},
+		{`[1,5)`, `[3,)`, `[1,)`},
+	}
+	for _, td := range testData {
+		a := parseRangeOrMultirange(t, evalCtx, td.a).(*tree.DRange)
+		b := parseRangeOrMultirange(t, evalCtx, td.b).(*tree.DRange)
+		res := tree.AsStringWithFlags(tree.MergeRanges(a, b), tree.FmtPgwireText)
+		require.Equal(t, td.expected, res, "range_merge(%s, %s)", td.a, td.b)
+	}
+}
@@ -67,6 +67,11 @@ type RoutineExpr struct {
 	// its inputs are NULL. If false, the function will not be evaluated in the
 	// presence of null inputs, and will instead evaluate directly to NULL.
 	CalledOnNullInput bool
+
+	// Program, if non-nil, describes the control flow of a routine written in a
+	// procedural language. In that case, the statements of the routine are
+	// executed as directed by the program rather than sequentially.
+	Program *RoutineProgram
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -78,6 +83,7 @@ func NewTypedRoutineExpr(
 	typ *types.T,
 	v volatility.V,
 	calledOnNullInput bool,
+	program *RoutineProgram,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Input:             input,
@@ -87,6 +93,7 @@ func NewTypedRoutineExpr(
 		Volatility:        v,
 		CalledOnNullInput: calledOnNullInput,
 		Name:              name,
+		Program:           program,
 	}
 }
 
@@ -119,3 +126,22 @@ func (node *RoutineExpr) Walk(v Visitor) Expr {
 	// Cannot walk into a routine, so this is a no-op.
 	return node
 }
+
+// UserDefinedAggregate describes the execution of an aggregate created with
+// CREATE AGGREGATE. It is only created by execbuilder.
+type UserDefinedAggregate struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Transition computes the next state of the aggregate from the current
+	// state followed by the arguments of the aggregate.
+	Transition *RoutineExpr
+
+	// Final computes the result of the aggregate from the final state. It is
+	// nil if the final state is the result.
+	Final *RoutineExpr
+
+	// InitCond is the initial state of the aggregate. It is DNull if the
+	// aggregate has no initial condition.
+	InitCond Datum
+}
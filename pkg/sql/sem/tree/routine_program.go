@@ -0,0 +1,167 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/types"
+
+// RoutineProgram describes the control flow of a routine written in a
+// procedural language, e.g. a PL/pgSQL function. The expressions and SQL
+// statements in the program are not stored in the program itself. Instead,
+// they are referenced by their index in the statements of the RoutineExpr that
+// owns the program, and they are planned with the current values of the
+// program's variables as input.
+//
+// RoutineProgram is only created by the optimizer. It is immutable once built,
+// so it can be shared by all evaluations of a routine.
+type RoutineProgram struct {
+	// VarNames and VarTypes contain the name and type of each variable slot in
+	// the program. The first slots are the arguments of the routine, followed
+	// by the variables declared in the body of the routine.
+	VarNames []Name
+	VarTypes []*types.T
+
+	// Body is the outermost block of the routine.
+	Body *RoutineBlock
+}
+
+// RoutineNoExpr is used in place of a statement index when an optional
+// expression is not present.
+const RoutineNoExpr = -1
+
+// RoutineInstr is a single instruction in a RoutineProgram.
+type RoutineInstr interface {
+	routineInstr()
+}
+
+// RoutineBlock is a block of instructions with its own variable declarations
+// and, optionally, exception handlers.
+type RoutineBlock struct {
+	Label string
+
+	// Init contains the variables declared in the block, which are initialized
+	// in order each time the block is entered.
+	Init []RoutineVarInit
+
+	Body []RoutineInstr
+
+	// Handlers are evaluated in order when an error occurs during the execution
+	// of Body. If one of them matches the error, the effects of the block are
+	// rolled back and the handler's body is executed instead.
+	Handlers []RoutineExceptionHandler
+}
+
+// RoutineVarInit initializes a variable declared in a block.
+type RoutineVarInit struct {
+	// Var is the variable slot.
+	Var int
+	// Expr is the index of the statement that computes the initial value, or
+	// RoutineNoExpr if the variable is initialized to NULL.
+	Expr int
+	// NotNull is true if NULL values cannot be assigned to the variable.
+	NotNull bool
+}
+
+// RoutineExceptionHandler handles errors raised within a RoutineBlock.
+type RoutineExceptionHandler struct {
+	// Codes are the error codes handled by the handler. Codes that end in
+	// "000" match any code in the same class.
+	Codes []string
+	// Others is true if the handler matches any error except query
+	// cancellation and assertion failures.
+	Others bool
+	Body   []RoutineInstr
+}
+
+// RoutineAssign assigns the result of an expression to a variable.
+type RoutineAssign struct {
+	Var  int
+	Expr int
+}
+
+// RoutineIf executes the branch of the first condition that evaluates to
+// true, or Else if there is no such condition.
+type RoutineIf struct {
+	Conds    []int
+	Branches [][]RoutineInstr
+	Else     []RoutineInstr
+}
+
+// RoutineLoop repeatedly executes its body. If Cond is not RoutineNoExpr, the
+// loop terminates once Cond no longer evaluates to true.
+type RoutineLoop struct {
+	Label string
+	Cond  int
+	Body  []RoutineInstr
+}
+
+// RoutineExit exits the innermost loop, or the enclosing loop or block with
+// the given label. If Continue is true, the next iteration of the loop starts
+// instead. If Cond is not RoutineNoExpr, the instruction only has an effect
+// when Cond evaluates to true.
+type RoutineExit struct {
+	Label    string
+	Cond     int
+	Continue bool
+}
+
+// RoutineReturnInstr returns from the routine. If Expr is RoutineNoExpr, the
+// result of the routine is NULL. It is distinct from RoutineReturn, which is
+// the AST node of a RETURN statement in a SQL routine body.
+type RoutineReturnInstr struct {
+	Expr int
+}
+
+// RoutineRaise reports a message or raises an error.
+type RoutineRaise struct {
+	// Severity is the severity of the message, e.g. "NOTICE". A severity of
+	// "EXCEPTION" raises an error.
+	Severity string
+	// Format is the message format string, where each "%" is replaced with the
+	// value of the next expression in Params.
+	Format string
+	Params []int
+	// Code is the error code of the raised error, if it was specified as a
+	// condition in the RAISE statement.
+	Code string
+	// Message, Detail, Hint, and ErrCode are the indexes of the statements that
+	// compute the corresponding USING options, or RoutineNoExpr.
+	Message int
+	Detail  int
+	Hint    int
+	ErrCode int
+}
+
+// RoutineExec executes a SQL statement. If Into is not empty, the columns of
+// the first row returned by the statement are assigned to the given variable
+// slots.
+type RoutineExec struct {
+	Stmt int
+	Into []int
+	// Strict is true if it is an error for the statement to return zero rows
+	// or more than one row.
+	Strict bool
+}
+
+// RoutineTxnControl commits or rolls back the current transaction, and starts
+// a new transaction. It is only used in procedures.
+type RoutineTxnControl struct {
+	Rollback bool
+}
+
+func (*RoutineBlock) routineInstr()       {}
+func (*RoutineAssign) routineInstr()      {}
+func (*RoutineIf) routineInstr()          {}
+func (*RoutineLoop) routineInstr()        {}
+func (*RoutineExit) routineInstr()        {}
+func (*RoutineReturnInstr) routineInstr() {}
+func (*RoutineRaise) routineInstr()       {}
+func (*RoutineExec) routineInstr()        {}
+func (*RoutineTxnControl) routineInstr()  {}
@@ -725,11 +725,68 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	prefix := "GROUP BY "
 	for _, n := range *node {
 		ctx.WriteString(prefix)
-		ctx.FormatNode(n)
+		formatGroupByItem(ctx, n)
 		prefix = ", "
 	}
 }
 
+// formatGroupByItem formats an item of a GROUP BY clause. GroupingSets are
+// never parenthesized, since a parenthesized ROLLUP or CUBE would be parsed as
+// a function call.
+func formatGroupByItem(ctx *FmtCtx, n Expr) {
+	if gs, ok := n.(*GroupingSet); ok {
+		gs.Format(ctx)
+		return
+	}
+	ctx.FormatNode(n)
+}
+
+// GroupingSetType is the type of a GroupingSet.
+type GroupingSetType int
+
+const (
+	// RollupGroupingSet represents ROLLUP (a, b, ...), which is equivalent to
+	// GROUPING SETS ((a, b, ...), ..., (a), ()).
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet represents CUBE (a, b, ...), which is equivalent to a
+	// GROUPING SETS clause listing every subset of the expressions.
+	CubeGroupingSet
+	// ListGroupingSet represents GROUPING SETS (...).
+	ListGroupingSet
+)
+
+// GroupingSet represents an item in a GROUP BY clause that specifies several
+// grouping sets rather than a single grouping expression. Each of the Exprs of
+// a ROLLUP or CUBE is either a single expression or a parenthesized list of
+// expressions that is treated as a unit. Each of the Exprs of a GROUPING SETS
+// list is either a single expression, a parenthesized list of expressions, or
+// a nested GroupingSet. The empty grouping set "()" is an empty tuple.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+var _ Expr = &GroupingSet{}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	switch node.Type {
+	case RollupGroupingSet:
+		ctx.WriteString("ROLLUP (")
+	case CubeGroupingSet:
+		ctx.WriteString("CUBE (")
+	case ListGroupingSet:
+		ctx.WriteString("GROUPING SETS (")
+	}
+	for i, n := range node.Exprs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		formatGroupByItem(ctx, n)
+	}
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
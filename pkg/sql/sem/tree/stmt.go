@@ -813,6 +813,15 @@ func (*CreateType) StatementTag() string { return "CREATE TYPE" }
 
 func (*CreateType) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateTrigger) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateTrigger) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateTrigger) StatementTag() string { return "CREATE TRIGGER" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRole) StatementReturnType() StatementReturnType { return Ack }
 
@@ -964,6 +973,15 @@ func (*DropType) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropType) StatementTag() string { return "DROP TYPE" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropTrigger) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropTrigger) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTrigger) StatementTag() string { return "DROP TRIGGER" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropSchema) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1334,6 +1352,15 @@ func (*SetClusterSetting) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetClusterSetting) StatementTag() string { return "SET CLUSTER SETTING" }
 
+// StatementReturnType implements the Statement interface.
+func (*SetConstraints) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return TypeDCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementReturnType implements the Statement interface.
 func (*SetTransaction) StatementReturnType() StatementReturnType { return Ack }
 
@@ -1962,7 +1989,21 @@ func (*CreateFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*CreateFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*CreateFunction) StatementTag() string { return "CREATE FUNCTION" }
+func (n *CreateFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "CREATE PROCEDURE"
+	}
+	return "CREATE FUNCTION"
+}
+
+// StatementReturnType implements the Statement interface.
+func (*CreateAggregate) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateAggregate) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateAggregate) StatementTag() string { return "CREATE AGGREGATE" }
 
 // StatementReturnType implements the Statement interface.
 func (*RoutineReturn) StatementReturnType() StatementReturnType { return Rows }
@@ -1980,7 +2021,15 @@ func (*DropFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*DropFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropFunction) StatementTag() string { return "DROP FUNCTION" }
+func (n *DropFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "DROP PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "DROP AGGREGATE"
+	}
+	return "DROP FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionOptions) StatementReturnType() StatementReturnType { return DDL }
@@ -1998,7 +2047,15 @@ func (*AlterFunctionRename) StatementReturnType() StatementReturnType { return D
 func (*AlterFunctionRename) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionRename) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionRename) StatementTag() string {
+	if n.IsProcedure {
+		return "ALTER PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { return DDL }
@@ -2007,7 +2064,15 @@ func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { retur
 func (*AlterFunctionSetSchema) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetSchema) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetSchema) StatementTag() string {
+	if n.IsProcedure {
+		return "ALTER PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return DDL }
@@ -2016,7 +2081,24 @@ func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return
 func (*AlterFunctionSetOwner) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetOwner) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetOwner) StatementTag() string {
+	if n.IsProcedure {
+		return "ALTER PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
+
+// StatementReturnType implements the Statement interface.
+func (*Call) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Call) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Call) StatementTag() string { return "CALL" }
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionDepExtension) StatementReturnType() StatementReturnType { return DDL }
@@ -2080,6 +2162,7 @@ func (n *ControlJobs) String() string                         { return AsString(
 func (n *ControlSchedules) String() string                    { return AsString(n) }
 func (n *ControlJobsForSchedules) String() string             { return AsString(n) }
 func (n *ControlJobsOfType) String() string                   { return AsString(n) }
+func (n *Call) String() string                                { return AsString(n) }
 func (n *CancelQueries) String() string                       { return AsString(n) }
 func (n *CancelSessions) String() string                      { return AsString(n) }
 func (n *CannedOptPlan) String() string                       { return AsString(n) }
@@ -2096,11 +2179,13 @@ func (n *CreateChangefeed) String() string                    { return AsString(
 func (n *CreateDatabase) String() string                      { return AsString(n) }
 func (n *CreateExtension) String() string                     { return AsString(n) }
 func (n *CreateFunction) String() string                      { return AsString(n) }
+func (n *CreateAggregate) String() string                     { return AsString(n) }
 func (n *CreateIndex) String() string                         { return AsString(n) }
 func (n *CreateRole) String() string                          { return AsString(n) }
 func (n *CreateTable) String() string                         { return AsString(n) }
 func (n *CreateTenant) String() string                        { return AsString(n) }
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
+func (n *CreateTrigger) String() string                       { return AsString(n) }
 func (n *CreateSchema) String() string                        { return AsString(n) }
 func (n *CreateSequence) String() string                      { return AsString(n) }
 func (n *CreateStats) String() string                         { return AsString(n) }
@@ -2115,6 +2200,7 @@ func (n *DropOwnedBy) String() string                         { return AsString(
 func (n *DropSchema) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
+func (n *DropTrigger) String() string                         { return AsString(n) }
 func (n *DropType) String() string                            { return AsString(n) }
 func (n *DropView) String() string                            { return AsString(n) }
 func (n *DropRole) String() string                            { return AsString(n) }
@@ -2157,6 +2243,7 @@ func (n *Scrub) String() string                               { return AsString(
 func (n *Select) String() string                              { return AsString(n) }
 func (n *SelectClause) String() string                        { return AsString(n) }
 func (n *SetClusterSetting) String() string                   { return AsString(n) }
+func (n *SetConstraints) String() string                      { return AsString(n) }
 func (n *SetZoneConfig) String() string                       { return AsString(n) }
 func (n *SetSessionAuthorizationDefault) String() string      { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string           { return AsString(n) }
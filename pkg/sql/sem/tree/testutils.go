@@ -81,6 +81,22 @@ func SampleDatum(t *types.T) Datum {
 		return j
 	case types.OidFamily:
 		return NewDOid(1009)
+	case types.TSQueryFamily:
+		q, _ := ParseDTSQuery("a & b")
+		return q
+	case types.TSVectorFamily:
+		v, _ := ParseDTSVector("a:1 b:2")
+		return v
+	case types.JsonpathFamily:
+		p, _ := ParseDJsonpath("$.a[*] ? (@ > 1)")
+		return p
+	case types.RangeFamily:
+		elem := SampleDatum(t.RangeContents())
+		r, _ := MakeDRange(t, RangeBound{Val: elem, Inclusive: true}, RangeBound{Val: elem, Inclusive: true})
+		return r
+	case types.MultirangeFamily:
+		r := SampleDatum(types.MakeRange(t.RangeContents())).(*DRange)
+		return MakeDMultirange(t, []*DRange{r})
 	case types.Box2DFamily:
 		b := geo.NewCartesianBoundingBox().AddPoint(1, 2).AddPoint(3, 4)
 		return NewDBox2D(*b)
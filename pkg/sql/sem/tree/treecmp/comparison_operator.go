@@ -69,6 +69,9 @@ const (
 	JSONSomeExists
 	JSONAllExists
 	Overlaps
+	TSMatches
+	Adjacent
+	JSONPathExists
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -120,6 +123,9 @@ var comparisonOpName = [...]string{
 	JSONSomeExists:    "?|",
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
+	TSMatches:         "@@",
+	Adjacent:          "-|-",
+	JSONPathExists:    "@?",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -0,0 +1,124 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// TriggerActionTime represents when a trigger fires relative to the row
+// operation that activates it.
+type TriggerActionTime uint8
+
+// TriggerActionTime values.
+const (
+	// TriggerActionTimeBefore indicates a trigger that fires before the row
+	// is modified.
+	TriggerActionTimeBefore TriggerActionTime = iota
+	// TriggerActionTimeAfter indicates a trigger that fires after the row is
+	// modified.
+	TriggerActionTimeAfter
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeBefore: "BEFORE",
+	TriggerActionTimeAfter:  "AFTER",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEventType represents a row operation that activates a trigger.
+type TriggerEventType uint8
+
+// TriggerEventType values.
+const (
+	TriggerEventInsert TriggerEventType = iota
+	TriggerEventUpdate
+	TriggerEventDelete
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventInsert: "INSERT",
+	TriggerEventUpdate: "UPDATE",
+	TriggerEventDelete: "DELETE",
+}
+
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvents is a list of row operations that activate a trigger.
+type TriggerEvents []TriggerEventType
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, event := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(event.String())
+	}
+}
+
+// Contains returns true if the list contains the given event.
+func (node TriggerEvents) Contains(event TriggerEventType) bool {
+	for _, e := range node {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      TableName
+	FuncName   FunctionName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" FOR EACH ROW EXECUTE FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("()")
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	Name         Name
+	Table        TableName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
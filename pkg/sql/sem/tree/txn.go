@@ -25,17 +25,25 @@ type IsolationLevel int
 const (
 	UnspecifiedIsolation IsolationLevel = iota
 	SerializableIsolation
+	ReadCommittedIsolation
 )
 
 var isolationLevelNames = [...]string{
-	UnspecifiedIsolation:  "UNSPECIFIED",
-	SerializableIsolation: "SERIALIZABLE",
+	UnspecifiedIsolation:   "UNSPECIFIED",
+	SerializableIsolation:  "SERIALIZABLE",
+	ReadCommittedIsolation: "READ COMMITTED",
 }
 
 // IsolationLevelMap is a map from string isolation level name to isolation
-// level, in the lowercase format that set isolation_level supports.
+// level, in the lowercase format that set isolation_level supports. Isolation
+// levels that are not implemented are mapped to the next stronger level that
+// is, as permitted by the SQL standard.
 var IsolationLevelMap = map[string]IsolationLevel{
-	"serializable": SerializableIsolation,
+	"read uncommitted": ReadCommittedIsolation,
+	"read committed":   ReadCommittedIsolation,
+	"snapshot":         SerializableIsolation,
+	"repeatable read":  SerializableIsolation,
+	"serializable":     SerializableIsolation,
 }
 
 func (i IsolationLevel) String() string {
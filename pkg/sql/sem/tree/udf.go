@@ -14,6 +14,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -97,17 +98,21 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
-	ctx.WriteString("FUNCTION ")
+	ctx.WriteString(routineKeyword(node.IsProcedure, false /* isAggregate */))
+	ctx.WriteString(" ")
 	ctx.FormatNode(&node.FuncName)
 	ctx.WriteString("(")
 	ctx.FormatNode(node.Args)
 	ctx.WriteString(")\n\t")
-	ctx.WriteString("RETURNS ")
-	if node.ReturnType.IsSet {
-		ctx.WriteString("SETOF ")
+	// Procedures do not have a return type.
+	if !node.IsProcedure {
+		ctx.WriteString("RETURNS ")
+		if node.ReturnType.IsSet {
+			ctx.WriteString("SETOF ")
+		}
+		ctx.WriteString(node.ReturnType.Type.SQLString())
+		ctx.WriteString("\n\t")
 	}
-	ctx.WriteString(node.ReturnType.Type.SQLString())
-	ctx.WriteString("\n\t")
 	var funcBody FunctionBodyStr
 	for _, option := range node.Options {
 		switch t := option.(type) {
@@ -131,6 +136,129 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	}
 }
 
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace  bool
+	FuncName FunctionName
+	Args     FuncArgs
+	// TransitionFunc is the state transition function of the aggregate (SFUNC).
+	TransitionFunc FunctionName
+	// StateType is the type of the aggregate state (STYPE).
+	StateType ResolvableTypeReference
+	// FinalFunc, if set, is the function which computes the result of the
+	// aggregate from the final state (FINALFUNC).
+	FinalFunc *FunctionName
+	// CombineFunc, if set, is the function which combines two partial states
+	// (COMBINEFUNC).
+	CombineFunc *FunctionName
+	// InitCond, if set, is the initial value of the state (INITCOND).
+	InitCond *string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("(")
+	ctx.FormatNode(node.Args)
+	ctx.WriteString(") (SFUNC = ")
+	ctx.FormatNode(&node.TransitionFunc)
+	ctx.WriteString(", STYPE = ")
+	ctx.WriteString(node.StateType.SQLString())
+	if node.FinalFunc != nil {
+		ctx.WriteString(", FINALFUNC = ")
+		ctx.FormatNode(node.FinalFunc)
+	}
+	if node.CombineFunc != nil {
+		ctx.WriteString(", COMBINEFUNC = ")
+		ctx.FormatNode(node.CombineFunc)
+	}
+	if node.InitCond != nil {
+		ctx.WriteString(", INITCOND = ")
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, *node.InitCond, ctx.flags.EncodeFlags())
+	}
+	ctx.WriteString(")")
+}
+
+// AggregateOption is an option of a CREATE AGGREGATE statement, of the form
+// name = value. Values which refer to functions or types are parsed as type
+// references, and other values are string literals.
+type AggregateOption struct {
+	Name  string
+	Type  ResolvableTypeReference
+	Value *string
+}
+
+// AggregateOptions is a list of AggregateOption.
+type AggregateOptions []AggregateOption
+
+// NewCreateAggregate returns a CREATE AGGREGATE statement with the given
+// options, or an error if the options are invalid.
+func NewCreateAggregate(
+	replace bool, name FunctionName, args FuncArgs, opts AggregateOptions,
+) (*CreateAggregate, error) {
+	node := &CreateAggregate{Replace: replace, FuncName: name, Args: args}
+	seen := make(map[string]struct{}, len(opts))
+	for _, opt := range opts {
+		optName := strings.ToLower(opt.Name)
+		if _, ok := seen[optName]; ok {
+			return nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q specified more than once", optName)
+		}
+		seen[optName] = struct{}{}
+		switch optName {
+		case "sfunc", "finalfunc", "combinefunc":
+			fnName, ok := opt.Type.(*UnresolvedObjectName)
+			if !ok {
+				return nil, pgerror.Newf(pgcode.Syntax, "aggregate %s must be a function name", optName)
+			}
+			fn := fnName.ToFunctionName()
+			switch optName {
+			case "sfunc":
+				node.TransitionFunc = fn
+			case "finalfunc":
+				node.FinalFunc = &fn
+			case "combinefunc":
+				node.CombineFunc = &fn
+			}
+		case "stype":
+			if opt.Type == nil {
+				return nil, pgerror.New(pgcode.Syntax, "aggregate stype must be a type name")
+			}
+			node.StateType = opt.Type
+		case "initcond":
+			if opt.Value == nil {
+				return nil, pgerror.New(pgcode.Syntax, "aggregate initcond must be a string literal")
+			}
+			node.InitCond = opt.Value
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q not recognized", opt.Name)
+		}
+	}
+	if _, ok := seen["sfunc"]; !ok {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if _, ok := seen["stype"]; !ok {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	return node, nil
+}
+
+// routineKeyword returns the keyword that refers to a routine in statements,
+// depending on whether the routine is a procedure, an aggregate or a function.
+func routineKeyword(isProcedure, isAggregate bool) string {
+	if isProcedure {
+		return "PROCEDURE"
+	}
+	if isAggregate {
+		return "AGGREGATE"
+	}
+	return "FUNCTION"
+}
+
 // RoutineBody represent a list of statements in a UDF body.
 type RoutineBody struct {
 	Stmts Statements
@@ -241,6 +369,8 @@ const (
 	_ FunctionLanguage = iota
 	// FunctionLangSQL represent SQL language.
 	FunctionLangSQL
+	// FunctionLangPLpgSQL represents the PL/pgSQL procedural language.
+	FunctionLangPLpgSQL
 )
 
 // Format implements the NodeFormatter interface.
@@ -249,6 +379,8 @@ func (node FunctionLanguage) Format(ctx *FmtCtx) {
 	switch node {
 	case FunctionLangSQL:
 		ctx.WriteString("SQL")
+	case FunctionLangPLpgSQL:
+		ctx.WriteString("plpgsql")
 	default:
 		panic(pgerror.New(pgcode.InvalidParameterValue, "Unknown function option"))
 	}
@@ -260,6 +392,8 @@ func AsFunctionLanguage(lang string) (FunctionLanguage, error) {
 	switch strings.ToLower(lang) {
 	case "sql":
 		return FunctionLangSQL, nil
+	case "plpgsql":
+		return FunctionLangPLpgSQL, nil
 	}
 	return 0, errors.Newf("language %q does not exist", lang)
 }
@@ -342,8 +476,11 @@ type FuncReturnType struct {
 	IsSet bool
 }
 
-// DropFunction represents a DROP FUNCTION statement.
+// DropFunction represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropFunction struct {
+	IsProcedure  bool
+	IsAggregate  bool
 	IfExists     bool
 	Functions    FuncObjs
 	DropBehavior DropBehavior
@@ -351,7 +488,9 @@ type DropFunction struct {
 
 // Format implements the NodeFormatter interface.
 func (node *DropFunction) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP FUNCTION ")
+	ctx.WriteString("DROP ")
+	ctx.WriteString(routineKeyword(node.IsProcedure, node.IsAggregate))
+	ctx.WriteString(" ")
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -428,43 +567,58 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterFunctionRename represents a ALTER FUNCTION...RENAME statement.
+// AlterFunctionRename represents a ALTER {FUNCTION|PROCEDURE|AGGREGATE}...RENAME
+// statement.
 type AlterFunctionRename struct {
-	Function FuncObj
-	NewName  Name
+	IsProcedure bool
+	IsAggregate bool
+	Function    FuncObj
+	NewName     Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.IsProcedure, node.IsAggregate))
+	ctx.WriteString(" ")
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.WriteString(string(node.NewName))
 }
 
-// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA statement.
+// AlterFunctionSetSchema represents a ALTER
+// {FUNCTION|PROCEDURE|AGGREGATE}...SET SCHEMA statement.
 type AlterFunctionSetSchema struct {
+	IsProcedure   bool
+	IsAggregate   bool
 	Function      FuncObj
 	NewSchemaName Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.IsProcedure, node.IsAggregate))
+	ctx.WriteString(" ")
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.WriteString(string(node.NewSchemaName))
 }
 
-// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO statement.
+// AlterFunctionSetOwner represents the ALTER
+// {FUNCTION|PROCEDURE|AGGREGATE}...OWNER TO statement.
 type AlterFunctionSetOwner struct {
-	Function FuncObj
-	NewOwner RoleSpec
+	IsProcedure bool
+	IsAggregate bool
+	Function    FuncObj
+	NewOwner    RoleSpec
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetOwner) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.IsProcedure, node.IsAggregate))
+	ctx.WriteString(" ")
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
@@ -488,6 +642,17 @@ func (node *AlterFunctionDepExtension) Format(ctx *FmtCtx) {
 	ctx.WriteString(string(node.Extension))
 }
 
+// Call represents a CALL statement, which invokes a procedure.
+type Call struct {
+	Proc *FuncExpr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	ctx.FormatNode(node.Proc)
+}
+
 // UDFDisallowanceVisitor is used to determine if a type checked expression
 // contains any UDF function sub-expression. It's needed only temporarily to
 // disallow any usage of UDF from relation objects.
@@ -0,0 +1,162 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/errors"
+)
+
+// SetConstraints sets the checking mode of deferrable constraints for the
+// current transaction. Deferred checks of constraints that become immediate
+// are performed right away.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	deferred := p.EvalContext().DeferredConstraints
+	if deferred == nil {
+		// Like Postgres, only warn when used outside of a transaction block,
+		// since the statement would have no effect.
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"SET CONSTRAINTS can only be used in transaction blocks",
+		))
+		return newZeroNode(nil /* columns */), nil
+	}
+	if n.Names != nil {
+		if err := p.checkConstraintsAreDeferrable(ctx, n.Names); err != nil {
+			return nil, err
+		}
+	}
+	deferred.SetMode(n.Names, n.Deferred)
+	if !n.Deferred {
+		if err := p.validateDeferredConstraints(ctx, deferred.TakePending(n.Names)); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// checkConstraintsAreDeferrable returns an error if any of the named
+// constraints does not exist in the current database or is not deferrable.
+func (p *planner) checkConstraintsAreDeferrable(ctx context.Context, names tree.NameList) error {
+	db, err := p.Descriptors().GetImmutableDatabaseByName(
+		ctx, p.Txn(), p.CurrentDatabase(), tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return err
+	}
+	tableDescs, err := p.Descriptors().GetAllTableDescriptorsInDatabase(ctx, p.Txn(), db)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		found := false
+		for _, tableDesc := range tableDescs {
+			if tableDesc.Dropped() {
+				continue
+			}
+			deferrability := descpb.ConstraintDeferrability_NotDeferrable
+			if ec := tableDesc.FindExclusionConstraintByName(string(name)); ec != nil {
+				deferrability = ec.Deferrability
+			} else if c, _ := tableDesc.FindConstraintWithName(string(name)); c != nil {
+				deferrability = constraintDeferrability(c)
+			} else {
+				continue
+			}
+			found = true
+			if deferrability == descpb.ConstraintDeferrability_NotDeferrable {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", tree.ErrString(&name))
+			}
+		}
+		if !found {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", tree.ErrString(&name))
+		}
+	}
+	return nil
+}
+
+// validateDeferredConstraints validates the given constraints, whose checks
+// were deferred by earlier statements in the transaction. Only the rows that
+// were written while the checks were deferred are validated, unless there
+// were too many of them, in which case all the rows of the table are
+// validated. Constraints that were dropped since their checks were deferred
+// are ignored.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, constraints []eval.DeferredConstraint,
+) error {
+	for i := range constraints {
+		dc := &constraints[i]
+		if !dc.NeedsCheck() {
+			continue
+		}
+		imm, err := p.Descriptors().GetImmutableTableByID(
+			ctx, p.Txn(), descpb.ID(dc.TableID), tree.ObjectLookupFlags{
+				CommonLookupFlags: tree.CommonLookupFlags{IncludeDropped: true},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if imm.Dropped() {
+			continue
+		}
+		c, _ := imm.FindConstraintWithName(dc.Name)
+		isExclusion := imm.FindExclusionConstraintByName(dc.Name) != nil
+		if c == nil && !isExclusion {
+			continue
+		}
+		var rowFilter string
+		if !dc.CheckAllRows {
+			var fk *descpb.ForeignKeyConstraint
+			if c != nil && c.AsForeignKey() != nil {
+				fk = c.AsForeignKey().ForeignKeyDesc()
+			}
+			var ok bool
+			if rowFilter, ok = deferredConstraintRowFilter(imm, dc, fk); ok && c != nil {
+				if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+					rowFilter, ok = uniqueWithoutIndexRowFilter(imm, uwi.UniqueWithoutIndexDesc(), rowFilter)
+				}
+			}
+			if !ok {
+				rowFilter = ""
+			}
+		}
+		// The validation functions operate on mutable descriptors, so make a
+		// copy of the table descriptor. Since the copy has the same version as
+		// the original, it is not used as a synthetic descriptor.
+		tableDesc := tabledesc.NewBuilder(imm.TableDesc()).BuildExistingMutableTable()
+		if err := p.WithInternalExecutor(ctx, func(ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor) error {
+			if isExclusion {
+				return validateExclusionConstraintInTxn(ctx, tableDesc, txn, ie, p.User(), dc.Name, rowFilter)
+			} else if ck := c.AsCheck(); ck != nil {
+				return validateCheckInTxn(ctx, &p.semaCtx, p.SessionData(), tableDesc, txn, ie, ck.GetExpr(), rowFilter)
+			} else if c.AsForeignKey() != nil {
+				return validateFkInTxn(ctx, tableDesc, txn, ie, p.Descriptors(), dc.Name, rowFilter)
+			} else if c.AsUniqueWithoutIndex() != nil {
+				return validateUniqueWithoutIndexConstraintInTxn(ctx, tableDesc, txn, ie, p.User(), dc.Name, rowFilter)
+			}
+			return errors.AssertionFailedf("constraint %q cannot be deferred", dc.Name)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
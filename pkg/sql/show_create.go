@@ -139,6 +139,12 @@ func ShowCreateTable(
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// The backing indexes of exclusion constraints are created by the
+		// constraints, which are shown below.
+		if desc.FindExclusionConstraintByIndexID(idx.GetID()) != nil {
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -479,6 +479,8 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	deferrability := descpb.TreeConstraintDeferrabilityValue[fk.Deferrability]
+	buf.WriteString(tree.AsString(&deferrability))
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -707,6 +709,8 @@ func showConstraintClause(
 		}
 		f.WriteString(expr)
 		f.WriteString(")")
+		deferrability := descpb.TreeConstraintDeferrabilityValue[e.CheckDesc().Deferrability]
+		f.FormatNode(&deferrability)
 		if !e.IsConstraintValidated() {
 			f.WriteString(" NOT VALID")
 		}
@@ -725,6 +729,8 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		deferrability := descpb.TreeConstraintDeferrabilityValue[c.UniqueWithoutIndexDesc().Deferrability]
+		f.FormatNode(&deferrability)
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.GetPredicate(), semaCtx, sessionData, tree.FmtParsable)
@@ -737,6 +743,40 @@ func showConstraintClause(
 			f.WriteString(" NOT VALID")
 		}
 	}
+	for i := range desc.GetExclusionConstraints() {
+		c := &desc.GetExclusionConstraints()[i]
+		f.WriteString(",\n\tCONSTRAINT ")
+		formatQuoteNames(&f.Buffer, c.Name)
+		f.WriteString(" EXCLUDE")
+		if c.Method != "" {
+			f.WriteString(" USING ")
+			f.WriteString(c.Method)
+		}
+		f.WriteString(" (")
+		colNames, err := desc.NamesForColumnIDs(c.ColumnIDs)
+		if err != nil {
+			return err
+		}
+		for j, name := range colNames {
+			if j > 0 {
+				f.WriteString(", ")
+			}
+			formatQuoteNames(&f.Buffer, name)
+			f.WriteString(" WITH ")
+			f.WriteString(c.Operators[j])
+		}
+		f.WriteString(")")
+		if c.IsPartial() {
+			f.WriteString(" WHERE ")
+			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.Predicate, semaCtx, sessionData, tree.FmtParsable)
+			if err != nil {
+				return err
+			}
+			f.WriteString(pred)
+		}
+		deferrability := descpb.TreeConstraintDeferrabilityValue[c.Deferrability]
+		f.FormatNode(&deferrability)
+	}
 	f.WriteString("\n)")
 	return nil
 }
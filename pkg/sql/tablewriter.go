@@ -146,6 +146,10 @@ type tableWriterBase struct {
 	forceProductionBatchSizes bool
 	// sv settings values for cluster settings
 	sv *settings.Values
+	// deferredConstraints records the written rows that must be checked for
+	// the constraints whose checks are deferred until the end of the
+	// transaction.
+	deferredConstraints deferredConstraintRecorder
 }
 
 var maxBatchBytes = settings.RegisterByteSizeSetting(
@@ -175,6 +179,7 @@ func (tb *tableWriterBase) init(
 	}
 	tb.maxBatchByteSize = mutations.MaxBatchByteSize(batchMaxBytes, tb.forceProductionBatchSizes)
 	tb.sv = settings
+	tb.deferredConstraints.init(evalCtx, tableDesc)
 	tb.initNewBatch()
 	return nil
 }
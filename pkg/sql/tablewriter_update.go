@@ -57,7 +57,12 @@ func (tu *tableUpdater) rowForUpdate(
 	traceKV bool,
 ) (tree.Datums, error) {
 	tu.currentBatchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	if err != nil {
+		return nil, err
+	}
+	tu.deferredConstraints.recordUpdate(oldValues, newValues, &tu.ru)
+	return newValues, nil
 }
 
 // tableDesc is part of the tableWriter interface.
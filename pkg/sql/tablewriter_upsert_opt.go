@@ -219,6 +219,7 @@ func (tu *optTableUpserter) insertNonConflictingRow(
 	if err := tu.ri.InsertRow(ctx, b, insertRow, pm, overwrite, traceKV); err != nil {
 		return err
 	}
+	tu.deferredConstraints.recordInsert(insertRow, tu.ri.InsertColIDtoRowIndex)
 
 	if !tu.rowsNeeded {
 		return nil
@@ -278,10 +279,11 @@ func (tu *optTableUpserter) updateConflictingRow(
 	// Queue the update in KV. This also returns an "update row"
 	// containing the updated values for every column in the
 	// table. This is useful for RETURNING, which we collect below.
-	_, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, traceKV)
 	if err != nil {
 		return err
 	}
+	tu.deferredConstraints.recordUpdate(fetchRow, newValues, &tu.ru)
 
 	// We only need a result row if we're collecting rows.
 	if !tu.rowsNeeded {
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -96,6 +97,9 @@ type txnState struct {
 	// The transaction's priority.
 	priority roachpb.UserPriority
 
+	// The transaction's isolation level.
+	isoLevel isolation.Level
+
 	// The transaction's read only state.
 	readOnly bool
 
@@ -161,6 +165,10 @@ const (
 //
 //	not nil.
 //
+// isoLevel: The transaction's isolation level. Must match the isolation level
+//
+//	of the txn arg if it is not nil.
+//
 // readOnly: The read-only character of the new txn.
 // txn: If not nil, this txn will be used instead of creating a new txn. If so,
 //
@@ -177,6 +185,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	sqlTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
 	priority roachpb.UserPriority,
+	isoLevel isolation.Level,
 	readOnly tree.ReadWriteMode,
 	txn *kv.Txn,
 	tranCtx transitionCtx,
@@ -224,11 +233,18 @@ func (ts *txnState) resetForNewSQLTxn(
 			if err := ts.setPriorityLocked(priority); err != nil {
 				panic(err)
 			}
+			if err := ts.setIsoLevelLocked(isoLevel); err != nil {
+				panic(err)
+			}
 		} else {
 			if priority != roachpb.UnspecifiedUserPriority {
 				panic(errors.AssertionFailedf("unexpected priority when using an existing txn: %s", priority))
 			}
+			if isoLevel != txn.IsoLevel() {
+				panic(errors.AssertionFailedf("unexpected isolation level when using an existing txn: %s", isoLevel))
+			}
 			ts.mu.txn = txn
+			ts.isoLevel = isoLevel
 		}
 
 		txnID = ts.mu.txn.ID()
@@ -250,6 +266,27 @@ func (ts *txnState) resetForNewSQLTxn(
 	return txnID
 }
 
+// replaceTxn replaces the KV transaction of the current SQL transaction with a
+// new one, after the previous one was committed or rolled back by a procedure.
+// The new transaction has the same priority as the previous one.
+func (ts *txnState) replaceTxn(
+	sqlTimestamp time.Time, tranCtx transitionCtx, qualityOfService sessiondatapb.QoSLevel,
+) (*kv.Txn, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.mu.txn = kv.NewTxnWithSteppingEnabled(ts.Ctx, tranCtx.db, tranCtx.nodeIDOrZero, qualityOfService)
+	ts.mu.txn.SetDebugName(sqlTxnName)
+	if err := ts.setPriorityLocked(ts.priority); err != nil {
+		return nil, err
+	}
+	if err := ts.setIsoLevelLocked(ts.isoLevel); err != nil {
+		return nil, err
+	}
+	ts.mu.txnStart = timeutil.Now()
+	ts.sqlTimestamp = sqlTimestamp
+	return ts.mu.txn, nil
+}
+
 // finishSQLTxn finalizes a transaction's results and closes the root span for
 // the current SQL txn. This needs to be called before resetForNewSQLTxn() is
 // called for starting another SQL txn. The ID of the finalized transaction is
@@ -338,6 +375,20 @@ func (ts *txnState) setPriorityLocked(userPriority roachpb.UserPriority) error {
 	return nil
 }
 
+func (ts *txnState) setIsoLevel(isoLevel isolation.Level) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.setIsoLevelLocked(isoLevel)
+}
+
+func (ts *txnState) setIsoLevelLocked(isoLevel isolation.Level) error {
+	if err := ts.mu.txn.SetIsoLevel(isoLevel); err != nil {
+		return err
+	}
+	ts.isoLevel = isoLevel
+	return nil
+}
+
 func (ts *txnState) setReadOnlyMode(mode tree.ReadWriteMode) error {
 	switch mode {
 	case tree.UnspecifiedReadWriteMode:
@@ -95,15 +95,32 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timetz:       TimeTZ,
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
+	oid.T_trigger:      Trigger,
+	oid.T_tsquery:      TSQuery,
+	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
 	oid.T_varbit:       VarBit,
 	oid.T_varchar:      VarChar,
 	oid.T_void:         Void,
 
-	oidext.T_geometry:  Geometry,
-	oidext.T_geography: Geography,
-	oidext.T_box2d:     Box2D,
+	oid.T_int4range: Int4Range,
+	oid.T_int8range: Int8Range,
+	oid.T_numrange:  NumRange,
+	oid.T_daterange: DateRange,
+	oid.T_tsrange:   TSRange,
+	oid.T_tstzrange: TSTZRange,
+
+	oidext.T_geometry:       Geometry,
+	oidext.T_geography:      Geography,
+	oidext.T_box2d:          Box2D,
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_datemultirange: DateMultirange,
+	oidext.T_tsmultirange:   TSMultirange,
+	oidext.T_tstzmultirange: TSTZMultirange,
+	oidext.T_jsonpath:       Jsonpath,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -140,13 +157,29 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_timetz:       oid.T__timetz,
 	oid.T_timestamp:    oid.T__timestamp,
 	oid.T_timestamptz:  oid.T__timestamptz,
+	oid.T_tsquery:      oid.T__tsquery,
+	oid.T_tsvector:     oid.T__tsvector,
 	oid.T_uuid:         oid.T__uuid,
 	oid.T_varbit:       oid.T__varbit,
 	oid.T_varchar:      oid.T__varchar,
 
-	oidext.T_geometry:  oidext.T__geometry,
-	oidext.T_geography: oidext.T__geography,
-	oidext.T_box2d:     oidext.T__box2d,
+	oid.T_int4range: oid.T__int4range,
+	oid.T_int8range: oid.T__int8range,
+	oid.T_numrange:  oid.T__numrange,
+	oid.T_daterange: oid.T__daterange,
+	oid.T_tsrange:   oid.T__tsrange,
+	oid.T_tstzrange: oid.T__tstzrange,
+
+	oidext.T_geometry:       oidext.T__geometry,
+	oidext.T_geography:      oidext.T__geography,
+	oidext.T_box2d:          oidext.T__box2d,
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_jsonpath:       oidext.T__jsonpath,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -175,10 +208,13 @@ var familyToOid = map[Family]oid.Oid{
 	TupleFamily:          oid.T_record,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
+	TSQueryFamily:        oid.T_tsquery,
+	TSVectorFamily:       oid.T_tsvector,
 
 	GeometryFamily:  oidext.T_geometry,
 	GeographyFamily: oidext.T_geography,
 	Box2DFamily:     oidext.T_box2d,
+	JsonpathFamily:  oidext.T_jsonpath,
 }
 
 // ArrayOids is a set of all oids which correspond to an array type.
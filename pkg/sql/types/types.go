@@ -478,6 +478,113 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type returned by functions that are executed by
+	// triggers.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSQuery is the type of a full text search query.
+	TSQuery = &T{
+		InternalType: InternalType{
+			Family: TSQueryFamily,
+			Oid:    oid.T_tsquery,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSVector is the type of a document that has been processed for full text
+	// search.
+	TSVector = &T{
+		InternalType: InternalType{
+			Family: TSVectorFamily,
+			Oid:    oid.T_tsvector,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Jsonpath is the type of a SQL/JSON path expression.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int4Range is the type of a range of Int4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of Int values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of Decimal values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of Date values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of Timestamp values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TimestampTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of Int4 values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of Int values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of Decimal values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of Date values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a multirange of Timestamp values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a multirange of TimestampTZ values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains the canonical range type for each supported range
+	// element type. Int4Range is a narrower variant of Int8Range, similar to
+	// Int4 and Int.
+	RangeTypes = []*T{
+		Int8Range,
+		NumRange,
+		DateRange,
+		TSRange,
+		TSTZRange,
+	}
+
+	// MultirangeTypes contains the canonical multirange type for each supported
+	// range element type, in the same order as RangeTypes.
+	MultirangeTypes = []*T{
+		Int8Multirange,
+		NumMultirange,
+		DateMultirange,
+		TSMultirange,
+		TSTZMultirange,
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -517,6 +624,9 @@ var (
 		TimeTZ,
 		Jsonb,
 		VarBit,
+		TSQuery,
+		TSVector,
+		Jsonpath,
 	}
 
 	// Any is a special type used only during static analysis as a wildcard type
@@ -539,6 +649,18 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type only used during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never
+	// have this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
+	// AnyMultirange is a special type only used during static analysis as a
+	// wildcard type that matches any multirange type. Execution-time values
+	// should never have this type.
+	AnyMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_anymultirange, Locale: &emptyLocale}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1128,6 +1250,83 @@ func MakeArray(typ *T) *T {
 	return arr
 }
 
+// rangeContents maps the Oid of each range and multirange type to the type of
+// its elements.
+var rangeContents = map[oid.Oid]*T{
+	oid.T_anyrange:          Any,
+	oid.T_int4range:         Int4,
+	oid.T_int8range:         Int,
+	oid.T_numrange:          Decimal,
+	oid.T_daterange:         Date,
+	oid.T_tsrange:           Timestamp,
+	oid.T_tstzrange:         TimestampTZ,
+	oidext.T_anymultirange:  Any,
+	oidext.T_int4multirange: Int4,
+	oidext.T_int8multirange: Int,
+	oidext.T_nummultirange:  Decimal,
+	oidext.T_datemultirange: Date,
+	oidext.T_tsmultirange:   Timestamp,
+	oidext.T_tstzmultirange: TimestampTZ,
+}
+
+// IsValidRangeContentsType returns true if there is a range type having
+// elements of the given type.
+func IsValidRangeContentsType(typ *T) bool {
+	switch typ.Family() {
+	case IntFamily, DecimalFamily, DateFamily, TimestampFamily, TimestampTZFamily:
+		return true
+	}
+	return false
+}
+
+// MakeRange returns the range type having elements of the given type. It
+// panics if there is no such range type; IsValidRangeContentsType can be used
+// to check beforehand.
+func MakeRange(typ *T) *T {
+	switch typ.Family() {
+	case IntFamily:
+		if typ.Width() == 64 {
+			return Int8Range
+		}
+		return Int4Range
+	case DecimalFamily:
+		return NumRange
+	case DateFamily:
+		return DateRange
+	case TimestampFamily:
+		return TSRange
+	case TimestampTZFamily:
+		return TSTZRange
+	case AnyFamily:
+		return AnyRange
+	}
+	panic(errors.AssertionFailedf("no range type for elements of type %s", typ))
+}
+
+// MakeMultirange returns the multirange type having elements of the given
+// type. It panics if there is no such multirange type;
+// IsValidRangeContentsType can be used to check beforehand.
+func MakeMultirange(typ *T) *T {
+	switch typ.Family() {
+	case IntFamily:
+		if typ.Width() == 64 {
+			return Int8Multirange
+		}
+		return Int4Multirange
+	case DecimalFamily:
+		return NumMultirange
+	case DateFamily:
+		return DateMultirange
+	case TimestampFamily:
+		return TSMultirange
+	case TimestampTZFamily:
+		return TSTZMultirange
+	case AnyFamily:
+		return AnyMultirange
+	}
+	panic(errors.AssertionFailedf("no multirange type for elements of type %s", typ))
+}
+
 // MakeTuple constructs a new instance of a TupleFamily type with the given
 // field types (some/all of which may be other TupleFamily types).
 //
@@ -1346,6 +1545,16 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the elements of a range or multirange
+// type. It returns nil for all other types.
+func (t *T) RangeContents() *T {
+	switch t.Family() {
+	case RangeFamily, MultirangeFamily:
+		return rangeContents[t.Oid()]
+	}
+	return nil
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1419,11 +1628,17 @@ var familyNames = map[Family]string{
 	TimestampFamily:      "timestamp",
 	TimestampTZFamily:    "timestamptz",
 	TimeTZFamily:         "timetz",
+	TSQueryFamily:        "tsquery",
+	TSVectorFamily:       "tsvector",
+	RangeFamily:          "range",
+	MultirangeFamily:     "multirange",
+	JsonpathFamily:       "jsonpath",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
 	VoidFamily:           "void",
 	EncodedKeyFamily:     "encodedkey",
+	TriggerFamily:        "trigger",
 }
 
 // Name returns a user-friendly word indicating the family type.
@@ -1508,6 +1723,9 @@ func (t *T) Name() string {
 	case TupleFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
+
 	case EnumFamily:
 		if t.Oid() == oid.T_anyenum {
 			return "anyenum"
@@ -1736,6 +1954,14 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "timestamp with time zone"
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
+	case TSQueryFamily:
+		return "tsquery"
+	case TSVectorFamily:
+		return "tsvector"
+	case JsonpathFamily:
+		return "jsonpath"
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1748,6 +1974,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "uuid"
 	case VoidFamily:
 		return "void"
+	case TriggerFamily:
+		return "trigger"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
 	default:
@@ -1931,6 +2159,11 @@ func (t *T) Equivalent(other *T) bool {
 			return false
 		}
 
+	case RangeFamily, MultirangeFamily:
+		if !t.RangeContents().Equivalent(other.RangeContents()) {
+			return false
+		}
+
 	case EnumFamily:
 		// If one of the types is anyenum, then allow the comparison to
 		// go through -- anyenum is used when matching overloads.
@@ -2520,6 +2753,8 @@ func (t *T) IsAmbiguous() bool {
 		return false
 	case ArrayFamily:
 		return t.ArrayContents().IsAmbiguous()
+	case RangeFamily, MultirangeFamily:
+		return t.RangeContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
 	}
@@ -2806,7 +3041,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"box":           21286,
 	"cidr":          18846,
 	"circle":        21286,
-	"jsonpath":      22513,
 	"line":          21286,
 	"lseg":          21286,
 	"macaddr":       45813,
@@ -2814,8 +3048,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"money":         41578,
 	"path":          21286,
 	"pg_lsn":        -1,
-	"tsquery":       7821,
-	"tsvector":      7821,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
@@ -249,6 +249,9 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 	case *ordinalityNode:
 		n.source = v.visit(n.source)
 
+	case *expandNode:
+		n.source = v.visit(n.source)
+
 	case *spoolNode:
 		n.source = v.visit(n.source)
 
@@ -366,6 +369,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterRoleSetNode{}):                        "alter role set var",
 	reflect.TypeOf(&applyJoinNode{}):                           "apply join",
 	reflect.TypeOf(&bufferNode{}):                              "buffer",
+	reflect.TypeOf(&callNode{}):                                "call",
 	reflect.TypeOf(&cancelQueriesNode{}):                       "cancel queries",
 	reflect.TypeOf(&cancelSessionsNode{}):                      "cancel sessions",
 	reflect.TypeOf(&changeDescriptorBackedPrivilegesNode{}):    "change privileges",
@@ -378,6 +382,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnSchemaNode{}):                     "comment on schema",
 	reflect.TypeOf(&controlJobsNode{}):                         "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):                    "control schedules",
+	reflect.TypeOf(&createAggregateNode{}):                     "create aggregate",
 	reflect.TypeOf(&createDatabaseNode{}):                      "create database",
 	reflect.TypeOf(&createExtensionNode{}):                     "create extension",
 	reflect.TypeOf(&createExternalConectionNode{}):             "create external connection",
@@ -388,6 +393,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
 	reflect.TypeOf(&createTableNode{}):                         "create table",
 	reflect.TypeOf(&createTenantNode{}):                        "create tenant",
+	reflect.TypeOf(&createTriggerNode{}):                       "create trigger",
 	reflect.TypeOf(&createTypeNode{}):                          "create type",
 	reflect.TypeOf(&CreateRoleNode{}):                          "create user/role",
 	reflect.TypeOf(&createViewNode{}):                          "create view",
@@ -404,6 +410,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
 	reflect.TypeOf(&dropTenantNode{}):                          "drop tenant",
+	reflect.TypeOf(&dropTriggerNode{}):                         "drop trigger",
 	reflect.TypeOf(&dropTypeNode{}):                            "drop type",
 	reflect.TypeOf(&DropRoleNode{}):                            "drop user/role",
 	reflect.TypeOf(&dropViewNode{}):                            "drop view",
@@ -411,6 +418,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&explainPlanNode{}):                         "explain plan",
 	reflect.TypeOf(&explainVecNode{}):                          "explain vectorized",
 	reflect.TypeOf(&explainDDLNode{}):                          "explain ddl",
+	reflect.TypeOf(&expandNode{}):                              "expand",
 	reflect.TypeOf(&exportNode{}):                              "export",
 	reflect.TypeOf(&fetchNode{}):                               "fetch",
 	reflect.TypeOf(&filterNode{}):                              "filter",
@@ -76,6 +76,10 @@ type windowFuncHolder struct {
 	partitionIdxs  []int
 	columnOrdering colinfo.ColumnOrdering
 	frame          *tree.WindowFrame
+
+	// userDefined is set if the function is an aggregate created with CREATE
+	// AGGREGATE.
+	userDefined *tree.UserDefinedAggregate
 }
 
 // samePartition returns whether w and other have the same PARTITION BY clause.
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -1143,6 +1144,11 @@ func GetIntent(reader Reader, key roachpb.Key) (*roachpb.Intent, error) {
 	if err != nil {
 		return nil, err
 	}
+	if !engineKey.IsExclusiveLockTableKey() {
+		// Replicated locks of non-Exclusive strength (e.g. Shared locks) sort
+		// after the intent for a key, so there is no intent.
+		return nil, nil
+	}
 	checkKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
 	if err != nil {
 		return nil, err
@@ -1167,13 +1173,16 @@ func GetIntent(reader Reader, key roachpb.Key) (*roachpb.Intent, error) {
 		return nil, err
 	}
 	// This should not be possible. There can only be one outstanding write
-	// intent for a key and with prefix match we don't find additional names.
+	// intent for a key and with prefix match we don't find additional names,
+	// other than replicated locks of non-Exclusive strength.
 	if hasNext {
 		engineKey, err := iter.EngineKey()
 		if err != nil {
 			return nil, err
 		}
-		return nil, errors.AssertionFailedf("unexpected additional key found %v while looking for %v", engineKey, key)
+		if engineKey.IsExclusiveLockTableKey() {
+			return nil, errors.AssertionFailedf("unexpected additional key found %v while looking for %v", engineKey, key)
+		}
 	}
 	return &intent, nil
 }
@@ -1232,6 +1241,10 @@ func ScanIntents(
 		if err != nil {
 			return nil, err
 		}
+		if !key.IsExclusiveLockTableKey() {
+			// Replicated locks of non-Exclusive strength are not intents.
+			continue
+		}
 		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
 		if err != nil {
 			return nil, err
@@ -1677,6 +1690,74 @@ func assertMVCCIteratorInvariants(iter MVCCIterator) error {
 	return nil
 }
 
+// ScanConflictingSharedLocks scans the replicated lock table for Shared locks
+// on keys in the span [start, end) that are held by transactions other than
+// txn, and returns them as intents with their Strength set. An empty end key
+// scans the single key at start. If maxLocks is positive, at most maxLocks locks are returned.
+//
+// Replicated Shared locks are not intents, so they are not observed by MVCC
+// reads and writes. Requests that acquire Exclusive locks (including requests
+// that write intents) use this function to find the Shared locks that they
+// conflict with.
+func ScanConflictingSharedLocks(
+	ctx context.Context,
+	reader Reader,
+	txn *roachpb.Transaction,
+	start, end roachpb.Key,
+	maxLocks int64,
+) ([]roachpb.Intent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ltStart, _ := keys.LockTableSingleKey(start, nil)
+	opts := IterOptions{LowerBound: ltStart}
+	if len(end) == 0 {
+		opts.Prefix = true
+	} else {
+		ltEnd, _ := keys.LockTableSingleKey(end, nil)
+		opts.UpperBound = ltEnd
+	}
+	iter := reader.NewEngineIterator(opts)
+	defer iter.Close()
+
+	var locks []roachpb.Intent
+	var meta enginepb.MVCCMetadata
+	var ok bool
+	var err error
+	for ok, err = iter.SeekEngineKeyGE(EngineKey{Key: ltStart}); ok; ok, err = iter.NextEngineKey() {
+		if maxLocks > 0 && int64(len(locks)) >= maxLocks {
+			break
+		}
+		key, err := iter.EngineKey()
+		if err != nil {
+			return nil, err
+		}
+		if !key.IsLockTableKey() || key.IsExclusiveLockTableKey() {
+			continue
+		}
+		if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+			return nil, err
+		}
+		if meta.Txn == nil {
+			return nil, errors.AssertionFailedf("lock without transaction")
+		}
+		if txn != nil && txn.ID == meta.Txn.ID {
+			continue
+		}
+		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
+		if err != nil {
+			return nil, err
+		}
+		intent := roachpb.MakeIntent(meta.Txn, lockedKey)
+		intent.Strength = lock.Strength(key.Version[0])
+		locks = append(locks, intent)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
 // ScanConflictingIntents scans intents using only the separated intents lock
 // table. The result set is added to the given `intents` slice. It ignores
 // intents that do not conflict with `txn`. If it encounters intents that were
@@ -1720,6 +1801,15 @@ func ScanConflictingIntents(
 		if maxIntents != 0 && int64(len(*intents)) >= maxIntents {
 			break
 		}
+		key, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return false, err
+		}
+		if !key.IsExclusiveLockTableKey() {
+			// Replicated locks of non-Exclusive strength (e.g. Shared locks) are
+			// not intents and do not conflict with non-locking reads.
+			continue
+		}
 		if err = protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
 			return false, err
 		}
@@ -1741,7 +1831,7 @@ func ScanConflictingIntents(
 		if conflictingIntent := meta.Timestamp.ToTimestamp().LessEq(ts); !conflictingIntent {
 			continue
 		}
-		key, err := iter.EngineKey()
+		key, err = iter.EngineKey()
 		if err != nil {
 			return false, err
 		}
@@ -145,6 +145,14 @@ func (k EngineKey) IsLockTableKey() bool {
 	return len(k.Version) == engineKeyVersionLockTableLen
 }
 
+// IsExclusiveLockTableKey returns true if the key is a LockTableKey for a lock
+// with the Exclusive strength, i.e. an intent. The lock table can also contain
+// replicated locks of weaker strengths (e.g. Shared locks), which are not
+// intents and have no provisional value.
+func (k EngineKey) IsExclusiveLockTableKey() bool {
+	return k.IsLockTableKey() && lock.Strength(k.Version[0]) == lock.Exclusive
+}
+
 // ToMVCCKey constructs a MVCCKey from the EngineKey.
 func (k EngineKey) ToMVCCKey() (MVCCKey, error) {
 	key := MVCCKey{Key: k.Key}
@@ -267,7 +275,7 @@ func (lk LockTableKey) ToEngineKey(buf []byte) (EngineKey, []byte) {
 	if len(lk.TxnUUID) != uuid.Size {
 		panic("invalid TxnUUID")
 	}
-	if lk.Strength != lock.Exclusive {
+	if lk.Strength != lock.Shared && lk.Strength != lock.Exclusive {
 		panic("unsupported lock strength")
 	}
 	// The first term in estimatedLen is for LockTableSingleKey.
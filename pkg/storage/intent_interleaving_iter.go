@@ -664,6 +664,32 @@ func (i *intentInterleavingIter) tryDecodeLockKey(
 		i.valid = false
 		return err
 	}
+	// The lock table can also contain replicated locks of non-Exclusive
+	// strength (e.g. Shared locks). These are not intents, so they have no
+	// provisional value and must not be interleaved. Step over them in the
+	// current direction of iteration.
+	for !engineKey.IsExclusiveLockTableKey() {
+		if i.dir < 0 {
+			iterState, err = i.intentIter.PrevEngineKeyWithLimit(nil /* limit */)
+		} else {
+			iterState, err = i.intentIter.NextEngineKeyWithLimit(nil /* limit */)
+		}
+		if err != nil {
+			i.err = err
+			i.valid = false
+			return err
+		}
+		i.intentIterState = iterState
+		if iterState != pebble.IterValid {
+			i.intentKey = nil
+			return nil
+		}
+		if engineKey, err = i.intentIter.UnsafeEngineKey(); err != nil {
+			i.err = err
+			i.valid = false
+			return err
+		}
+	}
 	if i.intentKey, err = keys.DecodeLockTableSingleKey(engineKey.Key); err != nil {
 		i.err = err
 		i.valid = false
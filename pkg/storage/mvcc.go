@@ -3941,6 +3941,153 @@ func MVCCIterate(
 	return intents, nil
 }
 
+// MVCCAcquireLock attempts to acquire a replicated lock with the specified
+// strength on the key on behalf of the transaction. Only the Shared strength is
+// supported; replicated Exclusive locks are acquired by writing intents.
+//
+// The lock is persisted in the replicated lock table keyspace. If the key is
+// locked by another transaction with a conflicting strength, a
+// WriteIntentError is returned so that the concurrency manager can wait for
+// the conflicting lock to be released. Re-acquiring a lock that is already
+// held by the transaction in its current epoch is a no-op, unless the sequence
+// number at which it was acquired has since been rolled back.
+//
+// Replicated locks that are not intents do not contribute to MVCC stats.
+func MVCCAcquireLock(
+	ctx context.Context, rw ReadWriter, txn *roachpb.Transaction, str lock.Strength, key roachpb.Key,
+) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	if txn == nil {
+		return errors.AssertionFailedf("cannot acquire lock on %s without a transaction", key)
+	}
+	if str != lock.Shared {
+		return errors.AssertionFailedf("unsupported replicated lock strength %s", str)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ltKey, _ := keys.LockTableSingleKey(key, nil)
+	iter := rw.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltKey})
+	defer iter.Close()
+
+	var meta enginepb.MVCCMetadata
+	var ok bool
+	var err error
+	for ok, err = iter.SeekEngineKeyGE(EngineKey{Key: ltKey}); ok; ok, err = iter.NextEngineKey() {
+		engineKey, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return err
+		}
+		if !engineKey.IsLockTableKey() {
+			continue
+		}
+		if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+			return err
+		}
+		if meta.Txn == nil {
+			return errors.AssertionFailedf("lock on %s without transaction", key)
+		}
+		exclusive := engineKey.IsExclusiveLockTableKey()
+		if meta.Txn.ID != txn.ID {
+			if exclusive {
+				// Shared locks conflict with intents written by other transactions.
+				return &roachpb.WriteIntentError{
+					Intents: []roachpb.Intent{roachpb.MakeIntent(meta.Txn, key)},
+				}
+			}
+			// Shared locks are compatible with each other.
+			continue
+		}
+		if !exclusive && meta.Txn.Epoch == txn.Epoch &&
+			!enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, txn.IgnoredSeqNums) {
+			// The lock is already held by this transaction.
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	meta = enginepb.MVCCMetadata{
+		Txn:       &txn.TxnMeta,
+		Timestamp: txn.WriteTimestamp.ToLegacyTimestamp(),
+	}
+	metaBytes, err := protoutil.Marshal(&meta)
+	if err != nil {
+		return err
+	}
+	lockKey, _ := LockTableKey{Key: key, Strength: str, TxnUUID: txn.ID[:]}.ToEngineKey(nil)
+	return rw.PutEngineKey(lockKey, metaBytes)
+}
+
+// mvccMaybeReleaseLock releases the replicated non-Exclusive lock with the
+// provided lock table key, as described by meta, if the lock update indicates
+// that the lock is no longer held. This is the case if the transaction has
+// been finalized, if the lock was acquired in an earlier epoch, or if the
+// sequence number at which the lock was acquired has been rolled back. Returns
+// whether the lock was released.
+func mvccMaybeReleaseLock(
+	w Writer, lockKey EngineKey, meta *enginepb.MVCCMetadata, update roachpb.LockUpdate,
+) (bool, error) {
+	if meta.Txn == nil {
+		return false, errors.AssertionFailedf("lock without transaction")
+	}
+	release := update.Status.IsFinalized() ||
+		meta.Txn.Epoch < update.Txn.Epoch ||
+		(meta.Txn.Epoch == update.Txn.Epoch &&
+			enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, update.IgnoredSeqNums))
+	if !release {
+		return false, nil
+	}
+	return true, w.ClearEngineKey(lockKey)
+}
+
+// MVCCResolveSharedLock releases the replicated Shared lock held by the
+// update's transaction on the update's key, if one exists and the update
+// indicates that it is no longer held. Returns whether a lock was released.
+//
+// MVCCResolveWriteIntent does not release Shared locks, so that resolving an
+// intent does not pay for an additional lookup in ranges that cannot contain
+// replicated Shared locks. Callers that resolve the locks of a transaction
+// that may hold replicated Shared locks must call both functions.
+// MVCCResolveWriteIntentRange releases Shared locks in the span.
+func MVCCResolveSharedLock(
+	ctx context.Context, rw ReadWriter, update roachpb.LockUpdate,
+) (bool, error) {
+	if len(update.Key) == 0 {
+		return false, emptyKeyError()
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	lockKey, _ := LockTableKey{
+		Key:      update.Key,
+		Strength: lock.Shared,
+		TxnUUID:  update.Txn.ID[:],
+	}.ToEngineKey(nil)
+	iter := rw.NewEngineIterator(IterOptions{Prefix: true, LowerBound: lockKey.Key})
+	defer iter.Close()
+	valid, err := iter.SeekEngineKeyGE(lockKey)
+	if err != nil || !valid {
+		return false, err
+	}
+	engineKey, err := iter.UnsafeEngineKey()
+	if err != nil {
+		return false, err
+	}
+	if !engineKey.Key.Equal(lockKey.Key) || !bytes.Equal(engineKey.Version, lockKey.Version) {
+		return false, nil
+	}
+	var meta enginepb.MVCCMetadata
+	if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+		return false, err
+	}
+	return mvccMaybeReleaseLock(rw, lockKey, &meta, update)
+}
+
 // MVCCResolveWriteIntent either commits, aborts (rolls back), or moves forward
 // in time an extant write intent for a given txn according to commit parameter.
 // ResolveWriteIntent will skip write intents of other txns. It returns
@@ -4027,6 +4174,8 @@ type separatedIntentAndVersionIter struct {
 	engineIterValid bool
 	engineIterErr   error
 	intentKey       roachpb.Key
+	// strength is the strength of the lock at the engineIter's position.
+	strength lock.Strength
 }
 
 var _ iterForKeyVersions = &separatedIntentAndVersionIter{}
@@ -4058,6 +4207,10 @@ func (s *separatedIntentAndVersionIter) initIntentKey() {
 			s.engineIterValid = false
 			return
 		}
+		s.strength = lock.Exclusive
+		if engineKey.IsLockTableKey() {
+			s.strength = lock.Strength(engineKey.Version[0])
+		}
 	}
 }
 
@@ -4798,6 +4951,23 @@ func MVCCResolveWriteIntentRange(
 			sepIter.nextEngineKey()
 			continue
 		}
+		if sepIter.strength != lock.Exclusive {
+			// Replicated locks of non-Exclusive strength are not intents, so they
+			// have no provisional value to resolve. Release them if needed.
+			lastResolvedKey = append(lastResolvedKey[:0], sepIter.UnsafeKey().Key...)
+			lockKey, _ := LockTableKey{
+				Key:      lastResolvedKey,
+				Strength: sepIter.strength,
+				TxnUUID:  meta.Txn.ID[:],
+			}.ToEngineKey(nil)
+			if released, err := mvccMaybeReleaseLock(rw, lockKey, meta, intent); err != nil {
+				return 0, nil, err
+			} else if released {
+				num++
+			}
+			sepIter.nextEngineKey()
+			continue
+		}
 		// Stash the parsed meta so don't need to parse it again in
 		// mvccResolveWriteIntent. This parsing can be ~10% of the resolution cost
 		// in some benchmarks.
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -2626,6 +2627,77 @@ func TestMVCCResolveTxn(t *testing.T) {
 	}
 }
 
+// TestMVCCAcquireSharedLock verifies that replicated Shared locks can be held
+// by multiple transactions at once, conflict with intents, and are released by
+// intent resolution.
+func TestMVCCAcquireSharedLock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	engine := NewDefaultInMemForTesting()
+	defer engine.Close()
+
+	// Both transactions can acquire a Shared lock on the same key, and doing so
+	// is idempotent.
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey1))
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn2, lock.Shared, testKey1))
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey1))
+
+	// Shared locks are not intents.
+	intent, err := GetIntent(engine, testKey1)
+	require.NoError(t, err)
+	require.Nil(t, intent)
+	value, _, err := MVCCGet(ctx, engine, testKey1, txn2.ReadTimestamp, MVCCGetOptions{})
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Writers find the Shared locks held by other transactions.
+	locks, err := ScanConflictingSharedLocks(ctx, engine, nil, testKey1, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	locks, err = ScanConflictingSharedLocks(ctx, engine, txn1, testKey1, testKey3, 0)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	require.Equal(t, txn2.ID, locks[0].Txn.ID)
+	require.Equal(t, testKey1, locks[0].Key)
+	require.Equal(t, lock.Shared, locks[0].Strength)
+
+	// A Shared lock cannot be acquired on a key with another transaction's
+	// intent.
+	require.NoError(t, MVCCPut(ctx, engine, nil, testKey2, txn2.ReadTimestamp, hlc.ClockTimestamp{}, value1, txn2))
+	err = MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey2)
+	require.IsType(t, &roachpb.WriteIntentError{}, err)
+
+	// Resolving txn1's intent on the key does not release its Shared lock,
+	// resolving the Shared lock does, and resolving the span of txn2's locks
+	// releases its Shared lock along with its intent.
+	_, err = MVCCResolveWriteIntent(ctx, engine, nil,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1}))
+	require.NoError(t, err)
+	locks, err = ScanConflictingSharedLocks(ctx, engine, nil, testKey1, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	released, err := MVCCResolveSharedLock(ctx, engine,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1}))
+	require.NoError(t, err)
+	require.True(t, released)
+	locks, err = ScanConflictingSharedLocks(ctx, engine, nil, testKey1, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	require.Equal(t, txn2.ID, locks[0].Txn.ID)
+
+	_, _, err = MVCCResolveWriteIntentRange(ctx, engine, nil,
+		roachpb.MakeLockUpdate(txn2Commit, roachpb.Span{Key: testKey1, EndKey: testKey3}), 0)
+	require.NoError(t, err)
+	locks, err = ScanConflictingSharedLocks(ctx, engine, nil, testKey1, testKey3, 0)
+	require.NoError(t, err)
+	require.Empty(t, locks)
+	intent, err = GetIntent(engine, testKey2)
+	require.NoError(t, err)
+	require.Nil(t, intent)
+}
+
 // TestMVCCResolveNewerIntent verifies that resolving a newer intent
 // than the committing transaction aborts the intent.
 func TestMVCCResolveNewerIntent(t *testing.T) {
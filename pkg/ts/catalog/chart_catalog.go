@@ -1540,6 +1540,18 @@ var charts = []sectionDescription{
 					"changefeed.internal_retry_message_count",
 				},
 			},
+			{
+				Title: "Dead Letter Queue",
+				Metrics: []string{
+					"changefeed.dlq_messages",
+				},
+			},
+			{
+				Title: "Dead Letter Queue Bytes",
+				Metrics: []string{
+					"changefeed.dlq_bytes",
+				},
+			},
 		},
 	},
 	{
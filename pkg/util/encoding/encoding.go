@@ -104,6 +104,15 @@ const (
 	emptyArray = geoInvertedIndexMarker + 1
 	voidMarker = emptyArray + 1
 
+	// Markers for the key encoding of ranges and multiranges. Like for arrays,
+	// the direction is stored in the marker so that the encoding can be
+	// decoded without knowing it. See RangeKeyTag.
+	rangeKeyMarker                = voidMarker + 1
+	rangeKeyDescendingMarker      = rangeKeyMarker + 1
+	multirangeKeyMarker           = rangeKeyDescendingMarker + 1
+	multirangeKeyDescendingMarker = multirangeKeyMarker + 1
+	rangeInvertedIndexMarker      = multirangeKeyDescendingMarker + 1
+
 	arrayKeyTerminator           byte = 0x00
 	arrayKeyDescendingTerminator byte = 0xFF
 	// We use different null encodings for nulls within key arrays. Doing this
@@ -1647,6 +1656,18 @@ const (
 	ArrayKeyDesc Type = 23 // Array key encoded descendingly
 	Box2D        Type = 24
 	Void         Type = 25
+	TSQuery      Type = 26
+	TSVector     Type = 27
+	Range        Type = 28
+	Multirange   Type = 29
+	RangeKeyAsc  Type = 30 // Range key encoding
+	RangeKeyDesc Type = 31 // Range key encoded descendingly
+	// MultirangeKeyAsc is the multirange key encoding.
+	MultirangeKeyAsc Type = 32
+	// MultirangeKeyDesc is the multirange key encoded descendingly.
+	MultirangeKeyDesc Type = 33
+	// JSONPath is the value encoding of the jsonpath type.
+	JSONPath Type = 34
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -1717,6 +1738,14 @@ func slowPeekType(b []byte) Type {
 			return Decimal
 		case m == voidMarker:
 			return Void
+		case m == rangeKeyMarker:
+			return RangeKeyAsc
+		case m == rangeKeyDescendingMarker:
+			return RangeKeyDesc
+		case m == multirangeKeyMarker:
+			return MultirangeKeyAsc
+		case m == multirangeKeyDescendingMarker:
+			return MultirangeKeyDesc
 		}
 	}
 	return Unknown
@@ -1833,6 +1862,22 @@ func PeekLength(b []byte) (int, error) {
 		}
 		length, err := getArrayLength(b[1:], dir)
 		return 1 + length, err
+	case rangeKeyMarker, rangeKeyDescendingMarker:
+		dir := Ascending
+		if m == rangeKeyDescendingMarker {
+			dir = Descending
+		}
+		length, err := getRangeKeyBodyLength(b[1:], dir)
+		return 1 + length, err
+	case multirangeKeyMarker, multirangeKeyDescendingMarker:
+		dir := Ascending
+		if m == multirangeKeyDescendingMarker {
+			dir = Descending
+		}
+		length, err := getMultirangeKeyBodyLength(b[1:], dir)
+		return 1 + length, err
+	case rangeInvertedIndexMarker:
+		return getRangeInvertedIndexKeyLength(b)
 	case bytesMarker:
 		return getBytesLength(b, ascendingBytesEscapes)
 	case box2DMarker:
@@ -2074,6 +2119,40 @@ func prettyPrintFirstValue(dir Direction, b []byte) ([]byte, string, error) {
 		}
 		build.WriteString("]")
 		return buf, build.String(), nil
+	case RangeKeyAsc, RangeKeyDesc:
+		encDir := Ascending
+		if typ == RangeKeyDesc {
+			encDir = Descending
+		}
+		return prettyPrintRangeKeyBody(encDir, b[1:])
+	case MultirangeKeyAsc, MultirangeKeyDesc:
+		encDir := Ascending
+		if typ == MultirangeKeyDesc {
+			encDir = Descending
+		}
+		var build strings.Builder
+		buf := b[1:]
+		build.WriteString("{")
+		for first := true; ; first = false {
+			if len(buf) == 0 {
+				return nil, "", errors.AssertionFailedf("invalid multirange (unterminated)")
+			}
+			if IsMultirangeKeyDone(buf, encDir) {
+				buf = buf[1:]
+				break
+			}
+			var next string
+			buf, next, err = prettyPrintRangeKeyBody(encDir, buf)
+			if err != nil {
+				return nil, "", err
+			}
+			if !first {
+				build.WriteString(",")
+			}
+			build.WriteString(next)
+		}
+		build.WriteString("}")
+		return buf, build.String(), nil
 	case NotNull:
 		b, _ = DecodeIfNotNull(b)
 		return b, "!NULL", nil
@@ -2606,6 +2685,46 @@ func EncodeJSONValue(appendTo []byte, colID uint32, data []byte) []byte {
 	return EncodeUntaggedBytesValue(appendTo, data)
 }
 
+// EncodeTSQueryValue encodes an already-byte-encoded TSQuery value with no
+// value tag but with a length prefix, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodeTSQueryValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, TSQuery)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
+// EncodeTSVectorValue encodes an already-byte-encoded TSVector value with no
+// value tag but with a length prefix, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodeTSVectorValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, TSVector)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
+// EncodeRangeValue encodes an already-byte-encoded range value with no value
+// tag but with a length prefix, appends it to the supplied buffer, and returns
+// the final buffer.
+func EncodeRangeValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, Range)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
+// EncodeMultirangeValue encodes an already-byte-encoded multirange value with
+// no value tag but with a length prefix, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodeMultirangeValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, Multirange)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
+// EncodeJSONPathValue encodes an already-byte-encoded jsonpath value with no
+// value tag but with a length prefix, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodeJSONPathValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, JSONPath)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
 // DecodeValueTag decodes a value encoded by EncodeValueTag, used as a prefix in
 // each of the other EncodeFooValue methods.
 //
@@ -2994,7 +3113,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 		return dataOffset + n, err
 	case Float:
 		return dataOffset + floatValueEncodedLength, nil
-	case Bytes, Array, JSON, Geo:
+	case Bytes, Array, JSON, Geo, TSQuery, TSVector, Range, Multirange, JSONPath:
 		_, n, i, err := DecodeNonsortingUvarint(b)
 		return dataOffset + n + int(i), err
 	case Box2D:
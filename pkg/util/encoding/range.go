@@ -0,0 +1,313 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package encoding
+
+import "github.com/cockroachdb/errors"
+
+// RangeKeyTag is a byte of the key encoding of a range that describes its
+// structure. The key encoding of a range is:
+//
+//	[rangeKeyMarker] <lower bound> <upper bound>
+//
+// where the lower bound is one of:
+//
+//	RangeKeyEmpty                  (the range is empty and has no upper bound)
+//	RangeKeyLowerInfinite
+//	RangeKeyLowerFinite enc(value) RangeKeyLowerInclusive|RangeKeyLowerExclusive
+//
+// and the upper bound is one of:
+//
+//	RangeKeyUpperFinite enc(value) RangeKeyUpperExclusive|RangeKeyUpperInclusive
+//	RangeKeyUpperInfinite
+//
+// The tags are chosen such that the encoding sorts like ranges do: the empty
+// range first, then by lower bound, then by upper bound. When the range is
+// encoded descendingly, the tags are complemented and the bound values are
+// encoded descendingly.
+//
+// A multirange is encoded as a multirange marker followed by the encodings of
+// its ranges without their markers, followed by a terminator. Since a
+// multirange never contains an empty range, the terminator can have the same
+// value as RangeKeyEmpty.
+type RangeKeyTag byte
+
+// The tags of the key encoding of a range. See RangeKeyTag.
+const (
+	RangeKeyEmpty         RangeKeyTag = 0x00
+	RangeKeyLowerInfinite RangeKeyTag = 0x01
+	RangeKeyLowerFinite   RangeKeyTag = 0x02
+
+	RangeKeyUpperFinite   RangeKeyTag = 0x01
+	RangeKeyUpperInfinite RangeKeyTag = 0x02
+
+	RangeKeyLowerInclusive RangeKeyTag = 0x00
+	RangeKeyLowerExclusive RangeKeyTag = 0x01
+
+	RangeKeyUpperExclusive RangeKeyTag = 0x00
+	RangeKeyUpperInclusive RangeKeyTag = 0x01
+
+	multirangeKeyTerminator = byte(RangeKeyEmpty)
+)
+
+// RangeInvertedIndexTag follows the marker of an inverted index key of a range
+// or multirange column, and describes the rest of the key. Every nonempty
+// range is indexed by one key for its lower bound and one for its upper bound,
+// so that the rows overlapping or containing a range can be found by scanning
+// a span of lower bounds and a span of upper bounds:
+//
+//	[rangeInvertedIndexMarker] RangeInvertedIndexEmpty
+//	[rangeInvertedIndexMarker] RangeInvertedIndexLower <lower bound>
+//	[rangeInvertedIndexMarker] RangeInvertedIndexUpper <upper bound>
+//
+// where the bounds are encoded ascendingly, like in the key encoding of a
+// range.
+type RangeInvertedIndexTag byte
+
+// The tags of inverted index keys of ranges. See RangeInvertedIndexTag.
+const (
+	RangeInvertedIndexEmpty RangeInvertedIndexTag = 0x00
+	RangeInvertedIndexLower RangeInvertedIndexTag = 0x01
+	RangeInvertedIndexUpper RangeInvertedIndexTag = 0x02
+)
+
+// EncodeRangeKeyMarker adds the range key encoding marker to buf and returns
+// the new buffer.
+func EncodeRangeKeyMarker(buf []byte, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, rangeKeyMarker)
+	case Descending:
+		return append(buf, rangeKeyDescendingMarker)
+	default:
+		panic("invalid direction")
+	}
+}
+
+// EncodeMultirangeKeyMarker adds the multirange key encoding marker to buf and
+// returns the new buffer.
+func EncodeMultirangeKeyMarker(buf []byte, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, multirangeKeyMarker)
+	case Descending:
+		return append(buf, multirangeKeyDescendingMarker)
+	default:
+		panic("invalid direction")
+	}
+}
+
+// EncodeMultirangeKeyTerminator adds the multirange key terminator to buf and
+// returns the new buffer.
+func EncodeMultirangeKeyTerminator(buf []byte, dir Direction) []byte {
+	return EncodeRangeKeyTag(buf, RangeKeyTag(multirangeKeyTerminator), dir)
+}
+
+// IsMultirangeKeyDone returns if the first byte in the input is the
+// multirange terminator for the input direction.
+func IsMultirangeKeyDone(buf []byte, dir Direction) bool {
+	expected := multirangeKeyTerminator
+	if dir == Descending {
+		expected = ^expected
+	}
+	return buf[0] == expected
+}
+
+// ValidateAndConsumeRangeKeyMarker checks that the marker at the front of buf
+// is valid for a range of the given direction, and consumes it if so. It
+// returns an error if the tag is invalid.
+func ValidateAndConsumeRangeKeyMarker(buf []byte, dir Direction) ([]byte, error) {
+	typ := PeekType(buf)
+	expected := RangeKeyAsc
+	if dir == Descending {
+		expected = RangeKeyDesc
+	}
+	if typ != expected {
+		return nil, errors.Newf("invalid type found %s", typ)
+	}
+	return buf[1:], nil
+}
+
+// ValidateAndConsumeMultirangeKeyMarker checks that the marker at the front of
+// buf is valid for a multirange of the given direction, and consumes it if so.
+// It returns an error if the tag is invalid.
+func ValidateAndConsumeMultirangeKeyMarker(buf []byte, dir Direction) ([]byte, error) {
+	typ := PeekType(buf)
+	expected := MultirangeKeyAsc
+	if dir == Descending {
+		expected = MultirangeKeyDesc
+	}
+	if typ != expected {
+		return nil, errors.Newf("invalid type found %s", typ)
+	}
+	return buf[1:], nil
+}
+
+// EncodeRangeKeyTag adds a tag of the key encoding of a range to buf and
+// returns the new buffer.
+func EncodeRangeKeyTag(buf []byte, tag RangeKeyTag, dir Direction) []byte {
+	if dir == Descending {
+		tag = ^tag
+	}
+	return append(buf, byte(tag))
+}
+
+// DecodeRangeKeyTag decodes a tag of the key encoding of a range from the
+// front of buf, and returns the remaining buffer.
+func DecodeRangeKeyTag(buf []byte, dir Direction) ([]byte, RangeKeyTag, error) {
+	if len(buf) == 0 {
+		return nil, 0, errors.Errorf("insufficient bytes to decode range tag")
+	}
+	tag := RangeKeyTag(buf[0])
+	if dir == Descending {
+		tag = ^tag
+	}
+	return buf[1:], tag, nil
+}
+
+// EncodeRangeInvertedIndexKeyPrefix adds the marker of an inverted index key
+// of a range and the given tag to buf, and returns the new buffer.
+func EncodeRangeInvertedIndexKeyPrefix(buf []byte, tag RangeInvertedIndexTag) []byte {
+	return append(buf, rangeInvertedIndexMarker, byte(tag))
+}
+
+// getRangeKeyBodyLength returns the length of the key encoding of a range,
+// excluding its marker, at the start of b.
+func getRangeKeyBodyLength(b []byte, dir Direction) (int, error) {
+	if len(b) > 0 && tagAt(b, 0, dir) == RangeKeyEmpty {
+		return 1, nil
+	}
+	n, err := getRangeKeyBoundLength(b, true /* lower */, dir)
+	if err != nil {
+		return 0, err
+	}
+	m, err := getRangeKeyBoundLength(b[n:], false /* lower */, dir)
+	if err != nil {
+		return 0, err
+	}
+	return n + m, nil
+}
+
+// getRangeKeyBoundLength returns the length of the key encoding of a range
+// bound at the start of b.
+func getRangeKeyBoundLength(b []byte, lower bool, dir Direction) (int, error) {
+	if len(b) == 0 {
+		return 0, errors.Errorf("insufficient bytes to decode range bound")
+	}
+	finite := RangeKeyUpperFinite
+	if lower {
+		finite = RangeKeyLowerFinite
+	}
+	switch tag := tagAt(b, 0, dir); {
+	case tag == finite:
+		n, err := PeekLength(b[1:])
+		if err != nil {
+			return 0, err
+		}
+		// The bound value is followed by its inclusivity.
+		if len(b) < 1+n+1 {
+			return 0, errors.Errorf("insufficient bytes to decode range bound")
+		}
+		return 1 + n + 1, nil
+	case lower && tag == RangeKeyLowerInfinite, !lower && tag == RangeKeyUpperInfinite:
+		return 1, nil
+	default:
+		return 0, errors.Errorf("invalid range tag %d", tag)
+	}
+}
+
+// getMultirangeKeyBodyLength returns the length of the key encoding of a
+// multirange, excluding its marker, at the start of b.
+func getMultirangeKeyBodyLength(b []byte, dir Direction) (int, error) {
+	n := 0
+	for {
+		if len(b) <= n {
+			return 0, errors.Errorf("invalid multirange encoding (unterminated)")
+		}
+		if IsMultirangeKeyDone(b[n:], dir) {
+			return n + 1, nil
+		}
+		m, err := getRangeKeyBodyLength(b[n:], dir)
+		if err != nil {
+			return 0, err
+		}
+		n += m
+	}
+}
+
+// getRangeInvertedIndexKeyLength returns the length of the inverted index key
+// of a range at the start of b.
+func getRangeInvertedIndexKeyLength(b []byte) (int, error) {
+	if len(b) < 2 {
+		return 0, errors.Errorf("insufficient bytes to decode range inverted index key")
+	}
+	switch RangeInvertedIndexTag(b[1]) {
+	case RangeInvertedIndexEmpty:
+		return 2, nil
+	case RangeInvertedIndexLower:
+		n, err := getRangeKeyBoundLength(b[2:], true /* lower */, Ascending)
+		return 2 + n, err
+	case RangeInvertedIndexUpper:
+		n, err := getRangeKeyBoundLength(b[2:], false /* lower */, Ascending)
+		return 2 + n, err
+	default:
+		return 0, errors.Errorf("invalid range inverted index tag %d", b[1])
+	}
+}
+
+func tagAt(b []byte, i int, dir Direction) RangeKeyTag {
+	tag := RangeKeyTag(b[i])
+	if dir == Descending {
+		tag = ^tag
+	}
+	return tag
+}
+
+// prettyPrintRangeKeyBody returns a string representation of the key
+// encoding of a range, excluding its marker, at the start of b, along with the
+// remaining bytes.
+func prettyPrintRangeKeyBody(dir Direction, b []byte) ([]byte, string, error) {
+	var err error
+	var tag RangeKeyTag
+	if b, tag, err = DecodeRangeKeyTag(b, dir); err != nil {
+		return nil, "", err
+	}
+	if tag == RangeKeyEmpty {
+		return b, "empty", nil
+	}
+	var lower, upper, lowerBracket, upperBracket string
+	lowerBracket, upperBracket = "(", ")"
+	if tag == RangeKeyLowerFinite {
+		if b, lower, err = prettyPrintFirstValue(dir, b); err != nil {
+			return nil, "", err
+		}
+		if b, tag, err = DecodeRangeKeyTag(b, dir); err != nil {
+			return nil, "", err
+		}
+		if tag == RangeKeyLowerInclusive {
+			lowerBracket = "["
+		}
+	}
+	if b, tag, err = DecodeRangeKeyTag(b, dir); err != nil {
+		return nil, "", err
+	}
+	if tag == RangeKeyUpperFinite {
+		if b, upper, err = prettyPrintFirstValue(dir, b); err != nil {
+			return nil, "", err
+		}
+		if b, tag, err = DecodeRangeKeyTag(b, dir); err != nil {
+			return nil, "", err
+		}
+		if tag == RangeKeyUpperInclusive {
+			upperBracket = "]"
+		}
+	}
+	return b, lowerBracket + lower + "," + upper + upperBracket, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/redact"
+)
+
+// testRange describes a range of integers. A nil bound is infinite.
+type testRange struct {
+	empty        bool
+	lower, upper *int64
+	lowerInc     bool
+	upperInc     bool
+}
+
+func intPtr(i int64) *int64 { return &i }
+
+func encodeTestRangeBody(b []byte, r testRange, dir Direction) []byte {
+	encodeVal := func(b []byte, v int64) []byte {
+		if dir == Descending {
+			return EncodeVarintDescending(b, v)
+		}
+		return EncodeVarintAscending(b, v)
+	}
+	if r.empty {
+		return EncodeRangeKeyTag(b, RangeKeyEmpty, dir)
+	}
+	if r.lower == nil {
+		b = EncodeRangeKeyTag(b, RangeKeyLowerInfinite, dir)
+	} else {
+		b = EncodeRangeKeyTag(b, RangeKeyLowerFinite, dir)
+		b = encodeVal(b, *r.lower)
+		inc := RangeKeyLowerExclusive
+		if r.lowerInc {
+			inc = RangeKeyLowerInclusive
+		}
+		b = EncodeRangeKeyTag(b, inc, dir)
+	}
+	if r.upper == nil {
+		return EncodeRangeKeyTag(b, RangeKeyUpperInfinite, dir)
+	}
+	b = EncodeRangeKeyTag(b, RangeKeyUpperFinite, dir)
+	b = encodeVal(b, *r.upper)
+	inc := RangeKeyUpperExclusive
+	if r.upperInc {
+		inc = RangeKeyUpperInclusive
+	}
+	return EncodeRangeKeyTag(b, inc, dir)
+}
+
+func encodeTestRange(r testRange, dir Direction) []byte {
+	return encodeTestRangeBody(EncodeRangeKeyMarker(nil, dir), r, dir)
+}
+
+func encodeTestMultirange(rs []testRange, dir Direction) []byte {
+	b := EncodeMultirangeKeyMarker(nil, dir)
+	for _, r := range rs {
+		b = encodeTestRangeBody(b, r, dir)
+	}
+	return EncodeMultirangeKeyTerminator(b, dir)
+}
+
+// sortedTestRanges are in the order in which ranges sort.
+var sortedTestRanges = []testRange{
+	{empty: true},
+	{upper: intPtr(-5)},
+	{upper: intPtr(-5), upperInc: true},
+	{upper: intPtr(3)},
+	{},
+	{lower: intPtr(-5), lowerInc: true, upper: intPtr(-5), upperInc: true},
+	{lower: intPtr(-5), lowerInc: true, upper: intPtr(10)},
+	{lower: intPtr(-5), upper: intPtr(10)},
+	{lower: intPtr(1), lowerInc: true, upper: intPtr(2)},
+	{lower: intPtr(1), lowerInc: true, upper: intPtr(2), upperInc: true},
+	{lower: intPtr(1), lowerInc: true},
+	{lower: intPtr(1000), upper: intPtr(1001)},
+}
+
+func TestRangeKeyEncodingOrder(t *testing.T) {
+	for _, dir := range []Direction{Ascending, Descending} {
+		for i := 1; i < len(sortedTestRanges); i++ {
+			prev := encodeTestRange(sortedTestRanges[i-1], dir)
+			cur := encodeTestRange(sortedTestRanges[i], dir)
+			c := bytes.Compare(prev, cur)
+			if dir == Descending {
+				c = -c
+			}
+			if c >= 0 {
+				t.Errorf("%s: expected %+v to sort before %+v", dir, sortedTestRanges[i-1], sortedTestRanges[i])
+			}
+		}
+	}
+}
+
+func TestMultirangeKeyEncodingOrder(t *testing.T) {
+	r := func(lower, upper int64) testRange {
+		return testRange{lower: intPtr(lower), lowerInc: true, upper: intPtr(upper)}
+	}
+	sorted := [][]testRange{
+		nil,
+		{{upper: intPtr(0)}},
+		{r(1, 2)},
+		{r(1, 2), r(3, 4)},
+		{r(1, 2), r(3, 5)},
+		{r(1, 3)},
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		for i := 1; i < len(sorted); i++ {
+			c := bytes.Compare(encodeTestMultirange(sorted[i-1], dir), encodeTestMultirange(sorted[i], dir))
+			if dir == Descending {
+				c = -c
+			}
+			if c >= 0 {
+				t.Errorf("%s: expected %+v to sort before %+v", dir, sorted[i-1], sorted[i])
+			}
+		}
+	}
+}
+
+func TestRangeKeyPeekLength(t *testing.T) {
+	suffix := []byte{0x01, 0x02}
+	check := func(t *testing.T, enc []byte) {
+		n, err := PeekLength(append(enc[:len(enc):len(enc)], suffix...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(enc) {
+			t.Errorf("expected length %d, got %d", len(enc), n)
+		}
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		for _, r := range sortedTestRanges {
+			check(t, encodeTestRange(r, dir))
+		}
+		check(t, encodeTestMultirange(nil, dir))
+		check(t, encodeTestMultirange(sortedTestRanges[1:], dir))
+	}
+
+	for _, r := range sortedTestRanges[1:] {
+		// The bounds of inverted index keys are encoded like the bounds of range
+		// keys.
+		body := encodeTestRangeBody(nil, r, Ascending)
+		n, err := getRangeKeyBoundLength(body, true /* lower */, Ascending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, EncodeRangeInvertedIndexKeyPrefix(nil, RangeInvertedIndexLower))
+		check(t, append(EncodeRangeInvertedIndexKeyPrefix(nil, RangeInvertedIndexLower), body[:n]...))
+		check(t, append(EncodeRangeInvertedIndexKeyPrefix(nil, RangeInvertedIndexUpper), body[n:]...))
+	}
+	check(t, EncodeRangeInvertedIndexKeyPrefix(nil, RangeInvertedIndexEmpty))
+}
+
+func TestPrettyPrintRangeKey(t *testing.T) {
+	testData := []struct {
+		key []byte
+		exp string
+	}{
+		{encodeTestRange(testRange{empty: true}, Ascending), "/empty"},
+		{encodeTestRange(testRange{}, Ascending), "/(,)"},
+		{encodeTestRange(testRange{lower: intPtr(1), lowerInc: true, upper: intPtr(5)}, Ascending), "/[1,5)"},
+		{encodeTestRange(testRange{lower: intPtr(1), upper: intPtr(5), upperInc: true}, Descending), "/(1,5]"},
+		{encodeTestMultirange([]testRange{
+			{upper: intPtr(0)}, {lower: intPtr(3), lowerInc: true},
+		}, Ascending), "/{(,0),[3,)}"},
+	}
+	for _, test := range testData {
+		var buf redact.StringBuilder
+		PrettyPrintValue(&buf, nil /* valDirs */, test.key, "/")
+		if got := buf.String(); got != test.exp {
+			t.Errorf("expected %q, got %q", test.exp, got)
+		}
+	}
+}
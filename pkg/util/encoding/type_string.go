@@ -34,11 +34,20 @@ func _() {
 	_ = x[ArrayKeyDesc-23]
 	_ = x[Box2D-24]
 	_ = x[Void-25]
+	_ = x[TSQuery-26]
+	_ = x[TSVector-27]
+	_ = x[Range-28]
+	_ = x[Multirange-29]
+	_ = x[RangeKeyAsc-30]
+	_ = x[RangeKeyDesc-31]
+	_ = x[MultirangeKeyAsc-32]
+	_ = x[MultirangeKeyDesc-33]
+	_ = x[JSONPath-34]
 }
 
-const _Type_name = "UnknownNullNotNullIntFloatDecimalBytesBytesDescTimeDurationTrueFalseUUIDArrayIPAddrJSONTupleBitArrayBitArrayDescTimeTZGeoGeoDescArrayKeyAscArrayKeyDescBox2DVoid"
+const _Type_name = "UnknownNullNotNullIntFloatDecimalBytesBytesDescTimeDurationTrueFalseUUIDArrayIPAddrJSONTupleBitArrayBitArrayDescTimeTZGeoGeoDescArrayKeyAscArrayKeyDescBox2DVoidTSQueryTSVectorRangeMultirangeRangeKeyAscRangeKeyDescMultirangeKeyAscMultirangeKeyDescJSONPath"
 
-var _Type_index = [...]uint8{0, 7, 11, 18, 21, 26, 33, 38, 47, 51, 59, 63, 68, 72, 77, 83, 87, 92, 100, 112, 118, 121, 128, 139, 151, 156, 160}
+var _Type_index = [...]uint8{0, 7, 11, 18, 21, 26, 33, 38, 47, 51, 59, 63, 68, 72, 77, 83, 87, 92, 100, 112, 118, 121, 128, 139, 151, 156, 160, 167, 175, 180, 190, 201, 213, 229, 246, 254}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
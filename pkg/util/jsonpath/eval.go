@@ -0,0 +1,829 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// errEval marks the errors that are raised by the evaluation of a path when it
+// encounters unexpected data, such as a missing key in strict mode. These
+// errors are suppressed by the silent mode of the SQL/JSON path functions.
+var errEval = errors.New("jsonpath evaluation error")
+
+// IsEvalError returns true if err was raised by path evaluation due to the
+// structure or the content of the target document, rather than due to a
+// problem with the path itself or the arguments it was evaluated with.
+func IsEvalError(err error) bool {
+	return errors.Is(err, errEval)
+}
+
+func evalErrorf(code pgcode.Code, format string, args ...interface{}) error {
+	return errors.Mark(pgerror.Newf(code, format, args...), errEval)
+}
+
+var (
+	// exactCtx is used for the arithmetic operators that can be computed
+	// exactly.
+	exactCtx = apd.BaseContext.WithPrecision(0)
+	// decimalCtx is used for division, matching the precision of the DECIMAL
+	// type.
+	decimalCtx = apd.BaseContext.WithPrecision(20)
+)
+
+// tri is the result of a predicate, using three-valued logic.
+type tri int
+
+const (
+	triFalse tri = iota
+	triTrue
+	triUnknown
+)
+
+func (t tri) toJSON() json.JSON {
+	switch t {
+	case triTrue:
+		return json.TrueJSONValue
+	case triFalse:
+		return json.FalseJSONValue
+	}
+	return json.NullJSONValue
+}
+
+// evaluator holds the state of the evaluation of a path.
+type evaluator struct {
+	strict bool
+	root   json.JSON
+	vars   json.JSON
+}
+
+// Eval evaluates the path against the target document and returns the
+// sequence of items it produces. vars, if not nil, must be an object that
+// provides the values of the variables referenced by the path.
+func (p *Path) Eval(target, vars json.JSON) ([]json.JSON, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			`"vars" argument is not an object`)
+	}
+	e := evaluator{strict: p.Strict, root: target, vars: vars}
+	if isPredicate(p.Expr) {
+		t, err := e.evalPred(p.Expr, target, -1 /* last */)
+		if err != nil {
+			return nil, err
+		}
+		return []json.JSON{t.toJSON()}, nil
+	}
+	return e.eval(p.Expr, target, -1 /* last */)
+}
+
+// Query evaluates the path like Eval. If silent is true, evaluation errors
+// (see IsEvalError) are suppressed, in which case ok is false.
+func (p *Path) Query(target, vars json.JSON, silent bool) (res []json.JSON, ok bool, err error) {
+	res, err = p.Eval(target, vars)
+	if err != nil {
+		if silent && IsEvalError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return res, true, nil
+}
+
+// Exists returns whether the path produces any item for the target document.
+// ok is false if the result is unknown, because an evaluation error was
+// suppressed.
+func (p *Path) Exists(target, vars json.JSON, silent bool) (exists, ok bool, err error) {
+	res, ok, err := p.Query(target, vars, silent)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	return len(res) > 0, true, nil
+}
+
+var errSingleBooleanExpected = pgerror.New(
+	pgcode.SingletonSQLJSONItemRequired, "single boolean result is expected",
+)
+
+// Match returns the result of a path that is a predicate check on the target
+// document. The path must produce a single boolean or null item. ok is false if
+// the result is null, or unknown because an error was suppressed.
+func (p *Path) Match(target, vars json.JSON, silent bool) (match, ok bool, err error) {
+	res, ok, err := p.Query(target, vars, silent)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	if len(res) == 1 {
+		switch res[0].Type() {
+		case json.TrueJSONType:
+			return true, true, nil
+		case json.FalseJSONType:
+			return false, true, nil
+		case json.NullJSONType:
+			return false, false, nil
+		}
+	}
+	if silent {
+		return false, false, nil
+	}
+	return false, false, errSingleBooleanExpected
+}
+
+// eval evaluates an expression with the given current item (`@`) and last
+// array index (`last`, or -1 outside of array subscripts).
+func (e *evaluator) eval(expr Expr, cur json.JSON, last int) ([]json.JSON, error) {
+	switch t := expr.(type) {
+	case Root:
+		return []json.JSON{e.root}, nil
+	case Current:
+		return []json.JSON{cur}, nil
+	case Last:
+		return []json.JSON{json.FromInt(last)}, nil
+	case *Variable:
+		var v json.JSON
+		if e.vars != nil {
+			var err error
+			if v, err = e.vars.FetchValKey(t.Name); err != nil {
+				return nil, err
+			}
+		}
+		if v == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", t.Name)
+		}
+		return []json.JSON{v}, nil
+	case *Literal:
+		return []json.JSON{t.Val}, nil
+	case *Chain:
+		items, err := e.eval(t.Head, cur, last)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range t.Steps {
+			if items, err = e.evalStep(s, items, cur, last); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *Binary:
+		if isPredicate(t) {
+			break
+		}
+		return e.evalArithmetic(t, cur, last)
+	case *Unary:
+		if isPredicate(t) {
+			break
+		}
+		return e.evalUnaryArithmetic(t, cur, last)
+	}
+	// Predicates used as values produce a boolean, or null for unknown.
+	res, err := e.evalPred(expr, cur, last)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// evalUnwrapped evaluates an expression and, in lax mode, replaces any arrays
+// in the result with their elements.
+func (e *evaluator) evalUnwrapped(expr Expr, cur json.JSON, last int) ([]json.JSON, error) {
+	items, err := e.eval(expr, cur, last)
+	if err != nil || e.strict {
+		return items, err
+	}
+	return unwrap(items)
+}
+
+// unwrap replaces the arrays in items with their elements.
+func unwrap(items []json.JSON) ([]json.JSON, error) {
+	var ret []json.JSON
+	for _, item := range items {
+		if item.Type() != json.ArrayJSONType {
+			ret = append(ret, item)
+			continue
+		}
+		for i, n := 0, item.Len(); i < n; i++ {
+			elem, err := item.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, elem)
+		}
+	}
+	return ret, nil
+}
+
+// singleNumeric evaluates an operand of an arithmetic operator, which must
+// produce a single number.
+func (e *evaluator) singleNumeric(
+	expr Expr, cur json.JSON, last int, desc string, op BinaryOp,
+) (*apd.Decimal, error) {
+	items, err := e.evalUnwrapped(expr, cur, last)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			return d, nil
+		}
+	}
+	return nil, evalErrorf(pgcode.SingletonSQLJSONItemRequired,
+		"%s operand of jsonpath operator %s is not a single numeric value", desc, op)
+}
+
+func (e *evaluator) evalArithmetic(b *Binary, cur json.JSON, last int) ([]json.JSON, error) {
+	l, err := e.singleNumeric(b.Left, cur, last, "left", b.Op)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.singleNumeric(b.Right, cur, last, "right", b.Op)
+	if err != nil {
+		return nil, err
+	}
+	var res apd.Decimal
+	switch b.Op {
+	case OpAdd:
+		_, err = exactCtx.Add(&res, l, r)
+	case OpSub:
+		_, err = exactCtx.Sub(&res, l, r)
+	case OpMul:
+		_, err = exactCtx.Mul(&res, l, r)
+	case OpDiv, OpMod:
+		if r.IsZero() {
+			return nil, evalErrorf(pgcode.DivisionByZero, "division by zero")
+		}
+		if b.Op == OpDiv {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = decimalCtx.Rem(&res, l, r)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unexpected arithmetic operator %s", b.Op)
+	}
+	if err != nil {
+		return nil, errors.Mark(pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange), errEval)
+	}
+	return []json.JSON{json.FromDecimal(res)}, nil
+}
+
+func (e *evaluator) evalUnaryArithmetic(u *Unary, cur json.JSON, last int) ([]json.JSON, error) {
+	items, err := e.evalUnwrapped(u.Operand, cur, last)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]json.JSON, len(items))
+	for i, item := range items {
+		d, ok := item.AsDecimal()
+		if !ok {
+			sign := "+"
+			if u.Op == OpMinus {
+				sign = "-"
+			}
+			return nil, evalErrorf(pgcode.NonNumericSQLJSONItem,
+				"operand of unary jsonpath operator %s is not a numeric value", sign)
+		}
+		if u.Op == OpMinus {
+			var neg apd.Decimal
+			neg.Neg(d)
+			ret[i] = json.FromDecimal(neg)
+		} else {
+			ret[i] = item
+		}
+	}
+	return ret, nil
+}
+
+// evalStep applies an accessor, filter or method to each of the items.
+func (e *evaluator) evalStep(s Step, items []json.JSON, cur json.JSON, last int) ([]json.JSON, error) {
+	var ret []json.JSON
+	switch t := s.(type) {
+	case *KeyStep:
+		for _, item := range items {
+			switch {
+			case item.Type() == json.ObjectJSONType:
+				v, err := item.FetchValKey(t.Key)
+				if err != nil {
+					return nil, err
+				}
+				if v != nil {
+					ret = append(ret, v)
+				} else if e.strict {
+					return nil, evalErrorf(pgcode.SQLJSONMemberNotFound,
+						"JSON object does not contain key %q", t.Key)
+				}
+			case item.Type() == json.ArrayJSONType && !e.strict:
+				elems, err := unwrap([]json.JSON{item})
+				if err != nil {
+					return nil, err
+				}
+				for _, elem := range elems {
+					if elem.Type() != json.ObjectJSONType {
+						continue
+					}
+					v, err := elem.FetchValKey(t.Key)
+					if err != nil {
+						return nil, err
+					}
+					if v != nil {
+						ret = append(ret, v)
+					}
+				}
+			case e.strict:
+				return nil, evalErrorf(pgcode.SQLJSONObjectNotFound,
+					"jsonpath member accessor can only be applied to an object")
+			}
+		}
+	case AnyKeyStep:
+		if !e.strict {
+			var err error
+			if items, err = unwrap(items); err != nil {
+				return nil, err
+			}
+		}
+		for _, item := range items {
+			if item.Type() != json.ObjectJSONType {
+				if e.strict {
+					return nil, evalErrorf(pgcode.SQLJSONObjectNotFound,
+						"jsonpath wildcard member accessor can only be applied to an object")
+				}
+				continue
+			}
+			it, err := item.ObjectIter()
+			if err != nil {
+				return nil, err
+			}
+			for it.Next() {
+				ret = append(ret, it.Value())
+			}
+		}
+	case AnyIndexStep:
+		for _, item := range items {
+			if item.Type() != json.ArrayJSONType {
+				if e.strict {
+					return nil, evalErrorf(pgcode.SQLJSONArrayNotFound,
+						"jsonpath wildcard array accessor can only be applied to an array")
+				}
+				ret = append(ret, item)
+				continue
+			}
+			elems, err := unwrap([]json.JSON{item})
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, elems...)
+		}
+	case *IndexStep:
+		for _, item := range items {
+			res, err := e.evalIndexStep(t, item, cur)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, res...)
+		}
+	case *FilterStep:
+		if !e.strict {
+			var err error
+			if items, err = unwrap(items); err != nil {
+				return nil, err
+			}
+		}
+		for _, item := range items {
+			res, err := e.evalPred(t.Pred, item, last)
+			if err != nil {
+				return nil, err
+			}
+			if res == triTrue {
+				ret = append(ret, item)
+			}
+		}
+	case *MethodStep:
+		for _, item := range items {
+			res, err := e.evalMethod(t.Method, item)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, res...)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unexpected jsonpath step %T", s)
+	}
+	return ret, nil
+}
+
+// evalIndexStep applies the subscripts of an array accessor to item. In lax
+// mode, non-array items are treated as single element arrays.
+func (e *evaluator) evalIndexStep(s *IndexStep, item json.JSON, cur json.JSON) ([]json.JSON, error) {
+	isArray := item.Type() == json.ArrayJSONType
+	if !isArray && e.strict {
+		return nil, evalErrorf(pgcode.SQLJSONArrayNotFound,
+			"jsonpath array accessor can only be applied to an array")
+	}
+	size := 1
+	if isArray {
+		size = item.Len()
+	}
+	subscript := func(expr Expr) (int, error) {
+		items, err := e.eval(expr, cur, size-1)
+		if err != nil {
+			return 0, err
+		}
+		if len(items) == 1 {
+			if d, ok := items[0].AsDecimal(); ok {
+				f, err := d.Float64()
+				if err == nil && f >= math.MinInt32 && f <= math.MaxInt32 {
+					return int(f), nil
+				}
+			}
+		}
+		return 0, evalErrorf(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	var ret []json.JSON
+	for _, sub := range s.Subscripts {
+		from, err := subscript(sub.From)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if sub.To != nil {
+			if to, err = subscript(sub.To); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || to >= size || from > to {
+			if e.strict {
+				return nil, evalErrorf(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds")
+			}
+			if from < 0 {
+				from = 0
+			}
+			if to >= size {
+				to = size - 1
+			}
+		}
+		for i := from; i <= to; i++ {
+			if !isArray {
+				ret = append(ret, item)
+				continue
+			}
+			elem, err := item.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, elem)
+		}
+	}
+	return ret, nil
+}
+
+// jsonTypeName returns the name of the type of j, as reported by the .type()
+// method.
+func jsonTypeName(j json.JSON) string {
+	switch j.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func (e *evaluator) evalMethod(m Method, item json.JSON) ([]json.JSON, error) {
+	switch m {
+	case MethodType:
+		return []json.JSON{json.FromString(jsonTypeName(item))}, nil
+	case MethodSize:
+		if item.Type() == json.ArrayJSONType {
+			return []json.JSON{json.FromInt(item.Len())}, nil
+		}
+		if e.strict {
+			return nil, evalErrorf(pgcode.SQLJSONArrayNotFound,
+				"jsonpath item method .%s() can only be applied to an array", m)
+		}
+		return []json.JSON{json.FromInt(1)}, nil
+	}
+	// The remaining methods operate on numbers, and unwrap arrays in lax mode.
+	if item.Type() == json.ArrayJSONType && !e.strict {
+		elems, err := unwrap([]json.JSON{item})
+		if err != nil {
+			return nil, err
+		}
+		var ret []json.JSON
+		for _, elem := range elems {
+			if elem.Type() == json.ArrayJSONType {
+				return nil, e.methodTypeError(m)
+			}
+			res, err := e.evalMethod(m, elem)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, res...)
+		}
+		return ret, nil
+	}
+	if m == MethodDouble {
+		return e.evalDouble(item)
+	}
+	d, ok := item.AsDecimal()
+	if !ok {
+		return nil, e.methodTypeError(m)
+	}
+	var res apd.Decimal
+	var err error
+	switch m {
+	case MethodCeiling:
+		_, err = exactCtx.Ceil(&res, d)
+	case MethodFloor:
+		_, err = exactCtx.Floor(&res, d)
+	case MethodAbs:
+		res.Abs(d)
+	default:
+		return nil, errors.AssertionFailedf("unexpected jsonpath method %s", m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{json.FromDecimal(res)}, nil
+}
+
+func (e *evaluator) methodTypeError(m Method) error {
+	if m == MethodDouble {
+		return evalErrorf(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+	}
+	return evalErrorf(pgcode.NonNumericSQLJSONItem,
+		"jsonpath item method .%s() can only be applied to a numeric value", m)
+}
+
+func (e *evaluator) evalDouble(item json.JSON) ([]json.JSON, error) {
+	var f float64
+	switch item.Type() {
+	case json.NumberJSONType:
+		d, _ := item.AsDecimal()
+		var err error
+		if f, err = d.Float64(); err != nil || math.IsInf(f, 0) {
+			return nil, evalErrorf(pgcode.NonNumericSQLJSONItem,
+				"numeric argument of jsonpath item method .double() is out of range for type double precision")
+		}
+	case json.StringJSONType:
+		s, err := item.AsText()
+		if err != nil {
+			return nil, err
+		}
+		f, err = strconv.ParseFloat(strings.TrimSpace(*s), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, evalErrorf(pgcode.NonNumericSQLJSONItem,
+				"string argument of jsonpath item method .double() is not a valid representation of a double precision number")
+		}
+	default:
+		return nil, e.methodTypeError(MethodDouble)
+	}
+	j, err := json.FromFloat64(f)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{j}, nil
+}
+
+// evalPred evaluates a predicate. Evaluation errors in the operands of a
+// predicate are not raised; instead, the predicate evaluates to unknown.
+func (e *evaluator) evalPred(expr Expr, cur json.JSON, last int) (tri, error) {
+	switch t := expr.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd:
+			l, err := e.evalPred(t.Left, cur, last)
+			if err != nil || l == triFalse {
+				return l, err
+			}
+			r, err := e.evalPred(t.Right, cur, last)
+			if err != nil || r != triTrue {
+				return r, err
+			}
+			return l, nil
+		case OpOr:
+			l, err := e.evalPred(t.Left, cur, last)
+			if err != nil || l == triTrue {
+				return l, err
+			}
+			r, err := e.evalPred(t.Right, cur, last)
+			if err != nil || r != triFalse {
+				return r, err
+			}
+			return l, nil
+		case OpStartsWith:
+			return e.evalCompare(t.Left, t.Right, cur, last, func(a, b json.JSON) tri {
+				as, bs := asString(a), asString(b)
+				if as == nil || bs == nil {
+					return triUnknown
+				}
+				return triFromBool(strings.HasPrefix(*as, *bs))
+			})
+		}
+		if t.Op.isComparison() {
+			return e.evalCompare(t.Left, t.Right, cur, last, func(a, b json.JSON) tri {
+				return compareItems(t.Op, a, b)
+			})
+		}
+	case *Unary:
+		switch t.Op {
+		case OpNot:
+			res, err := e.evalPred(t.Operand, cur, last)
+			if err != nil {
+				return res, err
+			}
+			switch res {
+			case triTrue:
+				return triFalse, nil
+			case triFalse:
+				return triTrue, nil
+			}
+			return triUnknown, nil
+		case OpIsUnknown:
+			res, err := e.evalPred(t.Operand, cur, last)
+			if err != nil {
+				return res, err
+			}
+			return triFromBool(res == triUnknown), nil
+		case OpExists:
+			items, err := e.eval(t.Operand, cur, last)
+			if err != nil {
+				if IsEvalError(err) {
+					return triUnknown, nil
+				}
+				return triUnknown, err
+			}
+			return triFromBool(len(items) > 0), nil
+		}
+	case *LikeRegex:
+		re, err := t.compile()
+		if err != nil {
+			return triUnknown, err
+		}
+		return e.evalCompare(t.Expr, nil /* right */, cur, last, func(a, _ json.JSON) tri {
+			s := asString(a)
+			if s == nil {
+				return triUnknown
+			}
+			return triFromBool(re.MatchString(*s))
+		})
+	}
+	return triUnknown, pgerror.New(pgcode.Syntax,
+		"jsonpath expression is not a predicate")
+}
+
+func triFromBool(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+func asString(j json.JSON) *string {
+	if j.Type() != json.StringJSONType {
+		return nil
+	}
+	s, err := j.AsText()
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+// evalCompare evaluates the operands of a comparison-like predicate and
+// applies cmp to every pair of their items. If right is nil, cmp is applied
+// to the items of left alone. In lax mode, the predicate is true if any pair
+// satisfies it; in strict mode, it is unknown if any pair is unknown.
+func (e *evaluator) evalCompare(
+	left, right Expr, cur json.JSON, last int, cmp func(a, b json.JSON) tri,
+) (tri, error) {
+	operand := func(expr Expr) ([]json.JSON, bool, error) {
+		items, err := e.evalUnwrapped(expr, cur, last)
+		if err != nil {
+			if IsEvalError(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		return items, true, nil
+	}
+	ls, ok, err := operand(left)
+	if err != nil || !ok {
+		return triUnknown, err
+	}
+	rs := []json.JSON{nil}
+	if right != nil {
+		if rs, ok, err = operand(right); err != nil || !ok {
+			return triUnknown, err
+		}
+	}
+	found, unknown := false, false
+	for _, l := range ls {
+		for _, r := range rs {
+			switch cmp(l, r) {
+			case triUnknown:
+				if e.strict {
+					return triUnknown, nil
+				}
+				unknown = true
+			case triTrue:
+				if !e.strict {
+					return triTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return triTrue, nil
+	}
+	if unknown {
+		return triUnknown, nil
+	}
+	return triFalse, nil
+}
+
+// compareItems compares two items with a comparison operator. Items of
+// different types are not comparable, except that null is not equal to
+// anything else, and containers are never comparable.
+func compareItems(op BinaryOp, a, b json.JSON) tri {
+	at, bt := a.Type(), b.Type()
+	isBool := func(t json.Type) bool { return t == json.TrueJSONType || t == json.FalseJSONType }
+	if at != bt && !(isBool(at) && isBool(bt)) {
+		if at == json.NullJSONType || bt == json.NullJSONType {
+			return triFromBool(op == OpNe)
+		}
+		return triUnknown
+	}
+	if at == json.ArrayJSONType || at == json.ObjectJSONType {
+		return triUnknown
+	}
+	c, err := a.Compare(b)
+	if err != nil {
+		return triUnknown
+	}
+	switch op {
+	case OpEq:
+		return triFromBool(c == 0)
+	case OpNe:
+		return triFromBool(c != 0)
+	case OpLt:
+		return triFromBool(c < 0)
+	case OpLe:
+		return triFromBool(c <= 0)
+	case OpGt:
+		return triFromBool(c > 0)
+	default:
+		return triFromBool(c >= 0)
+	}
+}
+
+// compile returns the regular expression of the like_regex predicate.
+func (l *LikeRegex) compile() (*regexp.Regexp, error) {
+	pattern := l.Pattern
+	var flags string
+	for _, f := range l.Flags {
+		switch f {
+		case 'i', 's', 'm':
+			flags += string(f)
+		case 'q':
+			pattern = regexp.QuoteMeta(l.Pattern)
+		case 'x':
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		}
+	}
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InvalidRegularExpression,
+			"invalid regular expression")
+	}
+	return re, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	tcs := []struct {
+		path   string
+		target string
+		vars   string
+		// expected is the JSON array of the items that are produced by the
+		// path.
+		expected string
+	}{
+		{`$`, `{"a": 1}`, ``, `[{"a": 1}]`},
+		{`$.a`, `{"a": 1}`, ``, `[1]`},
+		{`$.b`, `{"a": 1}`, ``, `[]`},
+		{`$.a.b`, `{"a": {"b": [1, 2]}}`, ``, `[[1, 2]]`},
+		{`$.a[*]`, `{"a": [1, 2, 3]}`, ``, `[1, 2, 3]`},
+		{`$.a[1]`, `{"a": [1, 2, 3]}`, ``, `[2]`},
+		{`$.a[last]`, `{"a": [1, 2, 3]}`, ``, `[3]`},
+		{`$.a[0, 1 to last]`, `{"a": [1, 2, 3]}`, ``, `[1, 2, 3]`},
+		{`$.a[last - 1]`, `{"a": [1, 2, 3]}`, ``, `[2]`},
+		{`$.a[5]`, `{"a": [1, 2, 3]}`, ``, `[]`},
+		{`$.a[1 to 10]`, `{"a": [1, 2, 3]}`, ``, `[2, 3]`},
+		{`$.*`, `{"a": 1, "b": 2}`, ``, `[1, 2]`},
+		{`$.a.*`, `{"a": [{"b": 1}, {"c": 2}]}`, ``, `[1, 2]`},
+
+		// Lax mode automatically unwraps arrays, and wraps non-arrays.
+		{`$.a.b`, `{"a": [{"b": 1}, {"b": 2}, 3]}`, ``, `[1, 2]`},
+		{`$.a[0]`, `{"a": 1}`, ``, `[1]`},
+		{`$.a[*]`, `{"a": 1}`, ``, `[1]`},
+		{`$.a.size()`, `{"a": 1}`, ``, `[1]`},
+		{`strict $.a.b`, `{"a": [{"b": 1}, {"b": 2}]}`, ``, `ERROR: jsonpath member accessor can only be applied to an object`},
+		{`strict $.b`, `{"a": 1}`, ``, `ERROR: JSON object does not contain key "b"`},
+		{`strict $.a[5]`, `{"a": [1]}`, ``, `ERROR: jsonpath array subscript is out of bounds`},
+		{`strict $.a[*]`, `{"a": 1}`, ``, `ERROR: jsonpath wildcard array accessor can only be applied to an array`},
+		{`strict $.a.size()`, `{"a": 1}`, ``, `ERROR: jsonpath item method .size() can only be applied to an array`},
+
+		// Filters.
+		{`$.a[*] ? (@ > 1)`, `{"a": [1, 2, 3]}`, ``, `[2, 3]`},
+		{`$.a ? (@ > 1)`, `{"a": [1, 2, 3]}`, ``, `[2, 3]`},
+		{`$.a ? (@.b == "x").c`, `{"a": [{"b": "x", "c": 1}, {"b": "y", "c": 2}]}`, ``, `[1]`},
+		{`$ ? (@.a > $min && @.a < $max)`, `[{"a": 1}, {"a": 5}]`, `{"min": 2, "max": 10}`, `[{"a": 5}]`},
+		{`$[*] ? (@ starts with "ab")`, `["abc", "bc", 1]`, ``, `["abc"]`},
+		{`$[*] ? (@ like_regex "^B" flag "i")`, `["abc", "bc", 1]`, ``, `["bc"]`},
+		{`$[*] ? (exists (@.a))`, `[{"a": 1}, {"b": 2}]`, ``, `[{"a": 1}]`},
+		{`$[*] ? (!(@ == 1))`, `[1, 2, "a"]`, ``, `[2]`},
+		{`$[*] ? ((@ > 1) is unknown)`, `[1, 2, "a"]`, ``, `["a"]`},
+		{`$[*] ? (@ == null)`, `[1, null, "a"]`, ``, `[null]`},
+		{`$[*] ? (@ != null)`, `[1, null, "a"]`, ``, `[1, "a"]`},
+		{`$[*] ? (@ == true)`, `[true, false, 1]`, ``, `[true]`},
+
+		// Predicates.
+		{`$.a == 1`, `{"a": 1}`, ``, `[true]`},
+		{`$.a == 2`, `{"a": 1}`, ``, `[false]`},
+		{`$.a == "1"`, `{"a": 1}`, ``, `[null]`},
+		{`$.a[*] > 2`, `{"a": [1, 2, 3]}`, ``, `[true]`},
+		{`strict $.a[*] > 2`, `{"a": [1, "x", 3]}`, ``, `[null]`},
+		{`$.a[*] > 2`, `{"a": [1, "x", 3]}`, ``, `[true]`},
+		{`$.a == 1 && $.b == 2`, `{"a": 1, "b": 2}`, ``, `[true]`},
+		{`$.a == 1 || $.b == 3`, `{"a": 2, "b": 2}`, ``, `[false]`},
+		{`strict $.c == 1`, `{"a": 1}`, ``, `[null]`},
+		{`exists($.a)`, `{"a": 1}`, ``, `[true]`},
+
+		// Arithmetic and item methods.
+		{`$.a + 1`, `{"a": 1}`, ``, `[2]`},
+		{`$.a * 2 - 1`, `{"a": 1.5}`, ``, `[2.0]`},
+		{`$.a / 4`, `{"a": 1}`, ``, `[0.25000000000000000000]`},
+		{`$.a % 4`, `{"a": 10}`, ``, `[2]`},
+		{`-$.a[*]`, `{"a": [1, -2]}`, ``, `[-1, 2]`},
+		{`$.a / 0`, `{"a": 1}`, ``, `ERROR: division by zero`},
+		{`$.a[*] + 1`, `{"a": [1, 2]}`, ``, `ERROR: left operand of jsonpath operator + is not a single numeric value`},
+		{`$.a.type()`, `{"a": [1]}`, ``, `["array"]`},
+		{`$.*.type()`, `{"a": 1, "b": "x", "c": null, "d": true, "e": {}}`, ``, `["number", "string", "null", "boolean", "object"]`},
+		{`$.a.size()`, `{"a": [1, 2]}`, ``, `[2]`},
+		{`$.a.floor()`, `{"a": 1.5}`, ``, `[1]`},
+		{`$.a.ceiling()`, `{"a": 1.5}`, ``, `[2]`},
+		{`$.a.abs()`, `{"a": [-1.5, 2]}`, ``, `[1.5, 2]`},
+		{`$.a.double()`, `{"a": "1.5"}`, ``, `[1.5]`},
+		{`$.a.double()`, `{"a": "x"}`, ``, `ERROR: string argument of jsonpath item method .double() is not a valid representation of a double precision number`},
+		{`$.a.abs()`, `{"a": "x"}`, ``, `ERROR: jsonpath item method .abs() can only be applied to a numeric value`},
+
+		// Variables.
+		{`$x`, `{}`, `{"x": [1, 2]}`, `[[1, 2]]`},
+		{`$x`, `{}`, `{}`, `ERROR: could not find jsonpath variable "x"`},
+		{`$x`, `{}`, `[1]`, `ERROR: "vars" argument is not an object`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			var vars json.JSON
+			if tc.vars != "" {
+				vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := p.Eval(target, vars)
+			if err != nil {
+				assert.Equal(t, tc.expected, "ERROR: "+err.Error())
+				return
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			assert.Equal(t, tc.expected, b.Build().String())
+		})
+	}
+}
+
+func TestIsEvalError(t *testing.T) {
+	eval := func(path, target string) error {
+		p, err := Parse(path)
+		require.NoError(t, err)
+		j, err := json.ParseJSON(target)
+		require.NoError(t, err)
+		_, err = p.Eval(j, nil /* vars */)
+		return err
+	}
+
+	err := eval(`strict $.a`, `{}`)
+	require.Error(t, err)
+	assert.True(t, IsEvalError(err))
+	assert.Equal(t, pgcode.SQLJSONMemberNotFound, pgerror.GetPGCode(err))
+
+	err = eval(`$ / 0`, `1`)
+	require.Error(t, err)
+	assert.True(t, IsEvalError(err))
+	assert.Equal(t, pgcode.DivisionByZero, pgerror.GetPGCode(err))
+
+	// Missing variables are not suppressed by silent mode.
+	err = eval(`$x`, `1`)
+	require.Error(t, err)
+	assert.False(t, IsEvalError(err))
+	assert.Equal(t, pgcode.UndefinedObject, pgerror.GetPGCode(err))
+}
@@ -0,0 +1,441 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON documents. See
+// https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH
+// for a description of the language.
+package jsonpath
+
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Path is a parsed SQL/JSON path expression.
+type Path struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors, like accessing a missing key, are reported rather than
+	// ignored.
+	Strict bool
+	// Expr is the expression that is evaluated against the target document.
+	Expr Expr
+}
+
+// String returns the canonical text representation of the path.
+func (p *Path) String() string {
+	var sb strings.Builder
+	if p.Strict {
+		sb.WriteString("strict ")
+	}
+	p.Expr.format(&sb, true /* parens */)
+	return sb.String()
+}
+
+// MemSize returns the approximate size of the path in bytes.
+func (p *Path) MemSize() uintptr {
+	// The AST isn't worth walking for an exact answer; its size is dominated by
+	// the size of the path's text representation.
+	return unsafe.Sizeof(*p) + uintptr(len(p.String()))*2
+}
+
+// IsPredicate returns true if the path is a predicate check expression, such
+// as `$.a > 1`, which evaluates to a single boolean (or null, for unknown).
+func (p *Path) IsPredicate() bool {
+	return isPredicate(p.Expr)
+}
+
+// Expr is a node in the tree of a SQL/JSON path expression.
+type Expr interface {
+	// format writes the text representation of the expression to sb. If parens
+	// is true, operator expressions are enclosed in parentheses.
+	format(sb *strings.Builder, parens bool)
+}
+
+// Root is the `$` expression, which refers to the target document.
+type Root struct{}
+
+// Current is the `@` expression, which refers to the item being tested by a
+// filter.
+type Current struct{}
+
+// Last is the `last` expression, which refers to the last index of the array
+// being subscripted.
+type Last struct{}
+
+// Variable is a `$name` expression, which refers to a field of the vars
+// object that the path is evaluated with.
+type Variable struct {
+	Name string
+}
+
+// Literal is a JSON scalar literal: a string, number, boolean or null.
+type Literal struct {
+	Val json.JSON
+}
+
+// Chain is an expression followed by a sequence of accessors, filters and
+// item methods, for example `$.a[*] ? (@ > 1).size()`.
+type Chain struct {
+	Head  Expr
+	Steps []Step
+}
+
+// BinaryOp is a binary operator in a path expression.
+type BinaryOp int
+
+const (
+	// OpAnd is the && operator.
+	OpAnd BinaryOp = iota
+	// OpOr is the || operator.
+	OpOr
+	// OpEq is the == operator.
+	OpEq
+	// OpNe is the != operator, which can also be written as <>.
+	OpNe
+	// OpLt is the < operator.
+	OpLt
+	// OpLe is the <= operator.
+	OpLe
+	// OpGt is the > operator.
+	OpGt
+	// OpGe is the >= operator.
+	OpGe
+	// OpStartsWith is the `starts with` operator.
+	OpStartsWith
+	// OpAdd is the + operator.
+	OpAdd
+	// OpSub is the - operator.
+	OpSub
+	// OpMul is the * operator.
+	OpMul
+	// OpDiv is the / operator.
+	OpDiv
+	// OpMod is the % operator.
+	OpMod
+)
+
+var binaryOpNames = [...]string{
+	OpAnd:        "&&",
+	OpOr:         "||",
+	OpEq:         "==",
+	OpNe:         "!=",
+	OpLt:         "<",
+	OpLe:         "<=",
+	OpGt:         ">",
+	OpGe:         ">=",
+	OpStartsWith: "starts with",
+	OpAdd:        "+",
+	OpSub:        "-",
+	OpMul:        "*",
+	OpDiv:        "/",
+	OpMod:        "%",
+}
+
+func (o BinaryOp) String() string {
+	return binaryOpNames[o]
+}
+
+// isComparison returns true if the operator is one of the comparison
+// operators.
+func (o BinaryOp) isComparison() bool {
+	switch o {
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+		return true
+	}
+	return false
+}
+
+// Binary is an expression with a binary operator.
+type Binary struct {
+	Op          BinaryOp
+	Left, Right Expr
+}
+
+// UnaryOp is a unary operator in a path expression.
+type UnaryOp int
+
+const (
+	// OpNot is the ! operator.
+	OpNot UnaryOp = iota
+	// OpPlus is the unary + operator.
+	OpPlus
+	// OpMinus is the unary - operator.
+	OpMinus
+	// OpExists is the exists(...) predicate.
+	OpExists
+	// OpIsUnknown is the `is unknown` predicate.
+	OpIsUnknown
+)
+
+// Unary is an expression with a unary operator.
+type Unary struct {
+	Op      UnaryOp
+	Operand Expr
+}
+
+// LikeRegex is the like_regex predicate.
+type LikeRegex struct {
+	Expr    Expr
+	Pattern string
+	// Flags is the canonical form of the flags of the predicate, which
+	// contains each of the characters "ismxq" at most once, in that order.
+	Flags string
+}
+
+// Step is an accessor, filter or item method in a Chain.
+type Step interface {
+	formatStep(sb *strings.Builder)
+}
+
+// KeyStep is the `.key` member accessor.
+type KeyStep struct {
+	Key string
+}
+
+// AnyKeyStep is the `.*` wildcard member accessor.
+type AnyKeyStep struct{}
+
+// AnyIndexStep is the `[*]` wildcard array accessor.
+type AnyIndexStep struct{}
+
+// Subscript is a single subscript, or a range of subscripts if To is not nil,
+// in an array accessor.
+type Subscript struct {
+	From, To Expr
+}
+
+// IndexStep is the `[subscript, ...]` array accessor.
+type IndexStep struct {
+	Subscripts []Subscript
+}
+
+// FilterStep is the `? (predicate)` filter expression.
+type FilterStep struct {
+	Pred Expr
+}
+
+// Method is an item method.
+type Method int
+
+const (
+	// MethodType is the .type() method.
+	MethodType Method = iota
+	// MethodSize is the .size() method.
+	MethodSize
+	// MethodDouble is the .double() method.
+	MethodDouble
+	// MethodCeiling is the .ceiling() method.
+	MethodCeiling
+	// MethodFloor is the .floor() method.
+	MethodFloor
+	// MethodAbs is the .abs() method.
+	MethodAbs
+)
+
+var methodNames = [...]string{
+	MethodType:    "type",
+	MethodSize:    "size",
+	MethodDouble:  "double",
+	MethodCeiling: "ceiling",
+	MethodFloor:   "floor",
+	MethodAbs:     "abs",
+}
+
+func (m Method) String() string {
+	return methodNames[m]
+}
+
+// MethodStep is an item method call, like `.size()`.
+type MethodStep struct {
+	Method Method
+}
+
+// priority returns the binding priority of an expression. Operands that have
+// a lower or equal priority than their operator are enclosed in parentheses
+// when formatted.
+func priority(e Expr) int {
+	switch t := e.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpOr:
+			return 0
+		case OpAnd:
+			return 1
+		case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpStartsWith:
+			return 2
+		case OpAdd, OpSub:
+			return 3
+		case OpMul, OpDiv, OpMod:
+			return 4
+		}
+	case *Unary:
+		switch t.Op {
+		case OpPlus, OpMinus:
+			return 5
+		}
+	}
+	return 6
+}
+
+// isPredicate returns true if e evaluates to a boolean.
+func isPredicate(e Expr) bool {
+	switch t := e.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd, OpOr, OpStartsWith:
+			return true
+		}
+		return t.Op.isComparison()
+	case *Unary:
+		switch t.Op {
+		case OpNot, OpExists, OpIsUnknown:
+			return true
+		}
+	case *LikeRegex:
+		return true
+	}
+	return false
+}
+
+func (Root) format(sb *strings.Builder, _ bool)    { sb.WriteByte('$') }
+func (Current) format(sb *strings.Builder, _ bool) { sb.WriteByte('@') }
+func (Last) format(sb *strings.Builder, _ bool)    { sb.WriteString("last") }
+
+func (v *Variable) format(sb *strings.Builder, _ bool) {
+	sb.WriteByte('$')
+	sb.WriteString(json.FromString(v.Name).String())
+}
+
+func (l *Literal) format(sb *strings.Builder, _ bool) {
+	sb.WriteString(l.Val.String())
+}
+
+func (c *Chain) format(sb *strings.Builder, _ bool) {
+	switch h := c.Head.(type) {
+	case Root, Current, Last, *Variable:
+		c.Head.format(sb, false /* parens */)
+	case *Literal:
+		// Negative numbers are parenthesized, so that the sign isn't applied
+		// to the whole chain when the path is parsed again.
+		if d, ok := h.Val.AsDecimal(); ok && d.Negative {
+			sb.WriteByte('(')
+			h.format(sb, false /* parens */)
+			sb.WriteByte(')')
+		} else {
+			h.format(sb, false /* parens */)
+		}
+	default:
+		sb.WriteByte('(')
+		c.Head.format(sb, false /* parens */)
+		sb.WriteByte(')')
+	}
+	for _, s := range c.Steps {
+		s.formatStep(sb)
+	}
+}
+
+func (b *Binary) format(sb *strings.Builder, parens bool) {
+	if parens {
+		sb.WriteByte('(')
+	}
+	p := priority(b)
+	b.Left.format(sb, priority(b.Left) <= p)
+	sb.WriteByte(' ')
+	sb.WriteString(b.Op.String())
+	sb.WriteByte(' ')
+	b.Right.format(sb, priority(b.Right) <= p)
+	if parens {
+		sb.WriteByte(')')
+	}
+}
+
+func (u *Unary) format(sb *strings.Builder, parens bool) {
+	switch u.Op {
+	case OpNot:
+		sb.WriteString("!(")
+		u.Operand.format(sb, false /* parens */)
+		sb.WriteByte(')')
+	case OpExists:
+		sb.WriteString("exists (")
+		u.Operand.format(sb, false /* parens */)
+		sb.WriteByte(')')
+	case OpIsUnknown:
+		sb.WriteByte('(')
+		u.Operand.format(sb, false /* parens */)
+		sb.WriteString(") is unknown")
+	case OpPlus, OpMinus:
+		if parens {
+			sb.WriteByte('(')
+		}
+		if u.Op == OpPlus {
+			sb.WriteByte('+')
+		} else {
+			sb.WriteByte('-')
+		}
+		u.Operand.format(sb, priority(u.Operand) <= priority(u))
+		if parens {
+			sb.WriteByte(')')
+		}
+	}
+}
+
+func (l *LikeRegex) format(sb *strings.Builder, parens bool) {
+	if parens {
+		sb.WriteByte('(')
+	}
+	l.Expr.format(sb, priority(l.Expr) <= priority(l))
+	sb.WriteString(" like_regex ")
+	sb.WriteString(json.FromString(l.Pattern).String())
+	if l.Flags != "" {
+		sb.WriteString(" flag ")
+		sb.WriteString(json.FromString(l.Flags).String())
+	}
+	if parens {
+		sb.WriteByte(')')
+	}
+}
+
+func (k *KeyStep) formatStep(sb *strings.Builder) {
+	sb.WriteByte('.')
+	sb.WriteString(json.FromString(k.Key).String())
+}
+
+func (AnyKeyStep) formatStep(sb *strings.Builder)   { sb.WriteString(".*") }
+func (AnyIndexStep) formatStep(sb *strings.Builder) { sb.WriteString("[*]") }
+
+func (s *IndexStep) formatStep(sb *strings.Builder) {
+	sb.WriteByte('[')
+	for i, sub := range s.Subscripts {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sub.From.format(sb, false /* parens */)
+		if sub.To != nil {
+			sb.WriteString(" to ")
+			sub.To.format(sb, false /* parens */)
+		}
+	}
+	sb.WriteByte(']')
+}
+
+func (f *FilterStep) formatStep(sb *strings.Builder) {
+	sb.WriteString("?(")
+	f.Pred.format(sb, false /* parens */)
+	sb.WriteByte(')')
+}
+
+func (m *MethodStep) formatStep(sb *strings.Builder) {
+	sb.WriteByte('.')
+	sb.WriteString(m.Method.String())
+	sb.WriteString("()")
+}
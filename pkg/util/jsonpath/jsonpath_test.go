@@ -0,0 +1,111 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`lax $`, `$`},
+		{`strict $`, `strict $`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b.c`, `$."a"."b"."c"`},
+		{`$.type`, `$."type"`},
+		{`$.*`, `$.*`},
+		{`$[*]`, `$[*]`},
+		{`$.a[1]`, `$."a"[1]`},
+		{`$.a[1, 2 to last]`, `$."a"[1,2 to last]`},
+		{`$.a[last - 1]`, `$."a"[last - 1]`},
+		{`$ ? (@.a > 1)`, `$?(@."a" > 1)`},
+		{`$.a ? (@ == "x").b`, `$."a"?(@ == "x")."b"`},
+		{`$.a.size()`, `$."a".size()`},
+		{`$.a.type()`, `$."a".type()`},
+		{`$.a.double().floor().ceiling().abs()`, `$."a".double().floor().ceiling().abs()`},
+		{`$a`, `$"a"`},
+		{`$"a b"`, `$"a b"`},
+		{`$.a == 1`, `($."a" == 1)`},
+		{`$.a <> 1`, `($."a" != 1)`},
+		{`$ ? (@ > 1 && @ < 5 || @ == 10)`, `$?(@ > 1 && @ < 5 || @ == 10)`},
+		{`$ ? (@ > 1 && (@ < 5 || @ == 10))`, `$?(@ > 1 && (@ < 5 || @ == 10))`},
+		{`$ ? (!(@ > 1))`, `$?(!(@ > 1))`},
+		{`$ ? (exists (@.a))`, `$?(exists (@."a"))`},
+		{`$ ? ((@ > 1) is unknown)`, `$?((@ > 1) is unknown)`},
+		{`$ ? (@ starts with "a")`, `$?(@ starts with "a")`},
+		{`$ ? (@ starts with $x)`, `$?(@ starts with $"x")`},
+		{`$ ? (@ like_regex "^a.*")`, `$?(@ like_regex "^a.*")`},
+		{`$ ? (@ like_regex "^a" flag "smi")`, `$?(@ like_regex "^a" flag "ism")`},
+		{`1 + 2 * 3`, `(1 + 2 * 3)`},
+		{`(1 + 2) * 3`, `((1 + 2) * 3)`},
+		{`-1`, `-1`},
+		{`+1`, `1`},
+		{`-$.a`, `(-$."a")`},
+		{`- -1`, `1`},
+		{`(1 + 2).type()`, `(1 + 2).type()`},
+		{`"a".type()`, `"a".type()`},
+		{`(-1).abs()`, `(-1).abs()`},
+		{`1.5`, `1.5`},
+		{`.5`, `0.5`},
+		{`true`, `true`},
+		{`null`, `null`},
+		{`"a\"bA"`, `"a\"bA"`},
+		{`  $ . a  `, `$."a"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p.String())
+
+			// The canonical form should parse to the same path.
+			p2, err := Parse(p.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p2.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$.a b`, `syntax error at or near "b" of jsonpath input`},
+		{`$[`, `syntax error at end of jsonpath input`},
+		{`$ ? (@.a)`, `syntax error at or near ")" of jsonpath input`},
+		{`$.a.foo()`, `syntax error at or near "(" of jsonpath input`},
+		{`@`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$ ? (@ like_regex "a" flag "z")`, `unrecognized flag character 'z' in LIKE_REGEX predicate`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+		{`"abc`, `unexpected end of quoted string`},
+		{`1a`, `trailing junk after numeric literal`},
+		{`$ # 1`, `syntax error at or near "#" of jsonpath input`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
@@ -0,0 +1,785 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// tokenKind is the kind of a lexical token of a path expression.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is a bare word, which is either a keyword or a member key.
+	tokIdent
+	// tokString is a double-quoted string literal.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a `$name` or `$"name"` variable reference.
+	tokVariable
+	// tokOp is an operator or punctuation character.
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	// val is the text of the token. For strings and variables, it is the
+	// unescaped value.
+	val string
+	// raw is the text of the token as written in the input.
+	raw string
+}
+
+// lexer splits a path expression into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// multi-character operators, which must be matched before single character
+// ones.
+var multiCharOps = []string{"==", "!=", "<>", "<=", ">=", "&&", "||"}
+
+const singleCharOps = "$@.,()[]?*+-/%<>!"
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// next returns the next token of the input.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && strings.IndexByte(" \t\n\r\f", l.input[l.pos]) >= 0 {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF}, nil
+	}
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '"':
+		s, err := l.lexString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokString, val: s, raw: l.input[start:l.pos]}, nil
+	case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"':
+		l.pos++
+		s, err := l.lexString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokVariable, val: s, raw: l.input[start:l.pos]}, nil
+	case c == '$' && l.pos+1 < len(l.input) && isIdentChar(l.input[l.pos+1]):
+		l.pos++
+		for l.pos < len(l.input) && isIdentChar(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokVariable, val: l.input[start+1 : l.pos], raw: l.input[start:l.pos]}, nil
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.input) && isDigit(l.input[l.pos+1])):
+		return l.lexNumber()
+	case isIdentChar(c):
+		for l.pos < len(l.input) && isIdentChar(l.input[l.pos]) {
+			l.pos++
+		}
+		s := l.input[start:l.pos]
+		return token{kind: tokIdent, val: s, raw: s}, nil
+	}
+	for _, op := range multiCharOps {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			if op == "<>" {
+				op = "!="
+			}
+			return token{kind: tokOp, val: op, raw: l.input[start:l.pos]}, nil
+		}
+	}
+	if strings.IndexByte(singleCharOps, c) >= 0 {
+		l.pos++
+		s := l.input[start:l.pos]
+		return token{kind: tokOp, val: s, raw: s}, nil
+	}
+	_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+	return token{}, syntaxError(l.input[l.pos : l.pos+size])
+}
+
+// lexNumber lexes a numeric literal, such as 1, 1.5, .5 or 1e10.
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	// A dot is only part of the number if it is followed by a digit, so that
+	// item methods can be called on integers, like `1.type()`.
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' && isDigit(l.input[l.pos+1]) {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		p := l.pos + 1
+		if p < len(l.input) && (l.input[p] == '+' || l.input[p] == '-') {
+			p++
+		}
+		if p < len(l.input) && isDigit(l.input[p]) {
+			l.pos = p
+			for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+				l.pos++
+			}
+		}
+	}
+	if l.pos < len(l.input) && isIdentChar(l.input[l.pos]) {
+		// Numbers directly followed by letters, like 1a, are invalid.
+		return token{}, pgerror.Newf(pgcode.Syntax,
+			"trailing junk after numeric literal at or near %q of jsonpath input",
+			l.input[start:l.pos+1])
+	}
+	s := l.input[start:l.pos]
+	return token{kind: tokNumber, val: s, raw: s}, nil
+}
+
+// lexString lexes a double-quoted string starting at the current position and
+// returns its unescaped value.
+func (l *lexer) lexString() (string, error) {
+	start := l.pos
+	l.pos++
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return sb.String(), nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return "", pgerror.Newf(pgcode.Syntax,
+					"unexpected end of quoted string at or near %q of jsonpath input", l.input[start:])
+			}
+			l.pos++
+			switch e := l.input[l.pos]; e {
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'v':
+				sb.WriteByte('\v')
+			case 'u':
+				if l.pos+5 > len(l.input) {
+					return "", pgerror.Newf(pgcode.Syntax,
+						"invalid Unicode escape sequence at or near %q of jsonpath input", l.input[l.pos-1:])
+				}
+				r, err := strconv.ParseUint(l.input[l.pos+1:l.pos+5], 16, 32)
+				if err != nil {
+					return "", pgerror.Newf(pgcode.Syntax,
+						"invalid Unicode escape sequence at or near %q of jsonpath input", l.input[l.pos-1:l.pos+5])
+				}
+				sb.WriteRune(rune(r))
+				l.pos += 4
+			default:
+				// Any other escaped character, including the quote and the
+				// backslash, stands for itself.
+				sb.WriteByte(e)
+			}
+			l.pos++
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+	return "", pgerror.Newf(pgcode.Syntax,
+		"unexpected end of quoted string at or near %q of jsonpath input", l.input[start:])
+}
+
+func syntaxError(near string) error {
+	if near == "" {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", near)
+}
+
+// parser is a recursive descent parser for path expressions.
+type parser struct {
+	lexer
+	tok token
+}
+
+// Parse parses a SQL/JSON path expression.
+func Parse(s string) (*Path, error) {
+	p := parser{lexer: lexer{input: s}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var ret Path
+	if p.isIdent("strict") {
+		ret.Strict = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	} else if p.isIdent("lax") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, syntaxError(p.tok.raw)
+	}
+	if err := checkExpr(expr, false /* inFilter */, false /* inSubscript */); err != nil {
+		return nil, err
+	}
+	ret.Expr = expr
+	return &ret, nil
+}
+
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.val == op
+}
+
+func (p *parser) isIdent(kw string) bool {
+	return p.tok.kind == tokIdent && p.tok.val == kw
+}
+
+// expectOp consumes the given operator, or returns a syntax error if the
+// current token is something else.
+func (p *parser) expectOp(op string) error {
+	if !p.isOp(op) {
+		return syntaxError(p.tok.raw)
+	}
+	return p.advance()
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if !p.isOp("!") {
+		return p.parseComparison()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &Unary{Op: OpNot, Operand: operand}, nil
+}
+
+var comparisonOps = map[string]BinaryOp{
+	"==": OpEq,
+	"!=": OpNe,
+	"<":  OpLt,
+	"<=": OpLe,
+	">":  OpGt,
+	">=": OpGe,
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.tok.kind == tokOp:
+		op, ok := comparisonOps[p.tok.val]
+		if !ok {
+			return left, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: op, Left: left, Right: right}, nil
+	case p.isIdent("starts"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isIdent("with") {
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var right Expr
+		switch p.tok.kind {
+		case tokString:
+			right = &Literal{Val: json.FromString(p.tok.val)}
+		case tokVariable:
+			right = &Variable{Name: p.tok.val}
+		default:
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: OpStartsWith, Left: left, Right: right}, nil
+	case p.isIdent("like_regex"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokString {
+			return nil, syntaxError(p.tok.raw)
+		}
+		ret := &LikeRegex{Expr: left, Pattern: p.tok.val}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.isIdent("flag") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokString {
+				return nil, syntaxError(p.tok.raw)
+			}
+			if ret.Flags, err = canonicalFlags(p.tok.val); err != nil {
+				return nil, err
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := ret.compile(); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case p.isIdent("is"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isIdent("unknown") {
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !isPredicate(left) {
+			return nil, syntaxError("unknown")
+		}
+		return &Unary{Op: OpIsUnknown, Operand: left}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := OpAdd
+		if p.tok.val == "-" {
+			op = OpSub
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		var op BinaryOp
+		switch p.tok.val {
+		case "*":
+			op = OpMul
+		case "/":
+			op = OpDiv
+		default:
+			op = OpMod
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if !p.isOp("+") && !p.isOp("-") {
+		return p.parseAccessor()
+	}
+	op := OpPlus
+	if p.tok.val == "-" {
+		op = OpMinus
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	// Fold signs into numeric literals, so that -1 is a single literal.
+	if lit, ok := operand.(*Literal); ok && lit.Val.Type() == json.NumberJSONType {
+		if op == OpPlus {
+			return lit, nil
+		}
+		d, _ := lit.Val.AsDecimal()
+		var neg apd.Decimal
+		neg.Neg(d)
+		return &Literal{Val: json.FromDecimal(neg)}, nil
+	}
+	return &Unary{Op: op, Operand: operand}, nil
+}
+
+// parseAccessor parses a primary expression followed by any number of
+// accessor, filter and method steps.
+func (p *parser) parseAccessor() (Expr, error) {
+	head, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var steps []Step
+	for {
+		var step Step
+		switch {
+		case p.isOp("."):
+			step, err = p.parseDotStep()
+		case p.isOp("["):
+			step, err = p.parseIndexStep()
+		case p.isOp("?"):
+			step, err = p.parseFilterStep()
+		default:
+			if len(steps) == 0 {
+				return head, nil
+			}
+			return &Chain{Head: head, Steps: steps}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+}
+
+var methods = map[string]Method{
+	"type":    MethodType,
+	"size":    MethodSize,
+	"double":  MethodDouble,
+	"ceiling": MethodCeiling,
+	"floor":   MethodFloor,
+	"abs":     MethodAbs,
+}
+
+func (p *parser) parseDotStep() (Step, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch p.tok.kind {
+	case tokOp:
+		if p.tok.val != "*" {
+			return nil, syntaxError(p.tok.raw)
+		}
+		return AnyKeyStep{}, p.advance()
+	case tokString:
+		key := p.tok.val
+		return &KeyStep{Key: key}, p.advance()
+	case tokIdent:
+		name := p.tok.val
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isOp("(") {
+			return &KeyStep{Key: name}, nil
+		}
+		m, ok := methods[name]
+		if !ok {
+			return nil, syntaxError("(")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return &MethodStep{Method: m}, nil
+	}
+	return nil, syntaxError(p.tok.raw)
+}
+
+func (p *parser) parseIndexStep() (Step, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isOp("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return AnyIndexStep{}, p.expectOp("]")
+	}
+	var ret IndexStep
+	for {
+		from, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		sub := Subscript{From: from}
+		if p.isIdent("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if sub.To, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+		}
+		ret.Subscripts = append(ret.Subscripts, sub)
+		if p.isOp("]") {
+			return &ret, p.advance()
+		}
+		if err := p.expectOp(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseFilterStep() (Step, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	if !isPredicate(pred) {
+		return nil, syntaxError(")")
+	}
+	return &FilterStep{Pred: pred}, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.tok
+	var ret Expr
+	switch t.kind {
+	case tokEOF:
+		return nil, syntaxError("")
+	case tokString:
+		ret = &Literal{Val: json.FromString(t.val)}
+	case tokNumber:
+		var d apd.Decimal
+		if _, _, err := d.SetString(t.val); err != nil {
+			return nil, errors.NewAssertionErrorWithWrappedErrf(err, "invalid numeric literal %q", t.val)
+		}
+		ret = &Literal{Val: json.FromDecimal(d)}
+	case tokVariable:
+		ret = &Variable{Name: t.val}
+	case tokIdent:
+		switch t.val {
+		case "true":
+			ret = &Literal{Val: json.TrueJSONValue}
+		case "false":
+			ret = &Literal{Val: json.FalseJSONValue}
+		case "null":
+			ret = &Literal{Val: json.NullJSONValue}
+		case "last":
+			ret = Last{}
+		case "exists":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			operand, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return &Unary{Op: OpExists, Operand: operand}, nil
+		default:
+			return nil, syntaxError(t.raw)
+		}
+	case tokOp:
+		switch t.val {
+		case "$":
+			ret = Root{}
+		case "@":
+			ret = Current{}
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		default:
+			return nil, syntaxError(t.raw)
+		}
+	}
+	return ret, p.advance()
+}
+
+// canonicalFlags validates the flags of a like_regex predicate and returns
+// them in canonical form.
+func canonicalFlags(flags string) (string, error) {
+	const valid = "ismxq"
+	var seen [len(valid)]bool
+	for _, f := range flags {
+		i := strings.IndexRune(valid, f)
+		if i < 0 {
+			return "", pgerror.Newf(pgcode.Syntax,
+				"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate", f)
+		}
+		seen[i] = true
+	}
+	var sb strings.Builder
+	for i := range seen {
+		if seen[i] {
+			sb.WriteByte(valid[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// checkExpr validates the uses of `@` and `last`, which may only appear
+// inside of filters and array subscripts, respectively.
+func checkExpr(e Expr, inFilter, inSubscript bool) error {
+	switch t := e.(type) {
+	case Current:
+		if !inFilter {
+			return pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+		}
+	case Last:
+		if !inSubscript {
+			return pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+		}
+	case *Chain:
+		if err := checkExpr(t.Head, inFilter, inSubscript); err != nil {
+			return err
+		}
+		for _, s := range t.Steps {
+			switch s := s.(type) {
+			case *IndexStep:
+				for _, sub := range s.Subscripts {
+					if err := checkExpr(sub.From, inFilter, true /* inSubscript */); err != nil {
+						return err
+					}
+					if sub.To != nil {
+						if err := checkExpr(sub.To, inFilter, true /* inSubscript */); err != nil {
+							return err
+						}
+					}
+				}
+			case *FilterStep:
+				if err := checkExpr(s.Pred, true /* inFilter */, inSubscript); err != nil {
+					return err
+				}
+			}
+		}
+	case *Binary:
+		if err := checkExpr(t.Left, inFilter, inSubscript); err != nil {
+			return err
+		}
+		return checkExpr(t.Right, inFilter, inSubscript)
+	case *Unary:
+		return checkExpr(t.Operand, inFilter, inSubscript)
+	case *LikeRegex:
+		return checkExpr(t.Expr, inFilter, inSubscript)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package metric
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/netutil/addr"
+	"github.com/cockroachdb/errors"
+	prometheusgo "github.com/prometheus/client_model/go"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// otlpGRPCDefaultPort and otlpHTTPDefaultPath are the defaults
+	// mandated by the OTLP specification.
+	otlpGRPCDefaultPort = "4317"
+	otlpHTTPDefaultPath = "/v1/metrics"
+
+	otlpTimeout = 10 * time.Second
+)
+
+var errNoOTLPEndpoint = errors.New("external.otlp.endpoint is not set")
+
+// OTLPExporter scrapes PrometheusExporter for metrics and pushes them
+// to an OpenTelemetry collector using the OTLP protocol. Counters are
+// exported as cumulative monotonic sums, gauges as gauges and histograms
+// as cumulative histograms with the buckets they were created with.
+//
+// The protocol is selected by the form of the endpoint: an http:// or
+// https:// URL selects OTLP/HTTP, using /v1/metrics if the URL has no
+// path, and a <host>:<port> address selects OTLP/gRPC without TLS, using
+// port 4317 if no port is specified.
+type OTLPExporter struct {
+	pm *PrometheusExporter
+	// startTime is reported as the start of the cumulative metrics.
+	startTime time.Time
+}
+
+// MakeOTLPExporter returns an initialized OTLP exporter. startTime is
+// the time since which counters and histograms have been accumulating,
+// typically the start time of the server.
+func MakeOTLPExporter(pm *PrometheusExporter, startTime time.Time) OTLPExporter {
+	return OTLPExporter{pm: pm, startTime: startTime}
+}
+
+// ValidateOTLPEndpoint returns an error if the endpoint is neither an
+// http:// or https:// URL nor a <host>:<port> address.
+func ValidateOTLPEndpoint(endpoint string) error {
+	_, _, err := parseOTLPEndpoint(endpoint)
+	return err
+}
+
+// parseOTLPEndpoint returns either the URL to which metrics are posted
+// using OTLP/HTTP, or the address to which they are sent using
+// OTLP/gRPC.
+func parseOTLPEndpoint(endpoint string) (httpURL string, grpcAddr string, err error) {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		var u *url.URL
+		if u, err = url.Parse(endpoint); err != nil {
+			return "", "", err
+		}
+		if u.Host == "" {
+			return "", "", errors.Newf("invalid OTLP endpoint %q: missing host", endpoint)
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = otlpHTTPDefaultPath
+		}
+		return u.String(), "", nil
+	}
+	host, port, err := addr.SplitHostPort(endpoint, otlpGRPCDefaultPort)
+	if err != nil {
+		return "", "", err
+	}
+	return "", net.JoinHostPort(host, port), nil
+}
+
+// ParseOTLPHeaders parses a list of headers in the format used by the
+// OTEL_EXPORTER_OTLP_HEADERS environment variable, i.e. comma-separated
+// key=value pairs in which keys and values may be URL-encoded.
+func ParseOTLPHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, errors.Newf("invalid header %q: expected key=value", kv)
+		}
+		k, err := url.PathUnescape(strings.TrimSpace(k))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid header %q", kv)
+		}
+		if k == "" {
+			return nil, errors.Newf("invalid header %q: empty key", kv)
+		}
+		v, err = url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid header %q", kv)
+		}
+		headers[k] = v
+	}
+	return headers, nil
+}
+
+// Push metrics scraped from registry to an OpenTelemetry collector,
+// sending the given headers along with the request. It converts the same
+// metrics that are pulled by Prometheus into OTLP metrics.
+func (oe *OTLPExporter) Push(
+	ctx context.Context, endpoint string, headers map[string]string,
+) error {
+	if endpoint == "" {
+		return errNoOTLPEndpoint
+	}
+	httpURL, grpcAddr, err := parseOTLPEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	// Regardless of whether Push() errors, clear metrics. Only latest metrics
+	// are pushed, like for Graphite.
+	defer oe.pm.clearMetrics()
+	req, err := oe.makeRequest(now())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, otlpTimeout)
+	defer cancel()
+	if httpURL != "" {
+		return pushOTLPHTTP(ctx, httpURL, headers, req)
+	}
+	return pushOTLPGRPC(ctx, grpcAddr, headers, req)
+}
+
+// makeRequest converts the scraped metric families into an OTLP export
+// request.
+func (oe *OTLPExporter) makeRequest(
+	at time.Time,
+) (*colmetricspb.ExportMetricsServiceRequest, error) {
+	h, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	families, err := oe.pm.Gather()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	startNanos, nowNanos := uint64(oe.startTime.UnixNano()), uint64(at.UnixNano())
+	metrics := make([]*metricspb.Metric, 0, len(families))
+	for _, family := range families {
+		if m := otlpMetricFromFamily(family, startNanos, nowNanos); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{
+					otlpAttribute("service.name", "cockroach"),
+					otlpAttribute("host.name", h),
+				},
+			},
+			InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: "cockroach"},
+				Metrics:                metrics,
+			}},
+		}},
+	}, nil
+}
+
+// otlpMetricFromFamily converts a prometheus metric family into an OTLP
+// metric. It returns nil for families that have no metrics or whose type
+// is not supported.
+func otlpMetricFromFamily(
+	family *prometheusgo.MetricFamily, startNanos, nowNanos uint64,
+) *metricspb.Metric {
+	if len(family.Metric) == 0 {
+		return nil
+	}
+	m := &metricspb.Metric{
+		Name:        family.GetName(),
+		Description: family.GetHelp(),
+	}
+	switch family.GetType() {
+	case prometheusgo.MetricType_COUNTER:
+		points := make([]*metricspb.NumberDataPoint, len(family.Metric))
+		for i, pm := range family.Metric {
+			points[i] = otlpNumberDataPoint(pm, pm.GetCounter().GetValue(), startNanos, nowNanos)
+		}
+		m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             points,
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case prometheusgo.MetricType_GAUGE:
+		points := make([]*metricspb.NumberDataPoint, len(family.Metric))
+		for i, pm := range family.Metric {
+			// Gauges have no start time.
+			points[i] = otlpNumberDataPoint(pm, pm.GetGauge().GetValue(), 0, nowNanos)
+		}
+		m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: points}}
+	case prometheusgo.MetricType_HISTOGRAM:
+		points := make([]*metricspb.HistogramDataPoint, len(family.Metric))
+		for i, pm := range family.Metric {
+			points[i] = otlpHistogramDataPoint(pm, startNanos, nowNanos)
+		}
+		m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             points,
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
+	default:
+		return nil
+	}
+	return m
+}
+
+func otlpNumberDataPoint(
+	pm *prometheusgo.Metric, value float64, startNanos, nowNanos uint64,
+) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		Attributes:        otlpAttributes(pm.Label),
+		StartTimeUnixNano: startNanos,
+		TimeUnixNano:      nowNanos,
+		Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+// otlpHistogramDataPoint converts a prometheus histogram into an OTLP
+// histogram data point. Prometheus buckets hold cumulative counts of the
+// values less than or equal to their upper bound, while OTLP buckets hold
+// the counts of the values between consecutive bounds, plus a final
+// bucket for the values above the last bound.
+func otlpHistogramDataPoint(
+	pm *prometheusgo.Metric, startNanos, nowNanos uint64,
+) *metricspb.HistogramDataPoint {
+	h := pm.GetHistogram()
+	buckets := h.Bucket
+	// The +Inf bucket, if present, is implied by the total count.
+	if n := len(buckets); n > 0 && math.IsInf(buckets[n-1].GetUpperBound(), +1) {
+		buckets = buckets[:n-1]
+	}
+	bounds := make([]float64, len(buckets))
+	counts := make([]uint64, len(buckets)+1)
+	var prev uint64
+	for i, b := range buckets {
+		bounds[i] = b.GetUpperBound()
+		counts[i] = b.GetCumulativeCount() - prev
+		prev = b.GetCumulativeCount()
+	}
+	counts[len(buckets)] = h.GetSampleCount() - prev
+	return &metricspb.HistogramDataPoint{
+		Attributes:        otlpAttributes(pm.Label),
+		StartTimeUnixNano: startNanos,
+		TimeUnixNano:      nowNanos,
+		Count:             h.GetSampleCount(),
+		Sum:               h.GetSampleSum(),
+		BucketCounts:      counts,
+		ExplicitBounds:    bounds,
+	}
+}
+
+func otlpAttributes(labels []*prometheusgo.LabelPair) []*commonpb.KeyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	attrs := make([]*commonpb.KeyValue, len(labels))
+	for i, l := range labels {
+		attrs[i] = otlpAttribute(l.GetName(), l.GetValue())
+	}
+	return attrs
+}
+
+func otlpAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// pushOTLPHTTP sends the request to a collector using OTLP/HTTP with
+// binary protobuf encoding.
+func pushOTLPHTTP(
+	ctx context.Context,
+	target string,
+	headers map[string]string,
+	req *colmetricspb.ExportMetricsServiceRequest,
+) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := httputil.NewClientWithTimeout(otlpTimeout).Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Newf("OTLP collector returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// pushOTLPGRPC sends the request to a collector using OTLP/gRPC.
+func pushOTLPGRPC(
+	ctx context.Context,
+	target string,
+	headers map[string]string,
+	req *colmetricspb.ExportMetricsServiceRequest,
+) error {
+	// As for traces, the connection to the collector does not use TLS.
+	conn, err := grpc.DialContext(
+		ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	if len(headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(headers))
+	}
+	if _, err := colmetricspb.NewMetricsServiceClient(conn).Export(ctx, req); err != nil {
+		return errors.Wrapf(err, "pushing metrics to %s", target)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package metric
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// otlpTestCollector is an in-process stand-in for an OpenTelemetry
+// collector, accepting metrics over both OTLP/gRPC and OTLP/HTTP.
+type otlpTestCollector struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	received chan otlpTestRequest
+}
+
+type otlpTestRequest struct {
+	req    *colmetricspb.ExportMetricsServiceRequest
+	apiKey string
+}
+
+// Export implements colmetricspb.MetricsServiceServer.
+func (c *otlpTestCollector) Export(
+	ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest,
+) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	var apiKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("api-key")) > 0 {
+		apiKey = md.Get("api-key")[0]
+	}
+	c.received <- otlpTestRequest{req: req, apiKey: apiKey}
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (c *otlpTestCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/metrics" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req := &colmetricspb.ExportMetricsServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.received <- otlpTestRequest{req: req, apiKey: r.Header.Get("Api-Key")}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	ctx := context.Background()
+	collector := &otlpTestCollector{received: make(chan otlpTestRequest, 1)}
+
+	grpcServer := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(grpcServer, collector)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = grpcServer.Serve(ln) }()
+	defer grpcServer.Stop()
+
+	httpServer := httptest.NewServer(collector)
+	defer httpServer.Close()
+
+	r := NewRegistry()
+	r.AddLabel("registry", "test")
+	c := NewCounter(Metadata{Name: "test.counter", Help: "a counter"})
+	r.AddMetric(c)
+	g := NewGauge(Metadata{Name: "test.gauge"})
+	r.AddMetric(g)
+	h := NewHistogram(Metadata{Name: "test.histogram"}, time.Minute, IOLatencyBuckets)
+	r.AddMetric(h)
+
+	c.Inc(3)
+	g.Update(-7)
+	for _, v := range []int64{1, 20000, 30000, 1e12} {
+		h.RecordValue(v)
+	}
+
+	startTime := time.Unix(1000, 0)
+	pm := MakePrometheusExporter()
+	oe := MakeOTLPExporter(&pm, startTime)
+
+	for _, tc := range []struct {
+		name     string
+		endpoint string
+	}{
+		{"grpc", ln.Addr().String()},
+		{"http", httpServer.URL},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pm.ScrapeRegistry(r, true /* includeChildMetrics */)
+			headers := map[string]string{"api-key": "secret"}
+			require.NoError(t, oe.Push(ctx, tc.endpoint, headers))
+			received := <-collector.received
+			require.Equal(t, "secret", received.apiKey)
+
+			rms := received.req.ResourceMetrics
+			require.Len(t, rms, 1)
+			require.Len(t, rms[0].InstrumentationLibraryMetrics, 1)
+			metrics := rms[0].InstrumentationLibraryMetrics[0].Metrics
+			require.Len(t, metrics, 3)
+
+			// Metrics are sorted by name.
+			require.Equal(t, "test_counter", metrics[0].Name)
+			require.Equal(t, "a counter", metrics[0].Description)
+			sum := metrics[0].GetSum()
+			require.NotNil(t, sum)
+			require.True(t, sum.IsMonotonic)
+			require.Equal(t,
+				metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				sum.AggregationTemporality)
+			require.Len(t, sum.DataPoints, 1)
+			require.Equal(t, 3.0, sum.DataPoints[0].GetAsDouble())
+			require.Equal(t, uint64(startTime.UnixNano()), sum.DataPoints[0].StartTimeUnixNano)
+			require.Equal(t, "registry", sum.DataPoints[0].Attributes[0].Key)
+			require.Equal(t, "test", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+
+			require.Equal(t, "test_gauge", metrics[1].Name)
+			gauge := metrics[1].GetGauge()
+			require.NotNil(t, gauge)
+			require.Len(t, gauge.DataPoints, 1)
+			require.Equal(t, -7.0, gauge.DataPoints[0].GetAsDouble())
+
+			require.Equal(t, "test_histogram", metrics[2].Name)
+			hist := metrics[2].GetHistogram()
+			require.NotNil(t, hist)
+			require.Len(t, hist.DataPoints, 1)
+			dp := hist.DataPoints[0]
+			require.Equal(t, uint64(4), dp.Count)
+			require.Equal(t, float64(1+20000+30000+1e12), dp.Sum)
+			require.Equal(t, IOLatencyBuckets, dp.ExplicitBounds)
+			expCounts := make([]uint64, len(IOLatencyBuckets)+1)
+			expCounts[0] = 1                     // 1 <= 10µs
+			expCounts[1] = 1                     // 20000 <= 26.8µs
+			expCounts[2] = 1                     // 30000 <= 71.9µs
+			expCounts[len(IOLatencyBuckets)] = 1 // 1e12 > 10s
+			require.Equal(t, expCounts, dp.BucketCounts)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		pm.ScrapeRegistry(r, true /* includeChildMetrics */)
+		require.Error(t, oe.Push(ctx, httpServer.URL+"/unknown", nil))
+		// The metrics are cleared even if the push fails.
+		for _, family := range pm.families {
+			require.Empty(t, family.Metric)
+		}
+	})
+}
+
+func TestParseOTLPEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		httpURL  string
+		grpcAddr string
+		err      string
+	}{
+		{endpoint: "localhost", grpcAddr: "localhost:4317"},
+		{endpoint: "localhost:1234", grpcAddr: "localhost:1234"},
+		{endpoint: "[::1]", grpcAddr: "[::1]:4317"},
+		{endpoint: "http://localhost:4318", httpURL: "http://localhost:4318/v1/metrics"},
+		{endpoint: "https://collector/", httpURL: "https://collector/v1/metrics"},
+		{endpoint: "https://collector/otlp/metrics", httpURL: "https://collector/otlp/metrics"},
+		{endpoint: "http://", err: "missing host"},
+		{endpoint: "::1", err: "invalid address format"},
+	} {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			httpURL, grpcAddr, err := parseOTLPEndpoint(tc.endpoint)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.httpURL, httpURL)
+			require.Equal(t, tc.grpcAddr, grpcAddr)
+		})
+	}
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		headers string
+		exp     map[string]string
+		err     string
+	}{
+		{headers: "", exp: map[string]string{}},
+		{headers: "a=b", exp: map[string]string{"a": "b"}},
+		{headers: " a = b , c=d%3De,", exp: map[string]string{"a": "b", "c": "d=e"}},
+		{headers: "authorization=Basic%20dXNlcg==", exp: map[string]string{"authorization": "Basic dXNlcg=="}},
+		{headers: "a", err: "expected key=value"},
+		{headers: "=b", err: "empty key"},
+		{headers: "a=%zz", err: "invalid header"},
+	} {
+		t.Run(tc.headers, func(t *testing.T) {
+			headers, err := ParseOTLPHeaders(tc.headers)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, headers)
+		})
+	}
+}
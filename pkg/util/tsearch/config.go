@@ -0,0 +1,361 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file defines the text search configurations, which control how a
+// document or query string is broken into tokens, and how each token is then
+// normalized into a lexeme. A configuration can stem tokens and drop stop
+// words - common words like "the" or "and" which aren't useful to search for.
+
+// DefaultConfig is the default text search configuration, which is used by
+// the text search builtins when no configuration is provided.
+const DefaultConfig = "english"
+
+// maxTSVectorPosition is the largest position that can be stored in a
+// TSVector. Larger positions are clamped to this value.
+const maxTSVectorPosition = 1<<14 - 1
+
+// maxTSLexemeLength is the length, in bytes, past which words are ignored
+// when constructing a TSVector or TSQuery from text.
+const maxTSLexemeLength = 2047
+
+// textSearchConfig is a text search configuration.
+type textSearchConfig struct {
+	// stem, if set, is used to stem tokens made up only of ASCII letters.
+	stem func(string) string
+	// stopWords is the set of words that are removed from documents and
+	// queries. It may be nil.
+	stopWords map[string]struct{}
+}
+
+var textSearchConfigs = map[string]*textSearchConfig{
+	"simple": {},
+	"english": {
+		stem:      stemEnglish,
+		stopWords: englishStopWords,
+	},
+}
+
+// getConfig returns the text search configuration with the given name, which
+// may be qualified with the pg_catalog schema.
+func getConfig(name string) (*textSearchConfig, error) {
+	name = strings.TrimPrefix(name, "pg_catalog.")
+	if config, ok := textSearchConfigs[name]; ok {
+		return config, nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", name)
+}
+
+// ValidConfig returns an error if the given name doesn't refer to a text
+// search configuration.
+func ValidConfig(name string) error {
+	_, err := getConfig(name)
+	return err
+}
+
+// Configs returns the names of the supported text search configurations, in
+// sorted order.
+func Configs() []string {
+	ret := make([]string, 0, len(textSearchConfigs))
+	for name := range textSearchConfigs {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// normalize returns the lexeme for the given token, or the empty string if
+// the token is a stop word or is too long to be indexed.
+func (c *textSearchConfig) normalize(token string) string {
+	if len(token) > maxTSLexemeLength {
+		return ""
+	}
+	lexeme := strings.ToLower(token)
+	if _, ok := c.stopWords[lexeme]; ok {
+		return ""
+	}
+	if c.stem != nil && isASCIILetters(lexeme) {
+		lexeme = c.stem(lexeme)
+	}
+	return lexeme
+}
+
+func isASCIILetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize splits the input into its words: runs of letters and digits. All
+// other characters are treated as separators.
+func tokenize(input string) []string {
+	return strings.FieldsFunc(input, func(r rune) bool { return !isTokenRune(r) })
+}
+
+// ToTSVector parses the input text into a TSVector, using the given text
+// search configuration to normalize each word. Each word in the input
+// occupies a position, including stop words that aren't included in the
+// result.
+func ToTSVector(config string, input string) (TSVector, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	tokens := tokenize(input)
+	ret := make(TSVector, 0, len(tokens))
+	for i, token := range tokens {
+		lexeme := c.normalize(token)
+		if lexeme == "" {
+			continue
+		}
+		pos := i + 1
+		if pos > maxTSVectorPosition {
+			pos = maxTSVectorPosition
+		}
+		ret = append(ret, tsTerm{lexeme: lexeme, positions: []tsPosition{{position: pos}}})
+	}
+	return normalizeTSVector(ret), nil
+}
+
+// ToTSQuery parses the input as a TSQuery, using the given text search
+// configuration to normalize each of its terms. Terms that consist only of
+// stop words are removed from the query.
+func ToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	terms, err := lexTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	if len(terms) == 0 {
+		return TSQuery{}, nil
+	}
+	queryParser := tsQueryParser{terms: terms, input: input}
+	q, err := queryParser.parse()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	root, _, _ := c.normalizeNode(q.root)
+	return TSQuery{root: root}, nil
+}
+
+// normalizeNode normalizes all of the terms in the query tree rooted at the
+// input node, returning the new tree. Since a term may be a stop word, the
+// resultant tree may be nil.
+//
+// Removing a term from a followed by operator must preserve the distance
+// between the remaining terms, so normalizeNode also returns the number of
+// positions that were removed from the left and right edges of the tree. If
+// the returned tree is nil, both are set to the width of the removed tree.
+func (c *textSearchConfig) normalizeNode(n *tsNode) (_ *tsNode, lGap, rGap int) {
+	switch n.op {
+	case invalid:
+		// A term may consist of multiple words, which must follow each other.
+		return c.phraseNode(tokenize(n.term.lexeme), n.term.positions), 0, 0
+	case not:
+		l, lGap, rGap := c.normalizeNode(n.l)
+		if l == nil {
+			return nil, lGap, rGap
+		}
+		return &tsNode{op: not, l: l}, lGap, rGap
+	}
+
+	l, llGap, lrGap := c.normalizeNode(n.l)
+	r, rlGap, rrGap := c.normalizeNode(n.r)
+	if n.op == followedby {
+		switch {
+		case l == nil && r == nil:
+			width := llGap + n.followedN + rlGap
+			return nil, width, width
+		case l == nil:
+			return r, llGap + n.followedN + rlGap, rrGap
+		case r == nil:
+			return l, llGap, lrGap + n.followedN + rlGap
+		}
+		return &tsNode{op: followedby, followedN: n.followedN + lrGap + rlGap, l: l, r: r}, llGap, rrGap
+	}
+	switch {
+	case l == nil && r == nil:
+		width := llGap
+		if rlGap > width {
+			width = rlGap
+		}
+		return nil, width, width
+	case l == nil:
+		return r, rlGap, rrGap
+	case r == nil:
+		return l, llGap, lrGap
+	}
+	return &tsNode{op: n.op, l: l, r: r}, 0, 0
+}
+
+// PlainToTSQuery converts the input text into a TSQuery that matches
+// documents containing all of the normalized words in the text.
+func PlainToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var root *tsNode
+	for _, token := range tokenize(input) {
+		root = c.appendTerm(root, and, token)
+	}
+	return TSQuery{root: root}, nil
+}
+
+// PhraseToTSQuery converts the input text into a TSQuery that matches
+// documents containing the normalized words in the text, in order. Removed
+// stop words are accounted for by the distance between the remaining words.
+func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: c.phraseNode(tokenize(input), nil /* positions */)}, nil
+}
+
+// appendTerm normalizes the token, and if it isn't a stop word, combines it
+// with the input tree using the given operator.
+func (c *textSearchConfig) appendTerm(root *tsNode, op tsOperator, token string) *tsNode {
+	lexeme := c.normalize(token)
+	if lexeme == "" {
+		return root
+	}
+	return combineNodes(root, op, &tsNode{term: tsTerm{lexeme: lexeme}})
+}
+
+// combineNodes combines two query trees, either of which may be nil, with the
+// given operator.
+func combineNodes(l *tsNode, op tsOperator, r *tsNode) *tsNode {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	}
+	ret := &tsNode{op: op, l: l, r: r}
+	if op == followedby {
+		ret.followedN = 1
+	}
+	return ret
+}
+
+// phraseNode returns a chain of followed by operators that matches the given
+// tokens in order, or nil if all of the tokens are stop words. The terms in
+// the chain are given the input positions, which hold their weights.
+func (c *textSearchConfig) phraseNode(tokens []string, positions []tsPosition) *tsNode {
+	var root *tsNode
+	lastPos := 0
+	for i, token := range tokens {
+		lexeme := c.normalize(token)
+		if lexeme == "" {
+			continue
+		}
+		term := &tsNode{term: tsTerm{lexeme: lexeme, positions: positions}}
+		if root == nil {
+			root = term
+		} else {
+			root = &tsNode{op: followedby, followedN: i - lastPos, l: root, r: term}
+		}
+		lastPos = i
+	}
+	return root
+}
+
+// WebSearchToTSQuery converts the input text into a TSQuery using a syntax
+// similar to the one used by web search engines:
+//
+//   - unquoted text is converted to terms separated by & operators.
+//   - "quoted text" is converted to terms separated by followed by operators.
+//   - the word or is converted to an | operator.
+//   - a - is converted to a ! operator.
+//
+// Any other punctuation, including an unmatched quote, is ignored.
+func WebSearchToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	// root is the disjunction of the completed groups, and group is the
+	// conjunction of the terms seen since the last or.
+	var root, group *tsNode
+	var negate, sawOr bool
+	addNode := func(n *tsNode) {
+		if n != nil && negate {
+			n = &tsNode{op: not, l: n}
+		}
+		negate = false
+		if n == nil {
+			return
+		}
+		if sawOr && group != nil {
+			root = combineNodes(root, or, group)
+			group = nil
+		}
+		sawOr = false
+		group = combineNodes(group, and, n)
+	}
+
+	for i := 0; i < len(input); {
+		switch {
+		case input[i] == '"' && strings.IndexByte(input[i+1:], '"') != -1:
+			end := i + 1 + strings.IndexByte(input[i+1:], '"')
+			addNode(c.phraseNode(tokenize(input[i+1:end]), nil /* positions */))
+			i = end + 1
+		case input[i] == '-' && i+1 < len(input) && (input[i+1] == '"' ||
+			isTokenRune(rune(input[i+1])) || input[i+1] >= 0x80):
+			negate = true
+			i++
+		default:
+			end := strings.IndexFunc(input[i:], func(r rune) bool { return !isTokenRune(r) })
+			if end == -1 {
+				end = len(input)
+			} else {
+				end += i
+			}
+			if end == i {
+				// Skip over a single separator rune.
+				_, size := utf8.DecodeRuneInString(input[i:])
+				negate = false
+				i += size
+				continue
+			}
+			word := input[i:end]
+			if strings.EqualFold(word, "or") && !negate {
+				sawOr = true
+			} else {
+				addNode(c.appendTerm(nil, and, word))
+			}
+			i = end
+		}
+	}
+	return TSQuery{root: combineNodes(root, or, group)}, nil
+}
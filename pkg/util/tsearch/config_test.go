@@ -0,0 +1,122 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTSVector(t *testing.T) {
+	tcs := []struct {
+		config   string
+		input    string
+		expected string
+	}{
+		{"english", "", ""},
+		{"english", "a fat cat sat on a mat and ate a fat rat",
+			`'ate':9 'cat':3 'fat':2,11 'mat':7 'rat':12 'sat':4`},
+		{"english", "The Fat Rats", `'fat':2 'rat':3`},
+		{"english", "rats-and-cats 42", `'42':4 'cat':3 'rat':1`},
+		{"pg_catalog.english", "jumping", `'jump':1`},
+		{"simple", "The Fat Rats", `'fat':2 'rats':3 'the':1`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ToTSVector(tc.config, tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v.String())
+		})
+	}
+
+	_, err := ToTSVector("klingon", "foo")
+	require.EqualError(t, err, `text search configuration "klingon" does not exist`)
+}
+
+func TestToTSQuery(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"cat", `'cat'`},
+		{"Fat & Rats", `'fat' & 'rat'`},
+		{"fat & !cows", `'fat' & !'cow'`},
+		{"supernovae:*", `'supernova':*`},
+		{"cats:AB", `'cat':AB`},
+		{"'rats-and-cats'", `'rat' <2> 'cat'`},
+		{"the", ``},
+		{"!the", ``},
+		{"the & cat", `'cat'`},
+		{"(the | a) & cat", `'cat'`},
+		{"cat <-> the <-> dog", `'cat' <2> 'dog'`},
+		{"cat <-> (the | a) <-> dog", `'cat' <2> 'dog'`},
+		{"cat <3> the <-> dog", `'cat' <4> 'dog'`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.String())
+		})
+	}
+}
+
+func TestPlainAndPhraseToTSQuery(t *testing.T) {
+	tcs := []struct {
+		input  string
+		plain  string
+		phrase string
+	}{
+		{"", "", ""},
+		{"The Fat Rats", `'fat' & 'rat'`, `'fat' <-> 'rat'`},
+		{"the cat and rats", `'cat' & 'rat'`, `'cat' <2> 'rat'`},
+		{"the and", ``, ``},
+		{"fat & rats!", `'fat' & 'rat'`, `'fat' <-> 'rat'`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := PlainToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.plain, q.String())
+			q, err = PhraseToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.phrase, q.String())
+		})
+	}
+}
+
+func TestWebSearchToTSQuery(t *testing.T) {
+	// The test cases come from the examples in the Postgres documentation.
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{`fat rat`, `'fat' & 'rat'`},
+		{`"supernovae stars" -crab`, `'supernova' <-> 'star' & !'crab'`},
+		{`"sad cat" or "fat rat"`, `'sad' <-> 'cat' | 'fat' <-> 'rat'`},
+		{`sad cat or fat rat`, `'sad' & 'cat' | 'fat' & 'rat'`},
+		{`signal -"segmentation fault"`, `'signal' & !( 'segment' <-> 'fault' )`},
+		{`""" )( dummy \\ query <->`, `'dummi' & 'queri'`},
+		{`or cat`, `'cat'`},
+		{`cat or`, `'cat'`},
+		{`-the cat`, `'cat'`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := WebSearchToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.String())
+		})
+	}
+}
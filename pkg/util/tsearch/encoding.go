@@ -0,0 +1,384 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// This file defines the binary encodings of TSVector and TSQuery, which are
+// the same as the binary formats that Postgres uses to send these types over
+// the wire, as well as the inverted index encoding of TSVector and the
+// inverted expressions used to search that index with a TSQuery.
+//
+// The TSVector encoding is:
+//   - a uint32 count of lexemes, followed by, for each lexeme:
+//   - the null-terminated lexeme,
+//   - a uint16 count of positions, followed by, for each position:
+//   - a uint16 containing the weight in the top 2 bits (3 for A through 0 for
+//     D) and the position in the bottom 14 bits.
+//
+// The TSQuery encoding is a uint32 count of query items, followed by the items
+// in prefix order, with the right operand of each operator before the left. Each
+// item begins with a type byte. A term item (type 1) is followed by a weight
+// bitmask byte, a prefix-match byte and the null-terminated lexeme. An operator
+// item (type 2) is followed by an operator byte (1 for not, 2 for and, 3 for
+// or and 4 for followed by), and a uint16 distance for followed by operators.
+
+const (
+	tsQueryItemTerm     = 1
+	tsQueryItemOperator = 2
+
+	tsQueryOpNot    = 1
+	tsQueryOpAnd    = 2
+	tsQueryOpOr     = 3
+	tsQueryOpPhrase = 4
+)
+
+// EncodeTSVector encodes a TSVector into the Postgres binary format.
+func EncodeTSVector(appendTo []byte, vector TSVector) ([]byte, error) {
+	appendTo = encoding.EncodeUint32Ascending(appendTo, uint32(len(vector)))
+	for _, t := range vector {
+		if err := checkLexeme(t.lexeme); err != nil {
+			return nil, err
+		}
+		appendTo = append(appendTo, t.lexeme...)
+		appendTo = append(appendTo, 0)
+		appendTo = appendUint16(appendTo, uint16(len(t.positions)))
+		for _, p := range t.positions {
+			appendTo = appendUint16(appendTo, encodeTSPosition(p))
+		}
+	}
+	return appendTo, nil
+}
+
+func appendUint16(appendTo []byte, v uint16) []byte {
+	return append(appendTo, byte(v>>8), byte(v))
+}
+
+func checkLexeme(lexeme string) error {
+	if lexeme == "" || len(lexeme) > maxTSLexemeLength || bytes.IndexByte([]byte(lexeme), 0) != -1 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "invalid lexeme %q", lexeme)
+	}
+	return nil
+}
+
+func encodeTSPosition(p tsPosition) uint16 {
+	var w uint16
+	switch {
+	case p.weight&weightA != 0:
+		w = 3
+	case p.weight&weightB != 0:
+		w = 2
+	case p.weight&weightC != 0:
+		w = 1
+	}
+	pos := p.position
+	if pos > maxTSVectorPosition {
+		pos = maxTSVectorPosition
+	}
+	return w<<14 | uint16(pos)
+}
+
+func decodeTSPosition(p uint16) tsPosition {
+	var w tsWeight
+	switch p >> 14 {
+	case 3:
+		w = weightA
+	case 2:
+		w = weightB
+	case 1:
+		w = weightC
+	}
+	return tsPosition{position: int(p & maxTSVectorPosition), weight: w}
+}
+
+// tsDecoder is a helper for decoding the binary TSVector and TSQuery formats.
+type tsDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *tsDecoder) fail() {
+	if d.err == nil {
+		d.err = pgerror.New(pgcode.InvalidBinaryRepresentation, "invalid text search binary data")
+	}
+	d.b = nil
+}
+
+func (d *tsDecoder) uint8() uint8 {
+	if len(d.b) < 1 {
+		d.fail()
+		return 0
+	}
+	ret := d.b[0]
+	d.b = d.b[1:]
+	return ret
+}
+
+func (d *tsDecoder) uint16() uint16 {
+	if len(d.b) < 2 {
+		d.fail()
+		return 0
+	}
+	ret := binary.BigEndian.Uint16(d.b)
+	d.b = d.b[2:]
+	return ret
+}
+
+func (d *tsDecoder) uint32() uint32 {
+	if len(d.b) < 4 {
+		d.fail()
+		return 0
+	}
+	ret := binary.BigEndian.Uint32(d.b)
+	d.b = d.b[4:]
+	return ret
+}
+
+func (d *tsDecoder) string() string {
+	i := bytes.IndexByte(d.b, 0)
+	if i == -1 {
+		d.fail()
+		return ""
+	}
+	ret := string(d.b[:i])
+	d.b = d.b[i+1:]
+	return ret
+}
+
+// DecodeTSVector decodes a TSVector from the Postgres binary format.
+func DecodeTSVector(b []byte) (TSVector, error) {
+	d := tsDecoder{b: b}
+	n := d.uint32()
+	if int(n) > len(b) {
+		d.fail()
+		return nil, d.err
+	}
+	ret := make(TSVector, 0, n)
+	for i := uint32(0); i < n && d.err == nil; i++ {
+		t := tsTerm{lexeme: d.string()}
+		nPos := d.uint16()
+		for j := uint16(0); j < nPos && d.err == nil; j++ {
+			t.positions = append(t.positions, decodeTSPosition(d.uint16()))
+		}
+		ret = append(ret, t)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return normalizeTSVector(ret), nil
+}
+
+// EncodeTSQuery encodes a TSQuery into the Postgres binary format.
+func EncodeTSQuery(appendTo []byte, query TSQuery) ([]byte, error) {
+	appendTo = encoding.EncodeUint32Ascending(appendTo, uint32(query.NumNodes()))
+	if query.root == nil {
+		return appendTo, nil
+	}
+	return encodeTSNode(appendTo, query.root)
+}
+
+func encodeTSNode(appendTo []byte, n *tsNode) ([]byte, error) {
+	var err error
+	switch n.op {
+	case invalid:
+		if err := checkLexeme(n.term.lexeme); err != nil {
+			return nil, err
+		}
+		var weight tsWeight
+		if len(n.term.positions) > 0 {
+			weight = n.term.positions[0].weight
+		}
+		var prefix byte
+		if weight&weightStar != 0 {
+			prefix = 1
+		}
+		appendTo = append(appendTo, tsQueryItemTerm, byte(weight&^weightStar), prefix)
+		appendTo = append(appendTo, n.term.lexeme...)
+		return append(appendTo, 0), nil
+	case not:
+		appendTo = append(appendTo, tsQueryItemOperator, tsQueryOpNot)
+		return encodeTSNode(appendTo, n.l)
+	case and:
+		appendTo = append(appendTo, tsQueryItemOperator, tsQueryOpAnd)
+	case or:
+		appendTo = append(appendTo, tsQueryItemOperator, tsQueryOpOr)
+	case followedby:
+		appendTo = append(appendTo, tsQueryItemOperator, tsQueryOpPhrase)
+		appendTo = appendUint16(appendTo, uint16(n.followedN))
+	default:
+		return nil, errors.AssertionFailedf("invalid operator %d", n.op)
+	}
+	if appendTo, err = encodeTSNode(appendTo, n.r); err != nil {
+		return nil, err
+	}
+	return encodeTSNode(appendTo, n.l)
+}
+
+// DecodeTSQuery decodes a TSQuery from the Postgres binary format.
+func DecodeTSQuery(b []byte) (TSQuery, error) {
+	d := tsDecoder{b: b}
+	n := d.uint32()
+	if n == 0 || d.err != nil {
+		return TSQuery{}, d.err
+	}
+	remaining := int(n)
+	root := d.node(&remaining)
+	if d.err == nil && (remaining != 0 || len(d.b) != 0) {
+		d.fail()
+	}
+	if d.err != nil {
+		return TSQuery{}, d.err
+	}
+	return TSQuery{root: root}, nil
+}
+
+// node decodes a single query node, decrementing remaining by the number of
+// items that it consumed.
+func (d *tsDecoder) node(remaining *int) *tsNode {
+	if *remaining <= 0 {
+		d.fail()
+	}
+	*remaining--
+	switch d.uint8() {
+	case tsQueryItemTerm:
+		weight := tsWeight(d.uint8()) & (weightA | weightB | weightC | weightD)
+		if d.uint8() != 0 {
+			weight |= weightStar
+		}
+		t := tsTerm{lexeme: d.string()}
+		if weight != 0 {
+			t.positions = []tsPosition{{weight: weight}}
+		}
+		return &tsNode{term: t}
+	case tsQueryItemOperator:
+		ret := &tsNode{}
+		switch d.uint8() {
+		case tsQueryOpNot:
+			ret.op = not
+			ret.l = d.node(remaining)
+			return ret
+		case tsQueryOpAnd:
+			ret.op = and
+		case tsQueryOpOr:
+			ret.op = or
+		case tsQueryOpPhrase:
+			ret.op = followedby
+			ret.followedN = int(d.uint16())
+		default:
+			d.fail()
+			return nil
+		}
+		ret.r = d.node(remaining)
+		ret.l = d.node(remaining)
+		return ret
+	}
+	d.fail()
+	return nil
+}
+
+// EncodeInvertedIndexKeys returns the inverted index keys for the input
+// TSVector: one key per lexeme, each of which is prefixed with inKey.
+func EncodeInvertedIndexKeys(inKey []byte, vector TSVector) ([][]byte, error) {
+	outKeys := make([][]byte, 0, len(vector))
+	for i := range vector {
+		// Make sure that each key has its own backing array.
+		outKey := make([]byte, len(inKey), len(inKey)+len(vector[i].lexeme)+3)
+		copy(outKey, inKey)
+		outKeys = append(outKeys, encoding.EncodeStringAscending(outKey, vector[i].lexeme))
+	}
+	return outKeys, nil
+}
+
+// GetInvertedExpr returns the inverted expression that can be used to search
+// an inverted index on a TSVector column for documents that could match the
+// query. An error is returned if the query can't be used to search the index,
+// which is the case when it could match documents that contain none of the
+// query's lexemes, like !'cat'.
+func (q TSQuery) GetInvertedExpr() (inverted.Expression, error) {
+	if q.root == nil {
+		return nil, errors.New("unable to search an inverted index with an empty query")
+	}
+	expr := q.root.invertedExpr()
+	if expr == nil {
+		return nil, errors.New("unable to search an inverted index with a negated query")
+	}
+	return expr, nil
+}
+
+// invertedExpr returns the inverted expression for the node, or nil if the
+// node can't be used to constrain an index search.
+func (n *tsNode) invertedExpr() inverted.Expression {
+	switch n.op {
+	case invalid:
+		key := encoding.EncodeStringAscending(nil, n.term.lexeme)
+		span := inverted.MakeSingleValSpan(key)
+		// A term with a weight restriction must be re-checked against the
+		// document, since the index doesn't store weights.
+		tight := true
+		if len(n.term.positions) > 0 {
+			weight := n.term.positions[0].weight
+			if weight&weightStar != 0 {
+				// Strip the string terminator to search for all of the lexemes that
+				// begin with the prefix.
+				prefix := key[:len(key)-2]
+				span = inverted.Span{Start: prefix, End: keysbase.PrefixEnd(prefix)}
+			}
+			tight = weight&^weightStar == 0
+		}
+		ret := inverted.ExprForSpan(span, tight)
+		// A document contains each of its lexemes once, so a single lexeme
+		// produces no duplicate primary keys.
+		ret.Unique = span.IsSingleVal()
+		return ret
+	case not:
+		return nil
+	case or:
+		l, r := n.l.invertedExpr(), n.r.invertedExpr()
+		if l == nil || r == nil {
+			return nil
+		}
+		return inverted.Or(l, r)
+	case and, followedby:
+		l, r := n.l.invertedExpr(), n.r.invertedExpr()
+		var ret inverted.Expression
+		switch {
+		case l == nil && r == nil:
+			return nil
+		case l == nil:
+			// 'cat' & !'dog' matches a subset of the documents that contain
+			// 'cat'.
+			ret = r
+			ret.SetNotTight()
+		case r == nil:
+			ret = l
+			ret.SetNotTight()
+		default:
+			ret = inverted.And(l, r)
+		}
+		if n.op == followedby {
+			// The index doesn't store positions, so followed by matches must be
+			// re-checked against the document.
+			ret.SetNotTight()
+		}
+		return ret
+	}
+	return nil
+}
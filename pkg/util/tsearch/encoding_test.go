@@ -0,0 +1,161 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTSVector(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`a`,
+		`a:1A,2 b:3C cat dog:4B`,
+		`'foo bar':16383 'baz':1,2,3,4,5`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := ParseTSVector(s)
+			require.NoError(t, err)
+			b, err := EncodeTSVector(nil, v)
+			require.NoError(t, err)
+			decoded, err := DecodeTSVector(b)
+			require.NoError(t, err)
+			assert.Equal(t, v.String(), decoded.String())
+		})
+	}
+
+	// The encoding matches the Postgres binary format.
+	v, err := ParseTSVector(`a:1A,2 b`)
+	require.NoError(t, err)
+	b, err := EncodeTSVector(nil, v)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0, 0, 0, 2,
+		'a', 0, 0, 2, 0xc0, 1, 0, 2,
+		'b', 0, 0, 0,
+	}, b)
+
+	_, err = DecodeTSVector(b[:len(b)-1])
+	require.EqualError(t, err, "invalid text search binary data")
+}
+
+func TestEncodeTSQuery(t *testing.T) {
+	for _, s := range []string{
+		`a`,
+		`a:AB & !(b:* | c) <2> d`,
+		`!a | b:*A <-> c`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			q, err := ParseTSQuery(s)
+			require.NoError(t, err)
+			b, err := EncodeTSQuery(nil, q)
+			require.NoError(t, err)
+			decoded, err := DecodeTSQuery(b)
+			require.NoError(t, err)
+			assert.Equal(t, q.String(), decoded.String())
+		})
+	}
+
+	// The encoding matches the Postgres binary format.
+	q, err := ParseTSQuery(`a & b:*`)
+	require.NoError(t, err)
+	b, err := EncodeTSQuery(nil, q)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0, 0, 0, 3,
+		2, 2,
+		1, 0, 1, 'b', 0,
+		1, 0, 0, 'a', 0,
+	}, b)
+
+	_, err = DecodeTSQuery(b[:len(b)-2])
+	require.EqualError(t, err, "invalid text search binary data")
+}
+
+func TestEncodeRandom(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	for i := 0; i < 1000; i++ {
+		v := RandomTSVector(rng)
+		encoded, err := EncodeTSVector(nil, v)
+		require.NoError(t, err)
+		decodedV, err := DecodeTSVector(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, v.String(), decodedV.String())
+		parsedV, err := ParseTSVector(v.String())
+		require.NoError(t, err)
+		assert.Equal(t, 0, v.Compare(parsedV))
+
+		q := RandomTSQuery(rng)
+		encoded, err = EncodeTSQuery(nil, q)
+		require.NoError(t, err)
+		decodedQ, err := DecodeTSQuery(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, q.String(), decodedQ.String())
+		parsedQ, err := ParseTSQuery(q.String())
+		require.NoError(t, err)
+		assert.Equal(t, q.String(), parsedQ.String())
+	}
+}
+
+func TestGetInvertedExpr(t *testing.T) {
+	tcs := []struct {
+		query string
+		ok    bool
+		tight bool
+	}{
+		{`a`, true, true},
+		{`a:*`, true, true},
+		{`a & b`, true, true},
+		{`a | b:*`, true, true},
+		{`a:B`, true, false},
+		{`a <-> b`, true, false},
+		{`a & !b`, true, false},
+		{`!a`, false, false},
+		{`a | !b`, false, false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			expr, err := q.GetInvertedExpr()
+			if !tc.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.tight, expr.IsTight())
+		})
+	}
+}
+
+func TestEncodeInvertedIndexKeys(t *testing.T) {
+	v, err := ParseTSVector(`cat:1 dog:2,3`)
+	require.NoError(t, err)
+	keys, err := EncodeInvertedIndexKeys([]byte{1}, v)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	// Each of the vector's lexemes is found by a query for it.
+	for i, lexeme := range []string{"cat", "dog"} {
+		q, err := ParseTSQuery(lexeme)
+		require.NoError(t, err)
+		expr, err := q.GetInvertedExpr()
+		require.NoError(t, err)
+		ok, err := expr.(*inverted.SpanExpression).ContainsKeys([][]byte{keys[i][1:]})
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
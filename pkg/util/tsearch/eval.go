@@ -34,6 +34,10 @@ type tsEvaluator struct {
 }
 
 func (e *tsEvaluator) eval() (bool, error) {
+	if e.q.root == nil {
+		// An empty query doesn't match any documents.
+		return false, nil
+	}
 	return e.evalNode(e.q.root)
 }
 
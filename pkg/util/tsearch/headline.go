@@ -0,0 +1,277 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_headline, which produces an excerpt of a document
+// with the words that match a query highlighted.
+
+// headlineOptions are the options that control the output of Headline. See
+// parseHeadlineOptions for their meaning.
+type headlineOptions struct {
+	startSel     string
+	stopSel      string
+	maxWords     int
+	minWords     int
+	shortWord    int
+	highlightAll bool
+}
+
+// parseHeadlineOptions parses a comma-separated list of Headline options, of
+// the form name=value. The supported options are:
+//
+//   - StartSel, StopSel: the strings with which to delimit query words in the
+//     document. They default to <b> and </b>.
+//   - MaxWords, MinWords: the longest and shortest headlines to output, in
+//     words. They default to 35 and 15.
+//   - ShortWord: words of this length or less are dropped from the end of a
+//     headline, unless they match the query. It defaults to 3.
+//   - HighlightAll: if true, the whole document is used as the headline,
+//     ignoring the preceding three options.
+func parseHeadlineOptions(options string) (headlineOptions, error) {
+	ret := headlineOptions{
+		startSel:  "<b>",
+		stopSel:   "</b>",
+		maxWords:  35,
+		minWords:  15,
+		shortWord: 3,
+	}
+	for _, opt := range strings.Split(options, ",") {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(opt, "=")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		var intValue *int
+		switch strings.ToLower(name) {
+		case "startsel":
+			ret.startSel = value
+		case "stopsel":
+			ret.stopSel = value
+		case "maxwords":
+			intValue = &ret.maxWords
+		case "minwords":
+			intValue = &ret.minWords
+		case "shortword":
+			intValue = &ret.shortWord
+		case "highlightall":
+			switch strings.ToLower(value) {
+			case "1", "on", "true", "t", "y", "yes":
+				ret.highlightAll = true
+			default:
+				ret.highlightAll = false
+			}
+		case "maxfragments", "fragmentdelimiter":
+			return ret, pgerror.Newf(pgcode.FeatureNotSupported,
+				"headline parameter %q is not supported", name)
+		default:
+			return ret, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized headline parameter: %q", name)
+		}
+		if intValue != nil {
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return ret, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value for headline parameter %q: %q", name, value)
+			}
+			*intValue = i
+		}
+	}
+	if !ret.highlightAll {
+		if ret.minWords >= ret.maxWords {
+			return ret, pgerror.New(pgcode.InvalidParameterValue,
+				"MinWords should be less than MaxWords")
+		}
+		if ret.minWords <= 0 {
+			return ret, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be positive")
+		}
+		if ret.shortWord < 0 {
+			return ret, pgerror.New(pgcode.InvalidParameterValue,
+				"ShortWord should be >= 0")
+		}
+	}
+	return ret, nil
+}
+
+// headlineWord is a word within a document.
+type headlineWord struct {
+	// start and end are the byte offsets of the word in the document.
+	start, end int
+	// lexeme is the normalized word, or empty if the word is a stop word.
+	lexeme string
+	// matched is true if the word matches one of the query's terms.
+	matched bool
+}
+
+// Headline returns an excerpt of the document with the words that match the
+// query highlighted, using the given text search configuration to normalize
+// the document's words, and the options described in parseHeadlineOptions.
+//
+// The excerpt is the shortest sequence of words that contains each of the
+// query terms that appear in the document, extended to MinWords words
+// (first to the right and then to the left) or shortened to MaxWords words.
+func Headline(config string, document string, q TSQuery, options string) (string, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return "", err
+	}
+	opts, err := parseHeadlineOptions(options)
+	if err != nil {
+		return "", err
+	}
+	terms := q.positiveTerms()
+	var words []headlineWord
+	for i := 0; i < len(document); {
+		r, size := utf8.DecodeRuneInString(document[i:])
+		if !isTokenRune(r) {
+			i += size
+			continue
+		}
+		end := strings.IndexFunc(document[i:], func(r rune) bool { return !isTokenRune(r) })
+		if end == -1 {
+			end = len(document)
+		} else {
+			end += i
+		}
+		w := headlineWord{start: i, end: end, lexeme: c.normalize(document[i:end])}
+		if w.lexeme != "" {
+			for _, t := range terms {
+				if w.lexeme == t.lexeme ||
+					(len(t.positions) > 0 && t.positions[0].weight&weightStar != 0 &&
+						strings.HasPrefix(w.lexeme, t.lexeme)) {
+					w.matched = true
+					break
+				}
+			}
+		}
+		words = append(words, w)
+		i = end
+	}
+	if len(words) == 0 {
+		return document, nil
+	}
+
+	// Find the range of words, and the range of the document, to output.
+	first, last := 0, len(words)-1
+	docStart, docEnd := 0, len(document)
+	if !opts.highlightAll {
+		first, last = headlineCover(words)
+		if last-first+1 > opts.maxWords {
+			last = first + opts.maxWords - 1
+		}
+		for last-first+1 < opts.minWords && last < len(words)-1 {
+			last++
+		}
+		for last-first+1 < opts.minWords && first > 0 {
+			first--
+		}
+		for last > first && !words[last].matched && words[last].end-words[last].start <= opts.shortWord {
+			last--
+		}
+		// Include the separators at the document's edges if the headline
+		// reaches them.
+		docStart, docEnd = words[first].start, words[last].end
+		if first == 0 {
+			docStart = 0
+		}
+		if last == len(words)-1 {
+			docEnd = len(document)
+		}
+	}
+
+	var buf strings.Builder
+	pos := docStart
+	for _, w := range words[first : last+1] {
+		if !w.matched {
+			continue
+		}
+		buf.WriteString(document[pos:w.start])
+		buf.WriteString(opts.startSel)
+		buf.WriteString(document[w.start:w.end])
+		buf.WriteString(opts.stopSel)
+		pos = w.end
+	}
+	buf.WriteString(document[pos:docEnd])
+	return buf.String(), nil
+}
+
+// headlineCover returns the shortest range of words that contains a match for
+// each of the distinct lexemes that are matched within the document. If no
+// words are matched, it returns an empty range at the start of the document.
+func headlineCover(words []headlineWord) (first, last int) {
+	distinct := make(map[string]int)
+	for _, w := range words {
+		if w.matched {
+			distinct[w.lexeme] = 0
+		}
+	}
+	if len(distinct) == 0 {
+		return 0, -1
+	}
+	// Find the shortest window using two pointers: counts holds the number of
+	// times each matched lexeme occurs within [l, r].
+	first, last = 0, len(words)-1
+	found := 0
+	l := 0
+	for r := range words {
+		if words[r].matched {
+			if distinct[words[r].lexeme] == 0 {
+				found++
+			}
+			distinct[words[r].lexeme]++
+		}
+		for found == len(distinct) {
+			if r-l < last-first {
+				first, last = l, r
+			}
+			if words[l].matched {
+				distinct[words[l].lexeme]--
+				if distinct[words[l].lexeme] == 0 {
+					found--
+				}
+			}
+			l++
+		}
+	}
+	return first, last
+}
+
+// positiveTerms returns the terms of the query that aren't negated.
+func (q TSQuery) positiveTerms() []tsTerm {
+	var ret []tsTerm
+	var collect func(n *tsNode)
+	collect = func(n *tsNode) {
+		switch n.op {
+		case invalid:
+			ret = append(ret, n.term)
+		case not:
+		default:
+			collect(n.l)
+			collect(n.r)
+		}
+	}
+	if q.root != nil {
+		collect(q.root)
+	}
+	return ret
+}
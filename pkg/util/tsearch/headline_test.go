@@ -0,0 +1,69 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadline(t *testing.T) {
+	const doc = "The most common type of search is to find all documents containing " +
+		"given query terms and return them in order of their similarity to the query."
+	tcs := []struct {
+		doc      string
+		query    string
+		options  string
+		expected string
+	}{
+		{doc, "query & similarity", "",
+			"containing given <b>query</b> terms and return them in order of their " +
+				"<b>similarity</b> to the <b>query</b>."},
+		{doc, "query & similarity", "StartSel = <, StopSel = >, MaxWords = 5, MinWords = 2",
+			"<similarity> to the <query>."},
+		{doc, "search:*", "MaxWords=4, MinWords=3",
+			"<b>search</b>"},
+		{doc, "search:*", "MaxWords=4, MinWords=3, ShortWord=1",
+			"<b>search</b> is to"},
+		{doc, "nothing", "MinWords=3, MaxWords=10", "The most common"},
+		{"A cat, a hat.", "cat | hat", `HighlightAll=true, StartSel="[", StopSel="]"`,
+			"A [cat], a [hat]."},
+		{"", "cat", "", ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ToTSQuery("english", tc.query)
+			require.NoError(t, err)
+			h, err := Headline("english", tc.doc, q, tc.options)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, h)
+		})
+	}
+}
+
+func TestHeadlineOptionsError(t *testing.T) {
+	for _, tc := range []struct {
+		options string
+		err     string
+	}{
+		{"Foo=1", `unrecognized headline parameter: "Foo"`},
+		{"MaxWords=a", `invalid value for headline parameter "MaxWords": "a"`},
+		{"MinWords=10, MaxWords=5", "MinWords should be less than MaxWords"},
+		{"MinWords=0", "MinWords should be positive"},
+	} {
+		t.Run(tc.options, func(t *testing.T) {
+			_, err := Headline("english", "doc", TSQuery{}, tc.options)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
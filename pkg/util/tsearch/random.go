@@ -0,0 +1,70 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "math/rand"
+
+// randomLexeme returns a short random lexeme. The alphabet is kept small so
+// that random TSVectors and TSQueries are likely to share lexemes.
+func randomLexeme(rng *rand.Rand) string {
+	const alphabet = "abcde"
+	b := make([]byte, 1+rng.Intn(3))
+	for i := range b {
+		b[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// RandomTSVector returns a random TSVector for testing.
+func RandomTSVector(rng *rand.Rand) TSVector {
+	weights := []tsWeight{0, weightC, weightB, weightA}
+	ret := make(TSVector, rng.Intn(10))
+	for i := range ret {
+		ret[i].lexeme = randomLexeme(rng)
+		for j, n := 0, rng.Intn(4); j < n; j++ {
+			ret[i].positions = append(ret[i].positions, tsPosition{
+				position: 1 + rng.Intn(100),
+				weight:   weights[rng.Intn(len(weights))],
+			})
+		}
+	}
+	return normalizeTSVector(ret)
+}
+
+// RandomTSQuery returns a random, non-empty TSQuery for testing.
+func RandomTSQuery(rng *rand.Rand) TSQuery {
+	return TSQuery{root: randomTSNode(rng, 3 /* depth */)}
+}
+
+func randomTSNode(rng *rand.Rand, depth int) *tsNode {
+	if depth == 0 || rng.Intn(3) == 0 {
+		n := &tsNode{term: tsTerm{lexeme: randomLexeme(rng)}}
+		if rng.Intn(5) == 0 {
+			n.term.positions = []tsPosition{{weight: weightStar}}
+		}
+		return n
+	}
+	switch rng.Intn(4) {
+	case 0:
+		return &tsNode{op: not, l: randomTSNode(rng, depth-1)}
+	case 1:
+		return &tsNode{op: and, l: randomTSNode(rng, depth-1), r: randomTSNode(rng, depth-1)}
+	case 2:
+		return &tsNode{op: or, l: randomTSNode(rng, depth-1), r: randomTSNode(rng, depth-1)}
+	default:
+		return &tsNode{
+			op:        followedby,
+			followedN: rng.Intn(4),
+			l:         randomTSNode(rng, depth-1),
+			r:         randomTSNode(rng, depth-1),
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_rank, which ranks how well a TSVector matches a
+// TSQuery. The implementation follows Postgres's calc_rank closely, including
+// its mix of single and double precision arithmetic, so that the results are
+// the same.
+
+// Normalization flags for Rank, which control how the rank of a document is
+// adjusted for its length. They may be combined.
+const (
+	// RankNormLogLength divides the rank by 1 + the logarithm of the document
+	// length.
+	RankNormLogLength = 1 << iota
+	// RankNormLength divides the rank by the document length.
+	RankNormLength
+	// Flag 4 divides the rank by the mean harmonic distance between extents, and
+	// is only used by ts_rank_cd.
+	_
+	// RankNormUniq divides the rank by the number of unique words in the
+	// document.
+	RankNormUniq
+	// RankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	RankNormLogUniq
+	// RankNormRDivRPlus1 divides the rank by itself + 1.
+	RankNormRDivRPlus1
+)
+
+// DefaultRankWeights are the weights used by Rank for lexemes with weights D,
+// C, B and A, respectively, when no weights are provided.
+var DefaultRankWeights = [4]float32{0.1, 0.2, 0.4, 1.0}
+
+// rankWeights holds the weight of each of the 4 lexeme weights, indexed by
+// the weight's binary encoding (0 for D through 3 for A).
+type rankWeights [4]float32
+
+func (w *rankWeights) weight(p tsPosition) float32 {
+	return w[encodeTSPosition(p)>>14]
+}
+
+// MakeRankWeights validates the input ts_rank weights, which must be 4 values
+// for weights D, C, B and A, respectively. Negative weights are replaced by
+// the default weight.
+func MakeRankWeights(weights []float32) ([4]float32, error) {
+	var ret [4]float32
+	if len(weights) < len(ret) {
+		return ret, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	for i := range ret {
+		w := weights[i]
+		if w < 0 {
+			w = DefaultRankWeights[i]
+		}
+		if w > 1.0 {
+			return ret, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+		ret[i] = w
+	}
+	return ret, nil
+}
+
+// Rank returns the rank of the TSVector for the TSQuery, using the given
+// weights (see MakeRankWeights) and combination of normalization flags.
+func Rank(weights [4]float32, v TSVector, q TSQuery, method int) float32 {
+	if len(v) == 0 || q.root == nil {
+		return 0
+	}
+	w := rankWeights(weights)
+	var res float32
+	if q.root.op == and || q.root.op == followedby {
+		res = w.rankAnd(v, q)
+	} else {
+		res = w.rankOr(v, q)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	if method&RankNormLogLength != 0 {
+		res = float32(float64(res) / (math.Log(float64(v.length()+1)) / math.Log(2.0)))
+	}
+	if method&RankNormLength != 0 {
+		if l := v.length(); l > 0 {
+			res /= float32(l)
+		}
+	}
+	if method&RankNormUniq != 0 {
+		res /= float32(len(v))
+	}
+	if method&RankNormLogUniq != 0 {
+		res = float32(float64(res) / (math.Log(float64(len(v)+1)) / math.Log(2.0)))
+	}
+	if method&RankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return res
+}
+
+// length returns the number of words in the document that the TSVector
+// represents, counting lexemes without positions once.
+func (t TSVector) length() int {
+	ret := 0
+	for i := range t {
+		if len(t[i].positions) == 0 {
+			ret++
+		} else {
+			ret += len(t[i].positions)
+		}
+	}
+	return ret
+}
+
+// rankTerms returns the distinct terms of the query, sorted by lexeme.
+func (q TSQuery) rankTerms() []tsTerm {
+	var terms []tsTerm
+	var collect func(n *tsNode)
+	collect = func(n *tsNode) {
+		if n.op == invalid {
+			terms = append(terms, n.term)
+			return
+		}
+		collect(n.l)
+		if n.r != nil {
+			collect(n.r)
+		}
+	}
+	collect(q.root)
+	sort.SliceStable(terms, func(i, j int) bool { return terms[i].lexeme < terms[j].lexeme })
+	ret := terms[:0]
+	for i := range terms {
+		if i == 0 || terms[i].lexeme != terms[i-1].lexeme {
+			ret = append(ret, terms[i])
+		}
+	}
+	return ret
+}
+
+// findTerms returns the terms of the TSVector that match the query term.
+func (t TSVector) findTerms(term tsTerm) TSVector {
+	i := sort.Search(len(t), func(i int) bool { return t[i].lexeme >= term.lexeme })
+	if len(term.positions) == 0 || term.positions[0].weight&weightStar == 0 {
+		if i < len(t) && t[i].lexeme == term.lexeme {
+			return t[i : i+1]
+		}
+		return nil
+	}
+	j := i
+	for j < len(t) && strings.HasPrefix(t[j].lexeme, term.lexeme) {
+		j++
+	}
+	return t[i:j]
+}
+
+// rankOr is Postgres's calc_rank_or.
+func (w *rankWeights) rankOr(v TSVector, q TSQuery) float32 {
+	terms := q.rankTerms()
+	var res float32
+	for _, term := range terms {
+		for _, entry := range v.findTerms(term) {
+			positions := entry.positions
+			if len(positions) == 0 {
+				positions = []tsPosition{{}}
+			}
+			var resj float32
+			wjm := float32(-1)
+			jm := 0
+			for j, p := range positions {
+				resj += w.weight(p) / float32((j+1)*(j+1))
+				if w.weight(p) > wjm {
+					wjm = w.weight(p)
+					jm = j
+				}
+			}
+			// The sum of 1/i^2 for i = 1 to infinity is pi^2/6.
+			res = float32(float64(res) +
+				float64(wjm+resj-wjm/float32((jm+1)*(jm+1)))/1.64493406685)
+		}
+	}
+	if len(terms) > 0 {
+		res /= float32(len(terms))
+	}
+	return res
+}
+
+// rankAnd is Postgres's calc_rank_and.
+func (w *rankWeights) rankAnd(v TSVector, q TSQuery) float32 {
+	terms := q.rankTerms()
+	if len(terms) < 2 {
+		return w.rankOr(v, q)
+	}
+	// Lexemes without positions are treated as if they were at the largest
+	// possible position.
+	noPositions := []tsPosition{{position: maxTSVectorPosition}}
+	pos := make([][]tsPosition, len(terms))
+	hasNoPositions := make([]bool, len(terms))
+	res := float32(-1)
+	for i, term := range terms {
+		for _, entry := range v.findTerms(term) {
+			pos[i] = entry.positions
+			hasNoPositions[i] = len(pos[i]) == 0
+			if hasNoPositions[i] {
+				pos[i] = noPositions
+			}
+			for k := 0; k < i; k++ {
+				if pos[k] == nil {
+					continue
+				}
+				for _, l := range pos[i] {
+					for _, p := range pos[k] {
+						dist := l.position - p.position
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 {
+							if !hasNoPositions[i] && !hasNoPositions[k] {
+								continue
+							}
+							dist = maxTSVectorPosition + 1
+						}
+						curw := float32(math.Sqrt(float64(w.weight(l) * w.weight(p) * wordDistance(dist))))
+						if res < 0 {
+							res = curw
+						} else {
+							res = float32(1.0 - (1.0-float64(res))*(1.0-float64(curw)))
+						}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// wordDistance returns the weight of two lexemes that occur at the given
+// distance from each other.
+func wordDistance(dist int) float32 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(float32(dist))/1.5-2)))
+}
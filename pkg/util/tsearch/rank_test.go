@@ -0,0 +1,55 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	tcs := []struct {
+		doc      string
+		query    string
+		method   int
+		expected float32
+	}{
+		{"a fat cat sat on a mat", "cat", 0, 0.06079271},
+		{"a fat cat sat on a mat", "cat | dog", 0, 0.030396355},
+		{"a fat cat sat on a mat", "fat & mat", 0, 0.09148999},
+		{"a fat cat sat on a mat", "fat & mat", RankNormLogLength | RankNormRDivRPlus1, 0.03790889},
+		{"a fat cat sat on a mat", "dog", 0, 0},
+		{"", "cat", 0, 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			v, err := ToTSVector("english", tc.doc)
+			require.NoError(t, err)
+			q, err := ToTSQuery("english", tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, Rank(DefaultRankWeights, v, q, tc.method))
+		})
+	}
+}
+
+func TestMakeRankWeights(t *testing.T) {
+	w, err := MakeRankWeights([]float32{-1, 0.5, 0.5, 0.5})
+	require.NoError(t, err)
+	assert.Equal(t, [4]float32{0.1, 0.5, 0.5, 0.5}, w)
+
+	_, err = MakeRankWeights([]float32{0.1, 0.2})
+	require.EqualError(t, err, "array of weight is too short")
+
+	_, err = MakeRankWeights([]float32{0.1, 0.2, 0.3, 1.5})
+	require.EqualError(t, err, "weight out of range")
+}
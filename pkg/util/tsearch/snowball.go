@@ -0,0 +1,347 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "strings"
+
+// This file implements the Snowball English ("Porter2") stemming algorithm,
+// which is the stemmer used by Postgres's english text search configuration.
+// See https://snowballstem.org/algorithms/english/stemmer.html for the
+// specification that this implementation follows.
+
+// englishExceptions are words that are stemmed to a fixed form (or left
+// unchanged) before any of the algorithm's steps run.
+var englishExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// englishPostStep1aExceptions are words that are left unchanged if they are
+// produced by step 1a of the algorithm.
+var englishPostStep1aExceptions = map[string]struct{}{
+	"inning":  {},
+	"outing":  {},
+	"canning": {},
+	"herring": {},
+	"earring": {},
+	"proceed": {},
+	"exceed":  {},
+	"succeed": {},
+}
+
+// englishSuffix is a suffix rule: a suffix that is replaced by repl if the
+// rule's condition holds.
+type englishSuffix struct {
+	suffix string
+	repl   string
+}
+
+// The suffix lists for steps 2, 3 and 4 are ordered longest first, so that the
+// first matching entry is the longest matching suffix.
+var englishStep2Suffixes = []englishSuffix{
+	{"ization", "ize"}, {"ational", "ate"}, {"fulness", "ful"}, {"ousness", "ous"},
+	{"iveness", "ive"}, {"tional", "tion"}, {"biliti", "ble"}, {"lessli", "less"},
+	{"entli", "ent"}, {"ation", "ate"}, {"alism", "al"}, {"aliti", "al"},
+	{"ousli", "ous"}, {"iviti", "ive"}, {"fulli", "ful"}, {"enci", "ence"},
+	{"anci", "ance"}, {"abli", "able"}, {"izer", "ize"}, {"ator", "ate"},
+	{"alli", "al"}, {"bli", "ble"}, {"ogi", "og"}, {"li", ""},
+}
+
+var englishStep3Suffixes = []englishSuffix{
+	{"ational", "ate"}, {"tional", "tion"}, {"alize", "al"}, {"icate", "ic"},
+	{"iciti", "ic"}, {"ative", ""}, {"ical", "ic"}, {"ness", ""}, {"ful", ""},
+}
+
+var englishStep4Suffixes = []string{
+	"ement", "ance", "ence", "able", "ible", "ment", "ant", "ent", "ism", "ate",
+	"iti", "ous", "ive", "ize", "ion", "al", "er", "ic",
+}
+
+// englishStemmer holds the state of a single word being stemmed.
+type englishStemmer struct {
+	b []byte
+	// r1 and r2 are the start offsets of the R1 and R2 regions of the word. They
+	// are equal to the length of the original word if the region is empty.
+	r1, r2 int
+}
+
+// stemEnglish returns the stem of the given lowercase word, which must
+// consist only of ASCII letters.
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	if stem, ok := englishExceptions[word]; ok {
+		return stem
+	}
+	s := englishStemmer{b: []byte(word)}
+	// Mark consonant-like y's as Y, so they aren't treated as vowels.
+	for i := range s.b {
+		if s.b[i] == 'y' && (i == 0 || isEnglishVowel(s.b[i-1])) {
+			s.b[i] = 'Y'
+		}
+	}
+	s.computeRegions()
+
+	s.step1a()
+	if _, ok := englishPostStep1aExceptions[string(s.b)]; ok {
+		return string(s.b)
+	}
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	return strings.ReplaceAll(string(s.b), "Y", "y")
+}
+
+func isEnglishVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// englishRegionStart returns the offset of the region that begins after the
+// first non-vowel that follows a vowel, starting the search at start.
+func englishRegionStart(b []byte, start int) int {
+	for i := start + 1; i < len(b); i++ {
+		if !isEnglishVowel(b[i]) && isEnglishVowel(b[i-1]) {
+			return i + 1
+		}
+	}
+	return len(b)
+}
+
+func (s *englishStemmer) computeRegions() {
+	s.r1 = -1
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(s.b), prefix) {
+			s.r1 = len(prefix)
+			break
+		}
+	}
+	if s.r1 == -1 {
+		s.r1 = englishRegionStart(s.b, 0)
+	}
+	s.r2 = englishRegionStart(s.b, s.r1)
+}
+
+func (s *englishStemmer) hasSuffix(suffix string) bool {
+	return strings.HasSuffix(string(s.b), suffix)
+}
+
+// suffixStart returns the offset at which the given suffix begins.
+func (s *englishStemmer) suffixStart(suffix string) int {
+	return len(s.b) - len(suffix)
+}
+
+func (s *englishStemmer) replaceSuffix(suffix, repl string) {
+	s.b = append(s.b[:s.suffixStart(suffix)], repl...)
+}
+
+func containsEnglishVowel(b []byte) bool {
+	for _, c := range b {
+		if isEnglishVowel(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsInShortSyllable returns whether b ends in a short syllable: a vowel
+// followed by a non-vowel other than w, x or Y and preceded by a non-vowel, or
+// a vowel at the beginning of the word followed by a non-vowel.
+func endsInShortSyllable(b []byte) bool {
+	n := len(b)
+	switch {
+	case n == 2:
+		return isEnglishVowel(b[0]) && !isEnglishVowel(b[1])
+	case n > 2:
+		last := b[n-1]
+		return !isEnglishVowel(b[n-3]) && isEnglishVowel(b[n-2]) && !isEnglishVowel(last) &&
+			last != 'w' && last != 'x' && last != 'Y'
+	}
+	return false
+}
+
+// isShortWord returns whether the word ends in a short syllable and has an
+// empty R1 region.
+func (s *englishStemmer) isShortWord() bool {
+	return s.r1 >= len(s.b) && endsInShortSyllable(s.b)
+}
+
+func (s *englishStemmer) endsInDouble() bool {
+	n := len(s.b)
+	if n < 2 || s.b[n-1] != s.b[n-2] {
+		return false
+	}
+	switch s.b[n-1] {
+	case 'b', 'd', 'f', 'g', 'm', 'n', 'p', 'r', 't':
+		return true
+	}
+	return false
+}
+
+// step1a removes plural suffixes.
+func (s *englishStemmer) step1a() {
+	switch {
+	case s.hasSuffix("sses"):
+		s.replaceSuffix("sses", "ss")
+	case s.hasSuffix("ied"), s.hasSuffix("ies"):
+		// Both suffixes are replaced by i if preceded by more than one letter,
+		// and by ie otherwise.
+		s.b = s.b[:len(s.b)-3]
+		if len(s.b) > 1 {
+			s.b = append(s.b, 'i')
+		} else {
+			s.b = append(s.b, 'i', 'e')
+		}
+	case s.hasSuffix("us"), s.hasSuffix("ss"):
+	case s.hasSuffix("s"):
+		// Delete the s if the preceding part of the word contains a vowel that
+		// is not immediately before the s.
+		if containsEnglishVowel(s.b[:len(s.b)-2]) {
+			s.b = s.b[:len(s.b)-1]
+		}
+	}
+}
+
+// step1b removes -ed and -ing suffixes.
+func (s *englishStemmer) step1b() {
+	switch {
+	case s.hasSuffix("eedly"):
+		if s.suffixStart("eedly") >= s.r1 {
+			s.replaceSuffix("eedly", "ee")
+		}
+		return
+	case s.hasSuffix("eed"):
+		if s.suffixStart("eed") >= s.r1 {
+			s.replaceSuffix("eed", "ee")
+		}
+		return
+	}
+	for _, suffix := range []string{"ingly", "edly", "ing", "ed"} {
+		if !s.hasSuffix(suffix) {
+			continue
+		}
+		stem := s.b[:s.suffixStart(suffix)]
+		if !containsEnglishVowel(stem) {
+			return
+		}
+		s.b = stem
+		switch {
+		case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+			s.b = append(s.b, 'e')
+		case s.endsInDouble():
+			s.b = s.b[:len(s.b)-1]
+		case s.isShortWord():
+			s.b = append(s.b, 'e')
+		}
+		return
+	}
+}
+
+// step1c replaces a trailing y with i if it is preceded by a non-vowel that
+// isn't the first letter of the word.
+func (s *englishStemmer) step1c() {
+	n := len(s.b)
+	if n > 2 && (s.b[n-1] == 'y' || s.b[n-1] == 'Y') && !isEnglishVowel(s.b[n-2]) {
+		s.b[n-1] = 'i'
+	}
+}
+
+func (s *englishStemmer) step2() {
+	for _, suf := range englishStep2Suffixes {
+		if !s.hasSuffix(suf.suffix) {
+			continue
+		}
+		start := s.suffixStart(suf.suffix)
+		if start < s.r1 {
+			return
+		}
+		switch suf.suffix {
+		case "ogi":
+			if start == 0 || s.b[start-1] != 'l' {
+				return
+			}
+		case "li":
+			if start == 0 || !strings.ContainsRune("cdeghkmnrt", rune(s.b[start-1])) {
+				return
+			}
+		}
+		s.replaceSuffix(suf.suffix, suf.repl)
+		return
+	}
+}
+
+func (s *englishStemmer) step3() {
+	for _, suf := range englishStep3Suffixes {
+		if !s.hasSuffix(suf.suffix) {
+			continue
+		}
+		start := s.suffixStart(suf.suffix)
+		if start < s.r1 || (suf.suffix == "ative" && start < s.r2) {
+			return
+		}
+		s.replaceSuffix(suf.suffix, suf.repl)
+		return
+	}
+}
+
+func (s *englishStemmer) step4() {
+	for _, suffix := range englishStep4Suffixes {
+		if !s.hasSuffix(suffix) {
+			continue
+		}
+		start := s.suffixStart(suffix)
+		if start < s.r2 {
+			return
+		}
+		if suffix == "ion" && (start == 0 || (s.b[start-1] != 's' && s.b[start-1] != 't')) {
+			return
+		}
+		s.b = s.b[:start]
+		return
+	}
+}
+
+func (s *englishStemmer) step5() {
+	n := len(s.b)
+	switch {
+	case s.hasSuffix("e"):
+		if n-1 >= s.r2 || (n-1 >= s.r1 && !endsInShortSyllable(s.b[:n-1])) {
+			s.b = s.b[:n-1]
+		}
+	case s.hasSuffix("l"):
+		if n-1 >= s.r2 && n > 1 && s.b[n-2] == 'l' {
+			s.b = s.b[:n-1]
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStemEnglish(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		// Short words and exceptions.
+		{"a", "a"},
+		{"is", "is"},
+		{"skies", "sky"},
+		{"dying", "die"},
+		{"news", "news"},
+		{"innings", "inning"},
+		{"exceeded", "exceed"},
+
+		// Step 1a.
+		{"caresses", "caress"},
+		{"ponies", "poni"},
+		{"ties", "tie"},
+		{"cats", "cat"},
+		{"gas", "gas"},
+		{"gaps", "gap"},
+		{"kiwis", "kiwi"},
+
+		// Step 1b.
+		{"feed", "feed"},
+		{"agreed", "agre"},
+		{"jumping", "jump"},
+		{"running", "run"},
+		{"hoped", "hope"},
+		{"controlling", "control"},
+		{"rolling", "roll"},
+
+		// Step 1c.
+		{"happy", "happi"},
+		{"cry", "cri"},
+		{"say", "say"},
+
+		// Steps 2 through 5.
+		{"consolation", "consol"},
+		{"knackeries", "knackeri"},
+		{"happiness", "happi"},
+		{"generously", "generous"},
+		{"communism", "communism"},
+		{"national", "nation"},
+		{"rationalization", "ration"},
+		{"connection", "connect"},
+		{"abilities", "abil"},
+		{"beautiful", "beauti"},
+		{"hopeful", "hope"},
+		{"conditional", "condit"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stemEnglish(tc.input))
+		})
+	}
+}
@@ -13,6 +13,7 @@ package tsearch
 import (
 	"fmt"
 	"strings"
+	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -223,26 +224,13 @@ func (p *tsQueryParser) parseTSExpr(minBindingPower int) (*tsNode, error) {
 		}
 		lExpr = expr
 	case not:
-		t, ok := p.nextTerm()
-		if !ok {
-			return p.syntaxError()
-		}
-		switch t.operator {
-		case invalid:
-			lExpr = &tsNode{op: not, l: &tsNode{term: *t}}
-		case lparen:
-			expr, err := p.parseTSExpr(0)
-			if err != nil {
-				return nil, err
-			}
-			lExpr = &tsNode{op: not, l: expr}
-			t, ok := p.nextTerm()
-			if !ok || t.operator != rparen {
-				return p.syntaxError()
-			}
-		default:
-			return p.syntaxError()
+		// The operand of a not binds more tightly than any binary operator, and
+		// may itself be negated.
+		expr, err := p.parseTSExpr(not.precedence())
+		if err != nil {
+			return nil, err
 		}
+		lExpr = &tsNode{op: not, l: expr}
 	default:
 		return p.syntaxError()
 	}
@@ -275,3 +263,87 @@ func (p *tsQueryParser) parseTSExpr(minBindingPower int) (*tsNode, error) {
 func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 	return nil, pgerror.Newf(pgcode.Syntax, "syntax error in TSQuery: %s", p.input)
 }
+
+// maxTSQueryFollowedByDistance is the largest distance that can be used with
+// the followed by operator.
+const maxTSQueryFollowedByDistance = 1 << 14
+
+// NumNodes returns the number of terms and operators in the query.
+func (q TSQuery) NumNodes() int {
+	if q.root == nil {
+		return 0
+	}
+	return q.root.numNodes()
+}
+
+func (n *tsNode) numNodes() int {
+	ret := 1
+	if n.l != nil {
+		ret += n.l.numNodes()
+	}
+	if n.r != nil {
+		ret += n.r.numNodes()
+	}
+	return ret
+}
+
+// And returns a query that matches documents that match both the receiver
+// and the input query. If either query is empty, the other is returned.
+func (q TSQuery) And(other TSQuery) TSQuery {
+	return TSQuery{root: combineNodes(q.root, and, other.root)}
+}
+
+// Or returns a query that matches documents that match either the receiver
+// or the input query. If either query is empty, the other is returned.
+func (q TSQuery) Or(other TSQuery) TSQuery {
+	return TSQuery{root: combineNodes(q.root, or, other.root)}
+}
+
+// Not returns a query that matches documents that don't match the receiver.
+func (q TSQuery) Not() TSQuery {
+	if q.root == nil {
+		return q
+	}
+	return TSQuery{root: &tsNode{op: not, l: q.root}}
+}
+
+// FollowedBy returns a query that matches documents in which a match of the
+// receiver is followed by a match of the input query, at the given distance.
+// If either query is empty, the other is returned.
+func (q TSQuery) FollowedBy(other TSQuery, distance int) (TSQuery, error) {
+	if distance < 0 || distance > maxTSQueryFollowedByDistance {
+		return TSQuery{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"distance in phrase operator must be an integer value between zero and %d inclusive",
+			maxTSQueryFollowedByDistance)
+	}
+	if q.root == nil || other.root == nil {
+		return TSQuery{root: combineNodes(q.root, followedby, other.root)}, nil
+	}
+	return TSQuery{root: &tsNode{op: followedby, followedN: distance, l: q.root, r: other.root}}, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether the receiver sorts before,
+// the same as or after the input TSQuery.
+func (q TSQuery) Compare(other TSQuery) int {
+	return strings.Compare(q.String(), other.String())
+}
+
+// MemSize returns the approximate size of the TSQuery in memory, in bytes.
+func (q TSQuery) MemSize() uintptr {
+	if q.root == nil {
+		return 0
+	}
+	return q.root.memSize()
+}
+
+func (n *tsNode) memSize() uintptr {
+	ret := unsafe.Sizeof(*n) + uintptr(len(n.term.lexeme)) +
+		uintptr(len(n.term.positions))*unsafe.Sizeof(tsPosition{})
+	if n.l != nil {
+		ret += n.l.memSize()
+	}
+	if n.r != nil {
+		ret += n.r.memSize()
+	}
+	return ret
+}
@@ -184,6 +184,8 @@ func TestParseTSQuery(t *testing.T) {
 		{`a <-> (d <0> !x | y)`, `'a' <-> ( 'd' <0> !'x' | 'y' )`},
 		{`(a) | b`, `'a' | 'b'`},
 		{`!a | b`, `!'a' | 'b'`},
+		{`!!a`, `!!'a'`},
+		{`!!(a & b) | !c`, `!!( 'a' & 'b' ) | !'c'`},
 
 		{`(b & c) <-> (d <0> !x | y)`, `( 'b' & 'c' ) <-> ( 'd' <0> !'x' | 'y' )`},
 		{`a | (b & c) <-> (d <0> !x | y)`, `'a' | ( 'b' & 'c' ) <-> ( 'd' <0> !'x' | 'y' )`},
@@ -275,3 +277,29 @@ func TestParseTSQueryError(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestTSQueryFunctions(t *testing.T) {
+	a, err := ParseTSQuery(`a`)
+	require.NoError(t, err)
+	bc, err := ParseTSQuery(`b | c`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, a.NumNodes())
+	assert.Equal(t, 3, bc.NumNodes())
+	assert.Equal(t, 0, TSQuery{}.NumNodes())
+
+	assert.Equal(t, `'a' & ( 'b' | 'c' )`, a.And(bc).String())
+	assert.Equal(t, `'a' | 'b' | 'c'`, a.Or(bc).String())
+	assert.Equal(t, `!( 'b' | 'c' )`, bc.Not().String())
+	assert.Equal(t, `'a'`, a.And(TSQuery{}).String())
+	assert.Equal(t, `'a'`, TSQuery{}.Or(a).String())
+
+	phrase, err := a.FollowedBy(bc, 3)
+	require.NoError(t, err)
+	assert.Equal(t, `'a' <3> ( 'b' | 'c' )`, phrase.String())
+	_, err = a.FollowedBy(bc, -1)
+	require.Error(t, err)
+
+	assert.Equal(t, 0, a.Compare(a))
+	assert.Equal(t, -1, a.Compare(bc))
+}
@@ -15,6 +15,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
 // This file defines the TSVector data structure, which is used to implement
@@ -175,7 +179,12 @@ func ParseTSVector(input string) (TSVector, error) {
 	if err != nil {
 		return ret, err
 	}
+	return normalizeTSVector(ret), nil
+}
 
+// normalizeTSVector sorts the input terms by lexeme, merging the position
+// lists of duplicate lexemes, and sorts and de-duplicates each position list.
+func normalizeTSVector(ret TSVector) TSVector {
 	if len(ret) > 1 {
 		// Sort and de-duplicate the resultant TSVector.
 		sort.Slice(ret, func(i, j int) bool {
@@ -207,5 +216,220 @@ func ParseTSVector(input string) (TSVector, error) {
 		lastIdx := len(ret) - 1
 		ret[lastIdx].positions = sortAndUniqTSPositions(ret[lastIdx].positions)
 	}
+	return ret
+}
+
+// parseWeight parses a weight name, A through D, into a tsWeight.
+func parseWeight(weight string) (tsWeight, error) {
+	switch weight {
+	case "A", "a":
+		return weightA, nil
+	case "B", "b":
+		return weightB, nil
+	case "C", "c":
+		return weightC, nil
+	case "D", "d":
+		return weightD, nil
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", weight)
+}
+
+// matchesWeight returns whether the position's weight is one of the weights in
+// the input bitfield.
+func (p tsPosition) matchesWeight(weights tsWeight) bool {
+	w := p.weight
+	if w == 0 {
+		w = weightD
+	}
+	return w&weights != 0
+}
+
+// Lexemes returns the lexemes of the TSVector, in order.
+func (t TSVector) Lexemes() []string {
+	ret := make([]string, len(t))
+	for i := range t {
+		ret[i] = t[i].lexeme
+	}
+	return ret
+}
+
+// ArrayToTSVector returns a TSVector that contains the input lexemes, without
+// any position information.
+func ArrayToTSVector(lexemes []string) (TSVector, error) {
+	ret := make(TSVector, len(lexemes))
+	for i, lexeme := range lexemes {
+		if lexeme == "" {
+			return nil, pgerror.New(pgcode.ZeroLengthCharacterString,
+				"lexeme array may not contain empty strings")
+		}
+		ret[i] = tsTerm{lexeme: lexeme}
+	}
+	return normalizeTSVector(ret), nil
+}
+
+// Strip returns a copy of the TSVector with all of its positions and weights
+// removed.
+func (t TSVector) Strip() TSVector {
+	ret := make(TSVector, len(t))
+	for i := range t {
+		ret[i] = tsTerm{lexeme: t[i].lexeme}
+	}
+	return ret
+}
+
+// SetWeight returns a copy of the TSVector with the weight of all of the
+// positions of the given lexemes set to the given weight. If lexemes is nil,
+// the weight of all of the positions in the TSVector is set.
+func (t TSVector) SetWeight(weight string, lexemes []string) (TSVector, error) {
+	w, err := parseWeight(weight)
+	if err != nil {
+		return nil, err
+	}
+	if w == weightD {
+		// Weight D is the default, and is never stored.
+		w = 0
+	}
+	var filter map[string]struct{}
+	if lexemes != nil {
+		filter = make(map[string]struct{}, len(lexemes))
+		for _, l := range lexemes {
+			filter[l] = struct{}{}
+		}
+	}
+	ret := make(TSVector, len(t))
+	for i := range t {
+		ret[i] = t[i]
+		if filter != nil {
+			if _, ok := filter[t[i].lexeme]; !ok {
+				continue
+			}
+		}
+		ret[i].positions = make([]tsPosition, len(t[i].positions))
+		for j, p := range t[i].positions {
+			ret[i].positions[j] = tsPosition{position: p.position, weight: w}
+		}
+	}
 	return ret, nil
 }
+
+// Delete returns a copy of the TSVector without the given lexemes.
+func (t TSVector) Delete(lexemes ...string) TSVector {
+	remove := make(map[string]struct{}, len(lexemes))
+	for _, l := range lexemes {
+		remove[l] = struct{}{}
+	}
+	ret := make(TSVector, 0, len(t))
+	for i := range t {
+		if _, ok := remove[t[i].lexeme]; !ok {
+			ret = append(ret, t[i])
+		}
+	}
+	return ret
+}
+
+// Filter returns a copy of the TSVector that only contains the positions with
+// one of the given weights. Lexemes that are left without any positions are
+// removed.
+func (t TSVector) Filter(weights []string) (TSVector, error) {
+	var mask tsWeight
+	for _, weight := range weights {
+		w, err := parseWeight(weight)
+		if err != nil {
+			return nil, err
+		}
+		mask |= w
+	}
+	ret := make(TSVector, 0, len(t))
+	for i := range t {
+		var positions []tsPosition
+		for _, p := range t[i].positions {
+			if p.matchesWeight(mask) {
+				positions = append(positions, p)
+			}
+		}
+		if len(positions) > 0 {
+			ret = append(ret, tsTerm{lexeme: t[i].lexeme, positions: positions})
+		}
+	}
+	return ret, nil
+}
+
+// Concat returns the concatenation of the receiver with the input TSVector.
+// The positions of the input are offset by the largest position in the
+// receiver, as if the input's document directly followed the receiver's.
+func (t TSVector) Concat(other TSVector) TSVector {
+	maxPos := 0
+	for i := range t {
+		for _, p := range t[i].positions {
+			if p.position > maxPos {
+				maxPos = p.position
+			}
+		}
+	}
+	ret := make(TSVector, 0, len(t)+len(other))
+	for i := range t {
+		ret = append(ret, tsTerm{
+			lexeme:    t[i].lexeme,
+			positions: append([]tsPosition(nil), t[i].positions...),
+		})
+	}
+	for i := range other {
+		term := tsTerm{lexeme: other[i].lexeme}
+		for _, p := range other[i].positions {
+			p.position += maxPos
+			if p.position > maxTSVectorPosition {
+				p.position = maxTSVectorPosition
+			}
+			term.positions = append(term.positions, p)
+		}
+		ret = append(ret, term)
+	}
+	return normalizeTSVector(ret)
+}
+
+// Compare returns -1, 0 or 1 depending on whether the receiver sorts before,
+// the same as or after the input TSVector.
+func (t TSVector) Compare(other TSVector) int {
+	for i := 0; i < len(t) && i < len(other); i++ {
+		if c := strings.Compare(t[i].lexeme, other[i].lexeme); c != 0 {
+			return c
+		}
+		l, r := t[i].positions, other[i].positions
+		for j := 0; j < len(l) && j < len(r); j++ {
+			if l[j].position != r[j].position {
+				if l[j].position < r[j].position {
+					return -1
+				}
+				return 1
+			}
+			if l[j].weight != r[j].weight {
+				if l[j].weight < r[j].weight {
+					return -1
+				}
+				return 1
+			}
+		}
+		if len(l) != len(r) {
+			if len(l) < len(r) {
+				return -1
+			}
+			return 1
+		}
+	}
+	if len(t) != len(other) {
+		if len(t) < len(other) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// MemSize returns the approximate size of the TSVector in memory, in bytes.
+func (t TSVector) MemSize() uintptr {
+	ret := uintptr(len(t)) * unsafe.Sizeof(tsTerm{})
+	for i := range t {
+		ret += uintptr(len(t[i].lexeme)) + uintptr(len(t[i].positions))*unsafe.Sizeof(tsPosition{})
+	}
+	return ret
+}
@@ -145,3 +145,41 @@ func TestParseTSRandom(t *testing.T) {
 		_, _ = ParseTSQuery(string(b))
 	}
 }
+
+func TestTSVectorFunctions(t *testing.T) {
+	v, err := ParseTSVector(`a:1 b:2,4 c`)
+	require.NoError(t, err)
+	other, err := ParseTSVector(`b:1 d:2A e`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "c"}, v.Lexemes())
+	assert.Equal(t, `'a' 'b' 'c'`, v.Strip().String())
+	assert.Equal(t, `'a':1 'c'`, v.Delete("b", "z").String())
+	assert.Equal(t, `'a':1 'b':2,4,5 'c' 'd':6A 'e'`, v.Concat(other).String())
+
+	weighted, err := v.SetWeight("A", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1A 'b':2A,4A 'c'`, weighted.String())
+	weighted, err = v.SetWeight("c", []string{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1 'b':2C,4C 'c'`, weighted.String())
+	_, err = v.SetWeight("E", nil)
+	require.EqualError(t, err, `unrecognized weight: "E"`)
+
+	filtered, err := weighted.Filter([]string{"c"})
+	require.NoError(t, err)
+	assert.Equal(t, `'b':2C,4C`, filtered.String())
+	filtered, err = weighted.Filter([]string{"a", "d"})
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1`, filtered.String())
+
+	arr, err := ArrayToTSVector([]string{"b", "a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, `'a' 'b'`, arr.String())
+	_, err = ArrayToTSVector([]string{""})
+	require.EqualError(t, err, "lexeme array may not contain empty strings")
+
+	assert.Equal(t, 0, v.Compare(v))
+	assert.Equal(t, -1, v.Compare(other))
+	assert.Equal(t, 1, v.Compare(v.Strip()))
+}